@@ -4,14 +4,42 @@ grammar TSL;
 // Rules
 start : expr EOF;
 
+// Queries are phrases with an optional projection prefix, and optional order
+// by, limit and offset clauses.
+query
+  : ( fields ( K_WHERE expr )? | expr )? orderBy? limit? offset? EOF
+  ;
+
+fields
+  : K_FIELDS columnName ( ',' columnName )*
+  ;
+
+orderBy
+  : K_ORDER K_BY sortKey ( ',' sortKey )*
+  ;
+
+sortKey
+  : columnName ( K_ASC | K_DESC )?
+  ;
+
+limit
+  : K_LIMIT NUMERIC_LITERAL
+  ;
+
+offset
+  : K_OFFSET NUMERIC_LITERAL
+  ;
+
 expr
   : mathExp literalOp literalValue                                           # LiteralOps
   | mathExp stringOp literalValue                                            # StringOps
-  | mathExp keyNot? K_LIKE literalValue                                      # Like
+  | mathExp keyNot? likeOp literalValue                                      # Like
   | mathExp K_IS keyNot? K_NULL                                              # IsNull
   | mathExp K_IS keyNot? literalValue                                        # IsLiteral
   | mathExp keyNot? K_BETWEEN literalValue K_AND literalValue                # Between
   | mathExp keyNot? K_IN ( '(' ( literalValue ( ',' literalValue )* )? ')' ) # In
+  | mathExp keyNot? K_WITHIN distance K_OF point                             # Within
+  | mathExp keyNot? K_WITHIN box                                             # WithinBox
   | K_NOT expr                                                               # Not
   | expr K_AND expr                                                          # And
   | expr K_OR expr                                                           # Or
@@ -27,25 +55,62 @@ stringOp
   : ( '~=' | '~!' )
   ;
 
+likeOp
+  : K_LIKE
+  | K_ILIKE
+  | K_CONTAINS
+  | K_STARTSWITH
+  | K_ENDSWITH
+  ;
+
 databaseName
-  : IDENTIFIER
+  : identifier
   ;
 
 tableName
-  : IDENTIFIER
+  : identifier
   ;
 
+// Column names with `*` wildcard parts, like spec.*.status, match any part.
 columnName
-  : ( ( databaseName '.' )? tableName '.' )? IDENTIFIER
+  : ( ( databaseName '.' )? tableName '.' )? identifier
+  | identifier ( '.' identifier )* ( '.' '*' ( '.' identifier )* )+
+  ;
+
+// Keywords that are not operators can be used as identifiers.
+identifier
+  : IDENTIFIER
+  | K_ILIKE
+  | K_CONTAINS
+  | K_STARTSWITH
+  | K_ENDSWITH
+  | K_TRUE
+  | K_FALSE
+  | K_NOW
+  | K_DATE
+  | K_WITHIN
+  | K_OF
+  | K_ORDER
+  | K_BY
+  | K_ASC
+  | K_DESC
+  | K_LIMIT
+  | K_OFFSET
+  | K_FIELDS
+  | K_WHERE
   ;
 
 literalValue
-  : signedNumber # NumberLiteral
-  | stringValue  # StringLiteral
+  : signedNumber  # NumberLiteral
+  | stringValue   # StringLiteral
+  | booleanValue  # BooleanLiteral
+  | dateValue     # DateLiteral
+  | durationValue # DurationLiteral
   ;
 
 mathExp
   : columnName                             # ColumnIdentifier
+  | IDENTIFIER '(' mathExp ')'             # FunctionCall
   | mathExp '*' ( literalValue | mathExp ) # MulOps
   | mathExp '/' ( literalValue | mathExp ) # DivOps
   | mathExp '%' ( literalValue | mathExp ) # ModOps
@@ -62,12 +127,50 @@ stringValue
   : STRING_LITERAL
   ;
 
+booleanValue
+  : K_TRUE
+  | K_FALSE
+  ;
+
+dateValue
+  : ( K_NOW '(' ')' | K_DATE '(' stringValue ')' | DATE_LITERAL ) dateOffset?
+  ;
+
+dateOffset
+  : ( '+' | '-' ) DURATION_LITERAL
+  ;
+
+durationValue
+  : ( '+' | '-' )? DURATION_LITERAL
+  ;
+
+// A distance in meters, or in the unit of the literal.
+distance
+  : NUMERIC_LITERAL
+  | DISTANCE_LITERAL
+  | DURATION_LITERAL
+  ;
+
+// A (lat, lon) point.
+point
+  : '(' literalValue ',' literalValue ')'
+  ;
+
+// A bounding box of the south west and the north east (lat, lon) corners.
+box
+  : '(' literalValue ',' literalValue ',' literalValue ',' literalValue ')'
+  ;
+
 keyNot
  : K_NOT
  ;
 
 // Words
 K_LIKE : L I K E;
+K_ILIKE : I L I K E;
+K_CONTAINS : C O N T A I N S;
+K_STARTSWITH : S T A R T S W I T H;
+K_ENDSWITH : E N D S W I T H;
 K_AND : A N D;
 K_OR : O R;
 K_BETWEEN : B E T W E E N;
@@ -75,6 +178,20 @@ K_IN : I N;
 K_IS : I S;
 K_NULL : N U L L;
 K_NOT : N O T;
+K_TRUE : T R U E;
+K_FALSE : F A L S E;
+K_NOW : N O W;
+K_DATE : D A T E;
+K_WITHIN : W I T H I N;
+K_OF : O F;
+K_ORDER : O R D E R;
+K_BY : B Y;
+K_ASC : A S C;
+K_DESC : D E S C;
+K_LIMIT : L I M I T;
+K_OFFSET : O F F S E T;
+K_FIELDS : F I E L D S;
+K_WHERE : W H E R E;
 
 IDENTIFIER
   : '"' (~'"' | '""')* '"'
@@ -88,6 +205,22 @@ NUMERIC_LITERAL
   | '.' DIGIT+ ( E [-+]? DIGIT+ )?
   ;
 
+// Dates, like 2020-01-01 or 2020-01-01T10:00:00Z.
+DATE_LITERAL
+  : DIGIT DIGIT DIGIT DIGIT '-' DIGIT DIGIT '-' DIGIT DIGIT
+    ( 'T' DIGIT DIGIT ':' DIGIT DIGIT ':' DIGIT DIGIT ( '.' DIGIT+ )? ( 'Z' | [+-] DIGIT DIGIT ':' DIGIT DIGIT ) )?
+  ;
+
+// Durations, like 5m, 2h30m or 7d.
+DURATION_LITERAL
+  : ( DIGIT+ ( '.' DIGIT+ )? ( 'ns' | 'us' | 'ms' | 's' | 'm' | 'h' | 'd' ) )+
+  ;
+
+// Distances, like 5km or 2mi, a bare 5m is a duration literal.
+DISTANCE_LITERAL
+  : DIGIT+ ( '.' DIGIT+ )? ( M | K M | M I )
+  ;
+
 STRING_LITERAL
   : '\'' ( ~'\'' | '\'\'' )* '\''
   ;
@@ -0,0 +1,93 @@
+// Copyright 2018 Yaacov Zamir <kobi.zamir@gmail.com>
+// and other contributors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"sort"
+	"strings"
+)
+
+// schemaFields reads field names from a schema file.
+//
+// The schema file is a JSON object mapping field names to their types:
+//   {"title": "string", "author": "string", "spec.pages": "number"}
+func schemaFields(filename string) (fields []string, err error) {
+	var schema map[string]interface{}
+
+	data, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return
+	}
+
+	err = json.Unmarshal(data, &schema)
+	if err != nil {
+		return
+	}
+
+	for k := range schema {
+		fields = append(fields, k)
+	}
+	sort.Strings(fields)
+
+	return
+}
+
+// dataFields reads field names from a sampled data file.
+//
+// The data file is a JSON array of documents, nested document fields are
+// joined using a dot (e.g. {"spec": {"pages": 5}} has the field "spec.pages").
+func dataFields(filename string) (fields []string, err error) {
+	var docs []map[string]interface{}
+
+	data, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return
+	}
+
+	err = json.Unmarshal(data, &docs)
+	if err != nil {
+		return
+	}
+
+	set := map[string]bool{}
+	for _, doc := range docs {
+		collectFields(set, "", doc)
+	}
+
+	for k := range set {
+		fields = append(fields, k)
+	}
+	sort.Strings(fields)
+
+	return
+}
+
+// collectFields adds the fields of a document to the fields set.
+func collectFields(set map[string]bool, prefix string, doc map[string]interface{}) {
+	for k, v := range doc {
+		name := strings.TrimPrefix(prefix+"."+k, ".")
+
+		// Walk into nested documents.
+		if nested, ok := v.(map[string]interface{}); ok {
+			collectFields(set, name, nested)
+			continue
+		}
+
+		set[name] = true
+	}
+}
@@ -0,0 +1,83 @@
+// Copyright 2018 Yaacov Zamir <kobi.zamir@gmail.com>
+// and other contributors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+// writeFile writes a test file into a temporary directory.
+func writeFile(t *testing.T, name string, data string) string {
+	dir, err := ioutil.TempDir("", "tsl_completion")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { os.RemoveAll(dir) })
+
+	filename := filepath.Join(dir, name)
+	if err := ioutil.WriteFile(filename, []byte(data), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	return filename
+}
+
+func TestSchemaFields(t *testing.T) {
+	filename := writeFile(t, "schema.json", `{"title": "string", "spec.pages": "number", "author": "string"}`)
+
+	fields, err := schemaFields(filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []string{"author", "spec.pages", "title"}
+	if !reflect.DeepEqual(fields, want) {
+		t.Errorf("expected %v instead it was %v", want, fields)
+	}
+
+	// Test bad schema files.
+	if _, err := schemaFields(writeFile(t, "bad.json", `["title"]`)); err == nil {
+		t.Error("expected an error reading a schema array")
+	}
+	if _, err := schemaFields(filepath.Join(os.TempDir(), "missing-tsl-schema.json")); err == nil {
+		t.Error("expected an error reading a missing file")
+	}
+}
+
+func TestDataFields(t *testing.T) {
+	filename := writeFile(t, "data.json", `[
+		{"title": "a", "spec": {"pages": 5, "meta": {"isbn": "x"}}},
+		{"title": "b", "author": "joe", "tags": ["go"], "spec": {"rating": 4}}
+	]`)
+
+	fields, err := dataFields(filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []string{"author", "spec.meta.isbn", "spec.pages", "spec.rating", "tags", "title"}
+	if !reflect.DeepEqual(fields, want) {
+		t.Errorf("expected %v instead it was %v", want, fields)
+	}
+
+	if _, err := dataFields(writeFile(t, "bad.json", `{"title": "a"}`)); err == nil {
+		t.Error("expected an error reading a data object")
+	}
+}
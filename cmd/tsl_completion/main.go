@@ -0,0 +1,84 @@
+// Copyright 2018 Yaacov Zamir <kobi.zamir@gmail.com>
+// and other contributors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package main.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+func check(err error) {
+	if err != nil {
+		log.Fatal(err)
+	}
+}
+
+func main() {
+	var fields []string
+	var script string
+	var err error
+
+	// Setup the input.
+	shellPtr := flag.String("s", "bash", "shell to generate completion for [bash/zsh/fish]")
+	commandsPtr := flag.String("c", "tsl_parser tsl_mem tsl_sqlite tsl_gorm tsl_mongo", "space separated list of commands to complete")
+	schemaPtr := flag.String("schema", "", "a JSON schema file, mapping field names to types (e.g. {\"title\": \"string\"})")
+	dataPtr := flag.String("data", "", "a JSON data file, an array of sample documents")
+	listPtr := flag.Bool("l", false, "list field names and exit (used by the completion scripts)")
+	flag.Parse()
+
+	// List field names, the completion scripts call us with this flag
+	// to complete field names dynamically.
+	if *listPtr {
+		if *schemaPtr != "" {
+			fields, err = schemaFields(*schemaPtr)
+			check(err)
+		}
+		if *dataPtr != "" {
+			dataFields, err := dataFields(*dataPtr)
+			check(err)
+			fields = append(fields, dataFields...)
+		}
+
+		for _, f := range fields {
+			fmt.Println(f)
+		}
+		return
+	}
+
+	// Build the fields listing command used by the completion script.
+	self, err := os.Executable()
+	check(err)
+	list := fmt.Sprintf("%s -l", self)
+	if *schemaPtr != "" {
+		schema, err := filepath.Abs(*schemaPtr)
+		check(err)
+		list = fmt.Sprintf("%s -schema '%s'", list, schema)
+	}
+	if *dataPtr != "" {
+		data, err := filepath.Abs(*dataPtr)
+		check(err)
+		list = fmt.Sprintf("%s -data '%s'", list, data)
+	}
+
+	script, err = completionScript(*shellPtr, list, strings.Fields(*commandsPtr))
+	check(err)
+	fmt.Print(script)
+}
@@ -0,0 +1,110 @@
+// Copyright 2018 Yaacov Zamir <kobi.zamir@gmail.com>
+// and other contributors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+// TSL keywords, completed together with the field names.
+const keywords = "and or not is null like ilike contains startswith endswith between in true false"
+
+// Output formats of the TSL CLI tools.
+const formats = "json yaml prettyjson sql dot"
+
+// bashScript is the bash completion script template, arguments are:
+// the function name, the fields listing command and the completed commands.
+const bashScript = `# bash completion for the TSL CLI tools.
+%[1]s() {
+    local cur prev words IFS=$'\n'
+    cur="${COMP_WORDS[COMP_CWORD]}"
+    prev="${COMP_WORDS[COMP_CWORD-1]}"
+
+    case "$prev" in
+        -i)
+            # Complete the last word of the TSL phrase.
+            local head="" last="${cur##*[ (\"\']}"
+            head="${cur:0:${#cur}-${#last}}"
+            words="$(%[2]s 2>/dev/null)"$'\n'"$(printf '%%s\n' %[3]s)"
+            COMPREPLY=($(compgen -P "$head" -W "$words" -- "$last"))
+            ;;
+        -o)
+            COMPREPLY=($(compgen -W "$(printf '%%s\n' %[4]s)" -- "$cur"))
+            ;;
+        *)
+            COMPREPLY=($(compgen -W "$(printf '%%s\n' -i -o)" -- "$cur"))
+            ;;
+    esac
+}
+complete -o nospace -F %[1]s %[5]s
+`
+
+// zshScript is the zsh completion script template, arguments are:
+// the function name, the fields listing command and the completed commands.
+const zshScript = `#compdef %[5]s
+# zsh completion for the TSL CLI tools.
+%[1]s_phrase() {
+    local -a words
+    words=(${(f)"$(%[2]s 2>/dev/null)"} %[3]s)
+    compset -P '*[ (]'
+    compadd -S '' -a words
+}
+
+%[1]s() {
+    _arguments \
+        '-i[the tsl string to parse]:phrase:%[1]s_phrase' \
+        '-o[output format]:format:(%[4]s)'
+}
+
+compdef %[1]s %[5]s
+`
+
+// fishScript is the fish completion script template, arguments are:
+// the function name, the fields listing command and the completed commands.
+const fishScript = `# fish completion for the TSL CLI tools.
+function %[1]s_phrase
+    set -l token (commandline -ct)
+    set -l head (string replace -r '[^ (]*$' '' -- $token)
+    for word in (%[2]s 2>/dev/null) %[3]s
+        echo $head$word
+    end
+end
+
+for cmd in %[5]s
+    complete -c $cmd -o i -x -a '(%[1]s_phrase)' -d 'the tsl string to parse'
+    complete -c $cmd -o o -x -a '%[4]s' -d 'output format'
+end
+`
+
+// completionScript returns the completion script of a shell, list is the
+// fields listing command.
+func completionScript(shell string, list string, commands []string) (string, error) {
+	var script string
+
+	switch shell {
+	case "bash":
+		script = bashScript
+	case "zsh":
+		script = zshScript
+	case "fish":
+		script = fishScript
+	default:
+		return "", fmt.Errorf("unsupported shell: %s", shell)
+	}
+
+	return fmt.Sprintf(script, "_tsl_complete", list, keywords, formats, strings.Join(commands, " ")), nil
+}
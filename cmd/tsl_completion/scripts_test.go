@@ -0,0 +1,47 @@
+// Copyright 2018 Yaacov Zamir <kobi.zamir@gmail.com>
+// and other contributors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestCompletionScript(t *testing.T) {
+	list := "/usr/bin/tsl_completion -l -schema '/tmp/schema.json'"
+	commands := []string{"tsl_parser", "tsl_mem"}
+
+	for _, shell := range []string{"bash", "zsh", "fish"} {
+		script, err := completionScript(shell, list, commands)
+		if err != nil {
+			t.Fatalf("%s: %v", shell, err)
+		}
+
+		// Test the templates are fully formatted.
+		for _, want := range []string{"_tsl_complete", list, keywords, "tsl_parser tsl_mem", "# " + shell + " completion"} {
+			if !strings.Contains(script, want) {
+				t.Errorf("%s: expected the script to contain %q", shell, want)
+			}
+		}
+		if strings.Contains(script, "%!") || strings.Contains(script, "%[") {
+			t.Errorf("%s: unexpected formatting verbs in the script:\n%s", shell, script)
+		}
+	}
+
+	if _, err := completionScript("tcsh", list, commands); err == nil {
+		t.Error("expected an unsupported shell error")
+	}
+}
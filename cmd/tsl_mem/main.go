@@ -24,6 +24,7 @@ import (
 	"flag"
 	"fmt"
 	"log"
+	"os"
 
 	prettyjson "github.com/hokaccha/go-prettyjson"
 	"github.com/yaacov/tree-search-language/pkg/tsl"
@@ -58,13 +59,23 @@ func checkColumnName(s string) (string, error) {
 	return s, fmt.Errorf("column \"%s\" not found", s)
 }
 
+// Result is a matching book, and the predicates that made it match.
+type Result struct {
+	Book    Book              `json:"book"`
+	Matches []semantics.Match `json:"matches"`
+}
+
 func main() {
 	var s []byte
+	var output interface{}
 	books := []Book{}
+	results := []Result{}
 
 	// Setup the input.
 	inputPtr := flag.String("i", "", "the tsl string to parse (e.g. \"author = 'Joe'\")")
 	outputPtr := flag.String("o", "json", "output format [json/yaml/prettyjson]")
+	matchesPtr := flag.Bool("m", false, "report the matching predicates for each book")
+	profilePtr := flag.Bool("profile", false, "print predicates evaluation count and time to stderr")
 	flag.Parse()
 
 	// Sanity check.
@@ -84,27 +95,47 @@ func main() {
 	err = prepareCollection()
 	check(err)
 
-	// Filter the books collection using our stl tree.
+	// Prepare the evaluation profile.
+	profile := NewProfile()
+	var trace semantics.TraceFunc
+	if *profilePtr {
+		trace = profile.Trace
+	}
+
+	// Filter the books collection using our stl tree, and profile the
+	// evaluation if requested.
 	for _, book := range Books {
-		matchingFilter, err := semantics.Walk(tree, evalFactory(book))
+		matchingFilter, matches, err := semantics.WalkMatchesTrace(tree, evalFactory(book), trace)
 		check(err)
+
 		if matchingFilter {
 			books = append(books, book)
+			results = append(results, Result{Book: book, Matches: matches})
 		}
 	}
 
 	// Printout the filtered list.
+	output = books
+	if *matchesPtr {
+		output = results
+	}
+
 	switch *outputPtr {
 	case "json":
-		s, err = json.Marshal(books)
+		s, err = json.Marshal(output)
 	case "yaml":
-		s, err = yaml.Marshal(books)
+		s, err = yaml.Marshal(output)
 	case "prettyjson":
-		s, err = prettyjson.Marshal(books)
+		s, err = prettyjson.Marshal(output)
 	default:
 		err = fmt.Errorf("unsuported output format: %s", *outputPtr)
 	}
 
 	check(err)
 	fmt.Printf("%s\n", s)
+
+	// Printout the evaluation profile.
+	if *profilePtr {
+		profile.Print(os.Stderr)
+	}
 }
@@ -0,0 +1,117 @@
+// Copyright 2018 Yaacov Zamir <kobi.zamir@gmail.com>
+// and other contributors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/yaacov/tree-search-language/pkg/tsl"
+)
+
+// nodeProfile holds the evaluation count and cumulative time of one tree node.
+type nodeProfile struct {
+	phrase string
+	count  int
+	total  time.Duration
+}
+
+// Profile collects evaluation statistics of the tree nodes.
+type Profile struct {
+	nodes map[string]*nodeProfile
+	total time.Duration // sum of all predicates evaluation time
+}
+
+// NewProfile creates a new empty profile.
+func NewProfile() *Profile {
+	return &Profile{nodes: map[string]*nodeProfile{}}
+}
+
+// Trace is a semantics.TraceFunc collecting the evaluation of predicate nodes.
+func (p *Profile) Trace(n tsl.Node, b bool, err error, d time.Duration) {
+	// Logical nodes time include the time of their children, skip them.
+	if n.Func == tsl.AndOp || n.Func == tsl.OrOp {
+		return
+	}
+
+	s := phrase(n)
+	if _, ok := p.nodes[s]; !ok {
+		p.nodes[s] = &nodeProfile{phrase: s}
+	}
+
+	p.nodes[s].count++
+	p.nodes[s].total += d
+	p.total += d
+}
+
+// Print writes the predicates, most expensive first.
+func (p *Profile) Print(w io.Writer) {
+	nodes := []*nodeProfile{}
+	for _, n := range p.nodes {
+		nodes = append(nodes, n)
+	}
+
+	sort.Slice(nodes, func(i, j int) bool {
+		return nodes[i].total > nodes[j].total
+	})
+
+	tw := tabwriter.NewWriter(w, 0, 8, 2, ' ', 0)
+	fmt.Fprintf(tw, "COUNT\tTIME\tPERCENT\tPREDICATE\n")
+	for _, n := range nodes {
+		percent := 0.0
+		if p.total > 0 {
+			percent = 100 * float64(n.total) / float64(p.total)
+		}
+
+		fmt.Fprintf(tw, "%d\t%v\t%.1f%%\t%s\n", n.count, n.total, percent, n.phrase)
+	}
+	tw.Flush()
+}
+
+// phrase returns a human readable description of a tree node.
+func phrase(n tsl.Node) string {
+	switch n.Func {
+	case tsl.IdentOp:
+		return fmt.Sprintf("%v", n.Left)
+	case tsl.StringOp, tsl.DateOp:
+		return fmt.Sprintf("'%v'", n.Left)
+	case tsl.NumberOp, tsl.DurationOp:
+		return fmt.Sprintf("%g", n.Left)
+	case tsl.BooleanOp:
+		return fmt.Sprintf("%t", n.Left)
+	case tsl.ArrayOp:
+		values := []string{}
+		for _, v := range n.Right.([]tsl.Node) {
+			values = append(values, phrase(v))
+		}
+		return fmt.Sprintf("(%s)", strings.Join(values, ", "))
+	}
+
+	// This is an operator node.
+	s := n.Func
+	if l, ok := n.Left.(tsl.Node); ok {
+		s = fmt.Sprintf("%s %s", phrase(l), s)
+	}
+	if r, ok := n.Right.(tsl.Node); ok {
+		s = fmt.Sprintf("%s %s", s, phrase(r))
+	}
+
+	return s
+}
@@ -0,0 +1,86 @@
+// Copyright 2018 Yaacov Zamir <kobi.zamir@gmail.com>
+// and other contributors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/yaacov/tree-search-language/pkg/tsl"
+	"github.com/yaacov/tree-search-language/pkg/walkers/semantics"
+)
+
+func TestProfile(t *testing.T) {
+	tree, err := tsl.ParseTSL("author = 'Joe' or spec.pages in (1, 2)")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	p := NewProfile()
+	books := []map[string]interface{}{
+		{"author": "Joe", "spec.pages": 1},
+		{"author": "Jane", "spec.pages": 2},
+		{"author": "Jim", "spec.pages": 3},
+	}
+	for _, book := range books {
+		eval := func(k string) (interface{}, bool) {
+			v, ok := book[k]
+			return v, ok
+		}
+		if _, _, err := semantics.WalkMatchesTrace(tree, eval, p.Trace); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// Logical nodes are not profiled, and all predicates are evaluated to
+	// collect the matches.
+	counts := map[string]int{"author $eq 'Joe'": 3, "spec.pages $in (1, 2)": 3}
+	if len(p.nodes) != len(counts) {
+		t.Errorf("expected %d predicates instead it was %d", len(counts), len(p.nodes))
+	}
+	for s, count := range counts {
+		if n, ok := p.nodes[s]; !ok || n.count != count {
+			t.Errorf("%s: expected %d evaluations instead it was %v", s, count, n)
+		}
+	}
+}
+
+func TestProfilePrint(t *testing.T) {
+	p := NewProfile()
+	cheap := tsl.Node{Func: tsl.EqOp, Left: tsl.Node{Func: tsl.IdentOp, Left: "a"}, Right: tsl.Node{Func: tsl.NumberOp, Left: 1.0}}
+	costly := tsl.Node{Func: tsl.RegexOp, Left: tsl.Node{Func: tsl.IdentOp, Left: "b"}, Right: tsl.Node{Func: tsl.StringOp, Left: "x"}}
+
+	p.Trace(cheap, true, nil, time.Millisecond)
+	p.Trace(costly, false, nil, 2*time.Millisecond)
+	p.Trace(costly, true, nil, time.Millisecond)
+	p.Trace(tsl.Node{Func: tsl.AndOp, Left: cheap, Right: costly}, false, nil, time.Second)
+
+	var b bytes.Buffer
+	p.Print(&b)
+
+	lines := strings.Split(strings.TrimSpace(b.String()), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("expected a header and two predicates instead it was %q", b.String())
+	}
+	if fields := strings.Fields(lines[1]); len(fields) < 4 || fields[0] != "2" || fields[2] != "75.0%" || fields[3] != "b" {
+		t.Errorf("expected the regex predicate first instead it was %q", lines[1])
+	}
+	if fields := strings.Fields(lines[2]); len(fields) < 4 || fields[0] != "1" || fields[2] != "25.0%" || fields[3] != "a" {
+		t.Errorf("expected the eq predicate last instead it was %q", lines[2])
+	}
+}
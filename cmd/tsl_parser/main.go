@@ -28,6 +28,7 @@ import (
 
 	"github.com/yaacov/tree-search-language/pkg/tsl"
 	"github.com/yaacov/tree-search-language/pkg/walkers/graphviz"
+	"github.com/yaacov/tree-search-language/pkg/walkers/mermaid"
 	walker "github.com/yaacov/tree-search-language/pkg/walkers/sql"
 )
 
@@ -43,7 +44,7 @@ func main() {
 
 	// Setup the input.
 	inputPtr := flag.String("i", "", "the tsl string to parse (e.g. \"title = 'kitty'\")")
-	outputPtr := flag.String("o", "json", "output format [json/yaml/prettyjson/sql]")
+	outputPtr := flag.String("o", "json", "output format [json/yaml/prettyjson/dot/mermaid/sql]")
 	flag.Parse()
 
 	// Sanity check.
@@ -65,6 +66,9 @@ func main() {
 	case "dot":
 		st, err = graphviz.Walk("", tree, "root")
 		s = append(s, fmt.Sprintf("digraph {\n%s\n}\n", st)...)
+	case "mermaid":
+		st, err = mermaid.Walk(tree)
+		s = append(s, st...)
 	case "sql":
 		var sql string
 		var args []interface{}
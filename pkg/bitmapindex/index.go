@@ -0,0 +1,223 @@
+// Copyright 2018 Yaacov Zamir <kobi.zamir@gmail.com>
+// and other contributors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package bitmapindex searches in-memory document collections using roaring
+// bitmap indexes.
+//
+// Categorical fields of the documents are indexed, each field value maps to
+// the bitmap of the documents holding it. Searches intersect and join the
+// bitmaps of `=` and `in` predicates on indexed fields, and evaluate the rest
+// of the tree only on the candidate documents.
+//
+// Usage:
+//   ix := bitmapindex.New("author", "spec.status")
+//   for _, doc := range docs {
+//     ix.Add(doc)
+//   }
+//
+//   // The ids of the documents matching a tree.
+//   ids, err := ix.Search(tree)
+//
+package bitmapindex
+
+import (
+	"time"
+
+	"github.com/RoaringBitmap/roaring"
+
+	"github.com/yaacov/tree-search-language/pkg/tsl"
+	"github.com/yaacov/tree-search-language/pkg/walkers/semantics"
+)
+
+// Doc is an in-memory document.
+type Doc = map[string]interface{}
+
+// Index is a collection of documents with bitmap indexes on some fields.
+type Index struct {
+	docs   []Doc
+	fields map[string]map[interface{}]*roaring.Bitmap // field to value key to documents.
+}
+
+// New creates an empty collection indexing fields.
+func New(fields ...string) *Index {
+	ix := &Index{fields: map[string]map[interface{}]*roaring.Bitmap{}}
+	for _, field := range fields {
+		ix.fields[field] = map[interface{}]*roaring.Bitmap{}
+	}
+
+	return ix
+}
+
+// Add adds a document and returns its id.
+func (ix *Index) Add(doc Doc) uint32 {
+	id := uint32(len(ix.docs))
+	ix.docs = append(ix.docs, doc)
+
+	for field, values := range ix.fields {
+		for _, v := range elements(doc[field]) {
+			key, ok := valueKey(v)
+			if !ok {
+				continue
+			}
+
+			if values[key] == nil {
+				values[key] = roaring.New()
+			}
+			values[key].Add(id)
+		}
+	}
+
+	return id
+}
+
+// Len returns the number of documents.
+func (ix *Index) Len() int {
+	return len(ix.docs)
+}
+
+// Doc returns a document by id.
+func (ix *Index) Doc(id uint32) Doc {
+	return ix.docs[id]
+}
+
+// Search returns the sorted ids of the documents matching a tree.
+//
+// Predicates answered by the indexes do not fail on documents with values of
+// the wrong type, they do not match them.
+func (ix *Index) Search(tree tsl.Node) (ids []uint32, err error) {
+	p := ix.plan(tree)
+
+	candidates := p.bitmap
+	if candidates == nil {
+		candidates = roaring.New()
+		candidates.AddRange(0, uint64(len(ix.docs)))
+	}
+	if p.residual == nil {
+		return candidates.ToArray(), nil
+	}
+
+	// Evaluate the residual tree on the candidate documents.
+	ids = []uint32{}
+	it := candidates.Iterator()
+	for it.HasNext() {
+		id := it.Next()
+		doc := ix.docs[id]
+
+		match, err := semantics.Walk(*p.residual, func(k string) (interface{}, bool) {
+			v, ok := doc[k]
+			return v, ok
+		})
+		if err != nil {
+			return nil, err
+		}
+		if match {
+			ids = append(ids, id)
+		}
+	}
+
+	return ids, nil
+}
+
+// elements returns the elements of a list value, list fields are indexed by
+// each of their elements, like semantics.Walk matches any of them.
+func elements(v interface{}) []interface{} {
+	switch v := v.(type) {
+	case []interface{}:
+		return v
+	case []string:
+		list := make([]interface{}, len(v))
+		for i, s := range v {
+			list[i] = s
+		}
+		return list
+	case []float64:
+		list := make([]interface{}, len(v))
+		for i, f := range v {
+			list[i] = f
+		}
+		return list
+	}
+
+	return []interface{}{v}
+}
+
+// maxExactFloat is the largest integer float64 numbers hold exactly.
+const maxExactFloat = 1 << 53
+
+// integerKey is the index key of an integer float64 can not hold exactly.
+type integerKey struct {
+	neg bool   // the value is negative.
+	abs uint64 // the absolute value.
+}
+
+// timeKey is the index key of a time, times are indexed by their instant.
+type timeKey struct {
+	sec  int64
+	nsec int
+}
+
+// valueKey returns the index key of a value, like semantics.Walk compares
+// them, numbers and durations are indexed as float64 seconds, integers float64
+// can not hold exactly are indexed by their exact value, and times by their
+// instant.
+func valueKey(v interface{}) (interface{}, bool) {
+	switch v := v.(type) {
+	case string, bool:
+		return v, true
+	case float64:
+		return v, true
+	case float32:
+		return float64(v), true
+	case int:
+		return intKey(int64(v)), true
+	case int32:
+		return float64(v), true
+	case int64:
+		return intKey(v), true
+	case uint:
+		return uintKey(uint64(v)), true
+	case uint32:
+		return float64(v), true
+	case uint64:
+		return uintKey(v), true
+	case time.Duration:
+		return v.Seconds(), true
+	case time.Time:
+		return timeKey{sec: v.Unix(), nsec: v.Nanosecond()}, true
+	}
+
+	return nil, false
+}
+
+// intKey returns the index key of an int64 value.
+func intKey(v int64) interface{} {
+	if v < -maxExactFloat || v > maxExactFloat {
+		if v < 0 {
+			return integerKey{neg: true, abs: uint64(-(v + 1)) + 1}
+		}
+		return integerKey{abs: uint64(v)}
+	}
+
+	return float64(v)
+}
+
+// uintKey returns the index key of a uint64 value.
+func uintKey(v uint64) interface{} {
+	if v > maxExactFloat {
+		return integerKey{abs: v}
+	}
+
+	return float64(v)
+}
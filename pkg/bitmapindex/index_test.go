@@ -0,0 +1,151 @@
+// Copyright 2018 Yaacov Zamir <kobi.zamir@gmail.com>
+// and other contributors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bitmapindex
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/yaacov/tree-search-language/pkg/tsl"
+	"github.com/yaacov/tree-search-language/pkg/walkers/semantics"
+)
+
+func newIndex(n int) *Index {
+	ix := New("author", "status", "rating", "tags")
+	for i := 0; i < n; i++ {
+		ix.Add(Doc{
+			"title":  fmt.Sprintf("Book %d", i),
+			"author": []string{"Joe", "Jane", "Bob"}[i%3],
+			"status": []string{"draft", "published"}[i%2],
+			"rating": float64(i % 5),
+			"pages":  i * 10,
+			"tags":   [][]string{{"new"}, {"sale", "classic"}, {}}[i%3],
+		})
+	}
+
+	return ix
+}
+
+func TestSearch(t *testing.T) {
+	ix := newIndex(100)
+
+	phrases := []string{
+		"author = 'Joe'",
+		"author in ('Joe', 'Bob') and status = 'draft'",
+		"author = 'Jane' and pages > 500",
+		"author = 'Jim' or status = 'published'",
+		"(author = 'Joe' and pages < 300) or rating = 4",
+		"rating in (1, 2) and (status = 'draft' or pages > 900)",
+		"pages between 100 and 200",
+		"author = 'Nobody'",
+		"tags = 'sale'",
+		"tags in ('new', 'classic') and status = 'draft'",
+	}
+
+	for _, phrase := range phrases {
+		tree, err := tsl.ParseTSL(phrase)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		got, err := ix.Search(tree)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if want := walkAll(ix, tree); !reflect.DeepEqual(got, want) {
+			t.Errorf("Search(%q) = %v, want %v", phrase, got, want)
+		}
+	}
+}
+
+func TestSearchValues(t *testing.T) {
+	ix := New("id", "d", "up")
+	ix.Add(Doc{"id": int64(9007199254740993), "d": time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC), "up": 5 * time.Minute})
+	ix.Add(Doc{"id": int64(9007199254740992), "d": time.Date(2020, 1, 1, 2, 0, 0, 0, time.FixedZone("", 7200)), "up": 300})
+	ix.Add(Doc{"id": uint64(18446744073709551615), "d": "2020-01-01", "up": float64(301)})
+	ix.Add(Doc{"id": float64(9007199254740994), "d": time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC), "up": time.Second})
+
+	// Large IN lists use a number set.
+	list := []string{"9007199254740993"}
+	for i := 1; i < 16; i++ {
+		list = append(list, fmt.Sprintf("%d", i))
+	}
+
+	phrases := []string{
+		"id = 9007199254740993",
+		"id = 9007199254740992",
+		"id = 9007199254740994",
+		"id = 18446744073709551615",
+		"id = 18446744073709551616",
+		"id in (9007199254740992)",
+		"id in (9007199254740993, 18446744073709551615)",
+		"id in (" + strings.Join(list, ", ") + ")",
+		"d = 2020-01-01",
+		"d in (2020-01-01, 2021-01-01)",
+		"d = '2020-01-01'",
+		"up = 5m",
+		"up = 300",
+		"up in (1s, 301)",
+		"id = 9007199254740993 or up = 301",
+	}
+
+	for _, phrase := range phrases {
+		tree, err := tsl.ParseTSL(phrase)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		got, err := ix.Search(tree)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if want := walkAll(ix, tree); !reflect.DeepEqual(got, want) {
+			t.Errorf("Search(%q) = %v, want %v", phrase, got, want)
+		}
+	}
+}
+
+// walkAll returns the ids of the documents matching a tree, evaluating all
+// the documents.
+func walkAll(ix *Index, tree tsl.Node) []uint32 {
+	ids := []uint32{}
+	for id, doc := range ix.docs {
+		ok, _ := semantics.Walk(tree, func(k string) (interface{}, bool) {
+			v, ok := doc[k]
+			return v, ok
+		})
+		if ok {
+			ids = append(ids, uint32(id))
+		}
+	}
+
+	return ids
+}
+
+func BenchmarkSearch(b *testing.B) {
+	ix := newIndex(100000)
+	tree, _ := tsl.ParseTSL("author = 'Joe' and status = 'draft' and pages > 1000")
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		ix.Search(tree)
+	}
+}
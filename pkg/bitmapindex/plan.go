@@ -0,0 +1,157 @@
+// Copyright 2018 Yaacov Zamir <kobi.zamir@gmail.com>
+// and other contributors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bitmapindex
+
+import (
+	"math"
+
+	"github.com/RoaringBitmap/roaring"
+
+	"github.com/yaacov/tree-search-language/pkg/tsl"
+)
+
+// plan is a search plan of a tree, the documents matching the tree are the
+// documents of the bitmap matching the residual tree.
+type plan struct {
+	bitmap   *roaring.Bitmap // the candidate documents, nil for all documents.
+	residual *tsl.Node       // the tree left to evaluate, nil if none.
+}
+
+// plan returns the search plan of a tree.
+func (ix *Index) plan(n tsl.Node) plan {
+	switch n.Func {
+	case tsl.EqOp, tsl.InOp:
+		if b, ok := ix.lookup(n); ok {
+			return plan{bitmap: b}
+		}
+	case tsl.AndOp:
+		l := ix.plan(n.Left.(tsl.Node))
+		r := ix.plan(n.Right.(tsl.Node))
+
+		// Intersect the candidates, and evaluate both residuals.
+		p := plan{bitmap: l.bitmap, residual: l.residual}
+		switch {
+		case p.bitmap == nil:
+			p.bitmap = r.bitmap
+		case r.bitmap != nil:
+			p.bitmap = roaring.And(l.bitmap, r.bitmap)
+		}
+		switch {
+		case p.residual == nil:
+			p.residual = r.residual
+		case r.residual != nil:
+			p.residual = &tsl.Node{Func: tsl.AndOp, Left: *l.residual, Right: *r.residual}
+		}
+		return p
+	case tsl.OrOp:
+		l := ix.plan(n.Left.(tsl.Node))
+		r := ix.plan(n.Right.(tsl.Node))
+
+		// Join the candidates if both sides have candidates, the OR node is
+		// evaluated again unless both sides are exact.
+		if l.bitmap != nil && r.bitmap != nil {
+			p := plan{bitmap: roaring.Or(l.bitmap, r.bitmap)}
+			if l.residual != nil || r.residual != nil {
+				p.residual = &n
+			}
+			return p
+		}
+	}
+
+	return plan{residual: &n}
+}
+
+// lookup returns the documents matching `=` and `in` predicates on indexed
+// fields.
+func (ix *Index) lookup(n tsl.Node) (*roaring.Bitmap, bool) {
+	l, ok := n.Left.(tsl.Node)
+	if !ok || l.Func != tsl.IdentOp {
+		return nil, false
+	}
+	values, ok := ix.fields[l.Left.(string)]
+	if !ok {
+		return nil, false
+	}
+
+	r, ok := n.Right.(tsl.Node)
+	if !ok {
+		return nil, false
+	}
+	literals := []tsl.Node{r}
+	if r.Func == tsl.ArrayOp {
+		literals = r.Right.([]tsl.Node)
+	}
+
+	bitmaps := []*roaring.Bitmap{}
+	for _, literal := range literals {
+		keys, ok := literalKeys(literal, r.Func == tsl.ArrayOp)
+		if !ok {
+			return nil, false
+		}
+		for _, key := range keys {
+			if b, ok := values[key]; ok {
+				bitmaps = append(bitmaps, b)
+			}
+		}
+	}
+
+	return roaring.FastOr(bitmaps...), true
+}
+
+// literalKeys returns the index keys of the values equal to a literal, like
+// semantics.Walk compares them, or false if the literal is not indexed.
+//
+// Walk compares the numbers of `in` list literals to float64 values, and
+// does not compare times to `in` list literals.
+func literalKeys(literal tsl.Node, list bool) ([]interface{}, bool) {
+	switch literal.Func {
+	case tsl.StringOp, tsl.BooleanOp:
+		return []interface{}{literal.Left}, true
+	case tsl.DateOp:
+		// Date literals match equal strings and times at the same instant.
+		keys := []interface{}{literal.Left}
+		if t, err := tsl.Date(literal); err == nil && !list {
+			keys = append(keys, timeKey{sec: t.Unix(), nsec: t.Nanosecond()})
+		}
+		return keys, true
+	case tsl.NumberOp, tsl.DurationOp:
+		f := literal.Left.(float64)
+		v, ok := tsl.Integer(literal)
+		if !ok && math.Abs(f) > maxExactFloat {
+			// Literals out of the 64 bit integer range are compared to large
+			// integers as float64 values, they are not indexed.
+			return nil, false
+		}
+
+		// Integers float64 holds exactly are compared exactly to integer
+		// literals float64 can not hold, other numbers are compared as
+		// float64 values.
+		keys := []interface{}{}
+		if _, isFloat := tsl.LiteralValue(literal).(float64); isFloat || list || math.Abs(f) > maxExactFloat {
+			keys = append(keys, f)
+		}
+		switch v := v.(type) {
+		case int64:
+			keys = append(keys, intKey(v))
+		case uint64:
+			keys = append(keys, uintKey(v))
+		}
+		return keys, true
+	}
+
+	// Only fixed literals are indexed, now() literals are not.
+	return nil, false
+}
@@ -0,0 +1,34 @@
+// Copyright 2018 Yaacov Zamir <kobi.zamir@gmail.com>
+// and other contributors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package admission evaluates TSL rules against Kubernetes objects in
+// validating admission webhooks.
+//
+// Rules are TSL phrases evaluated against the admitted object, fields are
+// dot separated paths into the object (e.g. `spec.replicas` or
+// `metadata.namespace`). Objects matching a rule are denied.
+//
+// Usage:
+//   rules, err := admission.ParseRules(map[string]string{
+//       "too-many-replicas": "spec.replicas > 50 and metadata.namespace != 'prod'",
+//   })
+//
+//   // Check an object.
+//   denied, err := rules.Check(obj)
+//
+//   // Or serve a validating webhook.
+//   http.Handle("/validate", admission.Handler(rules))
+//
+package admission
@@ -0,0 +1,104 @@
+// Copyright 2018 Yaacov Zamir <kobi.zamir@gmail.com>
+// and other contributors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package admission
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// review is the admission.k8s.io/v1 AdmissionReview, with only the fields
+// used by the handler.
+type review struct {
+	APIVersion string    `json:"apiVersion"`
+	Kind       string    `json:"kind"`
+	Request    *request  `json:"request,omitempty"`
+	Response   *response `json:"response,omitempty"`
+}
+
+type request struct {
+	UID    string          `json:"uid"`
+	Object json.RawMessage `json:"object"`
+}
+
+type response struct {
+	UID     string  `json:"uid"`
+	Allowed bool    `json:"allowed"`
+	Result  *status `json:"status,omitempty"`
+}
+
+type status struct {
+	Code    int32  `json:"code"`
+	Message string `json:"message"`
+}
+
+// Handler returns a validating admission webhook handler, denying objects
+// matching any of the rules.
+func Handler(rules Rules) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var in review
+
+		if err := json.NewDecoder(r.Body).Decode(&in); err != nil || in.Request == nil {
+			http.Error(w, "bad admission review", http.StatusBadRequest)
+			return
+		}
+
+		out := review{
+			APIVersion: in.APIVersion,
+			Kind:       in.Kind,
+			Response:   admit(rules, in.Request),
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(out)
+	})
+}
+
+// admit checks the request object against the rules.
+func admit(rules Rules, req *request) *response {
+	resp := &response{UID: req.UID}
+
+	// Requests without an object (e.g. DELETE) are allowed.
+	if len(req.Object) == 0 || string(req.Object) == "null" {
+		resp.Allowed = true
+		return resp
+	}
+
+	obj := &unstructured.Unstructured{}
+	if err := json.Unmarshal(req.Object, &obj.Object); err != nil {
+		resp.Result = &status{Code: http.StatusBadRequest, Message: err.Error()}
+		return resp
+	}
+
+	denied, err := rules.Check(obj)
+	switch {
+	case err != nil:
+		resp.Result = &status{Code: http.StatusInternalServerError, Message: err.Error()}
+	case len(denied) > 0:
+		resp.Result = &status{
+			Code:    http.StatusForbidden,
+			Message: fmt.Sprintf("denied by rules: %s", strings.Join(denied, ", ")),
+		}
+	default:
+		resp.Allowed = true
+	}
+
+	return resp
+}
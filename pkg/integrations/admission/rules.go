@@ -0,0 +1,92 @@
+// Copyright 2018 Yaacov Zamir <kobi.zamir@gmail.com>
+// and other contributors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package admission
+
+import (
+	"sort"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/yaacov/tree-search-language/pkg/tsl"
+	"github.com/yaacov/tree-search-language/pkg/walkers/semantics"
+)
+
+// Rule denies objects matching a TSL tree.
+type Rule struct {
+	Name string
+	Tree tsl.Node
+}
+
+// Rules is a list of deny rules.
+type Rules []Rule
+
+// ParseRules parses rule phrases by rule name, rules are sorted by name.
+func ParseRules(phrases map[string]string) (Rules, error) {
+	rules := Rules{}
+	for name, phrase := range phrases {
+		tree, err := tsl.ParseTSL(phrase)
+		if err != nil {
+			return nil, err
+		}
+		rules = append(rules, Rule{Name: name, Tree: tree})
+	}
+
+	sort.Slice(rules, func(i, j int) bool { return rules[i].Name < rules[j].Name })
+	return rules, nil
+}
+
+// Check returns the names of the rules matching an object.
+func (r Rules) Check(obj *unstructured.Unstructured) (denied []string, err error) {
+	eval := EvalFactory(obj)
+
+	for _, rule := range r {
+		var match bool
+
+		if match, err = semantics.Walk(rule.Tree, eval); err != nil {
+			return
+		}
+		if match {
+			denied = append(denied, rule.Name)
+		}
+	}
+
+	return
+}
+
+// Match checks if an object matches a TSL tree.
+func Match(tree tsl.Node, obj *unstructured.Unstructured) (bool, error) {
+	return semantics.Walk(tree, EvalFactory(obj))
+}
+
+// EvalFactory creates an evaluation function for a Kubernetes object,
+// identifiers are dot separated field paths, lists and objects can not be
+// compared and evaluate as missing fields.
+func EvalFactory(obj *unstructured.Unstructured) semantics.EvalFunc {
+	return func(k string) (interface{}, bool) {
+		v, ok, err := unstructured.NestedFieldNoCopy(obj.Object, strings.Split(k, ".")...)
+		if !ok || err != nil {
+			return nil, false
+		}
+
+		switch v.(type) {
+		case map[string]interface{}, []interface{}:
+			return nil, false
+		}
+
+		return v, true
+	}
+}
@@ -0,0 +1,58 @@
+// Copyright 2018 Yaacov Zamir <kobi.zamir@gmail.com>
+// and other contributors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package admission
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestHandler(t *testing.T) {
+	rules, err := ParseRules(map[string]string{
+		"too-many-replicas": "spec.replicas > 50 and metadata.namespace != 'prod'",
+		"no-latest":         "spec.image ~= ':latest$'",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		object  string
+		allowed bool
+	}{
+		{object: `{"metadata": {"namespace": "dev"}, "spec": {"replicas": 100}}`, allowed: false},
+		{object: `{"metadata": {"namespace": "prod"}, "spec": {"replicas": 100}}`, allowed: true},
+		{object: `{"metadata": {"namespace": "dev"}, "spec": {"replicas": 3, "image": "nginx:latest"}}`, allowed: false},
+		{object: `{"metadata": {"namespace": "dev"}, "spec": {"containers": [{"name": "a"}]}}`, allowed: true},
+	}
+
+	for _, tt := range tests {
+		body := []byte(`{"apiVersion": "admission.k8s.io/v1", "kind": "AdmissionReview", "request": {"uid": "1", "object": ` + tt.object + `}}`)
+		w := httptest.NewRecorder()
+		Handler(rules).ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/validate", bytes.NewReader(body)))
+
+		var out review
+		if err := json.Unmarshal(w.Body.Bytes(), &out); err != nil {
+			t.Fatal(err)
+		}
+		if out.Response == nil || out.Response.UID != "1" || out.Response.Allowed != tt.allowed {
+			t.Errorf("object %s: response %s, want allowed %v", tt.object, w.Body.String(), tt.allowed)
+		}
+	}
+}
@@ -0,0 +1,45 @@
+// Copyright 2018 Yaacov Zamir <kobi.zamir@gmail.com>
+// and other contributors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package grpcfilter is a gRPC interceptor for TSL filters.
+//
+// The interceptors read the TSL phrase from the `x-tsl-filter` metadata key
+// of incoming calls, parse and validate it using the method schema, and
+// store the TSL tree in the call context. Bad filters are answered with an
+// InvalidArgument status.
+//
+// Usage:
+//   // Set the schemas of the methods that support filtering.
+//   schemas := grpcfilter.Schemas{
+//       "/library.Library/ListBooks": validate.Schema{
+//           "title":      {Type: validate.String},
+//           "spec.pages": {Type: validate.Number},
+//       },
+//   }
+//
+//   s := grpc.NewServer(
+//       grpc.UnaryInterceptor(grpcfilter.UnaryServerInterceptor(schemas)),
+//       grpc.StreamInterceptor(grpcfilter.StreamServerInterceptor(schemas)),
+//   )
+//
+//   // Get the tree inside the method.
+//   func (s *server) ListBooks(ctx context.Context, req *pb.ListBooksRequest) (*pb.ListBooksResponse, error) {
+//       tree, ok := grpcfilter.FromContext(ctx)
+//       ...
+//   }
+//
+// Clients set the filter using the call metadata:
+//   ctx = metadata.AppendToOutgoingContext(ctx, grpcfilter.MetadataKey, "spec.pages > 100")
+package grpcfilter
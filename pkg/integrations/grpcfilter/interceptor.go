@@ -0,0 +1,117 @@
+// Copyright 2018 Yaacov Zamir <kobi.zamir@gmail.com>
+// and other contributors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package grpcfilter
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/yaacov/tree-search-language/pkg/tsl"
+	"github.com/yaacov/tree-search-language/pkg/validate"
+)
+
+// MetadataKey is the metadata key holding the TSL phrase.
+const MetadataKey = "x-tsl-filter"
+
+// Schemas maps full method names (e.g. "/library.Library/ListBooks") to the
+// method filter schema, methods missing from the map do not support filtering.
+type Schemas map[string]validate.Schema
+
+// contextKey is the type of the context key of the filter tree.
+type contextKey struct{}
+
+// NewContext returns a new context carrying a TSL tree.
+func NewContext(ctx context.Context, tree tsl.Node) context.Context {
+	return context.WithValue(ctx, contextKey{}, tree)
+}
+
+// FromContext returns the TSL tree stored in a context, if any.
+func FromContext(ctx context.Context) (tsl.Node, bool) {
+	tree, ok := ctx.Value(contextKey{}).(tsl.Node)
+	return tree, ok
+}
+
+// UnaryServerInterceptor returns a unary interceptor that parses the call filter.
+func UnaryServerInterceptor(schemas Schemas) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		ctx, err := filterContext(ctx, schemas, info.FullMethod)
+		if err != nil {
+			return nil, err
+		}
+
+		return handler(ctx, req)
+	}
+}
+
+// StreamServerInterceptor returns a stream interceptor that parses the call filter.
+func StreamServerInterceptor(schemas Schemas) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, err := filterContext(ss.Context(), schemas, info.FullMethod)
+		if err != nil {
+			return err
+		}
+
+		return handler(srv, &serverStream{ServerStream: ss, ctx: ctx})
+	}
+}
+
+// serverStream is a grpc.ServerStream with a filter context.
+type serverStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+// Context returns the stream context.
+func (s *serverStream) Context() context.Context {
+	return s.ctx
+}
+
+// filterContext parses the filter of a call, and returns a context carrying the TSL tree.
+func filterContext(ctx context.Context, schemas Schemas, method string) (context.Context, error) {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ctx, nil
+	}
+
+	// If no filter was given, return the context as is.
+	phrases := md.Get(MetadataKey)
+	if len(phrases) == 0 || phrases[0] == "" {
+		return ctx, nil
+	}
+
+	// Check that the method supports filtering.
+	schema, ok := schemas[method]
+	if !ok {
+		return nil, status.Errorf(codes.InvalidArgument, "method %s does not support filtering", method)
+	}
+
+	// Parse the filter phrase into a TSL tree.
+	tree, err := tsl.ParseTSL(phrases[0])
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	// Validate the tree using the method schema.
+	if err = schema.Validate(tree); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	return NewContext(ctx, tree), nil
+}
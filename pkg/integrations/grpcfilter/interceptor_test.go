@@ -0,0 +1,134 @@
+// Copyright 2018 Yaacov Zamir <kobi.zamir@gmail.com>
+// and other contributors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package grpcfilter
+
+import (
+	"context"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/yaacov/tree-search-language/pkg/tsl"
+	"github.com/yaacov/tree-search-language/pkg/validate"
+)
+
+const method = "/books.Books/List"
+
+var schemas = Schemas{
+	method: validate.Schema{"title": {Type: validate.String}, "spec.pages": {Type: validate.Number}},
+}
+
+// incoming returns a context with incoming call metadata.
+func incoming(pairs ...string) context.Context {
+	return metadata.NewIncomingContext(context.Background(), metadata.Pairs(pairs...))
+}
+
+// fakeStream is a server stream with a context.
+type fakeStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s fakeStream) Context() context.Context {
+	return s.ctx
+}
+
+func TestUnaryServerInterceptor(t *testing.T) {
+	interceptor := UnaryServerInterceptor(schemas)
+	info := &grpc.UnaryServerInfo{FullMethod: method}
+
+	var tree tsl.Node
+	var ok bool
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		tree, ok = FromContext(ctx)
+		return req, nil
+	}
+
+	// A valid filter is parsed into the handler context.
+	resp, err := interceptor(incoming(MetadataKey, "title = 'a' and spec.pages > 10"), "req", info, handler)
+	if err != nil || resp != "req" {
+		t.Fatalf("unexpected response %v, %v", resp, err)
+	}
+	if !ok || tree.Func != tsl.AndOp {
+		t.Errorf("expected a filter tree instead it was %v", tree)
+	}
+
+	// Calls without a filter are passed as is.
+	for _, ctx := range []context.Context{context.Background(), incoming("other", "x"), incoming(MetadataKey, "")} {
+		ok = true
+		if _, err := interceptor(ctx, "req", info, handler); err != nil || ok {
+			t.Errorf("expected no filter, got %v, %v", ok, err)
+		}
+	}
+}
+
+func TestUnaryServerInterceptorErrors(t *testing.T) {
+	interceptor := UnaryServerInterceptor(schemas)
+
+	called := false
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		called = true
+		return req, nil
+	}
+
+	tests := []struct {
+		method string
+		filter string
+	}{
+		{method: "/books.Books/Get", filter: "title = 'a'"},
+		{method: method, filter: "title = "},
+		{method: method, filter: "author = 'joe'"},
+		{method: method, filter: "spec.pages = 'many'"},
+	}
+
+	for _, tt := range tests {
+		_, err := interceptor(incoming(MetadataKey, tt.filter), "req", &grpc.UnaryServerInfo{FullMethod: tt.method}, handler)
+		if status.Code(err) != codes.InvalidArgument {
+			t.Errorf("%s %s: expected an invalid argument error instead it was %v", tt.method, tt.filter, err)
+		}
+	}
+	if called {
+		t.Error("expected the handler not to be called")
+	}
+}
+
+func TestStreamServerInterceptor(t *testing.T) {
+	interceptor := StreamServerInterceptor(schemas)
+	info := &grpc.StreamServerInfo{FullMethod: method}
+
+	var tree tsl.Node
+	var ok bool
+	handler := func(srv interface{}, ss grpc.ServerStream) error {
+		tree, ok = FromContext(ss.Context())
+		return nil
+	}
+
+	ss := fakeStream{ctx: incoming(MetadataKey, "spec.pages between 1 and 5")}
+	if err := interceptor(nil, ss, info, handler); err != nil {
+		t.Fatal(err)
+	}
+	if !ok || tree.Func != tsl.BetweenOp {
+		t.Errorf("expected a filter tree instead it was %v", tree)
+	}
+
+	ss = fakeStream{ctx: incoming(MetadataKey, "title ~= ")}
+	if err := interceptor(nil, ss, info, handler); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("expected an invalid argument error instead it was %v", err)
+	}
+}
@@ -0,0 +1,39 @@
+// Copyright 2018 Yaacov Zamir <kobi.zamir@gmail.com>
+// and other contributors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package httpfilter is a net/http middleware for TSL filter query parameters.
+//
+// The middleware parses the `filter` query parameter of a request into a TSL
+// tree, validates the tree against the route schema, and stores it in the
+// request context. Bad filters are answered with RFC 7807 problem responses.
+//
+// Usage:
+//   // Set the route schema, mapping field names to value types.
+//   schema := validate.Schema{
+//       "title":      {Type: validate.String},
+//       "author":     {Type: validate.String},
+//       "spec.pages": {Type: validate.Number},
+//   }
+//
+//   http.Handle("/books", httpfilter.Middleware(schema)(booksHandler))
+//
+//   // Get the tree inside the handler.
+//   func booksHandler(w http.ResponseWriter, r *http.Request) {
+//       tree, ok := httpfilter.FromContext(r.Context())
+//       ...
+//   }
+//
+// RFC 7807: https://tools.ietf.org/html/rfc7807
+package httpfilter
@@ -0,0 +1,100 @@
+// Copyright 2018 Yaacov Zamir <kobi.zamir@gmail.com>
+// and other contributors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package httpfilter
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/yaacov/tree-search-language/pkg/tsl"
+)
+
+// FilterParam is the name of the query parameter holding the TSL phrase.
+const FilterParam = "filter"
+
+// contextKey is the type of the request context key of the filter tree.
+type contextKey struct{}
+
+// Problem types of bad filters.
+const (
+	ParseErrorType      = "https://github.com/yaacov/tree-search-language/problems/parse-error"
+	ValidationErrorType = "https://github.com/yaacov/tree-search-language/problems/validation-error"
+)
+
+// Validator validates TSL trees, e.g. a validate.Schema.
+type Validator interface {
+	Validate(n tsl.Node) error
+}
+
+// Middleware parses the filter query parameter of requests into a TSL tree.
+//
+// The tree is validated using the route schema or validator, and stored in the request
+// context, requests without a filter are passed as is to the next handler.
+// Bad filters are answered with a 400 RFC 7807 problem response.
+//
+//  http.Handle("/books", httpfilter.Middleware(schema)(booksHandler))
+//
+func Middleware(schema Validator) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			phrase := r.URL.Query().Get(FilterParam)
+
+			// If no filter was given, just call the next handler.
+			if phrase == "" {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			// Parse the filter phrase into a TSL tree.
+			tree, err := tsl.ParseTSL(phrase)
+			if err != nil {
+				Problem{
+					Type:     ParseErrorType,
+					Title:    "Bad filter syntax",
+					Status:   http.StatusBadRequest,
+					Detail:   err.Error(),
+					Instance: r.URL.RequestURI(),
+				}.Write(w)
+				return
+			}
+
+			// Validate the tree using the route schema.
+			if err = schema.Validate(tree); err != nil {
+				Problem{
+					Type:     ValidationErrorType,
+					Title:    "Bad filter",
+					Status:   http.StatusBadRequest,
+					Detail:   err.Error(),
+					Instance: r.URL.RequestURI(),
+				}.Write(w)
+				return
+			}
+
+			next.ServeHTTP(w, r.WithContext(NewContext(r.Context(), tree)))
+		})
+	}
+}
+
+// NewContext returns a new context carrying a TSL tree.
+func NewContext(ctx context.Context, tree tsl.Node) context.Context {
+	return context.WithValue(ctx, contextKey{}, tree)
+}
+
+// FromContext returns the TSL tree stored in a context, if any.
+func FromContext(ctx context.Context) (tsl.Node, bool) {
+	tree, ok := ctx.Value(contextKey{}).(tsl.Node)
+	return tree, ok
+}
@@ -0,0 +1,90 @@
+// Copyright 2018 Yaacov Zamir <kobi.zamir@gmail.com>
+// and other contributors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package httpfilter
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/yaacov/tree-search-language/pkg/tsl"
+	"github.com/yaacov/tree-search-language/pkg/validate"
+)
+
+var schema = validate.Schema{
+	"title":      {Type: validate.String},
+	"spec.pages": {Type: validate.Number},
+	"price":      {Type: validate.Any},
+}
+
+// serve runs one request with filter through the middleware.
+func serve(filter string) (rr *httptest.ResponseRecorder, tree tsl.Node, ok bool) {
+	handler := Middleware(schema)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		tree, ok = FromContext(r.Context())
+	}))
+
+	req := httptest.NewRequest("GET", "/books?filter="+url.QueryEscape(filter), nil)
+	rr = httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+
+	return
+}
+
+func TestMiddleware(t *testing.T) {
+	rr, tree, ok := serve("title = 'Book' and spec.pages in (1, 2) and price < 3")
+	if rr.Code != http.StatusOK || !ok {
+		t.Fatalf("expected a tree, got status %d", rr.Code)
+	}
+	if tree.Func != tsl.AndOp {
+		t.Errorf("unexpected tree %v", tree)
+	}
+
+	// No filter.
+	rr, _, ok = serve("")
+	if rr.Code != http.StatusOK || ok {
+		t.Errorf("expected no tree, got status %d", rr.Code)
+	}
+}
+
+func TestMiddlewareProblems(t *testing.T) {
+	tests := map[string]string{
+		"title = ":               ParseErrorType,
+		"name = 'Joe'":           ValidationErrorType,
+		"spec.pages = 'many'":    ValidationErrorType,
+		"title in ('a', 2)":      ValidationErrorType,
+		"title is null or z > 1": ValidationErrorType,
+		"spec.* = 1":             ValidationErrorType,
+	}
+
+	for filter, problemType := range tests {
+		var p Problem
+
+		rr, _, _ := serve(filter)
+		if rr.Code != http.StatusBadRequest {
+			t.Errorf("%s: expected status 400 instead it was %d", filter, rr.Code)
+		}
+		if rr.Header().Get("Content-Type") != ProblemContentType {
+			t.Errorf("%s: unexpected content type %s", filter, rr.Header().Get("Content-Type"))
+		}
+
+		json.Unmarshal(rr.Body.Bytes(), &p)
+		if p.Type != problemType || p.Status != http.StatusBadRequest {
+			t.Errorf("%s: unexpected problem %v", filter, p)
+		}
+	}
+}
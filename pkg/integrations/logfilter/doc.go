@@ -0,0 +1,33 @@
+// Copyright 2018 Yaacov Zamir <kobi.zamir@gmail.com>
+// and other contributors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package logfilter filters structured log entries using TSL.
+//
+// A Filter evaluates a TSL tree against the fields of a log entry, entries
+// that do not match are dropped, or sampled when a sample rate is set. The
+// filter phrase can be replaced at runtime, for example when a config file
+// changes. The zapfilter and logrusfilter packages plug filters into zap and
+// logrus loggers.
+//
+// Usage:
+//   f, err := logfilter.NewFilter("level != 'debug' or component = 'db'")
+//
+//   // Keep one of every 100 non matching entries.
+//   f.SampleRate = 100
+//
+//   // Change the filter at runtime.
+//   err = f.Set("level != 'debug'")
+//
+package logfilter
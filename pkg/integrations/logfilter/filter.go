@@ -0,0 +1,148 @@
+// Copyright 2018 Yaacov Zamir <kobi.zamir@gmail.com>
+// and other contributors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logfilter
+
+import (
+	"fmt"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/yaacov/tree-search-language/pkg/tsl"
+	"github.com/yaacov/tree-search-language/pkg/walkers/semantics"
+)
+
+// Field names of the log entry properties.
+const (
+	LevelField   = "level"
+	MessageField = "msg"
+	LoggerField  = "logger"
+)
+
+// Filter matches log entry fields against a TSL tree, it is safe for
+// concurrent use.
+type Filter struct {
+	// SampleRate keeps one of every SampleRate non matching entries, if zero
+	// all non matching entries are dropped.
+	SampleRate uint64
+
+	tree    atomic.Value // *tsl.Node, nil matches all entries.
+	dropped uint64
+}
+
+// NewFilter parses a TSL phrase and returns a filter, an empty phrase
+// matches all entries.
+func NewFilter(phrase string) (*Filter, error) {
+	f := &Filter{}
+	if err := f.Set(phrase); err != nil {
+		return nil, err
+	}
+
+	return f, nil
+}
+
+// Set parses a TSL phrase and replaces the filter tree, an empty phrase
+// matches all entries. On parse error the current tree is kept.
+func (f *Filter) Set(phrase string) error {
+	var tree *tsl.Node
+
+	if strings.TrimSpace(phrase) != "" {
+		n, err := tsl.ParseTSL(phrase)
+		if err != nil {
+			return err
+		}
+		tree = &n
+	}
+
+	f.tree.Store(tree)
+	return nil
+}
+
+// Match checks if the entry fields match the filter tree, entries that fail
+// to evaluate do not match.
+func (f *Filter) Match(fields map[string]interface{}) bool {
+	tree, _ := f.tree.Load().(*tsl.Node)
+	if tree == nil {
+		return true
+	}
+
+	ok, err := semantics.Walk(*tree, evalFactory(fields))
+	return ok && err == nil
+}
+
+// Keep checks if an entry should be logged, matching entries are kept, and
+// non matching entries are sampled using the sample rate.
+func (f *Filter) Keep(fields map[string]interface{}) bool {
+	if f.Match(fields) {
+		return true
+	}
+
+	// Check for sampling.
+	if f.SampleRate == 0 {
+		return false
+	}
+	return atomic.AddUint64(&f.dropped, 1)%f.SampleRate == 1%f.SampleRate
+}
+
+// evalFactory creates an evaluation function for log entry fields.
+//
+// Identifiers are looked up as field names, and if not found, as dot
+// separated paths into nested objects.
+func evalFactory(fields map[string]interface{}) semantics.EvalFunc {
+	return func(k string) (interface{}, bool) {
+		if v, ok := fields[k]; ok {
+			return normalize(v), true
+		}
+
+		var v interface{} = fields
+		for _, key := range strings.Split(k, ".") {
+			m, ok := v.(map[string]interface{})
+			if !ok {
+				return nil, false
+			}
+			if v, ok = m[key]; !ok {
+				return nil, false
+			}
+		}
+
+		return normalize(v), true
+	}
+}
+
+// normalize converts field values to types the semantics walker can
+// compare.
+func normalize(v interface{}) interface{} {
+	switch v := v.(type) {
+	case nil, string, bool, float32, float64, int, int32, int64, uint, uint32, uint64:
+		return v
+	case int8:
+		return int(v)
+	case int16:
+		return int(v)
+	case uint8:
+		return uint(v)
+	case uint16:
+		return uint(v)
+	case time.Time:
+		return v.Format(time.RFC3339Nano)
+	case time.Duration:
+		return v.Seconds()
+	case error:
+		return v.Error()
+	}
+
+	return fmt.Sprint(v)
+}
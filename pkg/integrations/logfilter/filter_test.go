@@ -0,0 +1,80 @@
+// Copyright 2018 Yaacov Zamir <kobi.zamir@gmail.com>
+// and other contributors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logfilter
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestFilter(t *testing.T) {
+	f, err := NewFilter("level != 'debug' or component = 'db'")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		fields map[string]interface{}
+		want   bool
+	}{
+		{fields: map[string]interface{}{"level": "info"}, want: true},
+		{fields: map[string]interface{}{"level": "debug"}, want: false},
+		{fields: map[string]interface{}{"level": "debug", "component": "db"}, want: true},
+		{fields: map[string]interface{}{"level": "debug", "component": errors.New("db")}, want: true},
+	}
+
+	for _, tt := range tests {
+		if got := f.Match(tt.fields); got != tt.want {
+			t.Errorf("Match(%v) = %v, want %v", tt.fields, got, tt.want)
+		}
+	}
+
+	// Replace the filter at runtime.
+	if err := f.Set("req.status >= 500"); err != nil {
+		t.Fatal(err)
+	}
+	nested := map[string]interface{}{"req": map[string]interface{}{"status": int16(503)}}
+	if !f.Match(nested) {
+		t.Errorf("Match(%v) = false, want true", nested)
+	}
+
+	// A bad phrase keeps the current filter.
+	if err := f.Set("req.status >="); err == nil {
+		t.Error("Set() error = nil, want parse error")
+	}
+	if !f.Match(nested) {
+		t.Errorf("Match(%v) = false after bad Set, want true", nested)
+	}
+}
+
+func TestKeepSample(t *testing.T) {
+	f, err := NewFilter("level = 'error'")
+	if err != nil {
+		t.Fatal(err)
+	}
+	f.SampleRate = 3
+
+	kept := 0
+	for i := 0; i < 9; i++ {
+		if f.Keep(map[string]interface{}{"level": "debug"}) {
+			kept++
+		}
+	}
+
+	if kept != 3 {
+		t.Errorf("Keep() kept %d of 9 entries, want 3", kept)
+	}
+}
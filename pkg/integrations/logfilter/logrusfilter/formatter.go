@@ -0,0 +1,60 @@
+// Copyright 2018 Yaacov Zamir <kobi.zamir@gmail.com>
+// and other contributors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package logrusfilter filters logrus log entries using TSL.
+//
+// Logrus hooks can not drop entries, so the filter wraps the logger
+// formatter, dropped entries are formatted as empty output.
+//
+// Usage:
+//   f, err := logfilter.NewFilter("level != 'debug' or component = 'db'")
+//
+//   logrusfilter.Install(logger, f)
+//
+package logrusfilter
+
+import (
+	"github.com/sirupsen/logrus"
+
+	"github.com/yaacov/tree-search-language/pkg/integrations/logfilter"
+)
+
+// Formatter formats only entries matching a filter.
+type Formatter struct {
+	logrus.Formatter
+
+	Filter *logfilter.Filter
+}
+
+// Format formats the entry using the wrapped formatter if it matches the
+// filter, and returns empty output otherwise.
+func (f *Formatter) Format(e *logrus.Entry) ([]byte, error) {
+	fields := make(map[string]interface{}, len(e.Data)+2)
+	for k, v := range e.Data {
+		fields[k] = v
+	}
+	fields[logfilter.LevelField] = e.Level.String()
+	fields[logfilter.MessageField] = e.Message
+
+	if !f.Filter.Keep(fields) {
+		return nil, nil
+	}
+	return f.Formatter.Format(e)
+}
+
+// Install wraps the logger formatter with a filter.
+func Install(l *logrus.Logger, f *logfilter.Filter) {
+	l.SetFormatter(&Formatter{Formatter: l.Formatter, Filter: f})
+}
@@ -0,0 +1,72 @@
+// Copyright 2018 Yaacov Zamir <kobi.zamir@gmail.com>
+// and other contributors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logrusfilter
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/yaacov/tree-search-language/pkg/integrations/logfilter"
+)
+
+func TestInstall(t *testing.T) {
+	f, err := logfilter.NewFilter("level != 'debug' or component = 'db'")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var out bytes.Buffer
+	logger := logrus.New()
+	logger.Out = &out
+	logger.Level = logrus.DebugLevel
+	logger.Formatter = &logrus.TextFormatter{DisableTimestamp: true}
+	Install(logger, f)
+
+	logger.Debug("dropped")
+	logger.Info("kept")
+	logger.WithField("component", "db").Debug("field")
+	logger.WithField("component", "api").Debug("dropped field")
+
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	if len(lines) != 2 || !strings.Contains(lines[0], "msg=kept") || !strings.Contains(lines[1], "msg=field") {
+		t.Errorf("expected the kept entries instead it was %q", out.String())
+	}
+}
+
+func TestFormat(t *testing.T) {
+	f, err := logfilter.NewFilter("msg = 'keep'")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	formatter := &Formatter{Formatter: &logrus.JSONFormatter{}, Filter: f}
+	logger := logrus.New()
+
+	b, err := formatter.Format(logrus.NewEntry(logger).WithField("a", 1).WithField("msg", "drop"))
+	if err != nil || len(b) != 0 {
+		t.Errorf("expected empty output instead it was %q, %v", b, err)
+	}
+
+	e := logrus.NewEntry(logger)
+	e.Message = "keep"
+	b, err = formatter.Format(e)
+	if err != nil || !strings.Contains(string(b), `"msg":"keep"`) {
+		t.Errorf("expected formatted output instead it was %q, %v", b, err)
+	}
+}
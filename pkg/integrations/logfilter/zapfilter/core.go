@@ -0,0 +1,91 @@
+// Copyright 2018 Yaacov Zamir <kobi.zamir@gmail.com>
+// and other contributors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package zapfilter filters zap log entries using TSL.
+//
+// Usage:
+//   f, err := logfilter.NewFilter("level != 'debug' or component = 'db'")
+//
+//   logger := zap.New(zapfilter.NewCore(core, f))
+//
+//   // Or wrap the core of an existing logger.
+//   logger = logger.WithOptions(zapfilter.WrapCore(f))
+//
+package zapfilter
+
+import (
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+
+	"github.com/yaacov/tree-search-language/pkg/integrations/logfilter"
+)
+
+// core is a zap core dropping entries that do not match a filter.
+type core struct {
+	zapcore.Core
+
+	filter *logfilter.Filter
+	fields []zapcore.Field // fields added using With.
+}
+
+// NewCore returns a core that writes to c only entries matching the filter.
+func NewCore(c zapcore.Core, f *logfilter.Filter) zapcore.Core {
+	return &core{Core: c, filter: f}
+}
+
+// WrapCore returns a logger option wrapping the logger core with a filter.
+func WrapCore(f *logfilter.Filter) zap.Option {
+	return zap.WrapCore(func(c zapcore.Core) zapcore.Core {
+		return NewCore(c, f)
+	})
+}
+
+// With adds fields to the core.
+func (c *core) With(fields []zapcore.Field) zapcore.Core {
+	all := make([]zapcore.Field, 0, len(c.fields)+len(fields))
+	all = append(all, c.fields...)
+	all = append(all, fields...)
+
+	return &core{Core: c.Core.With(fields), filter: c.filter, fields: all}
+}
+
+// Check adds the core to the checked entry, if the wrapped core is enabled
+// for the entry level, the filter is evaluated when the entry is written.
+func (c *core) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.Enabled(ent.Level) {
+		return ce.AddCore(ent, c)
+	}
+	return ce
+}
+
+// Write writes the entry to the wrapped core if it matches the filter.
+func (c *core) Write(ent zapcore.Entry, fields []zapcore.Field) error {
+	enc := zapcore.NewMapObjectEncoder()
+	for _, f := range c.fields {
+		f.AddTo(enc)
+	}
+	for _, f := range fields {
+		f.AddTo(enc)
+	}
+
+	enc.Fields[logfilter.LevelField] = ent.Level.String()
+	enc.Fields[logfilter.MessageField] = ent.Message
+	enc.Fields[logfilter.LoggerField] = ent.LoggerName
+
+	if !c.filter.Keep(enc.Fields) {
+		return nil
+	}
+	return c.Core.Write(ent, fields)
+}
@@ -0,0 +1,69 @@
+// Copyright 2018 Yaacov Zamir <kobi.zamir@gmail.com>
+// and other contributors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package zapfilter
+
+import (
+	"testing"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+
+	"github.com/yaacov/tree-search-language/pkg/integrations/logfilter"
+)
+
+func TestCore(t *testing.T) {
+	f, err := logfilter.NewFilter("level != 'debug' or component = 'db'")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	obs, logs := observer.New(zapcore.DebugLevel)
+	logger := zap.New(NewCore(obs, f))
+
+	logger.Debug("dropped")
+	logger.Info("kept")
+	logger.Debug("field", zap.String("component", "db"))
+	logger.With(zap.String("component", "db")).Debug("with")
+	logger.With(zap.String("component", "api")).Debug("dropped with")
+
+	var got []string
+	for _, e := range logs.All() {
+		got = append(got, e.Message)
+	}
+	if len(got) != 3 || got[0] != "kept" || got[1] != "field" || got[2] != "with" {
+		t.Errorf("expected the kept entries instead it was %v", got)
+	}
+}
+
+func TestWrapCore(t *testing.T) {
+	f, err := logfilter.NewFilter("msg = 'keep' and logger = 'app'")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	obs, logs := observer.New(zapcore.InfoLevel)
+	logger := zap.New(obs).WithOptions(WrapCore(f)).Named("app")
+
+	logger.Info("keep")
+	logger.Info("drop this")
+	logger.Debug("keep")
+	logger.Named("other").Info("keep")
+
+	if entries := logs.All(); len(entries) != 1 || entries[0].LoggerName != "app" {
+		t.Errorf("expected one entry instead it was %v", entries)
+	}
+}
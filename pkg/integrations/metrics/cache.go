@@ -0,0 +1,66 @@
+// Copyright 2018 Yaacov Zamir <kobi.zamir@gmail.com>
+// and other contributors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/yaacov/tree-search-language/pkg/tslcache"
+)
+
+// cacheCollector exposes the counters of a tslcache cache.
+type cacheCollector struct {
+	cache *tslcache.Cache
+
+	hits      *prometheus.Desc
+	misses    *prometheus.Desc
+	evictions *prometheus.Desc
+	size      *prometheus.Desc
+}
+
+// CacheCollector returns a collector exposing the hits, misses, evictions and
+// size of a cache, metric names are prefixed by namespace.
+func CacheCollector(namespace string, c *tslcache.Cache) prometheus.Collector {
+	name := func(s string) string {
+		return prometheus.BuildFQName(namespace, "tsl_cache", s)
+	}
+
+	return &cacheCollector{
+		cache:     c,
+		hits:      prometheus.NewDesc(name("hits_total"), "Number of TSL cache hits.", nil, nil),
+		misses:    prometheus.NewDesc(name("misses_total"), "Number of TSL cache misses.", nil, nil),
+		evictions: prometheus.NewDesc(name("evictions_total"), "Number of TSL cache evictions.", nil, nil),
+		size:      prometheus.NewDesc(name("size"), "Number of cached TSL phrases.", nil, nil),
+	}
+}
+
+// Describe implements prometheus.Collector.
+func (c *cacheCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.hits
+	ch <- c.misses
+	ch <- c.evictions
+	ch <- c.size
+}
+
+// Collect implements prometheus.Collector.
+func (c *cacheCollector) Collect(ch chan<- prometheus.Metric) {
+	s := c.cache.Stats()
+
+	ch <- prometheus.MustNewConstMetric(c.hits, prometheus.CounterValue, float64(s.Hits))
+	ch <- prometheus.MustNewConstMetric(c.misses, prometheus.CounterValue, float64(s.Misses))
+	ch <- prometheus.MustNewConstMetric(c.evictions, prometheus.CounterValue, float64(s.Evictions))
+	ch <- prometheus.MustNewConstMetric(c.size, prometheus.GaugeValue, float64(s.Size))
+}
@@ -0,0 +1,131 @@
+// Copyright 2018 Yaacov Zamir <kobi.zamir@gmail.com>
+// and other contributors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package metrics instruments TSL parsing and evaluation using prometheus
+// collectors.
+//
+// Metrics counts parsed phrases and parse errors by kind, and observes
+// evaluation latency. CacheCollector exposes the counters of a tslcache
+// cache.
+//
+// Usage:
+//   m := metrics.New("myapp")
+//   prometheus.MustRegister(m, metrics.CacheCollector("myapp", cache))
+//
+//   // Use the instrumented parse and walk methods.
+//   tree, err := m.ParseTSL("name = 'joe'")
+//   ok, err := m.Walk(tree, eval)
+//
+package metrics
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/yaacov/tree-search-language/pkg/tsl"
+	"github.com/yaacov/tree-search-language/pkg/walkers/semantics"
+)
+
+// Parse error kinds, used as the `kind` label of the parse errors counter.
+const (
+	ParseErrorKind   = "parse"
+	LiteralErrorKind = "literal"
+	StackErrorKind   = "stack"
+	OtherErrorKind   = "other"
+)
+
+// Metrics holds the TSL prometheus collectors.
+type Metrics struct {
+	Parses      prometheus.Counter
+	ParseErrors *prometheus.CounterVec
+	EvalLatency prometheus.Histogram
+}
+
+// New creates the TSL collectors, metric names are prefixed by namespace.
+func New(namespace string) *Metrics {
+	return &Metrics{
+		Parses: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "tsl",
+			Name:      "parses_total",
+			Help:      "Number of parsed TSL phrases.",
+		}),
+		ParseErrors: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "tsl",
+			Name:      "parse_errors_total",
+			Help:      "Number of TSL phrases that failed to parse, by error kind.",
+		}, []string{"kind"}),
+		EvalLatency: prometheus.NewHistogram(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: "tsl",
+			Name:      "eval_duration_seconds",
+			Help:      "TSL tree evaluation latency in seconds.",
+			Buckets:   prometheus.ExponentialBuckets(1e-6, 4, 10),
+		}),
+	}
+}
+
+// Describe implements prometheus.Collector.
+func (m *Metrics) Describe(ch chan<- *prometheus.Desc) {
+	m.Parses.Describe(ch)
+	m.ParseErrors.Describe(ch)
+	m.EvalLatency.Describe(ch)
+}
+
+// Collect implements prometheus.Collector.
+func (m *Metrics) Collect(ch chan<- prometheus.Metric) {
+	m.Parses.Collect(ch)
+	m.ParseErrors.Collect(ch)
+	m.EvalLatency.Collect(ch)
+}
+
+// ParseTSL parses a TSL phrase, counting parses and parse errors.
+func (m *Metrics) ParseTSL(phrase string) (tsl.Node, error) {
+	tree, err := tsl.ParseTSL(phrase)
+
+	m.Parses.Inc()
+	if err != nil {
+		m.ParseErrors.WithLabelValues(ErrorKind(err)).Inc()
+	}
+
+	return tree, err
+}
+
+// Walk evaluates a TSL tree using the semantics walker, observing the
+// evaluation latency.
+func (m *Metrics) Walk(tree tsl.Node, eval semantics.EvalFunc) (bool, error) {
+	start := time.Now()
+	defer func() {
+		m.EvalLatency.Observe(time.Since(start).Seconds())
+	}()
+
+	return semantics.Walk(tree, eval)
+}
+
+// ErrorKind returns the kind label of a TSL error.
+func ErrorKind(err error) string {
+	switch err.(type) {
+	case tsl.ParseError:
+		return ParseErrorKind
+	case tsl.UnexpectedLiteralError:
+		return LiteralErrorKind
+	case tsl.StackError:
+		return StackErrorKind
+	}
+
+	return OtherErrorKind
+}
@@ -0,0 +1,57 @@
+// Copyright 2018 Yaacov Zamir <kobi.zamir@gmail.com>
+// and other contributors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrics
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+
+	"github.com/yaacov/tree-search-language/pkg/tslcache"
+)
+
+func TestMetrics(t *testing.T) {
+	m := New("test")
+	cache := tslcache.New(10, nil)
+
+	reg := prometheus.NewPedanticRegistry()
+	reg.MustRegister(m, CacheCollector("test", cache))
+
+	for _, phrase := range []string{"a = 1", "a =", "a = 'b' and"} {
+		m.ParseTSL(phrase)
+	}
+	cache.Get("a = 1")
+	cache.Get("a = 1")
+
+	want := `
+# HELP test_tsl_cache_hits_total Number of TSL cache hits.
+# TYPE test_tsl_cache_hits_total counter
+test_tsl_cache_hits_total 1
+# HELP test_tsl_parses_total Number of parsed TSL phrases.
+# TYPE test_tsl_parses_total counter
+test_tsl_parses_total 3
+# HELP test_tsl_parse_errors_total Number of TSL phrases that failed to parse, by error kind.
+# TYPE test_tsl_parse_errors_total counter
+test_tsl_parse_errors_total{kind="parse"} 2
+`
+	err := testutil.GatherAndCompare(reg, strings.NewReader(want),
+		"test_tsl_parses_total", "test_tsl_parse_errors_total", "test_tsl_cache_hits_total")
+	if err != nil {
+		t.Error(err)
+	}
+}
@@ -0,0 +1,32 @@
+// Copyright 2018 Yaacov Zamir <kobi.zamir@gmail.com>
+// and other contributors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package openapi derives TSL validation schemas from OpenAPI and JSON Schema
+// resource definitions.
+//
+// Object properties become filter fields, nested objects use dot separated
+// field names (e.g. `spec.pages`), and the property types set the field
+// literal type and allowed operators. Properties can set the allowed
+// operators using the `x-tsl-operators` extension, or be excluded from
+// filters using `x-tsl-filterable: false`.
+//
+// Usage:
+//   // Derive the schema of the Book resource of an OpenAPI document.
+//   schema, err := openapi.Load(doc, "Book")
+//
+//   // Use the schema in the httpfilter middleware.
+//   http.Handle("/books", httpfilter.Middleware(schema)(booksHandler))
+//
+package openapi
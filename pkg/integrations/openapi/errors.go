@@ -0,0 +1,28 @@
+// Copyright 2018 Yaacov Zamir <kobi.zamir@gmail.com>
+// and other contributors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package openapi
+
+import "fmt"
+
+// DefinitionError is raised when a resource definition can not be read.
+type DefinitionError struct {
+	Path string // the definition path, e.g. #/components/schemas/Book.
+	Msg  string // the error message.
+}
+
+func (e DefinitionError) Error() string {
+	return fmt.Sprintf("bad definition %s: %s", e.Path, e.Msg)
+}
@@ -0,0 +1,201 @@
+// Copyright 2018 Yaacov Zamir <kobi.zamir@gmail.com>
+// and other contributors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package openapi
+
+import (
+	"fmt"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+
+	"github.com/yaacov/tree-search-language/pkg/validate"
+)
+
+// maxDepth limits nested objects and references, to stop on cyclic
+// definitions.
+const maxDepth = 32
+
+// Load derives the schema of a named resource from an OpenAPI document,
+// the document can be JSON or YAML, the resource is looked up in
+// `components.schemas` (OpenAPI 3) or in `definitions` (OpenAPI 2).
+func Load(doc []byte, name string) (validate.Schema, error) {
+	var v interface{}
+
+	if err := yaml.Unmarshal(doc, &v); err != nil {
+		return nil, DefinitionError{Path: "#", Msg: err.Error()}
+	}
+	root, _ := normalize(v).(map[string]interface{})
+
+	for _, path := range []string{"#/components/schemas/", "#/definitions/"} {
+		if def, err := resolve(root, path+name); err == nil {
+			return fromDefinition(root, def, path+name)
+		}
+	}
+
+	return nil, DefinitionError{Path: name, Msg: "resource not found"}
+}
+
+// FromJSONSchema derives a schema from a decoded JSON Schema object
+// definition, references are resolved relative to the definition.
+func FromJSONSchema(def map[string]interface{}) (validate.Schema, error) {
+	return fromDefinition(def, def, "#")
+}
+
+func fromDefinition(root, def map[string]interface{}, path string) (validate.Schema, error) {
+	s := validate.Schema{}
+	if err := addObject(s, root, def, "", path, 0); err != nil {
+		return nil, err
+	}
+
+	return s, nil
+}
+
+// addObject adds the properties of an object definition to a schema,
+// prefixed by the object field name.
+func addObject(s validate.Schema, root, def map[string]interface{}, prefix, path string, depth int) (err error) {
+	if depth > maxDepth {
+		return DefinitionError{Path: path, Msg: "definition is too deep"}
+	}
+	if def, path, err = deref(root, def, path); err != nil {
+		return
+	}
+
+	// Merge allOf definitions.
+	if all, ok := def["allOf"].([]interface{}); ok {
+		for i, v := range all {
+			sub, _ := v.(map[string]interface{})
+			if err = addObject(s, root, sub, prefix, fmt.Sprintf("%s/allOf/%d", path, i), depth+1); err != nil {
+				return
+			}
+		}
+	}
+
+	props, _ := def["properties"].(map[string]interface{})
+	for name, v := range props {
+		prop, _ := v.(map[string]interface{})
+		propPath := path + "/properties/" + name
+		if prop, propPath, err = deref(root, prop, propPath); err != nil {
+			return
+		}
+
+		// Check for excluded properties.
+		if filterable, ok := prop["x-tsl-filterable"].(bool); ok && !filterable {
+			continue
+		}
+
+		field := prefix + name
+		if prop["type"] == "object" || prop["properties"] != nil || prop["allOf"] != nil {
+			if err = addObject(s, root, prop, field+".", propPath, depth+1); err != nil {
+				return
+			}
+			continue
+		}
+
+		if f, ok := newField(prop); ok {
+			s[field] = f
+		}
+	}
+
+	return
+}
+
+// newField creates a field from a property definition, arrays and untyped
+// properties are not filter fields.
+func newField(prop map[string]interface{}) (f validate.Field, ok bool) {
+	switch prop["type"] {
+	case "string":
+		f = validate.Field{Type: validate.String, Ops: StringOps}
+	case "number", "integer":
+		f = validate.Field{Type: validate.Number, Ops: NumberOps}
+	case "boolean":
+		f = validate.Field{Type: validate.Boolean, Ops: BooleanOps}
+	default:
+		return
+	}
+
+	if _, ok := prop["enum"]; ok {
+		f.Ops = EnumOps
+	}
+
+	// Check for allowed operators extension.
+	if ops, ok := prop["x-tsl-operators"].([]interface{}); ok {
+		f.Ops = []string{}
+		for _, op := range ops {
+			f.Ops = append(f.Ops, fmt.Sprintf("%v", op))
+		}
+	}
+
+	return f, true
+}
+
+// deref follows the reference of a definition, if any.
+func deref(root, def map[string]interface{}, path string) (map[string]interface{}, string, error) {
+	for depth := 0; depth < maxDepth; depth++ {
+		ref, ok := def["$ref"].(string)
+		if !ok {
+			return def, path, nil
+		}
+
+		next, err := resolve(root, ref)
+		if err != nil {
+			return nil, path, err
+		}
+		def, path = next, ref
+	}
+
+	return nil, path, DefinitionError{Path: path, Msg: "too many references"}
+}
+
+// resolve returns the definition of a local JSON pointer reference.
+func resolve(root map[string]interface{}, ref string) (map[string]interface{}, error) {
+	if !strings.HasPrefix(ref, "#") {
+		return nil, DefinitionError{Path: ref, Msg: "only local references are supported"}
+	}
+
+	def := root
+	for _, key := range strings.Split(strings.TrimPrefix(ref, "#"), "/") {
+		if key == "" {
+			continue
+		}
+		key = strings.Replace(strings.Replace(key, "~1", "/", -1), "~0", "~", -1)
+
+		next, ok := def[key].(map[string]interface{})
+		if !ok {
+			return nil, DefinitionError{Path: ref, Msg: "reference not found"}
+		}
+		def = next
+	}
+
+	return def, nil
+}
+
+// normalize converts YAML maps into JSON style maps.
+func normalize(v interface{}) interface{} {
+	switch v := v.(type) {
+	case map[interface{}]interface{}:
+		m := map[string]interface{}{}
+		for k, val := range v {
+			m[fmt.Sprintf("%v", k)] = normalize(val)
+		}
+		return m
+	case []interface{}:
+		for i, val := range v {
+			v[i] = normalize(val)
+		}
+	}
+
+	return v
+}
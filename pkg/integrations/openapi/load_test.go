@@ -0,0 +1,98 @@
+// Copyright 2018 Yaacov Zamir <kobi.zamir@gmail.com>
+// and other contributors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package openapi
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/yaacov/tree-search-language/pkg/tsl"
+	"github.com/yaacov/tree-search-language/pkg/validate"
+)
+
+var doc = []byte(`
+openapi: 3.0.0
+components:
+  schemas:
+    Spec:
+      type: object
+      properties:
+        pages:
+          type: integer
+        rating:
+          type: number
+          x-tsl-operators: ["$eq", "$gt"]
+    Book:
+      type: object
+      properties:
+        title:
+          type: string
+        state:
+          type: string
+          enum: [draft, published]
+        internal:
+          type: string
+          x-tsl-filterable: false
+        tags:
+          type: array
+          items:
+            type: string
+        spec:
+          $ref: '#/components/schemas/Spec'
+`)
+
+func TestLoad(t *testing.T) {
+	schema, err := Load(doc, "Book")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := validate.Schema{
+		"title":       {Type: validate.String, Ops: StringOps},
+		"state":       {Type: validate.String, Ops: EnumOps},
+		"spec.pages":  {Type: validate.Number, Ops: NumberOps},
+		"spec.rating": {Type: validate.Number, Ops: []string{tsl.EqOp, tsl.GtOp}},
+	}
+	if !reflect.DeepEqual(schema, want) {
+		t.Errorf("Load() = %v, want %v", schema, want)
+	}
+
+	tests := []struct {
+		phrase string
+		err    error
+	}{
+		{phrase: "title ~= 'a.*' and spec.pages > 100"},
+		{phrase: "state in ('draft')"},
+		{phrase: "state ~= 'dr.*'", err: validate.Errors{validate.OperatorError{Field: "state", Operator: tsl.RegexOp}}},
+		{phrase: "spec.rating < 3", err: validate.Errors{validate.OperatorError{Field: "spec.rating", Operator: tsl.LtOp}}},
+		{phrase: "internal = 'x'", err: validate.Errors{validate.UnknownFieldError{Field: "internal"}}},
+		{phrase: "spec.* > 100", err: validate.Errors{validate.UnknownFieldError{Field: "spec.*"}}},
+	}
+
+	for _, tt := range tests {
+		tree, err := tsl.ParseTSL(tt.phrase)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := schema.Validate(tree); !reflect.DeepEqual(err, tt.err) {
+			t.Errorf("Validate(%s) = %v, want %v", tt.phrase, err, tt.err)
+		}
+	}
+
+	if _, err := Load(doc, "Author"); err == nil {
+		t.Error("Load(Author) error = nil, want not found error")
+	}
+}
@@ -0,0 +1,44 @@
+// Copyright 2018 Yaacov Zamir <kobi.zamir@gmail.com>
+// and other contributors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package openapi
+
+import (
+	"github.com/yaacov/tree-search-language/pkg/tsl"
+)
+
+// Default operators of the field types.
+var (
+	StringOps = []string{
+		tsl.EqOp, tsl.NotEqOp, tsl.LtOp, tsl.LteOp, tsl.GtOp, tsl.GteOp,
+		tsl.RegexOp, tsl.NotRegexOp, tsl.LikeOp, tsl.NotLikeOp, tsl.ILikeOp, tsl.NotILikeOp,
+		tsl.ContainsOp, tsl.NotContainsOp, tsl.StartsWithOp, tsl.NotStartsWithOp, tsl.EndsWithOp, tsl.NotEndsWithOp,
+		tsl.InOp, tsl.NotInOp, tsl.BetweenOp, tsl.NotBetweenOp,
+		tsl.IsNilOp, tsl.IsNotNilOp,
+	}
+	NumberOps = []string{
+		tsl.EqOp, tsl.NotEqOp, tsl.LtOp, tsl.LteOp, tsl.GtOp, tsl.GteOp,
+		tsl.InOp, tsl.NotInOp, tsl.BetweenOp, tsl.NotBetweenOp,
+		tsl.IsNilOp, tsl.IsNotNilOp,
+		tsl.AddOp, tsl.SubtractOp, tsl.MultiplyOp, tsl.DivideOp, tsl.ModuloOp,
+	}
+	EnumOps = []string{
+		tsl.EqOp, tsl.NotEqOp, tsl.InOp, tsl.NotInOp, tsl.IsNilOp, tsl.IsNotNilOp,
+	}
+	BooleanOps = []string{
+		tsl.EqOp, tsl.NotEqOp, tsl.InOp, tsl.NotInOp, tsl.IsNilOp, tsl.IsNotNilOp,
+		tsl.IsTrueOp, tsl.IsNotTrueOp, tsl.IsFalseOp, tsl.IsNotFalseOp,
+	}
+)
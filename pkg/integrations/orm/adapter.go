@@ -0,0 +1,75 @@
+// Copyright 2018 Yaacov Zamir <kobi.zamir@gmail.com>
+// and other contributors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package orm
+
+import (
+	sq "github.com/Masterminds/squirrel"
+
+	"github.com/yaacov/tree-search-language/pkg/tsl"
+	"github.com/yaacov/tree-search-language/pkg/walkers/ident"
+	walker "github.com/yaacov/tree-search-language/pkg/walkers/sql"
+)
+
+// Adapter connects TSL filters to an ORM query builder.
+type Adapter interface {
+	// Column returns the column name of a field, or an error if the field
+	// can not be used in filters.
+	Column(field string) (string, error)
+
+	// PlaceholderFormat returns the placeholder style of the WHERE clause.
+	PlaceholderFormat() sq.PlaceholderFormat
+
+	// Apply adds the WHERE clause and its arguments to the ORM query.
+	Apply(where string, args []interface{}) error
+}
+
+// Columns maps field names to column names, a nil Columns map allows all
+// fields, using the field name as column name, it is the sql walker column
+// mapping.
+type Columns = walker.Columns
+
+// Filter applies a TSL tree as a WHERE clause using an adapter.
+func Filter(a Adapter, tree tsl.Node) (err error) {
+	var where string
+	var args []interface{}
+
+	// Replace field names with column names.
+	tree, err = ident.Walk(tree, a.Column)
+	if err != nil {
+		return
+	}
+
+	filter, err := walker.Walk(tree)
+	if err != nil {
+		return
+	}
+
+	where, args, err = filter.ToSql()
+	if err != nil {
+		return
+	}
+
+	// Check for custom placeholder style.
+	if format := a.PlaceholderFormat(); format != nil {
+		where, err = format.ReplacePlaceholders(where)
+		if err != nil {
+			return
+		}
+	}
+
+	err = a.Apply(where, args)
+	return
+}
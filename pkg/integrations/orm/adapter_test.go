@@ -0,0 +1,97 @@
+// Copyright 2018 Yaacov Zamir <kobi.zamir@gmail.com>
+// and other contributors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package orm
+
+import (
+	"reflect"
+	"testing"
+
+	sq "github.com/Masterminds/squirrel"
+
+	"github.com/yaacov/tree-search-language/pkg/tsl"
+)
+
+type testAdapter struct {
+	Columns
+	format sq.PlaceholderFormat
+	where  string
+	args   []interface{}
+}
+
+func (a *testAdapter) PlaceholderFormat() sq.PlaceholderFormat {
+	return a.format
+}
+
+func (a *testAdapter) Apply(where string, args []interface{}) error {
+	a.where, a.args = where, args
+	return nil
+}
+
+func TestFilter(t *testing.T) {
+	tests := []struct {
+		name    string
+		columns Columns
+		format  sq.PlaceholderFormat
+		phrase  string
+		where   string
+		args    []interface{}
+		err     error
+	}{
+		{
+			name:    "mapped columns",
+			columns: Columns{"name": "name", "spec.age": "age"},
+			format:  sq.Question,
+			phrase:  "name = 'joe' and spec.age > 18",
+			where:   "(name = ? AND age > ?)",
+			args:    []interface{}{"joe", 18.0},
+		},
+		{
+			name:   "dollar placeholders",
+			format: sq.Dollar,
+			phrase: "a = 1 or b = 2",
+			where:  "(a = $1 OR b = $2)",
+			args:   []interface{}{1.0, 2.0},
+		},
+		{
+			name:    "unknown field",
+			columns: Columns{"name": "name"},
+			phrase:  "age > 18",
+			err:     UnknownColumnError{Column: "age"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tree, err := tsl.ParseTSL(tt.phrase)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			a := &testAdapter{Columns: tt.columns, format: tt.format}
+			err = Filter(a, tree)
+			if err != tt.err {
+				t.Fatalf("Filter() error = %v, want %v", err, tt.err)
+			}
+			if err != nil {
+				return
+			}
+
+			if a.where != tt.where || !reflect.DeepEqual(a.args, tt.args) {
+				t.Errorf("Filter() = %q %v, want %q %v", a.where, a.args, tt.where, tt.args)
+			}
+		})
+	}
+}
@@ -0,0 +1,60 @@
+// Copyright 2018 Yaacov Zamir <kobi.zamir@gmail.com>
+// and other contributors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package boileradapter applies TSL filters to sqlboiler queries.
+//
+// Usage:
+//   mod, err := boileradapter.Where(columns, tree)
+//   books, err := models.Books(mod, qm.Limit(10)).All(ctx, db)
+//
+package boileradapter
+
+import (
+	sq "github.com/Masterminds/squirrel"
+	"github.com/volatiletech/sqlboiler/queries/qm"
+
+	"github.com/yaacov/tree-search-language/pkg/integrations/orm"
+	"github.com/yaacov/tree-search-language/pkg/tsl"
+)
+
+// Adapter collects sqlboiler query mods from TSL filters.
+type Adapter struct {
+	orm.Columns
+
+	// Mods are the query mods, Apply appends a where mod.
+	Mods []qm.QueryMod
+}
+
+// PlaceholderFormat returns sq.Question, sqlboiler rewrites placeholders
+// for the database dialect.
+func (a *Adapter) PlaceholderFormat() sq.PlaceholderFormat {
+	return sq.Question
+}
+
+// Apply appends a where query mod.
+func (a *Adapter) Apply(where string, args []interface{}) error {
+	a.Mods = append(a.Mods, qm.Where(where, args...))
+	return nil
+}
+
+// Where returns a sqlboiler where query mod filtering by a TSL tree.
+func Where(columns orm.Columns, tree tsl.Node) (qm.QueryMod, error) {
+	a := &Adapter{Columns: columns}
+	if err := orm.Filter(a, tree); err != nil {
+		return nil, err
+	}
+
+	return a.Mods[0], nil
+}
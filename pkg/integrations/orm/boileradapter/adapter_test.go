@@ -0,0 +1,82 @@
+// Copyright 2018 Yaacov Zamir <kobi.zamir@gmail.com>
+// and other contributors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package boileradapter
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/volatiletech/sqlboiler/drivers"
+	"github.com/volatiletech/sqlboiler/queries"
+	"github.com/volatiletech/sqlboiler/queries/qm"
+
+	"github.com/yaacov/tree-search-language/pkg/integrations/orm"
+	"github.com/yaacov/tree-search-language/pkg/tsl"
+)
+
+var columns = orm.Columns{"title": "title", "spec.pages": "pages"}
+
+func TestWhere(t *testing.T) {
+	tests := []struct {
+		phrase string
+		sql    string
+		args   []interface{}
+	}{
+		{
+			phrase: "title = 'a' and spec.pages > 10",
+			sql:    `SELECT * FROM "books" WHERE ((title = $1 AND pages > $2)) LIMIT 10;`,
+			args:   []interface{}{"a", 10.0},
+		},
+		{
+			phrase: "title in ('a', 'b') or spec.pages is null",
+			sql:    `SELECT * FROM "books" WHERE ((title IN ($1,$2) OR pages IS NULL)) LIMIT 10;`,
+			args:   []interface{}{"a", "b"},
+		},
+	}
+
+	for _, tt := range tests {
+		tree, err := tsl.ParseTSL(tt.phrase)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		mod, err := Where(columns, tree)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		q := &queries.Query{}
+		queries.SetDialect(q, &drivers.Dialect{LQ: '"', RQ: '"', UseIndexPlaceholders: true})
+		queries.SetFrom(q, `"books"`)
+		qm.Apply(q, mod, qm.Limit(10))
+
+		sql, args := queries.BuildQuery(q)
+		if sql != tt.sql || !reflect.DeepEqual(args, tt.args) {
+			t.Errorf("%s: expected %q %v instead it was %q %v", tt.phrase, tt.sql, tt.args, sql, args)
+		}
+	}
+}
+
+func TestWhereUnknownColumn(t *testing.T) {
+	tree, err := tsl.ParseTSL("author = 'joe'")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := Where(columns, tree); err != (orm.UnknownColumnError{Column: "author"}) {
+		t.Errorf("expected an unknown column error instead it was %v", err)
+	}
+}
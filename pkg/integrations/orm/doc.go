@@ -0,0 +1,34 @@
+// Copyright 2018 Yaacov Zamir <kobi.zamir@gmail.com>
+// and other contributors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package orm plugs TSL filters into ORM query builders.
+//
+// An Adapter maps field names to columns, sets the placeholder style of the
+// generated SQL, and applies the WHERE clause to the ORM query. The
+// gormadapter, entadapter and boileradapter packages implement adapters for
+// GORM, ent and sqlboiler.
+//
+// Usage:
+//   tree, err := tsl.ParseTSL("name = 'joe' and spec.age > 18")
+//
+//   // Map field names to columns, unknown fields are not allowed.
+//   columns := orm.Columns{"name": "name", "spec.age": "age"}
+//
+//   // Filter a GORM query.
+//   a := &gormadapter.Adapter{DB: db.Model(&User{}), Columns: columns}
+//   err = orm.Filter(a, tree)
+//   a.DB.Find(&users)
+//
+package orm
@@ -0,0 +1,85 @@
+// Copyright 2018 Yaacov Zamir <kobi.zamir@gmail.com>
+// and other contributors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package entadapter applies TSL filters to ent queries.
+//
+// The filter is returned as an ent selector predicate, that can be
+// converted to the predicate type of the generated entity package.
+//
+// Usage:
+//   p, err := entadapter.Predicate(columns, tree)
+//   books, err := client.Book.Query().Where(predicate.Book(p)).All(ctx)
+//
+// The Schema type builds the filter from ent predicates (e.g. sql.EQ and
+// sql.HasPrefix), checking columns using the generated entity package.
+//
+// Usage:
+//   schema := entadapter.Schema{Fields: columns, ValidColumn: book.ValidColumn}
+//   p, err := schema.Predicate(tree)
+//
+package entadapter
+
+import (
+	"strings"
+
+	"entgo.io/ent/dialect/sql"
+	sq "github.com/Masterminds/squirrel"
+
+	"github.com/yaacov/tree-search-language/pkg/integrations/orm"
+	"github.com/yaacov/tree-search-language/pkg/tsl"
+)
+
+// Adapter creates an ent selector predicate from TSL filters.
+type Adapter struct {
+	orm.Columns
+
+	// Predicate is set by Apply, it adds the WHERE clause to an ent selector.
+	Predicate func(*sql.Selector)
+}
+
+// PlaceholderFormat returns sq.Question, the ent builder writes the
+// arguments using the placeholders of the database dialect.
+func (a *Adapter) PlaceholderFormat() sq.PlaceholderFormat {
+	return sq.Question
+}
+
+// Apply sets the adapter predicate.
+func (a *Adapter) Apply(where string, args []interface{}) error {
+	parts := strings.Split(where, "?")
+
+	a.Predicate = func(s *sql.Selector) {
+		s.Where(sql.P(func(b *sql.Builder) {
+			// Write the SQL parts, with an argument after each part but the last.
+			for i, part := range parts {
+				b.WriteString(part)
+				if i < len(args) {
+					b.Arg(args[i])
+				}
+			}
+		}))
+	}
+
+	return nil
+}
+
+// Predicate returns an ent selector predicate filtering by a TSL tree.
+func Predicate(columns orm.Columns, tree tsl.Node) (func(*sql.Selector), error) {
+	a := &Adapter{Columns: columns}
+	if err := orm.Filter(a, tree); err != nil {
+		return nil, err
+	}
+
+	return a.Predicate, nil
+}
@@ -0,0 +1,118 @@
+// Copyright 2018 Yaacov Zamir <kobi.zamir@gmail.com>
+// and other contributors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package entadapter
+
+import (
+	"reflect"
+	"testing"
+
+	"entgo.io/ent/dialect"
+	"entgo.io/ent/dialect/sql"
+
+	"github.com/yaacov/tree-search-language/pkg/integrations/orm"
+	"github.com/yaacov/tree-search-language/pkg/tsl"
+)
+
+var columns = orm.Columns{"title": "title", "spec.pages": "pages"}
+
+// query returns the SQL of a books query filtered by a selector predicate.
+func query(p func(*sql.Selector)) (string, []interface{}) {
+	s := sql.Dialect(dialect.Postgres).Select("*").From(sql.Table("books"))
+	p(s)
+	return s.Query()
+}
+
+func TestPredicate(t *testing.T) {
+	tree, err := tsl.ParseTSL("title = 'a' and spec.pages > 10")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	p, err := Predicate(columns, tree)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sql, args := query(p)
+	want := `SELECT * FROM "books" WHERE (title = $1 AND pages > $2)`
+	if sql != want || !reflect.DeepEqual(args, []interface{}{"a", 10.0}) {
+		t.Errorf("expected %q instead it was %q %v", want, sql, args)
+	}
+
+	tree, err = tsl.ParseTSL("author = 'joe'")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Predicate(columns, tree); err != (orm.UnknownColumnError{Column: "author"}) {
+		t.Errorf("expected an unknown column error instead it was %v", err)
+	}
+}
+
+func TestSchemaPredicate(t *testing.T) {
+	schema := Schema{
+		Fields: columns,
+		ValidColumn: func(column string) bool {
+			return column == "title"
+		},
+	}
+
+	tests := []struct {
+		phrase string
+		sql    string
+		args   []interface{}
+	}{
+		{
+			phrase: "title = 'a' or title in ('b', 'c')",
+			sql:    `SELECT * FROM "books" WHERE "books"."title" = $1 OR "books"."title" IN ($2, $3)`,
+			args:   []interface{}{"a", "b", "c"},
+		},
+		{
+			phrase: "not (title like 'a%') and title between 'b' and 'c'",
+			sql:    `SELECT * FROM "books" WHERE (NOT ("books"."title" LIKE $1)) AND ("books"."title" >= $2 AND "books"."title" <= $3)`,
+			args:   []interface{}{"a%", "b", "c"},
+		},
+		{
+			phrase: "title is not null",
+			sql:    `SELECT * FROM "books" WHERE "books"."title" IS NOT NULL`,
+		},
+	}
+
+	for _, tt := range tests {
+		tree, err := tsl.ParseTSL(tt.phrase)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		p, err := schema.Predicate(tree)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		sql, args := query(p)
+		if sql != tt.sql || !reflect.DeepEqual(args, tt.args) {
+			t.Errorf("%s: expected %q %v instead it was %q %v", tt.phrase, tt.sql, tt.args, sql, args)
+		}
+	}
+
+	// Mapped fields must be valid entity columns.
+	tree, err := tsl.ParseTSL("spec.pages > 10")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := schema.Predicate(tree); err != (orm.UnknownColumnError{Column: "spec.pages"}) {
+		t.Errorf("expected an unknown column error instead it was %v", err)
+	}
+}
@@ -0,0 +1,228 @@
+// Copyright 2018 Yaacov Zamir <kobi.zamir@gmail.com>
+// and other contributors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package entadapter
+
+import (
+	"entgo.io/ent/dialect/sql"
+
+	"github.com/yaacov/tree-search-language/pkg/integrations/orm"
+	"github.com/yaacov/tree-search-language/pkg/tsl"
+)
+
+// Schema describes the filterable fields of an ent entity, using the
+// generated entity package.
+//
+//  schema := entadapter.Schema{
+//    Fields:      orm.Columns{"title": book.FieldTitle, "spec.pages": book.FieldPages},
+//    ValidColumn: book.ValidColumn,
+//  }
+//
+type Schema struct {
+	// Fields maps field names to entity columns, a nil Fields map allows all
+	// fields, using the field name as column name.
+	Fields orm.Columns
+
+	// ValidColumn reports if a column is an entity column, if nil all
+	// columns are valid.
+	ValidColumn func(column string) bool
+}
+
+// predicate creates an ent predicate for a selector.
+type predicate func(s *sql.Selector) *sql.Predicate
+
+// Predicate returns an ent selector predicate filtering by a TSL tree, built
+// from ent predicates, e.g. sql.EQ and sql.HasPrefix.
+//
+// Math expressions, regular expressions and case insensitive like are not
+// supported.
+//
+//  p, err := schema.Predicate(tree)
+//  books, err := client.Book.Query().Where(predicate.Book(p)).All(ctx)
+//
+func (s Schema) Predicate(tree tsl.Node) (func(*sql.Selector), error) {
+	p, err := s.walk(tree)
+	if err != nil {
+		return nil, err
+	}
+
+	return func(selector *sql.Selector) {
+		selector.Where(p(selector))
+	}, nil
+}
+
+// column returns the entity column of an identifier node.
+func (s Schema) column(n interface{}) (string, error) {
+	l, ok := n.(tsl.Node)
+	if !ok || l.Func != tsl.IdentOp {
+		return "", tsl.UnexpectedLiteralError{Literal: l.Func}
+	}
+
+	column, err := s.Fields.Column(l.Left.(string))
+	if err != nil {
+		return "", err
+	}
+	if s.ValidColumn != nil && !s.ValidColumn(column) {
+		return "", orm.UnknownColumnError{Column: l.Left.(string)}
+	}
+
+	return column, nil
+}
+
+// walk creates the ent predicate of a node.
+func (s Schema) walk(n tsl.Node) (predicate, error) {
+	switch n.Func {
+	case tsl.AndOp, tsl.OrOp:
+		l, err := s.walk(n.Left.(tsl.Node))
+		if err != nil {
+			return nil, err
+		}
+		r, err := s.walk(n.Right.(tsl.Node))
+		if err != nil {
+			return nil, err
+		}
+
+		join := sql.And
+		if n.Func == tsl.OrOp {
+			join = sql.Or
+		}
+		return func(selector *sql.Selector) *sql.Predicate {
+			return join(l(selector), r(selector))
+		}, nil
+	case tsl.NotOp:
+		l, err := s.walk(n.Left.(tsl.Node))
+		if err != nil {
+			return nil, err
+		}
+
+		return func(selector *sql.Selector) *sql.Predicate {
+			return sql.Not(l(selector))
+		}, nil
+	}
+
+	column, err := s.column(n.Left)
+	if err != nil {
+		return nil, err
+	}
+
+	// Right hand side literals.
+	var values []interface{}
+	if r, ok := n.Right.(tsl.Node); ok {
+		values = []interface{}{r.Left}
+		if r.Func == tsl.ArrayOp {
+			values = values[:0]
+			for _, v := range r.Right.([]tsl.Node) {
+				values = append(values, v.Left)
+			}
+		}
+	}
+
+	p, err := compare(n.Func, values)
+	if err != nil {
+		return nil, err
+	}
+
+	return func(selector *sql.Selector) *sql.Predicate {
+		return p(selector.C(column))
+	}, nil
+}
+
+// compare returns the ent predicate of a comparison operator for a column.
+func compare(op string, values []interface{}) (func(c string) *sql.Predicate, error) {
+	switch op {
+	case tsl.IsNilOp:
+		return sql.IsNull, nil
+	case tsl.IsNotNilOp:
+		return sql.NotNull, nil
+	case tsl.IsTrueOp, tsl.IsFalseOp:
+		return func(c string) *sql.Predicate {
+			return sql.EQ(c, op == tsl.IsTrueOp)
+		}, nil
+	case tsl.IsNotTrueOp, tsl.IsNotFalseOp:
+		// Not true also matches null values.
+		return func(c string) *sql.Predicate {
+			return sql.Or(sql.NEQ(c, op == tsl.IsNotTrueOp), sql.IsNull(c))
+		}, nil
+	case tsl.InOp:
+		return func(c string) *sql.Predicate {
+			return sql.In(c, values...)
+		}, nil
+	case tsl.NotInOp:
+		return func(c string) *sql.Predicate {
+			return sql.NotIn(c, values...)
+		}, nil
+	}
+
+	if len(values) == 0 {
+		return nil, tsl.UnexpectedLiteralError{Literal: op}
+	}
+	v := values[0]
+
+	switch op {
+	case tsl.EqOp:
+		return func(c string) *sql.Predicate { return sql.EQ(c, v) }, nil
+	case tsl.NotEqOp:
+		return func(c string) *sql.Predicate { return sql.NEQ(c, v) }, nil
+	case tsl.LtOp:
+		return func(c string) *sql.Predicate { return sql.LT(c, v) }, nil
+	case tsl.LteOp:
+		return func(c string) *sql.Predicate { return sql.LTE(c, v) }, nil
+	case tsl.GtOp:
+		return func(c string) *sql.Predicate { return sql.GT(c, v) }, nil
+	case tsl.GteOp:
+		return func(c string) *sql.Predicate { return sql.GTE(c, v) }, nil
+	case tsl.BetweenOp, tsl.NotBetweenOp:
+		if len(values) != 2 {
+			return nil, tsl.UnexpectedLiteralError{Literal: values}
+		}
+
+		return func(c string) *sql.Predicate {
+			p := sql.And(sql.GTE(c, values[0]), sql.LTE(c, values[1]))
+			if op == tsl.NotBetweenOp {
+				return sql.Not(p)
+			}
+			return p
+		}, nil
+	}
+
+	// The rest of the operators match strings.
+	str, ok := v.(string)
+	if !ok {
+		return nil, tsl.UnexpectedLiteralError{Literal: v}
+	}
+
+	var match func(c, s string) *sql.Predicate
+	negate := false
+	switch op {
+	case tsl.LikeOp, tsl.NotLikeOp:
+		match, negate = sql.Like, op == tsl.NotLikeOp
+	case tsl.ContainsOp, tsl.NotContainsOp:
+		match, negate = sql.Contains, op == tsl.NotContainsOp
+	case tsl.StartsWithOp, tsl.NotStartsWithOp:
+		match, negate = sql.HasPrefix, op == tsl.NotStartsWithOp
+	case tsl.EndsWithOp, tsl.NotEndsWithOp:
+		match, negate = sql.HasSuffix, op == tsl.NotEndsWithOp
+	default:
+		// If here than the operator is not supported.
+		return nil, tsl.UnexpectedLiteralError{Literal: op}
+	}
+
+	return func(c string) *sql.Predicate {
+		if negate {
+			return sql.Not(match(c, str))
+		}
+		return match(c, str)
+	}, nil
+}
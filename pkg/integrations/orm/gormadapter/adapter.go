@@ -0,0 +1,76 @@
+// Copyright 2018 Yaacov Zamir <kobi.zamir@gmail.com>
+// and other contributors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package gormadapter applies TSL filters to GORM queries.
+//
+// Usage:
+//   db, err = gormadapter.Where(db.Model(&Book{}), columns, tree)
+//   db.Find(&books)
+//
+//   // Or, filter a query chain using a scope.
+//   db.Model(&Book{}).Scopes(gormadapter.Scope(columns, tree)).Find(&books)
+//
+package gormadapter
+
+import (
+	sq "github.com/Masterminds/squirrel"
+	"github.com/jinzhu/gorm"
+
+	"github.com/yaacov/tree-search-language/pkg/integrations/orm"
+	"github.com/yaacov/tree-search-language/pkg/tsl"
+)
+
+// Adapter applies TSL filters to a GORM query.
+type Adapter struct {
+	orm.Columns
+
+	// DB is the GORM query, Apply replaces it with the filtered query.
+	DB *gorm.DB
+}
+
+// PlaceholderFormat returns sq.Question, GORM rewrites placeholders for
+// the database dialect.
+func (a *Adapter) PlaceholderFormat() sq.PlaceholderFormat {
+	return sq.Question
+}
+
+// Apply adds the WHERE clause to the GORM query.
+func (a *Adapter) Apply(where string, args []interface{}) error {
+	a.DB = a.DB.Where(where, args...)
+	return nil
+}
+
+// Where returns the GORM query filtered by a TSL tree.
+func Where(db *gorm.DB, columns orm.Columns, tree tsl.Node) (*gorm.DB, error) {
+	a := &Adapter{Columns: columns, DB: db}
+	if err := orm.Filter(a, tree); err != nil {
+		return db, err
+	}
+
+	return a.DB, nil
+}
+
+// Scope returns a GORM scope filtering queries by a TSL tree, filter errors
+// are added to the query errors.
+func Scope(columns orm.Columns, tree tsl.Node) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		filtered, err := Where(db, columns, tree)
+		if err != nil {
+			_ = filtered.AddError(err)
+		}
+
+		return filtered
+	}
+}
@@ -0,0 +1,136 @@
+// Copyright 2018 Yaacov Zamir <kobi.zamir@gmail.com>
+// and other contributors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxy
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// entry is a cached response.
+type entry struct {
+	status  int
+	header  http.Header
+	body    []byte
+	expires time.Time
+}
+
+// Cache is an http.RoundTripper caching successful GET responses by URL, it is
+// safe for concurrent use.
+//
+// Requests with an Authorization or Cookie header are not cached.
+type Cache struct {
+	next http.RoundTripper
+	ttl  time.Duration
+	size int
+
+	mu      sync.Mutex
+	entries map[string]entry
+}
+
+// NewCache returns a cache of up to size responses, cached for ttl, using next
+// to send requests.
+func NewCache(next http.RoundTripper, ttl time.Duration, size int) *Cache {
+	return &Cache{
+		next:    next,
+		ttl:     ttl,
+		size:    size,
+		entries: map[string]entry{},
+	}
+}
+
+// RoundTrip returns a cached response, or sends the request.
+func (c *Cache) RoundTrip(r *http.Request) (*http.Response, error) {
+	if r.Method != http.MethodGet || r.Header.Get("Authorization") != "" || r.Header.Get("Cookie") != "" {
+		return c.next.RoundTrip(r)
+	}
+	key := r.URL.String()
+
+	// Check for a cached response.
+	c.mu.Lock()
+	e, ok := c.entries[key]
+	c.mu.Unlock()
+	if ok && time.Now().Before(e.expires) {
+		return e.response(r), nil
+	}
+
+	resp, err := c.next.RoundTrip(r)
+	if err != nil || resp.StatusCode != http.StatusOK || resp.Header.Get("Content-Encoding") != "" {
+		return resp, err
+	}
+
+	body, err := ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	e = entry{
+		status:  resp.StatusCode,
+		header:  resp.Header.Clone(),
+		body:    body,
+		expires: time.Now().Add(c.ttl),
+	}
+	c.put(key, e)
+
+	return e.response(r), nil
+}
+
+// Purge removes all cached responses.
+func (c *Cache) Purge() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.entries = map[string]entry{}
+}
+
+// put adds a response to the cache, if the cache is full expired responses
+// are removed, and if it is still full the response is not cached.
+func (c *Cache) put(key string, e entry) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if len(c.entries) >= c.size {
+		now := time.Now()
+		for k, old := range c.entries {
+			if now.After(old.expires) {
+				delete(c.entries, k)
+			}
+		}
+	}
+
+	if len(c.entries) < c.size {
+		c.entries[key] = e
+	}
+}
+
+// response returns a new response of a cached entry.
+func (e entry) response(r *http.Request) *http.Response {
+	return &http.Response{
+		Status:        http.StatusText(e.status),
+		StatusCode:    e.status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        e.header.Clone(),
+		Body:          ioutil.NopCloser(bytes.NewReader(e.body)),
+		ContentLength: int64(len(e.body)),
+		Request:       r,
+	}
+}
@@ -0,0 +1,34 @@
+// Copyright 2018 Yaacov Zamir <kobi.zamir@gmail.com>
+// and other contributors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package proxy is a reverse proxy filtering JSON array responses using TSL.
+//
+// The proxy forwards requests to an upstream API, and filters the elements of
+// JSON array responses using the TSL tree stored in the request context by
+// the httpfilter middleware, for fronting APIs that lack filtering. The
+// filter query parameter is not forwarded upstream.
+//
+// Upstream responses can be cached using a Cache transport, so requests with
+// different filters on the same resource are served from the cache.
+//
+// Usage:
+//   target, err := url.Parse("https://api.example.com")
+//
+//   p := proxy.New(target)
+//   p.Transport = proxy.NewCache(http.DefaultTransport, time.Minute, 1000)
+//
+//   http.Handle("/", httpfilter.Middleware(schema)(p))
+//
+package proxy
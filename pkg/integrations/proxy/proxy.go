@@ -0,0 +1,130 @@
+// Copyright 2018 Yaacov Zamir <kobi.zamir@gmail.com>
+// and other contributors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxy
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"mime"
+	"net/http"
+	"net/http/httputil"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/yaacov/tree-search-language/pkg/integrations/httpfilter"
+	"github.com/yaacov/tree-search-language/pkg/integrations/stream"
+	"github.com/yaacov/tree-search-language/pkg/tsl"
+	"github.com/yaacov/tree-search-language/pkg/walkers/semantics"
+)
+
+// New returns a reverse proxy to target, filtering JSON array responses
+// using the TSL tree of the request context.
+func New(target *url.URL) *httputil.ReverseProxy {
+	p := httputil.NewSingleHostReverseProxy(target)
+
+	director := p.Director
+	p.Director = func(r *http.Request) {
+		director(r)
+
+		// Do not forward the filter.
+		q := r.URL.Query()
+		if _, ok := q[httpfilter.FilterParam]; ok {
+			q.Del(httpfilter.FilterParam)
+			r.URL.RawQuery = q.Encode()
+		}
+
+		// Filtered responses are decoded, ask for uncompressed responses.
+		if _, ok := httpfilter.FromContext(r.Context()); ok {
+			r.Header.Del("Accept-Encoding")
+		}
+	}
+	p.ModifyResponse = FilterResponse
+
+	return p
+}
+
+// FilterResponse filters the elements of a JSON array response using the TSL
+// tree of the request context, other responses are not changed.
+//
+// Nested objects are accessed using dot separated identifiers, elements that
+// are not objects are dropped.
+func FilterResponse(resp *http.Response) error {
+	tree, ok := httpfilter.FromContext(resp.Request.Context())
+	if !ok || resp.StatusCode != http.StatusOK || !isJSON(resp.Header.Get("Content-Type")) {
+		return nil
+	}
+
+	data, err := ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return err
+	}
+
+	// Filter the array elements.
+	var elements []interface{}
+	if err = json.Unmarshal(data, &elements); err != nil {
+		return err
+	}
+
+	elements, err = filter(tree, elements)
+	if err != nil {
+		return err
+	}
+
+	data, err = json.Marshal(elements)
+	if err != nil {
+		return err
+	}
+
+	// Replace the body, the upstream validators do not match the new body.
+	resp.Body = ioutil.NopCloser(bytes.NewReader(data))
+	resp.ContentLength = int64(len(data))
+	resp.Header.Set("Content-Length", strconv.Itoa(len(data)))
+	resp.Header.Del("Content-Encoding")
+	resp.Header.Del("ETag")
+
+	return nil
+}
+
+// filter returns the elements matching a tree.
+func filter(tree tsl.Node, elements []interface{}) ([]interface{}, error) {
+	matches := []interface{}{}
+
+	for _, e := range elements {
+		doc, ok := e.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		match, err := semantics.Walk(tree, stream.EvalFactory(doc))
+		if err != nil {
+			return nil, err
+		}
+		if match {
+			matches = append(matches, e)
+		}
+	}
+
+	return matches, nil
+}
+
+// isJSON checks if a content type is JSON.
+func isJSON(contentType string) bool {
+	t, _, err := mime.ParseMediaType(contentType)
+	return err == nil && (t == "application/json" || strings.HasSuffix(t, "+json"))
+}
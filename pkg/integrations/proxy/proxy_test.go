@@ -0,0 +1,92 @@
+// Copyright 2018 Yaacov Zamir <kobi.zamir@gmail.com>
+// and other contributors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxy
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/yaacov/tree-search-language/pkg/integrations/httpfilter"
+	"github.com/yaacov/tree-search-language/pkg/validate"
+)
+
+const books = `[
+  {"title": "Book", "author": "Joe", "spec": {"pages": 100}},
+  {"title": "Other Book", "author": "Jane", "spec": {"pages": 50}},
+  {"title": "Big Book", "author": "Joe", "spec": {"pages": 500}}
+]`
+
+func TestProxy(t *testing.T) {
+	hits := 0
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits++
+		if r.URL.Query().Get("filter") != "" {
+			t.Errorf("upstream got filter %q", r.URL.Query().Get("filter"))
+		}
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.Write([]byte(books))
+	}))
+	defer upstream.Close()
+
+	target, _ := url.Parse(upstream.URL)
+	p := New(target)
+	p.Transport = NewCache(http.DefaultTransport, time.Minute, 10)
+
+	server := httptest.NewServer(httpfilter.Middleware(validate.Schema{"author": {Type: validate.String}, "spec.pages": {Type: validate.Number}})(p))
+	defer server.Close()
+
+	tests := []struct {
+		filter string
+		want   string
+	}{
+		{"author = 'Joe' and spec.pages > 200", `[{"author":"Joe","spec":{"pages":500},"title":"Big Book"}]`},
+		{"spec.pages < 10", `[]`},
+	}
+
+	for _, tt := range tests {
+		resp, err := http.Get(server.URL + "/books?filter=" + url.QueryEscape(tt.filter))
+		if err != nil {
+			t.Fatal(err)
+		}
+		body, _ := ioutil.ReadAll(resp.Body)
+		resp.Body.Close()
+
+		if string(body) != tt.want {
+			t.Errorf("GET %q = %s, want %s", tt.filter, body, tt.want)
+		}
+	}
+
+	// Requests without a filter are not changed.
+	resp, err := http.Get(server.URL + "/books")
+	if err != nil {
+		t.Fatal(err)
+	}
+	body, _ := ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+
+	if string(body) != books {
+		t.Errorf("GET = %s, want %s", body, books)
+	}
+
+	// All the requests are served from the cached upstream response.
+	if hits != 1 {
+		t.Errorf("upstream hits = %d, want 1", hits)
+	}
+}
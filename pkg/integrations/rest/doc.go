@@ -0,0 +1,39 @@
+// Copyright 2018 Yaacov Zamir <kobi.zamir@gmail.com>
+// and other contributors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package rest helps to implement REST list endpoints using TSL filters.
+//
+// The List method reads the filter, sort and page query parameters of a
+// request, and queries one page of rows, and the total count of rows
+// matching the filter.
+//
+// Usage:
+//   // Set the field schema, and map user field names to SQL columns.
+//   schema := validate.Schema{
+//       "title":      {Type: validate.String},
+//       "spec.pages": {Type: validate.Number},
+//   }
+//
+//   lister := rest.Lister{
+//       DB:      db,
+//       Query:   sq.Select("title", "pages").From("books"),
+//       Schema:  schema,
+//       Columns: map[string]string{"spec.pages": "pages"},
+//   }
+//
+//   // GET /books?filter=spec.pages>100&sort=-spec.pages,title&page=2&page_size=10
+//   rows, total, err := lister.List(r)
+//
+package rest
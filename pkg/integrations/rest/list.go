@@ -0,0 +1,150 @@
+// Copyright 2018 Yaacov Zamir <kobi.zamir@gmail.com>
+// and other contributors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rest
+
+import (
+	"database/sql"
+	"fmt"
+	"net/http"
+
+	sq "github.com/Masterminds/squirrel"
+
+	"github.com/yaacov/tree-search-language/pkg/tsl"
+	"github.com/yaacov/tree-search-language/pkg/validate"
+	"github.com/yaacov/tree-search-language/pkg/walkers/ident"
+	walker "github.com/yaacov/tree-search-language/pkg/walkers/sql"
+)
+
+// Lister lists the rows of a base query, filtered, sorted and paginated
+// using the request query parameters.
+type Lister struct {
+	DB     *sql.DB
+	Query  sq.SelectBuilder // the base query, e.g. sq.Select("*").From("books")
+	Schema validate.Schema  // the fields users can filter and sort by.
+
+	// Columns maps field names to SQL column names, fields missing from the
+	// map use the field name.
+	Columns walker.Columns
+
+	// PlaceholderFormat of the queries, defaults to sq.Question.
+	PlaceholderFormat sq.PlaceholderFormat
+
+	// DefaultPageSize and MaxPageSize, default to DefaultPageSize and MaxPageSize.
+	DefaultPageSize uint64
+	MaxPageSize     uint64
+}
+
+// List queries one page of rows, and the total count of rows matching the
+// filter, the caller must close the returned rows.
+//
+// Bad query parameters return a ParamError, tsl.ParseError or a validate
+// error.
+func (l Lister) List(r *http.Request) (rows *sql.Rows, total uint64, err error) {
+	var filter sq.Sqlizer
+	var tree tsl.Node
+
+	defaultPageSize, maxPageSize := l.DefaultPageSize, l.MaxPageSize
+	if defaultPageSize == 0 {
+		defaultPageSize = DefaultPageSize
+	}
+	if maxPageSize == 0 {
+		maxPageSize = MaxPageSize
+	}
+
+	format := l.PlaceholderFormat
+	if format == nil {
+		format = sq.Question
+	}
+
+	p, err := ParseParams(r, defaultPageSize, maxPageSize)
+	if err != nil {
+		return
+	}
+
+	query := l.Query
+
+	// Add the filter.
+	if p.Filter != "" {
+		tree, err = tsl.ParseTSL(p.Filter)
+		if err != nil {
+			return
+		}
+
+		// Validate the tree and replace field names with column names.
+		if err = l.Schema.Validate(tree); err != nil {
+			return
+		}
+		tree, err = ident.Walk(tree, l.columnName)
+		if err != nil {
+			return
+		}
+
+		filter, err = walker.Walk(tree)
+		if err != nil {
+			return
+		}
+		query = query.Where(filter)
+	}
+
+	// Count all matching rows.
+	err = sq.Select("COUNT(*)").
+		FromSelect(query, "q").
+		PlaceholderFormat(format).
+		RunWith(l.DB).
+		QueryRowContext(r.Context()).
+		Scan(&total)
+	if err != nil {
+		return
+	}
+
+	// Add sorting.
+	for _, s := range p.Sort {
+		var column string
+
+		column, err = l.columnName(s.Field)
+		if err != nil {
+			err = ParamError{Param: SortParam, Value: s.Field}
+			return
+		}
+
+		if s.Desc {
+			column = fmt.Sprintf("%s DESC", column)
+		}
+		query = query.OrderBy(column)
+	}
+
+	// Query one page.
+	rows, err = query.
+		Limit(p.PageSize).
+		Offset((p.Page - 1) * p.PageSize).
+		PlaceholderFormat(format).
+		RunWith(l.DB).
+		QueryContext(r.Context())
+
+	return
+}
+
+// columnName returns the column name of a field.
+func (l Lister) columnName(s string) (string, error) {
+	if _, ok := l.Schema[s]; !ok {
+		return s, validate.UnknownFieldError{Field: s}
+	}
+	if c, ok := l.Columns[s]; ok {
+		return c, nil
+	}
+
+	return s, nil
+}
@@ -0,0 +1,92 @@
+// Copyright 2018 Yaacov Zamir <kobi.zamir@gmail.com>
+// and other contributors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rest
+
+import (
+	"database/sql"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	sq "github.com/Masterminds/squirrel"
+	_ "github.com/mattn/go-sqlite3"
+
+	"github.com/yaacov/tree-search-language/pkg/validate"
+)
+
+func prepareDB(t *testing.T) *sql.DB {
+	db, err := sql.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = db.Exec(`
+		create table books (title text, pages integer);
+		insert into books values ('a', 10), ('b', 20), ('c', 30), ('d', 40), ('e', 50);
+	`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return db
+}
+
+func TestList(t *testing.T) {
+	db := prepareDB(t)
+	defer db.Close()
+
+	lister := Lister{
+		DB:    db,
+		Query: sq.Select("title").From("books"),
+		Schema: validate.Schema{
+			"title":      {Type: validate.String},
+			"spec.pages": {Type: validate.Number},
+		},
+		Columns:         map[string]string{"spec.pages": "pages"},
+		DefaultPageSize: 2,
+	}
+
+	q := url.Values{}
+	q.Set(FilterParam, "spec.pages > 10")
+	q.Set(SortParam, "-spec.pages")
+	q.Set(PageParam, "2")
+	r := httptest.NewRequest("GET", "/books?"+q.Encode(), nil)
+
+	rows, total, err := lister.List(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rows.Close()
+
+	titles := ""
+	for rows.Next() {
+		var title string
+		rows.Scan(&title)
+		titles += title
+	}
+
+	if total != 4 || titles != "cb" {
+		t.Errorf("expected 4 rows and page \"cb\" instead it was %d and %q", total, titles)
+	}
+
+	// Bad parameters.
+	for _, query := range []string{"page=0", "sort=pages", "filter=pages>1", "page_size=1000"} {
+		r = httptest.NewRequest("GET", "/books?"+query, nil)
+		if _, _, err = lister.List(r); err == nil {
+			t.Errorf("%s: expected an error", query)
+		}
+	}
+}
@@ -0,0 +1,99 @@
+// Copyright 2018 Yaacov Zamir <kobi.zamir@gmail.com>
+// and other contributors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rest
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/yaacov/tree-search-language/pkg/integrations/httpfilter"
+)
+
+// List query parameter names.
+const (
+	FilterParam   = httpfilter.FilterParam
+	SortParam     = "sort"
+	PageParam     = "page"
+	PageSizeParam = "page_size"
+)
+
+// Default page sizes.
+const (
+	DefaultPageSize = 20
+	MaxPageSize     = 100
+)
+
+// SortField is one field of the sort parameter.
+type SortField struct {
+	Field string
+	Desc  bool
+}
+
+// Params are the parsed list query parameters.
+type Params struct {
+	Filter   string
+	Sort     []SortField
+	Page     uint64 // the page number, first page is 1.
+	PageSize uint64
+}
+
+// ParseParams reads the list query parameters of a request.
+//
+// The sort parameter is a comma separated list of fields, fields prefixed by
+// a minus sign are sorted in descending order (e.g. "-spec.pages,title").
+func ParseParams(r *http.Request, defaultPageSize uint64, maxPageSize uint64) (p Params, err error) {
+	q := r.URL.Query()
+
+	p.Filter = q.Get(FilterParam)
+	p.Page = 1
+	p.PageSize = defaultPageSize
+
+	// Parse sort fields.
+	if s := q.Get(SortParam); s != "" {
+		for _, f := range strings.Split(s, ",") {
+			f = strings.TrimSpace(f)
+			desc := strings.HasPrefix(f, "-")
+			f = strings.TrimLeft(f, "+-")
+
+			if f == "" {
+				err = ParamError{Param: SortParam, Value: s}
+				return
+			}
+			p.Sort = append(p.Sort, SortField{Field: f, Desc: desc})
+		}
+	}
+
+	// Parse page number.
+	if s := q.Get(PageParam); s != "" {
+		p.Page, err = strconv.ParseUint(s, 10, 64)
+		if err != nil || p.Page < 1 {
+			err = ParamError{Param: PageParam, Value: s}
+			return
+		}
+	}
+
+	// Parse page size.
+	if s := q.Get(PageSizeParam); s != "" {
+		p.PageSize, err = strconv.ParseUint(s, 10, 64)
+		if err != nil || p.PageSize < 1 || p.PageSize > maxPageSize {
+			err = ParamError{Param: PageSizeParam, Value: s}
+			return
+		}
+	}
+
+	return
+}
@@ -0,0 +1,55 @@
+// Copyright 2018 Yaacov Zamir <kobi.zamir@gmail.com>
+// and other contributors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rls
+
+import (
+	"context"
+	"database/sql"
+)
+
+// DB wraps a database handle, rewriting queries using a Rewriter.
+//
+// DB does not embed the wrapped handle, so queries can not bypass the
+// rewriter by mistake.
+type DB struct {
+	db       *sql.DB
+	rewriter Rewriter
+}
+
+// NewDB returns a database handle rewriting queries using r.
+func NewDB(db *sql.DB, r Rewriter) *DB {
+	return &DB{db: db, rewriter: r}
+}
+
+// QueryContext executes a rewritten query that returns rows.
+func (db *DB) QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	query, args, err := db.rewriter.Rewrite(ctx, query, args)
+	if err != nil {
+		return nil, err
+	}
+
+	return db.db.QueryContext(ctx, query, args...)
+}
+
+// ExecContext executes a rewritten query without returning any rows.
+func (db *DB) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	query, args, err := db.rewriter.Rewrite(ctx, query, args)
+	if err != nil {
+		return nil, err
+	}
+
+	return db.db.ExecContext(ctx, query, args...)
+}
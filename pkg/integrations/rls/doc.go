@@ -0,0 +1,45 @@
+// Copyright 2018 Yaacov Zamir <kobi.zamir@gmail.com>
+// and other contributors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package rls implements application-side row-level security for database/sql.
+//
+// SELECT queries are rewritten so that rows of configured tables are filtered
+// using per-user TSL predicates stored in the query context, each reference
+// to a configured table is replaced by a filtered derived table:
+//
+//   SELECT title FROM books b WHERE b.pages > ?
+//
+// becomes
+//
+//   SELECT title FROM (SELECT * FROM books WHERE owner = ?) AS b WHERE b.pages > ?
+//
+// Usage:
+//   db := rls.NewDB(sqlDB, rls.Rewriter{Tables: []string{"books"}})
+//
+//   // Store the user predicates in the request context.
+//   tree, err := tsl.ParseTSL("owner = 'joe'")
+//   ctx = rls.NewContext(ctx, map[string]tsl.Node{"books": tree})
+//
+//   rows, err := db.QueryContext(ctx, "SELECT title FROM books")
+//
+// Queries reading a configured table without a predicate in the context are
+// denied. Table names are matched by the last part of qualified names, so
+// `public.books` is the `books` table, and SELECT queries that reference a
+// configured table in a place that is not rewritten are denied. Only SELECT
+// (and WITH) queries are rewritten, other statements, like `EXPLAIN SELECT`,
+// `(SELECT ...)` or `DELETE`, that reference a configured table are denied,
+// writes to configured tables use the wrapped handle.
+//
+package rls
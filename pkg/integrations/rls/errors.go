@@ -0,0 +1,38 @@
+// Copyright 2018 Yaacov Zamir <kobi.zamir@gmail.com>
+// and other contributors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rls
+
+import "fmt"
+
+// MissingPredicateError is raised when a query reads a table with row-level
+// security, and the query context has no predicate for the table.
+type MissingPredicateError struct {
+	Table string // the table name.
+}
+
+func (e MissingPredicateError) Error() string {
+	return fmt.Sprintf("missing row predicate for table: %s", e.Table)
+}
+
+// UnfilteredTableError is raised when a query references a table with
+// row-level security in a way the rewriter can not filter.
+type UnfilteredTableError struct {
+	Table string // the table name.
+}
+
+func (e UnfilteredTableError) Error() string {
+	return fmt.Sprintf("unfiltered reference to row-level security table: %s", e.Table)
+}
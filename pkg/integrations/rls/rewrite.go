@@ -0,0 +1,227 @@
+// Copyright 2018 Yaacov Zamir <kobi.zamir@gmail.com>
+// and other contributors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rls
+
+import (
+	"context"
+	"strings"
+
+	sq "github.com/Masterminds/squirrel"
+
+	"github.com/yaacov/tree-search-language/pkg/tsl"
+	walker "github.com/yaacov/tree-search-language/pkg/walkers/sql"
+)
+
+// contextKey is the type of the context key of the predicates.
+type contextKey struct{}
+
+// NewContext returns a new context carrying the row predicates of tables.
+func NewContext(ctx context.Context, predicates map[string]tsl.Node) context.Context {
+	return context.WithValue(ctx, contextKey{}, predicates)
+}
+
+// FromContext returns the row predicates stored in a context, if any.
+func FromContext(ctx context.Context) (map[string]tsl.Node, bool) {
+	predicates, ok := ctx.Value(contextKey{}).(map[string]tsl.Node)
+	return predicates, ok
+}
+
+// notAlias are keywords that may follow a table name in a FROM clause.
+var notAlias = map[string]bool{
+	"WHERE": true, "JOIN": true, "INNER": true, "LEFT": true, "RIGHT": true,
+	"FULL": true, "CROSS": true, "OUTER": true, "NATURAL": true, "ON": true,
+	"USING": true, "GROUP": true, "ORDER": true, "LIMIT": true, "OFFSET": true,
+	"HAVING": true, "UNION": true, "INTERSECT": true, "EXCEPT": true,
+	"WINDOW": true, "FOR": true, "FETCH": true,
+}
+
+// tablePrefix are keywords that may precede a table name in a FROM clause.
+var tablePrefix = map[string]bool{
+	"LATERAL": true, "ONLY": true,
+}
+
+// Rewriter adds row predicates to SELECT queries.
+type Rewriter struct {
+	// Tables are the table names with row-level security.
+	Tables []string
+
+	// PlaceholderFormat is the placeholder style of the queries, defaults to
+	// sq.Question. Predicate arguments of numbered placeholder styles (e.g.
+	// sq.Dollar) are added after the query arguments.
+	PlaceholderFormat sq.PlaceholderFormat
+}
+
+// Rewrite adds the row predicates of the context to a query.
+func (r Rewriter) Rewrite(ctx context.Context, query string, args []interface{}) (string, []interface{}, error) {
+	tokens := tokenize(query)
+
+	// Check for a SELECT query, other statements are not rewritten, and must
+	// not reference tables with row-level security.
+	first := nextToken(tokens, 0)
+	if first < 0 || (!tokens[first].is("SELECT") && !tokens[first].is("WITH")) {
+		if err := r.unfiltered(tokens, nil); err != nil {
+			return "", nil, err
+		}
+		return query, args, nil
+	}
+
+	predicates, _ := FromContext(ctx)
+	numbered := r.numbered()
+
+	var b strings.Builder
+	var out []interface{}
+	used := 0                 // number of query arguments added to out.
+	expect := false           // expecting a table name.
+	inFrom := false           // inside a FROM list.
+	handled := map[int]bool{} // the indexes of rewritten tables and their aliases.
+
+	for i := 0; i < len(tokens); i++ {
+		t := tokens[i]
+
+		// Keep the query arguments in placeholder order.
+		if t.kind == placeholder && !numbered && used < len(args) {
+			out = append(out, args[used])
+			used++
+		}
+
+		switch {
+		case t.kind == space || t.kind == comment:
+			b.WriteString(t.text)
+			continue
+		case t.is("FROM") || t.is("JOIN"):
+			expect, inFrom = true, t.is("FROM")
+			b.WriteString(t.text)
+			continue
+		case t.kind == punct && t.text == "," && inFrom:
+			expect = true
+			b.WriteString(t.text)
+			continue
+		case expect && ((t.kind == punct && t.text == "(") || (t.kind == word && tablePrefix[strings.ToUpper(t.text)])):
+			// A table name may follow, e.g. `FROM (books JOIN ...` or `JOIN LATERAL books`.
+			b.WriteString(t.text)
+			continue
+		case !expect || (t.kind != word && t.kind != quoted):
+			if t.kind == punct || (t.kind == word && notAlias[strings.ToUpper(t.text)]) {
+				inFrom = false
+			}
+			expect = false
+			b.WriteString(t.text)
+			continue
+		}
+		expect = false
+
+		// Check for a table with row-level security.
+		table, ok := r.table(t.name())
+		if !ok {
+			b.WriteString(t.text)
+			continue
+		}
+
+		tree, ok := predicates[table]
+		if !ok {
+			return "", nil, MissingPredicateError{Table: table}
+		}
+
+		// Read the table alias, if any.
+		alias := t.last
+		handled[i] = true
+		if j := nextToken(tokens, i+1); j > 0 && tokens[j].is("AS") {
+			if k := nextToken(tokens, j+1); k > 0 && tokens[k].isName() {
+				alias, i = tokens[k].text, k
+			}
+		} else if j > 0 && tokens[j].isName() && !notAlias[strings.ToUpper(tokens[j].text)] {
+			alias, i = tokens[j].text, j
+		}
+
+		// Build the predicate, numbered placeholders follow the query arguments.
+		where, whereArgs, err := r.predicate(tree, len(args)+len(out))
+		if err != nil {
+			return "", nil, err
+		}
+		out = append(out, whereArgs...)
+
+		b.WriteString("(SELECT * FROM " + t.text + " WHERE " + where + ") AS " + alias)
+		handled[i] = true
+	}
+
+	// Fail closed, deny queries referencing a table that was not rewritten.
+	if err := r.unfiltered(tokens, handled); err != nil {
+		return "", nil, err
+	}
+
+	// Predicate arguments of numbered placeholders follow the query arguments.
+	if numbered {
+		return b.String(), append(append([]interface{}{}, args...), out...), nil
+	}
+
+	return b.String(), append(out, args[used:]...), nil
+}
+
+// unfiltered returns an error if a token references a table with row-level
+// security, other than the handled tokens.
+func (r Rewriter) unfiltered(tokens []token, handled map[int]bool) error {
+	for i, t := range tokens {
+		if table, ok := r.table(t.name()); t.isName() && ok && !handled[i] {
+			return UnfilteredTableError{Table: table}
+		}
+	}
+
+	return nil
+}
+
+// numbered checks if the placeholder style is numbered.
+func (r Rewriter) numbered() bool {
+	return r.PlaceholderFormat != nil && r.PlaceholderFormat != sq.Question
+}
+
+// table returns the configured table matching a name.
+func (r Rewriter) table(name string) (string, bool) {
+	for _, table := range r.Tables {
+		if strings.EqualFold(table, name) {
+			return table, true
+		}
+	}
+
+	return "", false
+}
+
+// predicate returns the SQL of a tree, numbered placeholders start after n
+// arguments.
+func (r Rewriter) predicate(tree tsl.Node, n int) (where string, args []interface{}, err error) {
+	filter, err := walker.Walk(tree)
+	if err != nil {
+		return
+	}
+
+	where, args, err = filter.ToSql()
+	if err != nil || !r.numbered() {
+		return
+	}
+
+	// Number the placeholders after the preceding arguments.
+	prefix := strings.Repeat("?,", n)
+	formattedPrefix, err := r.PlaceholderFormat.ReplacePlaceholders(prefix)
+	if err != nil {
+		return
+	}
+	where, err = r.PlaceholderFormat.ReplacePlaceholders(prefix + where)
+	if err != nil {
+		return
+	}
+	where = where[len(formattedPrefix):]
+
+	return
+}
@@ -0,0 +1,181 @@
+// Copyright 2018 Yaacov Zamir <kobi.zamir@gmail.com>
+// and other contributors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rls
+
+import (
+	"context"
+	"database/sql"
+	"reflect"
+	"testing"
+
+	sq "github.com/Masterminds/squirrel"
+	_ "github.com/mattn/go-sqlite3"
+
+	"github.com/yaacov/tree-search-language/pkg/tsl"
+)
+
+func TestRewrite(t *testing.T) {
+	owner, _ := tsl.ParseTSL("owner = 'joe'")
+	public, _ := tsl.ParseTSL("public = 1")
+	ctx := NewContext(context.Background(), map[string]tsl.Node{"books": owner, "notes": public})
+
+	tests := []struct {
+		format    sq.PlaceholderFormat
+		query     string
+		args      []interface{}
+		wantQuery string
+		wantArgs  []interface{}
+	}{
+		{
+			query:     "SELECT title FROM books WHERE pages > ?",
+			args:      []interface{}{100},
+			wantQuery: "SELECT title FROM (SELECT * FROM books WHERE owner = ?) AS books WHERE pages > ?",
+			wantArgs:  []interface{}{"joe", 100},
+		},
+		{
+			query:     "select b.title from authors a join books as b on a.id = b.author_id where a.name = ?",
+			args:      []interface{}{"jane"},
+			wantQuery: "select b.title from authors a join (SELECT * FROM books WHERE owner = ?) AS b on a.id = b.author_id where a.name = ?",
+			wantArgs:  []interface{}{"joe", "jane"},
+		},
+		{
+			query:     "SELECT * FROM Books b, notes WHERE b.title = 'from books' AND notes.id = ?",
+			args:      []interface{}{1},
+			wantQuery: "SELECT * FROM (SELECT * FROM Books WHERE owner = ?) AS b, (SELECT * FROM notes WHERE public = ?) AS notes WHERE b.title = 'from books' AND notes.id = ?",
+			wantArgs:  []interface{}{"joe", float64(1), 1},
+		},
+		{
+			format:    sq.Dollar,
+			query:     "SELECT title FROM books WHERE pages > $1",
+			args:      []interface{}{100},
+			wantQuery: "SELECT title FROM (SELECT * FROM books WHERE owner = $2) AS books WHERE pages > $1",
+			wantArgs:  []interface{}{100, "joe"},
+		},
+		{
+			query:     "SELECT * FROM public.books",
+			wantQuery: "SELECT * FROM (SELECT * FROM public.books WHERE owner = ?) AS books",
+			wantArgs:  []interface{}{"joe"},
+		},
+		{
+			query:     `SELECT * FROM "public"."books" b`,
+			wantQuery: `SELECT * FROM (SELECT * FROM "public"."books" WHERE owner = ?) AS b`,
+			wantArgs:  []interface{}{"joe"},
+		},
+		{
+			query:     "SELECT * FROM/**/books",
+			wantQuery: "SELECT * FROM/**/(SELECT * FROM books WHERE owner = ?) AS books",
+			wantArgs:  []interface{}{"joe"},
+		},
+		{
+			query:     "SELECT * FROM (books JOIN notes ON books.id = notes.book_id)",
+			wantQuery: "SELECT * FROM ((SELECT * FROM books WHERE owner = ?) AS books JOIN (SELECT * FROM notes WHERE public = ?) AS notes ON books.id = notes.book_id)",
+			wantArgs:  []interface{}{"joe", float64(1)},
+		},
+		{
+			query:     "SELECT * FROM notes n JOIN LATERAL books ON true",
+			wantQuery: "SELECT * FROM (SELECT * FROM notes WHERE public = ?) AS n JOIN LATERAL (SELECT * FROM books WHERE owner = ?) AS books ON true",
+			wantArgs:  []interface{}{float64(1), "joe"},
+		},
+		{
+			query:     "UPDATE authors SET name = ?",
+			args:      []interface{}{"x"},
+			wantQuery: "UPDATE authors SET name = ?",
+			wantArgs:  []interface{}{"x"},
+		},
+	}
+
+	for _, tt := range tests {
+		r := Rewriter{Tables: []string{"books", "notes"}, PlaceholderFormat: tt.format}
+
+		query, args, err := r.Rewrite(ctx, tt.query, tt.args)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if query != tt.wantQuery {
+			t.Errorf("Rewrite(%q) = %q, want %q", tt.query, query, tt.wantQuery)
+		}
+		if !reflect.DeepEqual(args, tt.wantArgs) {
+			t.Errorf("Rewrite(%q) args = %v, want %v", tt.query, args, tt.wantArgs)
+		}
+	}
+
+	// Tables without a predicate are denied.
+	r := Rewriter{Tables: []string{"secrets"}}
+	if _, _, err := r.Rewrite(ctx, "SELECT * FROM secrets", nil); err == nil {
+		t.Error("Rewrite() error = nil, want missing predicate error")
+	}
+
+	// Table references that are not rewritten are denied.
+	r = Rewriter{Tables: []string{"books"}}
+	for _, query := range []string{
+		"SELECT * FROM (SELECT 1) AS a, books",
+		"SELECT * FROM a UNION TABLE books",
+		"WITH x AS (SELECT 1) SELECT * FROM x, (SELECT 1) y, books",
+		"(SELECT * FROM books)",
+		"((SELECT title FROM books))",
+		"EXPLAIN SELECT * FROM books",
+		"EXPLAIN ANALYZE SELECT * FROM \"books\"",
+		"DELETE FROM books",
+		"UPDATE books SET title = ?",
+	} {
+		if _, _, err := r.Rewrite(ctx, query, nil); err != (UnfilteredTableError{Table: "books"}) {
+			t.Errorf("Rewrite(%q) error = %v, want unfiltered table error", query, err)
+		}
+	}
+}
+
+func TestDB(t *testing.T) {
+	sqlDB, err := sql.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer sqlDB.Close()
+
+	db := NewDB(sqlDB, Rewriter{Tables: []string{"books"}})
+	ctx := context.Background()
+
+	if _, err := sqlDB.ExecContext(ctx, "CREATE TABLE books (title TEXT, owner TEXT)"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := sqlDB.ExecContext(ctx, "INSERT INTO books VALUES ('a', 'joe'), ('b', 'jane'), ('c', 'joe')"); err != nil {
+		t.Fatal(err)
+	}
+
+	// Statements that are not rewritten can not reference configured tables.
+	if _, err := db.ExecContext(ctx, "DELETE FROM books"); err != (UnfilteredTableError{Table: "books"}) {
+		t.Errorf("ExecContext() error = %v, want unfiltered table error", err)
+	}
+
+	tree, _ := tsl.ParseTSL("owner = 'joe'")
+	rows, err := db.QueryContext(NewContext(ctx, map[string]tsl.Node{"books": tree}), "SELECT title FROM books ORDER BY title")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rows.Close()
+
+	titles := []string{}
+	for rows.Next() {
+		var title string
+		if err := rows.Scan(&title); err != nil {
+			t.Fatal(err)
+		}
+		titles = append(titles, title)
+	}
+
+	if !reflect.DeepEqual(titles, []string{"a", "c"}) {
+		t.Errorf("QueryContext() titles = %v, want [a c]", titles)
+	}
+}
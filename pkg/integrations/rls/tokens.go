@@ -0,0 +1,170 @@
+// Copyright 2018 Yaacov Zamir <kobi.zamir@gmail.com>
+// and other contributors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rls
+
+import (
+	"strings"
+	"unicode"
+)
+
+// Token kinds.
+const (
+	space = iota
+	word
+	quoted
+	str
+	comment
+	placeholder
+	punct
+)
+
+// token is a lexical token of an SQL query.
+type token struct {
+	kind int
+	text string
+	last string // the last part of a qualified name, e.g. `books` of `public.books`.
+}
+
+// is checks if a token is a keyword.
+func (t token) is(keyword string) bool {
+	return t.kind == word && strings.EqualFold(t.text, keyword)
+}
+
+// isName checks if a token can be a table name or alias.
+func (t token) isName() bool {
+	return t.kind == word || t.kind == quoted
+}
+
+// name returns the unquoted last part of a word or quoted identifier.
+func (t token) name() string {
+	if l := len(t.last); l > 1 && isQuote(rune(t.last[0])) {
+		q := t.last[:1]
+		return strings.Replace(t.last[1:l-1], q+q, q, -1)
+	}
+
+	return t.last
+}
+
+// isQuote checks if a rune quotes an identifier.
+func isQuote(r rune) bool {
+	return r == '"' || r == '`'
+}
+
+// isWordRune checks if a rune is part of a word.
+func isWordRune(r rune) bool {
+	return r == '_' || r == '.' || r == '$' || unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
+// tokenize splits an SQL query into tokens, the tokens text joins back into
+// the query.
+func tokenize(query string) (tokens []token) {
+	runes := []rune(query)
+
+	for i := 0; i < len(runes); {
+		start := i
+		last := i
+		kind := punct
+
+		switch r := runes[i]; {
+		case unicode.IsSpace(r):
+			kind = space
+			for i < len(runes) && unicode.IsSpace(runes[i]) {
+				i++
+			}
+		case isWordRune(r) || isQuote(r):
+			kind = word
+			for {
+				last = i
+				if isQuote(runes[i]) {
+					kind = quoted
+					i = skipQuoted(runes, i)
+				} else {
+					for i < len(runes) && isWordRune(runes[i]) {
+						if runes[i] == '.' {
+							last = i + 1
+						}
+						i++
+					}
+				}
+
+				// Join the parts of qualified names, e.g. `public."books"`.
+				if i < len(runes) && runes[i-1] == '.' && isQuote(runes[i]) {
+					continue
+				}
+				if i+1 < len(runes) && runes[i] == '.' && isQuote(runes[i-1]) && (isQuote(runes[i+1]) || isWordRune(runes[i+1])) {
+					i++
+					continue
+				}
+				break
+			}
+		case r == '\'':
+			kind = str
+			i = skipQuoted(runes, i)
+		case r == '-' && i+1 < len(runes) && runes[i+1] == '-':
+			kind = comment
+			for i < len(runes) && runes[i] != '\n' {
+				i++
+			}
+		case r == '/' && i+1 < len(runes) && runes[i+1] == '*':
+			// Block comments end with the first `*/`.
+			kind = comment
+			for i += 2; i < len(runes); i++ {
+				if runes[i-1] == '*' && runes[i] == '/' && i > start+2 {
+					i++
+					break
+				}
+			}
+		case r == '?':
+			kind = placeholder
+			i++
+		default:
+			i++
+		}
+
+		tokens = append(tokens, token{kind: kind, text: string(runes[start:i]), last: string(runes[last:i])})
+	}
+
+	return
+}
+
+// skipQuoted returns the index following a quoted string or identifier
+// starting at i, doubled quotes are escaped quotes.
+func skipQuoted(runes []rune, i int) int {
+	q := runes[i]
+	for i++; i < len(runes); i++ {
+		if runes[i] == q {
+			if i+1 < len(runes) && runes[i+1] == q {
+				i++
+				continue
+			}
+			return i + 1
+		}
+	}
+
+	return i
+}
+
+// nextToken returns the index of the first token from i that is not a space
+// or a comment, or -1 if none.
+func nextToken(tokens []token, i int) int {
+	for ; i < len(tokens); i++ {
+		if tokens[i].kind != space && tokens[i].kind != comment {
+			return i
+		}
+	}
+
+	return -1
+}
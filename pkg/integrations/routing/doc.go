@@ -0,0 +1,40 @@
+// Copyright 2018 Yaacov Zamir <kobi.zamir@gmail.com>
+// and other contributors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package routing routes JSON payloads using TSL rules.
+//
+// Each rule is a TSL filter plus an action, rules are evaluated in priority
+// order against incoming payloads. Matching tag rules add a tag and
+// evaluation continues, the first matching route or drop rule ends the
+// evaluation. Rules can be hot-reloaded from a YAML or JSON file, or using
+// an HTTP API.
+//
+// Usage:
+//   r, err := routing.NewRouter([]routing.Rule{
+//     {Name: "tests", Priority: 1, Filter: "env = 'test'", Action: routing.Drop},
+//     {Name: "vip", Priority: 2, Filter: "spec.total > 1000", Action: routing.Tag, Target: "vip"},
+//     {Name: "orders", Priority: 3, Filter: "type = 'order'", Action: routing.Route, Target: "orders"},
+//   })
+//
+//   result, err := r.Route([]byte(`{"type": "order", "spec": {"total": 1500}}`))
+//   // result.Target: "orders", result.Tags: [vip]
+//
+//   // Reload the rules when the file changes.
+//   go r.Watch(ctx, "rules.yaml", 10*time.Second, onError)
+//
+//   // Read and replace the rules using GET and PUT requests.
+//   http.Handle("/rules", r.Handler())
+//
+package routing
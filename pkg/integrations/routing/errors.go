@@ -0,0 +1,46 @@
+// Copyright 2018 Yaacov Zamir <kobi.zamir@gmail.com>
+// and other contributors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package routing
+
+import "fmt"
+
+// RuleError is raised when a rule fails to parse or evaluate.
+type RuleError struct {
+	Rule string // the rule name.
+	Err  error  // the rule error.
+}
+
+func (e RuleError) Error() string {
+	return fmt.Sprintf("rule %s: %v", e.Rule, e.Err)
+}
+
+// ActionError is raised when a rule has an unknown action.
+type ActionError struct {
+	Action Action // the unknown action.
+}
+
+func (e ActionError) Error() string {
+	return fmt.Sprintf("unknown action: %s", e.Action)
+}
+
+// MissingTargetError is raised when a route or tag rule has no target.
+type MissingTargetError struct {
+	Action Action // the rule action.
+}
+
+func (e MissingTargetError) Error() string {
+	return fmt.Sprintf("missing %s target", e.Action)
+}
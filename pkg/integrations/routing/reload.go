@@ -0,0 +1,113 @@
+// Copyright 2018 Yaacov Zamir <kobi.zamir@gmail.com>
+// and other contributors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package routing
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"time"
+
+	yaml "gopkg.in/yaml.v2"
+)
+
+// maxRulesSize is the maximum size of a rules request body.
+const maxRulesSize = 1 << 20
+
+// ParseRules parses a YAML or JSON list of rules.
+func ParseRules(data []byte) (rules []Rule, err error) {
+	err = yaml.Unmarshal(data, &rules)
+	return
+}
+
+// LoadFile reads a YAML or JSON rules file and replaces the router rules.
+func (r *Router) LoadFile(path string) error {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	rules, err := ParseRules(data)
+	if err != nil {
+		return err
+	}
+
+	return r.Load(rules)
+}
+
+// Watch reloads a rules file when its modification time changes, checking
+// the file every interval until the context is done.
+//
+// Reload errors are passed to onError, if not nil, and the router keeps its
+// current rules.
+func (r *Router) Watch(ctx context.Context, path string, interval time.Duration, onError func(error)) {
+	var modTime time.Time
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		// Check for a changed file.
+		info, err := os.Stat(path)
+		if err == nil && !info.ModTime().Equal(modTime) {
+			modTime = info.ModTime()
+			err = r.LoadFile(path)
+		}
+		if err != nil && onError != nil {
+			onError(err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Handler returns an HTTP handler of the router rules, GET requests return
+// the rules, and PUT requests with a YAML or JSON list of rules replace them.
+func (r *Router) Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		switch req.Method {
+		case http.MethodGet:
+		case http.MethodPut:
+			data, err := ioutil.ReadAll(http.MaxBytesReader(w, req.Body, maxRulesSize))
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+
+			rules, err := ParseRules(data)
+			if err == nil {
+				err = r.Load(rules)
+			}
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+		default:
+			w.Header().Set("Allow", "GET, PUT")
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(r.Rules())
+	})
+}
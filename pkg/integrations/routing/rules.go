@@ -0,0 +1,166 @@
+// Copyright 2018 Yaacov Zamir <kobi.zamir@gmail.com>
+// and other contributors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package routing
+
+import (
+	"encoding/json"
+	"sort"
+	"sync/atomic"
+
+	"github.com/yaacov/tree-search-language/pkg/integrations/stream"
+	"github.com/yaacov/tree-search-language/pkg/tsl"
+	"github.com/yaacov/tree-search-language/pkg/walkers/semantics"
+)
+
+// Action is the action of a rule.
+type Action string
+
+// Rule actions.
+const (
+	Route Action = "route" // Route the payload to the rule target, ends evaluation.
+	Drop  Action = "drop"  // Drop the payload, ends evaluation.
+	Tag   Action = "tag"   // Tag the payload with the rule target.
+)
+
+// Rule is a TSL filter plus an action.
+type Rule struct {
+	Name     string `json:"name" yaml:"name"`
+	Priority int    `json:"priority" yaml:"priority"` // lower priorities are evaluated first.
+	Filter   string `json:"filter" yaml:"filter"`     // a TSL phrase.
+	Action   Action `json:"action" yaml:"action"`
+	Target   string `json:"target,omitempty" yaml:"target,omitempty"` // the route or tag.
+}
+
+// Result is the result of routing a payload.
+type Result struct {
+	Target  string   `json:"target,omitempty"`  // the route target, empty if no route rule matched.
+	Dropped bool     `json:"dropped,omitempty"` // true if a drop rule matched.
+	Tags    []string `json:"tags,omitempty"`    // the tags of matching tag rules.
+	Rule    string   `json:"rule,omitempty"`    // the name of the rule ending the evaluation.
+}
+
+// compiledRule is a rule and its parsed filter.
+type compiledRule struct {
+	Rule
+	tree tsl.Node
+}
+
+// Router evaluates rules against JSON payloads, it is safe for concurrent
+// use, and the rules can be replaced while routing.
+type Router struct {
+	rules atomic.Value // []compiledRule
+}
+
+// NewRouter creates a router.
+func NewRouter(rules []Rule) (*Router, error) {
+	r := &Router{}
+	if err := r.Load(rules); err != nil {
+		return nil, err
+	}
+
+	return r, nil
+}
+
+// Load checks rules and replaces the router rules, on error the router rules
+// are not changed.
+func (r *Router) Load(rules []Rule) error {
+	compiled := make([]compiledRule, 0, len(rules))
+
+	for _, rule := range rules {
+		tree, err := tsl.ParseTSL(rule.Filter)
+		if err != nil {
+			return RuleError{Rule: rule.Name, Err: err}
+		}
+
+		// Check the action.
+		switch rule.Action {
+		case Route, Tag:
+			if rule.Target == "" {
+				return RuleError{Rule: rule.Name, Err: MissingTargetError{Action: rule.Action}}
+			}
+		case Drop:
+		default:
+			return RuleError{Rule: rule.Name, Err: ActionError{Action: rule.Action}}
+		}
+
+		compiled = append(compiled, compiledRule{Rule: rule, tree: tree})
+	}
+
+	// Sort by priority, keeping the order of rules with the same priority.
+	sort.SliceStable(compiled, func(i, j int) bool {
+		return compiled[i].Priority < compiled[j].Priority
+	})
+
+	r.rules.Store(compiled)
+	return nil
+}
+
+// Rules returns the router rules in evaluation order.
+func (r *Router) Rules() []Rule {
+	compiled, _ := r.rules.Load().([]compiledRule)
+
+	rules := make([]Rule, len(compiled))
+	for i, c := range compiled {
+		rules[i] = c.Rule
+	}
+
+	return rules
+}
+
+// Route decodes a JSON object payload and evaluates the rules against it.
+//
+// Nested objects are accessed using dot separated identifiers, for example
+// `spec.total` is the `total` key of the `spec` object.
+func (r *Router) Route(payload []byte) (result Result, err error) {
+	var doc map[string]interface{}
+
+	if err = json.Unmarshal(payload, &doc); err != nil {
+		return
+	}
+
+	return r.RouteDoc(stream.EvalFactory(doc))
+}
+
+// RouteDoc evaluates the rules against a document.
+func (r *Router) RouteDoc(eval semantics.EvalFunc) (result Result, err error) {
+	compiled, _ := r.rules.Load().([]compiledRule)
+
+	for _, c := range compiled {
+		var match bool
+
+		match, err = semantics.Walk(c.tree, eval)
+		if err != nil {
+			err = RuleError{Rule: c.Name, Err: err}
+			return
+		}
+		if !match {
+			continue
+		}
+
+		switch c.Action {
+		case Tag:
+			result.Tags = append(result.Tags, c.Target)
+		case Route:
+			result.Target, result.Rule = c.Target, c.Name
+			return
+		case Drop:
+			result.Dropped, result.Rule = true, c.Name
+			return
+		}
+	}
+
+	return
+}
@@ -0,0 +1,135 @@
+// Copyright 2018 Yaacov Zamir <kobi.zamir@gmail.com>
+// and other contributors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package routing
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+const rulesYAML = `
+- name: orders
+  priority: 3
+  filter: type = 'order'
+  action: route
+  target: orders
+- name: vip
+  priority: 2
+  filter: spec.total > 1000
+  action: tag
+  target: vip
+- name: tests
+  priority: 1
+  filter: env = 'test'
+  action: drop
+`
+
+func TestRouter(t *testing.T) {
+	rules, err := ParseRules([]byte(rulesYAML))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	r, err := NewRouter(rules)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		payload string
+		want    Result
+	}{
+		{`{"type": "order", "spec": {"total": 1500}}`, Result{Target: "orders", Tags: []string{"vip"}, Rule: "orders"}},
+		{`{"type": "order", "env": "test"}`, Result{Dropped: true, Rule: "tests"}},
+		{`{"type": "refund", "spec": {"total": 10}}`, Result{}},
+	}
+
+	for _, tt := range tests {
+		got, err := r.Route([]byte(tt.payload))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("Route(%s) = %+v, want %+v", tt.payload, got, tt.want)
+		}
+	}
+
+	// Bad rules do not replace the current rules.
+	if err := r.Load([]Rule{{Name: "bad", Filter: "a = 1", Action: Route}}); err == nil {
+		t.Error("Load() error = nil, want missing target error")
+	}
+	if len(r.Rules()) != 3 {
+		t.Errorf("Rules() = %v, want 3 rules", r.Rules())
+	}
+}
+
+func TestHandler(t *testing.T) {
+	r, _ := NewRouter(nil)
+	h := r.Handler()
+
+	req := httptest.NewRequest(http.MethodPut, "/rules", strings.NewReader(`[{"name": "all", "filter": "a = 1", "action": "drop"}]`))
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("PUT status = %d, body %s", w.Code, w.Body)
+	}
+	if got, _ := r.Route([]byte(`{"a": 1}`)); !got.Dropped {
+		t.Errorf("Route() = %+v, want dropped", got)
+	}
+
+	req = httptest.NewRequest(http.MethodPut, "/rules", strings.NewReader(`[{"name": "bad", "filter": "a = ", "action": "drop"}]`))
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, req)
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("PUT status = %d, want %d", w.Code, http.StatusBadRequest)
+	}
+}
+
+func TestWatch(t *testing.T) {
+	dir, err := ioutil.TempDir("", "routing")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "rules.yaml")
+	if err := ioutil.WriteFile(path, []byte(rulesYAML), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	r, _ := NewRouter(nil)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	go r.Watch(ctx, path, 10*time.Millisecond, nil)
+
+	for i := 0; i < 100 && len(r.Rules()) == 0; i++ {
+		time.Sleep(10 * time.Millisecond)
+	}
+	if len(r.Rules()) != 3 {
+		t.Errorf("Rules() = %v, want 3 rules", r.Rules())
+	}
+}
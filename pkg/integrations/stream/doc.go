@@ -0,0 +1,31 @@
+// Copyright 2018 Yaacov Zamir <kobi.zamir@gmail.com>
+// and other contributors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package stream filters JSON messages using TSL.
+//
+// A Filter decodes JSON message payloads, evaluates a TSL tree against the
+// decoded document, and counts passed, dropped and failed messages. The
+// kafkafilter and natsfilter packages wrap Kafka readers and NATS
+// subscriptions, forwarding only matching messages.
+//
+// Usage:
+//   f, err := stream.NewFilter("type = 'order' and spec.total > 100")
+//
+//   ok, err := f.Match([]byte(`{"type": "order", "spec": {"total": 150}}`))
+//
+//   // Passed, dropped and failed messages.
+//   stats := f.Stats()
+//
+package stream
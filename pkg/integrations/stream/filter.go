@@ -0,0 +1,121 @@
+// Copyright 2018 Yaacov Zamir <kobi.zamir@gmail.com>
+// and other contributors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package stream
+
+import (
+	"encoding/json"
+	"sync/atomic"
+
+	"github.com/yaacov/tree-search-language/pkg/tsl"
+	"github.com/yaacov/tree-search-language/pkg/walkers/semantics"
+)
+
+// Stats holds the message counters of a filter.
+type Stats struct {
+	Passed  uint64 `json:"passed"`  // messages matching the filter.
+	Dropped uint64 `json:"dropped"` // messages not matching the filter.
+	Errors  uint64 `json:"errors"`  // messages that failed to decode or evaluate.
+}
+
+// Filter matches JSON message payloads against a TSL tree, it is safe for
+// concurrent use.
+type Filter struct {
+	tree  tsl.Node
+	match func(semantics.EvalFunc) (bool, error)
+
+	passed  uint64
+	dropped uint64
+	errors  uint64
+}
+
+// NewFilter parses a TSL phrase and returns a filter.
+func NewFilter(phrase string) (*Filter, error) {
+	tree, err := tsl.ParseTSL(phrase)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewTreeFilter(tree), nil
+}
+
+// NewTreeFilter returns a filter for a parsed TSL tree.
+func NewTreeFilter(tree tsl.Node) *Filter {
+	match, err := semantics.Compile(tree)
+	if err != nil {
+		// Trees that do not compile fail to evaluate.
+		match = func(semantics.EvalFunc) (bool, error) {
+			return false, err
+		}
+	}
+
+	return &Filter{tree: tree, match: match}
+}
+
+// Match decodes a JSON object payload and checks if it matches the filter.
+//
+// Nested objects are accessed using dot separated identifiers, for example
+// `spec.total` is the `total` key of the `spec` object.
+func (f *Filter) Match(payload []byte) (ok bool, err error) {
+	var doc map[string]interface{}
+
+	if err = json.Unmarshal(payload, &doc); err == nil {
+		ok, err = semantics.Walk(f.tree, EvalFactory(doc))
+	}
+
+	f.count(ok, err)
+	return
+}
+
+// MatchRaw checks if a JSON object payload matches the filter without
+// decoding it, field values are extracted from the raw payload using
+// semantics.JSONEval, evaluating the compiled tree.
+//
+// MatchRaw is faster than Match for large payloads, but payloads are not
+// validated, values that can not be extracted from a malformed payload are
+// missing.
+func (f *Filter) MatchRaw(payload []byte) (ok bool, err error) {
+	ok, err = f.match(semantics.JSONEval(payload))
+
+	f.count(ok, err)
+	return
+}
+
+// count updates the message counters with a match result.
+func (f *Filter) count(ok bool, err error) {
+	switch {
+	case err != nil:
+		atomic.AddUint64(&f.errors, 1)
+	case ok:
+		atomic.AddUint64(&f.passed, 1)
+	default:
+		atomic.AddUint64(&f.dropped, 1)
+	}
+}
+
+// Stats returns the filter message counters.
+func (f *Filter) Stats() Stats {
+	return Stats{
+		Passed:  atomic.LoadUint64(&f.passed),
+		Dropped: atomic.LoadUint64(&f.dropped),
+		Errors:  atomic.LoadUint64(&f.errors),
+	}
+}
+
+// EvalFactory creates an evaluation function for a decoded JSON document,
+// nested objects are accessed using dot separated identifiers.
+func EvalFactory(doc map[string]interface{}) semantics.EvalFunc {
+	return semantics.DocEval(doc, semantics.Nested)
+}
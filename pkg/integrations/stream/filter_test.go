@@ -0,0 +1,89 @@
+// Copyright 2018 Yaacov Zamir <kobi.zamir@gmail.com>
+// and other contributors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package stream
+
+import (
+	"testing"
+)
+
+func TestFilter(t *testing.T) {
+	f, err := NewFilter("type = 'order' and spec.total > 100")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		payload string
+		want    bool
+		wantErr bool
+	}{
+		{payload: `{"type": "order", "spec": {"total": 150}}`, want: true},
+		{payload: `{"type": "order", "spec": {"total": 50}}`, want: false},
+		{payload: `{"type": "order"}`, want: false},
+		{payload: `{"type": "order", "spec": 5}`, want: false},
+		{payload: `not json`, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		got, err := f.Match([]byte(tt.payload))
+		if (err != nil) != tt.wantErr {
+			t.Errorf("Match(%s) error = %v, wantErr %v", tt.payload, err, tt.wantErr)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("Match(%s) = %v, want %v", tt.payload, got, tt.want)
+		}
+	}
+
+	want := Stats{Passed: 1, Dropped: 3, Errors: 1}
+	if got := f.Stats(); got != want {
+		t.Errorf("Stats() = %+v, want %+v", got, want)
+	}
+}
+
+func TestFilterMatchRaw(t *testing.T) {
+	f, err := NewFilter("type = 'order' and spec.total > 100")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		payload string
+		want    bool
+	}{
+		{payload: `{"type": "order", "spec": {"total": 150}}`, want: true},
+		{payload: `{"spec": {"items": [{"total": 500}], "total": 150}, "type": "order"}`, want: true},
+		{payload: `{"type": "order", "spec": {"total": 50}}`, want: false},
+		{payload: `{"type": "order", "spec": 5}`, want: false},
+		{payload: `not json`, want: false},
+	}
+
+	for _, tt := range tests {
+		got, err := f.MatchRaw([]byte(tt.payload))
+		if err != nil {
+			t.Errorf("MatchRaw(%s) error = %v", tt.payload, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("MatchRaw(%s) = %v, want %v", tt.payload, got, tt.want)
+		}
+	}
+
+	want := Stats{Passed: 2, Dropped: 3}
+	if got := f.Stats(); got != want {
+		t.Errorf("Stats() = %+v, want %+v", got, want)
+	}
+}
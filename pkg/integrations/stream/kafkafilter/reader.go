@@ -0,0 +1,83 @@
+// Copyright 2018 Yaacov Zamir <kobi.zamir@gmail.com>
+// and other contributors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package kafkafilter filters Kafka messages using TSL.
+//
+// Usage:
+//   f, err := stream.NewFilter("type = 'order'")
+//
+//   r := kafkafilter.NewReader(kafka.NewReader(config), f)
+//   for {
+//       // Read the next matching message.
+//       m, err := r.ReadMessage(ctx)
+//       ...
+//   }
+//
+package kafkafilter
+
+import (
+	"context"
+
+	"github.com/segmentio/kafka-go"
+
+	"github.com/yaacov/tree-search-language/pkg/integrations/stream"
+)
+
+// Reader wraps a Kafka reader, returning only messages matching a filter.
+type Reader struct {
+	*kafka.Reader
+
+	Filter *stream.Filter
+
+	// OnError is called for messages that fail to decode or evaluate, if
+	// nil these messages are dropped.
+	OnError func(m kafka.Message, err error)
+}
+
+// NewReader returns a reader filtering the messages of a Kafka reader.
+func NewReader(r *kafka.Reader, f *stream.Filter) *Reader {
+	return &Reader{Reader: r, Filter: f}
+}
+
+// ReadMessage reads and returns the next matching message, when using
+// consumer groups the offsets of dropped messages are committed too.
+func (r *Reader) ReadMessage(ctx context.Context) (kafka.Message, error) {
+	return r.next(ctx, r.Reader.ReadMessage)
+}
+
+// FetchMessage fetches and returns the next matching message without
+// committing it, dropped messages are committed when a later message is
+// committed.
+func (r *Reader) FetchMessage(ctx context.Context) (kafka.Message, error) {
+	return r.next(ctx, r.Reader.FetchMessage)
+}
+
+// next reads messages until one matches the filter.
+func (r *Reader) next(ctx context.Context, read func(context.Context) (kafka.Message, error)) (kafka.Message, error) {
+	for {
+		m, err := read(ctx)
+		if err != nil {
+			return m, err
+		}
+
+		ok, err := r.Filter.Match(m.Value)
+		if err != nil && r.OnError != nil {
+			r.OnError(m, err)
+		}
+		if ok {
+			return m, nil
+		}
+	}
+}
@@ -0,0 +1,87 @@
+// Copyright 2018 Yaacov Zamir <kobi.zamir@gmail.com>
+// and other contributors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kafkafilter
+
+import (
+	"context"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/segmentio/kafka-go"
+
+	"github.com/yaacov/tree-search-language/pkg/integrations/stream"
+)
+
+// messages returns a read function that returns the payloads in order, and
+// io.EOF when they are exhausted.
+func messages(payloads ...string) func(context.Context) (kafka.Message, error) {
+	return func(ctx context.Context) (kafka.Message, error) {
+		if len(payloads) == 0 {
+			return kafka.Message{}, io.EOF
+		}
+		m := kafka.Message{Value: []byte(payloads[0])}
+		payloads = payloads[1:]
+		return m, nil
+	}
+}
+
+func TestReader(t *testing.T) {
+	f, err := stream.NewFilter("type = 'order'")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var failed []string
+	r := NewReader(nil, f)
+	r.OnError = func(m kafka.Message, err error) {
+		failed = append(failed, string(m.Value))
+	}
+
+	read := messages(`{"type": "user"}`, `not json`, `{"type": "order", "id": 1}`, `{"type": "order", "id": 2}`)
+
+	var got []string
+	for {
+		m, err := r.next(context.Background(), read)
+		if err != nil {
+			if !errors.Is(err, io.EOF) {
+				t.Fatal(err)
+			}
+			break
+		}
+		got = append(got, string(m.Value))
+	}
+
+	if len(got) != 2 || got[0] != `{"type": "order", "id": 1}` || got[1] != `{"type": "order", "id": 2}` {
+		t.Errorf("expected the two order messages instead it was %v", got)
+	}
+	if len(failed) != 1 || failed[0] != "not json" {
+		t.Errorf("expected one failed message instead it was %v", failed)
+	}
+}
+
+func TestReaderDropsErrors(t *testing.T) {
+	f, err := stream.NewFilter("type = 'order'")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	r := NewReader(nil, f)
+	m, err := r.next(context.Background(), messages(`not json`, `{"type": "order"}`))
+	if err != nil || string(m.Value) != `{"type": "order"}` {
+		t.Errorf("expected the order message instead it was %s, %v", m.Value, err)
+	}
+}
@@ -0,0 +1,65 @@
+// Copyright 2018 Yaacov Zamir <kobi.zamir@gmail.com>
+// and other contributors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package natsfilter filters NATS messages using TSL.
+//
+// Usage:
+//   f, err := stream.NewFilter("type = 'order'")
+//
+//   // Call the handler only for matching messages.
+//   sub, err := natsfilter.Subscribe(nc, "events", f, func(m *nats.Msg) {
+//       ...
+//   })
+//
+//   // Or forward matching messages to another subject.
+//   sub, err := natsfilter.Forward(nc, "events", "events.orders", f)
+//
+package natsfilter
+
+import (
+	"github.com/nats-io/nats.go"
+
+	"github.com/yaacov/tree-search-language/pkg/integrations/stream"
+)
+
+// Handler returns a message handler calling cb only for messages matching
+// the filter, messages that fail to decode or evaluate are dropped.
+func Handler(f *stream.Filter, cb nats.MsgHandler) nats.MsgHandler {
+	return func(m *nats.Msg) {
+		if ok, _ := f.Match(m.Data); ok {
+			cb(m)
+		}
+	}
+}
+
+// Subscribe subscribes to a subject, calling cb only for messages matching
+// the filter.
+func Subscribe(nc *nats.Conn, subject string, f *stream.Filter, cb nats.MsgHandler) (*nats.Subscription, error) {
+	return nc.Subscribe(subject, Handler(f, cb))
+}
+
+// QueueSubscribe creates a queue subscription, calling cb only for messages
+// matching the filter.
+func QueueSubscribe(nc *nats.Conn, subject, queue string, f *stream.Filter, cb nats.MsgHandler) (*nats.Subscription, error) {
+	return nc.QueueSubscribe(subject, queue, Handler(f, cb))
+}
+
+// Forward subscribes to a subject, and publishes matching messages to
+// another subject.
+func Forward(nc *nats.Conn, from, to string, f *stream.Filter) (*nats.Subscription, error) {
+	return Subscribe(nc, from, f, func(m *nats.Msg) {
+		nc.Publish(to, m.Data)
+	})
+}
@@ -0,0 +1,54 @@
+// Copyright 2018 Yaacov Zamir <kobi.zamir@gmail.com>
+// and other contributors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package natsfilter
+
+import (
+	"testing"
+
+	"github.com/nats-io/nats.go"
+
+	"github.com/yaacov/tree-search-language/pkg/integrations/stream"
+)
+
+func TestHandler(t *testing.T) {
+	f, err := stream.NewFilter("type = 'order' and spec.total > 100")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var got []string
+	h := Handler(f, func(m *nats.Msg) {
+		got = append(got, string(m.Data))
+	})
+
+	for _, payload := range []string{
+		`{"type": "order", "spec": {"total": 150}}`,
+		`{"type": "order", "spec": {"total": 50}}`,
+		`{"type": "user", "spec": {"total": 150}}`,
+		`not json`,
+	} {
+		h(&nats.Msg{Subject: "orders", Data: []byte(payload)})
+	}
+
+	if len(got) != 1 || got[0] != `{"type": "order", "spec": {"total": 150}}` {
+		t.Errorf("expected one matching message instead it was %v", got)
+	}
+
+	want := stream.Stats{Passed: 1, Dropped: 2, Errors: 1}
+	if s := f.Stats(); s != want {
+		t.Errorf("Stats() = %+v, want %+v", s, want)
+	}
+}
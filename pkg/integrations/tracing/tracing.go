@@ -0,0 +1,161 @@
+// Copyright 2018 Yaacov Zamir <kobi.zamir@gmail.com>
+// and other contributors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package tracing wraps TSL parsing, evaluation and SQL generation with
+// OpenTelemetry spans.
+//
+// Spans carry the query shape, the TSL tree with literal values replaced by
+// `?`, and never the raw literals, so user data does not leak into traces.
+// Spans are created using the global tracer provider, and are no-ops until
+// one is set.
+//
+// Usage:
+//   tree, err := tracing.ParseTSL(ctx, "name = 'joe' and age > 18")
+//   // span attributes: tsl.shape = "(name $eq ?) $and (age $gt ?)"
+//
+//   ok, err := tracing.Walk(ctx, tree, eval)
+//   filter, err := tracing.SQLWalk(ctx, tree)
+//
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	sq "github.com/Masterminds/squirrel"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/yaacov/tree-search-language/pkg/tsl"
+	"github.com/yaacov/tree-search-language/pkg/walkers/semantics"
+	"github.com/yaacov/tree-search-language/pkg/walkers/sql"
+)
+
+// InstrumentationName is the name of the tracer creating the spans.
+const InstrumentationName = "github.com/yaacov/tree-search-language"
+
+// Span attribute keys.
+const (
+	ShapeKey        = attribute.Key("tsl.shape")
+	NodesKey        = attribute.Key("tsl.nodes")
+	PhraseLengthKey = attribute.Key("tsl.phrase.length")
+)
+
+// ParseTSL parses a TSL phrase inside a `tsl.Parse` span.
+func ParseTSL(ctx context.Context, phrase string) (tree tsl.Node, err error) {
+	_, span := start(ctx, "tsl.Parse", PhraseLengthKey.Int(len(phrase)))
+	defer func() { end(span, err) }()
+
+	tree, err = tsl.ParseTSL(phrase)
+	if err == nil {
+		span.SetAttributes(treeAttributes(tree)...)
+	}
+
+	return
+}
+
+// Walk evaluates a TSL tree using the semantics walker inside a `tsl.Walk`
+// span.
+func Walk(ctx context.Context, tree tsl.Node, eval semantics.EvalFunc) (b bool, err error) {
+	_, span := start(ctx, "tsl.Walk", treeAttributes(tree)...)
+	defer func() { end(span, err) }()
+
+	b, err = semantics.Walk(tree, eval)
+	return
+}
+
+// SQLWalk creates a squirrel SQL filter inside a `tsl.SQL` span.
+func SQLWalk(ctx context.Context, tree tsl.Node) (s sq.Sqlizer, err error) {
+	_, span := start(ctx, "tsl.SQL", treeAttributes(tree)...)
+	defer func() { end(span, err) }()
+
+	s, err = sql.Walk(tree)
+	return
+}
+
+// Shape returns the TSL tree as an infix phrase, with literal values
+// replaced by `?` and lists of values replaced by `(?)`.
+func Shape(n tsl.Node) string {
+	switch n.Func {
+	case tsl.IdentOp:
+		return fmt.Sprintf("%v", n.Left)
+	case tsl.StringOp, tsl.DateOp, tsl.NumberOp, tsl.DurationOp, tsl.NowOp, tsl.BooleanOp:
+		return "?"
+	case tsl.NullOp:
+		return "null"
+	case tsl.ArrayOp:
+		return "(?)"
+	}
+
+	// This is an operator node.
+	s := n.Func
+	if l, ok := n.Left.(tsl.Node); ok {
+		s = fmt.Sprintf("%s %s", group(l), s)
+	}
+	if r, ok := n.Right.(tsl.Node); ok {
+		s = fmt.Sprintf("%s %s", s, group(r))
+	}
+
+	return s
+}
+
+// group returns the shape of a node, in parentheses if it is an operator
+// with operands.
+func group(n tsl.Node) string {
+	s := Shape(n)
+	if _, ok := n.Right.(tsl.Node); ok && strings.Contains(s, " ") {
+		return "(" + s + ")"
+	}
+
+	return s
+}
+
+// countNodes returns the number of nodes in a TSL tree.
+func countNodes(n tsl.Node) (count int) {
+	count = 1
+	if l, ok := n.Left.(tsl.Node); ok {
+		count += countNodes(l)
+	}
+	switch r := n.Right.(type) {
+	case tsl.Node:
+		count += countNodes(r)
+	case []tsl.Node:
+		count += len(r)
+	}
+
+	return
+}
+
+func treeAttributes(tree tsl.Node) []attribute.KeyValue {
+	return []attribute.KeyValue{
+		ShapeKey.String(Shape(tree)),
+		NodesKey.Int(countNodes(tree)),
+	}
+}
+
+func start(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	return otel.Tracer(InstrumentationName).Start(ctx, name, trace.WithAttributes(attrs...))
+}
+
+func end(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
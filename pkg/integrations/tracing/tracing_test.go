@@ -0,0 +1,68 @@
+// Copyright 2018 Yaacov Zamir <kobi.zamir@gmail.com>
+// and other contributors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tracing
+
+import (
+	"context"
+	"testing"
+
+	"go.opentelemetry.io/otel"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+
+	"github.com/yaacov/tree-search-language/pkg/tsl"
+)
+
+func TestShape(t *testing.T) {
+	tests := []struct {
+		phrase string
+		want   string
+	}{
+		{phrase: "name = 'joe'", want: "name $eq ?"},
+		{phrase: "name = 'joe' and (age > 18 or city in ('rome', 'paris'))", want: "(name $eq ?) $and ((age $gt ?) $or (city $in (?)))"},
+		{phrase: "not pages * 2 < 30", want: "((pages $multiply ?) $lt ?) $not"},
+		{phrase: "author is null", want: "author $nexists"},
+	}
+
+	for _, tt := range tests {
+		tree, err := tsl.ParseTSL(tt.phrase)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := Shape(tree); got != tt.want {
+			t.Errorf("Shape(%s) = %s, want %s", tt.phrase, got, tt.want)
+		}
+	}
+}
+
+func TestParseTSL(t *testing.T) {
+	sr := tracetest.NewSpanRecorder()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(sr)))
+
+	if _, err := ParseTSL(context.Background(), "name = 'secret'"); err != nil {
+		t.Fatal(err)
+	}
+
+	spans := sr.Ended()
+	if len(spans) != 1 || spans[0].Name() != "tsl.Parse" {
+		t.Fatalf("spans = %v, want one tsl.Parse span", spans)
+	}
+	for _, a := range spans[0].Attributes() {
+		if a.Key == ShapeKey && a.Value.AsString() != "name $eq ?" {
+			t.Errorf("tsl.shape = %s, want name $eq ?", a.Value.AsString())
+		}
+	}
+}
@@ -0,0 +1,175 @@
+// Copyright 2018 Yaacov Zamir <kobi.zamir@gmail.com>
+// and other contributors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package optimize simplifies TSL trees before walking them.
+//
+// Trees built by code, for example trees joined with server side constraints,
+// or trees with substituted parameters, often include constant comparisons
+// and redundant operands, optimized trees are smaller and faster to walk.
+//
+// Usage:
+//   tree, err := tsl.ParseTSL("not not (a = 1 and (b = 2 and a = 1))")
+//
+//   // Returns the tree of "a = 1 and b = 2".
+//   tree = optimize.Optimize(tree)
+//
+//   // Trees that fold into a constant return a boolean literal.
+//   if b, ok := optimize.Constant(tree); ok {
+//       ...
+//   }
+//
+//   // Push NOT operators down to the comparisons, for backends without NOT,
+//   // "not (a > 5 or b = 1)" becomes "a <= 5 and b != 1".
+//   tree = optimize.PushNot(tree)
+//
+package optimize
+
+import (
+	"github.com/yaacov/tree-search-language/pkg/tsl"
+	"github.com/yaacov/tree-search-language/pkg/walkers/semantics"
+)
+
+// Optimize returns a simplified tree, matching the same documents.
+//
+// Comparisons of literals are folded into boolean literals, double negations
+// are removed, and nested AND and OR chains are flattened into left to right
+// chains, dropping redundant operands, like the `true` operands of AND, the
+// `false` operands of OR, and repeated operands. A tree that folds into a
+// constant is returned as a boolean literal node, semantics.Walk evaluates it
+// to its value.
+//
+// Operands that can not change the result of a chain are not walked, so
+// walking an optimized tree may not report errors of the dropped operands.
+func Optimize(n tsl.Node) tsl.Node {
+	switch n.Func {
+	case tsl.AndOp, tsl.OrOp:
+		return chain(n)
+	case tsl.NotOp:
+		l := Optimize(n.Left.(tsl.Node))
+
+		// Remove double negations.
+		if l.Func == tsl.NotOp {
+			return l.Left.(tsl.Node)
+		}
+		if b, ok := Constant(l); ok {
+			return boolean(!b)
+		}
+
+		n.Left = l
+		return n
+	}
+
+	// Fold comparisons of literals.
+	if constant(n) {
+		if b, err := semantics.Walk(n, nil); err == nil {
+			return boolean(b)
+		}
+	}
+
+	return n
+}
+
+// Constant returns the value of a boolean literal node.
+func Constant(n tsl.Node) (value bool, ok bool) {
+	if n.Func != tsl.BooleanOp {
+		return false, false
+	}
+
+	value, ok = n.Left.(bool)
+	return
+}
+
+// chain optimizes a chain of AND or OR nodes.
+func chain(n tsl.Node) tsl.Node {
+	// The value that decides the result of the chain, false for AND and true
+	// for OR, other constants are dropped.
+	decisive := n.Func == tsl.OrOp
+
+	operands := []tsl.Node{}
+	seen := map[string]bool{}
+	for _, o := range flatten(n.Func, n) {
+		o = Optimize(o)
+
+		if b, ok := Constant(o); ok {
+			if b == decisive {
+				return o
+			}
+			continue
+		}
+
+		// An optimized operand may be a chain of the same operator.
+		for _, o := range flatten(n.Func, o) {
+			if k := tsl.Key(o); !seen[k] {
+				seen[k] = true
+				operands = append(operands, o)
+			}
+		}
+	}
+
+	if len(operands) == 0 {
+		return boolean(!decisive)
+	}
+
+	// Rebuild the chain.
+	c := operands[0]
+	for _, o := range operands[1:] {
+		c = tsl.Node{Func: n.Func, Left: c, Right: o}
+	}
+
+	return c
+}
+
+// flatten collects the operands of a chain of AND or OR nodes.
+func flatten(op string, n tsl.Node) []tsl.Node {
+	if n.Func != op {
+		return []tsl.Node{n}
+	}
+
+	l, _ := n.Left.(tsl.Node)
+	r, _ := n.Right.(tsl.Node)
+	return append(flatten(op, l), flatten(op, r)...)
+}
+
+// constant returns true if a node compares literals.
+func constant(n tsl.Node) bool {
+	l, ok := n.Left.(tsl.Node)
+	if !ok || !literal(l) {
+		return false
+	}
+
+	switch r := n.Right.(type) {
+	case nil:
+		return true
+	case tsl.Node:
+		return literal(r) || r.Func == tsl.ArrayOp
+	}
+
+	return false
+}
+
+// literal returns true if a node is a literal.
+func literal(n tsl.Node) bool {
+	switch n.Func {
+	case tsl.StringOp, tsl.DateOp, tsl.NumberOp, tsl.DurationOp, tsl.NowOp, tsl.BooleanOp, tsl.NullOp:
+		return true
+	}
+
+	return false
+}
+
+// boolean returns a boolean literal node.
+func boolean(b bool) tsl.Node {
+	return tsl.Node{Func: tsl.BooleanOp, Left: b}
+}
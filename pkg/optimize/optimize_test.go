@@ -0,0 +1,155 @@
+// Copyright 2018 Yaacov Zamir <kobi.zamir@gmail.com>
+// and other contributors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package optimize
+
+import (
+	"testing"
+
+	"github.com/yaacov/tree-search-language/pkg/tsl"
+	"github.com/yaacov/tree-search-language/pkg/walkers/phrase"
+	"github.com/yaacov/tree-search-language/pkg/walkers/semantics"
+)
+
+func TestOptimize(t *testing.T) {
+	tests := []struct {
+		phrase string
+		want   string
+	}{
+		{phrase: "a = 1", want: "a = 1"},
+		{phrase: "not not a = 1", want: "a = 1"},
+		{phrase: "not not not a = 1", want: "not a = 1"},
+		{phrase: "a = 1 and (b = 2 and c = 3)", want: "a = 1 and b = 2 and c = 3"},
+		{phrase: "a = 1 or (b = 2 or (c = 3 or d = 4))", want: "a = 1 or b = 2 or c = 3 or d = 4"},
+		{phrase: "a = 1 and (b = 2 or c = 3)", want: "a = 1 and (b = 2 or c = 3)"},
+		{phrase: "not not (a = 1 and (b = 2 and a = 1))", want: "a = 1 and b = 2"},
+		{phrase: "a = 1 and not not (b = 2 and c = 3)", want: "a = 1 and b = 2 and c = 3"},
+		{phrase: "a = 1 or b = 2 or a = 1", want: "a = 1 or b = 2"},
+	}
+
+	for _, tt := range tests {
+		tree, err := tsl.ParseTSL(tt.phrase)
+		if err != nil {
+			t.Fatalf("failed to parse %s: %v", tt.phrase, err)
+		}
+
+		got, err := phrase.Walk(Optimize(tree))
+		if err != nil {
+			t.Errorf("Optimize(%s) error = %v", tt.phrase, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("Optimize(%s) = %s, want %s", tt.phrase, got, tt.want)
+		}
+	}
+}
+
+func TestOptimizeConstants(t *testing.T) {
+	number := func(f float64) tsl.Node { return tsl.Node{Func: tsl.NumberOp, Left: f} }
+	str := func(s string) tsl.Node { return tsl.Node{Func: tsl.StringOp, Left: s} }
+	node := func(op string, l, r tsl.Node) tsl.Node { return tsl.Node{Func: op, Left: l, Right: r} }
+
+	a, err := tsl.ParseTSL("a = 1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	yes := node(tsl.EqOp, number(1), number(1))
+	no := node(tsl.GtOp, str("a"), str("b"))
+
+	tests := []struct {
+		name string
+		tree tsl.Node
+		want string
+	}{
+		{name: "true", tree: yes, want: "true"},
+		{name: "false", tree: no, want: "false"},
+		{name: "in", tree: node(tsl.InOp, number(2), tsl.Node{Func: tsl.ArrayOp, Right: []tsl.Node{number(1), number(2)}}), want: "true"},
+		{name: "is null", tree: tsl.Node{Func: tsl.IsNilOp, Left: tsl.Node{Func: tsl.NullOp}}, want: "true"},
+		{name: "not", tree: tsl.Node{Func: tsl.NotOp, Left: no}, want: "true"},
+		{name: "x and true", tree: node(tsl.AndOp, a, yes), want: "a = 1"},
+		{name: "x and false", tree: node(tsl.AndOp, a, no), want: "false"},
+		{name: "x or true", tree: node(tsl.OrOp, yes, a), want: "true"},
+		{name: "x or false", tree: node(tsl.OrOp, no, a), want: "a = 1"},
+		{name: "true and true", tree: node(tsl.AndOp, yes, yes), want: "true"},
+		{name: "false or false", tree: node(tsl.OrOp, no, no), want: "false"},
+		{name: "nested", tree: node(tsl.OrOp, node(tsl.AndOp, a, no), node(tsl.AndOp, yes, a)), want: "a = 1"},
+	}
+
+	for _, tt := range tests {
+		got := Optimize(tt.tree)
+
+		s, err := phrase.Walk(got)
+		if b, ok := Constant(got); ok {
+			s, err = map[bool]string{true: "true", false: "false"}[b], nil
+		}
+		if err != nil {
+			t.Errorf("%s: error = %v", tt.name, err)
+			continue
+		}
+		if s != tt.want {
+			t.Errorf("%s: Optimize = %s, want %s", tt.name, s, tt.want)
+		}
+
+		// Optimized trees are walked like the original trees, Walk does not
+		// implement NOT operators.
+		eval := func(string) (interface{}, bool) { return 1.0, true }
+		want, err := semantics.Walk(tt.tree, eval)
+		if err != nil {
+			continue
+		}
+		if b, err := semantics.Walk(got, eval); b != want || err != nil {
+			t.Errorf("%s: Walk(Optimize) = %v, %v, want %v", tt.name, b, err, want)
+		}
+		match, err := semantics.Compile(got)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if b, err := match(eval); b != want || err != nil {
+			t.Errorf("%s: Compile(Optimize) = %v, %v, want %v", tt.name, b, err, want)
+		}
+	}
+}
+
+func TestPushNot(t *testing.T) {
+	tests := []struct {
+		phrase string
+		want   string
+	}{
+		{phrase: "not a > 5", want: "a <= 5"},
+		{phrase: "not (a > 5 or b = 1)", want: "a <= 5 and b != 1"},
+		{phrase: "not (a < 5 and (b in (1, 2) or c is null))", want: "a >= 5 or b not in (1, 2) and c is not null"},
+		{phrase: "not not a ~= 'x'", want: "a ~= 'x'"},
+		{phrase: "not (a = 1 and not b like 'x%')", want: "a != 1 or b like 'x%'"},
+		{phrase: "not a between 1 and 2 or not a is true", want: "a not between 1 and 2 or a is not true"},
+		{phrase: "a = 1 and not (b startswith 'x' or c not ilike 'y')", want: "a = 1 and (b not startswith 'x' and c ilike 'y')"},
+		{phrase: "not a + 1 > 2", want: "a + 1 <= 2"},
+	}
+
+	for _, tt := range tests {
+		tree, err := tsl.ParseTSL(tt.phrase)
+		if err != nil {
+			t.Fatalf("failed to parse %s: %v", tt.phrase, err)
+		}
+
+		got, err := phrase.Walk(PushNot(tree))
+		if err != nil {
+			t.Errorf("PushNot(%s) error = %v", tt.phrase, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("PushNot(%s) = %s, want %s", tt.phrase, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,110 @@
+// Copyright 2018 Yaacov Zamir <kobi.zamir@gmail.com>
+// and other contributors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package optimize
+
+import (
+	"github.com/yaacov/tree-search-language/pkg/tsl"
+)
+
+// negations maps operators to their negated operators.
+var negations = map[string]string{
+	tsl.EqOp:            tsl.NotEqOp,
+	tsl.LtOp:            tsl.GteOp,
+	tsl.LteOp:           tsl.GtOp,
+	tsl.RegexOp:         tsl.NotRegexOp,
+	tsl.LikeOp:          tsl.NotLikeOp,
+	tsl.ILikeOp:         tsl.NotILikeOp,
+	tsl.ContainsOp:      tsl.NotContainsOp,
+	tsl.StartsWithOp:    tsl.NotStartsWithOp,
+	tsl.EndsWithOp:      tsl.NotEndsWithOp,
+	tsl.InOp:            tsl.NotInOp,
+	tsl.BetweenOp:       tsl.NotBetweenOp,
+	tsl.WithinOp:        tsl.NotWithinOp,
+	tsl.WithinBoxOp:     tsl.NotWithinBoxOp,
+	tsl.IsNilOp:         tsl.IsNotNilOp,
+	tsl.IsTrueOp:        tsl.IsNotTrueOp,
+	tsl.IsFalseOp:       tsl.IsNotFalseOp,
+	tsl.NotEqOp:         tsl.EqOp,
+	tsl.GteOp:           tsl.LtOp,
+	tsl.GtOp:            tsl.LteOp,
+	tsl.NotRegexOp:      tsl.RegexOp,
+	tsl.NotLikeOp:       tsl.LikeOp,
+	tsl.NotILikeOp:      tsl.ILikeOp,
+	tsl.NotContainsOp:   tsl.ContainsOp,
+	tsl.NotStartsWithOp: tsl.StartsWithOp,
+	tsl.NotEndsWithOp:   tsl.EndsWithOp,
+	tsl.NotInOp:         tsl.InOp,
+	tsl.NotBetweenOp:    tsl.BetweenOp,
+	tsl.NotWithinOp:     tsl.WithinOp,
+	tsl.NotWithinBoxOp:  tsl.WithinBoxOp,
+	tsl.IsNotNilOp:      tsl.IsNilOp,
+	tsl.IsNotTrueOp:     tsl.IsTrueOp,
+	tsl.IsNotFalseOp:    tsl.IsFalseOp,
+}
+
+// PushNot returns a tree with the NOT operators pushed down to the
+// comparisons, for backends without native NOT support.
+//
+// Negated AND and OR nodes are rewritten using De Morgan's laws, and negated
+// comparisons are replaced by the negated operator, for example
+// `not (a > 5 or b = 1)` becomes `a <= 5 and b != 1`. NOT operators are kept
+// only for operators without a negated operator.
+//
+// Like SQL, a negated comparison does not match null or missing fields, so
+// `a <= 5` does not match documents without `a`, while `not a > 5` matches
+// them in the TSL semantics. Is null, is true and is false comparisons are
+// negated exactly.
+func PushNot(n tsl.Node) tsl.Node {
+	switch n.Func {
+	case tsl.AndOp, tsl.OrOp:
+		n.Left = PushNot(n.Left.(tsl.Node))
+		n.Right = PushNot(n.Right.(tsl.Node))
+		return n
+	case tsl.NotOp:
+		return negate(n.Left.(tsl.Node))
+	}
+
+	return n
+}
+
+// negate returns the negation of a node, with the NOT operators pushed down.
+func negate(n tsl.Node) tsl.Node {
+	switch n.Func {
+	case tsl.AndOp, tsl.OrOp:
+		op := tsl.AndOp
+		if n.Func == tsl.AndOp {
+			op = tsl.OrOp
+		}
+
+		return tsl.Node{
+			Func:  op,
+			Left:  negate(n.Left.(tsl.Node)),
+			Right: negate(n.Right.(tsl.Node)),
+		}
+	case tsl.NotOp:
+		return PushNot(n.Left.(tsl.Node))
+	}
+
+	if b, ok := Constant(n); ok {
+		return boolean(!b)
+	}
+	if op, ok := negations[n.Func]; ok {
+		n.Func = op
+		return n
+	}
+
+	return tsl.Node{Func: tsl.NotOp, Left: n}
+}
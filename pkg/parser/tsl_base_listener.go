@@ -27,23 +27,53 @@ func (s *BaseTSLListener) EnterStart(ctx *StartContext) {}
 // ExitStart is called when production start is exited.
 func (s *BaseTSLListener) ExitStart(ctx *StartContext) {}
 
+// EnterQuery is called when production query is entered.
+func (s *BaseTSLListener) EnterQuery(ctx *QueryContext) {}
+
+// ExitQuery is called when production query is exited.
+func (s *BaseTSLListener) ExitQuery(ctx *QueryContext) {}
+
+// EnterFields is called when production fields is entered.
+func (s *BaseTSLListener) EnterFields(ctx *FieldsContext) {}
+
+// ExitFields is called when production fields is exited.
+func (s *BaseTSLListener) ExitFields(ctx *FieldsContext) {}
+
+// EnterOrderBy is called when production orderBy is entered.
+func (s *BaseTSLListener) EnterOrderBy(ctx *OrderByContext) {}
+
+// ExitOrderBy is called when production orderBy is exited.
+func (s *BaseTSLListener) ExitOrderBy(ctx *OrderByContext) {}
+
+// EnterSortKey is called when production sortKey is entered.
+func (s *BaseTSLListener) EnterSortKey(ctx *SortKeyContext) {}
+
+// ExitSortKey is called when production sortKey is exited.
+func (s *BaseTSLListener) ExitSortKey(ctx *SortKeyContext) {}
+
+// EnterLimit is called when production limit is entered.
+func (s *BaseTSLListener) EnterLimit(ctx *LimitContext) {}
+
+// ExitLimit is called when production limit is exited.
+func (s *BaseTSLListener) ExitLimit(ctx *LimitContext) {}
+
+// EnterOffset is called when production offset is entered.
+func (s *BaseTSLListener) EnterOffset(ctx *OffsetContext) {}
+
+// ExitOffset is called when production offset is exited.
+func (s *BaseTSLListener) ExitOffset(ctx *OffsetContext) {}
+
 // EnterPar is called when production Par is entered.
 func (s *BaseTSLListener) EnterPar(ctx *ParContext) {}
 
 // ExitPar is called when production Par is exited.
 func (s *BaseTSLListener) ExitPar(ctx *ParContext) {}
 
-// EnterNot is called when production Not is entered.
-func (s *BaseTSLListener) EnterNot(ctx *NotContext) {}
-
-// ExitNot is called when production Not is exited.
-func (s *BaseTSLListener) ExitNot(ctx *NotContext) {}
+// EnterWithinBox is called when production WithinBox is entered.
+func (s *BaseTSLListener) EnterWithinBox(ctx *WithinBoxContext) {}
 
-// EnterLike is called when production Like is entered.
-func (s *BaseTSLListener) EnterLike(ctx *LikeContext) {}
-
-// ExitLike is called when production Like is exited.
-func (s *BaseTSLListener) ExitLike(ctx *LikeContext) {}
+// ExitWithinBox is called when production WithinBox is exited.
+func (s *BaseTSLListener) ExitWithinBox(ctx *WithinBoxContext) {}
 
 // EnterOr is called when production Or is entered.
 func (s *BaseTSLListener) EnterOr(ctx *OrContext) {}
@@ -63,12 +93,6 @@ func (s *BaseTSLListener) EnterIsLiteral(ctx *IsLiteralContext) {}
 // ExitIsLiteral is called when production IsLiteral is exited.
 func (s *BaseTSLListener) ExitIsLiteral(ctx *IsLiteralContext) {}
 
-// EnterAnd is called when production And is entered.
-func (s *BaseTSLListener) EnterAnd(ctx *AndContext) {}
-
-// ExitAnd is called when production And is exited.
-func (s *BaseTSLListener) ExitAnd(ctx *AndContext) {}
-
 // EnterBetween is called when production Between is entered.
 func (s *BaseTSLListener) EnterBetween(ctx *BetweenContext) {}
 
@@ -81,18 +105,42 @@ func (s *BaseTSLListener) EnterStringOps(ctx *StringOpsContext) {}
 // ExitStringOps is called when production StringOps is exited.
 func (s *BaseTSLListener) ExitStringOps(ctx *StringOpsContext) {}
 
-// EnterIsNull is called when production IsNull is entered.
-func (s *BaseTSLListener) EnterIsNull(ctx *IsNullContext) {}
-
-// ExitIsNull is called when production IsNull is exited.
-func (s *BaseTSLListener) ExitIsNull(ctx *IsNullContext) {}
-
 // EnterLiteralOps is called when production LiteralOps is entered.
 func (s *BaseTSLListener) EnterLiteralOps(ctx *LiteralOpsContext) {}
 
 // ExitLiteralOps is called when production LiteralOps is exited.
 func (s *BaseTSLListener) ExitLiteralOps(ctx *LiteralOpsContext) {}
 
+// EnterNot is called when production Not is entered.
+func (s *BaseTSLListener) EnterNot(ctx *NotContext) {}
+
+// ExitNot is called when production Not is exited.
+func (s *BaseTSLListener) ExitNot(ctx *NotContext) {}
+
+// EnterLike is called when production Like is entered.
+func (s *BaseTSLListener) EnterLike(ctx *LikeContext) {}
+
+// ExitLike is called when production Like is exited.
+func (s *BaseTSLListener) ExitLike(ctx *LikeContext) {}
+
+// EnterAnd is called when production And is entered.
+func (s *BaseTSLListener) EnterAnd(ctx *AndContext) {}
+
+// ExitAnd is called when production And is exited.
+func (s *BaseTSLListener) ExitAnd(ctx *AndContext) {}
+
+// EnterWithin is called when production Within is entered.
+func (s *BaseTSLListener) EnterWithin(ctx *WithinContext) {}
+
+// ExitWithin is called when production Within is exited.
+func (s *BaseTSLListener) ExitWithin(ctx *WithinContext) {}
+
+// EnterIsNull is called when production IsNull is entered.
+func (s *BaseTSLListener) EnterIsNull(ctx *IsNullContext) {}
+
+// ExitIsNull is called when production IsNull is exited.
+func (s *BaseTSLListener) ExitIsNull(ctx *IsNullContext) {}
+
 // EnterLiteralOp is called when production literalOp is entered.
 func (s *BaseTSLListener) EnterLiteralOp(ctx *LiteralOpContext) {}
 
@@ -105,6 +153,12 @@ func (s *BaseTSLListener) EnterStringOp(ctx *StringOpContext) {}
 // ExitStringOp is called when production stringOp is exited.
 func (s *BaseTSLListener) ExitStringOp(ctx *StringOpContext) {}
 
+// EnterLikeOp is called when production likeOp is entered.
+func (s *BaseTSLListener) EnterLikeOp(ctx *LikeOpContext) {}
+
+// ExitLikeOp is called when production likeOp is exited.
+func (s *BaseTSLListener) ExitLikeOp(ctx *LikeOpContext) {}
+
 // EnterDatabaseName is called when production databaseName is entered.
 func (s *BaseTSLListener) EnterDatabaseName(ctx *DatabaseNameContext) {}
 
@@ -123,6 +177,12 @@ func (s *BaseTSLListener) EnterColumnName(ctx *ColumnNameContext) {}
 // ExitColumnName is called when production columnName is exited.
 func (s *BaseTSLListener) ExitColumnName(ctx *ColumnNameContext) {}
 
+// EnterIdentifier is called when production identifier is entered.
+func (s *BaseTSLListener) EnterIdentifier(ctx *IdentifierContext) {}
+
+// ExitIdentifier is called when production identifier is exited.
+func (s *BaseTSLListener) ExitIdentifier(ctx *IdentifierContext) {}
+
 // EnterNumberLiteral is called when production NumberLiteral is entered.
 func (s *BaseTSLListener) EnterNumberLiteral(ctx *NumberLiteralContext) {}
 
@@ -135,6 +195,24 @@ func (s *BaseTSLListener) EnterStringLiteral(ctx *StringLiteralContext) {}
 // ExitStringLiteral is called when production StringLiteral is exited.
 func (s *BaseTSLListener) ExitStringLiteral(ctx *StringLiteralContext) {}
 
+// EnterBooleanLiteral is called when production BooleanLiteral is entered.
+func (s *BaseTSLListener) EnterBooleanLiteral(ctx *BooleanLiteralContext) {}
+
+// ExitBooleanLiteral is called when production BooleanLiteral is exited.
+func (s *BaseTSLListener) ExitBooleanLiteral(ctx *BooleanLiteralContext) {}
+
+// EnterDateLiteral is called when production DateLiteral is entered.
+func (s *BaseTSLListener) EnterDateLiteral(ctx *DateLiteralContext) {}
+
+// ExitDateLiteral is called when production DateLiteral is exited.
+func (s *BaseTSLListener) ExitDateLiteral(ctx *DateLiteralContext) {}
+
+// EnterDurationLiteral is called when production DurationLiteral is entered.
+func (s *BaseTSLListener) EnterDurationLiteral(ctx *DurationLiteralContext) {}
+
+// ExitDurationLiteral is called when production DurationLiteral is exited.
+func (s *BaseTSLListener) ExitDurationLiteral(ctx *DurationLiteralContext) {}
+
 // EnterMathPar is called when production MathPar is entered.
 func (s *BaseTSLListener) EnterMathPar(ctx *MathParContext) {}
 
@@ -171,6 +249,12 @@ func (s *BaseTSLListener) EnterColumnIdentifier(ctx *ColumnIdentifierContext) {}
 // ExitColumnIdentifier is called when production ColumnIdentifier is exited.
 func (s *BaseTSLListener) ExitColumnIdentifier(ctx *ColumnIdentifierContext) {}
 
+// EnterFunctionCall is called when production FunctionCall is entered.
+func (s *BaseTSLListener) EnterFunctionCall(ctx *FunctionCallContext) {}
+
+// ExitFunctionCall is called when production FunctionCall is exited.
+func (s *BaseTSLListener) ExitFunctionCall(ctx *FunctionCallContext) {}
+
 // EnterAddOps is called when production AddOps is entered.
 func (s *BaseTSLListener) EnterAddOps(ctx *AddOpsContext) {}
 
@@ -189,6 +273,48 @@ func (s *BaseTSLListener) EnterStringValue(ctx *StringValueContext) {}
 // ExitStringValue is called when production stringValue is exited.
 func (s *BaseTSLListener) ExitStringValue(ctx *StringValueContext) {}
 
+// EnterBooleanValue is called when production booleanValue is entered.
+func (s *BaseTSLListener) EnterBooleanValue(ctx *BooleanValueContext) {}
+
+// ExitBooleanValue is called when production booleanValue is exited.
+func (s *BaseTSLListener) ExitBooleanValue(ctx *BooleanValueContext) {}
+
+// EnterDateValue is called when production dateValue is entered.
+func (s *BaseTSLListener) EnterDateValue(ctx *DateValueContext) {}
+
+// ExitDateValue is called when production dateValue is exited.
+func (s *BaseTSLListener) ExitDateValue(ctx *DateValueContext) {}
+
+// EnterDateOffset is called when production dateOffset is entered.
+func (s *BaseTSLListener) EnterDateOffset(ctx *DateOffsetContext) {}
+
+// ExitDateOffset is called when production dateOffset is exited.
+func (s *BaseTSLListener) ExitDateOffset(ctx *DateOffsetContext) {}
+
+// EnterDurationValue is called when production durationValue is entered.
+func (s *BaseTSLListener) EnterDurationValue(ctx *DurationValueContext) {}
+
+// ExitDurationValue is called when production durationValue is exited.
+func (s *BaseTSLListener) ExitDurationValue(ctx *DurationValueContext) {}
+
+// EnterDistance is called when production distance is entered.
+func (s *BaseTSLListener) EnterDistance(ctx *DistanceContext) {}
+
+// ExitDistance is called when production distance is exited.
+func (s *BaseTSLListener) ExitDistance(ctx *DistanceContext) {}
+
+// EnterPoint is called when production point is entered.
+func (s *BaseTSLListener) EnterPoint(ctx *PointContext) {}
+
+// ExitPoint is called when production point is exited.
+func (s *BaseTSLListener) ExitPoint(ctx *PointContext) {}
+
+// EnterBox is called when production box is entered.
+func (s *BaseTSLListener) EnterBox(ctx *BoxContext) {}
+
+// ExitBox is called when production box is exited.
+func (s *BaseTSLListener) ExitBox(ctx *BoxContext) {}
+
 // EnterKeyNot is called when production keyNot is entered.
 func (s *BaseTSLListener) EnterKeyNot(ctx *KeyNotContext) {}
 
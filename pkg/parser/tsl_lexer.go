@@ -14,7 +14,7 @@ var _ = fmt.Printf
 var _ = unicode.IsLetter
 
 var serializedLexerAtn = []uint16{
-	3, 24715, 42794, 33075, 47597, 16764, 15335, 30598, 22884, 2, 32, 344,
+	3, 24715, 42794, 33075, 47597, 16764, 15335, 30598, 22884, 2, 53, 582,
 	8, 1, 4, 2, 9, 2, 4, 3, 9, 3, 4, 4, 9, 4, 4, 5, 9, 5, 4, 6, 9, 6, 4, 7,
 	9, 7, 4, 8, 9, 8, 4, 9, 9, 9, 4, 10, 9, 10, 4, 11, 9, 11, 4, 12, 9, 12,
 	4, 13, 9, 13, 4, 14, 9, 14, 4, 15, 9, 15, 4, 16, 9, 16, 4, 17, 9, 17, 4,
@@ -25,152 +25,262 @@ var serializedLexerAtn = []uint16{
 	39, 9, 39, 4, 40, 9, 40, 4, 41, 9, 41, 4, 42, 9, 42, 4, 43, 9, 43, 4, 44,
 	9, 44, 4, 45, 9, 45, 4, 46, 9, 46, 4, 47, 9, 47, 4, 48, 9, 48, 4, 49, 9,
 	49, 4, 50, 9, 50, 4, 51, 9, 51, 4, 52, 9, 52, 4, 53, 9, 53, 4, 54, 9, 54,
-	4, 55, 9, 55, 4, 56, 9, 56, 4, 57, 9, 57, 4, 58, 9, 58, 3, 2, 3, 2, 3,
+	4, 55, 9, 55, 4, 56, 9, 56, 4, 57, 9, 57, 4, 58, 9, 58, 4, 59, 9, 59, 4,
+	60, 9, 60, 4, 61, 9, 61, 4, 62, 9, 62, 4, 63, 9, 63, 4, 64, 9, 64, 4, 65,
+	9, 65, 4, 66, 9, 66, 4, 67, 9, 67, 4, 68, 9, 68, 4, 69, 9, 69, 4, 70, 9,
+	70, 4, 71, 9, 71, 4, 72, 9, 72, 4, 73, 9, 73, 4, 74, 9, 74, 4, 75, 9, 75,
+	4, 76, 9, 76, 4, 77, 9, 77, 4, 78, 9, 78, 4, 79, 9, 79, 3, 2, 3, 2, 3,
 	3, 3, 3, 3, 4, 3, 4, 3, 5, 3, 5, 3, 6, 3, 6, 3, 6, 3, 7, 3, 7, 3, 8, 3,
 	8, 3, 8, 3, 9, 3, 9, 3, 10, 3, 10, 3, 10, 3, 11, 3, 11, 3, 11, 3, 12, 3,
 	12, 3, 12, 3, 13, 3, 13, 3, 13, 3, 14, 3, 14, 3, 15, 3, 15, 3, 16, 3, 16,
 	3, 17, 3, 17, 3, 18, 3, 18, 3, 19, 3, 19, 3, 20, 3, 20, 3, 20, 3, 20, 3,
-	20, 3, 21, 3, 21, 3, 21, 3, 21, 3, 22, 3, 22, 3, 22, 3, 23, 3, 23, 3, 23,
-	3, 23, 3, 23, 3, 23, 3, 23, 3, 23, 3, 24, 3, 24, 3, 24, 3, 25, 3, 25, 3,
-	25, 3, 26, 3, 26, 3, 26, 3, 26, 3, 26, 3, 27, 3, 27, 3, 27, 3, 27, 3, 28,
-	3, 28, 3, 28, 3, 28, 7, 28, 199, 10, 28, 12, 28, 14, 28, 202, 11, 28, 3,
-	28, 3, 28, 3, 28, 3, 28, 3, 28, 7, 28, 209, 10, 28, 12, 28, 14, 28, 212,
-	11, 28, 3, 28, 3, 28, 3, 28, 7, 28, 217, 10, 28, 12, 28, 14, 28, 220, 11,
-	28, 3, 28, 3, 28, 3, 28, 7, 28, 225, 10, 28, 12, 28, 14, 28, 228, 11, 28,
-	5, 28, 230, 10, 28, 3, 29, 6, 29, 233, 10, 29, 13, 29, 14, 29, 234, 3,
-	29, 3, 29, 7, 29, 239, 10, 29, 12, 29, 14, 29, 242, 11, 29, 5, 29, 244,
-	10, 29, 3, 29, 3, 29, 5, 29, 248, 10, 29, 3, 29, 6, 29, 251, 10, 29, 13,
-	29, 14, 29, 252, 5, 29, 255, 10, 29, 3, 29, 3, 29, 6, 29, 259, 10, 29,
-	13, 29, 14, 29, 260, 3, 29, 3, 29, 5, 29, 265, 10, 29, 3, 29, 6, 29, 268,
-	10, 29, 13, 29, 14, 29, 269, 5, 29, 272, 10, 29, 5, 29, 274, 10, 29, 3,
-	30, 3, 30, 3, 30, 3, 30, 7, 30, 280, 10, 30, 12, 30, 14, 30, 283, 11, 30,
-	3, 30, 3, 30, 3, 31, 3, 31, 3, 31, 3, 31, 3, 32, 3, 32, 3, 33, 3, 33, 3,
-	34, 3, 34, 3, 35, 3, 35, 3, 36, 3, 36, 3, 37, 3, 37, 3, 38, 3, 38, 3, 39,
-	3, 39, 3, 40, 3, 40, 3, 41, 3, 41, 3, 42, 3, 42, 3, 43, 3, 43, 3, 44, 3,
-	44, 3, 45, 3, 45, 3, 46, 3, 46, 3, 47, 3, 47, 3, 48, 3, 48, 3, 49, 3, 49,
-	3, 50, 3, 50, 3, 51, 3, 51, 3, 52, 3, 52, 3, 53, 3, 53, 3, 54, 3, 54, 3,
-	55, 3, 55, 3, 56, 3, 56, 3, 57, 3, 57, 3, 58, 3, 58, 2, 2, 59, 3, 3, 5,
-	4, 7, 5, 9, 6, 11, 7, 13, 8, 15, 9, 17, 10, 19, 11, 21, 12, 23, 13, 25,
-	14, 27, 15, 29, 16, 31, 17, 33, 18, 35, 19, 37, 20, 39, 21, 41, 22, 43,
-	23, 45, 24, 47, 25, 49, 26, 51, 27, 53, 28, 55, 29, 57, 30, 59, 31, 61,
-	32, 63, 2, 65, 2, 67, 2, 69, 2, 71, 2, 73, 2, 75, 2, 77, 2, 79, 2, 81,
-	2, 83, 2, 85, 2, 87, 2, 89, 2, 91, 2, 93, 2, 95, 2, 97, 2, 99, 2, 101,
-	2, 103, 2, 105, 2, 107, 2, 109, 2, 111, 2, 113, 2, 115, 2, 3, 2, 37, 3,
-	2, 36, 36, 3, 2, 98, 98, 3, 2, 95, 95, 5, 2, 67, 92, 97, 97, 99, 124, 6,
-	2, 50, 59, 67, 92, 97, 97, 99, 124, 4, 2, 45, 45, 47, 47, 3, 2, 41, 41,
-	5, 2, 11, 13, 15, 15, 34, 34, 3, 2, 50, 59, 4, 2, 67, 67, 99, 99, 4, 2,
-	68, 68, 100, 100, 4, 2, 69, 69, 101, 101, 4, 2, 70, 70, 102, 102, 4, 2,
-	71, 71, 103, 103, 4, 2, 72, 72, 104, 104, 4, 2, 73, 73, 105, 105, 4, 2,
-	74, 74, 106, 106, 4, 2, 75, 75, 107, 107, 4, 2, 76, 76, 108, 108, 4, 2,
-	77, 77, 109, 109, 4, 2, 78, 78, 110, 110, 4, 2, 79, 79, 111, 111, 4, 2,
-	80, 80, 112, 112, 4, 2, 81, 81, 113, 113, 4, 2, 82, 82, 114, 114, 4, 2,
-	83, 83, 115, 115, 4, 2, 84, 84, 116, 116, 4, 2, 85, 85, 117, 117, 4, 2,
-	86, 86, 118, 118, 4, 2, 87, 87, 119, 119, 4, 2, 88, 88, 120, 120, 4, 2,
-	89, 89, 121, 121, 4, 2, 90, 90, 122, 122, 4, 2, 91, 91, 123, 123, 4, 2,
-	92, 92, 124, 124, 2, 338, 2, 3, 3, 2, 2, 2, 2, 5, 3, 2, 2, 2, 2, 7, 3,
-	2, 2, 2, 2, 9, 3, 2, 2, 2, 2, 11, 3, 2, 2, 2, 2, 13, 3, 2, 2, 2, 2, 15,
-	3, 2, 2, 2, 2, 17, 3, 2, 2, 2, 2, 19, 3, 2, 2, 2, 2, 21, 3, 2, 2, 2, 2,
-	23, 3, 2, 2, 2, 2, 25, 3, 2, 2, 2, 2, 27, 3, 2, 2, 2, 2, 29, 3, 2, 2, 2,
-	2, 31, 3, 2, 2, 2, 2, 33, 3, 2, 2, 2, 2, 35, 3, 2, 2, 2, 2, 37, 3, 2, 2,
-	2, 2, 39, 3, 2, 2, 2, 2, 41, 3, 2, 2, 2, 2, 43, 3, 2, 2, 2, 2, 45, 3, 2,
-	2, 2, 2, 47, 3, 2, 2, 2, 2, 49, 3, 2, 2, 2, 2, 51, 3, 2, 2, 2, 2, 53, 3,
-	2, 2, 2, 2, 55, 3, 2, 2, 2, 2, 57, 3, 2, 2, 2, 2, 59, 3, 2, 2, 2, 2, 61,
-	3, 2, 2, 2, 3, 117, 3, 2, 2, 2, 5, 119, 3, 2, 2, 2, 7, 121, 3, 2, 2, 2,
-	9, 123, 3, 2, 2, 2, 11, 125, 3, 2, 2, 2, 13, 128, 3, 2, 2, 2, 15, 130,
-	3, 2, 2, 2, 17, 133, 3, 2, 2, 2, 19, 135, 3, 2, 2, 2, 21, 138, 3, 2, 2,
-	2, 23, 141, 3, 2, 2, 2, 25, 144, 3, 2, 2, 2, 27, 147, 3, 2, 2, 2, 29, 149,
-	3, 2, 2, 2, 31, 151, 3, 2, 2, 2, 33, 153, 3, 2, 2, 2, 35, 155, 3, 2, 2,
-	2, 37, 157, 3, 2, 2, 2, 39, 159, 3, 2, 2, 2, 41, 164, 3, 2, 2, 2, 43, 168,
-	3, 2, 2, 2, 45, 171, 3, 2, 2, 2, 47, 179, 3, 2, 2, 2, 49, 182, 3, 2, 2,
-	2, 51, 185, 3, 2, 2, 2, 53, 190, 3, 2, 2, 2, 55, 229, 3, 2, 2, 2, 57, 273,
-	3, 2, 2, 2, 59, 275, 3, 2, 2, 2, 61, 286, 3, 2, 2, 2, 63, 290, 3, 2, 2,
-	2, 65, 292, 3, 2, 2, 2, 67, 294, 3, 2, 2, 2, 69, 296, 3, 2, 2, 2, 71, 298,
-	3, 2, 2, 2, 73, 300, 3, 2, 2, 2, 75, 302, 3, 2, 2, 2, 77, 304, 3, 2, 2,
-	2, 79, 306, 3, 2, 2, 2, 81, 308, 3, 2, 2, 2, 83, 310, 3, 2, 2, 2, 85, 312,
-	3, 2, 2, 2, 87, 314, 3, 2, 2, 2, 89, 316, 3, 2, 2, 2, 91, 318, 3, 2, 2,
-	2, 93, 320, 3, 2, 2, 2, 95, 322, 3, 2, 2, 2, 97, 324, 3, 2, 2, 2, 99, 326,
-	3, 2, 2, 2, 101, 328, 3, 2, 2, 2, 103, 330, 3, 2, 2, 2, 105, 332, 3, 2,
-	2, 2, 107, 334, 3, 2, 2, 2, 109, 336, 3, 2, 2, 2, 111, 338, 3, 2, 2, 2,
-	113, 340, 3, 2, 2, 2, 115, 342, 3, 2, 2, 2, 117, 118, 7, 42, 2, 2, 118,
-	4, 3, 2, 2, 2, 119, 120, 7, 46, 2, 2, 120, 6, 3, 2, 2, 2, 121, 122, 7,
-	43, 2, 2, 122, 8, 3, 2, 2, 2, 123, 124, 7, 62, 2, 2, 124, 10, 3, 2, 2,
-	2, 125, 126, 7, 62, 2, 2, 126, 127, 7, 63, 2, 2, 127, 12, 3, 2, 2, 2, 128,
-	129, 7, 64, 2, 2, 129, 14, 3, 2, 2, 2, 130, 131, 7, 64, 2, 2, 131, 132,
-	7, 63, 2, 2, 132, 16, 3, 2, 2, 2, 133, 134, 7, 63, 2, 2, 134, 18, 3, 2,
-	2, 2, 135, 136, 7, 35, 2, 2, 136, 137, 7, 63, 2, 2, 137, 20, 3, 2, 2, 2,
-	138, 139, 7, 62, 2, 2, 139, 140, 7, 64, 2, 2, 140, 22, 3, 2, 2, 2, 141,
-	142, 7, 128, 2, 2, 142, 143, 7, 63, 2, 2, 143, 24, 3, 2, 2, 2, 144, 145,
-	7, 128, 2, 2, 145, 146, 7, 35, 2, 2, 146, 26, 3, 2, 2, 2, 147, 148, 7,
-	48, 2, 2, 148, 28, 3, 2, 2, 2, 149, 150, 7, 44, 2, 2, 150, 30, 3, 2, 2,
-	2, 151, 152, 7, 49, 2, 2, 152, 32, 3, 2, 2, 2, 153, 154, 7, 39, 2, 2, 154,
-	34, 3, 2, 2, 2, 155, 156, 7, 45, 2, 2, 156, 36, 3, 2, 2, 2, 157, 158, 7,
-	47, 2, 2, 158, 38, 3, 2, 2, 2, 159, 160, 5, 87, 44, 2, 160, 161, 5, 81,
-	41, 2, 161, 162, 5, 85, 43, 2, 162, 163, 5, 73, 37, 2, 163, 40, 3, 2, 2,
-	2, 164, 165, 5, 65, 33, 2, 165, 166, 5, 91, 46, 2, 166, 167, 5, 71, 36,
-	2, 167, 42, 3, 2, 2, 2, 168, 169, 5, 93, 47, 2, 169, 170, 5, 99, 50, 2,
-	170, 44, 3, 2, 2, 2, 171, 172, 5, 67, 34, 2, 172, 173, 5, 73, 37, 2, 173,
-	174, 5, 103, 52, 2, 174, 175, 5, 109, 55, 2, 175, 176, 5, 73, 37, 2, 176,
-	177, 5, 73, 37, 2, 177, 178, 5, 91, 46, 2, 178, 46, 3, 2, 2, 2, 179, 180,
-	5, 81, 41, 2, 180, 181, 5, 91, 46, 2, 181, 48, 3, 2, 2, 2, 182, 183, 5,
-	81, 41, 2, 183, 184, 5, 101, 51, 2, 184, 50, 3, 2, 2, 2, 185, 186, 5, 91,
-	46, 2, 186, 187, 5, 105, 53, 2, 187, 188, 5, 87, 44, 2, 188, 189, 5, 87,
-	44, 2, 189, 52, 3, 2, 2, 2, 190, 191, 5, 91, 46, 2, 191, 192, 5, 93, 47,
-	2, 192, 193, 5, 103, 52, 2, 193, 54, 3, 2, 2, 2, 194, 200, 7, 36, 2, 2,
-	195, 199, 10, 2, 2, 2, 196, 197, 7, 36, 2, 2, 197, 199, 7, 36, 2, 2, 198,
-	195, 3, 2, 2, 2, 198, 196, 3, 2, 2, 2, 199, 202, 3, 2, 2, 2, 200, 198,
-	3, 2, 2, 2, 200, 201, 3, 2, 2, 2, 201, 203, 3, 2, 2, 2, 202, 200, 3, 2,
-	2, 2, 203, 230, 7, 36, 2, 2, 204, 210, 7, 98, 2, 2, 205, 209, 10, 3, 2,
-	2, 206, 207, 7, 98, 2, 2, 207, 209, 7, 98, 2, 2, 208, 205, 3, 2, 2, 2,
-	208, 206, 3, 2, 2, 2, 209, 212, 3, 2, 2, 2, 210, 208, 3, 2, 2, 2, 210,
-	211, 3, 2, 2, 2, 211, 213, 3, 2, 2, 2, 212, 210, 3, 2, 2, 2, 213, 230,
-	7, 98, 2, 2, 214, 218, 7, 93, 2, 2, 215, 217, 10, 4, 2, 2, 216, 215, 3,
-	2, 2, 2, 217, 220, 3, 2, 2, 2, 218, 216, 3, 2, 2, 2, 218, 219, 3, 2, 2,
-	2, 219, 221, 3, 2, 2, 2, 220, 218, 3, 2, 2, 2, 221, 230, 7, 95, 2, 2, 222,
-	226, 9, 5, 2, 2, 223, 225, 9, 6, 2, 2, 224, 223, 3, 2, 2, 2, 225, 228,
-	3, 2, 2, 2, 226, 224, 3, 2, 2, 2, 226, 227, 3, 2, 2, 2, 227, 230, 3, 2,
-	2, 2, 228, 226, 3, 2, 2, 2, 229, 194, 3, 2, 2, 2, 229, 204, 3, 2, 2, 2,
-	229, 214, 3, 2, 2, 2, 229, 222, 3, 2, 2, 2, 230, 56, 3, 2, 2, 2, 231, 233,
-	5, 63, 32, 2, 232, 231, 3, 2, 2, 2, 233, 234, 3, 2, 2, 2, 234, 232, 3,
-	2, 2, 2, 234, 235, 3, 2, 2, 2, 235, 243, 3, 2, 2, 2, 236, 240, 7, 48, 2,
-	2, 237, 239, 5, 63, 32, 2, 238, 237, 3, 2, 2, 2, 239, 242, 3, 2, 2, 2,
-	240, 238, 3, 2, 2, 2, 240, 241, 3, 2, 2, 2, 241, 244, 3, 2, 2, 2, 242,
-	240, 3, 2, 2, 2, 243, 236, 3, 2, 2, 2, 243, 244, 3, 2, 2, 2, 244, 254,
-	3, 2, 2, 2, 245, 247, 5, 73, 37, 2, 246, 248, 9, 7, 2, 2, 247, 246, 3,
-	2, 2, 2, 247, 248, 3, 2, 2, 2, 248, 250, 3, 2, 2, 2, 249, 251, 5, 63, 32,
-	2, 250, 249, 3, 2, 2, 2, 251, 252, 3, 2, 2, 2, 252, 250, 3, 2, 2, 2, 252,
-	253, 3, 2, 2, 2, 253, 255, 3, 2, 2, 2, 254, 245, 3, 2, 2, 2, 254, 255,
-	3, 2, 2, 2, 255, 274, 3, 2, 2, 2, 256, 258, 7, 48, 2, 2, 257, 259, 5, 63,
-	32, 2, 258, 257, 3, 2, 2, 2, 259, 260, 3, 2, 2, 2, 260, 258, 3, 2, 2, 2,
-	260, 261, 3, 2, 2, 2, 261, 271, 3, 2, 2, 2, 262, 264, 5, 73, 37, 2, 263,
-	265, 9, 7, 2, 2, 264, 263, 3, 2, 2, 2, 264, 265, 3, 2, 2, 2, 265, 267,
-	3, 2, 2, 2, 266, 268, 5, 63, 32, 2, 267, 266, 3, 2, 2, 2, 268, 269, 3,
-	2, 2, 2, 269, 267, 3, 2, 2, 2, 269, 270, 3, 2, 2, 2, 270, 272, 3, 2, 2,
-	2, 271, 262, 3, 2, 2, 2, 271, 272, 3, 2, 2, 2, 272, 274, 3, 2, 2, 2, 273,
-	232, 3, 2, 2, 2, 273, 256, 3, 2, 2, 2, 274, 58, 3, 2, 2, 2, 275, 281, 7,
-	41, 2, 2, 276, 280, 10, 8, 2, 2, 277, 278, 7, 41, 2, 2, 278, 280, 7, 41,
-	2, 2, 279, 276, 3, 2, 2, 2, 279, 277, 3, 2, 2, 2, 280, 283, 3, 2, 2, 2,
-	281, 279, 3, 2, 2, 2, 281, 282, 3, 2, 2, 2, 282, 284, 3, 2, 2, 2, 283,
-	281, 3, 2, 2, 2, 284, 285, 7, 41, 2, 2, 285, 60, 3, 2, 2, 2, 286, 287,
-	9, 9, 2, 2, 287, 288, 3, 2, 2, 2, 288, 289, 8, 31, 2, 2, 289, 62, 3, 2,
-	2, 2, 290, 291, 9, 10, 2, 2, 291, 64, 3, 2, 2, 2, 292, 293, 9, 11, 2, 2,
-	293, 66, 3, 2, 2, 2, 294, 295, 9, 12, 2, 2, 295, 68, 3, 2, 2, 2, 296, 297,
-	9, 13, 2, 2, 297, 70, 3, 2, 2, 2, 298, 299, 9, 14, 2, 2, 299, 72, 3, 2,
-	2, 2, 300, 301, 9, 15, 2, 2, 301, 74, 3, 2, 2, 2, 302, 303, 9, 16, 2, 2,
-	303, 76, 3, 2, 2, 2, 304, 305, 9, 17, 2, 2, 305, 78, 3, 2, 2, 2, 306, 307,
-	9, 18, 2, 2, 307, 80, 3, 2, 2, 2, 308, 309, 9, 19, 2, 2, 309, 82, 3, 2,
-	2, 2, 310, 311, 9, 20, 2, 2, 311, 84, 3, 2, 2, 2, 312, 313, 9, 21, 2, 2,
-	313, 86, 3, 2, 2, 2, 314, 315, 9, 22, 2, 2, 315, 88, 3, 2, 2, 2, 316, 317,
-	9, 23, 2, 2, 317, 90, 3, 2, 2, 2, 318, 319, 9, 24, 2, 2, 319, 92, 3, 2,
-	2, 2, 320, 321, 9, 25, 2, 2, 321, 94, 3, 2, 2, 2, 322, 323, 9, 26, 2, 2,
-	323, 96, 3, 2, 2, 2, 324, 325, 9, 27, 2, 2, 325, 98, 3, 2, 2, 2, 326, 327,
-	9, 28, 2, 2, 327, 100, 3, 2, 2, 2, 328, 329, 9, 29, 2, 2, 329, 102, 3,
-	2, 2, 2, 330, 331, 9, 30, 2, 2, 331, 104, 3, 2, 2, 2, 332, 333, 9, 31,
-	2, 2, 333, 106, 3, 2, 2, 2, 334, 335, 9, 32, 2, 2, 335, 108, 3, 2, 2, 2,
-	336, 337, 9, 33, 2, 2, 337, 110, 3, 2, 2, 2, 338, 339, 9, 34, 2, 2, 339,
-	112, 3, 2, 2, 2, 340, 341, 9, 35, 2, 2, 341, 114, 3, 2, 2, 2, 342, 343,
-	9, 36, 2, 2, 343, 116, 3, 2, 2, 2, 23, 2, 198, 200, 208, 210, 218, 226,
-	229, 234, 240, 243, 247, 252, 254, 260, 264, 269, 271, 273, 279, 281, 3,
-	2, 3, 2,
+	20, 3, 21, 3, 21, 3, 21, 3, 21, 3, 21, 3, 21, 3, 22, 3, 22, 3, 22, 3, 22,
+	3, 22, 3, 22, 3, 22, 3, 22, 3, 22, 3, 23, 3, 23, 3, 23, 3, 23, 3, 23, 3,
+	23, 3, 23, 3, 23, 3, 23, 3, 23, 3, 23, 3, 24, 3, 24, 3, 24, 3, 24, 3, 24,
+	3, 24, 3, 24, 3, 24, 3, 24, 3, 25, 3, 25, 3, 25, 3, 25, 3, 26, 3, 26, 3,
+	26, 3, 27, 3, 27, 3, 27, 3, 27, 3, 27, 3, 27, 3, 27, 3, 27, 3, 28, 3, 28,
+	3, 28, 3, 29, 3, 29, 3, 29, 3, 30, 3, 30, 3, 30, 3, 30, 3, 30, 3, 31, 3,
+	31, 3, 31, 3, 31, 3, 32, 3, 32, 3, 32, 3, 32, 3, 32, 3, 33, 3, 33, 3, 33,
+	3, 33, 3, 33, 3, 33, 3, 34, 3, 34, 3, 34, 3, 34, 3, 35, 3, 35, 3, 35, 3,
+	35, 3, 35, 3, 36, 3, 36, 3, 36, 3, 36, 3, 36, 3, 36, 3, 36, 3, 37, 3, 37,
+	3, 37, 3, 38, 3, 38, 3, 38, 3, 38, 3, 38, 3, 38, 3, 39, 3, 39, 3, 39, 3,
+	40, 3, 40, 3, 40, 3, 40, 3, 41, 3, 41, 3, 41, 3, 41, 3, 41, 3, 42, 3, 42,
+	3, 42, 3, 42, 3, 42, 3, 42, 3, 43, 3, 43, 3, 43, 3, 43, 3, 43, 3, 43, 3,
+	43, 3, 44, 3, 44, 3, 44, 3, 44, 3, 44, 3, 44, 3, 44, 3, 45, 3, 45, 3, 45,
+	3, 45, 3, 45, 3, 45, 3, 46, 3, 46, 3, 46, 3, 46, 7, 46, 350, 10, 46, 12,
+	46, 14, 46, 353, 11, 46, 3, 46, 3, 46, 3, 46, 3, 46, 3, 46, 7, 46, 360,
+	10, 46, 12, 46, 14, 46, 363, 11, 46, 3, 46, 3, 46, 3, 46, 7, 46, 368, 10,
+	46, 12, 46, 14, 46, 371, 11, 46, 3, 46, 3, 46, 3, 46, 7, 46, 376, 10, 46,
+	12, 46, 14, 46, 379, 11, 46, 5, 46, 381, 10, 46, 3, 47, 6, 47, 384, 10,
+	47, 13, 47, 14, 47, 385, 3, 47, 3, 47, 7, 47, 390, 10, 47, 12, 47, 14,
+	47, 393, 11, 47, 5, 47, 395, 10, 47, 3, 47, 3, 47, 5, 47, 399, 10, 47,
+	3, 47, 6, 47, 402, 10, 47, 13, 47, 14, 47, 403, 5, 47, 406, 10, 47, 3,
+	47, 3, 47, 6, 47, 410, 10, 47, 13, 47, 14, 47, 411, 3, 47, 3, 47, 5, 47,
+	416, 10, 47, 3, 47, 6, 47, 419, 10, 47, 13, 47, 14, 47, 420, 5, 47, 423,
+	10, 47, 5, 47, 425, 10, 47, 3, 48, 3, 48, 3, 48, 3, 48, 3, 48, 3, 48, 3,
+	48, 3, 48, 3, 48, 3, 48, 3, 48, 3, 48, 3, 48, 3, 48, 3, 48, 3, 48, 3, 48,
+	3, 48, 3, 48, 3, 48, 3, 48, 6, 48, 448, 10, 48, 13, 48, 14, 48, 449, 5,
+	48, 452, 10, 48, 3, 48, 3, 48, 3, 48, 3, 48, 3, 48, 3, 48, 3, 48, 3, 48,
+	5, 48, 462, 10, 48, 5, 48, 464, 10, 48, 3, 49, 6, 49, 467, 10, 49, 13,
+	49, 14, 49, 468, 3, 49, 3, 49, 6, 49, 473, 10, 49, 13, 49, 14, 49, 474,
+	5, 49, 477, 10, 49, 3, 49, 3, 49, 3, 49, 3, 49, 3, 49, 3, 49, 3, 49, 5,
+	49, 486, 10, 49, 6, 49, 488, 10, 49, 13, 49, 14, 49, 489, 3, 50, 6, 50,
+	493, 10, 50, 13, 50, 14, 50, 494, 3, 50, 3, 50, 6, 50, 499, 10, 50, 13,
+	50, 14, 50, 500, 5, 50, 503, 10, 50, 3, 50, 3, 50, 3, 50, 3, 50, 3, 50,
+	3, 50, 3, 50, 5, 50, 512, 10, 50, 3, 51, 3, 51, 3, 51, 3, 51, 7, 51, 518,
+	10, 51, 12, 51, 14, 51, 521, 11, 51, 3, 51, 3, 51, 3, 52, 3, 52, 3, 52,
+	3, 52, 3, 53, 3, 53, 3, 54, 3, 54, 3, 55, 3, 55, 3, 56, 3, 56, 3, 57, 3,
+	57, 3, 58, 3, 58, 3, 59, 3, 59, 3, 60, 3, 60, 3, 61, 3, 61, 3, 62, 3, 62,
+	3, 63, 3, 63, 3, 64, 3, 64, 3, 65, 3, 65, 3, 66, 3, 66, 3, 67, 3, 67, 3,
+	68, 3, 68, 3, 69, 3, 69, 3, 70, 3, 70, 3, 71, 3, 71, 3, 72, 3, 72, 3, 73,
+	3, 73, 3, 74, 3, 74, 3, 75, 3, 75, 3, 76, 3, 76, 3, 77, 3, 77, 3, 78, 3,
+	78, 3, 79, 3, 79, 2, 2, 80, 3, 3, 5, 4, 7, 5, 9, 6, 11, 7, 13, 8, 15, 9,
+	17, 10, 19, 11, 21, 12, 23, 13, 25, 14, 27, 15, 29, 16, 31, 17, 33, 18,
+	35, 19, 37, 20, 39, 21, 41, 22, 43, 23, 45, 24, 47, 25, 49, 26, 51, 27,
+	53, 28, 55, 29, 57, 30, 59, 31, 61, 32, 63, 33, 65, 34, 67, 35, 69, 36,
+	71, 37, 73, 38, 75, 39, 77, 40, 79, 41, 81, 42, 83, 43, 85, 44, 87, 45,
+	89, 46, 91, 47, 93, 48, 95, 49, 97, 50, 99, 51, 101, 52, 103, 53, 105,
+	2, 107, 2, 109, 2, 111, 2, 113, 2, 115, 2, 117, 2, 119, 2, 121, 2, 123,
+	2, 125, 2, 127, 2, 129, 2, 131, 2, 133, 2, 135, 2, 137, 2, 139, 2, 141,
+	2, 143, 2, 145, 2, 147, 2, 149, 2, 151, 2, 153, 2, 155, 2, 157, 2, 3, 2,
+	38, 3, 2, 36, 36, 3, 2, 98, 98, 3, 2, 95, 95, 5, 2, 67, 92, 97, 97, 99,
+	124, 6, 2, 50, 59, 67, 92, 97, 97, 99, 124, 4, 2, 45, 45, 47, 47, 6, 2,
+	102, 102, 106, 106, 111, 111, 117, 117, 3, 2, 41, 41, 5, 2, 11, 13, 15,
+	15, 34, 34, 3, 2, 50, 59, 4, 2, 67, 67, 99, 99, 4, 2, 68, 68, 100, 100,
+	4, 2, 69, 69, 101, 101, 4, 2, 70, 70, 102, 102, 4, 2, 71, 71, 103, 103,
+	4, 2, 72, 72, 104, 104, 4, 2, 73, 73, 105, 105, 4, 2, 74, 74, 106, 106,
+	4, 2, 75, 75, 107, 107, 4, 2, 76, 76, 108, 108, 4, 2, 77, 77, 109, 109,
+	4, 2, 78, 78, 110, 110, 4, 2, 79, 79, 111, 111, 4, 2, 80, 80, 112, 112,
+	4, 2, 81, 81, 113, 113, 4, 2, 82, 82, 114, 114, 4, 2, 83, 83, 115, 115,
+	4, 2, 84, 84, 116, 116, 4, 2, 85, 85, 117, 117, 4, 2, 86, 86, 118, 118,
+	4, 2, 87, 87, 119, 119, 4, 2, 88, 88, 120, 120, 4, 2, 89, 89, 121, 121,
+	4, 2, 90, 90, 122, 122, 4, 2, 91, 91, 123, 123, 4, 2, 92, 92, 124, 124,
+	2, 592, 2, 3, 3, 2, 2, 2, 2, 5, 3, 2, 2, 2, 2, 7, 3, 2, 2, 2, 2, 9, 3,
+	2, 2, 2, 2, 11, 3, 2, 2, 2, 2, 13, 3, 2, 2, 2, 2, 15, 3, 2, 2, 2, 2, 17,
+	3, 2, 2, 2, 2, 19, 3, 2, 2, 2, 2, 21, 3, 2, 2, 2, 2, 23, 3, 2, 2, 2, 2,
+	25, 3, 2, 2, 2, 2, 27, 3, 2, 2, 2, 2, 29, 3, 2, 2, 2, 2, 31, 3, 2, 2, 2,
+	2, 33, 3, 2, 2, 2, 2, 35, 3, 2, 2, 2, 2, 37, 3, 2, 2, 2, 2, 39, 3, 2, 2,
+	2, 2, 41, 3, 2, 2, 2, 2, 43, 3, 2, 2, 2, 2, 45, 3, 2, 2, 2, 2, 47, 3, 2,
+	2, 2, 2, 49, 3, 2, 2, 2, 2, 51, 3, 2, 2, 2, 2, 53, 3, 2, 2, 2, 2, 55, 3,
+	2, 2, 2, 2, 57, 3, 2, 2, 2, 2, 59, 3, 2, 2, 2, 2, 61, 3, 2, 2, 2, 2, 63,
+	3, 2, 2, 2, 2, 65, 3, 2, 2, 2, 2, 67, 3, 2, 2, 2, 2, 69, 3, 2, 2, 2, 2,
+	71, 3, 2, 2, 2, 2, 73, 3, 2, 2, 2, 2, 75, 3, 2, 2, 2, 2, 77, 3, 2, 2, 2,
+	2, 79, 3, 2, 2, 2, 2, 81, 3, 2, 2, 2, 2, 83, 3, 2, 2, 2, 2, 85, 3, 2, 2,
+	2, 2, 87, 3, 2, 2, 2, 2, 89, 3, 2, 2, 2, 2, 91, 3, 2, 2, 2, 2, 93, 3, 2,
+	2, 2, 2, 95, 3, 2, 2, 2, 2, 97, 3, 2, 2, 2, 2, 99, 3, 2, 2, 2, 2, 101,
+	3, 2, 2, 2, 2, 103, 3, 2, 2, 2, 3, 159, 3, 2, 2, 2, 5, 161, 3, 2, 2, 2,
+	7, 163, 3, 2, 2, 2, 9, 165, 3, 2, 2, 2, 11, 167, 3, 2, 2, 2, 13, 170, 3,
+	2, 2, 2, 15, 172, 3, 2, 2, 2, 17, 175, 3, 2, 2, 2, 19, 177, 3, 2, 2, 2,
+	21, 180, 3, 2, 2, 2, 23, 183, 3, 2, 2, 2, 25, 186, 3, 2, 2, 2, 27, 189,
+	3, 2, 2, 2, 29, 191, 3, 2, 2, 2, 31, 193, 3, 2, 2, 2, 33, 195, 3, 2, 2,
+	2, 35, 197, 3, 2, 2, 2, 37, 199, 3, 2, 2, 2, 39, 201, 3, 2, 2, 2, 41, 206,
+	3, 2, 2, 2, 43, 212, 3, 2, 2, 2, 45, 221, 3, 2, 2, 2, 47, 232, 3, 2, 2,
+	2, 49, 241, 3, 2, 2, 2, 51, 245, 3, 2, 2, 2, 53, 248, 3, 2, 2, 2, 55, 256,
+	3, 2, 2, 2, 57, 259, 3, 2, 2, 2, 59, 262, 3, 2, 2, 2, 61, 267, 3, 2, 2,
+	2, 63, 271, 3, 2, 2, 2, 65, 276, 3, 2, 2, 2, 67, 282, 3, 2, 2, 2, 69, 286,
+	3, 2, 2, 2, 71, 291, 3, 2, 2, 2, 73, 298, 3, 2, 2, 2, 75, 301, 3, 2, 2,
+	2, 77, 307, 3, 2, 2, 2, 79, 310, 3, 2, 2, 2, 81, 314, 3, 2, 2, 2, 83, 319,
+	3, 2, 2, 2, 85, 325, 3, 2, 2, 2, 87, 332, 3, 2, 2, 2, 89, 339, 3, 2, 2,
+	2, 91, 380, 3, 2, 2, 2, 93, 424, 3, 2, 2, 2, 95, 426, 3, 2, 2, 2, 97, 487,
+	3, 2, 2, 2, 99, 492, 3, 2, 2, 2, 101, 513, 3, 2, 2, 2, 103, 524, 3, 2,
+	2, 2, 105, 528, 3, 2, 2, 2, 107, 530, 3, 2, 2, 2, 109, 532, 3, 2, 2, 2,
+	111, 534, 3, 2, 2, 2, 113, 536, 3, 2, 2, 2, 115, 538, 3, 2, 2, 2, 117,
+	540, 3, 2, 2, 2, 119, 542, 3, 2, 2, 2, 121, 544, 3, 2, 2, 2, 123, 546,
+	3, 2, 2, 2, 125, 548, 3, 2, 2, 2, 127, 550, 3, 2, 2, 2, 129, 552, 3, 2,
+	2, 2, 131, 554, 3, 2, 2, 2, 133, 556, 3, 2, 2, 2, 135, 558, 3, 2, 2, 2,
+	137, 560, 3, 2, 2, 2, 139, 562, 3, 2, 2, 2, 141, 564, 3, 2, 2, 2, 143,
+	566, 3, 2, 2, 2, 145, 568, 3, 2, 2, 2, 147, 570, 3, 2, 2, 2, 149, 572,
+	3, 2, 2, 2, 151, 574, 3, 2, 2, 2, 153, 576, 3, 2, 2, 2, 155, 578, 3, 2,
+	2, 2, 157, 580, 3, 2, 2, 2, 159, 160, 7, 46, 2, 2, 160, 4, 3, 2, 2, 2,
+	161, 162, 7, 42, 2, 2, 162, 6, 3, 2, 2, 2, 163, 164, 7, 43, 2, 2, 164,
+	8, 3, 2, 2, 2, 165, 166, 7, 62, 2, 2, 166, 10, 3, 2, 2, 2, 167, 168, 7,
+	62, 2, 2, 168, 169, 7, 63, 2, 2, 169, 12, 3, 2, 2, 2, 170, 171, 7, 64,
+	2, 2, 171, 14, 3, 2, 2, 2, 172, 173, 7, 64, 2, 2, 173, 174, 7, 63, 2, 2,
+	174, 16, 3, 2, 2, 2, 175, 176, 7, 63, 2, 2, 176, 18, 3, 2, 2, 2, 177, 178,
+	7, 35, 2, 2, 178, 179, 7, 63, 2, 2, 179, 20, 3, 2, 2, 2, 180, 181, 7, 62,
+	2, 2, 181, 182, 7, 64, 2, 2, 182, 22, 3, 2, 2, 2, 183, 184, 7, 128, 2,
+	2, 184, 185, 7, 63, 2, 2, 185, 24, 3, 2, 2, 2, 186, 187, 7, 128, 2, 2,
+	187, 188, 7, 35, 2, 2, 188, 26, 3, 2, 2, 2, 189, 190, 7, 48, 2, 2, 190,
+	28, 3, 2, 2, 2, 191, 192, 7, 44, 2, 2, 192, 30, 3, 2, 2, 2, 193, 194, 7,
+	49, 2, 2, 194, 32, 3, 2, 2, 2, 195, 196, 7, 39, 2, 2, 196, 34, 3, 2, 2,
+	2, 197, 198, 7, 45, 2, 2, 198, 36, 3, 2, 2, 2, 199, 200, 7, 47, 2, 2, 200,
+	38, 3, 2, 2, 2, 201, 202, 5, 129, 65, 2, 202, 203, 5, 123, 62, 2, 203,
+	204, 5, 127, 64, 2, 204, 205, 5, 115, 58, 2, 205, 40, 3, 2, 2, 2, 206,
+	207, 5, 123, 62, 2, 207, 208, 5, 129, 65, 2, 208, 209, 5, 123, 62, 2, 209,
+	210, 5, 127, 64, 2, 210, 211, 5, 115, 58, 2, 211, 42, 3, 2, 2, 2, 212,
+	213, 5, 111, 56, 2, 213, 214, 5, 135, 68, 2, 214, 215, 5, 133, 67, 2, 215,
+	216, 5, 145, 73, 2, 216, 217, 5, 107, 54, 2, 217, 218, 5, 123, 62, 2, 218,
+	219, 5, 133, 67, 2, 219, 220, 5, 143, 72, 2, 220, 44, 3, 2, 2, 2, 221,
+	222, 5, 143, 72, 2, 222, 223, 5, 145, 73, 2, 223, 224, 5, 107, 54, 2, 224,
+	225, 5, 141, 71, 2, 225, 226, 5, 145, 73, 2, 226, 227, 5, 143, 72, 2, 227,
+	228, 5, 151, 76, 2, 228, 229, 5, 123, 62, 2, 229, 230, 5, 145, 73, 2, 230,
+	231, 5, 121, 61, 2, 231, 46, 3, 2, 2, 2, 232, 233, 5, 115, 58, 2, 233,
+	234, 5, 133, 67, 2, 234, 235, 5, 113, 57, 2, 235, 236, 5, 143, 72, 2, 236,
+	237, 5, 151, 76, 2, 237, 238, 5, 123, 62, 2, 238, 239, 5, 145, 73, 2, 239,
+	240, 5, 121, 61, 2, 240, 48, 3, 2, 2, 2, 241, 242, 5, 107, 54, 2, 242,
+	243, 5, 133, 67, 2, 243, 244, 5, 113, 57, 2, 244, 50, 3, 2, 2, 2, 245,
+	246, 5, 135, 68, 2, 246, 247, 5, 141, 71, 2, 247, 52, 3, 2, 2, 2, 248,
+	249, 5, 109, 55, 2, 249, 250, 5, 115, 58, 2, 250, 251, 5, 145, 73, 2, 251,
+	252, 5, 151, 76, 2, 252, 253, 5, 115, 58, 2, 253, 254, 5, 115, 58, 2, 254,
+	255, 5, 133, 67, 2, 255, 54, 3, 2, 2, 2, 256, 257, 5, 123, 62, 2, 257,
+	258, 5, 133, 67, 2, 258, 56, 3, 2, 2, 2, 259, 260, 5, 123, 62, 2, 260,
+	261, 5, 143, 72, 2, 261, 58, 3, 2, 2, 2, 262, 263, 5, 133, 67, 2, 263,
+	264, 5, 147, 74, 2, 264, 265, 5, 129, 65, 2, 265, 266, 5, 129, 65, 2, 266,
+	60, 3, 2, 2, 2, 267, 268, 5, 133, 67, 2, 268, 269, 5, 135, 68, 2, 269,
+	270, 5, 145, 73, 2, 270, 62, 3, 2, 2, 2, 271, 272, 5, 145, 73, 2, 272,
+	273, 5, 141, 71, 2, 273, 274, 5, 147, 74, 2, 274, 275, 5, 115, 58, 2, 275,
+	64, 3, 2, 2, 2, 276, 277, 5, 117, 59, 2, 277, 278, 5, 107, 54, 2, 278,
+	279, 5, 129, 65, 2, 279, 280, 5, 143, 72, 2, 280, 281, 5, 115, 58, 2, 281,
+	66, 3, 2, 2, 2, 282, 283, 5, 133, 67, 2, 283, 284, 5, 135, 68, 2, 284,
+	285, 5, 151, 76, 2, 285, 68, 3, 2, 2, 2, 286, 287, 5, 113, 57, 2, 287,
+	288, 5, 107, 54, 2, 288, 289, 5, 145, 73, 2, 289, 290, 5, 115, 58, 2, 290,
+	70, 3, 2, 2, 2, 291, 292, 5, 151, 76, 2, 292, 293, 5, 123, 62, 2, 293,
+	294, 5, 145, 73, 2, 294, 295, 5, 121, 61, 2, 295, 296, 5, 123, 62, 2, 296,
+	297, 5, 133, 67, 2, 297, 72, 3, 2, 2, 2, 298, 299, 5, 135, 68, 2, 299,
+	300, 5, 117, 59, 2, 300, 74, 3, 2, 2, 2, 301, 302, 5, 135, 68, 2, 302,
+	303, 5, 141, 71, 2, 303, 304, 5, 113, 57, 2, 304, 305, 5, 115, 58, 2, 305,
+	306, 5, 141, 71, 2, 306, 76, 3, 2, 2, 2, 307, 308, 5, 109, 55, 2, 308,
+	309, 5, 155, 78, 2, 309, 78, 3, 2, 2, 2, 310, 311, 5, 107, 54, 2, 311,
+	312, 5, 143, 72, 2, 312, 313, 5, 111, 56, 2, 313, 80, 3, 2, 2, 2, 314,
+	315, 5, 113, 57, 2, 315, 316, 5, 115, 58, 2, 316, 317, 5, 143, 72, 2, 317,
+	318, 5, 111, 56, 2, 318, 82, 3, 2, 2, 2, 319, 320, 5, 129, 65, 2, 320,
+	321, 5, 123, 62, 2, 321, 322, 5, 131, 66, 2, 322, 323, 5, 123, 62, 2, 323,
+	324, 5, 145, 73, 2, 324, 84, 3, 2, 2, 2, 325, 326, 5, 135, 68, 2, 326,
+	327, 5, 117, 59, 2, 327, 328, 5, 117, 59, 2, 328, 329, 5, 143, 72, 2, 329,
+	330, 5, 115, 58, 2, 330, 331, 5, 145, 73, 2, 331, 86, 3, 2, 2, 2, 332,
+	333, 5, 117, 59, 2, 333, 334, 5, 123, 62, 2, 334, 335, 5, 115, 58, 2, 335,
+	336, 5, 129, 65, 2, 336, 337, 5, 113, 57, 2, 337, 338, 5, 143, 72, 2, 338,
+	88, 3, 2, 2, 2, 339, 340, 5, 151, 76, 2, 340, 341, 5, 121, 61, 2, 341,
+	342, 5, 115, 58, 2, 342, 343, 5, 141, 71, 2, 343, 344, 5, 115, 58, 2, 344,
+	90, 3, 2, 2, 2, 345, 351, 7, 36, 2, 2, 346, 350, 10, 2, 2, 2, 347, 348,
+	7, 36, 2, 2, 348, 350, 7, 36, 2, 2, 349, 346, 3, 2, 2, 2, 349, 347, 3,
+	2, 2, 2, 350, 353, 3, 2, 2, 2, 351, 349, 3, 2, 2, 2, 351, 352, 3, 2, 2,
+	2, 352, 354, 3, 2, 2, 2, 353, 351, 3, 2, 2, 2, 354, 381, 7, 36, 2, 2, 355,
+	361, 7, 98, 2, 2, 356, 360, 10, 3, 2, 2, 357, 358, 7, 98, 2, 2, 358, 360,
+	7, 98, 2, 2, 359, 356, 3, 2, 2, 2, 359, 357, 3, 2, 2, 2, 360, 363, 3, 2,
+	2, 2, 361, 359, 3, 2, 2, 2, 361, 362, 3, 2, 2, 2, 362, 364, 3, 2, 2, 2,
+	363, 361, 3, 2, 2, 2, 364, 381, 7, 98, 2, 2, 365, 369, 7, 93, 2, 2, 366,
+	368, 10, 4, 2, 2, 367, 366, 3, 2, 2, 2, 368, 371, 3, 2, 2, 2, 369, 367,
+	3, 2, 2, 2, 369, 370, 3, 2, 2, 2, 370, 372, 3, 2, 2, 2, 371, 369, 3, 2,
+	2, 2, 372, 381, 7, 95, 2, 2, 373, 377, 9, 5, 2, 2, 374, 376, 9, 6, 2, 2,
+	375, 374, 3, 2, 2, 2, 376, 379, 3, 2, 2, 2, 377, 375, 3, 2, 2, 2, 377,
+	378, 3, 2, 2, 2, 378, 381, 3, 2, 2, 2, 379, 377, 3, 2, 2, 2, 380, 345,
+	3, 2, 2, 2, 380, 355, 3, 2, 2, 2, 380, 365, 3, 2, 2, 2, 380, 373, 3, 2,
+	2, 2, 381, 92, 3, 2, 2, 2, 382, 384, 5, 105, 53, 2, 383, 382, 3, 2, 2,
+	2, 384, 385, 3, 2, 2, 2, 385, 383, 3, 2, 2, 2, 385, 386, 3, 2, 2, 2, 386,
+	394, 3, 2, 2, 2, 387, 391, 7, 48, 2, 2, 388, 390, 5, 105, 53, 2, 389, 388,
+	3, 2, 2, 2, 390, 393, 3, 2, 2, 2, 391, 389, 3, 2, 2, 2, 391, 392, 3, 2,
+	2, 2, 392, 395, 3, 2, 2, 2, 393, 391, 3, 2, 2, 2, 394, 387, 3, 2, 2, 2,
+	394, 395, 3, 2, 2, 2, 395, 405, 3, 2, 2, 2, 396, 398, 5, 115, 58, 2, 397,
+	399, 9, 7, 2, 2, 398, 397, 3, 2, 2, 2, 398, 399, 3, 2, 2, 2, 399, 401,
+	3, 2, 2, 2, 400, 402, 5, 105, 53, 2, 401, 400, 3, 2, 2, 2, 402, 403, 3,
+	2, 2, 2, 403, 401, 3, 2, 2, 2, 403, 404, 3, 2, 2, 2, 404, 406, 3, 2, 2,
+	2, 405, 396, 3, 2, 2, 2, 405, 406, 3, 2, 2, 2, 406, 425, 3, 2, 2, 2, 407,
+	409, 7, 48, 2, 2, 408, 410, 5, 105, 53, 2, 409, 408, 3, 2, 2, 2, 410, 411,
+	3, 2, 2, 2, 411, 409, 3, 2, 2, 2, 411, 412, 3, 2, 2, 2, 412, 422, 3, 2,
+	2, 2, 413, 415, 5, 115, 58, 2, 414, 416, 9, 7, 2, 2, 415, 414, 3, 2, 2,
+	2, 415, 416, 3, 2, 2, 2, 416, 418, 3, 2, 2, 2, 417, 419, 5, 105, 53, 2,
+	418, 417, 3, 2, 2, 2, 419, 420, 3, 2, 2, 2, 420, 418, 3, 2, 2, 2, 420,
+	421, 3, 2, 2, 2, 421, 423, 3, 2, 2, 2, 422, 413, 3, 2, 2, 2, 422, 423,
+	3, 2, 2, 2, 423, 425, 3, 2, 2, 2, 424, 383, 3, 2, 2, 2, 424, 407, 3, 2,
+	2, 2, 425, 94, 3, 2, 2, 2, 426, 427, 5, 105, 53, 2, 427, 428, 5, 105, 53,
+	2, 428, 429, 5, 105, 53, 2, 429, 430, 5, 105, 53, 2, 430, 431, 7, 47, 2,
+	2, 431, 432, 5, 105, 53, 2, 432, 433, 5, 105, 53, 2, 433, 434, 7, 47, 2,
+	2, 434, 435, 5, 105, 53, 2, 435, 463, 5, 105, 53, 2, 436, 437, 7, 86, 2,
+	2, 437, 438, 5, 105, 53, 2, 438, 439, 5, 105, 53, 2, 439, 440, 7, 60, 2,
+	2, 440, 441, 5, 105, 53, 2, 441, 442, 5, 105, 53, 2, 442, 443, 7, 60, 2,
+	2, 443, 444, 5, 105, 53, 2, 444, 451, 5, 105, 53, 2, 445, 447, 7, 48, 2,
+	2, 446, 448, 5, 105, 53, 2, 447, 446, 3, 2, 2, 2, 448, 449, 3, 2, 2, 2,
+	449, 447, 3, 2, 2, 2, 449, 450, 3, 2, 2, 2, 450, 452, 3, 2, 2, 2, 451,
+	445, 3, 2, 2, 2, 451, 452, 3, 2, 2, 2, 452, 461, 3, 2, 2, 2, 453, 462,
+	7, 92, 2, 2, 454, 455, 9, 7, 2, 2, 455, 456, 5, 105, 53, 2, 456, 457, 5,
+	105, 53, 2, 457, 458, 7, 60, 2, 2, 458, 459, 5, 105, 53, 2, 459, 460, 5,
+	105, 53, 2, 460, 462, 3, 2, 2, 2, 461, 453, 3, 2, 2, 2, 461, 454, 3, 2,
+	2, 2, 462, 464, 3, 2, 2, 2, 463, 436, 3, 2, 2, 2, 463, 464, 3, 2, 2, 2,
+	464, 96, 3, 2, 2, 2, 465, 467, 5, 105, 53, 2, 466, 465, 3, 2, 2, 2, 467,
+	468, 3, 2, 2, 2, 468, 466, 3, 2, 2, 2, 468, 469, 3, 2, 2, 2, 469, 476,
+	3, 2, 2, 2, 470, 472, 7, 48, 2, 2, 471, 473, 5, 105, 53, 2, 472, 471, 3,
+	2, 2, 2, 473, 474, 3, 2, 2, 2, 474, 472, 3, 2, 2, 2, 474, 475, 3, 2, 2,
+	2, 475, 477, 3, 2, 2, 2, 476, 470, 3, 2, 2, 2, 476, 477, 3, 2, 2, 2, 477,
+	485, 3, 2, 2, 2, 478, 479, 7, 112, 2, 2, 479, 486, 7, 117, 2, 2, 480, 481,
+	7, 119, 2, 2, 481, 486, 7, 117, 2, 2, 482, 483, 7, 111, 2, 2, 483, 486,
+	7, 117, 2, 2, 484, 486, 9, 8, 2, 2, 485, 478, 3, 2, 2, 2, 485, 480, 3,
+	2, 2, 2, 485, 482, 3, 2, 2, 2, 485, 484, 3, 2, 2, 2, 486, 488, 3, 2, 2,
+	2, 487, 466, 3, 2, 2, 2, 488, 489, 3, 2, 2, 2, 489, 487, 3, 2, 2, 2, 489,
+	490, 3, 2, 2, 2, 490, 98, 3, 2, 2, 2, 491, 493, 5, 105, 53, 2, 492, 491,
+	3, 2, 2, 2, 493, 494, 3, 2, 2, 2, 494, 492, 3, 2, 2, 2, 494, 495, 3, 2,
+	2, 2, 495, 502, 3, 2, 2, 2, 496, 498, 7, 48, 2, 2, 497, 499, 5, 105, 53,
+	2, 498, 497, 3, 2, 2, 2, 499, 500, 3, 2, 2, 2, 500, 498, 3, 2, 2, 2, 500,
+	501, 3, 2, 2, 2, 501, 503, 3, 2, 2, 2, 502, 496, 3, 2, 2, 2, 502, 503,
+	3, 2, 2, 2, 503, 511, 3, 2, 2, 2, 504, 512, 5, 131, 66, 2, 505, 506, 5,
+	127, 64, 2, 506, 507, 5, 131, 66, 2, 507, 512, 3, 2, 2, 2, 508, 509, 5,
+	131, 66, 2, 509, 510, 5, 123, 62, 2, 510, 512, 3, 2, 2, 2, 511, 504, 3,
+	2, 2, 2, 511, 505, 3, 2, 2, 2, 511, 508, 3, 2, 2, 2, 512, 100, 3, 2, 2,
+	2, 513, 519, 7, 41, 2, 2, 514, 518, 10, 9, 2, 2, 515, 516, 7, 41, 2, 2,
+	516, 518, 7, 41, 2, 2, 517, 514, 3, 2, 2, 2, 517, 515, 3, 2, 2, 2, 518,
+	521, 3, 2, 2, 2, 519, 517, 3, 2, 2, 2, 519, 520, 3, 2, 2, 2, 520, 522,
+	3, 2, 2, 2, 521, 519, 3, 2, 2, 2, 522, 523, 7, 41, 2, 2, 523, 102, 3, 2,
+	2, 2, 524, 525, 9, 10, 2, 2, 525, 526, 3, 2, 2, 2, 526, 527, 8, 52, 2,
+	2, 527, 104, 3, 2, 2, 2, 528, 529, 9, 11, 2, 2, 529, 106, 3, 2, 2, 2, 530,
+	531, 9, 12, 2, 2, 531, 108, 3, 2, 2, 2, 532, 533, 9, 13, 2, 2, 533, 110,
+	3, 2, 2, 2, 534, 535, 9, 14, 2, 2, 535, 112, 3, 2, 2, 2, 536, 537, 9, 15,
+	2, 2, 537, 114, 3, 2, 2, 2, 538, 539, 9, 16, 2, 2, 539, 116, 3, 2, 2, 2,
+	540, 541, 9, 17, 2, 2, 541, 118, 3, 2, 2, 2, 542, 543, 9, 18, 2, 2, 543,
+	120, 3, 2, 2, 2, 544, 545, 9, 19, 2, 2, 545, 122, 3, 2, 2, 2, 546, 547,
+	9, 20, 2, 2, 547, 124, 3, 2, 2, 2, 548, 549, 9, 21, 2, 2, 549, 126, 3,
+	2, 2, 2, 550, 551, 9, 22, 2, 2, 551, 128, 3, 2, 2, 2, 552, 553, 9, 23,
+	2, 2, 553, 130, 3, 2, 2, 2, 554, 555, 9, 24, 2, 2, 555, 132, 3, 2, 2, 2,
+	556, 557, 9, 25, 2, 2, 557, 134, 3, 2, 2, 2, 558, 559, 9, 26, 2, 2, 559,
+	136, 3, 2, 2, 2, 560, 561, 9, 27, 2, 2, 561, 138, 3, 2, 2, 2, 562, 563,
+	9, 28, 2, 2, 563, 140, 3, 2, 2, 2, 564, 565, 9, 29, 2, 2, 565, 142, 3,
+	2, 2, 2, 566, 567, 9, 30, 2, 2, 567, 144, 3, 2, 2, 2, 568, 569, 9, 31,
+	2, 2, 569, 146, 3, 2, 2, 2, 570, 571, 9, 32, 2, 2, 571, 148, 3, 2, 2, 2,
+	572, 573, 9, 33, 2, 2, 573, 150, 3, 2, 2, 2, 574, 575, 9, 34, 2, 2, 575,
+	152, 3, 2, 2, 2, 576, 577, 9, 35, 2, 2, 577, 154, 3, 2, 2, 2, 578, 579,
+	9, 36, 2, 2, 579, 156, 3, 2, 2, 2, 580, 581, 9, 37, 2, 2, 581, 158, 3,
+	2, 2, 2, 36, 2, 349, 351, 359, 361, 369, 377, 380, 385, 391, 394, 398,
+	403, 405, 411, 415, 420, 422, 424, 449, 451, 461, 463, 468, 474, 476, 485,
+	489, 494, 500, 502, 511, 517, 519, 3, 2, 3, 2,
 }
 
 var lexerDeserializer = antlr.NewATNDeserializer(nil)
@@ -185,23 +295,30 @@ var lexerModeNames = []string{
 }
 
 var lexerLiteralNames = []string{
-	"", "'('", "','", "')'", "'<'", "'<='", "'>'", "'>='", "'='", "'!='", "'<>'",
+	"", "','", "'('", "')'", "'<'", "'<='", "'>'", "'>='", "'='", "'!='", "'<>'",
 	"'~='", "'~!'", "'.'", "'*'", "'/'", "'%'", "'+'", "'-'",
 }
 
 var lexerSymbolicNames = []string{
 	"", "", "", "", "", "", "", "", "", "", "", "", "", "", "", "", "", "",
-	"", "K_LIKE", "K_AND", "K_OR", "K_BETWEEN", "K_IN", "K_IS", "K_NULL", "K_NOT",
-	"IDENTIFIER", "NUMERIC_LITERAL", "STRING_LITERAL", "SPACES",
+	"", "K_LIKE", "K_ILIKE", "K_CONTAINS", "K_STARTSWITH", "K_ENDSWITH", "K_AND",
+	"K_OR", "K_BETWEEN", "K_IN", "K_IS", "K_NULL", "K_NOT", "K_TRUE", "K_FALSE",
+	"K_NOW", "K_DATE", "K_WITHIN", "K_OF", "K_ORDER", "K_BY", "K_ASC", "K_DESC",
+	"K_LIMIT", "K_OFFSET", "K_FIELDS", "K_WHERE", "IDENTIFIER", "NUMERIC_LITERAL",
+	"DATE_LITERAL", "DURATION_LITERAL", "DISTANCE_LITERAL", "STRING_LITERAL",
+	"SPACES",
 }
 
 var lexerRuleNames = []string{
 	"T__0", "T__1", "T__2", "T__3", "T__4", "T__5", "T__6", "T__7", "T__8",
 	"T__9", "T__10", "T__11", "T__12", "T__13", "T__14", "T__15", "T__16",
-	"T__17", "K_LIKE", "K_AND", "K_OR", "K_BETWEEN", "K_IN", "K_IS", "K_NULL",
-	"K_NOT", "IDENTIFIER", "NUMERIC_LITERAL", "STRING_LITERAL", "SPACES", "DIGIT",
-	"A", "B", "C", "D", "E", "F", "G", "H", "I", "J", "K", "L", "M", "N", "O",
-	"P", "Q", "R", "S", "T", "U", "V", "W", "X", "Y", "Z",
+	"T__17", "K_LIKE", "K_ILIKE", "K_CONTAINS", "K_STARTSWITH", "K_ENDSWITH",
+	"K_AND", "K_OR", "K_BETWEEN", "K_IN", "K_IS", "K_NULL", "K_NOT", "K_TRUE",
+	"K_FALSE", "K_NOW", "K_DATE", "K_WITHIN", "K_OF", "K_ORDER", "K_BY", "K_ASC",
+	"K_DESC", "K_LIMIT", "K_OFFSET", "K_FIELDS", "K_WHERE", "IDENTIFIER", "NUMERIC_LITERAL",
+	"DATE_LITERAL", "DURATION_LITERAL", "DISTANCE_LITERAL", "STRING_LITERAL",
+	"SPACES", "DIGIT", "A", "B", "C", "D", "E", "F", "G", "H", "I", "J", "K",
+	"L", "M", "N", "O", "P", "Q", "R", "S", "T", "U", "V", "W", "X", "Y", "Z",
 }
 
 type TSLLexer struct {
@@ -239,34 +356,55 @@ func NewTSLLexer(input antlr.CharStream) *TSLLexer {
 
 // TSLLexer tokens.
 const (
-	TSLLexerT__0            = 1
-	TSLLexerT__1            = 2
-	TSLLexerT__2            = 3
-	TSLLexerT__3            = 4
-	TSLLexerT__4            = 5
-	TSLLexerT__5            = 6
-	TSLLexerT__6            = 7
-	TSLLexerT__7            = 8
-	TSLLexerT__8            = 9
-	TSLLexerT__9            = 10
-	TSLLexerT__10           = 11
-	TSLLexerT__11           = 12
-	TSLLexerT__12           = 13
-	TSLLexerT__13           = 14
-	TSLLexerT__14           = 15
-	TSLLexerT__15           = 16
-	TSLLexerT__16           = 17
-	TSLLexerT__17           = 18
-	TSLLexerK_LIKE          = 19
-	TSLLexerK_AND           = 20
-	TSLLexerK_OR            = 21
-	TSLLexerK_BETWEEN       = 22
-	TSLLexerK_IN            = 23
-	TSLLexerK_IS            = 24
-	TSLLexerK_NULL          = 25
-	TSLLexerK_NOT           = 26
-	TSLLexerIDENTIFIER      = 27
-	TSLLexerNUMERIC_LITERAL = 28
-	TSLLexerSTRING_LITERAL  = 29
-	TSLLexerSPACES          = 30
+	TSLLexerT__0             = 1
+	TSLLexerT__1             = 2
+	TSLLexerT__2             = 3
+	TSLLexerT__3             = 4
+	TSLLexerT__4             = 5
+	TSLLexerT__5             = 6
+	TSLLexerT__6             = 7
+	TSLLexerT__7             = 8
+	TSLLexerT__8             = 9
+	TSLLexerT__9             = 10
+	TSLLexerT__10            = 11
+	TSLLexerT__11            = 12
+	TSLLexerT__12            = 13
+	TSLLexerT__13            = 14
+	TSLLexerT__14            = 15
+	TSLLexerT__15            = 16
+	TSLLexerT__16            = 17
+	TSLLexerT__17            = 18
+	TSLLexerK_LIKE           = 19
+	TSLLexerK_ILIKE          = 20
+	TSLLexerK_CONTAINS       = 21
+	TSLLexerK_STARTSWITH     = 22
+	TSLLexerK_ENDSWITH       = 23
+	TSLLexerK_AND            = 24
+	TSLLexerK_OR             = 25
+	TSLLexerK_BETWEEN        = 26
+	TSLLexerK_IN             = 27
+	TSLLexerK_IS             = 28
+	TSLLexerK_NULL           = 29
+	TSLLexerK_NOT            = 30
+	TSLLexerK_TRUE           = 31
+	TSLLexerK_FALSE          = 32
+	TSLLexerK_NOW            = 33
+	TSLLexerK_DATE           = 34
+	TSLLexerK_WITHIN         = 35
+	TSLLexerK_OF             = 36
+	TSLLexerK_ORDER          = 37
+	TSLLexerK_BY             = 38
+	TSLLexerK_ASC            = 39
+	TSLLexerK_DESC           = 40
+	TSLLexerK_LIMIT          = 41
+	TSLLexerK_OFFSET         = 42
+	TSLLexerK_FIELDS         = 43
+	TSLLexerK_WHERE          = 44
+	TSLLexerIDENTIFIER       = 45
+	TSLLexerNUMERIC_LITERAL  = 46
+	TSLLexerDATE_LITERAL     = 47
+	TSLLexerDURATION_LITERAL = 48
+	TSLLexerDISTANCE_LITERAL = 49
+	TSLLexerSTRING_LITERAL   = 50
+	TSLLexerSPACES           = 51
 )
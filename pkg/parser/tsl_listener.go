@@ -11,14 +11,29 @@ type TSLListener interface {
 	// EnterStart is called when entering the start production.
 	EnterStart(c *StartContext)
 
+	// EnterQuery is called when entering the query production.
+	EnterQuery(c *QueryContext)
+
+	// EnterFields is called when entering the fields production.
+	EnterFields(c *FieldsContext)
+
+	// EnterOrderBy is called when entering the orderBy production.
+	EnterOrderBy(c *OrderByContext)
+
+	// EnterSortKey is called when entering the sortKey production.
+	EnterSortKey(c *SortKeyContext)
+
+	// EnterLimit is called when entering the limit production.
+	EnterLimit(c *LimitContext)
+
+	// EnterOffset is called when entering the offset production.
+	EnterOffset(c *OffsetContext)
+
 	// EnterPar is called when entering the Par production.
 	EnterPar(c *ParContext)
 
-	// EnterNot is called when entering the Not production.
-	EnterNot(c *NotContext)
-
-	// EnterLike is called when entering the Like production.
-	EnterLike(c *LikeContext)
+	// EnterWithinBox is called when entering the WithinBox production.
+	EnterWithinBox(c *WithinBoxContext)
 
 	// EnterOr is called when entering the Or production.
 	EnterOr(c *OrContext)
@@ -29,27 +44,39 @@ type TSLListener interface {
 	// EnterIsLiteral is called when entering the IsLiteral production.
 	EnterIsLiteral(c *IsLiteralContext)
 
-	// EnterAnd is called when entering the And production.
-	EnterAnd(c *AndContext)
-
 	// EnterBetween is called when entering the Between production.
 	EnterBetween(c *BetweenContext)
 
 	// EnterStringOps is called when entering the StringOps production.
 	EnterStringOps(c *StringOpsContext)
 
-	// EnterIsNull is called when entering the IsNull production.
-	EnterIsNull(c *IsNullContext)
-
 	// EnterLiteralOps is called when entering the LiteralOps production.
 	EnterLiteralOps(c *LiteralOpsContext)
 
+	// EnterNot is called when entering the Not production.
+	EnterNot(c *NotContext)
+
+	// EnterLike is called when entering the Like production.
+	EnterLike(c *LikeContext)
+
+	// EnterAnd is called when entering the And production.
+	EnterAnd(c *AndContext)
+
+	// EnterWithin is called when entering the Within production.
+	EnterWithin(c *WithinContext)
+
+	// EnterIsNull is called when entering the IsNull production.
+	EnterIsNull(c *IsNullContext)
+
 	// EnterLiteralOp is called when entering the literalOp production.
 	EnterLiteralOp(c *LiteralOpContext)
 
 	// EnterStringOp is called when entering the stringOp production.
 	EnterStringOp(c *StringOpContext)
 
+	// EnterLikeOp is called when entering the likeOp production.
+	EnterLikeOp(c *LikeOpContext)
+
 	// EnterDatabaseName is called when entering the databaseName production.
 	EnterDatabaseName(c *DatabaseNameContext)
 
@@ -59,12 +86,24 @@ type TSLListener interface {
 	// EnterColumnName is called when entering the columnName production.
 	EnterColumnName(c *ColumnNameContext)
 
+	// EnterIdentifier is called when entering the identifier production.
+	EnterIdentifier(c *IdentifierContext)
+
 	// EnterNumberLiteral is called when entering the NumberLiteral production.
 	EnterNumberLiteral(c *NumberLiteralContext)
 
 	// EnterStringLiteral is called when entering the StringLiteral production.
 	EnterStringLiteral(c *StringLiteralContext)
 
+	// EnterBooleanLiteral is called when entering the BooleanLiteral production.
+	EnterBooleanLiteral(c *BooleanLiteralContext)
+
+	// EnterDateLiteral is called when entering the DateLiteral production.
+	EnterDateLiteral(c *DateLiteralContext)
+
+	// EnterDurationLiteral is called when entering the DurationLiteral production.
+	EnterDurationLiteral(c *DurationLiteralContext)
+
 	// EnterMathPar is called when entering the MathPar production.
 	EnterMathPar(c *MathParContext)
 
@@ -83,6 +122,9 @@ type TSLListener interface {
 	// EnterColumnIdentifier is called when entering the ColumnIdentifier production.
 	EnterColumnIdentifier(c *ColumnIdentifierContext)
 
+	// EnterFunctionCall is called when entering the FunctionCall production.
+	EnterFunctionCall(c *FunctionCallContext)
+
 	// EnterAddOps is called when entering the AddOps production.
 	EnterAddOps(c *AddOpsContext)
 
@@ -92,20 +134,56 @@ type TSLListener interface {
 	// EnterStringValue is called when entering the stringValue production.
 	EnterStringValue(c *StringValueContext)
 
+	// EnterBooleanValue is called when entering the booleanValue production.
+	EnterBooleanValue(c *BooleanValueContext)
+
+	// EnterDateValue is called when entering the dateValue production.
+	EnterDateValue(c *DateValueContext)
+
+	// EnterDateOffset is called when entering the dateOffset production.
+	EnterDateOffset(c *DateOffsetContext)
+
+	// EnterDurationValue is called when entering the durationValue production.
+	EnterDurationValue(c *DurationValueContext)
+
+	// EnterDistance is called when entering the distance production.
+	EnterDistance(c *DistanceContext)
+
+	// EnterPoint is called when entering the point production.
+	EnterPoint(c *PointContext)
+
+	// EnterBox is called when entering the box production.
+	EnterBox(c *BoxContext)
+
 	// EnterKeyNot is called when entering the keyNot production.
 	EnterKeyNot(c *KeyNotContext)
 
 	// ExitStart is called when exiting the start production.
 	ExitStart(c *StartContext)
 
+	// ExitQuery is called when exiting the query production.
+	ExitQuery(c *QueryContext)
+
+	// ExitFields is called when exiting the fields production.
+	ExitFields(c *FieldsContext)
+
+	// ExitOrderBy is called when exiting the orderBy production.
+	ExitOrderBy(c *OrderByContext)
+
+	// ExitSortKey is called when exiting the sortKey production.
+	ExitSortKey(c *SortKeyContext)
+
+	// ExitLimit is called when exiting the limit production.
+	ExitLimit(c *LimitContext)
+
+	// ExitOffset is called when exiting the offset production.
+	ExitOffset(c *OffsetContext)
+
 	// ExitPar is called when exiting the Par production.
 	ExitPar(c *ParContext)
 
-	// ExitNot is called when exiting the Not production.
-	ExitNot(c *NotContext)
-
-	// ExitLike is called when exiting the Like production.
-	ExitLike(c *LikeContext)
+	// ExitWithinBox is called when exiting the WithinBox production.
+	ExitWithinBox(c *WithinBoxContext)
 
 	// ExitOr is called when exiting the Or production.
 	ExitOr(c *OrContext)
@@ -116,27 +194,39 @@ type TSLListener interface {
 	// ExitIsLiteral is called when exiting the IsLiteral production.
 	ExitIsLiteral(c *IsLiteralContext)
 
-	// ExitAnd is called when exiting the And production.
-	ExitAnd(c *AndContext)
-
 	// ExitBetween is called when exiting the Between production.
 	ExitBetween(c *BetweenContext)
 
 	// ExitStringOps is called when exiting the StringOps production.
 	ExitStringOps(c *StringOpsContext)
 
-	// ExitIsNull is called when exiting the IsNull production.
-	ExitIsNull(c *IsNullContext)
-
 	// ExitLiteralOps is called when exiting the LiteralOps production.
 	ExitLiteralOps(c *LiteralOpsContext)
 
+	// ExitNot is called when exiting the Not production.
+	ExitNot(c *NotContext)
+
+	// ExitLike is called when exiting the Like production.
+	ExitLike(c *LikeContext)
+
+	// ExitAnd is called when exiting the And production.
+	ExitAnd(c *AndContext)
+
+	// ExitWithin is called when exiting the Within production.
+	ExitWithin(c *WithinContext)
+
+	// ExitIsNull is called when exiting the IsNull production.
+	ExitIsNull(c *IsNullContext)
+
 	// ExitLiteralOp is called when exiting the literalOp production.
 	ExitLiteralOp(c *LiteralOpContext)
 
 	// ExitStringOp is called when exiting the stringOp production.
 	ExitStringOp(c *StringOpContext)
 
+	// ExitLikeOp is called when exiting the likeOp production.
+	ExitLikeOp(c *LikeOpContext)
+
 	// ExitDatabaseName is called when exiting the databaseName production.
 	ExitDatabaseName(c *DatabaseNameContext)
 
@@ -146,12 +236,24 @@ type TSLListener interface {
 	// ExitColumnName is called when exiting the columnName production.
 	ExitColumnName(c *ColumnNameContext)
 
+	// ExitIdentifier is called when exiting the identifier production.
+	ExitIdentifier(c *IdentifierContext)
+
 	// ExitNumberLiteral is called when exiting the NumberLiteral production.
 	ExitNumberLiteral(c *NumberLiteralContext)
 
 	// ExitStringLiteral is called when exiting the StringLiteral production.
 	ExitStringLiteral(c *StringLiteralContext)
 
+	// ExitBooleanLiteral is called when exiting the BooleanLiteral production.
+	ExitBooleanLiteral(c *BooleanLiteralContext)
+
+	// ExitDateLiteral is called when exiting the DateLiteral production.
+	ExitDateLiteral(c *DateLiteralContext)
+
+	// ExitDurationLiteral is called when exiting the DurationLiteral production.
+	ExitDurationLiteral(c *DurationLiteralContext)
+
 	// ExitMathPar is called when exiting the MathPar production.
 	ExitMathPar(c *MathParContext)
 
@@ -170,6 +272,9 @@ type TSLListener interface {
 	// ExitColumnIdentifier is called when exiting the ColumnIdentifier production.
 	ExitColumnIdentifier(c *ColumnIdentifierContext)
 
+	// ExitFunctionCall is called when exiting the FunctionCall production.
+	ExitFunctionCall(c *FunctionCallContext)
+
 	// ExitAddOps is called when exiting the AddOps production.
 	ExitAddOps(c *AddOpsContext)
 
@@ -179,6 +284,27 @@ type TSLListener interface {
 	// ExitStringValue is called when exiting the stringValue production.
 	ExitStringValue(c *StringValueContext)
 
+	// ExitBooleanValue is called when exiting the booleanValue production.
+	ExitBooleanValue(c *BooleanValueContext)
+
+	// ExitDateValue is called when exiting the dateValue production.
+	ExitDateValue(c *DateValueContext)
+
+	// ExitDateOffset is called when exiting the dateOffset production.
+	ExitDateOffset(c *DateOffsetContext)
+
+	// ExitDurationValue is called when exiting the durationValue production.
+	ExitDurationValue(c *DurationValueContext)
+
+	// ExitDistance is called when exiting the distance production.
+	ExitDistance(c *DistanceContext)
+
+	// ExitPoint is called when exiting the point production.
+	ExitPoint(c *PointContext)
+
+	// ExitBox is called when exiting the box production.
+	ExitBox(c *BoxContext)
+
 	// ExitKeyNot is called when exiting the keyNot production.
 	ExitKeyNot(c *KeyNotContext)
 }
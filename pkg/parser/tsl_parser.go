@@ -16,106 +16,190 @@ var _ = reflect.Copy
 var _ = strconv.Itoa
 
 var parserATN = []uint16{
-	3, 24715, 42794, 33075, 47597, 16764, 15335, 30598, 22884, 3, 32, 184,
+	3, 24715, 42794, 33075, 47597, 16764, 15335, 30598, 22884, 3, 53, 354,
 	4, 2, 9, 2, 4, 3, 9, 3, 4, 4, 9, 4, 4, 5, 9, 5, 4, 6, 9, 6, 4, 7, 9, 7,
 	4, 8, 9, 8, 4, 9, 9, 9, 4, 10, 9, 10, 4, 11, 9, 11, 4, 12, 9, 12, 4, 13,
-	9, 13, 3, 2, 3, 2, 3, 2, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3,
-	3, 3, 3, 3, 3, 3, 5, 3, 41, 10, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3,
-	5, 3, 49, 10, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 5, 3, 56, 10, 3, 3, 3, 3,
-	3, 3, 3, 3, 3, 5, 3, 62, 10, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3,
-	3, 5, 3, 71, 10, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 7, 3, 78, 10, 3, 12,
-	3, 14, 3, 81, 11, 3, 5, 3, 83, 10, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3,
-	3, 3, 3, 3, 3, 5, 3, 93, 10, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 7,
-	3, 101, 10, 3, 12, 3, 14, 3, 104, 11, 3, 3, 4, 3, 4, 5, 4, 108, 10, 4,
-	3, 5, 3, 5, 3, 6, 3, 6, 3, 7, 3, 7, 3, 8, 3, 8, 3, 8, 5, 8, 119, 10, 8,
-	3, 8, 3, 8, 3, 8, 5, 8, 124, 10, 8, 3, 8, 3, 8, 3, 9, 3, 9, 5, 9, 130,
-	10, 9, 3, 10, 3, 10, 3, 10, 3, 10, 3, 10, 3, 10, 5, 10, 138, 10, 10, 3,
-	10, 3, 10, 3, 10, 3, 10, 5, 10, 144, 10, 10, 3, 10, 3, 10, 3, 10, 3, 10,
-	5, 10, 150, 10, 10, 3, 10, 3, 10, 3, 10, 3, 10, 5, 10, 156, 10, 10, 3,
-	10, 3, 10, 3, 10, 3, 10, 5, 10, 162, 10, 10, 3, 10, 3, 10, 3, 10, 3, 10,
-	5, 10, 168, 10, 10, 7, 10, 170, 10, 10, 12, 10, 14, 10, 173, 11, 10, 3,
-	11, 5, 11, 176, 10, 11, 3, 11, 3, 11, 3, 12, 3, 12, 3, 13, 3, 13, 3, 13,
-	2, 4, 4, 18, 14, 2, 4, 6, 8, 10, 12, 14, 16, 18, 20, 22, 24, 2, 6, 3, 2,
-	6, 9, 3, 2, 10, 12, 3, 2, 13, 14, 3, 2, 19, 20, 2, 204, 2, 26, 3, 2, 2,
-	2, 4, 92, 3, 2, 2, 2, 6, 107, 3, 2, 2, 2, 8, 109, 3, 2, 2, 2, 10, 111,
-	3, 2, 2, 2, 12, 113, 3, 2, 2, 2, 14, 123, 3, 2, 2, 2, 16, 129, 3, 2, 2,
-	2, 18, 137, 3, 2, 2, 2, 20, 175, 3, 2, 2, 2, 22, 179, 3, 2, 2, 2, 24, 181,
-	3, 2, 2, 2, 26, 27, 5, 4, 3, 2, 27, 28, 7, 2, 2, 3, 28, 3, 3, 2, 2, 2,
-	29, 30, 8, 3, 1, 2, 30, 31, 5, 18, 10, 2, 31, 32, 5, 6, 4, 2, 32, 33, 5,
-	16, 9, 2, 33, 93, 3, 2, 2, 2, 34, 35, 5, 18, 10, 2, 35, 36, 5, 8, 5, 2,
-	36, 37, 5, 16, 9, 2, 37, 93, 3, 2, 2, 2, 38, 40, 5, 18, 10, 2, 39, 41,
-	5, 24, 13, 2, 40, 39, 3, 2, 2, 2, 40, 41, 3, 2, 2, 2, 41, 42, 3, 2, 2,
-	2, 42, 43, 7, 21, 2, 2, 43, 44, 5, 16, 9, 2, 44, 93, 3, 2, 2, 2, 45, 46,
-	5, 18, 10, 2, 46, 48, 7, 26, 2, 2, 47, 49, 5, 24, 13, 2, 48, 47, 3, 2,
-	2, 2, 48, 49, 3, 2, 2, 2, 49, 50, 3, 2, 2, 2, 50, 51, 7, 27, 2, 2, 51,
-	93, 3, 2, 2, 2, 52, 53, 5, 18, 10, 2, 53, 55, 7, 26, 2, 2, 54, 56, 5, 24,
-	13, 2, 55, 54, 3, 2, 2, 2, 55, 56, 3, 2, 2, 2, 56, 57, 3, 2, 2, 2, 57,
-	58, 5, 16, 9, 2, 58, 93, 3, 2, 2, 2, 59, 61, 5, 18, 10, 2, 60, 62, 5, 24,
-	13, 2, 61, 60, 3, 2, 2, 2, 61, 62, 3, 2, 2, 2, 62, 63, 3, 2, 2, 2, 63,
-	64, 7, 24, 2, 2, 64, 65, 5, 16, 9, 2, 65, 66, 7, 22, 2, 2, 66, 67, 5, 16,
-	9, 2, 67, 93, 3, 2, 2, 2, 68, 70, 5, 18, 10, 2, 69, 71, 5, 24, 13, 2, 70,
-	69, 3, 2, 2, 2, 70, 71, 3, 2, 2, 2, 71, 72, 3, 2, 2, 2, 72, 73, 7, 25,
-	2, 2, 73, 82, 7, 3, 2, 2, 74, 79, 5, 16, 9, 2, 75, 76, 7, 4, 2, 2, 76,
-	78, 5, 16, 9, 2, 77, 75, 3, 2, 2, 2, 78, 81, 3, 2, 2, 2, 79, 77, 3, 2,
-	2, 2, 79, 80, 3, 2, 2, 2, 80, 83, 3, 2, 2, 2, 81, 79, 3, 2, 2, 2, 82, 74,
-	3, 2, 2, 2, 82, 83, 3, 2, 2, 2, 83, 84, 3, 2, 2, 2, 84, 85, 7, 5, 2, 2,
-	85, 93, 3, 2, 2, 2, 86, 87, 7, 28, 2, 2, 87, 93, 5, 4, 3, 6, 88, 89, 7,
-	3, 2, 2, 89, 90, 5, 4, 3, 2, 90, 91, 7, 5, 2, 2, 91, 93, 3, 2, 2, 2, 92,
-	29, 3, 2, 2, 2, 92, 34, 3, 2, 2, 2, 92, 38, 3, 2, 2, 2, 92, 45, 3, 2, 2,
-	2, 92, 52, 3, 2, 2, 2, 92, 59, 3, 2, 2, 2, 92, 68, 3, 2, 2, 2, 92, 86,
-	3, 2, 2, 2, 92, 88, 3, 2, 2, 2, 93, 102, 3, 2, 2, 2, 94, 95, 12, 5, 2,
-	2, 95, 96, 7, 22, 2, 2, 96, 101, 5, 4, 3, 6, 97, 98, 12, 4, 2, 2, 98, 99,
-	7, 23, 2, 2, 99, 101, 5, 4, 3, 5, 100, 94, 3, 2, 2, 2, 100, 97, 3, 2, 2,
-	2, 101, 104, 3, 2, 2, 2, 102, 100, 3, 2, 2, 2, 102, 103, 3, 2, 2, 2, 103,
-	5, 3, 2, 2, 2, 104, 102, 3, 2, 2, 2, 105, 108, 9, 2, 2, 2, 106, 108, 9,
-	3, 2, 2, 107, 105, 3, 2, 2, 2, 107, 106, 3, 2, 2, 2, 108, 7, 3, 2, 2, 2,
-	109, 110, 9, 4, 2, 2, 110, 9, 3, 2, 2, 2, 111, 112, 7, 29, 2, 2, 112, 11,
-	3, 2, 2, 2, 113, 114, 7, 29, 2, 2, 114, 13, 3, 2, 2, 2, 115, 116, 5, 10,
-	6, 2, 116, 117, 7, 15, 2, 2, 117, 119, 3, 2, 2, 2, 118, 115, 3, 2, 2, 2,
-	118, 119, 3, 2, 2, 2, 119, 120, 3, 2, 2, 2, 120, 121, 5, 12, 7, 2, 121,
-	122, 7, 15, 2, 2, 122, 124, 3, 2, 2, 2, 123, 118, 3, 2, 2, 2, 123, 124,
-	3, 2, 2, 2, 124, 125, 3, 2, 2, 2, 125, 126, 7, 29, 2, 2, 126, 15, 3, 2,
-	2, 2, 127, 130, 5, 20, 11, 2, 128, 130, 5, 22, 12, 2, 129, 127, 3, 2, 2,
-	2, 129, 128, 3, 2, 2, 2, 130, 17, 3, 2, 2, 2, 131, 132, 8, 10, 1, 2, 132,
-	138, 5, 14, 8, 2, 133, 134, 7, 3, 2, 2, 134, 135, 5, 18, 10, 2, 135, 136,
-	7, 5, 2, 2, 136, 138, 3, 2, 2, 2, 137, 131, 3, 2, 2, 2, 137, 133, 3, 2,
-	2, 2, 138, 171, 3, 2, 2, 2, 139, 140, 12, 8, 2, 2, 140, 143, 7, 16, 2,
-	2, 141, 144, 5, 16, 9, 2, 142, 144, 5, 18, 10, 2, 143, 141, 3, 2, 2, 2,
-	143, 142, 3, 2, 2, 2, 144, 170, 3, 2, 2, 2, 145, 146, 12, 7, 2, 2, 146,
-	149, 7, 17, 2, 2, 147, 150, 5, 16, 9, 2, 148, 150, 5, 18, 10, 2, 149, 147,
-	3, 2, 2, 2, 149, 148, 3, 2, 2, 2, 150, 170, 3, 2, 2, 2, 151, 152, 12, 6,
-	2, 2, 152, 155, 7, 18, 2, 2, 153, 156, 5, 16, 9, 2, 154, 156, 5, 18, 10,
-	2, 155, 153, 3, 2, 2, 2, 155, 154, 3, 2, 2, 2, 156, 170, 3, 2, 2, 2, 157,
-	158, 12, 5, 2, 2, 158, 161, 7, 19, 2, 2, 159, 162, 5, 16, 9, 2, 160, 162,
-	5, 18, 10, 2, 161, 159, 3, 2, 2, 2, 161, 160, 3, 2, 2, 2, 162, 170, 3,
-	2, 2, 2, 163, 164, 12, 4, 2, 2, 164, 167, 7, 20, 2, 2, 165, 168, 5, 16,
-	9, 2, 166, 168, 5, 18, 10, 2, 167, 165, 3, 2, 2, 2, 167, 166, 3, 2, 2,
-	2, 168, 170, 3, 2, 2, 2, 169, 139, 3, 2, 2, 2, 169, 145, 3, 2, 2, 2, 169,
-	151, 3, 2, 2, 2, 169, 157, 3, 2, 2, 2, 169, 163, 3, 2, 2, 2, 170, 173,
-	3, 2, 2, 2, 171, 169, 3, 2, 2, 2, 171, 172, 3, 2, 2, 2, 172, 19, 3, 2,
-	2, 2, 173, 171, 3, 2, 2, 2, 174, 176, 9, 5, 2, 2, 175, 174, 3, 2, 2, 2,
-	175, 176, 3, 2, 2, 2, 176, 177, 3, 2, 2, 2, 177, 178, 7, 30, 2, 2, 178,
-	21, 3, 2, 2, 2, 179, 180, 7, 31, 2, 2, 180, 23, 3, 2, 2, 2, 181, 182, 7,
-	28, 2, 2, 182, 25, 3, 2, 2, 2, 25, 40, 48, 55, 61, 70, 79, 82, 92, 100,
-	102, 107, 118, 123, 129, 137, 143, 149, 155, 161, 167, 169, 171, 175,
+	9, 13, 4, 14, 9, 14, 4, 15, 9, 15, 4, 16, 9, 16, 4, 17, 9, 17, 4, 18, 9,
+	18, 4, 19, 9, 19, 4, 20, 9, 20, 4, 21, 9, 21, 4, 22, 9, 22, 4, 23, 9, 23,
+	4, 24, 9, 24, 4, 25, 9, 25, 4, 26, 9, 26, 4, 27, 9, 27, 4, 28, 9, 28, 3,
+	2, 3, 2, 3, 2, 3, 3, 3, 3, 3, 3, 5, 3, 63, 10, 3, 3, 3, 5, 3, 66, 10, 3,
+	3, 3, 5, 3, 69, 10, 3, 3, 3, 5, 3, 72, 10, 3, 3, 3, 5, 3, 75, 10, 3, 3,
+	3, 3, 3, 3, 4, 3, 4, 3, 4, 3, 4, 7, 4, 83, 10, 4, 12, 4, 14, 4, 86, 11,
+	4, 3, 5, 3, 5, 3, 5, 3, 5, 3, 5, 7, 5, 93, 10, 5, 12, 5, 14, 5, 96, 11,
+	5, 3, 6, 3, 6, 5, 6, 100, 10, 6, 3, 7, 3, 7, 3, 7, 3, 8, 3, 8, 3, 8, 3,
+	9, 3, 9, 3, 9, 3, 9, 3, 9, 3, 9, 3, 9, 3, 9, 3, 9, 3, 9, 3, 9, 5, 9, 119,
+	10, 9, 3, 9, 3, 9, 3, 9, 3, 9, 3, 9, 3, 9, 5, 9, 127, 10, 9, 3, 9, 3, 9,
+	3, 9, 3, 9, 3, 9, 5, 9, 134, 10, 9, 3, 9, 3, 9, 3, 9, 3, 9, 5, 9, 140,
+	10, 9, 3, 9, 3, 9, 3, 9, 3, 9, 3, 9, 3, 9, 3, 9, 5, 9, 149, 10, 9, 3, 9,
+	3, 9, 3, 9, 3, 9, 3, 9, 7, 9, 156, 10, 9, 12, 9, 14, 9, 159, 11, 9, 5,
+	9, 161, 10, 9, 3, 9, 3, 9, 3, 9, 3, 9, 5, 9, 167, 10, 9, 3, 9, 3, 9, 3,
+	9, 3, 9, 3, 9, 3, 9, 3, 9, 5, 9, 176, 10, 9, 3, 9, 3, 9, 3, 9, 3, 9, 3,
+	9, 3, 9, 3, 9, 3, 9, 3, 9, 5, 9, 187, 10, 9, 3, 9, 3, 9, 3, 9, 3, 9, 3,
+	9, 3, 9, 7, 9, 195, 10, 9, 12, 9, 14, 9, 198, 11, 9, 3, 10, 3, 10, 5, 10,
+	202, 10, 10, 3, 11, 3, 11, 3, 12, 3, 12, 3, 13, 3, 13, 3, 14, 3, 14, 3,
+	15, 3, 15, 3, 15, 5, 15, 215, 10, 15, 3, 15, 3, 15, 3, 15, 5, 15, 220,
+	10, 15, 3, 15, 3, 15, 3, 15, 3, 15, 7, 15, 226, 10, 15, 12, 15, 14, 15,
+	229, 11, 15, 3, 15, 3, 15, 3, 15, 3, 15, 7, 15, 235, 10, 15, 12, 15, 14,
+	15, 238, 11, 15, 6, 15, 240, 10, 15, 13, 15, 14, 15, 241, 5, 15, 244, 10,
+	15, 3, 16, 3, 16, 3, 17, 3, 17, 3, 17, 3, 17, 3, 17, 5, 17, 253, 10, 17,
+	3, 18, 3, 18, 3, 18, 3, 18, 3, 18, 3, 18, 3, 18, 3, 18, 3, 18, 3, 18, 3,
+	18, 5, 18, 266, 10, 18, 3, 18, 3, 18, 3, 18, 3, 18, 5, 18, 272, 10, 18,
+	3, 18, 3, 18, 3, 18, 3, 18, 5, 18, 278, 10, 18, 3, 18, 3, 18, 3, 18, 3,
+	18, 5, 18, 284, 10, 18, 3, 18, 3, 18, 3, 18, 3, 18, 5, 18, 290, 10, 18,
+	3, 18, 3, 18, 3, 18, 3, 18, 5, 18, 296, 10, 18, 7, 18, 298, 10, 18, 12,
+	18, 14, 18, 301, 11, 18, 3, 19, 5, 19, 304, 10, 19, 3, 19, 3, 19, 3, 20,
+	3, 20, 3, 21, 3, 21, 3, 22, 3, 22, 3, 22, 3, 22, 3, 22, 3, 22, 3, 22, 3,
+	22, 3, 22, 5, 22, 321, 10, 22, 3, 22, 5, 22, 324, 10, 22, 3, 23, 3, 23,
+	3, 23, 3, 24, 5, 24, 330, 10, 24, 3, 24, 3, 24, 3, 25, 3, 25, 3, 26, 3,
+	26, 3, 26, 3, 26, 3, 26, 3, 26, 3, 27, 3, 27, 3, 27, 3, 27, 3, 27, 3, 27,
+	3, 27, 3, 27, 3, 27, 3, 27, 3, 28, 3, 28, 3, 28, 2, 4, 16, 34, 29, 2, 4,
+	6, 8, 10, 12, 14, 16, 18, 20, 22, 24, 26, 28, 30, 32, 34, 36, 38, 40, 42,
+	44, 46, 48, 50, 52, 54, 2, 11, 3, 2, 41, 42, 3, 2, 6, 9, 3, 2, 10, 12,
+	3, 2, 13, 14, 3, 2, 21, 25, 4, 2, 22, 25, 33, 47, 3, 2, 19, 20, 3, 2, 33,
+	34, 4, 2, 48, 48, 50, 51, 2, 384, 2, 56, 3, 2, 2, 2, 4, 65, 3, 2, 2, 2,
+	6, 78, 3, 2, 2, 2, 8, 87, 3, 2, 2, 2, 10, 97, 3, 2, 2, 2, 12, 101, 3, 2,
+	2, 2, 14, 104, 3, 2, 2, 2, 16, 186, 3, 2, 2, 2, 18, 201, 3, 2, 2, 2, 20,
+	203, 3, 2, 2, 2, 22, 205, 3, 2, 2, 2, 24, 207, 3, 2, 2, 2, 26, 209, 3,
+	2, 2, 2, 28, 243, 3, 2, 2, 2, 30, 245, 3, 2, 2, 2, 32, 252, 3, 2, 2, 2,
+	34, 265, 3, 2, 2, 2, 36, 303, 3, 2, 2, 2, 38, 307, 3, 2, 2, 2, 40, 309,
+	3, 2, 2, 2, 42, 320, 3, 2, 2, 2, 44, 325, 3, 2, 2, 2, 46, 329, 3, 2, 2,
+	2, 48, 333, 3, 2, 2, 2, 50, 335, 3, 2, 2, 2, 52, 341, 3, 2, 2, 2, 54, 351,
+	3, 2, 2, 2, 56, 57, 5, 16, 9, 2, 57, 58, 7, 2, 2, 3, 58, 3, 3, 2, 2, 2,
+	59, 62, 5, 6, 4, 2, 60, 61, 7, 46, 2, 2, 61, 63, 5, 16, 9, 2, 62, 60, 3,
+	2, 2, 2, 62, 63, 3, 2, 2, 2, 63, 66, 3, 2, 2, 2, 64, 66, 5, 16, 9, 2, 65,
+	59, 3, 2, 2, 2, 65, 64, 3, 2, 2, 2, 65, 66, 3, 2, 2, 2, 66, 68, 3, 2, 2,
+	2, 67, 69, 5, 8, 5, 2, 68, 67, 3, 2, 2, 2, 68, 69, 3, 2, 2, 2, 69, 71,
+	3, 2, 2, 2, 70, 72, 5, 12, 7, 2, 71, 70, 3, 2, 2, 2, 71, 72, 3, 2, 2, 2,
+	72, 74, 3, 2, 2, 2, 73, 75, 5, 14, 8, 2, 74, 73, 3, 2, 2, 2, 74, 75, 3,
+	2, 2, 2, 75, 76, 3, 2, 2, 2, 76, 77, 7, 2, 2, 3, 77, 5, 3, 2, 2, 2, 78,
+	79, 7, 45, 2, 2, 79, 84, 5, 28, 15, 2, 80, 81, 7, 3, 2, 2, 81, 83, 5, 28,
+	15, 2, 82, 80, 3, 2, 2, 2, 83, 86, 3, 2, 2, 2, 84, 82, 3, 2, 2, 2, 84,
+	85, 3, 2, 2, 2, 85, 7, 3, 2, 2, 2, 86, 84, 3, 2, 2, 2, 87, 88, 7, 39, 2,
+	2, 88, 89, 7, 40, 2, 2, 89, 94, 5, 10, 6, 2, 90, 91, 7, 3, 2, 2, 91, 93,
+	5, 10, 6, 2, 92, 90, 3, 2, 2, 2, 93, 96, 3, 2, 2, 2, 94, 92, 3, 2, 2, 2,
+	94, 95, 3, 2, 2, 2, 95, 9, 3, 2, 2, 2, 96, 94, 3, 2, 2, 2, 97, 99, 5, 28,
+	15, 2, 98, 100, 9, 2, 2, 2, 99, 98, 3, 2, 2, 2, 99, 100, 3, 2, 2, 2, 100,
+	11, 3, 2, 2, 2, 101, 102, 7, 43, 2, 2, 102, 103, 7, 48, 2, 2, 103, 13,
+	3, 2, 2, 2, 104, 105, 7, 44, 2, 2, 105, 106, 7, 48, 2, 2, 106, 15, 3, 2,
+	2, 2, 107, 108, 8, 9, 1, 2, 108, 109, 5, 34, 18, 2, 109, 110, 5, 18, 10,
+	2, 110, 111, 5, 32, 17, 2, 111, 187, 3, 2, 2, 2, 112, 113, 5, 34, 18, 2,
+	113, 114, 5, 20, 11, 2, 114, 115, 5, 32, 17, 2, 115, 187, 3, 2, 2, 2, 116,
+	118, 5, 34, 18, 2, 117, 119, 5, 54, 28, 2, 118, 117, 3, 2, 2, 2, 118, 119,
+	3, 2, 2, 2, 119, 120, 3, 2, 2, 2, 120, 121, 5, 22, 12, 2, 121, 122, 5,
+	32, 17, 2, 122, 187, 3, 2, 2, 2, 123, 124, 5, 34, 18, 2, 124, 126, 7, 30,
+	2, 2, 125, 127, 5, 54, 28, 2, 126, 125, 3, 2, 2, 2, 126, 127, 3, 2, 2,
+	2, 127, 128, 3, 2, 2, 2, 128, 129, 7, 31, 2, 2, 129, 187, 3, 2, 2, 2, 130,
+	131, 5, 34, 18, 2, 131, 133, 7, 30, 2, 2, 132, 134, 5, 54, 28, 2, 133,
+	132, 3, 2, 2, 2, 133, 134, 3, 2, 2, 2, 134, 135, 3, 2, 2, 2, 135, 136,
+	5, 32, 17, 2, 136, 187, 3, 2, 2, 2, 137, 139, 5, 34, 18, 2, 138, 140, 5,
+	54, 28, 2, 139, 138, 3, 2, 2, 2, 139, 140, 3, 2, 2, 2, 140, 141, 3, 2,
+	2, 2, 141, 142, 7, 28, 2, 2, 142, 143, 5, 32, 17, 2, 143, 144, 7, 26, 2,
+	2, 144, 145, 5, 32, 17, 2, 145, 187, 3, 2, 2, 2, 146, 148, 5, 34, 18, 2,
+	147, 149, 5, 54, 28, 2, 148, 147, 3, 2, 2, 2, 148, 149, 3, 2, 2, 2, 149,
+	150, 3, 2, 2, 2, 150, 151, 7, 29, 2, 2, 151, 160, 7, 4, 2, 2, 152, 157,
+	5, 32, 17, 2, 153, 154, 7, 3, 2, 2, 154, 156, 5, 32, 17, 2, 155, 153, 3,
+	2, 2, 2, 156, 159, 3, 2, 2, 2, 157, 155, 3, 2, 2, 2, 157, 158, 3, 2, 2,
+	2, 158, 161, 3, 2, 2, 2, 159, 157, 3, 2, 2, 2, 160, 152, 3, 2, 2, 2, 160,
+	161, 3, 2, 2, 2, 161, 162, 3, 2, 2, 2, 162, 163, 7, 5, 2, 2, 163, 187,
+	3, 2, 2, 2, 164, 166, 5, 34, 18, 2, 165, 167, 5, 54, 28, 2, 166, 165, 3,
+	2, 2, 2, 166, 167, 3, 2, 2, 2, 167, 168, 3, 2, 2, 2, 168, 169, 7, 37, 2,
+	2, 169, 170, 5, 48, 25, 2, 170, 171, 7, 38, 2, 2, 171, 172, 5, 50, 26,
+	2, 172, 187, 3, 2, 2, 2, 173, 175, 5, 34, 18, 2, 174, 176, 5, 54, 28, 2,
+	175, 174, 3, 2, 2, 2, 175, 176, 3, 2, 2, 2, 176, 177, 3, 2, 2, 2, 177,
+	178, 7, 37, 2, 2, 178, 179, 5, 52, 27, 2, 179, 187, 3, 2, 2, 2, 180, 181,
+	7, 32, 2, 2, 181, 187, 5, 16, 9, 6, 182, 183, 7, 4, 2, 2, 183, 184, 5,
+	16, 9, 2, 184, 185, 7, 5, 2, 2, 185, 187, 3, 2, 2, 2, 186, 107, 3, 2, 2,
+	2, 186, 112, 3, 2, 2, 2, 186, 116, 3, 2, 2, 2, 186, 123, 3, 2, 2, 2, 186,
+	130, 3, 2, 2, 2, 186, 137, 3, 2, 2, 2, 186, 146, 3, 2, 2, 2, 186, 164,
+	3, 2, 2, 2, 186, 173, 3, 2, 2, 2, 186, 180, 3, 2, 2, 2, 186, 182, 3, 2,
+	2, 2, 187, 196, 3, 2, 2, 2, 188, 189, 12, 5, 2, 2, 189, 190, 7, 26, 2,
+	2, 190, 195, 5, 16, 9, 6, 191, 192, 12, 4, 2, 2, 192, 193, 7, 27, 2, 2,
+	193, 195, 5, 16, 9, 5, 194, 188, 3, 2, 2, 2, 194, 191, 3, 2, 2, 2, 195,
+	198, 3, 2, 2, 2, 196, 194, 3, 2, 2, 2, 196, 197, 3, 2, 2, 2, 197, 17, 3,
+	2, 2, 2, 198, 196, 3, 2, 2, 2, 199, 202, 9, 3, 2, 2, 200, 202, 9, 4, 2,
+	2, 201, 199, 3, 2, 2, 2, 201, 200, 3, 2, 2, 2, 202, 19, 3, 2, 2, 2, 203,
+	204, 9, 5, 2, 2, 204, 21, 3, 2, 2, 2, 205, 206, 9, 6, 2, 2, 206, 23, 3,
+	2, 2, 2, 207, 208, 5, 30, 16, 2, 208, 25, 3, 2, 2, 2, 209, 210, 5, 30,
+	16, 2, 210, 27, 3, 2, 2, 2, 211, 212, 5, 24, 13, 2, 212, 213, 7, 15, 2,
+	2, 213, 215, 3, 2, 2, 2, 214, 211, 3, 2, 2, 2, 214, 215, 3, 2, 2, 2, 215,
+	216, 3, 2, 2, 2, 216, 217, 5, 26, 14, 2, 217, 218, 7, 15, 2, 2, 218, 220,
+	3, 2, 2, 2, 219, 214, 3, 2, 2, 2, 219, 220, 3, 2, 2, 2, 220, 221, 3, 2,
+	2, 2, 221, 244, 5, 30, 16, 2, 222, 227, 5, 30, 16, 2, 223, 224, 7, 15,
+	2, 2, 224, 226, 5, 30, 16, 2, 225, 223, 3, 2, 2, 2, 226, 229, 3, 2, 2,
+	2, 227, 225, 3, 2, 2, 2, 227, 228, 3, 2, 2, 2, 228, 239, 3, 2, 2, 2, 229,
+	227, 3, 2, 2, 2, 230, 231, 7, 15, 2, 2, 231, 236, 7, 16, 2, 2, 232, 233,
+	7, 15, 2, 2, 233, 235, 5, 30, 16, 2, 234, 232, 3, 2, 2, 2, 235, 238, 3,
+	2, 2, 2, 236, 234, 3, 2, 2, 2, 236, 237, 3, 2, 2, 2, 237, 240, 3, 2, 2,
+	2, 238, 236, 3, 2, 2, 2, 239, 230, 3, 2, 2, 2, 240, 241, 3, 2, 2, 2, 241,
+	239, 3, 2, 2, 2, 241, 242, 3, 2, 2, 2, 242, 244, 3, 2, 2, 2, 243, 219,
+	3, 2, 2, 2, 243, 222, 3, 2, 2, 2, 244, 29, 3, 2, 2, 2, 245, 246, 9, 7,
+	2, 2, 246, 31, 3, 2, 2, 2, 247, 253, 5, 36, 19, 2, 248, 253, 5, 38, 20,
+	2, 249, 253, 5, 40, 21, 2, 250, 253, 5, 42, 22, 2, 251, 253, 5, 46, 24,
+	2, 252, 247, 3, 2, 2, 2, 252, 248, 3, 2, 2, 2, 252, 249, 3, 2, 2, 2, 252,
+	250, 3, 2, 2, 2, 252, 251, 3, 2, 2, 2, 253, 33, 3, 2, 2, 2, 254, 255, 8,
+	18, 1, 2, 255, 266, 5, 28, 15, 2, 256, 257, 7, 47, 2, 2, 257, 258, 7, 4,
+	2, 2, 258, 259, 5, 34, 18, 2, 259, 260, 7, 5, 2, 2, 260, 266, 3, 2, 2,
+	2, 261, 262, 7, 4, 2, 2, 262, 263, 5, 34, 18, 2, 263, 264, 7, 5, 2, 2,
+	264, 266, 3, 2, 2, 2, 265, 254, 3, 2, 2, 2, 265, 256, 3, 2, 2, 2, 265,
+	261, 3, 2, 2, 2, 266, 299, 3, 2, 2, 2, 267, 268, 12, 8, 2, 2, 268, 271,
+	7, 16, 2, 2, 269, 272, 5, 32, 17, 2, 270, 272, 5, 34, 18, 2, 271, 269,
+	3, 2, 2, 2, 271, 270, 3, 2, 2, 2, 272, 298, 3, 2, 2, 2, 273, 274, 12, 7,
+	2, 2, 274, 277, 7, 17, 2, 2, 275, 278, 5, 32, 17, 2, 276, 278, 5, 34, 18,
+	2, 277, 275, 3, 2, 2, 2, 277, 276, 3, 2, 2, 2, 278, 298, 3, 2, 2, 2, 279,
+	280, 12, 6, 2, 2, 280, 283, 7, 18, 2, 2, 281, 284, 5, 32, 17, 2, 282, 284,
+	5, 34, 18, 2, 283, 281, 3, 2, 2, 2, 283, 282, 3, 2, 2, 2, 284, 298, 3,
+	2, 2, 2, 285, 286, 12, 5, 2, 2, 286, 289, 7, 19, 2, 2, 287, 290, 5, 32,
+	17, 2, 288, 290, 5, 34, 18, 2, 289, 287, 3, 2, 2, 2, 289, 288, 3, 2, 2,
+	2, 290, 298, 3, 2, 2, 2, 291, 292, 12, 4, 2, 2, 292, 295, 7, 20, 2, 2,
+	293, 296, 5, 32, 17, 2, 294, 296, 5, 34, 18, 2, 295, 293, 3, 2, 2, 2, 295,
+	294, 3, 2, 2, 2, 296, 298, 3, 2, 2, 2, 297, 267, 3, 2, 2, 2, 297, 273,
+	3, 2, 2, 2, 297, 279, 3, 2, 2, 2, 297, 285, 3, 2, 2, 2, 297, 291, 3, 2,
+	2, 2, 298, 301, 3, 2, 2, 2, 299, 297, 3, 2, 2, 2, 299, 300, 3, 2, 2, 2,
+	300, 35, 3, 2, 2, 2, 301, 299, 3, 2, 2, 2, 302, 304, 9, 8, 2, 2, 303, 302,
+	3, 2, 2, 2, 303, 304, 3, 2, 2, 2, 304, 305, 3, 2, 2, 2, 305, 306, 7, 48,
+	2, 2, 306, 37, 3, 2, 2, 2, 307, 308, 7, 52, 2, 2, 308, 39, 3, 2, 2, 2,
+	309, 310, 9, 9, 2, 2, 310, 41, 3, 2, 2, 2, 311, 312, 7, 35, 2, 2, 312,
+	313, 7, 4, 2, 2, 313, 321, 7, 5, 2, 2, 314, 315, 7, 36, 2, 2, 315, 316,
+	7, 4, 2, 2, 316, 317, 5, 38, 20, 2, 317, 318, 7, 5, 2, 2, 318, 321, 3,
+	2, 2, 2, 319, 321, 7, 49, 2, 2, 320, 311, 3, 2, 2, 2, 320, 314, 3, 2, 2,
+	2, 320, 319, 3, 2, 2, 2, 321, 323, 3, 2, 2, 2, 322, 324, 5, 44, 23, 2,
+	323, 322, 3, 2, 2, 2, 323, 324, 3, 2, 2, 2, 324, 43, 3, 2, 2, 2, 325, 326,
+	9, 8, 2, 2, 326, 327, 7, 50, 2, 2, 327, 45, 3, 2, 2, 2, 328, 330, 9, 8,
+	2, 2, 329, 328, 3, 2, 2, 2, 329, 330, 3, 2, 2, 2, 330, 331, 3, 2, 2, 2,
+	331, 332, 7, 50, 2, 2, 332, 47, 3, 2, 2, 2, 333, 334, 9, 10, 2, 2, 334,
+	49, 3, 2, 2, 2, 335, 336, 7, 4, 2, 2, 336, 337, 5, 32, 17, 2, 337, 338,
+	7, 3, 2, 2, 338, 339, 5, 32, 17, 2, 339, 340, 7, 5, 2, 2, 340, 51, 3, 2,
+	2, 2, 341, 342, 7, 4, 2, 2, 342, 343, 5, 32, 17, 2, 343, 344, 7, 3, 2,
+	2, 344, 345, 5, 32, 17, 2, 345, 346, 7, 3, 2, 2, 346, 347, 5, 32, 17, 2,
+	347, 348, 7, 3, 2, 2, 348, 349, 5, 32, 17, 2, 349, 350, 7, 5, 2, 2, 350,
+	53, 3, 2, 2, 2, 351, 352, 7, 32, 2, 2, 352, 55, 3, 2, 2, 2, 42, 62, 65,
+	68, 71, 74, 84, 94, 99, 118, 126, 133, 139, 148, 157, 160, 166, 175, 186,
+	194, 196, 201, 214, 219, 227, 236, 241, 243, 252, 265, 271, 277, 283, 289,
+	295, 297, 299, 303, 320, 323, 329,
 }
 var deserializer = antlr.NewATNDeserializer(nil)
 var deserializedATN = deserializer.DeserializeFromUInt16(parserATN)
 
 var literalNames = []string{
-	"", "'('", "','", "')'", "'<'", "'<='", "'>'", "'>='", "'='", "'!='", "'<>'",
+	"", "','", "'('", "')'", "'<'", "'<='", "'>'", "'>='", "'='", "'!='", "'<>'",
 	"'~='", "'~!'", "'.'", "'*'", "'/'", "'%'", "'+'", "'-'",
 }
 var symbolicNames = []string{
 	"", "", "", "", "", "", "", "", "", "", "", "", "", "", "", "", "", "",
-	"", "K_LIKE", "K_AND", "K_OR", "K_BETWEEN", "K_IN", "K_IS", "K_NULL", "K_NOT",
-	"IDENTIFIER", "NUMERIC_LITERAL", "STRING_LITERAL", "SPACES",
+	"", "K_LIKE", "K_ILIKE", "K_CONTAINS", "K_STARTSWITH", "K_ENDSWITH", "K_AND",
+	"K_OR", "K_BETWEEN", "K_IN", "K_IS", "K_NULL", "K_NOT", "K_TRUE", "K_FALSE",
+	"K_NOW", "K_DATE", "K_WITHIN", "K_OF", "K_ORDER", "K_BY", "K_ASC", "K_DESC",
+	"K_LIMIT", "K_OFFSET", "K_FIELDS", "K_WHERE", "IDENTIFIER", "NUMERIC_LITERAL",
+	"DATE_LITERAL", "DURATION_LITERAL", "DISTANCE_LITERAL", "STRING_LITERAL",
+	"SPACES",
 }
 
 var ruleNames = []string{
-	"start", "expr", "literalOp", "stringOp", "databaseName", "tableName",
-	"columnName", "literalValue", "mathExp", "signedNumber", "stringValue",
-	"keyNot",
+	"start", "query", "fields", "orderBy", "sortKey", "limit", "offset", "expr",
+	"literalOp", "stringOp", "likeOp", "databaseName", "tableName", "columnName",
+	"identifier", "literalValue", "mathExp", "signedNumber", "stringValue",
+	"booleanValue", "dateValue", "dateOffset", "durationValue", "distance",
+	"point", "box", "keyNot",
 }
 var decisionToDFA = make([]*antlr.DFA, len(deserializedATN.DecisionToState))
 
@@ -145,53 +229,89 @@ func NewTSLParser(input antlr.TokenStream) *TSLParser {
 
 // TSLParser tokens.
 const (
-	TSLParserEOF             = antlr.TokenEOF
-	TSLParserT__0            = 1
-	TSLParserT__1            = 2
-	TSLParserT__2            = 3
-	TSLParserT__3            = 4
-	TSLParserT__4            = 5
-	TSLParserT__5            = 6
-	TSLParserT__6            = 7
-	TSLParserT__7            = 8
-	TSLParserT__8            = 9
-	TSLParserT__9            = 10
-	TSLParserT__10           = 11
-	TSLParserT__11           = 12
-	TSLParserT__12           = 13
-	TSLParserT__13           = 14
-	TSLParserT__14           = 15
-	TSLParserT__15           = 16
-	TSLParserT__16           = 17
-	TSLParserT__17           = 18
-	TSLParserK_LIKE          = 19
-	TSLParserK_AND           = 20
-	TSLParserK_OR            = 21
-	TSLParserK_BETWEEN       = 22
-	TSLParserK_IN            = 23
-	TSLParserK_IS            = 24
-	TSLParserK_NULL          = 25
-	TSLParserK_NOT           = 26
-	TSLParserIDENTIFIER      = 27
-	TSLParserNUMERIC_LITERAL = 28
-	TSLParserSTRING_LITERAL  = 29
-	TSLParserSPACES          = 30
+	TSLParserEOF              = antlr.TokenEOF
+	TSLParserT__0             = 1
+	TSLParserT__1             = 2
+	TSLParserT__2             = 3
+	TSLParserT__3             = 4
+	TSLParserT__4             = 5
+	TSLParserT__5             = 6
+	TSLParserT__6             = 7
+	TSLParserT__7             = 8
+	TSLParserT__8             = 9
+	TSLParserT__9             = 10
+	TSLParserT__10            = 11
+	TSLParserT__11            = 12
+	TSLParserT__12            = 13
+	TSLParserT__13            = 14
+	TSLParserT__14            = 15
+	TSLParserT__15            = 16
+	TSLParserT__16            = 17
+	TSLParserT__17            = 18
+	TSLParserK_LIKE           = 19
+	TSLParserK_ILIKE          = 20
+	TSLParserK_CONTAINS       = 21
+	TSLParserK_STARTSWITH     = 22
+	TSLParserK_ENDSWITH       = 23
+	TSLParserK_AND            = 24
+	TSLParserK_OR             = 25
+	TSLParserK_BETWEEN        = 26
+	TSLParserK_IN             = 27
+	TSLParserK_IS             = 28
+	TSLParserK_NULL           = 29
+	TSLParserK_NOT            = 30
+	TSLParserK_TRUE           = 31
+	TSLParserK_FALSE          = 32
+	TSLParserK_NOW            = 33
+	TSLParserK_DATE           = 34
+	TSLParserK_WITHIN         = 35
+	TSLParserK_OF             = 36
+	TSLParserK_ORDER          = 37
+	TSLParserK_BY             = 38
+	TSLParserK_ASC            = 39
+	TSLParserK_DESC           = 40
+	TSLParserK_LIMIT          = 41
+	TSLParserK_OFFSET         = 42
+	TSLParserK_FIELDS         = 43
+	TSLParserK_WHERE          = 44
+	TSLParserIDENTIFIER       = 45
+	TSLParserNUMERIC_LITERAL  = 46
+	TSLParserDATE_LITERAL     = 47
+	TSLParserDURATION_LITERAL = 48
+	TSLParserDISTANCE_LITERAL = 49
+	TSLParserSTRING_LITERAL   = 50
+	TSLParserSPACES           = 51
 )
 
 // TSLParser rules.
 const (
-	TSLParserRULE_start        = 0
-	TSLParserRULE_expr         = 1
-	TSLParserRULE_literalOp    = 2
-	TSLParserRULE_stringOp     = 3
-	TSLParserRULE_databaseName = 4
-	TSLParserRULE_tableName    = 5
-	TSLParserRULE_columnName   = 6
-	TSLParserRULE_literalValue = 7
-	TSLParserRULE_mathExp      = 8
-	TSLParserRULE_signedNumber = 9
-	TSLParserRULE_stringValue  = 10
-	TSLParserRULE_keyNot       = 11
+	TSLParserRULE_start         = 0
+	TSLParserRULE_query         = 1
+	TSLParserRULE_fields        = 2
+	TSLParserRULE_orderBy       = 3
+	TSLParserRULE_sortKey       = 4
+	TSLParserRULE_limit         = 5
+	TSLParserRULE_offset        = 6
+	TSLParserRULE_expr          = 7
+	TSLParserRULE_literalOp     = 8
+	TSLParserRULE_stringOp      = 9
+	TSLParserRULE_likeOp        = 10
+	TSLParserRULE_databaseName  = 11
+	TSLParserRULE_tableName     = 12
+	TSLParserRULE_columnName    = 13
+	TSLParserRULE_identifier    = 14
+	TSLParserRULE_literalValue  = 15
+	TSLParserRULE_mathExp       = 16
+	TSLParserRULE_signedNumber  = 17
+	TSLParserRULE_stringValue   = 18
+	TSLParserRULE_booleanValue  = 19
+	TSLParserRULE_dateValue     = 20
+	TSLParserRULE_dateOffset    = 21
+	TSLParserRULE_durationValue = 22
+	TSLParserRULE_distance      = 23
+	TSLParserRULE_point         = 24
+	TSLParserRULE_box           = 25
+	TSLParserRULE_keyNot        = 26
 )
 
 // IStartContext is an interface to support dynamic dispatch.
@@ -288,86 +408,70 @@ func (p *TSLParser) Start() (localctx IStartContext) {
 
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(24)
+		p.SetState(54)
 		p.expr(0)
 	}
 	{
-		p.SetState(25)
+		p.SetState(55)
 		p.Match(TSLParserEOF)
 	}
 
 	return localctx
 }
 
-// IExprContext is an interface to support dynamic dispatch.
-type IExprContext interface {
+// IQueryContext is an interface to support dynamic dispatch.
+type IQueryContext interface {
 	antlr.ParserRuleContext
 
 	// GetParser returns the parser.
 	GetParser() antlr.Parser
 
-	// IsExprContext differentiates from other interfaces.
-	IsExprContext()
+	// IsQueryContext differentiates from other interfaces.
+	IsQueryContext()
 }
 
-type ExprContext struct {
+type QueryContext struct {
 	*antlr.BaseParserRuleContext
 	parser antlr.Parser
 }
 
-func NewEmptyExprContext() *ExprContext {
-	var p = new(ExprContext)
+func NewEmptyQueryContext() *QueryContext {
+	var p = new(QueryContext)
 	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(nil, -1)
-	p.RuleIndex = TSLParserRULE_expr
+	p.RuleIndex = TSLParserRULE_query
 	return p
 }
 
-func (*ExprContext) IsExprContext() {}
+func (*QueryContext) IsQueryContext() {}
 
-func NewExprContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *ExprContext {
-	var p = new(ExprContext)
+func NewQueryContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *QueryContext {
+	var p = new(QueryContext)
 
 	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(parent, invokingState)
 
 	p.parser = parser
-	p.RuleIndex = TSLParserRULE_expr
+	p.RuleIndex = TSLParserRULE_query
 
 	return p
 }
 
-func (s *ExprContext) GetParser() antlr.Parser { return s.parser }
-
-func (s *ExprContext) CopyFrom(ctx *ExprContext) {
-	s.BaseParserRuleContext.CopyFrom(ctx.BaseParserRuleContext)
-}
-
-func (s *ExprContext) GetRuleContext() antlr.RuleContext {
-	return s
-}
-
-func (s *ExprContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
-	return antlr.TreesStringTree(s, ruleNames, recog)
-}
+func (s *QueryContext) GetParser() antlr.Parser { return s.parser }
 
-type ParContext struct {
-	*ExprContext
+func (s *QueryContext) EOF() antlr.TerminalNode {
+	return s.GetToken(TSLParserEOF, 0)
 }
 
-func NewParContext(parser antlr.Parser, ctx antlr.ParserRuleContext) *ParContext {
-	var p = new(ParContext)
-
-	p.ExprContext = NewEmptyExprContext()
-	p.parser = parser
-	p.CopyFrom(ctx.(*ExprContext))
+func (s *QueryContext) Fields() IFieldsContext {
+	var t = s.GetTypedRuleContext(reflect.TypeOf((*IFieldsContext)(nil)).Elem(), 0)
 
-	return p
-}
+	if t == nil {
+		return nil
+	}
 
-func (s *ParContext) GetRuleContext() antlr.RuleContext {
-	return s
+	return t.(IFieldsContext)
 }
 
-func (s *ParContext) Expr() IExprContext {
+func (s *QueryContext) Expr() IExprContext {
 	var t = s.GetTypedRuleContext(reflect.TypeOf((*IExprContext)(nil)).Elem(), 0)
 
 	if t == nil {
@@ -377,586 +481,854 @@ func (s *ParContext) Expr() IExprContext {
 	return t.(IExprContext)
 }
 
-func (s *ParContext) EnterRule(listener antlr.ParseTreeListener) {
-	if listenerT, ok := listener.(TSLListener); ok {
-		listenerT.EnterPar(s)
+func (s *QueryContext) OrderBy() IOrderByContext {
+	var t = s.GetTypedRuleContext(reflect.TypeOf((*IOrderByContext)(nil)).Elem(), 0)
+
+	if t == nil {
+		return nil
 	}
+
+	return t.(IOrderByContext)
 }
 
-func (s *ParContext) ExitRule(listener antlr.ParseTreeListener) {
-	if listenerT, ok := listener.(TSLListener); ok {
-		listenerT.ExitPar(s)
+func (s *QueryContext) Limit() ILimitContext {
+	var t = s.GetTypedRuleContext(reflect.TypeOf((*ILimitContext)(nil)).Elem(), 0)
+
+	if t == nil {
+		return nil
 	}
-}
 
-type NotContext struct {
-	*ExprContext
+	return t.(ILimitContext)
 }
 
-func NewNotContext(parser antlr.Parser, ctx antlr.ParserRuleContext) *NotContext {
-	var p = new(NotContext)
+func (s *QueryContext) Offset() IOffsetContext {
+	var t = s.GetTypedRuleContext(reflect.TypeOf((*IOffsetContext)(nil)).Elem(), 0)
 
-	p.ExprContext = NewEmptyExprContext()
-	p.parser = parser
-	p.CopyFrom(ctx.(*ExprContext))
+	if t == nil {
+		return nil
+	}
 
-	return p
+	return t.(IOffsetContext)
 }
 
-func (s *NotContext) GetRuleContext() antlr.RuleContext {
-	return s
+func (s *QueryContext) K_WHERE() antlr.TerminalNode {
+	return s.GetToken(TSLParserK_WHERE, 0)
 }
 
-func (s *NotContext) K_NOT() antlr.TerminalNode {
-	return s.GetToken(TSLParserK_NOT, 0)
+func (s *QueryContext) GetRuleContext() antlr.RuleContext {
+	return s
 }
 
-func (s *NotContext) Expr() IExprContext {
-	var t = s.GetTypedRuleContext(reflect.TypeOf((*IExprContext)(nil)).Elem(), 0)
-
-	if t == nil {
-		return nil
-	}
-
-	return t.(IExprContext)
+func (s *QueryContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
+	return antlr.TreesStringTree(s, ruleNames, recog)
 }
 
-func (s *NotContext) EnterRule(listener antlr.ParseTreeListener) {
+func (s *QueryContext) EnterRule(listener antlr.ParseTreeListener) {
 	if listenerT, ok := listener.(TSLListener); ok {
-		listenerT.EnterNot(s)
+		listenerT.EnterQuery(s)
 	}
 }
 
-func (s *NotContext) ExitRule(listener antlr.ParseTreeListener) {
+func (s *QueryContext) ExitRule(listener antlr.ParseTreeListener) {
 	if listenerT, ok := listener.(TSLListener); ok {
-		listenerT.ExitNot(s)
+		listenerT.ExitQuery(s)
 	}
 }
 
-type LikeContext struct {
-	*ExprContext
-}
+func (p *TSLParser) Query() (localctx IQueryContext) {
+	localctx = NewQueryContext(p, p.GetParserRuleContext(), p.GetState())
+	p.EnterRule(localctx, 2, TSLParserRULE_query)
+	var _la int
 
-func NewLikeContext(parser antlr.Parser, ctx antlr.ParserRuleContext) *LikeContext {
-	var p = new(LikeContext)
+	defer func() {
+		p.ExitRule()
+	}()
 
-	p.ExprContext = NewEmptyExprContext()
-	p.parser = parser
-	p.CopyFrom(ctx.(*ExprContext))
+	defer func() {
+		if err := recover(); err != nil {
+			if v, ok := err.(antlr.RecognitionException); ok {
+				localctx.SetException(v)
+				p.GetErrorHandler().ReportError(p, v)
+				p.GetErrorHandler().Recover(p, v)
+			} else {
+				panic(err)
+			}
+		}
+	}()
 
-	return p
-}
+	p.EnterOuterAlt(localctx, 1)
+	p.SetState(63)
+	p.GetErrorHandler().Sync(p)
 
-func (s *LikeContext) GetRuleContext() antlr.RuleContext {
-	return s
-}
+	if p.GetInterpreter().AdaptivePredict(p.GetTokenStream(), 1, p.GetParserRuleContext()) == 1 {
+		{
+			p.SetState(57)
+			p.Fields()
+		}
+		p.SetState(60)
+		p.GetErrorHandler().Sync(p)
+		_la = p.GetTokenStream().LA(1)
 
-func (s *LikeContext) MathExp() IMathExpContext {
-	var t = s.GetTypedRuleContext(reflect.TypeOf((*IMathExpContext)(nil)).Elem(), 0)
+		if _la == TSLParserK_WHERE {
+			{
+				p.SetState(58)
+				p.Match(TSLParserK_WHERE)
+			}
+			{
+				p.SetState(59)
+				p.expr(0)
+			}
 
-	if t == nil {
-		return nil
-	}
+		}
 
-	return t.(IMathExpContext)
-}
+	} else if p.GetInterpreter().AdaptivePredict(p.GetTokenStream(), 1, p.GetParserRuleContext()) == 2 {
+		{
+			p.SetState(62)
+			p.expr(0)
+		}
 
-func (s *LikeContext) K_LIKE() antlr.TerminalNode {
-	return s.GetToken(TSLParserK_LIKE, 0)
-}
+	}
+	p.SetState(66)
+	p.GetErrorHandler().Sync(p)
+	_la = p.GetTokenStream().LA(1)
 
-func (s *LikeContext) LiteralValue() ILiteralValueContext {
-	var t = s.GetTypedRuleContext(reflect.TypeOf((*ILiteralValueContext)(nil)).Elem(), 0)
+	if _la == TSLParserK_ORDER {
+		{
+			p.SetState(65)
+			p.OrderBy()
+		}
 
-	if t == nil {
-		return nil
 	}
+	p.SetState(69)
+	p.GetErrorHandler().Sync(p)
+	_la = p.GetTokenStream().LA(1)
 
-	return t.(ILiteralValueContext)
-}
+	if _la == TSLParserK_LIMIT {
+		{
+			p.SetState(68)
+			p.Limit()
+		}
 
-func (s *LikeContext) KeyNot() IKeyNotContext {
-	var t = s.GetTypedRuleContext(reflect.TypeOf((*IKeyNotContext)(nil)).Elem(), 0)
+	}
+	p.SetState(72)
+	p.GetErrorHandler().Sync(p)
+	_la = p.GetTokenStream().LA(1)
 
-	if t == nil {
-		return nil
+	if _la == TSLParserK_OFFSET {
+		{
+			p.SetState(71)
+			p.Offset()
+		}
+
+	}
+	{
+		p.SetState(74)
+		p.Match(TSLParserEOF)
 	}
 
-	return t.(IKeyNotContext)
+	return localctx
 }
 
-func (s *LikeContext) EnterRule(listener antlr.ParseTreeListener) {
-	if listenerT, ok := listener.(TSLListener); ok {
-		listenerT.EnterLike(s)
-	}
+// IFieldsContext is an interface to support dynamic dispatch.
+type IFieldsContext interface {
+	antlr.ParserRuleContext
+
+	// GetParser returns the parser.
+	GetParser() antlr.Parser
+
+	// IsFieldsContext differentiates from other interfaces.
+	IsFieldsContext()
 }
 
-func (s *LikeContext) ExitRule(listener antlr.ParseTreeListener) {
-	if listenerT, ok := listener.(TSLListener); ok {
-		listenerT.ExitLike(s)
-	}
+type FieldsContext struct {
+	*antlr.BaseParserRuleContext
+	parser antlr.Parser
 }
 
-type OrContext struct {
-	*ExprContext
+func NewEmptyFieldsContext() *FieldsContext {
+	var p = new(FieldsContext)
+	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(nil, -1)
+	p.RuleIndex = TSLParserRULE_fields
+	return p
 }
 
-func NewOrContext(parser antlr.Parser, ctx antlr.ParserRuleContext) *OrContext {
-	var p = new(OrContext)
+func (*FieldsContext) IsFieldsContext() {}
+
+func NewFieldsContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *FieldsContext {
+	var p = new(FieldsContext)
+
+	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(parent, invokingState)
 
-	p.ExprContext = NewEmptyExprContext()
 	p.parser = parser
-	p.CopyFrom(ctx.(*ExprContext))
+	p.RuleIndex = TSLParserRULE_fields
 
 	return p
 }
 
-func (s *OrContext) GetRuleContext() antlr.RuleContext {
-	return s
+func (s *FieldsContext) GetParser() antlr.Parser { return s.parser }
+
+func (s *FieldsContext) K_FIELDS() antlr.TerminalNode {
+	return s.GetToken(TSLParserK_FIELDS, 0)
 }
 
-func (s *OrContext) AllExpr() []IExprContext {
-	var ts = s.GetTypedRuleContexts(reflect.TypeOf((*IExprContext)(nil)).Elem())
-	var tst = make([]IExprContext, len(ts))
+func (s *FieldsContext) AllColumnName() []IColumnNameContext {
+	var ts = s.GetTypedRuleContexts(reflect.TypeOf((*IColumnNameContext)(nil)).Elem())
+	var tst = make([]IColumnNameContext, len(ts))
 
 	for i, t := range ts {
 		if t != nil {
-			tst[i] = t.(IExprContext)
+			tst[i] = t.(IColumnNameContext)
 		}
 	}
 
 	return tst
 }
 
-func (s *OrContext) Expr(i int) IExprContext {
-	var t = s.GetTypedRuleContext(reflect.TypeOf((*IExprContext)(nil)).Elem(), i)
+func (s *FieldsContext) ColumnName(i int) IColumnNameContext {
+	var t = s.GetTypedRuleContext(reflect.TypeOf((*IColumnNameContext)(nil)).Elem(), i)
 
 	if t == nil {
 		return nil
 	}
 
-	return t.(IExprContext)
+	return t.(IColumnNameContext)
 }
 
-func (s *OrContext) K_OR() antlr.TerminalNode {
-	return s.GetToken(TSLParserK_OR, 0)
+func (s *FieldsContext) GetRuleContext() antlr.RuleContext {
+	return s
 }
 
-func (s *OrContext) EnterRule(listener antlr.ParseTreeListener) {
-	if listenerT, ok := listener.(TSLListener); ok {
-		listenerT.EnterOr(s)
-	}
+func (s *FieldsContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
+	return antlr.TreesStringTree(s, ruleNames, recog)
 }
 
-func (s *OrContext) ExitRule(listener antlr.ParseTreeListener) {
+func (s *FieldsContext) EnterRule(listener antlr.ParseTreeListener) {
 	if listenerT, ok := listener.(TSLListener); ok {
-		listenerT.ExitOr(s)
+		listenerT.EnterFields(s)
 	}
 }
 
-type InContext struct {
-	*ExprContext
+func (s *FieldsContext) ExitRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(TSLListener); ok {
+		listenerT.ExitFields(s)
+	}
 }
 
-func NewInContext(parser antlr.Parser, ctx antlr.ParserRuleContext) *InContext {
-	var p = new(InContext)
-
-	p.ExprContext = NewEmptyExprContext()
-	p.parser = parser
-	p.CopyFrom(ctx.(*ExprContext))
-
-	return p
-}
+func (p *TSLParser) Fields() (localctx IFieldsContext) {
+	localctx = NewFieldsContext(p, p.GetParserRuleContext(), p.GetState())
+	p.EnterRule(localctx, 4, TSLParserRULE_fields)
+	var _la int
 
-func (s *InContext) GetRuleContext() antlr.RuleContext {
-	return s
-}
+	defer func() {
+		p.ExitRule()
+	}()
 
-func (s *InContext) MathExp() IMathExpContext {
-	var t = s.GetTypedRuleContext(reflect.TypeOf((*IMathExpContext)(nil)).Elem(), 0)
+	defer func() {
+		if err := recover(); err != nil {
+			if v, ok := err.(antlr.RecognitionException); ok {
+				localctx.SetException(v)
+				p.GetErrorHandler().ReportError(p, v)
+				p.GetErrorHandler().Recover(p, v)
+			} else {
+				panic(err)
+			}
+		}
+	}()
 
-	if t == nil {
-		return nil
+	p.EnterOuterAlt(localctx, 1)
+	{
+		p.SetState(76)
+		p.Match(TSLParserK_FIELDS)
 	}
-
-	return t.(IMathExpContext)
-}
-
-func (s *InContext) K_IN() antlr.TerminalNode {
-	return s.GetToken(TSLParserK_IN, 0)
-}
-
-func (s *InContext) KeyNot() IKeyNotContext {
-	var t = s.GetTypedRuleContext(reflect.TypeOf((*IKeyNotContext)(nil)).Elem(), 0)
-
-	if t == nil {
-		return nil
+	{
+		p.SetState(77)
+		p.ColumnName()
 	}
+	p.SetState(82)
+	p.GetErrorHandler().Sync(p)
+	_la = p.GetTokenStream().LA(1)
 
-	return t.(IKeyNotContext)
-}
-
-func (s *InContext) AllLiteralValue() []ILiteralValueContext {
-	var ts = s.GetTypedRuleContexts(reflect.TypeOf((*ILiteralValueContext)(nil)).Elem())
-	var tst = make([]ILiteralValueContext, len(ts))
-
-	for i, t := range ts {
-		if t != nil {
-			tst[i] = t.(ILiteralValueContext)
+	for _la == TSLParserT__0 {
+		{
+			p.SetState(78)
+			p.Match(TSLParserT__0)
+		}
+		{
+			p.SetState(79)
+			p.ColumnName()
 		}
+
+		p.SetState(84)
+		p.GetErrorHandler().Sync(p)
+		_la = p.GetTokenStream().LA(1)
 	}
 
-	return tst
+	return localctx
 }
 
-func (s *InContext) LiteralValue(i int) ILiteralValueContext {
-	var t = s.GetTypedRuleContext(reflect.TypeOf((*ILiteralValueContext)(nil)).Elem(), i)
+// IOrderByContext is an interface to support dynamic dispatch.
+type IOrderByContext interface {
+	antlr.ParserRuleContext
 
-	if t == nil {
-		return nil
-	}
+	// GetParser returns the parser.
+	GetParser() antlr.Parser
 
-	return t.(ILiteralValueContext)
+	// IsOrderByContext differentiates from other interfaces.
+	IsOrderByContext()
 }
 
-func (s *InContext) EnterRule(listener antlr.ParseTreeListener) {
-	if listenerT, ok := listener.(TSLListener); ok {
-		listenerT.EnterIn(s)
-	}
+type OrderByContext struct {
+	*antlr.BaseParserRuleContext
+	parser antlr.Parser
 }
 
-func (s *InContext) ExitRule(listener antlr.ParseTreeListener) {
-	if listenerT, ok := listener.(TSLListener); ok {
-		listenerT.ExitIn(s)
-	}
+func NewEmptyOrderByContext() *OrderByContext {
+	var p = new(OrderByContext)
+	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(nil, -1)
+	p.RuleIndex = TSLParserRULE_orderBy
+	return p
 }
 
-type IsLiteralContext struct {
-	*ExprContext
-}
+func (*OrderByContext) IsOrderByContext() {}
 
-func NewIsLiteralContext(parser antlr.Parser, ctx antlr.ParserRuleContext) *IsLiteralContext {
-	var p = new(IsLiteralContext)
+func NewOrderByContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *OrderByContext {
+	var p = new(OrderByContext)
+
+	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(parent, invokingState)
 
-	p.ExprContext = NewEmptyExprContext()
 	p.parser = parser
-	p.CopyFrom(ctx.(*ExprContext))
+	p.RuleIndex = TSLParserRULE_orderBy
 
 	return p
 }
 
-func (s *IsLiteralContext) GetRuleContext() antlr.RuleContext {
-	return s
-}
-
-func (s *IsLiteralContext) MathExp() IMathExpContext {
-	var t = s.GetTypedRuleContext(reflect.TypeOf((*IMathExpContext)(nil)).Elem(), 0)
-
-	if t == nil {
-		return nil
-	}
+func (s *OrderByContext) GetParser() antlr.Parser { return s.parser }
 
-	return t.(IMathExpContext)
+func (s *OrderByContext) K_ORDER() antlr.TerminalNode {
+	return s.GetToken(TSLParserK_ORDER, 0)
 }
 
-func (s *IsLiteralContext) K_IS() antlr.TerminalNode {
-	return s.GetToken(TSLParserK_IS, 0)
+func (s *OrderByContext) K_BY() antlr.TerminalNode {
+	return s.GetToken(TSLParserK_BY, 0)
 }
 
-func (s *IsLiteralContext) LiteralValue() ILiteralValueContext {
-	var t = s.GetTypedRuleContext(reflect.TypeOf((*ILiteralValueContext)(nil)).Elem(), 0)
+func (s *OrderByContext) AllSortKey() []ISortKeyContext {
+	var ts = s.GetTypedRuleContexts(reflect.TypeOf((*ISortKeyContext)(nil)).Elem())
+	var tst = make([]ISortKeyContext, len(ts))
 
-	if t == nil {
-		return nil
+	for i, t := range ts {
+		if t != nil {
+			tst[i] = t.(ISortKeyContext)
+		}
 	}
 
-	return t.(ILiteralValueContext)
+	return tst
 }
 
-func (s *IsLiteralContext) KeyNot() IKeyNotContext {
-	var t = s.GetTypedRuleContext(reflect.TypeOf((*IKeyNotContext)(nil)).Elem(), 0)
+func (s *OrderByContext) SortKey(i int) ISortKeyContext {
+	var t = s.GetTypedRuleContext(reflect.TypeOf((*ISortKeyContext)(nil)).Elem(), i)
 
 	if t == nil {
 		return nil
 	}
 
-	return t.(IKeyNotContext)
+	return t.(ISortKeyContext)
 }
 
-func (s *IsLiteralContext) EnterRule(listener antlr.ParseTreeListener) {
-	if listenerT, ok := listener.(TSLListener); ok {
-		listenerT.EnterIsLiteral(s)
-	}
+func (s *OrderByContext) GetRuleContext() antlr.RuleContext {
+	return s
 }
 
-func (s *IsLiteralContext) ExitRule(listener antlr.ParseTreeListener) {
+func (s *OrderByContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
+	return antlr.TreesStringTree(s, ruleNames, recog)
+}
+
+func (s *OrderByContext) EnterRule(listener antlr.ParseTreeListener) {
 	if listenerT, ok := listener.(TSLListener); ok {
-		listenerT.ExitIsLiteral(s)
+		listenerT.EnterOrderBy(s)
 	}
 }
 
-type AndContext struct {
-	*ExprContext
+func (s *OrderByContext) ExitRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(TSLListener); ok {
+		listenerT.ExitOrderBy(s)
+	}
 }
 
-func NewAndContext(parser antlr.Parser, ctx antlr.ParserRuleContext) *AndContext {
-	var p = new(AndContext)
-
-	p.ExprContext = NewEmptyExprContext()
-	p.parser = parser
-	p.CopyFrom(ctx.(*ExprContext))
+func (p *TSLParser) OrderBy() (localctx IOrderByContext) {
+	localctx = NewOrderByContext(p, p.GetParserRuleContext(), p.GetState())
+	p.EnterRule(localctx, 6, TSLParserRULE_orderBy)
+	var _la int
 
-	return p
-}
+	defer func() {
+		p.ExitRule()
+	}()
 
-func (s *AndContext) GetRuleContext() antlr.RuleContext {
-	return s
-}
+	defer func() {
+		if err := recover(); err != nil {
+			if v, ok := err.(antlr.RecognitionException); ok {
+				localctx.SetException(v)
+				p.GetErrorHandler().ReportError(p, v)
+				p.GetErrorHandler().Recover(p, v)
+			} else {
+				panic(err)
+			}
+		}
+	}()
 
-func (s *AndContext) AllExpr() []IExprContext {
-	var ts = s.GetTypedRuleContexts(reflect.TypeOf((*IExprContext)(nil)).Elem())
-	var tst = make([]IExprContext, len(ts))
+	p.EnterOuterAlt(localctx, 1)
+	{
+		p.SetState(85)
+		p.Match(TSLParserK_ORDER)
+	}
+	{
+		p.SetState(86)
+		p.Match(TSLParserK_BY)
+	}
+	{
+		p.SetState(87)
+		p.SortKey()
+	}
+	p.SetState(92)
+	p.GetErrorHandler().Sync(p)
+	_la = p.GetTokenStream().LA(1)
 
-	for i, t := range ts {
-		if t != nil {
-			tst[i] = t.(IExprContext)
+	for _la == TSLParserT__0 {
+		{
+			p.SetState(88)
+			p.Match(TSLParserT__0)
+		}
+		{
+			p.SetState(89)
+			p.SortKey()
 		}
+
+		p.SetState(94)
+		p.GetErrorHandler().Sync(p)
+		_la = p.GetTokenStream().LA(1)
 	}
 
-	return tst
+	return localctx
 }
 
-func (s *AndContext) Expr(i int) IExprContext {
-	var t = s.GetTypedRuleContext(reflect.TypeOf((*IExprContext)(nil)).Elem(), i)
+// ISortKeyContext is an interface to support dynamic dispatch.
+type ISortKeyContext interface {
+	antlr.ParserRuleContext
 
-	if t == nil {
-		return nil
-	}
+	// GetParser returns the parser.
+	GetParser() antlr.Parser
 
-	return t.(IExprContext)
+	// IsSortKeyContext differentiates from other interfaces.
+	IsSortKeyContext()
 }
 
-func (s *AndContext) K_AND() antlr.TerminalNode {
-	return s.GetToken(TSLParserK_AND, 0)
+type SortKeyContext struct {
+	*antlr.BaseParserRuleContext
+	parser antlr.Parser
 }
 
-func (s *AndContext) EnterRule(listener antlr.ParseTreeListener) {
-	if listenerT, ok := listener.(TSLListener); ok {
-		listenerT.EnterAnd(s)
-	}
+func NewEmptySortKeyContext() *SortKeyContext {
+	var p = new(SortKeyContext)
+	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(nil, -1)
+	p.RuleIndex = TSLParserRULE_sortKey
+	return p
 }
 
-func (s *AndContext) ExitRule(listener antlr.ParseTreeListener) {
-	if listenerT, ok := listener.(TSLListener); ok {
-		listenerT.ExitAnd(s)
-	}
-}
+func (*SortKeyContext) IsSortKeyContext() {}
 
-type BetweenContext struct {
-	*ExprContext
-}
+func NewSortKeyContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *SortKeyContext {
+	var p = new(SortKeyContext)
 
-func NewBetweenContext(parser antlr.Parser, ctx antlr.ParserRuleContext) *BetweenContext {
-	var p = new(BetweenContext)
+	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(parent, invokingState)
 
-	p.ExprContext = NewEmptyExprContext()
 	p.parser = parser
-	p.CopyFrom(ctx.(*ExprContext))
+	p.RuleIndex = TSLParserRULE_sortKey
 
 	return p
 }
 
-func (s *BetweenContext) GetRuleContext() antlr.RuleContext {
-	return s
-}
+func (s *SortKeyContext) GetParser() antlr.Parser { return s.parser }
 
-func (s *BetweenContext) MathExp() IMathExpContext {
-	var t = s.GetTypedRuleContext(reflect.TypeOf((*IMathExpContext)(nil)).Elem(), 0)
+func (s *SortKeyContext) ColumnName() IColumnNameContext {
+	var t = s.GetTypedRuleContext(reflect.TypeOf((*IColumnNameContext)(nil)).Elem(), 0)
 
 	if t == nil {
 		return nil
 	}
 
-	return t.(IMathExpContext)
-}
-
-func (s *BetweenContext) K_BETWEEN() antlr.TerminalNode {
-	return s.GetToken(TSLParserK_BETWEEN, 0)
+	return t.(IColumnNameContext)
 }
 
-func (s *BetweenContext) AllLiteralValue() []ILiteralValueContext {
-	var ts = s.GetTypedRuleContexts(reflect.TypeOf((*ILiteralValueContext)(nil)).Elem())
-	var tst = make([]ILiteralValueContext, len(ts))
-
-	for i, t := range ts {
-		if t != nil {
-			tst[i] = t.(ILiteralValueContext)
-		}
-	}
-
-	return tst
+func (s *SortKeyContext) K_ASC() antlr.TerminalNode {
+	return s.GetToken(TSLParserK_ASC, 0)
 }
 
-func (s *BetweenContext) LiteralValue(i int) ILiteralValueContext {
-	var t = s.GetTypedRuleContext(reflect.TypeOf((*ILiteralValueContext)(nil)).Elem(), i)
-
-	if t == nil {
-		return nil
-	}
-
-	return t.(ILiteralValueContext)
+func (s *SortKeyContext) K_DESC() antlr.TerminalNode {
+	return s.GetToken(TSLParserK_DESC, 0)
 }
 
-func (s *BetweenContext) K_AND() antlr.TerminalNode {
-	return s.GetToken(TSLParserK_AND, 0)
+func (s *SortKeyContext) GetRuleContext() antlr.RuleContext {
+	return s
 }
 
-func (s *BetweenContext) KeyNot() IKeyNotContext {
-	var t = s.GetTypedRuleContext(reflect.TypeOf((*IKeyNotContext)(nil)).Elem(), 0)
-
-	if t == nil {
-		return nil
-	}
-
-	return t.(IKeyNotContext)
+func (s *SortKeyContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
+	return antlr.TreesStringTree(s, ruleNames, recog)
 }
 
-func (s *BetweenContext) EnterRule(listener antlr.ParseTreeListener) {
+func (s *SortKeyContext) EnterRule(listener antlr.ParseTreeListener) {
 	if listenerT, ok := listener.(TSLListener); ok {
-		listenerT.EnterBetween(s)
+		listenerT.EnterSortKey(s)
 	}
 }
 
-func (s *BetweenContext) ExitRule(listener antlr.ParseTreeListener) {
+func (s *SortKeyContext) ExitRule(listener antlr.ParseTreeListener) {
 	if listenerT, ok := listener.(TSLListener); ok {
-		listenerT.ExitBetween(s)
+		listenerT.ExitSortKey(s)
 	}
 }
 
-type StringOpsContext struct {
-	*ExprContext
-}
+func (p *TSLParser) SortKey() (localctx ISortKeyContext) {
+	localctx = NewSortKeyContext(p, p.GetParserRuleContext(), p.GetState())
+	p.EnterRule(localctx, 8, TSLParserRULE_sortKey)
+	var _la int
 
-func NewStringOpsContext(parser antlr.Parser, ctx antlr.ParserRuleContext) *StringOpsContext {
-	var p = new(StringOpsContext)
+	defer func() {
+		p.ExitRule()
+	}()
 
-	p.ExprContext = NewEmptyExprContext()
-	p.parser = parser
-	p.CopyFrom(ctx.(*ExprContext))
+	defer func() {
+		if err := recover(); err != nil {
+			if v, ok := err.(antlr.RecognitionException); ok {
+				localctx.SetException(v)
+				p.GetErrorHandler().ReportError(p, v)
+				p.GetErrorHandler().Recover(p, v)
+			} else {
+				panic(err)
+			}
+		}
+	}()
 
+	p.EnterOuterAlt(localctx, 1)
+	{
+		p.SetState(95)
+		p.ColumnName()
+	}
+	p.SetState(97)
+	p.GetErrorHandler().Sync(p)
+	_la = p.GetTokenStream().LA(1)
+
+	if _la == TSLParserK_ASC || _la == TSLParserK_DESC {
+		{
+			p.SetState(96)
+			_la = p.GetTokenStream().LA(1)
+
+			if !(_la == TSLParserK_ASC || _la == TSLParserK_DESC) {
+				p.GetErrorHandler().RecoverInline(p)
+			} else {
+				p.GetErrorHandler().ReportMatch(p)
+				p.Consume()
+			}
+		}
+
+	}
+
+	return localctx
+}
+
+// ILimitContext is an interface to support dynamic dispatch.
+type ILimitContext interface {
+	antlr.ParserRuleContext
+
+	// GetParser returns the parser.
+	GetParser() antlr.Parser
+
+	// IsLimitContext differentiates from other interfaces.
+	IsLimitContext()
+}
+
+type LimitContext struct {
+	*antlr.BaseParserRuleContext
+	parser antlr.Parser
+}
+
+func NewEmptyLimitContext() *LimitContext {
+	var p = new(LimitContext)
+	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(nil, -1)
+	p.RuleIndex = TSLParserRULE_limit
 	return p
 }
 
-func (s *StringOpsContext) GetRuleContext() antlr.RuleContext {
+func (*LimitContext) IsLimitContext() {}
+
+func NewLimitContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *LimitContext {
+	var p = new(LimitContext)
+
+	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(parent, invokingState)
+
+	p.parser = parser
+	p.RuleIndex = TSLParserRULE_limit
+
+	return p
+}
+
+func (s *LimitContext) GetParser() antlr.Parser { return s.parser }
+
+func (s *LimitContext) K_LIMIT() antlr.TerminalNode {
+	return s.GetToken(TSLParserK_LIMIT, 0)
+}
+
+func (s *LimitContext) NUMERIC_LITERAL() antlr.TerminalNode {
+	return s.GetToken(TSLParserNUMERIC_LITERAL, 0)
+}
+
+func (s *LimitContext) GetRuleContext() antlr.RuleContext {
 	return s
 }
 
-func (s *StringOpsContext) MathExp() IMathExpContext {
-	var t = s.GetTypedRuleContext(reflect.TypeOf((*IMathExpContext)(nil)).Elem(), 0)
+func (s *LimitContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
+	return antlr.TreesStringTree(s, ruleNames, recog)
+}
 
-	if t == nil {
-		return nil
+func (s *LimitContext) EnterRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(TSLListener); ok {
+		listenerT.EnterLimit(s)
 	}
+}
 
-	return t.(IMathExpContext)
+func (s *LimitContext) ExitRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(TSLListener); ok {
+		listenerT.ExitLimit(s)
+	}
 }
 
-func (s *StringOpsContext) StringOp() IStringOpContext {
-	var t = s.GetTypedRuleContext(reflect.TypeOf((*IStringOpContext)(nil)).Elem(), 0)
+func (p *TSLParser) Limit() (localctx ILimitContext) {
+	localctx = NewLimitContext(p, p.GetParserRuleContext(), p.GetState())
+	p.EnterRule(localctx, 10, TSLParserRULE_limit)
 
-	if t == nil {
-		return nil
+	defer func() {
+		p.ExitRule()
+	}()
+
+	defer func() {
+		if err := recover(); err != nil {
+			if v, ok := err.(antlr.RecognitionException); ok {
+				localctx.SetException(v)
+				p.GetErrorHandler().ReportError(p, v)
+				p.GetErrorHandler().Recover(p, v)
+			} else {
+				panic(err)
+			}
+		}
+	}()
+
+	p.EnterOuterAlt(localctx, 1)
+	{
+		p.SetState(99)
+		p.Match(TSLParserK_LIMIT)
+	}
+	{
+		p.SetState(100)
+		p.Match(TSLParserNUMERIC_LITERAL)
 	}
 
-	return t.(IStringOpContext)
+	return localctx
 }
 
-func (s *StringOpsContext) LiteralValue() ILiteralValueContext {
-	var t = s.GetTypedRuleContext(reflect.TypeOf((*ILiteralValueContext)(nil)).Elem(), 0)
+// IOffsetContext is an interface to support dynamic dispatch.
+type IOffsetContext interface {
+	antlr.ParserRuleContext
 
-	if t == nil {
-		return nil
-	}
+	// GetParser returns the parser.
+	GetParser() antlr.Parser
 
-	return t.(ILiteralValueContext)
+	// IsOffsetContext differentiates from other interfaces.
+	IsOffsetContext()
 }
 
-func (s *StringOpsContext) EnterRule(listener antlr.ParseTreeListener) {
+type OffsetContext struct {
+	*antlr.BaseParserRuleContext
+	parser antlr.Parser
+}
+
+func NewEmptyOffsetContext() *OffsetContext {
+	var p = new(OffsetContext)
+	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(nil, -1)
+	p.RuleIndex = TSLParserRULE_offset
+	return p
+}
+
+func (*OffsetContext) IsOffsetContext() {}
+
+func NewOffsetContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *OffsetContext {
+	var p = new(OffsetContext)
+
+	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(parent, invokingState)
+
+	p.parser = parser
+	p.RuleIndex = TSLParserRULE_offset
+
+	return p
+}
+
+func (s *OffsetContext) GetParser() antlr.Parser { return s.parser }
+
+func (s *OffsetContext) K_OFFSET() antlr.TerminalNode {
+	return s.GetToken(TSLParserK_OFFSET, 0)
+}
+
+func (s *OffsetContext) NUMERIC_LITERAL() antlr.TerminalNode {
+	return s.GetToken(TSLParserNUMERIC_LITERAL, 0)
+}
+
+func (s *OffsetContext) GetRuleContext() antlr.RuleContext {
+	return s
+}
+
+func (s *OffsetContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
+	return antlr.TreesStringTree(s, ruleNames, recog)
+}
+
+func (s *OffsetContext) EnterRule(listener antlr.ParseTreeListener) {
 	if listenerT, ok := listener.(TSLListener); ok {
-		listenerT.EnterStringOps(s)
+		listenerT.EnterOffset(s)
 	}
 }
 
-func (s *StringOpsContext) ExitRule(listener antlr.ParseTreeListener) {
+func (s *OffsetContext) ExitRule(listener antlr.ParseTreeListener) {
 	if listenerT, ok := listener.(TSLListener); ok {
-		listenerT.ExitStringOps(s)
+		listenerT.ExitOffset(s)
 	}
 }
 
-type IsNullContext struct {
-	*ExprContext
+func (p *TSLParser) Offset() (localctx IOffsetContext) {
+	localctx = NewOffsetContext(p, p.GetParserRuleContext(), p.GetState())
+	p.EnterRule(localctx, 12, TSLParserRULE_offset)
+
+	defer func() {
+		p.ExitRule()
+	}()
+
+	defer func() {
+		if err := recover(); err != nil {
+			if v, ok := err.(antlr.RecognitionException); ok {
+				localctx.SetException(v)
+				p.GetErrorHandler().ReportError(p, v)
+				p.GetErrorHandler().Recover(p, v)
+			} else {
+				panic(err)
+			}
+		}
+	}()
+
+	p.EnterOuterAlt(localctx, 1)
+	{
+		p.SetState(102)
+		p.Match(TSLParserK_OFFSET)
+	}
+	{
+		p.SetState(103)
+		p.Match(TSLParserNUMERIC_LITERAL)
+	}
+
+	return localctx
 }
 
-func NewIsNullContext(parser antlr.Parser, ctx antlr.ParserRuleContext) *IsNullContext {
-	var p = new(IsNullContext)
+// IExprContext is an interface to support dynamic dispatch.
+type IExprContext interface {
+	antlr.ParserRuleContext
+
+	// GetParser returns the parser.
+	GetParser() antlr.Parser
+
+	// IsExprContext differentiates from other interfaces.
+	IsExprContext()
+}
+
+type ExprContext struct {
+	*antlr.BaseParserRuleContext
+	parser antlr.Parser
+}
+
+func NewEmptyExprContext() *ExprContext {
+	var p = new(ExprContext)
+	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(nil, -1)
+	p.RuleIndex = TSLParserRULE_expr
+	return p
+}
+
+func (*ExprContext) IsExprContext() {}
+
+func NewExprContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *ExprContext {
+	var p = new(ExprContext)
+
+	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(parent, invokingState)
 
-	p.ExprContext = NewEmptyExprContext()
 	p.parser = parser
-	p.CopyFrom(ctx.(*ExprContext))
+	p.RuleIndex = TSLParserRULE_expr
 
 	return p
 }
 
-func (s *IsNullContext) GetRuleContext() antlr.RuleContext {
-	return s
+func (s *ExprContext) GetParser() antlr.Parser { return s.parser }
+
+func (s *ExprContext) CopyFrom(ctx *ExprContext) {
+	s.BaseParserRuleContext.CopyFrom(ctx.BaseParserRuleContext)
 }
 
-func (s *IsNullContext) MathExp() IMathExpContext {
-	var t = s.GetTypedRuleContext(reflect.TypeOf((*IMathExpContext)(nil)).Elem(), 0)
+func (s *ExprContext) GetRuleContext() antlr.RuleContext {
+	return s
+}
 
-	if t == nil {
-		return nil
-	}
+func (s *ExprContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
+	return antlr.TreesStringTree(s, ruleNames, recog)
+}
 
-	return t.(IMathExpContext)
+type ParContext struct {
+	*ExprContext
 }
 
-func (s *IsNullContext) K_IS() antlr.TerminalNode {
-	return s.GetToken(TSLParserK_IS, 0)
+func NewParContext(parser antlr.Parser, ctx antlr.ParserRuleContext) *ParContext {
+	var p = new(ParContext)
+
+	p.ExprContext = NewEmptyExprContext()
+	p.parser = parser
+	p.CopyFrom(ctx.(*ExprContext))
+
+	return p
 }
 
-func (s *IsNullContext) K_NULL() antlr.TerminalNode {
-	return s.GetToken(TSLParserK_NULL, 0)
+func (s *ParContext) GetRuleContext() antlr.RuleContext {
+	return s
 }
 
-func (s *IsNullContext) KeyNot() IKeyNotContext {
-	var t = s.GetTypedRuleContext(reflect.TypeOf((*IKeyNotContext)(nil)).Elem(), 0)
+func (s *ParContext) Expr() IExprContext {
+	var t = s.GetTypedRuleContext(reflect.TypeOf((*IExprContext)(nil)).Elem(), 0)
 
 	if t == nil {
 		return nil
 	}
 
-	return t.(IKeyNotContext)
+	return t.(IExprContext)
 }
 
-func (s *IsNullContext) EnterRule(listener antlr.ParseTreeListener) {
+func (s *ParContext) EnterRule(listener antlr.ParseTreeListener) {
 	if listenerT, ok := listener.(TSLListener); ok {
-		listenerT.EnterIsNull(s)
+		listenerT.EnterPar(s)
 	}
 }
 
-func (s *IsNullContext) ExitRule(listener antlr.ParseTreeListener) {
+func (s *ParContext) ExitRule(listener antlr.ParseTreeListener) {
 	if listenerT, ok := listener.(TSLListener); ok {
-		listenerT.ExitIsNull(s)
+		listenerT.ExitPar(s)
 	}
 }
 
-type LiteralOpsContext struct {
+type WithinBoxContext struct {
 	*ExprContext
 }
 
-func NewLiteralOpsContext(parser antlr.Parser, ctx antlr.ParserRuleContext) *LiteralOpsContext {
-	var p = new(LiteralOpsContext)
+func NewWithinBoxContext(parser antlr.Parser, ctx antlr.ParserRuleContext) *WithinBoxContext {
+	var p = new(WithinBoxContext)
 
 	p.ExprContext = NewEmptyExprContext()
 	p.parser = parser
@@ -965,11 +1337,11 @@ func NewLiteralOpsContext(parser antlr.Parser, ctx antlr.ParserRuleContext) *Lit
 	return p
 }
 
-func (s *LiteralOpsContext) GetRuleContext() antlr.RuleContext {
+func (s *WithinBoxContext) GetRuleContext() antlr.RuleContext {
 	return s
 }
 
-func (s *LiteralOpsContext) MathExp() IMathExpContext {
+func (s *WithinBoxContext) MathExp() IMathExpContext {
 	var t = s.GetTypedRuleContext(reflect.TypeOf((*IMathExpContext)(nil)).Elem(), 0)
 
 	if t == nil {
@@ -979,660 +1351,2904 @@ func (s *LiteralOpsContext) MathExp() IMathExpContext {
 	return t.(IMathExpContext)
 }
 
-func (s *LiteralOpsContext) LiteralOp() ILiteralOpContext {
-	var t = s.GetTypedRuleContext(reflect.TypeOf((*ILiteralOpContext)(nil)).Elem(), 0)
+func (s *WithinBoxContext) K_WITHIN() antlr.TerminalNode {
+	return s.GetToken(TSLParserK_WITHIN, 0)
+}
+
+func (s *WithinBoxContext) Box() IBoxContext {
+	var t = s.GetTypedRuleContext(reflect.TypeOf((*IBoxContext)(nil)).Elem(), 0)
 
 	if t == nil {
 		return nil
 	}
 
-	return t.(ILiteralOpContext)
+	return t.(IBoxContext)
 }
 
-func (s *LiteralOpsContext) LiteralValue() ILiteralValueContext {
-	var t = s.GetTypedRuleContext(reflect.TypeOf((*ILiteralValueContext)(nil)).Elem(), 0)
+func (s *WithinBoxContext) KeyNot() IKeyNotContext {
+	var t = s.GetTypedRuleContext(reflect.TypeOf((*IKeyNotContext)(nil)).Elem(), 0)
 
 	if t == nil {
 		return nil
 	}
 
-	return t.(ILiteralValueContext)
+	return t.(IKeyNotContext)
 }
 
-func (s *LiteralOpsContext) EnterRule(listener antlr.ParseTreeListener) {
+func (s *WithinBoxContext) EnterRule(listener antlr.ParseTreeListener) {
 	if listenerT, ok := listener.(TSLListener); ok {
-		listenerT.EnterLiteralOps(s)
+		listenerT.EnterWithinBox(s)
 	}
 }
 
-func (s *LiteralOpsContext) ExitRule(listener antlr.ParseTreeListener) {
+func (s *WithinBoxContext) ExitRule(listener antlr.ParseTreeListener) {
 	if listenerT, ok := listener.(TSLListener); ok {
-		listenerT.ExitLiteralOps(s)
+		listenerT.ExitWithinBox(s)
 	}
 }
 
-func (p *TSLParser) Expr() (localctx IExprContext) {
-	return p.expr(0)
+type OrContext struct {
+	*ExprContext
 }
 
-func (p *TSLParser) expr(_p int) (localctx IExprContext) {
-	var _parentctx antlr.ParserRuleContext = p.GetParserRuleContext()
-	_parentState := p.GetState()
-	localctx = NewExprContext(p, p.GetParserRuleContext(), _parentState)
-	var _prevctx IExprContext = localctx
-	var _ antlr.ParserRuleContext = _prevctx // TODO: To prevent unused variable warning.
-	_startState := 2
-	p.EnterRecursionRule(localctx, 2, TSLParserRULE_expr, _p)
-	var _la int
+func NewOrContext(parser antlr.Parser, ctx antlr.ParserRuleContext) *OrContext {
+	var p = new(OrContext)
 
-	defer func() {
-		p.UnrollRecursionContexts(_parentctx)
-	}()
+	p.ExprContext = NewEmptyExprContext()
+	p.parser = parser
+	p.CopyFrom(ctx.(*ExprContext))
 
-	defer func() {
-		if err := recover(); err != nil {
-			if v, ok := err.(antlr.RecognitionException); ok {
-				localctx.SetException(v)
-				p.GetErrorHandler().ReportError(p, v)
-				p.GetErrorHandler().Recover(p, v)
-			} else {
-				panic(err)
-			}
-		}
-	}()
+	return p
+}
 
-	var _alt int
+func (s *OrContext) GetRuleContext() antlr.RuleContext {
+	return s
+}
 
-	p.EnterOuterAlt(localctx, 1)
-	p.SetState(90)
-	p.GetErrorHandler().Sync(p)
-	switch p.GetInterpreter().AdaptivePredict(p.GetTokenStream(), 7, p.GetParserRuleContext()) {
-	case 1:
-		localctx = NewLiteralOpsContext(p, localctx)
-		p.SetParserRuleContext(localctx)
-		_prevctx = localctx
+func (s *OrContext) AllExpr() []IExprContext {
+	var ts = s.GetTypedRuleContexts(reflect.TypeOf((*IExprContext)(nil)).Elem())
+	var tst = make([]IExprContext, len(ts))
 
-		{
-			p.SetState(28)
-			p.mathExp(0)
-		}
-		{
-			p.SetState(29)
-			p.LiteralOp()
-		}
-		{
-			p.SetState(30)
-			p.LiteralValue()
+	for i, t := range ts {
+		if t != nil {
+			tst[i] = t.(IExprContext)
 		}
+	}
 
-	case 2:
-		localctx = NewStringOpsContext(p, localctx)
-		p.SetParserRuleContext(localctx)
-		_prevctx = localctx
-		{
-			p.SetState(32)
-			p.mathExp(0)
-		}
-		{
-			p.SetState(33)
-			p.StringOp()
-		}
-		{
-			p.SetState(34)
-			p.LiteralValue()
-		}
+	return tst
+}
 
-	case 3:
-		localctx = NewLikeContext(p, localctx)
-		p.SetParserRuleContext(localctx)
-		_prevctx = localctx
-		{
-			p.SetState(36)
-			p.mathExp(0)
-		}
-		p.SetState(38)
-		p.GetErrorHandler().Sync(p)
-		_la = p.GetTokenStream().LA(1)
+func (s *OrContext) Expr(i int) IExprContext {
+	var t = s.GetTypedRuleContext(reflect.TypeOf((*IExprContext)(nil)).Elem(), i)
 
-		if _la == TSLParserK_NOT {
-			{
-				p.SetState(37)
-				p.KeyNot()
-			}
+	if t == nil {
+		return nil
+	}
 
-		}
-		{
-			p.SetState(40)
-			p.Match(TSLParserK_LIKE)
-		}
-		{
-			p.SetState(41)
-			p.LiteralValue()
-		}
+	return t.(IExprContext)
+}
 
-	case 4:
-		localctx = NewIsNullContext(p, localctx)
-		p.SetParserRuleContext(localctx)
-		_prevctx = localctx
-		{
-			p.SetState(43)
-			p.mathExp(0)
-		}
-		{
-			p.SetState(44)
-			p.Match(TSLParserK_IS)
-		}
-		p.SetState(46)
-		p.GetErrorHandler().Sync(p)
-		_la = p.GetTokenStream().LA(1)
+func (s *OrContext) K_OR() antlr.TerminalNode {
+	return s.GetToken(TSLParserK_OR, 0)
+}
 
-		if _la == TSLParserK_NOT {
-			{
-				p.SetState(45)
-				p.KeyNot()
-			}
+func (s *OrContext) EnterRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(TSLListener); ok {
+		listenerT.EnterOr(s)
+	}
+}
 
-		}
-		{
-			p.SetState(48)
-			p.Match(TSLParserK_NULL)
-		}
+func (s *OrContext) ExitRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(TSLListener); ok {
+		listenerT.ExitOr(s)
+	}
+}
 
-	case 5:
-		localctx = NewIsLiteralContext(p, localctx)
-		p.SetParserRuleContext(localctx)
-		_prevctx = localctx
-		{
-			p.SetState(50)
-			p.mathExp(0)
-		}
-		{
-			p.SetState(51)
-			p.Match(TSLParserK_IS)
-		}
-		p.SetState(53)
-		p.GetErrorHandler().Sync(p)
-		_la = p.GetTokenStream().LA(1)
+type InContext struct {
+	*ExprContext
+}
 
-		if _la == TSLParserK_NOT {
-			{
-				p.SetState(52)
-				p.KeyNot()
-			}
+func NewInContext(parser antlr.Parser, ctx antlr.ParserRuleContext) *InContext {
+	var p = new(InContext)
 
-		}
-		{
-			p.SetState(55)
-			p.LiteralValue()
-		}
+	p.ExprContext = NewEmptyExprContext()
+	p.parser = parser
+	p.CopyFrom(ctx.(*ExprContext))
 
-	case 6:
-		localctx = NewBetweenContext(p, localctx)
-		p.SetParserRuleContext(localctx)
-		_prevctx = localctx
-		{
-			p.SetState(57)
-			p.mathExp(0)
-		}
-		p.SetState(59)
-		p.GetErrorHandler().Sync(p)
-		_la = p.GetTokenStream().LA(1)
+	return p
+}
 
-		if _la == TSLParserK_NOT {
-			{
-				p.SetState(58)
-				p.KeyNot()
-			}
+func (s *InContext) GetRuleContext() antlr.RuleContext {
+	return s
+}
 
-		}
-		{
-			p.SetState(61)
-			p.Match(TSLParserK_BETWEEN)
-		}
-		{
-			p.SetState(62)
-			p.LiteralValue()
-		}
-		{
-			p.SetState(63)
-			p.Match(TSLParserK_AND)
-		}
-		{
-			p.SetState(64)
-			p.LiteralValue()
-		}
+func (s *InContext) MathExp() IMathExpContext {
+	var t = s.GetTypedRuleContext(reflect.TypeOf((*IMathExpContext)(nil)).Elem(), 0)
 
-	case 7:
-		localctx = NewInContext(p, localctx)
-		p.SetParserRuleContext(localctx)
-		_prevctx = localctx
-		{
-			p.SetState(66)
-			p.mathExp(0)
-		}
-		p.SetState(68)
-		p.GetErrorHandler().Sync(p)
-		_la = p.GetTokenStream().LA(1)
+	if t == nil {
+		return nil
+	}
 
-		if _la == TSLParserK_NOT {
-			{
-				p.SetState(67)
-				p.KeyNot()
-			}
+	return t.(IMathExpContext)
+}
 
-		}
-		{
-			p.SetState(70)
-			p.Match(TSLParserK_IN)
-		}
+func (s *InContext) K_IN() antlr.TerminalNode {
+	return s.GetToken(TSLParserK_IN, 0)
+}
 
-		{
-			p.SetState(71)
-			p.Match(TSLParserT__0)
-		}
-		p.SetState(80)
-		p.GetErrorHandler().Sync(p)
-		_la = p.GetTokenStream().LA(1)
+func (s *InContext) KeyNot() IKeyNotContext {
+	var t = s.GetTypedRuleContext(reflect.TypeOf((*IKeyNotContext)(nil)).Elem(), 0)
 
-		if ((_la)&-(0x1f+1)) == 0 && ((1<<uint(_la))&((1<<TSLParserT__16)|(1<<TSLParserT__17)|(1<<TSLParserNUMERIC_LITERAL)|(1<<TSLParserSTRING_LITERAL))) != 0 {
-			{
-				p.SetState(72)
-				p.LiteralValue()
-			}
-			p.SetState(77)
-			p.GetErrorHandler().Sync(p)
-			_la = p.GetTokenStream().LA(1)
+	if t == nil {
+		return nil
+	}
 
-			for _la == TSLParserT__1 {
-				{
-					p.SetState(73)
-					p.Match(TSLParserT__1)
-				}
-				{
-					p.SetState(74)
-					p.LiteralValue()
-				}
+	return t.(IKeyNotContext)
+}
 
-				p.SetState(79)
-				p.GetErrorHandler().Sync(p)
-				_la = p.GetTokenStream().LA(1)
-			}
+func (s *InContext) AllLiteralValue() []ILiteralValueContext {
+	var ts = s.GetTypedRuleContexts(reflect.TypeOf((*ILiteralValueContext)(nil)).Elem())
+	var tst = make([]ILiteralValueContext, len(ts))
 
+	for i, t := range ts {
+		if t != nil {
+			tst[i] = t.(ILiteralValueContext)
 		}
-		{
-			p.SetState(82)
-			p.Match(TSLParserT__2)
+	}
+
+	return tst
+}
+
+func (s *InContext) LiteralValue(i int) ILiteralValueContext {
+	var t = s.GetTypedRuleContext(reflect.TypeOf((*ILiteralValueContext)(nil)).Elem(), i)
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(ILiteralValueContext)
+}
+
+func (s *InContext) EnterRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(TSLListener); ok {
+		listenerT.EnterIn(s)
+	}
+}
+
+func (s *InContext) ExitRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(TSLListener); ok {
+		listenerT.ExitIn(s)
+	}
+}
+
+type IsLiteralContext struct {
+	*ExprContext
+}
+
+func NewIsLiteralContext(parser antlr.Parser, ctx antlr.ParserRuleContext) *IsLiteralContext {
+	var p = new(IsLiteralContext)
+
+	p.ExprContext = NewEmptyExprContext()
+	p.parser = parser
+	p.CopyFrom(ctx.(*ExprContext))
+
+	return p
+}
+
+func (s *IsLiteralContext) GetRuleContext() antlr.RuleContext {
+	return s
+}
+
+func (s *IsLiteralContext) MathExp() IMathExpContext {
+	var t = s.GetTypedRuleContext(reflect.TypeOf((*IMathExpContext)(nil)).Elem(), 0)
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IMathExpContext)
+}
+
+func (s *IsLiteralContext) K_IS() antlr.TerminalNode {
+	return s.GetToken(TSLParserK_IS, 0)
+}
+
+func (s *IsLiteralContext) LiteralValue() ILiteralValueContext {
+	var t = s.GetTypedRuleContext(reflect.TypeOf((*ILiteralValueContext)(nil)).Elem(), 0)
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(ILiteralValueContext)
+}
+
+func (s *IsLiteralContext) KeyNot() IKeyNotContext {
+	var t = s.GetTypedRuleContext(reflect.TypeOf((*IKeyNotContext)(nil)).Elem(), 0)
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IKeyNotContext)
+}
+
+func (s *IsLiteralContext) EnterRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(TSLListener); ok {
+		listenerT.EnterIsLiteral(s)
+	}
+}
+
+func (s *IsLiteralContext) ExitRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(TSLListener); ok {
+		listenerT.ExitIsLiteral(s)
+	}
+}
+
+type BetweenContext struct {
+	*ExprContext
+}
+
+func NewBetweenContext(parser antlr.Parser, ctx antlr.ParserRuleContext) *BetweenContext {
+	var p = new(BetweenContext)
+
+	p.ExprContext = NewEmptyExprContext()
+	p.parser = parser
+	p.CopyFrom(ctx.(*ExprContext))
+
+	return p
+}
+
+func (s *BetweenContext) GetRuleContext() antlr.RuleContext {
+	return s
+}
+
+func (s *BetweenContext) MathExp() IMathExpContext {
+	var t = s.GetTypedRuleContext(reflect.TypeOf((*IMathExpContext)(nil)).Elem(), 0)
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IMathExpContext)
+}
+
+func (s *BetweenContext) K_BETWEEN() antlr.TerminalNode {
+	return s.GetToken(TSLParserK_BETWEEN, 0)
+}
+
+func (s *BetweenContext) AllLiteralValue() []ILiteralValueContext {
+	var ts = s.GetTypedRuleContexts(reflect.TypeOf((*ILiteralValueContext)(nil)).Elem())
+	var tst = make([]ILiteralValueContext, len(ts))
+
+	for i, t := range ts {
+		if t != nil {
+			tst[i] = t.(ILiteralValueContext)
+		}
+	}
+
+	return tst
+}
+
+func (s *BetweenContext) LiteralValue(i int) ILiteralValueContext {
+	var t = s.GetTypedRuleContext(reflect.TypeOf((*ILiteralValueContext)(nil)).Elem(), i)
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(ILiteralValueContext)
+}
+
+func (s *BetweenContext) K_AND() antlr.TerminalNode {
+	return s.GetToken(TSLParserK_AND, 0)
+}
+
+func (s *BetweenContext) KeyNot() IKeyNotContext {
+	var t = s.GetTypedRuleContext(reflect.TypeOf((*IKeyNotContext)(nil)).Elem(), 0)
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IKeyNotContext)
+}
+
+func (s *BetweenContext) EnterRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(TSLListener); ok {
+		listenerT.EnterBetween(s)
+	}
+}
+
+func (s *BetweenContext) ExitRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(TSLListener); ok {
+		listenerT.ExitBetween(s)
+	}
+}
+
+type StringOpsContext struct {
+	*ExprContext
+}
+
+func NewStringOpsContext(parser antlr.Parser, ctx antlr.ParserRuleContext) *StringOpsContext {
+	var p = new(StringOpsContext)
+
+	p.ExprContext = NewEmptyExprContext()
+	p.parser = parser
+	p.CopyFrom(ctx.(*ExprContext))
+
+	return p
+}
+
+func (s *StringOpsContext) GetRuleContext() antlr.RuleContext {
+	return s
+}
+
+func (s *StringOpsContext) MathExp() IMathExpContext {
+	var t = s.GetTypedRuleContext(reflect.TypeOf((*IMathExpContext)(nil)).Elem(), 0)
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IMathExpContext)
+}
+
+func (s *StringOpsContext) StringOp() IStringOpContext {
+	var t = s.GetTypedRuleContext(reflect.TypeOf((*IStringOpContext)(nil)).Elem(), 0)
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IStringOpContext)
+}
+
+func (s *StringOpsContext) LiteralValue() ILiteralValueContext {
+	var t = s.GetTypedRuleContext(reflect.TypeOf((*ILiteralValueContext)(nil)).Elem(), 0)
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(ILiteralValueContext)
+}
+
+func (s *StringOpsContext) EnterRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(TSLListener); ok {
+		listenerT.EnterStringOps(s)
+	}
+}
+
+func (s *StringOpsContext) ExitRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(TSLListener); ok {
+		listenerT.ExitStringOps(s)
+	}
+}
+
+type LiteralOpsContext struct {
+	*ExprContext
+}
+
+func NewLiteralOpsContext(parser antlr.Parser, ctx antlr.ParserRuleContext) *LiteralOpsContext {
+	var p = new(LiteralOpsContext)
+
+	p.ExprContext = NewEmptyExprContext()
+	p.parser = parser
+	p.CopyFrom(ctx.(*ExprContext))
+
+	return p
+}
+
+func (s *LiteralOpsContext) GetRuleContext() antlr.RuleContext {
+	return s
+}
+
+func (s *LiteralOpsContext) MathExp() IMathExpContext {
+	var t = s.GetTypedRuleContext(reflect.TypeOf((*IMathExpContext)(nil)).Elem(), 0)
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IMathExpContext)
+}
+
+func (s *LiteralOpsContext) LiteralOp() ILiteralOpContext {
+	var t = s.GetTypedRuleContext(reflect.TypeOf((*ILiteralOpContext)(nil)).Elem(), 0)
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(ILiteralOpContext)
+}
+
+func (s *LiteralOpsContext) LiteralValue() ILiteralValueContext {
+	var t = s.GetTypedRuleContext(reflect.TypeOf((*ILiteralValueContext)(nil)).Elem(), 0)
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(ILiteralValueContext)
+}
+
+func (s *LiteralOpsContext) EnterRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(TSLListener); ok {
+		listenerT.EnterLiteralOps(s)
+	}
+}
+
+func (s *LiteralOpsContext) ExitRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(TSLListener); ok {
+		listenerT.ExitLiteralOps(s)
+	}
+}
+
+type NotContext struct {
+	*ExprContext
+}
+
+func NewNotContext(parser antlr.Parser, ctx antlr.ParserRuleContext) *NotContext {
+	var p = new(NotContext)
+
+	p.ExprContext = NewEmptyExprContext()
+	p.parser = parser
+	p.CopyFrom(ctx.(*ExprContext))
+
+	return p
+}
+
+func (s *NotContext) GetRuleContext() antlr.RuleContext {
+	return s
+}
+
+func (s *NotContext) K_NOT() antlr.TerminalNode {
+	return s.GetToken(TSLParserK_NOT, 0)
+}
+
+func (s *NotContext) Expr() IExprContext {
+	var t = s.GetTypedRuleContext(reflect.TypeOf((*IExprContext)(nil)).Elem(), 0)
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IExprContext)
+}
+
+func (s *NotContext) EnterRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(TSLListener); ok {
+		listenerT.EnterNot(s)
+	}
+}
+
+func (s *NotContext) ExitRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(TSLListener); ok {
+		listenerT.ExitNot(s)
+	}
+}
+
+type LikeContext struct {
+	*ExprContext
+}
+
+func NewLikeContext(parser antlr.Parser, ctx antlr.ParserRuleContext) *LikeContext {
+	var p = new(LikeContext)
+
+	p.ExprContext = NewEmptyExprContext()
+	p.parser = parser
+	p.CopyFrom(ctx.(*ExprContext))
+
+	return p
+}
+
+func (s *LikeContext) GetRuleContext() antlr.RuleContext {
+	return s
+}
+
+func (s *LikeContext) MathExp() IMathExpContext {
+	var t = s.GetTypedRuleContext(reflect.TypeOf((*IMathExpContext)(nil)).Elem(), 0)
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IMathExpContext)
+}
+
+func (s *LikeContext) LikeOp() ILikeOpContext {
+	var t = s.GetTypedRuleContext(reflect.TypeOf((*ILikeOpContext)(nil)).Elem(), 0)
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(ILikeOpContext)
+}
+
+func (s *LikeContext) LiteralValue() ILiteralValueContext {
+	var t = s.GetTypedRuleContext(reflect.TypeOf((*ILiteralValueContext)(nil)).Elem(), 0)
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(ILiteralValueContext)
+}
+
+func (s *LikeContext) KeyNot() IKeyNotContext {
+	var t = s.GetTypedRuleContext(reflect.TypeOf((*IKeyNotContext)(nil)).Elem(), 0)
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IKeyNotContext)
+}
+
+func (s *LikeContext) EnterRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(TSLListener); ok {
+		listenerT.EnterLike(s)
+	}
+}
+
+func (s *LikeContext) ExitRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(TSLListener); ok {
+		listenerT.ExitLike(s)
+	}
+}
+
+type AndContext struct {
+	*ExprContext
+}
+
+func NewAndContext(parser antlr.Parser, ctx antlr.ParserRuleContext) *AndContext {
+	var p = new(AndContext)
+
+	p.ExprContext = NewEmptyExprContext()
+	p.parser = parser
+	p.CopyFrom(ctx.(*ExprContext))
+
+	return p
+}
+
+func (s *AndContext) GetRuleContext() antlr.RuleContext {
+	return s
+}
+
+func (s *AndContext) AllExpr() []IExprContext {
+	var ts = s.GetTypedRuleContexts(reflect.TypeOf((*IExprContext)(nil)).Elem())
+	var tst = make([]IExprContext, len(ts))
+
+	for i, t := range ts {
+		if t != nil {
+			tst[i] = t.(IExprContext)
+		}
+	}
+
+	return tst
+}
+
+func (s *AndContext) Expr(i int) IExprContext {
+	var t = s.GetTypedRuleContext(reflect.TypeOf((*IExprContext)(nil)).Elem(), i)
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IExprContext)
+}
+
+func (s *AndContext) K_AND() antlr.TerminalNode {
+	return s.GetToken(TSLParserK_AND, 0)
+}
+
+func (s *AndContext) EnterRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(TSLListener); ok {
+		listenerT.EnterAnd(s)
+	}
+}
+
+func (s *AndContext) ExitRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(TSLListener); ok {
+		listenerT.ExitAnd(s)
+	}
+}
+
+type WithinContext struct {
+	*ExprContext
+}
+
+func NewWithinContext(parser antlr.Parser, ctx antlr.ParserRuleContext) *WithinContext {
+	var p = new(WithinContext)
+
+	p.ExprContext = NewEmptyExprContext()
+	p.parser = parser
+	p.CopyFrom(ctx.(*ExprContext))
+
+	return p
+}
+
+func (s *WithinContext) GetRuleContext() antlr.RuleContext {
+	return s
+}
+
+func (s *WithinContext) MathExp() IMathExpContext {
+	var t = s.GetTypedRuleContext(reflect.TypeOf((*IMathExpContext)(nil)).Elem(), 0)
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IMathExpContext)
+}
+
+func (s *WithinContext) K_WITHIN() antlr.TerminalNode {
+	return s.GetToken(TSLParserK_WITHIN, 0)
+}
+
+func (s *WithinContext) Distance() IDistanceContext {
+	var t = s.GetTypedRuleContext(reflect.TypeOf((*IDistanceContext)(nil)).Elem(), 0)
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IDistanceContext)
+}
+
+func (s *WithinContext) K_OF() antlr.TerminalNode {
+	return s.GetToken(TSLParserK_OF, 0)
+}
+
+func (s *WithinContext) Point() IPointContext {
+	var t = s.GetTypedRuleContext(reflect.TypeOf((*IPointContext)(nil)).Elem(), 0)
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IPointContext)
+}
+
+func (s *WithinContext) KeyNot() IKeyNotContext {
+	var t = s.GetTypedRuleContext(reflect.TypeOf((*IKeyNotContext)(nil)).Elem(), 0)
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IKeyNotContext)
+}
+
+func (s *WithinContext) EnterRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(TSLListener); ok {
+		listenerT.EnterWithin(s)
+	}
+}
+
+func (s *WithinContext) ExitRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(TSLListener); ok {
+		listenerT.ExitWithin(s)
+	}
+}
+
+type IsNullContext struct {
+	*ExprContext
+}
+
+func NewIsNullContext(parser antlr.Parser, ctx antlr.ParserRuleContext) *IsNullContext {
+	var p = new(IsNullContext)
+
+	p.ExprContext = NewEmptyExprContext()
+	p.parser = parser
+	p.CopyFrom(ctx.(*ExprContext))
+
+	return p
+}
+
+func (s *IsNullContext) GetRuleContext() antlr.RuleContext {
+	return s
+}
+
+func (s *IsNullContext) MathExp() IMathExpContext {
+	var t = s.GetTypedRuleContext(reflect.TypeOf((*IMathExpContext)(nil)).Elem(), 0)
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IMathExpContext)
+}
+
+func (s *IsNullContext) K_IS() antlr.TerminalNode {
+	return s.GetToken(TSLParserK_IS, 0)
+}
+
+func (s *IsNullContext) K_NULL() antlr.TerminalNode {
+	return s.GetToken(TSLParserK_NULL, 0)
+}
+
+func (s *IsNullContext) KeyNot() IKeyNotContext {
+	var t = s.GetTypedRuleContext(reflect.TypeOf((*IKeyNotContext)(nil)).Elem(), 0)
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IKeyNotContext)
+}
+
+func (s *IsNullContext) EnterRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(TSLListener); ok {
+		listenerT.EnterIsNull(s)
+	}
+}
+
+func (s *IsNullContext) ExitRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(TSLListener); ok {
+		listenerT.ExitIsNull(s)
+	}
+}
+
+func (p *TSLParser) Expr() (localctx IExprContext) {
+	return p.expr(0)
+}
+
+func (p *TSLParser) expr(_p int) (localctx IExprContext) {
+	var _parentctx antlr.ParserRuleContext = p.GetParserRuleContext()
+	_parentState := p.GetState()
+	localctx = NewExprContext(p, p.GetParserRuleContext(), _parentState)
+	var _prevctx IExprContext = localctx
+	var _ antlr.ParserRuleContext = _prevctx // TODO: To prevent unused variable warning.
+	_startState := 14
+	p.EnterRecursionRule(localctx, 14, TSLParserRULE_expr, _p)
+	var _la int
+
+	defer func() {
+		p.UnrollRecursionContexts(_parentctx)
+	}()
+
+	defer func() {
+		if err := recover(); err != nil {
+			if v, ok := err.(antlr.RecognitionException); ok {
+				localctx.SetException(v)
+				p.GetErrorHandler().ReportError(p, v)
+				p.GetErrorHandler().Recover(p, v)
+			} else {
+				panic(err)
+			}
+		}
+	}()
+
+	var _alt int
+
+	p.EnterOuterAlt(localctx, 1)
+	p.SetState(184)
+	p.GetErrorHandler().Sync(p)
+	switch p.GetInterpreter().AdaptivePredict(p.GetTokenStream(), 17, p.GetParserRuleContext()) {
+	case 1:
+		localctx = NewLiteralOpsContext(p, localctx)
+		p.SetParserRuleContext(localctx)
+		_prevctx = localctx
+
+		{
+			p.SetState(106)
+			p.mathExp(0)
+		}
+		{
+			p.SetState(107)
+			p.LiteralOp()
+		}
+		{
+			p.SetState(108)
+			p.LiteralValue()
+		}
+
+	case 2:
+		localctx = NewStringOpsContext(p, localctx)
+		p.SetParserRuleContext(localctx)
+		_prevctx = localctx
+		{
+			p.SetState(110)
+			p.mathExp(0)
+		}
+		{
+			p.SetState(111)
+			p.StringOp()
+		}
+		{
+			p.SetState(112)
+			p.LiteralValue()
+		}
+
+	case 3:
+		localctx = NewLikeContext(p, localctx)
+		p.SetParserRuleContext(localctx)
+		_prevctx = localctx
+		{
+			p.SetState(114)
+			p.mathExp(0)
+		}
+		p.SetState(116)
+		p.GetErrorHandler().Sync(p)
+		_la = p.GetTokenStream().LA(1)
+
+		if _la == TSLParserK_NOT {
+			{
+				p.SetState(115)
+				p.KeyNot()
+			}
+
+		}
+		{
+			p.SetState(118)
+			p.LikeOp()
+		}
+		{
+			p.SetState(119)
+			p.LiteralValue()
+		}
+
+	case 4:
+		localctx = NewIsNullContext(p, localctx)
+		p.SetParserRuleContext(localctx)
+		_prevctx = localctx
+		{
+			p.SetState(121)
+			p.mathExp(0)
+		}
+		{
+			p.SetState(122)
+			p.Match(TSLParserK_IS)
+		}
+		p.SetState(124)
+		p.GetErrorHandler().Sync(p)
+		_la = p.GetTokenStream().LA(1)
+
+		if _la == TSLParserK_NOT {
+			{
+				p.SetState(123)
+				p.KeyNot()
+			}
+
+		}
+		{
+			p.SetState(126)
+			p.Match(TSLParserK_NULL)
+		}
+
+	case 5:
+		localctx = NewIsLiteralContext(p, localctx)
+		p.SetParserRuleContext(localctx)
+		_prevctx = localctx
+		{
+			p.SetState(128)
+			p.mathExp(0)
+		}
+		{
+			p.SetState(129)
+			p.Match(TSLParserK_IS)
+		}
+		p.SetState(131)
+		p.GetErrorHandler().Sync(p)
+		_la = p.GetTokenStream().LA(1)
+
+		if _la == TSLParserK_NOT {
+			{
+				p.SetState(130)
+				p.KeyNot()
+			}
+
+		}
+		{
+			p.SetState(133)
+			p.LiteralValue()
+		}
+
+	case 6:
+		localctx = NewBetweenContext(p, localctx)
+		p.SetParserRuleContext(localctx)
+		_prevctx = localctx
+		{
+			p.SetState(135)
+			p.mathExp(0)
+		}
+		p.SetState(137)
+		p.GetErrorHandler().Sync(p)
+		_la = p.GetTokenStream().LA(1)
+
+		if _la == TSLParserK_NOT {
+			{
+				p.SetState(136)
+				p.KeyNot()
+			}
+
+		}
+		{
+			p.SetState(139)
+			p.Match(TSLParserK_BETWEEN)
+		}
+		{
+			p.SetState(140)
+			p.LiteralValue()
+		}
+		{
+			p.SetState(141)
+			p.Match(TSLParserK_AND)
+		}
+		{
+			p.SetState(142)
+			p.LiteralValue()
+		}
+
+	case 7:
+		localctx = NewInContext(p, localctx)
+		p.SetParserRuleContext(localctx)
+		_prevctx = localctx
+		{
+			p.SetState(144)
+			p.mathExp(0)
+		}
+		p.SetState(146)
+		p.GetErrorHandler().Sync(p)
+		_la = p.GetTokenStream().LA(1)
+
+		if _la == TSLParserK_NOT {
+			{
+				p.SetState(145)
+				p.KeyNot()
+			}
+
+		}
+		{
+			p.SetState(148)
+			p.Match(TSLParserK_IN)
+		}
+
+		{
+			p.SetState(149)
+			p.Match(TSLParserT__1)
+		}
+		p.SetState(158)
+		p.GetErrorHandler().Sync(p)
+		_la = p.GetTokenStream().LA(1)
+
+		if (((_la)&-(0x1f+1)) == 0 && ((1<<uint(_la))&((1<<TSLParserT__16)|(1<<TSLParserT__17)|(1<<TSLParserK_TRUE))) != 0) || (((_la-32)&-(0x1f+1)) == 0 && ((1<<uint((_la-32)))&((1<<(TSLParserK_FALSE-32))|(1<<(TSLParserK_NOW-32))|(1<<(TSLParserK_DATE-32))|(1<<(TSLParserNUMERIC_LITERAL-32))|(1<<(TSLParserDATE_LITERAL-32))|(1<<(TSLParserDURATION_LITERAL-32))|(1<<(TSLParserSTRING_LITERAL-32)))) != 0) {
+			{
+				p.SetState(150)
+				p.LiteralValue()
+			}
+			p.SetState(155)
+			p.GetErrorHandler().Sync(p)
+			_la = p.GetTokenStream().LA(1)
+
+			for _la == TSLParserT__0 {
+				{
+					p.SetState(151)
+					p.Match(TSLParserT__0)
+				}
+				{
+					p.SetState(152)
+					p.LiteralValue()
+				}
+
+				p.SetState(157)
+				p.GetErrorHandler().Sync(p)
+				_la = p.GetTokenStream().LA(1)
+			}
+
+		}
+		{
+			p.SetState(160)
+			p.Match(TSLParserT__2)
+		}
+
+	case 8:
+		localctx = NewWithinContext(p, localctx)
+		p.SetParserRuleContext(localctx)
+		_prevctx = localctx
+		{
+			p.SetState(162)
+			p.mathExp(0)
+		}
+		p.SetState(164)
+		p.GetErrorHandler().Sync(p)
+		_la = p.GetTokenStream().LA(1)
+
+		if _la == TSLParserK_NOT {
+			{
+				p.SetState(163)
+				p.KeyNot()
+			}
+
+		}
+		{
+			p.SetState(166)
+			p.Match(TSLParserK_WITHIN)
+		}
+		{
+			p.SetState(167)
+			p.Distance()
+		}
+		{
+			p.SetState(168)
+			p.Match(TSLParserK_OF)
+		}
+		{
+			p.SetState(169)
+			p.Point()
+		}
+
+	case 9:
+		localctx = NewWithinBoxContext(p, localctx)
+		p.SetParserRuleContext(localctx)
+		_prevctx = localctx
+		{
+			p.SetState(171)
+			p.mathExp(0)
+		}
+		p.SetState(173)
+		p.GetErrorHandler().Sync(p)
+		_la = p.GetTokenStream().LA(1)
+
+		if _la == TSLParserK_NOT {
+			{
+				p.SetState(172)
+				p.KeyNot()
+			}
+
+		}
+		{
+			p.SetState(175)
+			p.Match(TSLParserK_WITHIN)
+		}
+		{
+			p.SetState(176)
+			p.Box()
+		}
+
+	case 10:
+		localctx = NewNotContext(p, localctx)
+		p.SetParserRuleContext(localctx)
+		_prevctx = localctx
+		{
+			p.SetState(178)
+			p.Match(TSLParserK_NOT)
+		}
+		{
+			p.SetState(179)
+			p.expr(4)
+		}
+
+	case 11:
+		localctx = NewParContext(p, localctx)
+		p.SetParserRuleContext(localctx)
+		_prevctx = localctx
+		{
+			p.SetState(180)
+			p.Match(TSLParserT__1)
+		}
+		{
+			p.SetState(181)
+			p.expr(0)
+		}
+		{
+			p.SetState(182)
+			p.Match(TSLParserT__2)
+		}
+
+	}
+	p.GetParserRuleContext().SetStop(p.GetTokenStream().LT(-1))
+	p.SetState(194)
+	p.GetErrorHandler().Sync(p)
+	_alt = p.GetInterpreter().AdaptivePredict(p.GetTokenStream(), 19, p.GetParserRuleContext())
+
+	for _alt != 2 && _alt != antlr.ATNInvalidAltNumber {
+		if _alt == 1 {
+			if p.GetParseListeners() != nil {
+				p.TriggerExitRuleEvent()
+			}
+			_prevctx = localctx
+			p.SetState(192)
+			p.GetErrorHandler().Sync(p)
+			switch p.GetInterpreter().AdaptivePredict(p.GetTokenStream(), 18, p.GetParserRuleContext()) {
+			case 1:
+				localctx = NewAndContext(p, NewExprContext(p, _parentctx, _parentState))
+				p.PushNewRecursionContext(localctx, _startState, TSLParserRULE_expr)
+				p.SetState(186)
+
+				if !(p.Precpred(p.GetParserRuleContext(), 3)) {
+					panic(antlr.NewFailedPredicateException(p, "p.Precpred(p.GetParserRuleContext(), 3)", ""))
+				}
+				{
+					p.SetState(187)
+					p.Match(TSLParserK_AND)
+				}
+				{
+					p.SetState(188)
+					p.expr(4)
+				}
+
+			case 2:
+				localctx = NewOrContext(p, NewExprContext(p, _parentctx, _parentState))
+				p.PushNewRecursionContext(localctx, _startState, TSLParserRULE_expr)
+				p.SetState(189)
+
+				if !(p.Precpred(p.GetParserRuleContext(), 2)) {
+					panic(antlr.NewFailedPredicateException(p, "p.Precpred(p.GetParserRuleContext(), 2)", ""))
+				}
+				{
+					p.SetState(190)
+					p.Match(TSLParserK_OR)
+				}
+				{
+					p.SetState(191)
+					p.expr(3)
+				}
+
+			}
+
+		}
+		p.SetState(196)
+		p.GetErrorHandler().Sync(p)
+		_alt = p.GetInterpreter().AdaptivePredict(p.GetTokenStream(), 19, p.GetParserRuleContext())
+	}
+
+	return localctx
+}
+
+// ILiteralOpContext is an interface to support dynamic dispatch.
+type ILiteralOpContext interface {
+	antlr.ParserRuleContext
+
+	// GetParser returns the parser.
+	GetParser() antlr.Parser
+
+	// IsLiteralOpContext differentiates from other interfaces.
+	IsLiteralOpContext()
+}
+
+type LiteralOpContext struct {
+	*antlr.BaseParserRuleContext
+	parser antlr.Parser
+}
+
+func NewEmptyLiteralOpContext() *LiteralOpContext {
+	var p = new(LiteralOpContext)
+	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(nil, -1)
+	p.RuleIndex = TSLParserRULE_literalOp
+	return p
+}
+
+func (*LiteralOpContext) IsLiteralOpContext() {}
+
+func NewLiteralOpContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *LiteralOpContext {
+	var p = new(LiteralOpContext)
+
+	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(parent, invokingState)
+
+	p.parser = parser
+	p.RuleIndex = TSLParserRULE_literalOp
+
+	return p
+}
+
+func (s *LiteralOpContext) GetParser() antlr.Parser { return s.parser }
+func (s *LiteralOpContext) GetRuleContext() antlr.RuleContext {
+	return s
+}
+
+func (s *LiteralOpContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
+	return antlr.TreesStringTree(s, ruleNames, recog)
+}
+
+func (s *LiteralOpContext) EnterRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(TSLListener); ok {
+		listenerT.EnterLiteralOp(s)
+	}
+}
+
+func (s *LiteralOpContext) ExitRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(TSLListener); ok {
+		listenerT.ExitLiteralOp(s)
+	}
+}
+
+func (p *TSLParser) LiteralOp() (localctx ILiteralOpContext) {
+	localctx = NewLiteralOpContext(p, p.GetParserRuleContext(), p.GetState())
+	p.EnterRule(localctx, 16, TSLParserRULE_literalOp)
+	var _la int
+
+	defer func() {
+		p.ExitRule()
+	}()
+
+	defer func() {
+		if err := recover(); err != nil {
+			if v, ok := err.(antlr.RecognitionException); ok {
+				localctx.SetException(v)
+				p.GetErrorHandler().ReportError(p, v)
+				p.GetErrorHandler().Recover(p, v)
+			} else {
+				panic(err)
+			}
+		}
+	}()
+
+	p.SetState(199)
+	p.GetErrorHandler().Sync(p)
+
+	switch p.GetTokenStream().LA(1) {
+	case TSLParserT__3, TSLParserT__4, TSLParserT__5, TSLParserT__6:
+		p.EnterOuterAlt(localctx, 1)
+		{
+			p.SetState(197)
+			_la = p.GetTokenStream().LA(1)
+
+			if !(((_la)&-(0x1f+1)) == 0 && ((1<<uint(_la))&((1<<TSLParserT__3)|(1<<TSLParserT__4)|(1<<TSLParserT__5)|(1<<TSLParserT__6))) != 0) {
+				p.GetErrorHandler().RecoverInline(p)
+			} else {
+				p.GetErrorHandler().ReportMatch(p)
+				p.Consume()
+			}
+		}
+
+	case TSLParserT__7, TSLParserT__8, TSLParserT__9:
+		p.EnterOuterAlt(localctx, 2)
+		{
+			p.SetState(198)
+			_la = p.GetTokenStream().LA(1)
+
+			if !(((_la)&-(0x1f+1)) == 0 && ((1<<uint(_la))&((1<<TSLParserT__7)|(1<<TSLParserT__8)|(1<<TSLParserT__9))) != 0) {
+				p.GetErrorHandler().RecoverInline(p)
+			} else {
+				p.GetErrorHandler().ReportMatch(p)
+				p.Consume()
+			}
+		}
+
+	default:
+		panic(antlr.NewNoViableAltException(p, nil, nil, nil, nil, nil))
+	}
+
+	return localctx
+}
+
+// IStringOpContext is an interface to support dynamic dispatch.
+type IStringOpContext interface {
+	antlr.ParserRuleContext
+
+	// GetParser returns the parser.
+	GetParser() antlr.Parser
+
+	// IsStringOpContext differentiates from other interfaces.
+	IsStringOpContext()
+}
+
+type StringOpContext struct {
+	*antlr.BaseParserRuleContext
+	parser antlr.Parser
+}
+
+func NewEmptyStringOpContext() *StringOpContext {
+	var p = new(StringOpContext)
+	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(nil, -1)
+	p.RuleIndex = TSLParserRULE_stringOp
+	return p
+}
+
+func (*StringOpContext) IsStringOpContext() {}
+
+func NewStringOpContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *StringOpContext {
+	var p = new(StringOpContext)
+
+	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(parent, invokingState)
+
+	p.parser = parser
+	p.RuleIndex = TSLParserRULE_stringOp
+
+	return p
+}
+
+func (s *StringOpContext) GetParser() antlr.Parser { return s.parser }
+func (s *StringOpContext) GetRuleContext() antlr.RuleContext {
+	return s
+}
+
+func (s *StringOpContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
+	return antlr.TreesStringTree(s, ruleNames, recog)
+}
+
+func (s *StringOpContext) EnterRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(TSLListener); ok {
+		listenerT.EnterStringOp(s)
+	}
+}
+
+func (s *StringOpContext) ExitRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(TSLListener); ok {
+		listenerT.ExitStringOp(s)
+	}
+}
+
+func (p *TSLParser) StringOp() (localctx IStringOpContext) {
+	localctx = NewStringOpContext(p, p.GetParserRuleContext(), p.GetState())
+	p.EnterRule(localctx, 18, TSLParserRULE_stringOp)
+	var _la int
+
+	defer func() {
+		p.ExitRule()
+	}()
+
+	defer func() {
+		if err := recover(); err != nil {
+			if v, ok := err.(antlr.RecognitionException); ok {
+				localctx.SetException(v)
+				p.GetErrorHandler().ReportError(p, v)
+				p.GetErrorHandler().Recover(p, v)
+			} else {
+				panic(err)
+			}
+		}
+	}()
+
+	p.EnterOuterAlt(localctx, 1)
+	{
+		p.SetState(201)
+		_la = p.GetTokenStream().LA(1)
+
+		if !(_la == TSLParserT__10 || _la == TSLParserT__11) {
+			p.GetErrorHandler().RecoverInline(p)
+		} else {
+			p.GetErrorHandler().ReportMatch(p)
+			p.Consume()
+		}
+	}
+
+	return localctx
+}
+
+// ILikeOpContext is an interface to support dynamic dispatch.
+type ILikeOpContext interface {
+	antlr.ParserRuleContext
+
+	// GetParser returns the parser.
+	GetParser() antlr.Parser
+
+	// IsLikeOpContext differentiates from other interfaces.
+	IsLikeOpContext()
+}
+
+type LikeOpContext struct {
+	*antlr.BaseParserRuleContext
+	parser antlr.Parser
+}
+
+func NewEmptyLikeOpContext() *LikeOpContext {
+	var p = new(LikeOpContext)
+	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(nil, -1)
+	p.RuleIndex = TSLParserRULE_likeOp
+	return p
+}
+
+func (*LikeOpContext) IsLikeOpContext() {}
+
+func NewLikeOpContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *LikeOpContext {
+	var p = new(LikeOpContext)
+
+	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(parent, invokingState)
+
+	p.parser = parser
+	p.RuleIndex = TSLParserRULE_likeOp
+
+	return p
+}
+
+func (s *LikeOpContext) GetParser() antlr.Parser { return s.parser }
+
+func (s *LikeOpContext) K_LIKE() antlr.TerminalNode {
+	return s.GetToken(TSLParserK_LIKE, 0)
+}
+
+func (s *LikeOpContext) K_ILIKE() antlr.TerminalNode {
+	return s.GetToken(TSLParserK_ILIKE, 0)
+}
+
+func (s *LikeOpContext) K_CONTAINS() antlr.TerminalNode {
+	return s.GetToken(TSLParserK_CONTAINS, 0)
+}
+
+func (s *LikeOpContext) K_STARTSWITH() antlr.TerminalNode {
+	return s.GetToken(TSLParserK_STARTSWITH, 0)
+}
+
+func (s *LikeOpContext) K_ENDSWITH() antlr.TerminalNode {
+	return s.GetToken(TSLParserK_ENDSWITH, 0)
+}
+
+func (s *LikeOpContext) GetRuleContext() antlr.RuleContext {
+	return s
+}
+
+func (s *LikeOpContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
+	return antlr.TreesStringTree(s, ruleNames, recog)
+}
+
+func (s *LikeOpContext) EnterRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(TSLListener); ok {
+		listenerT.EnterLikeOp(s)
+	}
+}
+
+func (s *LikeOpContext) ExitRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(TSLListener); ok {
+		listenerT.ExitLikeOp(s)
+	}
+}
+
+func (p *TSLParser) LikeOp() (localctx ILikeOpContext) {
+	localctx = NewLikeOpContext(p, p.GetParserRuleContext(), p.GetState())
+	p.EnterRule(localctx, 20, TSLParserRULE_likeOp)
+	var _la int
+
+	defer func() {
+		p.ExitRule()
+	}()
+
+	defer func() {
+		if err := recover(); err != nil {
+			if v, ok := err.(antlr.RecognitionException); ok {
+				localctx.SetException(v)
+				p.GetErrorHandler().ReportError(p, v)
+				p.GetErrorHandler().Recover(p, v)
+			} else {
+				panic(err)
+			}
+		}
+	}()
+
+	p.EnterOuterAlt(localctx, 1)
+	{
+		p.SetState(203)
+		_la = p.GetTokenStream().LA(1)
+
+		if !(((_la)&-(0x1f+1)) == 0 && ((1<<uint(_la))&((1<<TSLParserK_LIKE)|(1<<TSLParserK_ILIKE)|(1<<TSLParserK_CONTAINS)|(1<<TSLParserK_STARTSWITH)|(1<<TSLParserK_ENDSWITH))) != 0) {
+			p.GetErrorHandler().RecoverInline(p)
+		} else {
+			p.GetErrorHandler().ReportMatch(p)
+			p.Consume()
+		}
+	}
+
+	return localctx
+}
+
+// IDatabaseNameContext is an interface to support dynamic dispatch.
+type IDatabaseNameContext interface {
+	antlr.ParserRuleContext
+
+	// GetParser returns the parser.
+	GetParser() antlr.Parser
+
+	// IsDatabaseNameContext differentiates from other interfaces.
+	IsDatabaseNameContext()
+}
+
+type DatabaseNameContext struct {
+	*antlr.BaseParserRuleContext
+	parser antlr.Parser
+}
+
+func NewEmptyDatabaseNameContext() *DatabaseNameContext {
+	var p = new(DatabaseNameContext)
+	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(nil, -1)
+	p.RuleIndex = TSLParserRULE_databaseName
+	return p
+}
+
+func (*DatabaseNameContext) IsDatabaseNameContext() {}
+
+func NewDatabaseNameContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *DatabaseNameContext {
+	var p = new(DatabaseNameContext)
+
+	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(parent, invokingState)
+
+	p.parser = parser
+	p.RuleIndex = TSLParserRULE_databaseName
+
+	return p
+}
+
+func (s *DatabaseNameContext) GetParser() antlr.Parser { return s.parser }
+
+func (s *DatabaseNameContext) Identifier() IIdentifierContext {
+	var t = s.GetTypedRuleContext(reflect.TypeOf((*IIdentifierContext)(nil)).Elem(), 0)
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IIdentifierContext)
+}
+
+func (s *DatabaseNameContext) GetRuleContext() antlr.RuleContext {
+	return s
+}
+
+func (s *DatabaseNameContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
+	return antlr.TreesStringTree(s, ruleNames, recog)
+}
+
+func (s *DatabaseNameContext) EnterRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(TSLListener); ok {
+		listenerT.EnterDatabaseName(s)
+	}
+}
+
+func (s *DatabaseNameContext) ExitRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(TSLListener); ok {
+		listenerT.ExitDatabaseName(s)
+	}
+}
+
+func (p *TSLParser) DatabaseName() (localctx IDatabaseNameContext) {
+	localctx = NewDatabaseNameContext(p, p.GetParserRuleContext(), p.GetState())
+	p.EnterRule(localctx, 22, TSLParserRULE_databaseName)
+
+	defer func() {
+		p.ExitRule()
+	}()
+
+	defer func() {
+		if err := recover(); err != nil {
+			if v, ok := err.(antlr.RecognitionException); ok {
+				localctx.SetException(v)
+				p.GetErrorHandler().ReportError(p, v)
+				p.GetErrorHandler().Recover(p, v)
+			} else {
+				panic(err)
+			}
+		}
+	}()
+
+	p.EnterOuterAlt(localctx, 1)
+	{
+		p.SetState(205)
+		p.Identifier()
+	}
+
+	return localctx
+}
+
+// ITableNameContext is an interface to support dynamic dispatch.
+type ITableNameContext interface {
+	antlr.ParserRuleContext
+
+	// GetParser returns the parser.
+	GetParser() antlr.Parser
+
+	// IsTableNameContext differentiates from other interfaces.
+	IsTableNameContext()
+}
+
+type TableNameContext struct {
+	*antlr.BaseParserRuleContext
+	parser antlr.Parser
+}
+
+func NewEmptyTableNameContext() *TableNameContext {
+	var p = new(TableNameContext)
+	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(nil, -1)
+	p.RuleIndex = TSLParserRULE_tableName
+	return p
+}
+
+func (*TableNameContext) IsTableNameContext() {}
+
+func NewTableNameContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *TableNameContext {
+	var p = new(TableNameContext)
+
+	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(parent, invokingState)
+
+	p.parser = parser
+	p.RuleIndex = TSLParserRULE_tableName
+
+	return p
+}
+
+func (s *TableNameContext) GetParser() antlr.Parser { return s.parser }
+
+func (s *TableNameContext) Identifier() IIdentifierContext {
+	var t = s.GetTypedRuleContext(reflect.TypeOf((*IIdentifierContext)(nil)).Elem(), 0)
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IIdentifierContext)
+}
+
+func (s *TableNameContext) GetRuleContext() antlr.RuleContext {
+	return s
+}
+
+func (s *TableNameContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
+	return antlr.TreesStringTree(s, ruleNames, recog)
+}
+
+func (s *TableNameContext) EnterRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(TSLListener); ok {
+		listenerT.EnterTableName(s)
+	}
+}
+
+func (s *TableNameContext) ExitRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(TSLListener); ok {
+		listenerT.ExitTableName(s)
+	}
+}
+
+func (p *TSLParser) TableName() (localctx ITableNameContext) {
+	localctx = NewTableNameContext(p, p.GetParserRuleContext(), p.GetState())
+	p.EnterRule(localctx, 24, TSLParserRULE_tableName)
+
+	defer func() {
+		p.ExitRule()
+	}()
+
+	defer func() {
+		if err := recover(); err != nil {
+			if v, ok := err.(antlr.RecognitionException); ok {
+				localctx.SetException(v)
+				p.GetErrorHandler().ReportError(p, v)
+				p.GetErrorHandler().Recover(p, v)
+			} else {
+				panic(err)
+			}
+		}
+	}()
+
+	p.EnterOuterAlt(localctx, 1)
+	{
+		p.SetState(207)
+		p.Identifier()
+	}
+
+	return localctx
+}
+
+// IColumnNameContext is an interface to support dynamic dispatch.
+type IColumnNameContext interface {
+	antlr.ParserRuleContext
+
+	// GetParser returns the parser.
+	GetParser() antlr.Parser
+
+	// IsColumnNameContext differentiates from other interfaces.
+	IsColumnNameContext()
+}
+
+type ColumnNameContext struct {
+	*antlr.BaseParserRuleContext
+	parser antlr.Parser
+}
+
+func NewEmptyColumnNameContext() *ColumnNameContext {
+	var p = new(ColumnNameContext)
+	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(nil, -1)
+	p.RuleIndex = TSLParserRULE_columnName
+	return p
+}
+
+func (*ColumnNameContext) IsColumnNameContext() {}
+
+func NewColumnNameContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *ColumnNameContext {
+	var p = new(ColumnNameContext)
+
+	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(parent, invokingState)
+
+	p.parser = parser
+	p.RuleIndex = TSLParserRULE_columnName
+
+	return p
+}
+
+func (s *ColumnNameContext) GetParser() antlr.Parser { return s.parser }
+
+func (s *ColumnNameContext) AllIdentifier() []IIdentifierContext {
+	var ts = s.GetTypedRuleContexts(reflect.TypeOf((*IIdentifierContext)(nil)).Elem())
+	var tst = make([]IIdentifierContext, len(ts))
+
+	for i, t := range ts {
+		if t != nil {
+			tst[i] = t.(IIdentifierContext)
+		}
+	}
+
+	return tst
+}
+
+func (s *ColumnNameContext) Identifier(i int) IIdentifierContext {
+	var t = s.GetTypedRuleContext(reflect.TypeOf((*IIdentifierContext)(nil)).Elem(), i)
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IIdentifierContext)
+}
+
+func (s *ColumnNameContext) TableName() ITableNameContext {
+	var t = s.GetTypedRuleContext(reflect.TypeOf((*ITableNameContext)(nil)).Elem(), 0)
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(ITableNameContext)
+}
+
+func (s *ColumnNameContext) DatabaseName() IDatabaseNameContext {
+	var t = s.GetTypedRuleContext(reflect.TypeOf((*IDatabaseNameContext)(nil)).Elem(), 0)
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IDatabaseNameContext)
+}
+
+func (s *ColumnNameContext) GetRuleContext() antlr.RuleContext {
+	return s
+}
+
+func (s *ColumnNameContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
+	return antlr.TreesStringTree(s, ruleNames, recog)
+}
+
+func (s *ColumnNameContext) EnterRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(TSLListener); ok {
+		listenerT.EnterColumnName(s)
+	}
+}
+
+func (s *ColumnNameContext) ExitRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(TSLListener); ok {
+		listenerT.ExitColumnName(s)
+	}
+}
+
+func (p *TSLParser) ColumnName() (localctx IColumnNameContext) {
+	localctx = NewColumnNameContext(p, p.GetParserRuleContext(), p.GetState())
+	p.EnterRule(localctx, 26, TSLParserRULE_columnName)
+
+	defer func() {
+		p.ExitRule()
+	}()
+
+	defer func() {
+		if err := recover(); err != nil {
+			if v, ok := err.(antlr.RecognitionException); ok {
+				localctx.SetException(v)
+				p.GetErrorHandler().ReportError(p, v)
+				p.GetErrorHandler().Recover(p, v)
+			} else {
+				panic(err)
+			}
+		}
+	}()
+
+	var _alt int
+
+	p.SetState(241)
+	p.GetErrorHandler().Sync(p)
+	switch p.GetInterpreter().AdaptivePredict(p.GetTokenStream(), 26, p.GetParserRuleContext()) {
+	case 1:
+		p.EnterOuterAlt(localctx, 1)
+		p.SetState(217)
+		p.GetErrorHandler().Sync(p)
+
+		if p.GetInterpreter().AdaptivePredict(p.GetTokenStream(), 22, p.GetParserRuleContext()) == 1 {
+			p.SetState(212)
+			p.GetErrorHandler().Sync(p)
+
+			if p.GetInterpreter().AdaptivePredict(p.GetTokenStream(), 21, p.GetParserRuleContext()) == 1 {
+				{
+					p.SetState(209)
+					p.DatabaseName()
+				}
+				{
+					p.SetState(210)
+					p.Match(TSLParserT__12)
+				}
+
+			}
+			{
+				p.SetState(214)
+				p.TableName()
+			}
+			{
+				p.SetState(215)
+				p.Match(TSLParserT__12)
+			}
+
+		}
+		{
+			p.SetState(219)
+			p.Identifier()
+		}
+
+	case 2:
+		p.EnterOuterAlt(localctx, 2)
+		{
+			p.SetState(220)
+			p.Identifier()
+		}
+		p.SetState(225)
+		p.GetErrorHandler().Sync(p)
+		_alt = p.GetInterpreter().AdaptivePredict(p.GetTokenStream(), 23, p.GetParserRuleContext())
+
+		for _alt != 2 && _alt != antlr.ATNInvalidAltNumber {
+			if _alt == 1 {
+				{
+					p.SetState(221)
+					p.Match(TSLParserT__12)
+				}
+				{
+					p.SetState(222)
+					p.Identifier()
+				}
+
+			}
+			p.SetState(227)
+			p.GetErrorHandler().Sync(p)
+			_alt = p.GetInterpreter().AdaptivePredict(p.GetTokenStream(), 23, p.GetParserRuleContext())
+		}
+		p.SetState(237)
+		p.GetErrorHandler().Sync(p)
+		_alt = 1
+		for ok := true; ok; ok = _alt != 2 && _alt != antlr.ATNInvalidAltNumber {
+			switch _alt {
+			case 1:
+				{
+					p.SetState(228)
+					p.Match(TSLParserT__12)
+				}
+				{
+					p.SetState(229)
+					p.Match(TSLParserT__13)
+				}
+				p.SetState(234)
+				p.GetErrorHandler().Sync(p)
+				_alt = p.GetInterpreter().AdaptivePredict(p.GetTokenStream(), 24, p.GetParserRuleContext())
+
+				for _alt != 2 && _alt != antlr.ATNInvalidAltNumber {
+					if _alt == 1 {
+						{
+							p.SetState(230)
+							p.Match(TSLParserT__12)
+						}
+						{
+							p.SetState(231)
+							p.Identifier()
+						}
+
+					}
+					p.SetState(236)
+					p.GetErrorHandler().Sync(p)
+					_alt = p.GetInterpreter().AdaptivePredict(p.GetTokenStream(), 24, p.GetParserRuleContext())
+				}
+
+			default:
+				panic(antlr.NewNoViableAltException(p, nil, nil, nil, nil, nil))
+			}
+
+			p.SetState(239)
+			p.GetErrorHandler().Sync(p)
+			_alt = p.GetInterpreter().AdaptivePredict(p.GetTokenStream(), 25, p.GetParserRuleContext())
+		}
+
+	}
+
+	return localctx
+}
+
+// IIdentifierContext is an interface to support dynamic dispatch.
+type IIdentifierContext interface {
+	antlr.ParserRuleContext
+
+	// GetParser returns the parser.
+	GetParser() antlr.Parser
+
+	// IsIdentifierContext differentiates from other interfaces.
+	IsIdentifierContext()
+}
+
+type IdentifierContext struct {
+	*antlr.BaseParserRuleContext
+	parser antlr.Parser
+}
+
+func NewEmptyIdentifierContext() *IdentifierContext {
+	var p = new(IdentifierContext)
+	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(nil, -1)
+	p.RuleIndex = TSLParserRULE_identifier
+	return p
+}
+
+func (*IdentifierContext) IsIdentifierContext() {}
+
+func NewIdentifierContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *IdentifierContext {
+	var p = new(IdentifierContext)
+
+	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(parent, invokingState)
+
+	p.parser = parser
+	p.RuleIndex = TSLParserRULE_identifier
+
+	return p
+}
+
+func (s *IdentifierContext) GetParser() antlr.Parser { return s.parser }
+
+func (s *IdentifierContext) IDENTIFIER() antlr.TerminalNode {
+	return s.GetToken(TSLParserIDENTIFIER, 0)
+}
+
+func (s *IdentifierContext) K_ILIKE() antlr.TerminalNode {
+	return s.GetToken(TSLParserK_ILIKE, 0)
+}
+
+func (s *IdentifierContext) K_CONTAINS() antlr.TerminalNode {
+	return s.GetToken(TSLParserK_CONTAINS, 0)
+}
+
+func (s *IdentifierContext) K_STARTSWITH() antlr.TerminalNode {
+	return s.GetToken(TSLParserK_STARTSWITH, 0)
+}
+
+func (s *IdentifierContext) K_ENDSWITH() antlr.TerminalNode {
+	return s.GetToken(TSLParserK_ENDSWITH, 0)
+}
+
+func (s *IdentifierContext) K_TRUE() antlr.TerminalNode {
+	return s.GetToken(TSLParserK_TRUE, 0)
+}
+
+func (s *IdentifierContext) K_FALSE() antlr.TerminalNode {
+	return s.GetToken(TSLParserK_FALSE, 0)
+}
+
+func (s *IdentifierContext) K_NOW() antlr.TerminalNode {
+	return s.GetToken(TSLParserK_NOW, 0)
+}
+
+func (s *IdentifierContext) K_DATE() antlr.TerminalNode {
+	return s.GetToken(TSLParserK_DATE, 0)
+}
+
+func (s *IdentifierContext) K_WITHIN() antlr.TerminalNode {
+	return s.GetToken(TSLParserK_WITHIN, 0)
+}
+
+func (s *IdentifierContext) K_OF() antlr.TerminalNode {
+	return s.GetToken(TSLParserK_OF, 0)
+}
+
+func (s *IdentifierContext) K_ORDER() antlr.TerminalNode {
+	return s.GetToken(TSLParserK_ORDER, 0)
+}
+
+func (s *IdentifierContext) K_BY() antlr.TerminalNode {
+	return s.GetToken(TSLParserK_BY, 0)
+}
+
+func (s *IdentifierContext) K_ASC() antlr.TerminalNode {
+	return s.GetToken(TSLParserK_ASC, 0)
+}
+
+func (s *IdentifierContext) K_DESC() antlr.TerminalNode {
+	return s.GetToken(TSLParserK_DESC, 0)
+}
+
+func (s *IdentifierContext) K_LIMIT() antlr.TerminalNode {
+	return s.GetToken(TSLParserK_LIMIT, 0)
+}
+
+func (s *IdentifierContext) K_OFFSET() antlr.TerminalNode {
+	return s.GetToken(TSLParserK_OFFSET, 0)
+}
+
+func (s *IdentifierContext) K_FIELDS() antlr.TerminalNode {
+	return s.GetToken(TSLParserK_FIELDS, 0)
+}
+
+func (s *IdentifierContext) K_WHERE() antlr.TerminalNode {
+	return s.GetToken(TSLParserK_WHERE, 0)
+}
+
+func (s *IdentifierContext) GetRuleContext() antlr.RuleContext {
+	return s
+}
+
+func (s *IdentifierContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
+	return antlr.TreesStringTree(s, ruleNames, recog)
+}
+
+func (s *IdentifierContext) EnterRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(TSLListener); ok {
+		listenerT.EnterIdentifier(s)
+	}
+}
+
+func (s *IdentifierContext) ExitRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(TSLListener); ok {
+		listenerT.ExitIdentifier(s)
+	}
+}
+
+func (p *TSLParser) Identifier() (localctx IIdentifierContext) {
+	localctx = NewIdentifierContext(p, p.GetParserRuleContext(), p.GetState())
+	p.EnterRule(localctx, 28, TSLParserRULE_identifier)
+	var _la int
+
+	defer func() {
+		p.ExitRule()
+	}()
+
+	defer func() {
+		if err := recover(); err != nil {
+			if v, ok := err.(antlr.RecognitionException); ok {
+				localctx.SetException(v)
+				p.GetErrorHandler().ReportError(p, v)
+				p.GetErrorHandler().Recover(p, v)
+			} else {
+				panic(err)
+			}
+		}
+	}()
+
+	p.EnterOuterAlt(localctx, 1)
+	{
+		p.SetState(243)
+		_la = p.GetTokenStream().LA(1)
+
+		if !(((_la-20)&-(0x1f+1)) == 0 && ((1<<uint((_la-20)))&((1<<(TSLParserK_ILIKE-20))|(1<<(TSLParserK_CONTAINS-20))|(1<<(TSLParserK_STARTSWITH-20))|(1<<(TSLParserK_ENDSWITH-20))|(1<<(TSLParserK_TRUE-20))|(1<<(TSLParserK_FALSE-20))|(1<<(TSLParserK_NOW-20))|(1<<(TSLParserK_DATE-20))|(1<<(TSLParserK_WITHIN-20))|(1<<(TSLParserK_OF-20))|(1<<(TSLParserK_ORDER-20))|(1<<(TSLParserK_BY-20))|(1<<(TSLParserK_ASC-20))|(1<<(TSLParserK_DESC-20))|(1<<(TSLParserK_LIMIT-20))|(1<<(TSLParserK_OFFSET-20))|(1<<(TSLParserK_FIELDS-20))|(1<<(TSLParserK_WHERE-20))|(1<<(TSLParserIDENTIFIER-20)))) != 0) {
+			p.GetErrorHandler().RecoverInline(p)
+		} else {
+			p.GetErrorHandler().ReportMatch(p)
+			p.Consume()
+		}
+	}
+
+	return localctx
+}
+
+// ILiteralValueContext is an interface to support dynamic dispatch.
+type ILiteralValueContext interface {
+	antlr.ParserRuleContext
+
+	// GetParser returns the parser.
+	GetParser() antlr.Parser
+
+	// IsLiteralValueContext differentiates from other interfaces.
+	IsLiteralValueContext()
+}
+
+type LiteralValueContext struct {
+	*antlr.BaseParserRuleContext
+	parser antlr.Parser
+}
+
+func NewEmptyLiteralValueContext() *LiteralValueContext {
+	var p = new(LiteralValueContext)
+	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(nil, -1)
+	p.RuleIndex = TSLParserRULE_literalValue
+	return p
+}
+
+func (*LiteralValueContext) IsLiteralValueContext() {}
+
+func NewLiteralValueContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *LiteralValueContext {
+	var p = new(LiteralValueContext)
+
+	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(parent, invokingState)
+
+	p.parser = parser
+	p.RuleIndex = TSLParserRULE_literalValue
+
+	return p
+}
+
+func (s *LiteralValueContext) GetParser() antlr.Parser { return s.parser }
+
+func (s *LiteralValueContext) CopyFrom(ctx *LiteralValueContext) {
+	s.BaseParserRuleContext.CopyFrom(ctx.BaseParserRuleContext)
+}
+
+func (s *LiteralValueContext) GetRuleContext() antlr.RuleContext {
+	return s
+}
+
+func (s *LiteralValueContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
+	return antlr.TreesStringTree(s, ruleNames, recog)
+}
+
+type StringLiteralContext struct {
+	*LiteralValueContext
+}
+
+func NewStringLiteralContext(parser antlr.Parser, ctx antlr.ParserRuleContext) *StringLiteralContext {
+	var p = new(StringLiteralContext)
+
+	p.LiteralValueContext = NewEmptyLiteralValueContext()
+	p.parser = parser
+	p.CopyFrom(ctx.(*LiteralValueContext))
+
+	return p
+}
+
+func (s *StringLiteralContext) GetRuleContext() antlr.RuleContext {
+	return s
+}
+
+func (s *StringLiteralContext) StringValue() IStringValueContext {
+	var t = s.GetTypedRuleContext(reflect.TypeOf((*IStringValueContext)(nil)).Elem(), 0)
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IStringValueContext)
+}
+
+func (s *StringLiteralContext) EnterRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(TSLListener); ok {
+		listenerT.EnterStringLiteral(s)
+	}
+}
+
+func (s *StringLiteralContext) ExitRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(TSLListener); ok {
+		listenerT.ExitStringLiteral(s)
+	}
+}
+
+type DateLiteralContext struct {
+	*LiteralValueContext
+}
+
+func NewDateLiteralContext(parser antlr.Parser, ctx antlr.ParserRuleContext) *DateLiteralContext {
+	var p = new(DateLiteralContext)
+
+	p.LiteralValueContext = NewEmptyLiteralValueContext()
+	p.parser = parser
+	p.CopyFrom(ctx.(*LiteralValueContext))
+
+	return p
+}
+
+func (s *DateLiteralContext) GetRuleContext() antlr.RuleContext {
+	return s
+}
+
+func (s *DateLiteralContext) DateValue() IDateValueContext {
+	var t = s.GetTypedRuleContext(reflect.TypeOf((*IDateValueContext)(nil)).Elem(), 0)
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IDateValueContext)
+}
+
+func (s *DateLiteralContext) EnterRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(TSLListener); ok {
+		listenerT.EnterDateLiteral(s)
+	}
+}
+
+func (s *DateLiteralContext) ExitRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(TSLListener); ok {
+		listenerT.ExitDateLiteral(s)
+	}
+}
+
+type BooleanLiteralContext struct {
+	*LiteralValueContext
+}
+
+func NewBooleanLiteralContext(parser antlr.Parser, ctx antlr.ParserRuleContext) *BooleanLiteralContext {
+	var p = new(BooleanLiteralContext)
+
+	p.LiteralValueContext = NewEmptyLiteralValueContext()
+	p.parser = parser
+	p.CopyFrom(ctx.(*LiteralValueContext))
+
+	return p
+}
+
+func (s *BooleanLiteralContext) GetRuleContext() antlr.RuleContext {
+	return s
+}
+
+func (s *BooleanLiteralContext) BooleanValue() IBooleanValueContext {
+	var t = s.GetTypedRuleContext(reflect.TypeOf((*IBooleanValueContext)(nil)).Elem(), 0)
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IBooleanValueContext)
+}
+
+func (s *BooleanLiteralContext) EnterRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(TSLListener); ok {
+		listenerT.EnterBooleanLiteral(s)
+	}
+}
+
+func (s *BooleanLiteralContext) ExitRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(TSLListener); ok {
+		listenerT.ExitBooleanLiteral(s)
+	}
+}
+
+type NumberLiteralContext struct {
+	*LiteralValueContext
+}
+
+func NewNumberLiteralContext(parser antlr.Parser, ctx antlr.ParserRuleContext) *NumberLiteralContext {
+	var p = new(NumberLiteralContext)
+
+	p.LiteralValueContext = NewEmptyLiteralValueContext()
+	p.parser = parser
+	p.CopyFrom(ctx.(*LiteralValueContext))
+
+	return p
+}
+
+func (s *NumberLiteralContext) GetRuleContext() antlr.RuleContext {
+	return s
+}
+
+func (s *NumberLiteralContext) SignedNumber() ISignedNumberContext {
+	var t = s.GetTypedRuleContext(reflect.TypeOf((*ISignedNumberContext)(nil)).Elem(), 0)
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(ISignedNumberContext)
+}
+
+func (s *NumberLiteralContext) EnterRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(TSLListener); ok {
+		listenerT.EnterNumberLiteral(s)
+	}
+}
+
+func (s *NumberLiteralContext) ExitRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(TSLListener); ok {
+		listenerT.ExitNumberLiteral(s)
+	}
+}
+
+type DurationLiteralContext struct {
+	*LiteralValueContext
+}
+
+func NewDurationLiteralContext(parser antlr.Parser, ctx antlr.ParserRuleContext) *DurationLiteralContext {
+	var p = new(DurationLiteralContext)
+
+	p.LiteralValueContext = NewEmptyLiteralValueContext()
+	p.parser = parser
+	p.CopyFrom(ctx.(*LiteralValueContext))
+
+	return p
+}
+
+func (s *DurationLiteralContext) GetRuleContext() antlr.RuleContext {
+	return s
+}
+
+func (s *DurationLiteralContext) DurationValue() IDurationValueContext {
+	var t = s.GetTypedRuleContext(reflect.TypeOf((*IDurationValueContext)(nil)).Elem(), 0)
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IDurationValueContext)
+}
+
+func (s *DurationLiteralContext) EnterRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(TSLListener); ok {
+		listenerT.EnterDurationLiteral(s)
+	}
+}
+
+func (s *DurationLiteralContext) ExitRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(TSLListener); ok {
+		listenerT.ExitDurationLiteral(s)
+	}
+}
+
+func (p *TSLParser) LiteralValue() (localctx ILiteralValueContext) {
+	localctx = NewLiteralValueContext(p, p.GetParserRuleContext(), p.GetState())
+	p.EnterRule(localctx, 30, TSLParserRULE_literalValue)
+
+	defer func() {
+		p.ExitRule()
+	}()
+
+	defer func() {
+		if err := recover(); err != nil {
+			if v, ok := err.(antlr.RecognitionException); ok {
+				localctx.SetException(v)
+				p.GetErrorHandler().ReportError(p, v)
+				p.GetErrorHandler().Recover(p, v)
+			} else {
+				panic(err)
+			}
 		}
+	}()
 
-	case 8:
-		localctx = NewNotContext(p, localctx)
-		p.SetParserRuleContext(localctx)
-		_prevctx = localctx
+	p.SetState(250)
+	p.GetErrorHandler().Sync(p)
+	switch p.GetInterpreter().AdaptivePredict(p.GetTokenStream(), 27, p.GetParserRuleContext()) {
+	case 1:
+		localctx = NewNumberLiteralContext(p, localctx)
+		p.EnterOuterAlt(localctx, 1)
 		{
-			p.SetState(84)
-			p.Match(TSLParserK_NOT)
+			p.SetState(245)
+			p.SignedNumber()
 		}
+
+	case 2:
+		localctx = NewStringLiteralContext(p, localctx)
+		p.EnterOuterAlt(localctx, 2)
 		{
-			p.SetState(85)
-			p.expr(4)
+			p.SetState(246)
+			p.StringValue()
 		}
 
-	case 9:
-		localctx = NewParContext(p, localctx)
-		p.SetParserRuleContext(localctx)
-		_prevctx = localctx
+	case 3:
+		localctx = NewBooleanLiteralContext(p, localctx)
+		p.EnterOuterAlt(localctx, 3)
 		{
-			p.SetState(86)
-			p.Match(TSLParserT__0)
+			p.SetState(247)
+			p.BooleanValue()
 		}
+
+	case 4:
+		localctx = NewDateLiteralContext(p, localctx)
+		p.EnterOuterAlt(localctx, 4)
 		{
-			p.SetState(87)
-			p.expr(0)
+			p.SetState(248)
+			p.DateValue()
 		}
+
+	case 5:
+		localctx = NewDurationLiteralContext(p, localctx)
+		p.EnterOuterAlt(localctx, 5)
 		{
-			p.SetState(88)
-			p.Match(TSLParserT__2)
+			p.SetState(249)
+			p.DurationValue()
 		}
 
 	}
-	p.GetParserRuleContext().SetStop(p.GetTokenStream().LT(-1))
-	p.SetState(100)
-	p.GetErrorHandler().Sync(p)
-	_alt = p.GetInterpreter().AdaptivePredict(p.GetTokenStream(), 9, p.GetParserRuleContext())
 
-	for _alt != 2 && _alt != antlr.ATNInvalidAltNumber {
-		if _alt == 1 {
-			if p.GetParseListeners() != nil {
-				p.TriggerExitRuleEvent()
-			}
-			_prevctx = localctx
-			p.SetState(98)
-			p.GetErrorHandler().Sync(p)
-			switch p.GetInterpreter().AdaptivePredict(p.GetTokenStream(), 8, p.GetParserRuleContext()) {
-			case 1:
-				localctx = NewAndContext(p, NewExprContext(p, _parentctx, _parentState))
-				p.PushNewRecursionContext(localctx, _startState, TSLParserRULE_expr)
-				p.SetState(92)
+	return localctx
+}
 
-				if !(p.Precpred(p.GetParserRuleContext(), 3)) {
-					panic(antlr.NewFailedPredicateException(p, "p.Precpred(p.GetParserRuleContext(), 3)", ""))
-				}
-				{
-					p.SetState(93)
-					p.Match(TSLParserK_AND)
-				}
-				{
-					p.SetState(94)
-					p.expr(4)
-				}
+// IMathExpContext is an interface to support dynamic dispatch.
+type IMathExpContext interface {
+	antlr.ParserRuleContext
 
-			case 2:
-				localctx = NewOrContext(p, NewExprContext(p, _parentctx, _parentState))
-				p.PushNewRecursionContext(localctx, _startState, TSLParserRULE_expr)
-				p.SetState(95)
+	// GetParser returns the parser.
+	GetParser() antlr.Parser
 
-				if !(p.Precpred(p.GetParserRuleContext(), 2)) {
-					panic(antlr.NewFailedPredicateException(p, "p.Precpred(p.GetParserRuleContext(), 2)", ""))
-				}
-				{
-					p.SetState(96)
-					p.Match(TSLParserK_OR)
-				}
-				{
-					p.SetState(97)
-					p.expr(3)
-				}
+	// IsMathExpContext differentiates from other interfaces.
+	IsMathExpContext()
+}
 
-			}
+type MathExpContext struct {
+	*antlr.BaseParserRuleContext
+	parser antlr.Parser
+}
+
+func NewEmptyMathExpContext() *MathExpContext {
+	var p = new(MathExpContext)
+	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(nil, -1)
+	p.RuleIndex = TSLParserRULE_mathExp
+	return p
+}
+
+func (*MathExpContext) IsMathExpContext() {}
+
+func NewMathExpContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *MathExpContext {
+	var p = new(MathExpContext)
+
+	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(parent, invokingState)
+
+	p.parser = parser
+	p.RuleIndex = TSLParserRULE_mathExp
+
+	return p
+}
+
+func (s *MathExpContext) GetParser() antlr.Parser { return s.parser }
+
+func (s *MathExpContext) CopyFrom(ctx *MathExpContext) {
+	s.BaseParserRuleContext.CopyFrom(ctx.BaseParserRuleContext)
+}
+
+func (s *MathExpContext) GetRuleContext() antlr.RuleContext {
+	return s
+}
+
+func (s *MathExpContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
+	return antlr.TreesStringTree(s, ruleNames, recog)
+}
+
+type MathParContext struct {
+	*MathExpContext
+}
+
+func NewMathParContext(parser antlr.Parser, ctx antlr.ParserRuleContext) *MathParContext {
+	var p = new(MathParContext)
+
+	p.MathExpContext = NewEmptyMathExpContext()
+	p.parser = parser
+	p.CopyFrom(ctx.(*MathExpContext))
+
+	return p
+}
+
+func (s *MathParContext) GetRuleContext() antlr.RuleContext {
+	return s
+}
+
+func (s *MathParContext) MathExp() IMathExpContext {
+	var t = s.GetTypedRuleContext(reflect.TypeOf((*IMathExpContext)(nil)).Elem(), 0)
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IMathExpContext)
+}
+
+func (s *MathParContext) EnterRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(TSLListener); ok {
+		listenerT.EnterMathPar(s)
+	}
+}
+
+func (s *MathParContext) ExitRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(TSLListener); ok {
+		listenerT.ExitMathPar(s)
+	}
+}
+
+type ModOpsContext struct {
+	*MathExpContext
+}
+
+func NewModOpsContext(parser antlr.Parser, ctx antlr.ParserRuleContext) *ModOpsContext {
+	var p = new(ModOpsContext)
+
+	p.MathExpContext = NewEmptyMathExpContext()
+	p.parser = parser
+	p.CopyFrom(ctx.(*MathExpContext))
+
+	return p
+}
+
+func (s *ModOpsContext) GetRuleContext() antlr.RuleContext {
+	return s
+}
+
+func (s *ModOpsContext) AllMathExp() []IMathExpContext {
+	var ts = s.GetTypedRuleContexts(reflect.TypeOf((*IMathExpContext)(nil)).Elem())
+	var tst = make([]IMathExpContext, len(ts))
 
+	for i, t := range ts {
+		if t != nil {
+			tst[i] = t.(IMathExpContext)
+		}
+	}
+
+	return tst
+}
+
+func (s *ModOpsContext) MathExp(i int) IMathExpContext {
+	var t = s.GetTypedRuleContext(reflect.TypeOf((*IMathExpContext)(nil)).Elem(), i)
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IMathExpContext)
+}
+
+func (s *ModOpsContext) LiteralValue() ILiteralValueContext {
+	var t = s.GetTypedRuleContext(reflect.TypeOf((*ILiteralValueContext)(nil)).Elem(), 0)
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(ILiteralValueContext)
+}
+
+func (s *ModOpsContext) EnterRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(TSLListener); ok {
+		listenerT.EnterModOps(s)
+	}
+}
+
+func (s *ModOpsContext) ExitRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(TSLListener); ok {
+		listenerT.ExitModOps(s)
+	}
+}
+
+type SubOpsContext struct {
+	*MathExpContext
+}
+
+func NewSubOpsContext(parser antlr.Parser, ctx antlr.ParserRuleContext) *SubOpsContext {
+	var p = new(SubOpsContext)
+
+	p.MathExpContext = NewEmptyMathExpContext()
+	p.parser = parser
+	p.CopyFrom(ctx.(*MathExpContext))
+
+	return p
+}
+
+func (s *SubOpsContext) GetRuleContext() antlr.RuleContext {
+	return s
+}
+
+func (s *SubOpsContext) AllMathExp() []IMathExpContext {
+	var ts = s.GetTypedRuleContexts(reflect.TypeOf((*IMathExpContext)(nil)).Elem())
+	var tst = make([]IMathExpContext, len(ts))
+
+	for i, t := range ts {
+		if t != nil {
+			tst[i] = t.(IMathExpContext)
 		}
-		p.SetState(102)
-		p.GetErrorHandler().Sync(p)
-		_alt = p.GetInterpreter().AdaptivePredict(p.GetTokenStream(), 9, p.GetParserRuleContext())
 	}
 
-	return localctx
+	return tst
 }
 
-// ILiteralOpContext is an interface to support dynamic dispatch.
-type ILiteralOpContext interface {
-	antlr.ParserRuleContext
+func (s *SubOpsContext) MathExp(i int) IMathExpContext {
+	var t = s.GetTypedRuleContext(reflect.TypeOf((*IMathExpContext)(nil)).Elem(), i)
 
-	// GetParser returns the parser.
-	GetParser() antlr.Parser
+	if t == nil {
+		return nil
+	}
 
-	// IsLiteralOpContext differentiates from other interfaces.
-	IsLiteralOpContext()
+	return t.(IMathExpContext)
 }
 
-type LiteralOpContext struct {
-	*antlr.BaseParserRuleContext
-	parser antlr.Parser
+func (s *SubOpsContext) LiteralValue() ILiteralValueContext {
+	var t = s.GetTypedRuleContext(reflect.TypeOf((*ILiteralValueContext)(nil)).Elem(), 0)
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(ILiteralValueContext)
 }
 
-func NewEmptyLiteralOpContext() *LiteralOpContext {
-	var p = new(LiteralOpContext)
-	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(nil, -1)
-	p.RuleIndex = TSLParserRULE_literalOp
-	return p
+func (s *SubOpsContext) EnterRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(TSLListener); ok {
+		listenerT.EnterSubOps(s)
+	}
 }
 
-func (*LiteralOpContext) IsLiteralOpContext() {}
+func (s *SubOpsContext) ExitRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(TSLListener); ok {
+		listenerT.ExitSubOps(s)
+	}
+}
 
-func NewLiteralOpContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *LiteralOpContext {
-	var p = new(LiteralOpContext)
+type MulOpsContext struct {
+	*MathExpContext
+}
 
-	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(parent, invokingState)
+func NewMulOpsContext(parser antlr.Parser, ctx antlr.ParserRuleContext) *MulOpsContext {
+	var p = new(MulOpsContext)
 
+	p.MathExpContext = NewEmptyMathExpContext()
 	p.parser = parser
-	p.RuleIndex = TSLParserRULE_literalOp
+	p.CopyFrom(ctx.(*MathExpContext))
 
 	return p
 }
 
-func (s *LiteralOpContext) GetParser() antlr.Parser { return s.parser }
-func (s *LiteralOpContext) GetRuleContext() antlr.RuleContext {
+func (s *MulOpsContext) GetRuleContext() antlr.RuleContext {
 	return s
 }
 
-func (s *LiteralOpContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
-	return antlr.TreesStringTree(s, ruleNames, recog)
+func (s *MulOpsContext) AllMathExp() []IMathExpContext {
+	var ts = s.GetTypedRuleContexts(reflect.TypeOf((*IMathExpContext)(nil)).Elem())
+	var tst = make([]IMathExpContext, len(ts))
+
+	for i, t := range ts {
+		if t != nil {
+			tst[i] = t.(IMathExpContext)
+		}
+	}
+
+	return tst
 }
 
-func (s *LiteralOpContext) EnterRule(listener antlr.ParseTreeListener) {
+func (s *MulOpsContext) MathExp(i int) IMathExpContext {
+	var t = s.GetTypedRuleContext(reflect.TypeOf((*IMathExpContext)(nil)).Elem(), i)
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IMathExpContext)
+}
+
+func (s *MulOpsContext) LiteralValue() ILiteralValueContext {
+	var t = s.GetTypedRuleContext(reflect.TypeOf((*ILiteralValueContext)(nil)).Elem(), 0)
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(ILiteralValueContext)
+}
+
+func (s *MulOpsContext) EnterRule(listener antlr.ParseTreeListener) {
 	if listenerT, ok := listener.(TSLListener); ok {
-		listenerT.EnterLiteralOp(s)
+		listenerT.EnterMulOps(s)
 	}
 }
 
-func (s *LiteralOpContext) ExitRule(listener antlr.ParseTreeListener) {
+func (s *MulOpsContext) ExitRule(listener antlr.ParseTreeListener) {
 	if listenerT, ok := listener.(TSLListener); ok {
-		listenerT.ExitLiteralOp(s)
+		listenerT.ExitMulOps(s)
 	}
 }
 
-func (p *TSLParser) LiteralOp() (localctx ILiteralOpContext) {
-	localctx = NewLiteralOpContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 4, TSLParserRULE_literalOp)
-	var _la int
+type DivOpsContext struct {
+	*MathExpContext
+}
 
-	defer func() {
-		p.ExitRule()
-	}()
+func NewDivOpsContext(parser antlr.Parser, ctx antlr.ParserRuleContext) *DivOpsContext {
+	var p = new(DivOpsContext)
 
-	defer func() {
-		if err := recover(); err != nil {
-			if v, ok := err.(antlr.RecognitionException); ok {
-				localctx.SetException(v)
-				p.GetErrorHandler().ReportError(p, v)
-				p.GetErrorHandler().Recover(p, v)
-			} else {
-				panic(err)
-			}
-		}
-	}()
+	p.MathExpContext = NewEmptyMathExpContext()
+	p.parser = parser
+	p.CopyFrom(ctx.(*MathExpContext))
 
-	p.SetState(105)
-	p.GetErrorHandler().Sync(p)
+	return p
+}
 
-	switch p.GetTokenStream().LA(1) {
-	case TSLParserT__3, TSLParserT__4, TSLParserT__5, TSLParserT__6:
-		p.EnterOuterAlt(localctx, 1)
-		{
-			p.SetState(103)
-			_la = p.GetTokenStream().LA(1)
+func (s *DivOpsContext) GetRuleContext() antlr.RuleContext {
+	return s
+}
 
-			if !(((_la)&-(0x1f+1)) == 0 && ((1<<uint(_la))&((1<<TSLParserT__3)|(1<<TSLParserT__4)|(1<<TSLParserT__5)|(1<<TSLParserT__6))) != 0) {
-				p.GetErrorHandler().RecoverInline(p)
-			} else {
-				p.GetErrorHandler().ReportMatch(p)
-				p.Consume()
-			}
+func (s *DivOpsContext) AllMathExp() []IMathExpContext {
+	var ts = s.GetTypedRuleContexts(reflect.TypeOf((*IMathExpContext)(nil)).Elem())
+	var tst = make([]IMathExpContext, len(ts))
+
+	for i, t := range ts {
+		if t != nil {
+			tst[i] = t.(IMathExpContext)
 		}
+	}
 
-	case TSLParserT__7, TSLParserT__8, TSLParserT__9:
-		p.EnterOuterAlt(localctx, 2)
-		{
-			p.SetState(104)
-			_la = p.GetTokenStream().LA(1)
+	return tst
+}
 
-			if !(((_la)&-(0x1f+1)) == 0 && ((1<<uint(_la))&((1<<TSLParserT__7)|(1<<TSLParserT__8)|(1<<TSLParserT__9))) != 0) {
-				p.GetErrorHandler().RecoverInline(p)
-			} else {
-				p.GetErrorHandler().ReportMatch(p)
-				p.Consume()
-			}
-		}
+func (s *DivOpsContext) MathExp(i int) IMathExpContext {
+	var t = s.GetTypedRuleContext(reflect.TypeOf((*IMathExpContext)(nil)).Elem(), i)
 
-	default:
-		panic(antlr.NewNoViableAltException(p, nil, nil, nil, nil, nil))
+	if t == nil {
+		return nil
 	}
 
-	return localctx
+	return t.(IMathExpContext)
 }
 
-// IStringOpContext is an interface to support dynamic dispatch.
-type IStringOpContext interface {
-	antlr.ParserRuleContext
+func (s *DivOpsContext) LiteralValue() ILiteralValueContext {
+	var t = s.GetTypedRuleContext(reflect.TypeOf((*ILiteralValueContext)(nil)).Elem(), 0)
 
-	// GetParser returns the parser.
-	GetParser() antlr.Parser
+	if t == nil {
+		return nil
+	}
 
-	// IsStringOpContext differentiates from other interfaces.
-	IsStringOpContext()
+	return t.(ILiteralValueContext)
 }
 
-type StringOpContext struct {
-	*antlr.BaseParserRuleContext
-	parser antlr.Parser
+func (s *DivOpsContext) EnterRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(TSLListener); ok {
+		listenerT.EnterDivOps(s)
+	}
 }
 
-func NewEmptyStringOpContext() *StringOpContext {
-	var p = new(StringOpContext)
-	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(nil, -1)
-	p.RuleIndex = TSLParserRULE_stringOp
-	return p
+func (s *DivOpsContext) ExitRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(TSLListener); ok {
+		listenerT.ExitDivOps(s)
+	}
 }
 
-func (*StringOpContext) IsStringOpContext() {}
-
-func NewStringOpContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *StringOpContext {
-	var p = new(StringOpContext)
+type ColumnIdentifierContext struct {
+	*MathExpContext
+}
 
-	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(parent, invokingState)
+func NewColumnIdentifierContext(parser antlr.Parser, ctx antlr.ParserRuleContext) *ColumnIdentifierContext {
+	var p = new(ColumnIdentifierContext)
 
+	p.MathExpContext = NewEmptyMathExpContext()
 	p.parser = parser
-	p.RuleIndex = TSLParserRULE_stringOp
+	p.CopyFrom(ctx.(*MathExpContext))
 
 	return p
 }
 
-func (s *StringOpContext) GetParser() antlr.Parser { return s.parser }
-func (s *StringOpContext) GetRuleContext() antlr.RuleContext {
+func (s *ColumnIdentifierContext) GetRuleContext() antlr.RuleContext {
 	return s
 }
 
-func (s *StringOpContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
-	return antlr.TreesStringTree(s, ruleNames, recog)
-}
+func (s *ColumnIdentifierContext) ColumnName() IColumnNameContext {
+	var t = s.GetTypedRuleContext(reflect.TypeOf((*IColumnNameContext)(nil)).Elem(), 0)
 
-func (s *StringOpContext) EnterRule(listener antlr.ParseTreeListener) {
-	if listenerT, ok := listener.(TSLListener); ok {
-		listenerT.EnterStringOp(s)
+	if t == nil {
+		return nil
 	}
+
+	return t.(IColumnNameContext)
 }
 
-func (s *StringOpContext) ExitRule(listener antlr.ParseTreeListener) {
+func (s *ColumnIdentifierContext) EnterRule(listener antlr.ParseTreeListener) {
 	if listenerT, ok := listener.(TSLListener); ok {
-		listenerT.ExitStringOp(s)
+		listenerT.EnterColumnIdentifier(s)
 	}
 }
 
-func (p *TSLParser) StringOp() (localctx IStringOpContext) {
-	localctx = NewStringOpContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 6, TSLParserRULE_stringOp)
-	var _la int
-
-	defer func() {
-		p.ExitRule()
-	}()
-
-	defer func() {
-		if err := recover(); err != nil {
-			if v, ok := err.(antlr.RecognitionException); ok {
-				localctx.SetException(v)
-				p.GetErrorHandler().ReportError(p, v)
-				p.GetErrorHandler().Recover(p, v)
-			} else {
-				panic(err)
-			}
-		}
-	}()
-
-	p.EnterOuterAlt(localctx, 1)
-	{
-		p.SetState(107)
-		_la = p.GetTokenStream().LA(1)
-
-		if !(_la == TSLParserT__10 || _la == TSLParserT__11) {
-			p.GetErrorHandler().RecoverInline(p)
-		} else {
-			p.GetErrorHandler().ReportMatch(p)
-			p.Consume()
-		}
+func (s *ColumnIdentifierContext) ExitRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(TSLListener); ok {
+		listenerT.ExitColumnIdentifier(s)
 	}
+}
 
-	return localctx
+type FunctionCallContext struct {
+	*MathExpContext
 }
 
-// IDatabaseNameContext is an interface to support dynamic dispatch.
-type IDatabaseNameContext interface {
-	antlr.ParserRuleContext
+func NewFunctionCallContext(parser antlr.Parser, ctx antlr.ParserRuleContext) *FunctionCallContext {
+	var p = new(FunctionCallContext)
 
-	// GetParser returns the parser.
-	GetParser() antlr.Parser
+	p.MathExpContext = NewEmptyMathExpContext()
+	p.parser = parser
+	p.CopyFrom(ctx.(*MathExpContext))
 
-	// IsDatabaseNameContext differentiates from other interfaces.
-	IsDatabaseNameContext()
+	return p
 }
 
-type DatabaseNameContext struct {
-	*antlr.BaseParserRuleContext
-	parser antlr.Parser
+func (s *FunctionCallContext) GetRuleContext() antlr.RuleContext {
+	return s
 }
 
-func NewEmptyDatabaseNameContext() *DatabaseNameContext {
-	var p = new(DatabaseNameContext)
-	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(nil, -1)
-	p.RuleIndex = TSLParserRULE_databaseName
-	return p
+func (s *FunctionCallContext) IDENTIFIER() antlr.TerminalNode {
+	return s.GetToken(TSLParserIDENTIFIER, 0)
 }
 
-func (*DatabaseNameContext) IsDatabaseNameContext() {}
+func (s *FunctionCallContext) MathExp() IMathExpContext {
+	var t = s.GetTypedRuleContext(reflect.TypeOf((*IMathExpContext)(nil)).Elem(), 0)
 
-func NewDatabaseNameContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *DatabaseNameContext {
-	var p = new(DatabaseNameContext)
+	if t == nil {
+		return nil
+	}
 
-	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(parent, invokingState)
+	return t.(IMathExpContext)
+}
+
+func (s *FunctionCallContext) EnterRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(TSLListener); ok {
+		listenerT.EnterFunctionCall(s)
+	}
+}
+
+func (s *FunctionCallContext) ExitRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(TSLListener); ok {
+		listenerT.ExitFunctionCall(s)
+	}
+}
+
+type AddOpsContext struct {
+	*MathExpContext
+}
+
+func NewAddOpsContext(parser antlr.Parser, ctx antlr.ParserRuleContext) *AddOpsContext {
+	var p = new(AddOpsContext)
 
+	p.MathExpContext = NewEmptyMathExpContext()
 	p.parser = parser
-	p.RuleIndex = TSLParserRULE_databaseName
+	p.CopyFrom(ctx.(*MathExpContext))
 
 	return p
 }
 
-func (s *DatabaseNameContext) GetParser() antlr.Parser { return s.parser }
+func (s *AddOpsContext) GetRuleContext() antlr.RuleContext {
+	return s
+}
 
-func (s *DatabaseNameContext) IDENTIFIER() antlr.TerminalNode {
-	return s.GetToken(TSLParserIDENTIFIER, 0)
+func (s *AddOpsContext) AllMathExp() []IMathExpContext {
+	var ts = s.GetTypedRuleContexts(reflect.TypeOf((*IMathExpContext)(nil)).Elem())
+	var tst = make([]IMathExpContext, len(ts))
+
+	for i, t := range ts {
+		if t != nil {
+			tst[i] = t.(IMathExpContext)
+		}
+	}
+
+	return tst
 }
 
-func (s *DatabaseNameContext) GetRuleContext() antlr.RuleContext {
-	return s
+func (s *AddOpsContext) MathExp(i int) IMathExpContext {
+	var t = s.GetTypedRuleContext(reflect.TypeOf((*IMathExpContext)(nil)).Elem(), i)
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IMathExpContext)
 }
 
-func (s *DatabaseNameContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
-	return antlr.TreesStringTree(s, ruleNames, recog)
+func (s *AddOpsContext) LiteralValue() ILiteralValueContext {
+	var t = s.GetTypedRuleContext(reflect.TypeOf((*ILiteralValueContext)(nil)).Elem(), 0)
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(ILiteralValueContext)
 }
 
-func (s *DatabaseNameContext) EnterRule(listener antlr.ParseTreeListener) {
+func (s *AddOpsContext) EnterRule(listener antlr.ParseTreeListener) {
 	if listenerT, ok := listener.(TSLListener); ok {
-		listenerT.EnterDatabaseName(s)
+		listenerT.EnterAddOps(s)
 	}
 }
 
-func (s *DatabaseNameContext) ExitRule(listener antlr.ParseTreeListener) {
+func (s *AddOpsContext) ExitRule(listener antlr.ParseTreeListener) {
 	if listenerT, ok := listener.(TSLListener); ok {
-		listenerT.ExitDatabaseName(s)
+		listenerT.ExitAddOps(s)
 	}
 }
 
-func (p *TSLParser) DatabaseName() (localctx IDatabaseNameContext) {
-	localctx = NewDatabaseNameContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 8, TSLParserRULE_databaseName)
+func (p *TSLParser) MathExp() (localctx IMathExpContext) {
+	return p.mathExp(0)
+}
+
+func (p *TSLParser) mathExp(_p int) (localctx IMathExpContext) {
+	var _parentctx antlr.ParserRuleContext = p.GetParserRuleContext()
+	_parentState := p.GetState()
+	localctx = NewMathExpContext(p, p.GetParserRuleContext(), _parentState)
+	var _prevctx IMathExpContext = localctx
+	var _ antlr.ParserRuleContext = _prevctx // TODO: To prevent unused variable warning.
+	_startState := 32
+	p.EnterRecursionRule(localctx, 32, TSLParserRULE_mathExp, _p)
 
 	defer func() {
-		p.ExitRule()
+		p.UnrollRecursionContexts(_parentctx)
 	}()
 
 	defer func() {
@@ -1647,191 +4263,297 @@ func (p *TSLParser) DatabaseName() (localctx IDatabaseNameContext) {
 		}
 	}()
 
+	var _alt int
+
 	p.EnterOuterAlt(localctx, 1)
-	{
-		p.SetState(109)
-		p.Match(TSLParserIDENTIFIER)
+	p.SetState(263)
+	p.GetErrorHandler().Sync(p)
+	switch p.GetInterpreter().AdaptivePredict(p.GetTokenStream(), 28, p.GetParserRuleContext()) {
+	case 1:
+		localctx = NewColumnIdentifierContext(p, localctx)
+		p.SetParserRuleContext(localctx)
+		_prevctx = localctx
+
+		{
+			p.SetState(253)
+			p.ColumnName()
+		}
+
+	case 2:
+		localctx = NewFunctionCallContext(p, localctx)
+		p.SetParserRuleContext(localctx)
+		_prevctx = localctx
+		{
+			p.SetState(254)
+			p.Match(TSLParserIDENTIFIER)
+		}
+		{
+			p.SetState(255)
+			p.Match(TSLParserT__1)
+		}
+		{
+			p.SetState(256)
+			p.mathExp(0)
+		}
+		{
+			p.SetState(257)
+			p.Match(TSLParserT__2)
+		}
+
+	case 3:
+		localctx = NewMathParContext(p, localctx)
+		p.SetParserRuleContext(localctx)
+		_prevctx = localctx
+		{
+			p.SetState(259)
+			p.Match(TSLParserT__1)
+		}
+		{
+			p.SetState(260)
+			p.mathExp(0)
+		}
+		{
+			p.SetState(261)
+			p.Match(TSLParserT__2)
+		}
+
 	}
+	p.GetParserRuleContext().SetStop(p.GetTokenStream().LT(-1))
+	p.SetState(297)
+	p.GetErrorHandler().Sync(p)
+	_alt = p.GetInterpreter().AdaptivePredict(p.GetTokenStream(), 35, p.GetParserRuleContext())
 
-	return localctx
-}
+	for _alt != 2 && _alt != antlr.ATNInvalidAltNumber {
+		if _alt == 1 {
+			if p.GetParseListeners() != nil {
+				p.TriggerExitRuleEvent()
+			}
+			_prevctx = localctx
+			p.SetState(295)
+			p.GetErrorHandler().Sync(p)
+			switch p.GetInterpreter().AdaptivePredict(p.GetTokenStream(), 34, p.GetParserRuleContext()) {
+			case 1:
+				localctx = NewMulOpsContext(p, NewMathExpContext(p, _parentctx, _parentState))
+				p.PushNewRecursionContext(localctx, _startState, TSLParserRULE_mathExp)
+				p.SetState(265)
 
-// ITableNameContext is an interface to support dynamic dispatch.
-type ITableNameContext interface {
-	antlr.ParserRuleContext
+				if !(p.Precpred(p.GetParserRuleContext(), 6)) {
+					panic(antlr.NewFailedPredicateException(p, "p.Precpred(p.GetParserRuleContext(), 6)", ""))
+				}
+				{
+					p.SetState(266)
+					p.Match(TSLParserT__13)
+				}
+				p.SetState(269)
+				p.GetErrorHandler().Sync(p)
+				switch p.GetInterpreter().AdaptivePredict(p.GetTokenStream(), 29, p.GetParserRuleContext()) {
+				case 1:
+					{
+						p.SetState(267)
+						p.LiteralValue()
+					}
 
-	// GetParser returns the parser.
-	GetParser() antlr.Parser
+				case 2:
+					{
+						p.SetState(268)
+						p.mathExp(0)
+					}
 
-	// IsTableNameContext differentiates from other interfaces.
-	IsTableNameContext()
-}
+				}
 
-type TableNameContext struct {
-	*antlr.BaseParserRuleContext
-	parser antlr.Parser
-}
+			case 2:
+				localctx = NewDivOpsContext(p, NewMathExpContext(p, _parentctx, _parentState))
+				p.PushNewRecursionContext(localctx, _startState, TSLParserRULE_mathExp)
+				p.SetState(271)
 
-func NewEmptyTableNameContext() *TableNameContext {
-	var p = new(TableNameContext)
-	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(nil, -1)
-	p.RuleIndex = TSLParserRULE_tableName
-	return p
-}
+				if !(p.Precpred(p.GetParserRuleContext(), 5)) {
+					panic(antlr.NewFailedPredicateException(p, "p.Precpred(p.GetParserRuleContext(), 5)", ""))
+				}
+				{
+					p.SetState(272)
+					p.Match(TSLParserT__14)
+				}
+				p.SetState(275)
+				p.GetErrorHandler().Sync(p)
+				switch p.GetInterpreter().AdaptivePredict(p.GetTokenStream(), 30, p.GetParserRuleContext()) {
+				case 1:
+					{
+						p.SetState(273)
+						p.LiteralValue()
+					}
 
-func (*TableNameContext) IsTableNameContext() {}
+				case 2:
+					{
+						p.SetState(274)
+						p.mathExp(0)
+					}
 
-func NewTableNameContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *TableNameContext {
-	var p = new(TableNameContext)
+				}
 
-	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(parent, invokingState)
+			case 3:
+				localctx = NewModOpsContext(p, NewMathExpContext(p, _parentctx, _parentState))
+				p.PushNewRecursionContext(localctx, _startState, TSLParserRULE_mathExp)
+				p.SetState(277)
 
-	p.parser = parser
-	p.RuleIndex = TSLParserRULE_tableName
+				if !(p.Precpred(p.GetParserRuleContext(), 4)) {
+					panic(antlr.NewFailedPredicateException(p, "p.Precpred(p.GetParserRuleContext(), 4)", ""))
+				}
+				{
+					p.SetState(278)
+					p.Match(TSLParserT__15)
+				}
+				p.SetState(281)
+				p.GetErrorHandler().Sync(p)
+				switch p.GetInterpreter().AdaptivePredict(p.GetTokenStream(), 31, p.GetParserRuleContext()) {
+				case 1:
+					{
+						p.SetState(279)
+						p.LiteralValue()
+					}
 
-	return p
-}
+				case 2:
+					{
+						p.SetState(280)
+						p.mathExp(0)
+					}
 
-func (s *TableNameContext) GetParser() antlr.Parser { return s.parser }
+				}
 
-func (s *TableNameContext) IDENTIFIER() antlr.TerminalNode {
-	return s.GetToken(TSLParserIDENTIFIER, 0)
-}
+			case 4:
+				localctx = NewAddOpsContext(p, NewMathExpContext(p, _parentctx, _parentState))
+				p.PushNewRecursionContext(localctx, _startState, TSLParserRULE_mathExp)
+				p.SetState(283)
+
+				if !(p.Precpred(p.GetParserRuleContext(), 3)) {
+					panic(antlr.NewFailedPredicateException(p, "p.Precpred(p.GetParserRuleContext(), 3)", ""))
+				}
+				{
+					p.SetState(284)
+					p.Match(TSLParserT__16)
+				}
+				p.SetState(287)
+				p.GetErrorHandler().Sync(p)
+				switch p.GetInterpreter().AdaptivePredict(p.GetTokenStream(), 32, p.GetParserRuleContext()) {
+				case 1:
+					{
+						p.SetState(285)
+						p.LiteralValue()
+					}
 
-func (s *TableNameContext) GetRuleContext() antlr.RuleContext {
-	return s
-}
+				case 2:
+					{
+						p.SetState(286)
+						p.mathExp(0)
+					}
 
-func (s *TableNameContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
-	return antlr.TreesStringTree(s, ruleNames, recog)
-}
+				}
 
-func (s *TableNameContext) EnterRule(listener antlr.ParseTreeListener) {
-	if listenerT, ok := listener.(TSLListener); ok {
-		listenerT.EnterTableName(s)
-	}
-}
+			case 5:
+				localctx = NewSubOpsContext(p, NewMathExpContext(p, _parentctx, _parentState))
+				p.PushNewRecursionContext(localctx, _startState, TSLParserRULE_mathExp)
+				p.SetState(289)
 
-func (s *TableNameContext) ExitRule(listener antlr.ParseTreeListener) {
-	if listenerT, ok := listener.(TSLListener); ok {
-		listenerT.ExitTableName(s)
-	}
-}
+				if !(p.Precpred(p.GetParserRuleContext(), 2)) {
+					panic(antlr.NewFailedPredicateException(p, "p.Precpred(p.GetParserRuleContext(), 2)", ""))
+				}
+				{
+					p.SetState(290)
+					p.Match(TSLParserT__17)
+				}
+				p.SetState(293)
+				p.GetErrorHandler().Sync(p)
+				switch p.GetInterpreter().AdaptivePredict(p.GetTokenStream(), 33, p.GetParserRuleContext()) {
+				case 1:
+					{
+						p.SetState(291)
+						p.LiteralValue()
+					}
 
-func (p *TSLParser) TableName() (localctx ITableNameContext) {
-	localctx = NewTableNameContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 10, TSLParserRULE_tableName)
+				case 2:
+					{
+						p.SetState(292)
+						p.mathExp(0)
+					}
 
-	defer func() {
-		p.ExitRule()
-	}()
+				}
 
-	defer func() {
-		if err := recover(); err != nil {
-			if v, ok := err.(antlr.RecognitionException); ok {
-				localctx.SetException(v)
-				p.GetErrorHandler().ReportError(p, v)
-				p.GetErrorHandler().Recover(p, v)
-			} else {
-				panic(err)
 			}
-		}
-	}()
 
-	p.EnterOuterAlt(localctx, 1)
-	{
-		p.SetState(111)
-		p.Match(TSLParserIDENTIFIER)
+		}
+		p.SetState(299)
+		p.GetErrorHandler().Sync(p)
+		_alt = p.GetInterpreter().AdaptivePredict(p.GetTokenStream(), 35, p.GetParserRuleContext())
 	}
 
 	return localctx
 }
 
-// IColumnNameContext is an interface to support dynamic dispatch.
-type IColumnNameContext interface {
+// ISignedNumberContext is an interface to support dynamic dispatch.
+type ISignedNumberContext interface {
 	antlr.ParserRuleContext
 
 	// GetParser returns the parser.
 	GetParser() antlr.Parser
 
-	// IsColumnNameContext differentiates from other interfaces.
-	IsColumnNameContext()
+	// IsSignedNumberContext differentiates from other interfaces.
+	IsSignedNumberContext()
 }
 
-type ColumnNameContext struct {
+type SignedNumberContext struct {
 	*antlr.BaseParserRuleContext
 	parser antlr.Parser
 }
 
-func NewEmptyColumnNameContext() *ColumnNameContext {
-	var p = new(ColumnNameContext)
+func NewEmptySignedNumberContext() *SignedNumberContext {
+	var p = new(SignedNumberContext)
 	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(nil, -1)
-	p.RuleIndex = TSLParserRULE_columnName
+	p.RuleIndex = TSLParserRULE_signedNumber
 	return p
 }
 
-func (*ColumnNameContext) IsColumnNameContext() {}
+func (*SignedNumberContext) IsSignedNumberContext() {}
 
-func NewColumnNameContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *ColumnNameContext {
-	var p = new(ColumnNameContext)
+func NewSignedNumberContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *SignedNumberContext {
+	var p = new(SignedNumberContext)
 
 	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(parent, invokingState)
 
 	p.parser = parser
-	p.RuleIndex = TSLParserRULE_columnName
+	p.RuleIndex = TSLParserRULE_signedNumber
 
 	return p
 }
 
-func (s *ColumnNameContext) GetParser() antlr.Parser { return s.parser }
-
-func (s *ColumnNameContext) IDENTIFIER() antlr.TerminalNode {
-	return s.GetToken(TSLParserIDENTIFIER, 0)
-}
-
-func (s *ColumnNameContext) TableName() ITableNameContext {
-	var t = s.GetTypedRuleContext(reflect.TypeOf((*ITableNameContext)(nil)).Elem(), 0)
-
-	if t == nil {
-		return nil
-	}
-
-	return t.(ITableNameContext)
-}
-
-func (s *ColumnNameContext) DatabaseName() IDatabaseNameContext {
-	var t = s.GetTypedRuleContext(reflect.TypeOf((*IDatabaseNameContext)(nil)).Elem(), 0)
-
-	if t == nil {
-		return nil
-	}
+func (s *SignedNumberContext) GetParser() antlr.Parser { return s.parser }
 
-	return t.(IDatabaseNameContext)
+func (s *SignedNumberContext) NUMERIC_LITERAL() antlr.TerminalNode {
+	return s.GetToken(TSLParserNUMERIC_LITERAL, 0)
 }
 
-func (s *ColumnNameContext) GetRuleContext() antlr.RuleContext {
+func (s *SignedNumberContext) GetRuleContext() antlr.RuleContext {
 	return s
 }
 
-func (s *ColumnNameContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
+func (s *SignedNumberContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
 	return antlr.TreesStringTree(s, ruleNames, recog)
 }
 
-func (s *ColumnNameContext) EnterRule(listener antlr.ParseTreeListener) {
+func (s *SignedNumberContext) EnterRule(listener antlr.ParseTreeListener) {
 	if listenerT, ok := listener.(TSLListener); ok {
-		listenerT.EnterColumnName(s)
+		listenerT.EnterSignedNumber(s)
 	}
 }
 
-func (s *ColumnNameContext) ExitRule(listener antlr.ParseTreeListener) {
+func (s *SignedNumberContext) ExitRule(listener antlr.ParseTreeListener) {
 	if listenerT, ok := listener.(TSLListener); ok {
-		listenerT.ExitColumnName(s)
+		listenerT.ExitSignedNumber(s)
 	}
 }
 
-func (p *TSLParser) ColumnName() (localctx IColumnNameContext) {
-	localctx = NewColumnNameContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 12, TSLParserRULE_columnName)
+func (p *TSLParser) SignedNumber() (localctx ISignedNumberContext) {
+	localctx = NewSignedNumberContext(p, p.GetParserRuleContext(), p.GetState())
+	p.EnterRule(localctx, 34, TSLParserRULE_signedNumber)
+	var _la int
 
 	defer func() {
 		p.ExitRule()
@@ -1850,175 +4572,97 @@ func (p *TSLParser) ColumnName() (localctx IColumnNameContext) {
 	}()
 
 	p.EnterOuterAlt(localctx, 1)
-	p.SetState(121)
+	p.SetState(301)
 	p.GetErrorHandler().Sync(p)
+	_la = p.GetTokenStream().LA(1)
 
-	if p.GetInterpreter().AdaptivePredict(p.GetTokenStream(), 12, p.GetParserRuleContext()) == 1 {
-		p.SetState(116)
-		p.GetErrorHandler().Sync(p)
+	if _la == TSLParserT__16 || _la == TSLParserT__17 {
+		{
+			p.SetState(300)
+			_la = p.GetTokenStream().LA(1)
 
-		if p.GetInterpreter().AdaptivePredict(p.GetTokenStream(), 11, p.GetParserRuleContext()) == 1 {
-			{
-				p.SetState(113)
-				p.DatabaseName()
-			}
-			{
-				p.SetState(114)
-				p.Match(TSLParserT__12)
+			if !(_la == TSLParserT__16 || _la == TSLParserT__17) {
+				p.GetErrorHandler().RecoverInline(p)
+			} else {
+				p.GetErrorHandler().ReportMatch(p)
+				p.Consume()
 			}
-
-		}
-		{
-			p.SetState(118)
-			p.TableName()
-		}
-		{
-			p.SetState(119)
-			p.Match(TSLParserT__12)
 		}
 
 	}
 	{
-		p.SetState(123)
-		p.Match(TSLParserIDENTIFIER)
+		p.SetState(303)
+		p.Match(TSLParserNUMERIC_LITERAL)
 	}
 
 	return localctx
 }
 
-// ILiteralValueContext is an interface to support dynamic dispatch.
-type ILiteralValueContext interface {
+// IStringValueContext is an interface to support dynamic dispatch.
+type IStringValueContext interface {
 	antlr.ParserRuleContext
 
 	// GetParser returns the parser.
 	GetParser() antlr.Parser
 
-	// IsLiteralValueContext differentiates from other interfaces.
-	IsLiteralValueContext()
+	// IsStringValueContext differentiates from other interfaces.
+	IsStringValueContext()
 }
 
-type LiteralValueContext struct {
+type StringValueContext struct {
 	*antlr.BaseParserRuleContext
 	parser antlr.Parser
 }
 
-func NewEmptyLiteralValueContext() *LiteralValueContext {
-	var p = new(LiteralValueContext)
+func NewEmptyStringValueContext() *StringValueContext {
+	var p = new(StringValueContext)
 	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(nil, -1)
-	p.RuleIndex = TSLParserRULE_literalValue
+	p.RuleIndex = TSLParserRULE_stringValue
 	return p
 }
 
-func (*LiteralValueContext) IsLiteralValueContext() {}
+func (*StringValueContext) IsStringValueContext() {}
 
-func NewLiteralValueContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *LiteralValueContext {
-	var p = new(LiteralValueContext)
+func NewStringValueContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *StringValueContext {
+	var p = new(StringValueContext)
 
 	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(parent, invokingState)
 
 	p.parser = parser
-	p.RuleIndex = TSLParserRULE_literalValue
+	p.RuleIndex = TSLParserRULE_stringValue
 
 	return p
 }
 
-func (s *LiteralValueContext) GetParser() antlr.Parser { return s.parser }
+func (s *StringValueContext) GetParser() antlr.Parser { return s.parser }
 
-func (s *LiteralValueContext) CopyFrom(ctx *LiteralValueContext) {
-	s.BaseParserRuleContext.CopyFrom(ctx.BaseParserRuleContext)
+func (s *StringValueContext) STRING_LITERAL() antlr.TerminalNode {
+	return s.GetToken(TSLParserSTRING_LITERAL, 0)
 }
 
-func (s *LiteralValueContext) GetRuleContext() antlr.RuleContext {
+func (s *StringValueContext) GetRuleContext() antlr.RuleContext {
 	return s
 }
 
-func (s *LiteralValueContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
+func (s *StringValueContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
 	return antlr.TreesStringTree(s, ruleNames, recog)
 }
 
-type StringLiteralContext struct {
-	*LiteralValueContext
-}
-
-func NewStringLiteralContext(parser antlr.Parser, ctx antlr.ParserRuleContext) *StringLiteralContext {
-	var p = new(StringLiteralContext)
-
-	p.LiteralValueContext = NewEmptyLiteralValueContext()
-	p.parser = parser
-	p.CopyFrom(ctx.(*LiteralValueContext))
-
-	return p
-}
-
-func (s *StringLiteralContext) GetRuleContext() antlr.RuleContext {
-	return s
-}
-
-func (s *StringLiteralContext) StringValue() IStringValueContext {
-	var t = s.GetTypedRuleContext(reflect.TypeOf((*IStringValueContext)(nil)).Elem(), 0)
-
-	if t == nil {
-		return nil
-	}
-
-	return t.(IStringValueContext)
-}
-
-func (s *StringLiteralContext) EnterRule(listener antlr.ParseTreeListener) {
-	if listenerT, ok := listener.(TSLListener); ok {
-		listenerT.EnterStringLiteral(s)
-	}
-}
-
-func (s *StringLiteralContext) ExitRule(listener antlr.ParseTreeListener) {
-	if listenerT, ok := listener.(TSLListener); ok {
-		listenerT.ExitStringLiteral(s)
-	}
-}
-
-type NumberLiteralContext struct {
-	*LiteralValueContext
-}
-
-func NewNumberLiteralContext(parser antlr.Parser, ctx antlr.ParserRuleContext) *NumberLiteralContext {
-	var p = new(NumberLiteralContext)
-
-	p.LiteralValueContext = NewEmptyLiteralValueContext()
-	p.parser = parser
-	p.CopyFrom(ctx.(*LiteralValueContext))
-
-	return p
-}
-
-func (s *NumberLiteralContext) GetRuleContext() antlr.RuleContext {
-	return s
-}
-
-func (s *NumberLiteralContext) SignedNumber() ISignedNumberContext {
-	var t = s.GetTypedRuleContext(reflect.TypeOf((*ISignedNumberContext)(nil)).Elem(), 0)
-
-	if t == nil {
-		return nil
-	}
-
-	return t.(ISignedNumberContext)
-}
-
-func (s *NumberLiteralContext) EnterRule(listener antlr.ParseTreeListener) {
+func (s *StringValueContext) EnterRule(listener antlr.ParseTreeListener) {
 	if listenerT, ok := listener.(TSLListener); ok {
-		listenerT.EnterNumberLiteral(s)
+		listenerT.EnterStringValue(s)
 	}
 }
 
-func (s *NumberLiteralContext) ExitRule(listener antlr.ParseTreeListener) {
+func (s *StringValueContext) ExitRule(listener antlr.ParseTreeListener) {
 	if listenerT, ok := listener.(TSLListener); ok {
-		listenerT.ExitNumberLiteral(s)
+		listenerT.ExitStringValue(s)
 	}
 }
 
-func (p *TSLParser) LiteralValue() (localctx ILiteralValueContext) {
-	localctx = NewLiteralValueContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 14, TSLParserRULE_literalValue)
+func (p *TSLParser) StringValue() (localctx IStringValueContext) {
+	localctx = NewStringValueContext(p, p.GetParserRuleContext(), p.GetState())
+	p.EnterRule(localctx, 36, TSLParserRULE_stringValue)
 
 	defer func() {
 		p.ExitRule()
@@ -2036,493 +4680,460 @@ func (p *TSLParser) LiteralValue() (localctx ILiteralValueContext) {
 		}
 	}()
 
-	p.SetState(127)
-	p.GetErrorHandler().Sync(p)
-
-	switch p.GetTokenStream().LA(1) {
-	case TSLParserT__16, TSLParserT__17, TSLParserNUMERIC_LITERAL:
-		localctx = NewNumberLiteralContext(p, localctx)
-		p.EnterOuterAlt(localctx, 1)
-		{
-			p.SetState(125)
-			p.SignedNumber()
-		}
-
-	case TSLParserSTRING_LITERAL:
-		localctx = NewStringLiteralContext(p, localctx)
-		p.EnterOuterAlt(localctx, 2)
-		{
-			p.SetState(126)
-			p.StringValue()
-		}
-
-	default:
-		panic(antlr.NewNoViableAltException(p, nil, nil, nil, nil, nil))
+	p.EnterOuterAlt(localctx, 1)
+	{
+		p.SetState(305)
+		p.Match(TSLParserSTRING_LITERAL)
 	}
 
 	return localctx
 }
 
-// IMathExpContext is an interface to support dynamic dispatch.
-type IMathExpContext interface {
+// IBooleanValueContext is an interface to support dynamic dispatch.
+type IBooleanValueContext interface {
 	antlr.ParserRuleContext
 
 	// GetParser returns the parser.
 	GetParser() antlr.Parser
 
-	// IsMathExpContext differentiates from other interfaces.
-	IsMathExpContext()
+	// IsBooleanValueContext differentiates from other interfaces.
+	IsBooleanValueContext()
 }
 
-type MathExpContext struct {
+type BooleanValueContext struct {
 	*antlr.BaseParserRuleContext
 	parser antlr.Parser
 }
 
-func NewEmptyMathExpContext() *MathExpContext {
-	var p = new(MathExpContext)
+func NewEmptyBooleanValueContext() *BooleanValueContext {
+	var p = new(BooleanValueContext)
 	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(nil, -1)
-	p.RuleIndex = TSLParserRULE_mathExp
+	p.RuleIndex = TSLParserRULE_booleanValue
 	return p
 }
 
-func (*MathExpContext) IsMathExpContext() {}
+func (*BooleanValueContext) IsBooleanValueContext() {}
 
-func NewMathExpContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *MathExpContext {
-	var p = new(MathExpContext)
+func NewBooleanValueContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *BooleanValueContext {
+	var p = new(BooleanValueContext)
 
 	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(parent, invokingState)
 
 	p.parser = parser
-	p.RuleIndex = TSLParserRULE_mathExp
+	p.RuleIndex = TSLParserRULE_booleanValue
 
 	return p
 }
 
-func (s *MathExpContext) GetParser() antlr.Parser { return s.parser }
-
-func (s *MathExpContext) CopyFrom(ctx *MathExpContext) {
-	s.BaseParserRuleContext.CopyFrom(ctx.BaseParserRuleContext)
-}
-
-func (s *MathExpContext) GetRuleContext() antlr.RuleContext {
-	return s
-}
-
-func (s *MathExpContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
-	return antlr.TreesStringTree(s, ruleNames, recog)
-}
+func (s *BooleanValueContext) GetParser() antlr.Parser { return s.parser }
 
-type MathParContext struct {
-	*MathExpContext
+func (s *BooleanValueContext) K_TRUE() antlr.TerminalNode {
+	return s.GetToken(TSLParserK_TRUE, 0)
 }
 
-func NewMathParContext(parser antlr.Parser, ctx antlr.ParserRuleContext) *MathParContext {
-	var p = new(MathParContext)
-
-	p.MathExpContext = NewEmptyMathExpContext()
-	p.parser = parser
-	p.CopyFrom(ctx.(*MathExpContext))
-
-	return p
+func (s *BooleanValueContext) K_FALSE() antlr.TerminalNode {
+	return s.GetToken(TSLParserK_FALSE, 0)
 }
 
-func (s *MathParContext) GetRuleContext() antlr.RuleContext {
+func (s *BooleanValueContext) GetRuleContext() antlr.RuleContext {
 	return s
 }
 
-func (s *MathParContext) MathExp() IMathExpContext {
-	var t = s.GetTypedRuleContext(reflect.TypeOf((*IMathExpContext)(nil)).Elem(), 0)
-
-	if t == nil {
-		return nil
-	}
-
-	return t.(IMathExpContext)
+func (s *BooleanValueContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
+	return antlr.TreesStringTree(s, ruleNames, recog)
 }
 
-func (s *MathParContext) EnterRule(listener antlr.ParseTreeListener) {
+func (s *BooleanValueContext) EnterRule(listener antlr.ParseTreeListener) {
 	if listenerT, ok := listener.(TSLListener); ok {
-		listenerT.EnterMathPar(s)
+		listenerT.EnterBooleanValue(s)
 	}
 }
 
-func (s *MathParContext) ExitRule(listener antlr.ParseTreeListener) {
+func (s *BooleanValueContext) ExitRule(listener antlr.ParseTreeListener) {
 	if listenerT, ok := listener.(TSLListener); ok {
-		listenerT.ExitMathPar(s)
+		listenerT.ExitBooleanValue(s)
 	}
 }
 
-type ModOpsContext struct {
-	*MathExpContext
-}
-
-func NewModOpsContext(parser antlr.Parser, ctx antlr.ParserRuleContext) *ModOpsContext {
-	var p = new(ModOpsContext)
-
-	p.MathExpContext = NewEmptyMathExpContext()
-	p.parser = parser
-	p.CopyFrom(ctx.(*MathExpContext))
+func (p *TSLParser) BooleanValue() (localctx IBooleanValueContext) {
+	localctx = NewBooleanValueContext(p, p.GetParserRuleContext(), p.GetState())
+	p.EnterRule(localctx, 38, TSLParserRULE_booleanValue)
+	var _la int
 
-	return p
-}
+	defer func() {
+		p.ExitRule()
+	}()
 
-func (s *ModOpsContext) GetRuleContext() antlr.RuleContext {
-	return s
-}
+	defer func() {
+		if err := recover(); err != nil {
+			if v, ok := err.(antlr.RecognitionException); ok {
+				localctx.SetException(v)
+				p.GetErrorHandler().ReportError(p, v)
+				p.GetErrorHandler().Recover(p, v)
+			} else {
+				panic(err)
+			}
+		}
+	}()
 
-func (s *ModOpsContext) AllMathExp() []IMathExpContext {
-	var ts = s.GetTypedRuleContexts(reflect.TypeOf((*IMathExpContext)(nil)).Elem())
-	var tst = make([]IMathExpContext, len(ts))
+	p.EnterOuterAlt(localctx, 1)
+	{
+		p.SetState(307)
+		_la = p.GetTokenStream().LA(1)
 
-	for i, t := range ts {
-		if t != nil {
-			tst[i] = t.(IMathExpContext)
+		if !(_la == TSLParserK_TRUE || _la == TSLParserK_FALSE) {
+			p.GetErrorHandler().RecoverInline(p)
+		} else {
+			p.GetErrorHandler().ReportMatch(p)
+			p.Consume()
 		}
 	}
 
-	return tst
+	return localctx
 }
 
-func (s *ModOpsContext) MathExp(i int) IMathExpContext {
-	var t = s.GetTypedRuleContext(reflect.TypeOf((*IMathExpContext)(nil)).Elem(), i)
+// IDateValueContext is an interface to support dynamic dispatch.
+type IDateValueContext interface {
+	antlr.ParserRuleContext
 
-	if t == nil {
-		return nil
-	}
+	// GetParser returns the parser.
+	GetParser() antlr.Parser
 
-	return t.(IMathExpContext)
+	// IsDateValueContext differentiates from other interfaces.
+	IsDateValueContext()
 }
 
-func (s *ModOpsContext) LiteralValue() ILiteralValueContext {
-	var t = s.GetTypedRuleContext(reflect.TypeOf((*ILiteralValueContext)(nil)).Elem(), 0)
-
-	if t == nil {
-		return nil
-	}
-
-	return t.(ILiteralValueContext)
+type DateValueContext struct {
+	*antlr.BaseParserRuleContext
+	parser antlr.Parser
 }
 
-func (s *ModOpsContext) EnterRule(listener antlr.ParseTreeListener) {
-	if listenerT, ok := listener.(TSLListener); ok {
-		listenerT.EnterModOps(s)
-	}
+func NewEmptyDateValueContext() *DateValueContext {
+	var p = new(DateValueContext)
+	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(nil, -1)
+	p.RuleIndex = TSLParserRULE_dateValue
+	return p
 }
 
-func (s *ModOpsContext) ExitRule(listener antlr.ParseTreeListener) {
-	if listenerT, ok := listener.(TSLListener); ok {
-		listenerT.ExitModOps(s)
-	}
-}
+func (*DateValueContext) IsDateValueContext() {}
 
-type SubOpsContext struct {
-	*MathExpContext
-}
+func NewDateValueContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *DateValueContext {
+	var p = new(DateValueContext)
 
-func NewSubOpsContext(parser antlr.Parser, ctx antlr.ParserRuleContext) *SubOpsContext {
-	var p = new(SubOpsContext)
+	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(parent, invokingState)
 
-	p.MathExpContext = NewEmptyMathExpContext()
 	p.parser = parser
-	p.CopyFrom(ctx.(*MathExpContext))
+	p.RuleIndex = TSLParserRULE_dateValue
 
 	return p
 }
 
-func (s *SubOpsContext) GetRuleContext() antlr.RuleContext {
-	return s
-}
-
-func (s *SubOpsContext) AllMathExp() []IMathExpContext {
-	var ts = s.GetTypedRuleContexts(reflect.TypeOf((*IMathExpContext)(nil)).Elem())
-	var tst = make([]IMathExpContext, len(ts))
+func (s *DateValueContext) GetParser() antlr.Parser { return s.parser }
 
-	for i, t := range ts {
-		if t != nil {
-			tst[i] = t.(IMathExpContext)
-		}
-	}
+func (s *DateValueContext) K_NOW() antlr.TerminalNode {
+	return s.GetToken(TSLParserK_NOW, 0)
+}
 
-	return tst
+func (s *DateValueContext) K_DATE() antlr.TerminalNode {
+	return s.GetToken(TSLParserK_DATE, 0)
 }
 
-func (s *SubOpsContext) MathExp(i int) IMathExpContext {
-	var t = s.GetTypedRuleContext(reflect.TypeOf((*IMathExpContext)(nil)).Elem(), i)
+func (s *DateValueContext) StringValue() IStringValueContext {
+	var t = s.GetTypedRuleContext(reflect.TypeOf((*IStringValueContext)(nil)).Elem(), 0)
 
 	if t == nil {
 		return nil
 	}
 
-	return t.(IMathExpContext)
+	return t.(IStringValueContext)
 }
 
-func (s *SubOpsContext) LiteralValue() ILiteralValueContext {
-	var t = s.GetTypedRuleContext(reflect.TypeOf((*ILiteralValueContext)(nil)).Elem(), 0)
+func (s *DateValueContext) DATE_LITERAL() antlr.TerminalNode {
+	return s.GetToken(TSLParserDATE_LITERAL, 0)
+}
+
+func (s *DateValueContext) DateOffset() IDateOffsetContext {
+	var t = s.GetTypedRuleContext(reflect.TypeOf((*IDateOffsetContext)(nil)).Elem(), 0)
 
 	if t == nil {
 		return nil
 	}
 
-	return t.(ILiteralValueContext)
+	return t.(IDateOffsetContext)
 }
 
-func (s *SubOpsContext) EnterRule(listener antlr.ParseTreeListener) {
+func (s *DateValueContext) GetRuleContext() antlr.RuleContext {
+	return s
+}
+
+func (s *DateValueContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
+	return antlr.TreesStringTree(s, ruleNames, recog)
+}
+
+func (s *DateValueContext) EnterRule(listener antlr.ParseTreeListener) {
 	if listenerT, ok := listener.(TSLListener); ok {
-		listenerT.EnterSubOps(s)
+		listenerT.EnterDateValue(s)
 	}
 }
 
-func (s *SubOpsContext) ExitRule(listener antlr.ParseTreeListener) {
+func (s *DateValueContext) ExitRule(listener antlr.ParseTreeListener) {
 	if listenerT, ok := listener.(TSLListener); ok {
-		listenerT.ExitSubOps(s)
+		listenerT.ExitDateValue(s)
 	}
 }
 
-type MulOpsContext struct {
-	*MathExpContext
-}
+func (p *TSLParser) DateValue() (localctx IDateValueContext) {
+	localctx = NewDateValueContext(p, p.GetParserRuleContext(), p.GetState())
+	p.EnterRule(localctx, 40, TSLParserRULE_dateValue)
 
-func NewMulOpsContext(parser antlr.Parser, ctx antlr.ParserRuleContext) *MulOpsContext {
-	var p = new(MulOpsContext)
+	defer func() {
+		p.ExitRule()
+	}()
 
-	p.MathExpContext = NewEmptyMathExpContext()
-	p.parser = parser
-	p.CopyFrom(ctx.(*MathExpContext))
+	defer func() {
+		if err := recover(); err != nil {
+			if v, ok := err.(antlr.RecognitionException); ok {
+				localctx.SetException(v)
+				p.GetErrorHandler().ReportError(p, v)
+				p.GetErrorHandler().Recover(p, v)
+			} else {
+				panic(err)
+			}
+		}
+	}()
 
-	return p
-}
+	p.EnterOuterAlt(localctx, 1)
+	p.SetState(318)
+	p.GetErrorHandler().Sync(p)
 
-func (s *MulOpsContext) GetRuleContext() antlr.RuleContext {
-	return s
-}
+	switch p.GetTokenStream().LA(1) {
+	case TSLParserK_NOW:
+		{
+			p.SetState(309)
+			p.Match(TSLParserK_NOW)
+		}
+		{
+			p.SetState(310)
+			p.Match(TSLParserT__1)
+		}
+		{
+			p.SetState(311)
+			p.Match(TSLParserT__2)
+		}
 
-func (s *MulOpsContext) AllMathExp() []IMathExpContext {
-	var ts = s.GetTypedRuleContexts(reflect.TypeOf((*IMathExpContext)(nil)).Elem())
-	var tst = make([]IMathExpContext, len(ts))
+	case TSLParserK_DATE:
+		{
+			p.SetState(312)
+			p.Match(TSLParserK_DATE)
+		}
+		{
+			p.SetState(313)
+			p.Match(TSLParserT__1)
+		}
+		{
+			p.SetState(314)
+			p.StringValue()
+		}
+		{
+			p.SetState(315)
+			p.Match(TSLParserT__2)
+		}
 
-	for i, t := range ts {
-		if t != nil {
-			tst[i] = t.(IMathExpContext)
+	case TSLParserDATE_LITERAL:
+		{
+			p.SetState(317)
+			p.Match(TSLParserDATE_LITERAL)
 		}
-	}
 
-	return tst
-}
+	default:
+		panic(antlr.NewNoViableAltException(p, nil, nil, nil, nil, nil))
+	}
+	p.SetState(321)
+	p.GetErrorHandler().Sync(p)
 
-func (s *MulOpsContext) MathExp(i int) IMathExpContext {
-	var t = s.GetTypedRuleContext(reflect.TypeOf((*IMathExpContext)(nil)).Elem(), i)
+	if p.GetInterpreter().AdaptivePredict(p.GetTokenStream(), 38, p.GetParserRuleContext()) == 1 {
+		{
+			p.SetState(320)
+			p.DateOffset()
+		}
 
-	if t == nil {
-		return nil
 	}
 
-	return t.(IMathExpContext)
+	return localctx
 }
 
-func (s *MulOpsContext) LiteralValue() ILiteralValueContext {
-	var t = s.GetTypedRuleContext(reflect.TypeOf((*ILiteralValueContext)(nil)).Elem(), 0)
+// IDateOffsetContext is an interface to support dynamic dispatch.
+type IDateOffsetContext interface {
+	antlr.ParserRuleContext
 
-	if t == nil {
-		return nil
-	}
+	// GetParser returns the parser.
+	GetParser() antlr.Parser
 
-	return t.(ILiteralValueContext)
+	// IsDateOffsetContext differentiates from other interfaces.
+	IsDateOffsetContext()
 }
 
-func (s *MulOpsContext) EnterRule(listener antlr.ParseTreeListener) {
-	if listenerT, ok := listener.(TSLListener); ok {
-		listenerT.EnterMulOps(s)
-	}
+type DateOffsetContext struct {
+	*antlr.BaseParserRuleContext
+	parser antlr.Parser
 }
 
-func (s *MulOpsContext) ExitRule(listener antlr.ParseTreeListener) {
-	if listenerT, ok := listener.(TSLListener); ok {
-		listenerT.ExitMulOps(s)
-	}
+func NewEmptyDateOffsetContext() *DateOffsetContext {
+	var p = new(DateOffsetContext)
+	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(nil, -1)
+	p.RuleIndex = TSLParserRULE_dateOffset
+	return p
 }
 
-type DivOpsContext struct {
-	*MathExpContext
-}
+func (*DateOffsetContext) IsDateOffsetContext() {}
 
-func NewDivOpsContext(parser antlr.Parser, ctx antlr.ParserRuleContext) *DivOpsContext {
-	var p = new(DivOpsContext)
+func NewDateOffsetContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *DateOffsetContext {
+	var p = new(DateOffsetContext)
+
+	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(parent, invokingState)
 
-	p.MathExpContext = NewEmptyMathExpContext()
 	p.parser = parser
-	p.CopyFrom(ctx.(*MathExpContext))
+	p.RuleIndex = TSLParserRULE_dateOffset
 
 	return p
 }
 
-func (s *DivOpsContext) GetRuleContext() antlr.RuleContext {
-	return s
-}
-
-func (s *DivOpsContext) AllMathExp() []IMathExpContext {
-	var ts = s.GetTypedRuleContexts(reflect.TypeOf((*IMathExpContext)(nil)).Elem())
-	var tst = make([]IMathExpContext, len(ts))
-
-	for i, t := range ts {
-		if t != nil {
-			tst[i] = t.(IMathExpContext)
-		}
-	}
+func (s *DateOffsetContext) GetParser() antlr.Parser { return s.parser }
 
-	return tst
+func (s *DateOffsetContext) DURATION_LITERAL() antlr.TerminalNode {
+	return s.GetToken(TSLParserDURATION_LITERAL, 0)
 }
 
-func (s *DivOpsContext) MathExp(i int) IMathExpContext {
-	var t = s.GetTypedRuleContext(reflect.TypeOf((*IMathExpContext)(nil)).Elem(), i)
-
-	if t == nil {
-		return nil
-	}
-
-	return t.(IMathExpContext)
+func (s *DateOffsetContext) GetRuleContext() antlr.RuleContext {
+	return s
 }
 
-func (s *DivOpsContext) LiteralValue() ILiteralValueContext {
-	var t = s.GetTypedRuleContext(reflect.TypeOf((*ILiteralValueContext)(nil)).Elem(), 0)
-
-	if t == nil {
-		return nil
-	}
-
-	return t.(ILiteralValueContext)
+func (s *DateOffsetContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
+	return antlr.TreesStringTree(s, ruleNames, recog)
 }
 
-func (s *DivOpsContext) EnterRule(listener antlr.ParseTreeListener) {
+func (s *DateOffsetContext) EnterRule(listener antlr.ParseTreeListener) {
 	if listenerT, ok := listener.(TSLListener); ok {
-		listenerT.EnterDivOps(s)
+		listenerT.EnterDateOffset(s)
 	}
 }
 
-func (s *DivOpsContext) ExitRule(listener antlr.ParseTreeListener) {
+func (s *DateOffsetContext) ExitRule(listener antlr.ParseTreeListener) {
 	if listenerT, ok := listener.(TSLListener); ok {
-		listenerT.ExitDivOps(s)
+		listenerT.ExitDateOffset(s)
 	}
 }
 
-type ColumnIdentifierContext struct {
-	*MathExpContext
-}
+func (p *TSLParser) DateOffset() (localctx IDateOffsetContext) {
+	localctx = NewDateOffsetContext(p, p.GetParserRuleContext(), p.GetState())
+	p.EnterRule(localctx, 42, TSLParserRULE_dateOffset)
+	var _la int
 
-func NewColumnIdentifierContext(parser antlr.Parser, ctx antlr.ParserRuleContext) *ColumnIdentifierContext {
-	var p = new(ColumnIdentifierContext)
+	defer func() {
+		p.ExitRule()
+	}()
 
-	p.MathExpContext = NewEmptyMathExpContext()
-	p.parser = parser
-	p.CopyFrom(ctx.(*MathExpContext))
+	defer func() {
+		if err := recover(); err != nil {
+			if v, ok := err.(antlr.RecognitionException); ok {
+				localctx.SetException(v)
+				p.GetErrorHandler().ReportError(p, v)
+				p.GetErrorHandler().Recover(p, v)
+			} else {
+				panic(err)
+			}
+		}
+	}()
 
-	return p
-}
+	p.EnterOuterAlt(localctx, 1)
+	{
+		p.SetState(323)
+		_la = p.GetTokenStream().LA(1)
 
-func (s *ColumnIdentifierContext) GetRuleContext() antlr.RuleContext {
-	return s
+		if !(_la == TSLParserT__16 || _la == TSLParserT__17) {
+			p.GetErrorHandler().RecoverInline(p)
+		} else {
+			p.GetErrorHandler().ReportMatch(p)
+			p.Consume()
+		}
+	}
+	{
+		p.SetState(324)
+		p.Match(TSLParserDURATION_LITERAL)
+	}
+
+	return localctx
 }
 
-func (s *ColumnIdentifierContext) ColumnName() IColumnNameContext {
-	var t = s.GetTypedRuleContext(reflect.TypeOf((*IColumnNameContext)(nil)).Elem(), 0)
+// IDurationValueContext is an interface to support dynamic dispatch.
+type IDurationValueContext interface {
+	antlr.ParserRuleContext
 
-	if t == nil {
-		return nil
-	}
+	// GetParser returns the parser.
+	GetParser() antlr.Parser
 
-	return t.(IColumnNameContext)
+	// IsDurationValueContext differentiates from other interfaces.
+	IsDurationValueContext()
 }
 
-func (s *ColumnIdentifierContext) EnterRule(listener antlr.ParseTreeListener) {
-	if listenerT, ok := listener.(TSLListener); ok {
-		listenerT.EnterColumnIdentifier(s)
-	}
+type DurationValueContext struct {
+	*antlr.BaseParserRuleContext
+	parser antlr.Parser
 }
 
-func (s *ColumnIdentifierContext) ExitRule(listener antlr.ParseTreeListener) {
-	if listenerT, ok := listener.(TSLListener); ok {
-		listenerT.ExitColumnIdentifier(s)
-	}
+func NewEmptyDurationValueContext() *DurationValueContext {
+	var p = new(DurationValueContext)
+	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(nil, -1)
+	p.RuleIndex = TSLParserRULE_durationValue
+	return p
 }
 
-type AddOpsContext struct {
-	*MathExpContext
-}
+func (*DurationValueContext) IsDurationValueContext() {}
 
-func NewAddOpsContext(parser antlr.Parser, ctx antlr.ParserRuleContext) *AddOpsContext {
-	var p = new(AddOpsContext)
+func NewDurationValueContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *DurationValueContext {
+	var p = new(DurationValueContext)
+
+	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(parent, invokingState)
 
-	p.MathExpContext = NewEmptyMathExpContext()
 	p.parser = parser
-	p.CopyFrom(ctx.(*MathExpContext))
+	p.RuleIndex = TSLParserRULE_durationValue
 
 	return p
 }
 
-func (s *AddOpsContext) GetRuleContext() antlr.RuleContext {
-	return s
-}
-
-func (s *AddOpsContext) AllMathExp() []IMathExpContext {
-	var ts = s.GetTypedRuleContexts(reflect.TypeOf((*IMathExpContext)(nil)).Elem())
-	var tst = make([]IMathExpContext, len(ts))
-
-	for i, t := range ts {
-		if t != nil {
-			tst[i] = t.(IMathExpContext)
-		}
-	}
+func (s *DurationValueContext) GetParser() antlr.Parser { return s.parser }
 
-	return tst
+func (s *DurationValueContext) DURATION_LITERAL() antlr.TerminalNode {
+	return s.GetToken(TSLParserDURATION_LITERAL, 0)
 }
 
-func (s *AddOpsContext) MathExp(i int) IMathExpContext {
-	var t = s.GetTypedRuleContext(reflect.TypeOf((*IMathExpContext)(nil)).Elem(), i)
-
-	if t == nil {
-		return nil
-	}
-
-	return t.(IMathExpContext)
+func (s *DurationValueContext) GetRuleContext() antlr.RuleContext {
+	return s
 }
 
-func (s *AddOpsContext) LiteralValue() ILiteralValueContext {
-	var t = s.GetTypedRuleContext(reflect.TypeOf((*ILiteralValueContext)(nil)).Elem(), 0)
-
-	if t == nil {
-		return nil
-	}
-
-	return t.(ILiteralValueContext)
+func (s *DurationValueContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
+	return antlr.TreesStringTree(s, ruleNames, recog)
 }
 
-func (s *AddOpsContext) EnterRule(listener antlr.ParseTreeListener) {
+func (s *DurationValueContext) EnterRule(listener antlr.ParseTreeListener) {
 	if listenerT, ok := listener.(TSLListener); ok {
-		listenerT.EnterAddOps(s)
+		listenerT.EnterDurationValue(s)
 	}
 }
 
-func (s *AddOpsContext) ExitRule(listener antlr.ParseTreeListener) {
+func (s *DurationValueContext) ExitRule(listener antlr.ParseTreeListener) {
 	if listenerT, ok := listener.(TSLListener); ok {
-		listenerT.ExitAddOps(s)
+		listenerT.ExitDurationValue(s)
 	}
 }
 
-func (p *TSLParser) MathExp() (localctx IMathExpContext) {
-	return p.mathExp(0)
-}
-
-func (p *TSLParser) mathExp(_p int) (localctx IMathExpContext) {
-	var _parentctx antlr.ParserRuleContext = p.GetParserRuleContext()
-	_parentState := p.GetState()
-	localctx = NewMathExpContext(p, p.GetParserRuleContext(), _parentState)
-	var _prevctx IMathExpContext = localctx
-	var _ antlr.ParserRuleContext = _prevctx // TODO: To prevent unused variable warning.
-	_startState := 16
-	p.EnterRecursionRule(localctx, 16, TSLParserRULE_mathExp, _p)
+func (p *TSLParser) DurationValue() (localctx IDurationValueContext) {
+	localctx = NewDurationValueContext(p, p.GetParserRuleContext(), p.GetState())
+	p.EnterRule(localctx, 44, TSLParserRULE_durationValue)
+	var _la int
 
 	defer func() {
-		p.UnrollRecursionContexts(_parentctx)
+		p.ExitRule()
 	}()
 
 	defer func() {
@@ -2537,294 +5148,224 @@ func (p *TSLParser) mathExp(_p int) (localctx IMathExpContext) {
 		}
 	}()
 
-	var _alt int
-
 	p.EnterOuterAlt(localctx, 1)
-	p.SetState(135)
+	p.SetState(327)
 	p.GetErrorHandler().Sync(p)
+	_la = p.GetTokenStream().LA(1)
 
-	switch p.GetTokenStream().LA(1) {
-	case TSLParserIDENTIFIER:
-		localctx = NewColumnIdentifierContext(p, localctx)
-		p.SetParserRuleContext(localctx)
-		_prevctx = localctx
-
-		{
-			p.SetState(130)
-			p.ColumnName()
-		}
-
-	case TSLParserT__0:
-		localctx = NewMathParContext(p, localctx)
-		p.SetParserRuleContext(localctx)
-		_prevctx = localctx
-		{
-			p.SetState(131)
-			p.Match(TSLParserT__0)
-		}
-		{
-			p.SetState(132)
-			p.mathExp(0)
-		}
+	if _la == TSLParserT__16 || _la == TSLParserT__17 {
 		{
-			p.SetState(133)
-			p.Match(TSLParserT__2)
-		}
-
-	default:
-		panic(antlr.NewNoViableAltException(p, nil, nil, nil, nil, nil))
-	}
-	p.GetParserRuleContext().SetStop(p.GetTokenStream().LT(-1))
-	p.SetState(169)
-	p.GetErrorHandler().Sync(p)
-	_alt = p.GetInterpreter().AdaptivePredict(p.GetTokenStream(), 21, p.GetParserRuleContext())
+			p.SetState(326)
+			_la = p.GetTokenStream().LA(1)
 
-	for _alt != 2 && _alt != antlr.ATNInvalidAltNumber {
-		if _alt == 1 {
-			if p.GetParseListeners() != nil {
-				p.TriggerExitRuleEvent()
+			if !(_la == TSLParserT__16 || _la == TSLParserT__17) {
+				p.GetErrorHandler().RecoverInline(p)
+			} else {
+				p.GetErrorHandler().ReportMatch(p)
+				p.Consume()
 			}
-			_prevctx = localctx
-			p.SetState(167)
-			p.GetErrorHandler().Sync(p)
-			switch p.GetInterpreter().AdaptivePredict(p.GetTokenStream(), 20, p.GetParserRuleContext()) {
-			case 1:
-				localctx = NewMulOpsContext(p, NewMathExpContext(p, _parentctx, _parentState))
-				p.PushNewRecursionContext(localctx, _startState, TSLParserRULE_mathExp)
-				p.SetState(137)
-
-				if !(p.Precpred(p.GetParserRuleContext(), 6)) {
-					panic(antlr.NewFailedPredicateException(p, "p.Precpred(p.GetParserRuleContext(), 6)", ""))
-				}
-				{
-					p.SetState(138)
-					p.Match(TSLParserT__13)
-				}
-				p.SetState(141)
-				p.GetErrorHandler().Sync(p)
-
-				switch p.GetTokenStream().LA(1) {
-				case TSLParserT__16, TSLParserT__17, TSLParserNUMERIC_LITERAL, TSLParserSTRING_LITERAL:
-					{
-						p.SetState(139)
-						p.LiteralValue()
-					}
+		}
 
-				case TSLParserT__0, TSLParserIDENTIFIER:
-					{
-						p.SetState(140)
-						p.mathExp(0)
-					}
+	}
+	{
+		p.SetState(329)
+		p.Match(TSLParserDURATION_LITERAL)
+	}
 
-				default:
-					panic(antlr.NewNoViableAltException(p, nil, nil, nil, nil, nil))
-				}
+	return localctx
+}
 
-			case 2:
-				localctx = NewDivOpsContext(p, NewMathExpContext(p, _parentctx, _parentState))
-				p.PushNewRecursionContext(localctx, _startState, TSLParserRULE_mathExp)
-				p.SetState(143)
+// IDistanceContext is an interface to support dynamic dispatch.
+type IDistanceContext interface {
+	antlr.ParserRuleContext
 
-				if !(p.Precpred(p.GetParserRuleContext(), 5)) {
-					panic(antlr.NewFailedPredicateException(p, "p.Precpred(p.GetParserRuleContext(), 5)", ""))
-				}
-				{
-					p.SetState(144)
-					p.Match(TSLParserT__14)
-				}
-				p.SetState(147)
-				p.GetErrorHandler().Sync(p)
+	// GetParser returns the parser.
+	GetParser() antlr.Parser
 
-				switch p.GetTokenStream().LA(1) {
-				case TSLParserT__16, TSLParserT__17, TSLParserNUMERIC_LITERAL, TSLParserSTRING_LITERAL:
-					{
-						p.SetState(145)
-						p.LiteralValue()
-					}
+	// IsDistanceContext differentiates from other interfaces.
+	IsDistanceContext()
+}
 
-				case TSLParserT__0, TSLParserIDENTIFIER:
-					{
-						p.SetState(146)
-						p.mathExp(0)
-					}
+type DistanceContext struct {
+	*antlr.BaseParserRuleContext
+	parser antlr.Parser
+}
 
-				default:
-					panic(antlr.NewNoViableAltException(p, nil, nil, nil, nil, nil))
-				}
+func NewEmptyDistanceContext() *DistanceContext {
+	var p = new(DistanceContext)
+	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(nil, -1)
+	p.RuleIndex = TSLParserRULE_distance
+	return p
+}
 
-			case 3:
-				localctx = NewModOpsContext(p, NewMathExpContext(p, _parentctx, _parentState))
-				p.PushNewRecursionContext(localctx, _startState, TSLParserRULE_mathExp)
-				p.SetState(149)
+func (*DistanceContext) IsDistanceContext() {}
 
-				if !(p.Precpred(p.GetParserRuleContext(), 4)) {
-					panic(antlr.NewFailedPredicateException(p, "p.Precpred(p.GetParserRuleContext(), 4)", ""))
-				}
-				{
-					p.SetState(150)
-					p.Match(TSLParserT__15)
-				}
-				p.SetState(153)
-				p.GetErrorHandler().Sync(p)
+func NewDistanceContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *DistanceContext {
+	var p = new(DistanceContext)
 
-				switch p.GetTokenStream().LA(1) {
-				case TSLParserT__16, TSLParserT__17, TSLParserNUMERIC_LITERAL, TSLParserSTRING_LITERAL:
-					{
-						p.SetState(151)
-						p.LiteralValue()
-					}
+	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(parent, invokingState)
 
-				case TSLParserT__0, TSLParserIDENTIFIER:
-					{
-						p.SetState(152)
-						p.mathExp(0)
-					}
+	p.parser = parser
+	p.RuleIndex = TSLParserRULE_distance
 
-				default:
-					panic(antlr.NewNoViableAltException(p, nil, nil, nil, nil, nil))
-				}
+	return p
+}
 
-			case 4:
-				localctx = NewAddOpsContext(p, NewMathExpContext(p, _parentctx, _parentState))
-				p.PushNewRecursionContext(localctx, _startState, TSLParserRULE_mathExp)
-				p.SetState(155)
+func (s *DistanceContext) GetParser() antlr.Parser { return s.parser }
 
-				if !(p.Precpred(p.GetParserRuleContext(), 3)) {
-					panic(antlr.NewFailedPredicateException(p, "p.Precpred(p.GetParserRuleContext(), 3)", ""))
-				}
-				{
-					p.SetState(156)
-					p.Match(TSLParserT__16)
-				}
-				p.SetState(159)
-				p.GetErrorHandler().Sync(p)
+func (s *DistanceContext) NUMERIC_LITERAL() antlr.TerminalNode {
+	return s.GetToken(TSLParserNUMERIC_LITERAL, 0)
+}
 
-				switch p.GetTokenStream().LA(1) {
-				case TSLParserT__16, TSLParserT__17, TSLParserNUMERIC_LITERAL, TSLParserSTRING_LITERAL:
-					{
-						p.SetState(157)
-						p.LiteralValue()
-					}
+func (s *DistanceContext) DISTANCE_LITERAL() antlr.TerminalNode {
+	return s.GetToken(TSLParserDISTANCE_LITERAL, 0)
+}
 
-				case TSLParserT__0, TSLParserIDENTIFIER:
-					{
-						p.SetState(158)
-						p.mathExp(0)
-					}
+func (s *DistanceContext) DURATION_LITERAL() antlr.TerminalNode {
+	return s.GetToken(TSLParserDURATION_LITERAL, 0)
+}
 
-				default:
-					panic(antlr.NewNoViableAltException(p, nil, nil, nil, nil, nil))
-				}
+func (s *DistanceContext) GetRuleContext() antlr.RuleContext {
+	return s
+}
 
-			case 5:
-				localctx = NewSubOpsContext(p, NewMathExpContext(p, _parentctx, _parentState))
-				p.PushNewRecursionContext(localctx, _startState, TSLParserRULE_mathExp)
-				p.SetState(161)
+func (s *DistanceContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
+	return antlr.TreesStringTree(s, ruleNames, recog)
+}
 
-				if !(p.Precpred(p.GetParserRuleContext(), 2)) {
-					panic(antlr.NewFailedPredicateException(p, "p.Precpred(p.GetParserRuleContext(), 2)", ""))
-				}
-				{
-					p.SetState(162)
-					p.Match(TSLParserT__17)
-				}
-				p.SetState(165)
-				p.GetErrorHandler().Sync(p)
+func (s *DistanceContext) EnterRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(TSLListener); ok {
+		listenerT.EnterDistance(s)
+	}
+}
 
-				switch p.GetTokenStream().LA(1) {
-				case TSLParserT__16, TSLParserT__17, TSLParserNUMERIC_LITERAL, TSLParserSTRING_LITERAL:
-					{
-						p.SetState(163)
-						p.LiteralValue()
-					}
+func (s *DistanceContext) ExitRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(TSLListener); ok {
+		listenerT.ExitDistance(s)
+	}
+}
 
-				case TSLParserT__0, TSLParserIDENTIFIER:
-					{
-						p.SetState(164)
-						p.mathExp(0)
-					}
+func (p *TSLParser) Distance() (localctx IDistanceContext) {
+	localctx = NewDistanceContext(p, p.GetParserRuleContext(), p.GetState())
+	p.EnterRule(localctx, 46, TSLParserRULE_distance)
+	var _la int
 
-				default:
-					panic(antlr.NewNoViableAltException(p, nil, nil, nil, nil, nil))
-				}
+	defer func() {
+		p.ExitRule()
+	}()
 
+	defer func() {
+		if err := recover(); err != nil {
+			if v, ok := err.(antlr.RecognitionException); ok {
+				localctx.SetException(v)
+				p.GetErrorHandler().ReportError(p, v)
+				p.GetErrorHandler().Recover(p, v)
+			} else {
+				panic(err)
 			}
+		}
+	}()
+
+	p.EnterOuterAlt(localctx, 1)
+	{
+		p.SetState(331)
+		_la = p.GetTokenStream().LA(1)
 
+		if !(((_la-46)&-(0x1f+1)) == 0 && ((1<<uint((_la-46)))&((1<<(TSLParserNUMERIC_LITERAL-46))|(1<<(TSLParserDURATION_LITERAL-46))|(1<<(TSLParserDISTANCE_LITERAL-46)))) != 0) {
+			p.GetErrorHandler().RecoverInline(p)
+		} else {
+			p.GetErrorHandler().ReportMatch(p)
+			p.Consume()
 		}
-		p.SetState(171)
-		p.GetErrorHandler().Sync(p)
-		_alt = p.GetInterpreter().AdaptivePredict(p.GetTokenStream(), 21, p.GetParserRuleContext())
 	}
 
 	return localctx
 }
 
-// ISignedNumberContext is an interface to support dynamic dispatch.
-type ISignedNumberContext interface {
+// IPointContext is an interface to support dynamic dispatch.
+type IPointContext interface {
 	antlr.ParserRuleContext
 
 	// GetParser returns the parser.
 	GetParser() antlr.Parser
 
-	// IsSignedNumberContext differentiates from other interfaces.
-	IsSignedNumberContext()
+	// IsPointContext differentiates from other interfaces.
+	IsPointContext()
 }
 
-type SignedNumberContext struct {
+type PointContext struct {
 	*antlr.BaseParserRuleContext
 	parser antlr.Parser
 }
 
-func NewEmptySignedNumberContext() *SignedNumberContext {
-	var p = new(SignedNumberContext)
+func NewEmptyPointContext() *PointContext {
+	var p = new(PointContext)
 	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(nil, -1)
-	p.RuleIndex = TSLParserRULE_signedNumber
+	p.RuleIndex = TSLParserRULE_point
 	return p
 }
 
-func (*SignedNumberContext) IsSignedNumberContext() {}
+func (*PointContext) IsPointContext() {}
 
-func NewSignedNumberContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *SignedNumberContext {
-	var p = new(SignedNumberContext)
+func NewPointContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *PointContext {
+	var p = new(PointContext)
 
 	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(parent, invokingState)
 
 	p.parser = parser
-	p.RuleIndex = TSLParserRULE_signedNumber
+	p.RuleIndex = TSLParserRULE_point
 
 	return p
 }
 
-func (s *SignedNumberContext) GetParser() antlr.Parser { return s.parser }
+func (s *PointContext) GetParser() antlr.Parser { return s.parser }
 
-func (s *SignedNumberContext) NUMERIC_LITERAL() antlr.TerminalNode {
-	return s.GetToken(TSLParserNUMERIC_LITERAL, 0)
+func (s *PointContext) AllLiteralValue() []ILiteralValueContext {
+	var ts = s.GetTypedRuleContexts(reflect.TypeOf((*ILiteralValueContext)(nil)).Elem())
+	var tst = make([]ILiteralValueContext, len(ts))
+
+	for i, t := range ts {
+		if t != nil {
+			tst[i] = t.(ILiteralValueContext)
+		}
+	}
+
+	return tst
 }
 
-func (s *SignedNumberContext) GetRuleContext() antlr.RuleContext {
+func (s *PointContext) LiteralValue(i int) ILiteralValueContext {
+	var t = s.GetTypedRuleContext(reflect.TypeOf((*ILiteralValueContext)(nil)).Elem(), i)
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(ILiteralValueContext)
+}
+
+func (s *PointContext) GetRuleContext() antlr.RuleContext {
 	return s
 }
 
-func (s *SignedNumberContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
+func (s *PointContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
 	return antlr.TreesStringTree(s, ruleNames, recog)
 }
 
-func (s *SignedNumberContext) EnterRule(listener antlr.ParseTreeListener) {
+func (s *PointContext) EnterRule(listener antlr.ParseTreeListener) {
 	if listenerT, ok := listener.(TSLListener); ok {
-		listenerT.EnterSignedNumber(s)
+		listenerT.EnterPoint(s)
 	}
 }
 
-func (s *SignedNumberContext) ExitRule(listener antlr.ParseTreeListener) {
+func (s *PointContext) ExitRule(listener antlr.ParseTreeListener) {
 	if listenerT, ok := listener.(TSLListener); ok {
-		listenerT.ExitSignedNumber(s)
+		listenerT.ExitPoint(s)
 	}
 }
 
-func (p *TSLParser) SignedNumber() (localctx ISignedNumberContext) {
-	localctx = NewSignedNumberContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 18, TSLParserRULE_signedNumber)
-	var _la int
+func (p *TSLParser) Point() (localctx IPointContext) {
+	localctx = NewPointContext(p, p.GetParserRuleContext(), p.GetState())
+	p.EnterRule(localctx, 48, TSLParserRULE_point)
 
 	defer func() {
 		p.ExitRule()
@@ -2843,97 +5384,114 @@ func (p *TSLParser) SignedNumber() (localctx ISignedNumberContext) {
 	}()
 
 	p.EnterOuterAlt(localctx, 1)
-	p.SetState(173)
-	p.GetErrorHandler().Sync(p)
-	_la = p.GetTokenStream().LA(1)
-
-	if _la == TSLParserT__16 || _la == TSLParserT__17 {
-		{
-			p.SetState(172)
-			_la = p.GetTokenStream().LA(1)
-
-			if !(_la == TSLParserT__16 || _la == TSLParserT__17) {
-				p.GetErrorHandler().RecoverInline(p)
-			} else {
-				p.GetErrorHandler().ReportMatch(p)
-				p.Consume()
-			}
-		}
-
+	{
+		p.SetState(333)
+		p.Match(TSLParserT__1)
 	}
 	{
-		p.SetState(175)
-		p.Match(TSLParserNUMERIC_LITERAL)
+		p.SetState(334)
+		p.LiteralValue()
+	}
+	{
+		p.SetState(335)
+		p.Match(TSLParserT__0)
+	}
+	{
+		p.SetState(336)
+		p.LiteralValue()
+	}
+	{
+		p.SetState(337)
+		p.Match(TSLParserT__2)
 	}
 
 	return localctx
 }
 
-// IStringValueContext is an interface to support dynamic dispatch.
-type IStringValueContext interface {
+// IBoxContext is an interface to support dynamic dispatch.
+type IBoxContext interface {
 	antlr.ParserRuleContext
 
 	// GetParser returns the parser.
 	GetParser() antlr.Parser
 
-	// IsStringValueContext differentiates from other interfaces.
-	IsStringValueContext()
+	// IsBoxContext differentiates from other interfaces.
+	IsBoxContext()
 }
 
-type StringValueContext struct {
+type BoxContext struct {
 	*antlr.BaseParserRuleContext
 	parser antlr.Parser
 }
 
-func NewEmptyStringValueContext() *StringValueContext {
-	var p = new(StringValueContext)
+func NewEmptyBoxContext() *BoxContext {
+	var p = new(BoxContext)
 	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(nil, -1)
-	p.RuleIndex = TSLParserRULE_stringValue
+	p.RuleIndex = TSLParserRULE_box
 	return p
 }
 
-func (*StringValueContext) IsStringValueContext() {}
+func (*BoxContext) IsBoxContext() {}
 
-func NewStringValueContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *StringValueContext {
-	var p = new(StringValueContext)
+func NewBoxContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *BoxContext {
+	var p = new(BoxContext)
 
 	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(parent, invokingState)
 
 	p.parser = parser
-	p.RuleIndex = TSLParserRULE_stringValue
+	p.RuleIndex = TSLParserRULE_box
 
 	return p
 }
 
-func (s *StringValueContext) GetParser() antlr.Parser { return s.parser }
+func (s *BoxContext) GetParser() antlr.Parser { return s.parser }
 
-func (s *StringValueContext) STRING_LITERAL() antlr.TerminalNode {
-	return s.GetToken(TSLParserSTRING_LITERAL, 0)
+func (s *BoxContext) AllLiteralValue() []ILiteralValueContext {
+	var ts = s.GetTypedRuleContexts(reflect.TypeOf((*ILiteralValueContext)(nil)).Elem())
+	var tst = make([]ILiteralValueContext, len(ts))
+
+	for i, t := range ts {
+		if t != nil {
+			tst[i] = t.(ILiteralValueContext)
+		}
+	}
+
+	return tst
 }
 
-func (s *StringValueContext) GetRuleContext() antlr.RuleContext {
+func (s *BoxContext) LiteralValue(i int) ILiteralValueContext {
+	var t = s.GetTypedRuleContext(reflect.TypeOf((*ILiteralValueContext)(nil)).Elem(), i)
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(ILiteralValueContext)
+}
+
+func (s *BoxContext) GetRuleContext() antlr.RuleContext {
 	return s
 }
 
-func (s *StringValueContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
+func (s *BoxContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
 	return antlr.TreesStringTree(s, ruleNames, recog)
 }
 
-func (s *StringValueContext) EnterRule(listener antlr.ParseTreeListener) {
+func (s *BoxContext) EnterRule(listener antlr.ParseTreeListener) {
 	if listenerT, ok := listener.(TSLListener); ok {
-		listenerT.EnterStringValue(s)
+		listenerT.EnterBox(s)
 	}
 }
 
-func (s *StringValueContext) ExitRule(listener antlr.ParseTreeListener) {
+func (s *BoxContext) ExitRule(listener antlr.ParseTreeListener) {
 	if listenerT, ok := listener.(TSLListener); ok {
-		listenerT.ExitStringValue(s)
+		listenerT.ExitBox(s)
 	}
 }
 
-func (p *TSLParser) StringValue() (localctx IStringValueContext) {
-	localctx = NewStringValueContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 20, TSLParserRULE_stringValue)
+func (p *TSLParser) Box() (localctx IBoxContext) {
+	localctx = NewBoxContext(p, p.GetParserRuleContext(), p.GetState())
+	p.EnterRule(localctx, 50, TSLParserRULE_box)
 
 	defer func() {
 		p.ExitRule()
@@ -2953,8 +5511,40 @@ func (p *TSLParser) StringValue() (localctx IStringValueContext) {
 
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(177)
-		p.Match(TSLParserSTRING_LITERAL)
+		p.SetState(339)
+		p.Match(TSLParserT__1)
+	}
+	{
+		p.SetState(340)
+		p.LiteralValue()
+	}
+	{
+		p.SetState(341)
+		p.Match(TSLParserT__0)
+	}
+	{
+		p.SetState(342)
+		p.LiteralValue()
+	}
+	{
+		p.SetState(343)
+		p.Match(TSLParserT__0)
+	}
+	{
+		p.SetState(344)
+		p.LiteralValue()
+	}
+	{
+		p.SetState(345)
+		p.Match(TSLParserT__0)
+	}
+	{
+		p.SetState(346)
+		p.LiteralValue()
+	}
+	{
+		p.SetState(347)
+		p.Match(TSLParserT__2)
 	}
 
 	return localctx
@@ -3024,7 +5614,7 @@ func (s *KeyNotContext) ExitRule(listener antlr.ParseTreeListener) {
 
 func (p *TSLParser) KeyNot() (localctx IKeyNotContext) {
 	localctx = NewKeyNotContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 22, TSLParserRULE_keyNot)
+	p.EnterRule(localctx, 52, TSLParserRULE_keyNot)
 
 	defer func() {
 		p.ExitRule()
@@ -3044,7 +5634,7 @@ func (p *TSLParser) KeyNot() (localctx IKeyNotContext) {
 
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(179)
+		p.SetState(349)
 		p.Match(TSLParserK_NOT)
 	}
 
@@ -3053,14 +5643,14 @@ func (p *TSLParser) KeyNot() (localctx IKeyNotContext) {
 
 func (p *TSLParser) Sempred(localctx antlr.RuleContext, ruleIndex, predIndex int) bool {
 	switch ruleIndex {
-	case 1:
+	case 7:
 		var t *ExprContext = nil
 		if localctx != nil {
 			t = localctx.(*ExprContext)
 		}
 		return p.Expr_Sempred(t, predIndex)
 
-	case 8:
+	case 16:
 		var t *MathExpContext = nil
 		if localctx != nil {
 			t = localctx.(*MathExpContext)
@@ -0,0 +1,63 @@
+// Copyright 2018 Yaacov Zamir <kobi.zamir@gmail.com>
+// and other contributors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package policy
+
+import (
+	"github.com/yaacov/tree-search-language/pkg/tsl"
+)
+
+// Constrain wraps a user TSL tree with server enforced constraints.
+//
+// The constraints and the user tree are joined using AND at the root of the
+// returned tree, so OR clauses of the user tree can not override them. An
+// empty user tree (tsl.Node{}) returns only the constraints.
+//
+// Example:
+//  	// Returns the tree of "tenant_id = 'acme' and deleted_at is null and (<user tree>)".
+//  	constraints, err := policy.ParseConstraints("tenant_id = 'acme'", "deleted_at is null")
+//  	tree = policy.Constrain(tree, constraints...)
+//
+func Constrain(tree tsl.Node, constraints ...tsl.Node) tsl.Node {
+	// Join the constraints right to left, keeping their order.
+	for i := len(constraints) - 1; i >= 0; i-- {
+		if tree.Func == "" {
+			tree = constraints[i]
+			continue
+		}
+
+		tree = tsl.Node{
+			Func:  tsl.AndOp,
+			Left:  constraints[i],
+			Right: tree,
+		}
+	}
+
+	return tree
+}
+
+// ParseConstraints parses server side constraint phrases.
+func ParseConstraints(phrases ...string) (constraints []tsl.Node, err error) {
+	for _, phrase := range phrases {
+		var n tsl.Node
+
+		if n, err = tsl.ParseTSL(phrase); err != nil {
+			return
+		}
+		constraints = append(constraints, n)
+	}
+
+	return
+}
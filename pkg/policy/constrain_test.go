@@ -0,0 +1,69 @@
+// Copyright 2018 Yaacov Zamir <kobi.zamir@gmail.com>
+// and other contributors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package policy
+
+import (
+	"testing"
+
+	sq "github.com/Masterminds/squirrel"
+
+	"github.com/yaacov/tree-search-language/pkg/tsl"
+	"github.com/yaacov/tree-search-language/pkg/walkers/sql"
+)
+
+func TestConstrain(t *testing.T) {
+	constraints, err := ParseConstraints("tenant_id = 'acme'", "deleted_at is null")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	user, err := tsl.ParseTSL("name = 'joe' or name = 'jane'")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name string
+		tree tsl.Node
+		want string
+	}{
+		{
+			name: "user tree",
+			tree: user,
+			want: "SELECT * FROM t WHERE (tenant_id = ? AND (deleted_at IS NULL AND (name = ? OR name = ?)))",
+		},
+		{
+			name: "empty user tree",
+			tree: tsl.Node{},
+			want: "SELECT * FROM t WHERE (tenant_id = ? AND deleted_at IS NULL)",
+		},
+	}
+
+	for _, tt := range tests {
+		filter, err := sql.Walk(Constrain(tt.tree, constraints...))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		got, _, err := sq.Select("*").From("t").Where(filter).ToSql()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != tt.want {
+			t.Errorf("%s: got %s, want %s", tt.name, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,59 @@
+// Copyright 2018 Yaacov Zamir <kobi.zamir@gmail.com>
+// and other contributors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package policy
+
+import "fmt"
+
+// FieldDeniedError is raised when a query uses a field the policy does not
+// allow.
+type FieldDeniedError struct {
+	Field string // the denied field name.
+}
+
+func (e FieldDeniedError) Error() string {
+	return fmt.Sprintf("field not allowed: %s", e.Field)
+}
+
+// OperatorDeniedError is raised when a query uses an operator the policy
+// does not allow.
+type OperatorDeniedError struct {
+	Operator string // the denied TSL operator.
+}
+
+func (e OperatorDeniedError) Error() string {
+	return fmt.Sprintf("operator not allowed: %s", e.Operator)
+}
+
+// RegexLimitError is raised when a query regular expression exceeds a policy
+// limit.
+type RegexLimitError struct {
+	Pattern string // the regular expression.
+	Limit   string // the exceeded limit, "length" or "program size".
+	Max     int    // the limit value.
+}
+
+func (e RegexLimitError) Error() string {
+	return fmt.Sprintf("regular expression exceeds %s limit of %d: %s", e.Limit, e.Max, e.Pattern)
+}
+
+// UnknownRoleError is raised when no policy is defined for a role.
+type UnknownRoleError struct {
+	Role string // the role name.
+}
+
+func (e UnknownRoleError) Error() string {
+	return fmt.Sprintf("no policy for role: %s", e.Role)
+}
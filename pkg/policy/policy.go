@@ -0,0 +1,278 @@
+// Copyright 2018 Yaacov Zamir <kobi.zamir@gmail.com>
+// and other contributors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package policy implements field and operator access-control policies for
+// TSL queries.
+//
+// A Policy allows or denies fields and operators, and adds mandatory
+// predicates (e.g. the tenant id) to every query, ApplyPolicy rejects
+// queries breaking the policy and rewrites the allowed ones.
+//
+// Usage:
+//   policies := policy.Policies{
+//       "viewer": {
+//           AllowFields: []string{"title", "author", "spec.*"},
+//           DenyOps:     []string{tsl.RegexOp, tsl.NotRegexOp},
+//       },
+//   }
+//
+//   p := policies["viewer"]
+//   p.Required = []tsl.Node{policy.Equals("tenant_id", tenant)}
+//
+//   // Reject or rewrite the user query.
+//   tree, err = policy.ApplyPolicy(tree, p)
+//
+package policy
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/yaacov/tree-search-language/pkg/tsl"
+)
+
+// Policy describes the fields and operators a tenant or role can use.
+//
+// Field names ending with `.*` match all fields with that prefix, e.g.
+// `spec.*` matches `spec.pages`. Wildcard identifiers, like `user.*`, are
+// denied if they may match a denied field, and allowed only if all the fields
+// they may match are allowed. TSL literal and identifier nodes are not
+// operators.
+//
+// Identifiers are matched the way SQL databases resolve them, quoted parts,
+// like `"password"`, `` `password` `` or `[password]`, are unquoted, and
+// field names are case insensitive.
+type Policy struct {
+	AllowFields []string // if not empty, only these fields are allowed.
+	DenyFields  []string // fields that are not allowed.
+	AllowOps    []string // if not empty, only these operators are allowed.
+	DenyOps     []string // operators that are not allowed.
+
+	// Regular expression limits bound the evaluation cost of queries from
+	// untrusted users, a zero limit is not checked.
+	DenyRegex       bool // regular expression operators are not allowed.
+	MaxRegexLength  int  // maximal regular expression pattern length.
+	MaxRegexProgram int  // maximal compiled regular expression program size.
+
+	// Required predicates are added to every query using AND, they are not
+	// checked against the policy.
+	Required []tsl.Node
+}
+
+// Policies maps tenants or roles to policies.
+type Policies map[string]Policy
+
+// Apply applies the policy of a role to a TSL tree.
+func (p Policies) Apply(role string, tree tsl.Node) (tsl.Node, error) {
+	policy, ok := p[role]
+	if !ok {
+		return tree, UnknownRoleError{Role: role}
+	}
+
+	return ApplyPolicy(tree, policy)
+}
+
+// ApplyPolicy checks that a TSL tree only uses allowed fields and
+// operators, and returns the tree with the required predicates added.
+func ApplyPolicy(tree tsl.Node, p Policy) (tsl.Node, error) {
+	if err := p.Check(tree); err != nil {
+		return tree, err
+	}
+
+	return Constrain(tree, p.Required...), nil
+}
+
+// Check checks that a TSL tree only uses allowed fields and operators.
+func (p Policy) Check(n tsl.Node) error {
+	switch n.Func {
+	case tsl.IdentOp:
+		if !p.allowField(n.Left.(string)) {
+			return FieldDeniedError{Field: n.Left.(string)}
+		}
+		return nil
+	case tsl.StringOp, tsl.DateOp, tsl.NumberOp, tsl.DurationOp, tsl.NowOp, tsl.BooleanOp, tsl.NullOp, tsl.ArrayOp:
+		// This are our leafs.
+		return nil
+	}
+
+	if !p.allowOp(n.Func) {
+		return OperatorDeniedError{Operator: n.Func}
+	}
+
+	// Check regular expression limits.
+	if r, ok := n.Right.(tsl.Node); ok && (n.Func == tsl.RegexOp || n.Func == tsl.NotRegexOp) {
+		if p.DenyRegex {
+			return OperatorDeniedError{Operator: n.Func}
+		}
+		if pattern, ok := r.Left.(string); ok {
+			if err := p.checkRegex(pattern); err != nil {
+				return err
+			}
+		}
+	}
+
+	// Check left and right sides.
+	if l, ok := n.Left.(tsl.Node); ok {
+		if err := p.Check(l); err != nil {
+			return err
+		}
+	}
+	if r, ok := n.Right.(tsl.Node); ok {
+		if err := p.Check(r); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Equals returns a `field = value` predicate, numbers are compared as
+// numbers, booleans as booleans and other values as strings.
+func Equals(field string, value interface{}) tsl.Node {
+	literal := tsl.Node{Func: tsl.StringOp, Left: fmt.Sprintf("%v", value)}
+
+	switch v := value.(type) {
+	case float64:
+		literal = tsl.Node{Func: tsl.NumberOp, Left: v}
+	case int:
+		literal = tsl.Node{Func: tsl.NumberOp, Left: float64(v)}
+	case int64:
+		literal = tsl.Node{Func: tsl.NumberOp, Left: float64(v)}
+	case bool:
+		literal = tsl.Node{Func: tsl.BooleanOp, Left: v}
+	}
+
+	return tsl.Node{
+		Func:  tsl.EqOp,
+		Left:  tsl.Node{Func: tsl.IdentOp, Left: field},
+		Right: literal,
+	}
+}
+
+func (p Policy) allowField(field string) bool {
+	if matchAny(p.DenyFields, field, overlaps) {
+		return false
+	}
+
+	return len(p.AllowFields) == 0 || matchAny(p.AllowFields, field, covers)
+}
+
+func (p Policy) allowOp(op string) bool {
+	if contains(p.DenyOps, op) {
+		return false
+	}
+
+	return len(p.AllowOps) == 0 || contains(p.AllowOps, op)
+}
+
+// matchAny checks if a field matches one of the field patterns.
+func matchAny(patterns []string, field string, match func(pattern, field []string) bool) bool {
+	parts := fieldParts(field)
+	for _, p := range patterns {
+		pattern := fieldParts(p)
+		if strings.Join(pattern, ".") == strings.Join(parts, ".") || match(pattern, parts) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// fieldParts returns the unquoted and lower cased parts of a dotted field
+// identifier, parts can be quoted using double quotes, back ticks or square
+// brackets, and quoted parts may hold dots.
+func fieldParts(field string) []string {
+	parts := []string{}
+
+	var part strings.Builder
+	for i := 0; i < len(field); i++ {
+		c := field[i]
+		switch c {
+		case '.':
+			parts = append(parts, strings.ToLower(part.String()))
+			part.Reset()
+			continue
+		case '"', '`', '[':
+			// Read a quoted part, closing quotes are escaped by doubling them.
+			close := c
+			if c == '[' {
+				close = ']'
+			}
+			for i++; i < len(field); i++ {
+				if field[i] == close {
+					if close == ']' || i+1 >= len(field) || field[i+1] != close {
+						break
+					}
+					i++
+				}
+				part.WriteByte(field[i])
+			}
+			continue
+		}
+		part.WriteByte(c)
+	}
+
+	return append(parts, strings.ToLower(part.String()))
+}
+
+// overlaps checks if a field pattern matches some of the fields of a field
+// identifier, `*` parts of identifiers match any one part, e.g. `user.*`
+// overlaps `user.password`.
+func overlaps(pattern, field []string) bool {
+	return matchParts(pattern, field, func(p, f string) bool {
+		return p == f || f == "*"
+	})
+}
+
+// covers checks if a field pattern matches all the fields of a field
+// identifier, e.g. `user.*` covers `user.*.name` but `user.name` does not
+// cover `user.*`.
+func covers(pattern, field []string) bool {
+	return matchParts(pattern, field, func(p, f string) bool {
+		return p == f && f != "*"
+	})
+}
+
+// matchParts matches the parts of a field pattern and a field identifier,
+// a pattern ending with `*` matches fields with that prefix.
+func matchParts(pattern, field []string, match func(p, f string) bool) bool {
+	if n := len(pattern) - 1; n > 0 && pattern[n] == "*" {
+		if len(field) <= n {
+			return false
+		}
+		pattern, field = pattern[:n], field[:n]
+	}
+	if len(pattern) != len(field) {
+		return false
+	}
+
+	for i := range pattern {
+		if !match(pattern[i], field[i]) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func contains(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+
+	return false
+}
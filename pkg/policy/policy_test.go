@@ -0,0 +1,197 @@
+// Copyright 2018 Yaacov Zamir <kobi.zamir@gmail.com>
+// and other contributors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package policy
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/yaacov/tree-search-language/pkg/tsl"
+)
+
+func TestApplyPolicy(t *testing.T) {
+	p := Policy{
+		AllowFields: []string{"title", "spec.*"},
+		DenyFields:  []string{"spec.secret"},
+		DenyOps:     []string{tsl.RegexOp},
+		Required:    []tsl.Node{Equals("tenant_id", "acme")},
+	}
+
+	tests := []struct {
+		phrase string
+		want   string
+		err    error
+	}{
+		{phrase: "title = 'a' or spec.pages > 5", want: "tenant_id = 'acme' and (title = 'a' or spec.pages > 5)"},
+		{phrase: "author = 'joe'", err: FieldDeniedError{Field: "author"}},
+		{phrase: "spec.secret = 'x'", err: FieldDeniedError{Field: "spec.secret"}},
+		{phrase: "title ~= 'a.*'", err: OperatorDeniedError{Operator: tsl.RegexOp}},
+		{phrase: "spec.*.pages > 5", want: "tenant_id = 'acme' and spec.*.pages > 5"},
+		{phrase: "spec.* = 'x'", err: FieldDeniedError{Field: "spec.*"}},
+		{phrase: "title.* = 'a'", err: FieldDeniedError{Field: "title.*"}},
+	}
+
+	for _, tt := range tests {
+		tree, err := tsl.ParseTSL(tt.phrase)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		got, err := ApplyPolicy(tree, p)
+		if err != tt.err {
+			t.Errorf("ApplyPolicy(%s) error = %v, want %v", tt.phrase, err, tt.err)
+			continue
+		}
+		if err != nil {
+			continue
+		}
+
+		want, err := tsl.ParseTSL(tt.want)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("ApplyPolicy(%s) = %v, want %v", tt.phrase, got, want)
+		}
+	}
+}
+
+func TestWildcardFields(t *testing.T) {
+	p := Policy{DenyFields: []string{"user.password"}}
+
+	tests := []struct {
+		phrase string
+		err    error
+	}{
+		{phrase: "user.name = 'joe'"},
+		{phrase: "user.password = 'hunter2'", err: FieldDeniedError{Field: "user.password"}},
+		{phrase: "user.* = 'hunter2'", err: FieldDeniedError{Field: "user.*"}},
+		{phrase: "user.* in ('a', 'hunter2')", err: FieldDeniedError{Field: "user.*"}},
+		{phrase: "user.*.password = 'hunter2'"},
+	}
+
+	for _, tt := range tests {
+		tree, err := tsl.ParseTSL(tt.phrase)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if err := p.Check(tree); err != tt.err {
+			t.Errorf("Check(%s) error = %v, want %v", tt.phrase, err, tt.err)
+		}
+	}
+}
+
+func TestQuotedFields(t *testing.T) {
+	p := Policy{DenyFields: []string{"password", "user.*"}}
+
+	tests := []struct {
+		phrase string
+		err    error
+	}{
+		{phrase: "name = 'x'"},
+		{phrase: `"password" = 'x'`, err: FieldDeniedError{Field: `"password"`}},
+		{phrase: "`password` = 'x'", err: FieldDeniedError{Field: "`password`"}},
+		{phrase: "[password] = 'x'", err: FieldDeniedError{Field: "[password]"}},
+		{phrase: "PASSWORD = 'x'", err: FieldDeniedError{Field: "PASSWORD"}},
+		{phrase: `"PassWord" = 'x'`, err: FieldDeniedError{Field: `"PassWord"`}},
+		{phrase: `"user".name = 'x'`, err: FieldDeniedError{Field: `"user".name`}},
+		{phrase: "`user`.`name` = 'x'", err: FieldDeniedError{Field: "`user`.`name`"}},
+		{phrase: "[USER].name = 'x'", err: FieldDeniedError{Field: "[USER].name"}},
+		{phrase: "User.Name = 'x'", err: FieldDeniedError{Field: "User.Name"}},
+		{phrase: `"user.name" = 'x'`},
+		{phrase: `"pass""word" = 'x'`},
+	}
+
+	for _, tt := range tests {
+		tree, err := tsl.ParseTSL(tt.phrase)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if err := p.Check(tree); err != tt.err {
+			t.Errorf("Check(%s) error = %v, want %v", tt.phrase, err, tt.err)
+		}
+	}
+
+	// Allowed fields are matched the same way.
+	p = Policy{AllowFields: []string{"title"}}
+	for _, phrase := range []string{`"title" = 'x'`, "TITLE = 'x'", "[Title] = 'x'"} {
+		tree, _ := tsl.ParseTSL(phrase)
+		if err := p.Check(tree); err != nil {
+			t.Errorf("Check(%s) error = %v, want nil", phrase, err)
+		}
+	}
+}
+
+func TestRegexLimits(t *testing.T) {
+	p := Policy{MaxRegexLength: 12, MaxRegexProgram: 40}
+
+	tests := []struct {
+		phrase string
+		err    error
+	}{
+		{phrase: "title ~= '^a.*b$'"},
+		{phrase: "title ~! 'abcdefghijklmn'", err: RegexLimitError{Pattern: "abcdefghijklmn", Limit: "length", Max: 12}},
+		{phrase: "title ~= '(a{1,9}){1,9}'", err: RegexLimitError{Pattern: "(a{1,9}){1,9}", Limit: "length", Max: 12}},
+		{phrase: "title ~= '(a{2,9}){9}'", err: RegexLimitError{Pattern: "(a{2,9}){9}", Limit: "program size", Max: 40}},
+		{phrase: "title like 'abcdefghijklmn'"},
+	}
+
+	for _, tt := range tests {
+		tree, err := tsl.ParseTSL(tt.phrase)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if err := p.Check(tree); err != tt.err {
+			t.Errorf("Check(%s) error = %v, want %v", tt.phrase, err, tt.err)
+		}
+	}
+
+	// Regular expressions can be denied regardless of the allowed operators.
+	tree, err := tsl.ParseTSL("title ~= 'a'")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := (Policy{DenyRegex: true}).Check(tree); err != (OperatorDeniedError{Operator: tsl.RegexOp}) {
+		t.Errorf("expected an operator denied error, got %v", err)
+	}
+}
+
+func TestAllowOps(t *testing.T) {
+	p := Policy{AllowOps: []string{tsl.AndOp, tsl.GtOp, tsl.EqOp}}
+
+	tests := []struct {
+		phrase string
+		err    error
+	}{
+		{phrase: "created > now() - 1d and title = 'a'"},
+		{phrase: "created > date('2020-01-01') + 1d"},
+		{phrase: "title = 'a' or pages > 5", err: OperatorDeniedError{Operator: tsl.OrOp}},
+	}
+
+	for _, tt := range tests {
+		tree, err := tsl.ParseTSL(tt.phrase)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if err := p.Check(tree); err != tt.err {
+			t.Errorf("Check(%s) error = %v, want %v", tt.phrase, err, tt.err)
+		}
+	}
+}
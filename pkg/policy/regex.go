@@ -0,0 +1,50 @@
+// Copyright 2018 Yaacov Zamir <kobi.zamir@gmail.com>
+// and other contributors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package policy
+
+import (
+	"regexp/syntax"
+
+	"github.com/yaacov/tree-search-language/pkg/tsl"
+)
+
+// checkRegex checks that a regular expression is within the policy limits.
+//
+// Go regular expressions match in time linear in the input size, the
+// matching cost grows with the compiled program size, so the program size
+// bounds the cost of repetitions like `(a{1,100}){1,100}` better than the
+// pattern length.
+func (p Policy) checkRegex(pattern string) error {
+	if p.MaxRegexLength > 0 && len(pattern) > p.MaxRegexLength {
+		return RegexLimitError{Pattern: pattern, Limit: "length", Max: p.MaxRegexLength}
+	}
+
+	if p.MaxRegexProgram > 0 {
+		re, err := syntax.Parse(pattern, syntax.Perl)
+		if err != nil {
+			return tsl.RegexError{Pattern: pattern, Msg: err.Error()}
+		}
+		prog, err := syntax.Compile(re.Simplify())
+		if err != nil {
+			return tsl.RegexError{Pattern: pattern, Msg: err.Error()}
+		}
+		if len(prog.Inst) > p.MaxRegexProgram {
+			return RegexLimitError{Pattern: pattern, Limit: "program size", Max: p.MaxRegexProgram}
+		}
+	}
+
+	return nil
+}
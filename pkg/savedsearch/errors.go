@@ -0,0 +1,39 @@
+// Copyright 2018 Yaacov Zamir <kobi.zamir@gmail.com>
+// and other contributors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package savedsearch
+
+import "fmt"
+
+// NotFoundError is raised when a saved search does not exist.
+type NotFoundError struct {
+	Owner string
+	Name  string
+}
+
+func (e NotFoundError) Error() string {
+	return fmt.Sprintf("saved search not found: %s/%s", e.Owner, e.Name)
+}
+
+// MigrationError is raised when a saved search can not be migrated to the
+// current schema version.
+type MigrationError struct {
+	Version int   // the schema version that failed to migrate.
+	Err     error // the migration error.
+}
+
+func (e MigrationError) Error() string {
+	return fmt.Sprintf("failed to migrate from schema version %d: %v", e.Version, e.Err)
+}
@@ -0,0 +1,171 @@
+// Copyright 2018 Yaacov Zamir <kobi.zamir@gmail.com>
+// and other contributors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package savedsearch persists named TSL filters.
+//
+// Searches are validated when saved, and store the schema version they were
+// validated against. When the schema changes, searches saved with older
+// versions are migrated when loaded.
+//
+// Usage:
+//   m := savedsearch.Manager{
+//       Store:         savedsearch.NewMemoryStore(),
+//       Validator:     schema,
+//       SchemaVersion: 2,
+//       Migrations: map[int]savedsearch.Migration{
+//           // Version 1 used the "pages" field, renamed to "spec.pages".
+//           1: savedsearch.RenameField("pages", "spec.pages"),
+//       },
+//   }
+//
+//   s, err := m.Save(ctx, "joe", "long-books", "spec.pages > 500")
+//   s, err = m.Load(ctx, "joe", "long-books")
+//
+package savedsearch
+
+import (
+	"context"
+	"time"
+
+	"github.com/yaacov/tree-search-language/pkg/tsl"
+	"github.com/yaacov/tree-search-language/pkg/walkers/ident"
+)
+
+// Search is a saved search.
+type Search struct {
+	Owner         string    `json:"owner"`
+	Name          string    `json:"name"`
+	Phrase        string    `json:"phrase"`
+	Tree          tsl.Node  `json:"-"`
+	SchemaVersion int       `json:"schemaVersion"`
+	Created       time.Time `json:"created"`
+	Updated       time.Time `json:"updated"`
+}
+
+// Validator validates TSL trees, e.g. a validate schema.
+type Validator interface {
+	Validate(n tsl.Node) error
+}
+
+// Migration migrates a tree from one schema version to the next.
+type Migration func(tree tsl.Node) (tsl.Node, error)
+
+// RenameField returns a migration renaming a field.
+func RenameField(from, to string) Migration {
+	return func(tree tsl.Node) (tsl.Node, error) {
+		return ident.Walk(tree, func(s string) (string, error) {
+			if s == from {
+				return to, nil
+			}
+			return s, nil
+		})
+	}
+}
+
+// Manager validates, saves and migrates searches.
+type Manager struct {
+	Store         Store
+	Validator     Validator // optional.
+	SchemaVersion int       // the current schema version.
+
+	// Migrations maps schema versions to the migration into the next version.
+	Migrations map[int]Migration
+}
+
+// Save parses, validates and saves a search.
+func (m Manager) Save(ctx context.Context, owner, name, phrase string) (Search, error) {
+	tree, err := tsl.ParseTSL(phrase)
+	if err != nil {
+		return Search{}, err
+	}
+	if m.Validator != nil {
+		if err = m.Validator.Validate(tree); err != nil {
+			return Search{}, err
+		}
+	}
+
+	now := time.Now().UTC()
+	s := Search{
+		Owner:         owner,
+		Name:          name,
+		Phrase:        phrase,
+		Tree:          tree,
+		SchemaVersion: m.SchemaVersion,
+		Created:       now,
+		Updated:       now,
+	}
+
+	// Keep the creation time of replaced searches.
+	if old, err := m.Store.Get(ctx, owner, name); err == nil {
+		s.Created = old.Created
+	}
+
+	return s, m.Store.Put(ctx, s)
+}
+
+// Load returns a saved search, searches saved with older schema versions
+// are migrated, validated and saved again.
+func (m Manager) Load(ctx context.Context, owner, name string) (Search, error) {
+	s, err := m.Store.Get(ctx, owner, name)
+	if err != nil || s.SchemaVersion >= m.SchemaVersion {
+		return s, err
+	}
+
+	if s, err = m.migrate(s); err != nil {
+		return s, err
+	}
+
+	return s, m.Store.Put(ctx, s)
+}
+
+// Delete removes a saved search.
+func (m Manager) Delete(ctx context.Context, owner, name string) error {
+	return m.Store.Delete(ctx, owner, name)
+}
+
+// List returns the saved searches of an owner, searches are not migrated.
+func (m Manager) List(ctx context.Context, owner string) ([]Search, error) {
+	return m.Store.List(ctx, owner)
+}
+
+// migrate migrates a search to the current schema version.
+func (m Manager) migrate(s Search) (Search, error) {
+	var err error
+
+	tree := s.Tree
+	for v := s.SchemaVersion; v < m.SchemaVersion; v++ {
+		// Versions without migrations did not change the schema.
+		if migration, ok := m.Migrations[v]; ok {
+			if tree, err = migration(tree); err != nil {
+				return s, MigrationError{Version: v, Err: err}
+			}
+		}
+	}
+
+	if m.Validator != nil {
+		if err = m.Validator.Validate(tree); err != nil {
+			return s, MigrationError{Version: s.SchemaVersion, Err: err}
+		}
+	}
+
+	if s.Phrase, err = Format(tree); err != nil {
+		return s, MigrationError{Version: s.SchemaVersion, Err: err}
+	}
+	s.Tree = tree
+	s.SchemaVersion = m.SchemaVersion
+	s.Updated = time.Now().UTC()
+
+	return s, nil
+}
@@ -0,0 +1,86 @@
+// Copyright 2018 Yaacov Zamir <kobi.zamir@gmail.com>
+// and other contributors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package savedsearch
+
+import (
+	"context"
+	"database/sql"
+	"reflect"
+	"testing"
+
+	_ "github.com/mattn/go-sqlite3"
+
+	"github.com/yaacov/tree-search-language/pkg/tsl"
+	"github.com/yaacov/tree-search-language/pkg/validate"
+)
+
+func TestManager(t *testing.T) {
+	ctx := context.Background()
+
+	db, err := sql.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	store := SQLStore{DB: db}
+	if err := store.CreateTable(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, s := range []Store{NewMemoryStore(), store} {
+		v1 := Manager{
+			Store:         s,
+			Validator:     validate.Schema{"title": {Type: validate.String}, "pages": {Type: validate.Number}},
+			SchemaVersion: 1,
+		}
+		if _, err := v1.Save(ctx, "joe", "long", "title ~= 'go' and pages not between 10 and 500"); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := v1.Save(ctx, "joe", "bad", "author = 'jane'"); err == nil {
+			t.Error("Save() error = nil, want unknown field error")
+		}
+
+		// Version 2 renamed "pages" to "spec.pages".
+		v2 := Manager{
+			Store:         s,
+			Validator:     validate.Schema{"title": {Type: validate.String}, "spec.pages": {Type: validate.Number}},
+			SchemaVersion: 2,
+			Migrations:    map[int]Migration{1: RenameField("pages", "spec.pages")},
+		}
+		got, err := v2.Load(ctx, "joe", "long")
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		want, err := tsl.ParseTSL("title ~= 'go' and spec.pages not between 10 and 500")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got.SchemaVersion != 2 || !reflect.DeepEqual(got.Tree, want) {
+			t.Errorf("Load() = %+v, want migrated tree %v", got, want)
+		}
+
+		// The migrated search is saved.
+		list, err := v2.List(ctx, "joe")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(list) != 1 || list[0].Phrase != "title ~= 'go' and spec.pages not between 10 and 500" {
+			t.Errorf("List() = %+v, want the migrated search", list)
+		}
+	}
+}
@@ -0,0 +1,173 @@
+// Copyright 2018 Yaacov Zamir <kobi.zamir@gmail.com>
+// and other contributors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package savedsearch
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+
+	"github.com/yaacov/tree-search-language/pkg/tsl"
+)
+
+// SQLStore persists saved searches in an SQL table.
+//
+// Searches are stored as phrases, and parsed again when read. The table
+// can be created using CreateTable, or using a similar statement:
+//
+//  CREATE TABLE saved_searches (
+//      owner TEXT NOT NULL,
+//      name TEXT NOT NULL,
+//      phrase TEXT NOT NULL,
+//      schema_version INTEGER NOT NULL,
+//      created TIMESTAMP NOT NULL,
+//      updated TIMESTAMP NOT NULL,
+//      PRIMARY KEY (owner, name)
+//  )
+//
+type SQLStore struct {
+	DB    *sql.DB
+	Table string // defaults to saved_searches.
+
+	// PlaceholderFormat of the queries, defaults to sq.Question.
+	PlaceholderFormat sq.PlaceholderFormat
+}
+
+// CreateTable creates the saved searches table if it does not exist.
+func (s SQLStore) CreateTable(ctx context.Context) error {
+	_, err := s.DB.ExecContext(ctx, "CREATE TABLE IF NOT EXISTS "+s.table()+` (
+		owner TEXT NOT NULL,
+		name TEXT NOT NULL,
+		phrase TEXT NOT NULL,
+		schema_version INTEGER NOT NULL,
+		created TIMESTAMP NOT NULL,
+		updated TIMESTAMP NOT NULL,
+		PRIMARY KEY (owner, name))`)
+
+	return err
+}
+
+// Get implements Store.
+func (s SQLStore) Get(ctx context.Context, owner, name string) (Search, error) {
+	rows, err := s.query(ctx, sq.Eq{"owner": owner, "name": name})
+	if err != nil {
+		return Search{}, err
+	}
+	if len(rows) == 0 {
+		return Search{}, NotFoundError{Owner: owner, Name: name}
+	}
+
+	return rows[0], nil
+}
+
+// Put implements Store.
+func (s SQLStore) Put(ctx context.Context, search Search) error {
+	tx, err := s.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	_, err = s.builder().Delete(s.table()).
+		Where(sq.Eq{"owner": search.Owner, "name": search.Name}).
+		RunWith(tx).ExecContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.builder().Insert(s.table()).
+		Columns("owner", "name", "phrase", "schema_version", "created", "updated").
+		Values(search.Owner, search.Name, search.Phrase, search.SchemaVersion, search.Created, search.Updated).
+		RunWith(tx).ExecContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// Delete implements Store.
+func (s SQLStore) Delete(ctx context.Context, owner, name string) error {
+	res, err := s.builder().Delete(s.table()).
+		Where(sq.Eq{"owner": owner, "name": name}).
+		RunWith(s.DB).ExecContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	if n, err := res.RowsAffected(); err == nil && n == 0 {
+		return NotFoundError{Owner: owner, Name: name}
+	}
+	return nil
+}
+
+// List implements Store.
+func (s SQLStore) List(ctx context.Context, owner string) ([]Search, error) {
+	return s.query(ctx, sq.Eq{"owner": owner})
+}
+
+func (s SQLStore) query(ctx context.Context, where sq.Eq) (list []Search, err error) {
+	rows, err := s.builder().
+		Select("owner", "name", "phrase", "schema_version", "created", "updated").
+		From(s.table()).
+		Where(where).
+		OrderBy("name").
+		RunWith(s.DB).QueryContext(ctx)
+	if err != nil {
+		return
+	}
+	defer rows.Close()
+
+	list = []Search{}
+	for rows.Next() {
+		var search Search
+		var created, updated time.Time
+
+		err = rows.Scan(&search.Owner, &search.Name, &search.Phrase, &search.SchemaVersion, &created, &updated)
+		if err != nil {
+			return
+		}
+		search.Created, search.Updated = created, updated
+
+		// Parse the stored phrase.
+		if search.Tree, err = tsl.ParseTSL(search.Phrase); err != nil {
+			return
+		}
+		list = append(list, search)
+	}
+
+	err = rows.Err()
+	return
+}
+
+func (s SQLStore) builder() sq.StatementBuilderType {
+	format := s.PlaceholderFormat
+	if format == nil {
+		format = sq.Question
+	}
+
+	return sq.StatementBuilder.PlaceholderFormat(format)
+}
+
+func (s SQLStore) table() string {
+	if s.Table == "" {
+		return "saved_searches"
+	}
+
+	return s.Table
+}
@@ -0,0 +1,100 @@
+// Copyright 2018 Yaacov Zamir <kobi.zamir@gmail.com>
+// and other contributors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package savedsearch
+
+import (
+	"context"
+	"sort"
+	"sync"
+)
+
+// Store persists saved searches.
+type Store interface {
+	// Get returns a saved search, or a NotFoundError.
+	Get(ctx context.Context, owner, name string) (Search, error)
+
+	// Put creates or replaces a saved search.
+	Put(ctx context.Context, s Search) error
+
+	// Delete removes a saved search, or returns a NotFoundError.
+	Delete(ctx context.Context, owner, name string) error
+
+	// List returns the saved searches of an owner, sorted by name.
+	List(ctx context.Context, owner string) ([]Search, error)
+}
+
+// MemoryStore is an in memory store, it is safe for concurrent use.
+type MemoryStore struct {
+	mu       sync.RWMutex
+	searches map[[2]string]Search
+}
+
+// NewMemoryStore creates an empty in memory store.
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{searches: map[[2]string]Search{}}
+}
+
+// Get implements Store.
+func (m *MemoryStore) Get(ctx context.Context, owner, name string) (Search, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	s, ok := m.searches[[2]string{owner, name}]
+	if !ok {
+		return s, NotFoundError{Owner: owner, Name: name}
+	}
+
+	return s, nil
+}
+
+// Put implements Store.
+func (m *MemoryStore) Put(ctx context.Context, s Search) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.searches[[2]string{s.Owner, s.Name}] = s
+	return nil
+}
+
+// Delete implements Store.
+func (m *MemoryStore) Delete(ctx context.Context, owner, name string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	key := [2]string{owner, name}
+	if _, ok := m.searches[key]; !ok {
+		return NotFoundError{Owner: owner, Name: name}
+	}
+
+	delete(m.searches, key)
+	return nil
+}
+
+// List implements Store.
+func (m *MemoryStore) List(ctx context.Context, owner string) ([]Search, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	list := []Search{}
+	for key, s := range m.searches {
+		if key[0] == owner {
+			list = append(list, s)
+		}
+	}
+
+	sort.Slice(list, func(i, j int) bool { return list[i].Name < list[j].Name })
+	return list, nil
+}
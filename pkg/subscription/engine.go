@@ -0,0 +1,333 @@
+// Copyright 2018 Yaacov Zamir <kobi.zamir@gmail.com>
+// and other contributors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package subscription implements a reverse matching engine for TSL filters.
+//
+// Many filters are registered in an engine, and for each incoming document
+// the engine finds the filters matching it. Filters are indexed by their
+// equality predicates (e.g. `type = 'order'` or `city in ('rome', 'paris')`)
+// that must hold for the filter to match, and only filters whose indexed
+// predicates hold for the document are evaluated.
+//
+// Usage:
+//   e := subscription.NewEngine()
+//   err := e.Add("big-orders", "type = 'order' and total > 1000")
+//   err = e.Add("rome", "city = 'rome'")
+//
+//   // Find the filters matching a document.
+//   ids, err := e.Match(eval)
+//
+package subscription
+
+import (
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/yaacov/tree-search-language/pkg/tsl"
+	"github.com/yaacov/tree-search-language/pkg/walkers/semantics"
+)
+
+// predicate is an indexed `field = value` predicate.
+type predicate struct {
+	field string
+	value string // the value key, see valueKey.
+}
+
+// filter is a registered filter.
+type filter struct {
+	tree tsl.Node
+	keys []predicate // one of these must hold, empty if not indexed.
+}
+
+// Engine matches documents against registered filters, it is safe for
+// concurrent use.
+type Engine struct {
+	mu        sync.RWMutex
+	filters   map[string]*filter
+	index     map[string]map[string]map[string]bool // field to value key to filter ids.
+	unindexed map[string]bool                       // filters evaluated for every document.
+}
+
+// NewEngine creates an empty engine.
+func NewEngine() *Engine {
+	return &Engine{
+		filters:   map[string]*filter{},
+		index:     map[string]map[string]map[string]bool{},
+		unindexed: map[string]bool{},
+	}
+}
+
+// Add parses a TSL phrase and registers it, replacing a filter with the
+// same id.
+func (e *Engine) Add(id, phrase string) error {
+	tree, err := tsl.ParseTSL(phrase)
+	if err != nil {
+		return err
+	}
+
+	e.AddTree(id, tree)
+	return nil
+}
+
+// AddTree registers a TSL tree, replacing a filter with the same id.
+func (e *Engine) AddTree(id string, tree tsl.Node) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.remove(id)
+
+	keys, _ := indexKeys(tree)
+	e.filters[id] = &filter{tree: tree, keys: keys}
+
+	if len(keys) == 0 {
+		e.unindexed[id] = true
+		return
+	}
+	for _, k := range keys {
+		if e.index[k.field] == nil {
+			e.index[k.field] = map[string]map[string]bool{}
+		}
+		if e.index[k.field][k.value] == nil {
+			e.index[k.field][k.value] = map[string]bool{}
+		}
+		e.index[k.field][k.value][id] = true
+	}
+}
+
+// Remove unregisters a filter.
+func (e *Engine) Remove(id string) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.remove(id)
+}
+
+// Len returns the number of registered filters.
+func (e *Engine) Len() int {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	return len(e.filters)
+}
+
+// Match returns the sorted ids of the filters matching a document.
+func (e *Engine) Match(eval semantics.EvalFunc) (ids []string, err error) {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	// Collect the candidate filters, using the document values of the
+	// indexed fields.
+	candidates := map[string]bool{}
+	for id := range e.unindexed {
+		candidates[id] = true
+	}
+	for field, values := range e.index {
+		v, ok := eval(field)
+		if !ok {
+			continue
+		}
+		keys, ok := docKeys(v)
+		if !ok {
+			// Values without a key may match any filter on the field.
+			for _, ids := range values {
+				for id := range ids {
+					candidates[id] = true
+				}
+			}
+			continue
+		}
+		for _, key := range keys {
+			for id := range values[key] {
+				candidates[id] = true
+			}
+		}
+	}
+
+	// Evaluate the candidates.
+	ids = []string{}
+	for id := range candidates {
+		var match bool
+
+		if match, err = semantics.Walk(e.filters[id].tree, eval); err != nil {
+			return
+		}
+		if match {
+			ids = append(ids, id)
+		}
+	}
+
+	sort.Strings(ids)
+	return
+}
+
+// remove unregisters a filter, the caller must hold the lock.
+func (e *Engine) remove(id string) {
+	f, ok := e.filters[id]
+	if !ok {
+		return
+	}
+
+	delete(e.filters, id)
+	delete(e.unindexed, id)
+	for _, k := range f.keys {
+		delete(e.index[k.field][k.value], id)
+		if len(e.index[k.field][k.value]) == 0 {
+			delete(e.index[k.field], k.value)
+		}
+		if len(e.index[k.field]) == 0 {
+			delete(e.index, k.field)
+		}
+	}
+}
+
+// indexKeys returns equality predicates of a tree, one of them must hold
+// for the tree to match a document.
+func indexKeys(n tsl.Node) ([]predicate, bool) {
+	switch n.Func {
+	case tsl.EqOp, tsl.InOp:
+		l, ok := n.Left.(tsl.Node)
+		if !ok || l.Func != tsl.IdentOp {
+			return nil, false
+		}
+
+		r := n.Right.(tsl.Node)
+		values := []tsl.Node{r}
+		if r.Func == tsl.ArrayOp {
+			values = r.Right.([]tsl.Node)
+		}
+
+		keys := []predicate{}
+		for _, v := range values {
+			literal, ok := literalKeys(v)
+			if !ok {
+				return nil, false
+			}
+			for _, key := range literal {
+				keys = append(keys, predicate{field: l.Left.(string), value: key})
+			}
+		}
+		return keys, true
+	case tsl.AndOp:
+		// One side must hold, prefer the side with less keys.
+		l, lok := indexKeys(n.Left.(tsl.Node))
+		r, rok := indexKeys(n.Right.(tsl.Node))
+		if lok && (!rok || len(l) <= len(r)) {
+			return l, true
+		}
+		return r, rok
+	case tsl.OrOp:
+		// Either side can hold, both sides must be indexed.
+		l, lok := indexKeys(n.Left.(tsl.Node))
+		r, rok := indexKeys(n.Right.(tsl.Node))
+		if lok && rok {
+			return append(l, r...), true
+		}
+	}
+
+	return nil, false
+}
+
+// literalKeys returns the index keys of a literal, date literals match equal
+// strings and times at the same instant.
+func literalKeys(n tsl.Node) ([]string, bool) {
+	switch n.Func {
+	case tsl.StringOp, tsl.NumberOp, tsl.DurationOp:
+		key, ok := valueKey(n.Left)
+		return []string{key}, ok
+	case tsl.DateOp:
+		keys := []string{"s" + n.Left.(string)}
+		if t, err := tsl.Date(n); err == nil {
+			key, _ := valueKey(t)
+			keys = append(keys, key)
+		}
+		return keys, true
+	}
+
+	// Only fixed literals are indexed, now() literals are not.
+	return nil, false
+}
+
+// docKeys returns the index keys of a document value, list values have the
+// keys of each of their elements, like semantics.Walk matches any of them.
+// It returns false if some value has no key.
+func docKeys(v interface{}) ([]string, bool) {
+	list := []interface{}{v}
+	switch v := v.(type) {
+	case nil:
+		return nil, true
+	case []interface{}:
+		list = v
+	case []string:
+		keys := make([]string, len(v))
+		for i, s := range v {
+			keys[i], _ = valueKey(s)
+		}
+		return keys, true
+	case []float64:
+		keys := make([]string, len(v))
+		for i, f := range v {
+			keys[i], _ = valueKey(f)
+		}
+		return keys, true
+	}
+
+	keys := []string{}
+	for _, e := range list {
+		if e == nil {
+			continue
+		}
+		key, ok := valueKey(e)
+		if !ok {
+			return nil, false
+		}
+		keys = append(keys, key)
+	}
+
+	return keys, true
+}
+
+// valueKey returns the index key of a string, number, duration or time
+// value, numbers and durations are keyed by their float64 seconds, like
+// semantics.Walk compares them.
+func valueKey(v interface{}) (string, bool) {
+	switch v := v.(type) {
+	case string:
+		return "s" + v, true
+	case time.Time:
+		return "t" + v.UTC().Format(time.RFC3339Nano), true
+	case time.Duration:
+		return valueKey(v.Seconds())
+	case float64:
+		return "n" + strconv.FormatFloat(v, 'g', -1, 64), true
+	case float32:
+		return valueKey(float64(v))
+	case int:
+		return valueKey(float64(v))
+	case int32:
+		return valueKey(float64(v))
+	case int64:
+		return valueKey(float64(v))
+	case uint:
+		return valueKey(float64(v))
+	case uint32:
+		return valueKey(float64(v))
+	case uint64:
+		return valueKey(float64(v))
+	}
+
+	return "", false
+}
@@ -0,0 +1,139 @@
+// Copyright 2018 Yaacov Zamir <kobi.zamir@gmail.com>
+// and other contributors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package subscription
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/yaacov/tree-search-language/pkg/tsl"
+	"github.com/yaacov/tree-search-language/pkg/walkers/semantics"
+)
+
+func evalFactory(doc map[string]interface{}) semantics.EvalFunc {
+	return func(k string) (interface{}, bool) {
+		v, ok := doc[k]
+		return v, ok
+	}
+}
+
+func TestEngine(t *testing.T) {
+	filters := map[string]string{
+		"orders":     "type = 'order'",
+		"big-orders": "type = 'order' and total > 1000",
+		"cities":     "city in ('rome', 'paris') or type = 'trip'",
+		"totals":     "total > 500",
+		"not-rome":   "city != 'rome'",
+		"count":      "count = 3",
+	}
+
+	e := NewEngine()
+	for id, phrase := range filters {
+		if err := e.Add(id, phrase); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	docs := []map[string]interface{}{
+		{"type": "order", "total": 2000, "city": "rome"},
+		{"type": "trip", "total": 100, "city": "london"},
+		{"type": "order", "total": 600.0, "city": "paris", "count": int64(3)},
+		{},
+	}
+
+	for _, doc := range docs {
+		got, err := e.Match(evalFactory(doc))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if want := walkAll(filters, doc); !reflect.DeepEqual(got, want) {
+			t.Errorf("Match(%v) = %v, want %v", doc, got, want)
+		}
+	}
+
+	e.Remove("orders")
+	if got, _ := e.Match(evalFactory(docs[0])); fmt.Sprint(got) != "[big-orders cities totals]" {
+		t.Errorf("Match() after Remove = %v", got)
+	}
+}
+
+func TestEngineValues(t *testing.T) {
+	filters := map[string]string{
+		"tag-x":     "tags = 'x'",
+		"tag-in":    "tags in ('z', 'y')",
+		"up-5m":     "up = 5m",
+		"up-300":    "up = 300",
+		"date":      "d = 2020-01-01",
+		"date-text": "d = '2020-01-01'",
+		"id":        "id = 9007199254740993",
+	}
+
+	e := NewEngine()
+	for id, phrase := range filters {
+		if err := e.Add(id, phrase); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	docs := []map[string]interface{}{
+		{"tags": []string{"x", "y"}, "up": 5 * time.Minute, "d": time.Date(2020, 1, 1, 2, 0, 0, 0, time.FixedZone("", 7200))},
+		{"tags": []interface{}{"y", nil}, "up": 300, "d": "2020-01-01", "id": int64(9007199254740993)},
+		{"tags": []interface{}{"x"}, "up": time.Second, "d": time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC), "id": uint64(9007199254740993)},
+	}
+
+	for _, doc := range docs {
+		got, err := e.Match(evalFactory(doc))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if want := walkAll(filters, doc); !reflect.DeepEqual(got, want) {
+			t.Errorf("Match(%v) = %v, want %v", doc, got, want)
+		}
+	}
+}
+
+// walkAll returns the sorted ids of the filters matching a document,
+// evaluating all the filters.
+func walkAll(filters map[string]string, doc map[string]interface{}) []string {
+	ids := []string{}
+	for id, phrase := range filters {
+		tree, _ := tsl.ParseTSL(phrase)
+		if ok, _ := semantics.Walk(tree, evalFactory(doc)); ok {
+			ids = append(ids, id)
+		}
+	}
+
+	sort.Strings(ids)
+	return ids
+}
+
+func BenchmarkEngine(b *testing.B) {
+	e := NewEngine()
+	for i := 0; i < 10000; i++ {
+		e.Add(fmt.Sprintf("user-%d", i), fmt.Sprintf("user_id = %d and amount > 100", i))
+	}
+	eval := evalFactory(map[string]interface{}{"user_id": 42, "amount": 200})
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		e.Match(eval)
+	}
+}
@@ -0,0 +1,34 @@
+// Copyright 2018 Yaacov Zamir <kobi.zamir@gmail.com>
+// and other contributors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package targeting evaluates feature-flag targeting rules written in TSL.
+//
+// Rules are evaluated against user attribute maps. Deterministic bucketing is
+// done using the virtual `hash.<attribute>` identifier, a stable hash of the
+// attribute value salted by the flag key, so each flag buckets users
+// independently, and `bucket.<attribute>` is the hash modulo 100:
+//
+//   country in ('US', 'CA') and hash.user_id % 100 < 20
+//   plan = 'pro' or bucket.user_id < 5
+//
+// Math expressions on the left side of comparisons are evaluated using the
+// attribute values.
+//
+// Usage:
+//   f, err := targeting.NewFlag("new-checkout", "country = 'US' and hash.user_id % 100 < 20")
+//
+//   on, err := f.Enabled(map[string]interface{}{"user_id": "u-1234", "country": "US"})
+//
+package targeting
@@ -0,0 +1,77 @@
+// Copyright 2018 Yaacov Zamir <kobi.zamir@gmail.com>
+// and other contributors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package targeting
+
+import (
+	"strings"
+
+	"github.com/yaacov/tree-search-language/pkg/tsl"
+	"github.com/yaacov/tree-search-language/pkg/walkers/semantics"
+)
+
+// Virtual identifier prefixes.
+const (
+	HashPrefix   = "hash."   // the hash of an attribute value.
+	BucketPrefix = "bucket." // the hash of an attribute value modulo 100.
+)
+
+// Flag is a feature flag with a targeting rule.
+type Flag struct {
+	Key  string   // the flag key, used to salt the hashes.
+	Rule tsl.Node // the targeting rule.
+}
+
+// NewFlag parses a targeting rule and returns a flag.
+func NewFlag(key, rule string) (f Flag, err error) {
+	f.Key = key
+	f.Rule, err = tsl.ParseTSL(rule)
+	return
+}
+
+// Enabled checks if a flag is enabled for a user with attributes.
+func (f Flag) Enabled(attrs map[string]interface{}) (bool, error) {
+	return Evaluate(f.Rule, EvalFactory(f.Key, attrs))
+}
+
+// EvalFactory creates an evaluation function for user attributes, resolving
+// the hash and bucket virtual identifiers using salt.
+func EvalFactory(salt string, attrs map[string]interface{}) semantics.EvalFunc {
+	return func(k string) (interface{}, bool) {
+		// Attributes take precedence over virtual identifiers.
+		if v, ok := attrs[k]; ok {
+			return v, true
+		}
+
+		switch {
+		case strings.HasPrefix(k, HashPrefix):
+			if v, ok := attrs[strings.TrimPrefix(k, HashPrefix)]; ok && v != nil {
+				return Hash(salt, v), true
+			}
+		case strings.HasPrefix(k, BucketPrefix):
+			if v, ok := attrs[strings.TrimPrefix(k, BucketPrefix)]; ok && v != nil {
+				return Bucket(salt, v), true
+			}
+		}
+
+		return nil, false
+	}
+}
+
+// Evaluate checks if a targeting rule compiles to `true` for a document,
+// math expressions are evaluated using the document values.
+func Evaluate(tree tsl.Node, eval semantics.EvalFunc) (bool, error) {
+	return semantics.Walk(tree, eval)
+}
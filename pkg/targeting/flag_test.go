@@ -0,0 +1,80 @@
+// Copyright 2018 Yaacov Zamir <kobi.zamir@gmail.com>
+// and other contributors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package targeting
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestFlag(t *testing.T) {
+	tests := []struct {
+		rule  string
+		attrs map[string]interface{}
+		want  bool
+	}{
+		{"country in ('US', 'CA')", map[string]interface{}{"country": "CA"}, true},
+		{"hash.user_id % 100 < 100", map[string]interface{}{"user_id": "u-1"}, true},
+		{"hash.user_id % 100 < 0", map[string]interface{}{"user_id": "u-1"}, false},
+		{"bucket.user_id < 100 and plan = 'pro'", map[string]interface{}{"user_id": 7, "plan": "pro"}, true},
+		{"bucket.user_id < 100", map[string]interface{}{}, false},
+		{"age * 12 >= 216", map[string]interface{}{"age": 18}, true},
+		{"age / 0 > 1", map[string]interface{}{"age": 18}, false},
+	}
+
+	for _, tt := range tests {
+		f, err := NewFlag("flag", tt.rule)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		got, err := f.Enabled(tt.attrs)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != tt.want {
+			t.Errorf("Enabled(%q, %v) = %v, want %v", tt.rule, tt.attrs, got, tt.want)
+		}
+	}
+}
+
+func TestBucket(t *testing.T) {
+	f, _ := NewFlag("rollout", "hash.user_id % 100 < 20")
+
+	// About 20% of the users are enabled.
+	enabled := 0
+	for i := 0; i < 10000; i++ {
+		on, err := f.Enabled(map[string]interface{}{"user_id": fmt.Sprintf("user-%d", i)})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if on {
+			enabled++
+		}
+	}
+
+	if enabled < 1800 || enabled > 2200 {
+		t.Errorf("enabled users = %d, want about 2000", enabled)
+	}
+
+	// Bucketing is deterministic, and salted by the flag key.
+	if Bucket("a", "joe") != Bucket("a", "joe") {
+		t.Error("Bucket() is not deterministic")
+	}
+	if Hash("a", "joe") == Hash("b", "joe") {
+		t.Error("Hash() is not salted")
+	}
+}
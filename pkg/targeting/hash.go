@@ -0,0 +1,36 @@
+// Copyright 2018 Yaacov Zamir <kobi.zamir@gmail.com>
+// and other contributors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package targeting
+
+import (
+	"fmt"
+	"hash/fnv"
+)
+
+// Hash returns a stable 32 bit FNV-1a hash of a value salted by a flag key,
+// values are hashed using their default string format, so the number 42 and
+// the string "42" have the same hash.
+func Hash(salt string, v interface{}) uint32 {
+	h := fnv.New32a()
+	fmt.Fprintf(h, "%s.%v", salt, v)
+
+	return h.Sum32()
+}
+
+// Bucket returns the bucket of a value salted by a flag key, between 0 and 99.
+func Bucket(salt string, v interface{}) uint32 {
+	return Hash(salt, v) % 100
+}
@@ -0,0 +1,127 @@
+// Copyright 2018 Yaacov Zamir <kobi.zamir@gmail.com>
+// and other contributors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tsl
+
+import (
+	"github.com/antlr/antlr4/runtime/Go/antlr"
+
+	"github.com/yaacov/tree-search-language/pkg/parser"
+)
+
+// arenaSlabSize is the number of nodes in an arena slab.
+const arenaSlabSize = 256
+
+// Arena parses TSL phrases reusing the parser state and the node memory.
+//
+// The lexer, parser and listener are allocated once and reused by all parses,
+// and literal lists of parsed trees are allocated from node slabs owned by the
+// arena, the slabs are freed together when the arena is reset. An arena is not
+// safe for concurrent use, services parsing concurrently should keep arenas in
+// a sync.Pool.
+//
+// Usage:
+//   arena := tsl.NewArena()
+//
+//   for _, phrase := range phrases {
+//       tree, err := arena.ParseTSL(phrase)
+//       ...
+//
+//       // Free the trees, trees parsed before a reset must not be used.
+//       arena.Reset()
+//   }
+//
+type Arena struct {
+	slab []Node
+
+	errorListener *ErrorListener
+	lexer         *parser.TSLLexer
+	parser        *parser.TSLParser
+	listener      Listener
+}
+
+// NewArena creates a new parse arena.
+func NewArena() *Arena {
+	a := &Arena{errorListener: NewErrorListener()}
+
+	// Setup the Lexer, the input is set on each parse.
+	a.lexer = parser.NewTSLLexer(antlr.NewInputStream(""))
+	a.lexer.RemoveErrorListeners()
+	a.lexer.AddErrorListener(a.errorListener)
+	tokens := antlr.NewCommonTokenStream(a.lexer, antlr.TokenDefaultChannel)
+
+	// Setup the Parser.
+	a.parser = parser.NewTSLParser(tokens)
+	a.parser.RemoveErrorListeners()
+	a.parser.AddErrorListener(a.errorListener)
+
+	a.listener.arena = a
+
+	return a
+}
+
+// ParseTSL parses a TSL phrase into a TSL tree allocated in the arena.
+func (a *Arena) ParseTSL(input string) (tree Node, err error) {
+	// Reset the parser state.
+	a.errorListener.Err = nil
+	a.listener.Stack = a.listener.Stack[:0]
+	a.listener.Errs = a.listener.Errs[:0]
+
+	// Set the input, token streams can not be rewound to a new input.
+	a.lexer.SetInputStream(antlr.NewInputStream(input))
+	a.parser.SetTokenStream(antlr.NewCommonTokenStream(a.lexer, antlr.TokenDefaultChannel))
+
+	// Parse the expression (by walking the tree).
+	antlr.ParseTreeWalkerDefault.Walk(&a.listener, a.parser.Start())
+
+	// Check for errors.
+	err = a.errorListener.Err
+	if err != nil {
+		return
+	}
+
+	// Get the parsed tree.
+	tree, err = a.listener.GetTree()
+
+	return
+}
+
+// Reset frees all the trees parsed by the arena, the node memory is reused by
+// the next parses.
+func (a *Arena) Reset() {
+	// Clear the slab so it does not hold values of freed trees.
+	for i := range a.slab {
+		a.slab[i] = Node{}
+	}
+	a.slab = a.slab[:0]
+}
+
+// alloc allocates a list of nodes from the arena slab.
+func (a *Arena) alloc(size int) []Node {
+	// Check for room in the current slab, full slabs are freed by the
+	// garbage collector once all their trees are unused.
+	if len(a.slab)+size > cap(a.slab) {
+		slabSize := arenaSlabSize
+		if size > slabSize {
+			slabSize = size
+		}
+		a.slab = make([]Node, 0, slabSize)
+	}
+
+	i := len(a.slab)
+	a.slab = a.slab[:i+size]
+
+	return a.slab[i : i+size : i+size]
+}
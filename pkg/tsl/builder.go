@@ -0,0 +1,260 @@
+// Copyright 2018 Yaacov Zamir <kobi.zamir@gmail.com>
+// and other contributors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tsl
+
+import (
+	"reflect"
+	"time"
+)
+
+// Builder builds TSL trees in code, without formatting TSL phrases.
+//
+// Builders are created by the comparison functions, and joined using the And,
+// Or and Not methods. Build errors, for example unsupported literal values,
+// are kept by the builder and returned by Build. The zero Builder is empty,
+// joining it to another builder returns the other builder.
+//
+// Example:
+//  	// Build the tree of "author = 'Joe' and spec.pages > 100".
+//  	tree, err := tsl.Eq("author", "Joe").And(tsl.Gt("spec.pages", 100)).Build()
+//
+//  	// Join optional filters.
+//  	var b tsl.Builder
+//  	if name != "" {
+//  		b = b.And(tsl.Eq("name", name))
+//  	}
+//
+type Builder struct {
+	node Node
+	err  error
+}
+
+// Build returns the prepared tree of a builder, or the first build error.
+func (b Builder) Build() (Node, error) {
+	if b.err != nil {
+		return Node{}, b.err
+	}
+
+	return Prepare(b.node)
+}
+
+// And joins two builders using the AND operator.
+func (b Builder) And(o Builder) Builder {
+	return join(AndOp, b, o)
+}
+
+// Or joins two builders using the OR operator.
+func (b Builder) Or(o Builder) Builder {
+	return join(OrOp, b, o)
+}
+
+// Not negates a builder.
+func (b Builder) Not() Builder {
+	if b.err != nil || b.node.Func == "" {
+		return b
+	}
+
+	return Builder{node: Node{Func: NotOp, Left: b.node}}
+}
+
+// Eq builds a `field = value` comparison.
+func Eq(field string, value interface{}) Builder {
+	return compare(EqOp, field, value)
+}
+
+// Ne builds a `field != value` comparison.
+func Ne(field string, value interface{}) Builder {
+	return compare(NotEqOp, field, value)
+}
+
+// Lt builds a `field < value` comparison.
+func Lt(field string, value interface{}) Builder {
+	return compare(LtOp, field, value)
+}
+
+// Lte builds a `field <= value` comparison.
+func Lte(field string, value interface{}) Builder {
+	return compare(LteOp, field, value)
+}
+
+// Gt builds a `field > value` comparison.
+func Gt(field string, value interface{}) Builder {
+	return compare(GtOp, field, value)
+}
+
+// Gte builds a `field >= value` comparison.
+func Gte(field string, value interface{}) Builder {
+	return compare(GteOp, field, value)
+}
+
+// Regex builds a `field ~= pattern` comparison, the pattern is compiled by Build.
+func Regex(field string, pattern string) Builder {
+	return compare(RegexOp, field, pattern)
+}
+
+// Like builds a `field like pattern` comparison.
+func Like(field string, pattern string) Builder {
+	return compare(LikeOp, field, pattern)
+}
+
+// ILike builds a `field ilike pattern` comparison.
+func ILike(field string, pattern string) Builder {
+	return compare(ILikeOp, field, pattern)
+}
+
+// Contains builds a `field contains s` comparison.
+func Contains(field string, s string) Builder {
+	return compare(ContainsOp, field, s)
+}
+
+// StartsWith builds a `field startswith s` comparison.
+func StartsWith(field string, s string) Builder {
+	return compare(StartsWithOp, field, s)
+}
+
+// EndsWith builds a `field endswith s` comparison.
+func EndsWith(field string, s string) Builder {
+	return compare(EndsWithOp, field, s)
+}
+
+// In builds a `field in (values...)` comparison.
+func In(field string, values ...interface{}) Builder {
+	return list(InOp, field, values)
+}
+
+// NotIn builds a `field not in (values...)` comparison.
+func NotIn(field string, values ...interface{}) Builder {
+	return list(NotInOp, field, values)
+}
+
+// Between builds a `field between from and to` comparison.
+func Between(field string, from interface{}, to interface{}) Builder {
+	return list(BetweenOp, field, []interface{}{from, to})
+}
+
+// IsNull builds a `field is null` comparison.
+func IsNull(field string) Builder {
+	return unary(IsNilOp, field)
+}
+
+// IsNotNull builds a `field is not null` comparison.
+func IsNotNull(field string) Builder {
+	return unary(IsNotNilOp, field)
+}
+
+// IsTrue builds a `field is true` comparison.
+func IsTrue(field string) Builder {
+	return unary(IsTrueOp, field)
+}
+
+// IsFalse builds a `field is false` comparison.
+func IsFalse(field string) Builder {
+	return unary(IsFalseOp, field)
+}
+
+// join joins two builders, keeping the first build error.
+func join(op string, a Builder, b Builder) Builder {
+	switch {
+	case a.err != nil:
+		return a
+	case b.err != nil:
+		return b
+	case a.node.Func == "":
+		return b
+	case b.node.Func == "":
+		return a
+	}
+
+	return Builder{node: Node{Func: op, Left: a.node, Right: b.node}}
+}
+
+// unary builds a comparison without a literal.
+func unary(op string, field string) Builder {
+	l, err := ident(field)
+	if err != nil {
+		return Builder{err: err}
+	}
+
+	return Builder{node: Node{Func: op, Left: l}}
+}
+
+// compare builds a comparison of a field and a literal value.
+func compare(op string, field string, value interface{}) Builder {
+	l, err := ident(field)
+	if err != nil {
+		return Builder{err: err}
+	}
+
+	r, err := literal(value)
+	if err != nil {
+		return Builder{err: err}
+	}
+
+	return Builder{node: Node{Func: op, Left: l, Right: r}}
+}
+
+// list builds a comparison of a field and a list of literal values.
+func list(op string, field string, values []interface{}) Builder {
+	l, err := ident(field)
+	if err != nil {
+		return Builder{err: err}
+	}
+
+	nodes := make([]Node, len(values))
+	for i, v := range values {
+		if nodes[i], err = literal(v); err != nil {
+			return Builder{err: err}
+		}
+	}
+
+	return Builder{node: Node{Func: op, Left: l, Right: Node{Func: ArrayOp, Right: nodes}}}
+}
+
+// ident returns the identifier node of a field.
+func ident(field string) (Node, error) {
+	if field == "" {
+		return Node{}, UnexpectedLiteralError{ExpectedType: "identifier", Literal: field}
+	}
+
+	return Node{Func: IdentOp, Left: field}, nil
+}
+
+// literal returns the literal node of a value.
+func literal(value interface{}) (Node, error) {
+	switch v := value.(type) {
+	case string:
+		return Node{Func: StringOp, Left: v}, nil
+	case bool:
+		return Node{Func: BooleanOp, Left: v}, nil
+	case time.Time:
+		return Node{Func: DateOp, Left: v.Format(time.RFC3339Nano), Right: v}, nil
+	case time.Duration:
+		return Node{Func: DurationOp, Left: v.Seconds(), Right: v}, nil
+	}
+
+	// Check for numbers.
+	v := reflect.ValueOf(value)
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return integerLiteral(v.Int()), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return integerLiteral(v.Uint()), nil
+	case reflect.Float32, reflect.Float64:
+		return Node{Func: NumberOp, Left: v.Float()}, nil
+	}
+
+	return Node{}, UnexpectedLiteralError{Literal: value}
+}
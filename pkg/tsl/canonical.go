@@ -0,0 +1,225 @@
+// Copyright 2018 Yaacov Zamir <kobi.zamir@gmail.com>
+// and other contributors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tsl
+
+import (
+	"fmt"
+	"hash/fnv"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Canonicalize returns a canonical form of a tree, trees of equivalent
+// filters that differ only in the order of commutative operands, or in the
+// order and repetitions of IN list literals, have the same canonical form.
+//
+// Operands of AND and OR chains are sorted and deduplicated, operands of
+// additions and multiplications of fields are sorted, IN lists are sorted and
+// deduplicated, and negative zero number literals are normalized into zero.
+func Canonicalize(n Node) Node {
+	switch n.Func {
+	case AndOp, OrOp:
+		// Collect the canonical operands of the chain.
+		operands := map[string]Node{}
+		for _, o := range flatten(n.Func, n) {
+			o = Canonicalize(o)
+			operands[Key(o)] = o
+		}
+
+		// Sort the operands by key.
+		keys := make([]string, 0, len(operands))
+		for k := range operands {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+
+		// Rebuild the chain.
+		c := operands[keys[0]]
+		for _, k := range keys[1:] {
+			c = Node{Func: n.Func, Left: c, Right: operands[k]}
+		}
+		return c
+	case InOp, NotInOp:
+		n.Left = canonicalOperand(n.Left)
+
+		r, ok := n.Right.(Node)
+		if !ok || r.Func != ArrayOp {
+			return n
+		}
+
+		// Sort and deduplicate the literals.
+		literals := map[string]Node{}
+		for _, l := range r.Right.([]Node) {
+			l = Canonicalize(l)
+			literals[Key(l)] = l
+		}
+		keys := make([]string, 0, len(literals))
+		for k := range literals {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+
+		list := make([]Node, len(keys))
+		for i, k := range keys {
+			list[i] = literals[k]
+		}
+		n.Right = Node{Func: ArrayOp, Right: list}
+
+		// Index the new list if it's large.
+		n, _ = Prepare(n)
+		return n
+	case NumberOp:
+		// Normalize negative zero.
+		if f, ok := n.Left.(float64); ok && f == 0 {
+			n.Left = float64(0)
+		}
+		return n
+	case ArrayOp:
+		list := make([]Node, len(n.Right.([]Node)))
+		for i, l := range n.Right.([]Node) {
+			list[i] = Canonicalize(l)
+		}
+		n.Right = list
+		return n
+	}
+
+	n.Left = canonicalOperand(n.Left)
+	n.Right = canonicalOperand(n.Right)
+
+	// Sort the operands of commutative math operators, a literal operand
+	// is kept on the right side.
+	if n.Func == AddOp || n.Func == MultiplyOp {
+		l, lok := n.Left.(Node)
+		r, rok := n.Right.(Node)
+		if lok && rok && !isLiteral(l) && !isLiteral(r) && Key(r) < Key(l) {
+			n.Left, n.Right = r, l
+		}
+	}
+
+	return n
+}
+
+// canonicalOperand returns the canonical form of a node operand.
+func canonicalOperand(v interface{}) interface{} {
+	if n, ok := v.(Node); ok {
+		return Canonicalize(n)
+	}
+
+	return v
+}
+
+// isLiteral returns true if a node is a literal.
+func isLiteral(n Node) bool {
+	switch n.Func {
+	case StringOp, DateOp, NumberOp, DurationOp, NowOp, BooleanOp, NullOp, ArrayOp:
+		return true
+	}
+
+	return false
+}
+
+// Equal returns true if two trees are equal, compiled regular expressions,
+// parsed dates and IN list sets are not compared.
+//
+// Equivalent trees, for example trees differing only in the order of AND
+// operands, are equal after they are canonicalized.
+func Equal(a, b Node) bool {
+	return Key(a) == Key(b)
+}
+
+// Hash returns the 64-bit FNV-1a hash of a tree key, equal trees have equal
+// hashes.
+//
+// Example:
+//  	// Use the hash of the canonical tree as a cache key.
+//  	h := tsl.Hash(tsl.Canonicalize(tree))
+//
+func Hash(n Node) uint64 {
+	h := fnv.New64a()
+	h.Write([]byte(Key(n)))
+
+	return h.Sum64()
+}
+
+// Key serializes a tree into an unambiguous string, equal trees have equal
+// keys.
+func Key(n Node) string {
+	var b strings.Builder
+	writeKey(&b, n)
+	return b.String()
+}
+
+// flatten collects the operands of a chain of AND or OR nodes.
+func flatten(op string, n Node) []Node {
+	if n.Func != op {
+		return []Node{n}
+	}
+
+	l, _ := n.Left.(Node)
+	r, _ := n.Right.(Node)
+	return append(flatten(op, l), flatten(op, r)...)
+}
+
+// writeKey writes the key of a node.
+func writeKey(b *strings.Builder, n Node) {
+	b.WriteString(n.Func)
+
+	switch n.Func {
+	case IdentOp:
+		b.WriteString(strconv.Quote(n.Left.(string)))
+		return
+	case StringOp, DateOp:
+		b.WriteString(strconv.Quote(fmt.Sprintf("%v", n.Left)))
+		return
+	case NumberOp, DurationOp, NowOp:
+		if i, ok := n.Right.(int64); ok && n.Func == NumberOp {
+			b.WriteString(strconv.FormatInt(i, 10))
+		} else if u, ok := n.Right.(uint64); ok && n.Func == NumberOp {
+			b.WriteString(strconv.FormatUint(u, 10))
+		} else if f, ok := n.Left.(float64); ok {
+			b.WriteString(strconv.FormatFloat(f, 'g', -1, 64))
+		} else {
+			fmt.Fprintf(b, "%v", n.Left)
+		}
+		return
+	case BooleanOp:
+		fmt.Fprintf(b, "%v", n.Left)
+		return
+	case NullOp:
+		return
+	case ArrayOp:
+		b.WriteString("[")
+		for i, l := range n.Right.([]Node) {
+			if i > 0 {
+				b.WriteString(",")
+			}
+			writeKey(b, l)
+		}
+		b.WriteString("]")
+		return
+	}
+
+	b.WriteString("(")
+	if l, ok := n.Left.(Node); ok {
+		writeKey(b, l)
+	}
+	b.WriteString(",")
+	if r, ok := n.Right.(Node); ok {
+		writeKey(b, r)
+	}
+	b.WriteString(")")
+}
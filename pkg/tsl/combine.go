@@ -0,0 +1,54 @@
+// Copyright 2018 Yaacov Zamir <kobi.zamir@gmail.com>
+// and other contributors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tsl
+
+// CombineAnd joins trees using the AND operator, empty trees (Node{}) are
+// skipped, and if all the trees are empty, an empty tree is returned.
+//
+// Each tree is kept as a whole operand, so OR operators of one tree can not
+// change the meaning of the others.
+//
+// Example:
+//  	// Returns the tree of "tenant = 'acme' and deleted is null and (<user tree>)".
+//  	tree = tsl.CombineAnd(tenant, notDeleted, userTree)
+//
+func CombineAnd(trees ...Node) Node {
+	return combine(AndOp, trees)
+}
+
+// CombineOr joins trees using the OR operator, empty trees (Node{}) are
+// skipped, and if all the trees are empty, an empty tree is returned.
+func CombineOr(trees ...Node) Node {
+	return combine(OrOp, trees)
+}
+
+// combine joins trees from left to right.
+func combine(op string, trees []Node) Node {
+	var c Node
+
+	for _, n := range trees {
+		switch {
+		case n.Func == "":
+			continue
+		case c.Func == "":
+			c = n
+		default:
+			c = Node{Func: op, Left: c, Right: n}
+		}
+	}
+
+	return c
+}
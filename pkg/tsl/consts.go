@@ -17,37 +17,91 @@ package tsl
 
 // TLS operators.
 const (
-	IdentOp      = "$ident"  // Empty operator for itentifiers
-	ArrayOp      = "$array"  // Empty operator for arrays
-	StringOp     = "$string" // Empty operator for strings
-	NumberOp     = "$number" // Empty operator for numbers
-	NullOp       = "$null"   // Empty operator for nulls
-	LtOp         = "$lt"
-	LteOp        = "$lte"
-	GtOp         = "$gt"
-	GteOp        = "$gte"
-	EqOp         = "$eq"
-	NotEqOp      = "$ne"
-	RegexOp      = "$regex"
-	NotRegexOp   = "$nregex"
-	LikeOp       = "$like"
-	NotLikeOp    = "$nlike"
-	InOp         = "$in"
-	NotInOp      = "$nin"
-	BetweenOp    = "$between"
-	NotBetweenOp = "$nbetween"
-	NotOp        = "$not"
-	AndOp        = "$and"
-	OrOp         = "$or"
-	IsNilOp      = "$nexists"
-	IsNotNilOp   = "$exists"
-	AddOp        = "$add"
-	SubtractOp   = "$subtract"
-	MultiplyOp   = "$multiply"
-	DivideOp     = "$divide"
-	ModuloOp     = "$modulo"
+	IdentOp         = "$ident"    // Empty operator for itentifiers
+	ArrayOp         = "$array"    // Empty operator for arrays
+	StringOp        = "$string"   // Empty operator for strings
+	NumberOp        = "$number"   // Empty operator for numbers
+	NullOp          = "$null"     // Empty operator for nulls
+	DateOp          = "$date"     // Empty operator for dates
+	DurationOp      = "$duration" // Empty operator for durations
+	NowOp           = "$now"      // Empty operator for the current time
+	BooleanOp       = "$boolean"  // Empty operator for booleans
+	LtOp            = "$lt"
+	LteOp           = "$lte"
+	GtOp            = "$gt"
+	GteOp           = "$gte"
+	EqOp            = "$eq"
+	NotEqOp         = "$ne"
+	RegexOp         = "$regex"
+	NotRegexOp      = "$nregex"
+	LikeOp          = "$like"
+	NotLikeOp       = "$nlike"
+	ILikeOp         = "$ilike"
+	NotILikeOp      = "$nilike"
+	ContainsOp      = "$contains"
+	NotContainsOp   = "$ncontains"
+	StartsWithOp    = "$startswith"
+	NotStartsWithOp = "$nstartswith"
+	EndsWithOp      = "$endswith"
+	NotEndsWithOp   = "$nendswith"
+	InOp            = "$in"
+	NotInOp         = "$nin"
+	BetweenOp       = "$between"
+	NotBetweenOp    = "$nbetween"
+	WithinOp        = "$within"
+	NotWithinOp     = "$nwithin"
+	WithinBoxOp     = "$withinbox"
+	NotWithinBoxOp  = "$nwithinbox"
+	NotOp           = "$not"
+	AndOp           = "$and"
+	OrOp            = "$or"
+	IsNilOp         = "$nexists"
+	IsNotNilOp      = "$exists"
+	IsTrueOp        = "$true"
+	IsNotTrueOp     = "$ntrue"
+	IsFalseOp       = "$false"
+	IsNotFalseOp    = "$nfalse"
+	AddOp           = "$add"
+	SubtractOp      = "$subtract"
+	MultiplyOp      = "$multiply"
+	DivideOp        = "$divide"
+	ModuloOp        = "$modulo"
+	LenOp           = "$len"
+	LowerOp         = "$lower"
+	UpperOp         = "$upper"
+	AbsOp           = "$abs"
+	RoundOp         = "$round"
+	SemverOp        = "$semver"
 )
 
+// likeOps maps LIKE keywords to TSL operators, and negated operators.
+var likeOps = map[string][2]string{
+	"like":       {LikeOp, NotLikeOp},
+	"ilike":      {ILikeOp, NotILikeOp},
+	"contains":   {ContainsOp, NotContainsOp},
+	"startswith": {StartsWithOp, NotStartsWithOp},
+	"endswith":   {EndsWithOp, NotEndsWithOp},
+}
+
+// distanceUnits maps distance units to meters.
+var distanceUnits = map[string]float64{
+	"m":  1,
+	"km": 1000,
+	"mi": 1609.344,
+}
+
+// funcOps maps function names to TSL function operators, function calls are
+// math expressions with one argument, `lower(author)` is parsed into
+// Node{Func: LowerOp, Left: Node{Func: IdentOp, Left: "author"}}.
+var funcOps = map[string]string{
+	"len":    LenOp,
+	"lower":  LowerOp,
+	"upper":  UpperOp,
+	"abs":    AbsOp,
+	"round":  RoundOp,
+	"semver": SemverOp,
+}
+
 // opDic maps SQL'ish operators to TLS operators.
 var opDic = map[string]string{
 	"<":  LtOp,
@@ -0,0 +1,78 @@
+// Copyright 2018 Yaacov Zamir <kobi.zamir@gmail.com>
+// and other contributors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tsl
+
+import (
+	"time"
+)
+
+// DateLayout is the layout of date only literals.
+const DateLayout = "2006-01-02"
+
+// ParseDate parses a RFC 3339 or a `YYYY-MM-DD` date string, date only strings
+// are midnight UTC.
+func ParseDate(s string) (time.Time, bool) {
+	// Check the string starts with a date, before trying to parse it.
+	if len(s) < len(DateLayout) || s[4] != '-' || s[7] != '-' {
+		return time.Time{}, false
+	}
+
+	if len(s) == len(DateLayout) {
+		t, err := time.Parse(DateLayout, s)
+		return t, err == nil
+	}
+
+	t, err := time.Parse(time.RFC3339Nano, s)
+	return t, err == nil
+}
+
+// Date returns the time of a date literal node, the date is parsed if the node
+// was not prepared. The time of a now literal node is the current time plus
+// the literal offset.
+func Date(n Node) (time.Time, error) {
+	// Check for a now literal.
+	if d, ok := n.Right.(time.Duration); ok && n.Func == NowOp {
+		return time.Now().Add(d), nil
+	}
+
+	// Check for a prepared node.
+	if t, ok := n.Right.(time.Time); ok {
+		return t, nil
+	}
+
+	if s, ok := n.Left.(string); ok {
+		if t, ok := ParseDate(s); ok {
+			return t, nil
+		}
+	}
+
+	return time.Time{}, UnexpectedLiteralError{ExpectedType: "date", Literal: n.Left}
+}
+
+// dateCall returns the date literal node of a `date('2020-01-01')` call of
+// the date string s, plus a duration offset, like `date('2020-01-01') + 12h`.
+func dateCall(s string, offset time.Duration) (Node, error) {
+	t, ok := ParseDate(s)
+	if !ok {
+		return Node{}, UnexpectedLiteralError{ExpectedType: "date", Literal: s}
+	}
+	if offset == 0 {
+		return Node{Func: DateOp, Left: s, Right: t}, nil
+	}
+
+	t = t.Add(offset)
+	return Node{Func: DateOp, Left: t.Format(time.RFC3339Nano), Right: t}, nil
+}
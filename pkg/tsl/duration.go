@@ -0,0 +1,79 @@
+// Copyright 2018 Yaacov Zamir <kobi.zamir@gmail.com>
+// and other contributors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tsl
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Day is the duration of the `d` duration unit.
+const Day = 24 * time.Hour
+
+// ParseDuration parses a duration string like `5m`, `2h30m` or `7d`.
+//
+// Durations are parsed like time.ParseDuration, with the additional `d` unit
+// of 24 hours.
+func ParseDuration(s string) (time.Duration, error) {
+	sign, rest := time.Duration(1), s
+	if strings.HasPrefix(rest, "-") || strings.HasPrefix(rest, "+") {
+		if rest[0] == '-' {
+			sign = -1
+		}
+		rest = rest[1:]
+	}
+
+	// Parse the days part, the rest is parsed by the time package.
+	var days time.Duration
+	if i := strings.IndexByte(rest, 'd'); i > 0 {
+		n := 0
+		for _, c := range rest[:i] {
+			if c < '0' || c > '9' {
+				return 0, fmt.Errorf("invalid duration %q", s)
+			}
+			n = n*10 + int(c-'0')
+		}
+		days, rest = time.Duration(n)*Day, rest[i+1:]
+	}
+	if rest == "" {
+		return sign * days, nil
+	}
+
+	d, err := time.ParseDuration(rest)
+	if err != nil || d < 0 || strings.HasPrefix(rest, "+") {
+		return 0, fmt.Errorf("invalid duration %q", s)
+	}
+
+	return sign * (days + d), nil
+}
+
+// FormatDuration formats a duration as a string parsed by ParseDuration and by
+// the TSL parser.
+func FormatDuration(d time.Duration) string {
+	switch {
+	case d == 0:
+		return "0s"
+	case d%Day == 0:
+		return fmt.Sprintf("%dd", d/Day)
+	case d%time.Microsecond != 0:
+		return fmt.Sprintf("%dns", d)
+	}
+
+	// Microseconds are formatted using the `µs` unit, that is not an identifier
+	// character.
+	return strings.Replace(d.String(), "µs", "us", 1)
+}
@@ -0,0 +1,278 @@
+// Copyright 2018 Yaacov Zamir <kobi.zamir@gmail.com>
+// and other contributors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tsl
+
+import (
+	"bytes"
+	"encoding/json"
+	"strconv"
+	"time"
+)
+
+// encodedNode is the encoded form of a node, it has no methods, so encoding it
+// does not recurse into the node marshalers.
+type encodedNode struct {
+	Func  string      `json:"func" yaml:"func"`
+	Left  interface{} `json:"left,omitempty" yaml:"left,omitempty"`
+	Right interface{} `json:"right,omitempty" yaml:"right,omitempty"`
+}
+
+// encode returns the encoded form of a node.
+//
+// The "func" key holds the operator, or the literal type of literal nodes,
+// the "left" and "right" keys hold the operands. Literal nodes keep their
+// value in "left", duration and now literals also keep their nanoseconds in "right",
+// large integer number literals also keep their exact value in "right", and
+// array nodes keep their literal list in "right". Compiled regular
+// expressions, parsed dates and IN list sets are not encoded, they are rebuilt
+// when decoding.
+func (n Node) encode() encodedNode {
+	e := encodedNode{Func: n.Func, Left: n.Left, Right: n.Right}
+
+	switch n.Func {
+	case IdentOp, StringOp, DateOp, BooleanOp, NullOp:
+		e.Right = nil
+	case NumberOp:
+		switch n.Right.(type) {
+		case int64, uint64:
+			// Keep the exact value of large integers.
+		default:
+			e.Right = nil
+		}
+	case DurationOp, NowOp:
+		if d, ok := n.Right.(time.Duration); ok {
+			e.Right = int64(d)
+		}
+	case ArrayOp:
+		e.Left = nil
+	}
+
+	return e
+}
+
+// MarshalJSON encodes a node using a stable schema, see Node.encode.
+func (n Node) MarshalJSON() ([]byte, error) {
+	return json.Marshal(n.encode())
+}
+
+// MarshalYAML encodes a node using the same schema as MarshalJSON.
+func (n Node) MarshalYAML() (interface{}, error) {
+	return n.encode(), nil
+}
+
+// UnmarshalJSON decodes a node encoded by MarshalJSON, the decoded tree is
+// prepared, so it can be walked without re-parsing the phrase.
+func (n *Node) UnmarshalJSON(data []byte) error {
+	var v interface{}
+
+	// Decode numbers as json.Number, to keep the exact duration nanoseconds
+	// and integers.
+	d := json.NewDecoder(bytes.NewReader(data))
+	d.UseNumber()
+	if err := d.Decode(&v); err != nil {
+		return err
+	}
+
+	return n.decode(v)
+}
+
+// UnmarshalYAML decodes a node encoded by MarshalYAML, the decoded tree is
+// prepared, so it can be walked without re-parsing the phrase.
+func (n *Node) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var v interface{}
+	if err := unmarshal(&v); err != nil {
+		return err
+	}
+
+	return n.decode(v)
+}
+
+// decode sets a node to the prepared tree of a decoded JSON or YAML value.
+func (n *Node) decode(v interface{}) error {
+	tree, err := decodeNode(v)
+	if err != nil {
+		return err
+	}
+
+	tree, err = Prepare(tree)
+	if err != nil {
+		return err
+	}
+
+	*n = tree
+	return nil
+}
+
+// decodeNode converts a decoded JSON or YAML value into a node, restoring the
+// Go types of the literals.
+func decodeNode(v interface{}) (Node, error) {
+	m, ok := fields(v)
+	if !ok {
+		return Node{}, UnexpectedLiteralError{ExpectedType: "node", Literal: v}
+	}
+
+	op, _ := m["func"].(string)
+	n := Node{Func: op}
+
+	switch op {
+	case "":
+		return n, UnexpectedLiteralError{ExpectedType: "operator", Literal: v}
+	case IdentOp, StringOp, DateOp:
+		s, ok := m["left"].(string)
+		if !ok {
+			return n, UnexpectedLiteralError{ExpectedType: "string", Literal: m["left"]}
+		}
+		n.Left = s
+	case NumberOp:
+		// Prefer the exact integer value, fall back to the float value.
+		if i, ok := integer(m["right"]); ok {
+			n = integerLiteral(i)
+			break
+		}
+		f, ok := number(m["left"])
+		if !ok {
+			return n, UnexpectedLiteralError{ExpectedType: "number", Literal: m["left"]}
+		}
+		n.Left = f
+	case DurationOp, NowOp:
+		// Prefer the exact nanoseconds, fall back to the seconds.
+		d, ok := nanoseconds(m["right"])
+		if !ok {
+			f, ok := number(m["left"])
+			if !ok {
+				return n, UnexpectedLiteralError{ExpectedType: "duration", Literal: m["left"]}
+			}
+			d = time.Duration(f * float64(time.Second))
+		}
+		n.Left, n.Right = d.Seconds(), d
+	case BooleanOp:
+		b, ok := m["left"].(bool)
+		if !ok {
+			return n, UnexpectedLiteralError{ExpectedType: "boolean", Literal: m["left"]}
+		}
+		n.Left = b
+	case NullOp:
+		// Null literals have no value.
+	case ArrayOp:
+		list, ok := m["right"].([]interface{})
+		if !ok {
+			return n, UnexpectedLiteralError{ExpectedType: "array", Literal: m["right"]}
+		}
+
+		nodes := make([]Node, len(list))
+		for i, v := range list {
+			node, err := decodeNode(v)
+			if err != nil {
+				return n, err
+			}
+			nodes[i] = node
+		}
+		n.Right = nodes
+	default:
+		// Operators have node operands.
+		var err error
+		if n.Left, err = decodeOperand(m["left"]); err != nil {
+			return n, err
+		}
+		if n.Right, err = decodeOperand(m["right"]); err != nil {
+			return n, err
+		}
+	}
+
+	return n, nil
+}
+
+// decodeOperand decodes an optional operand node, missing operands are nil.
+func decodeOperand(v interface{}) (interface{}, error) {
+	if v == nil {
+		return nil, nil
+	}
+
+	return decodeNode(v)
+}
+
+// fields returns the keys of a decoded object, JSON objects are decoded into
+// string keyed maps, and YAML mappings into maps of any key type.
+func fields(v interface{}) (map[string]interface{}, bool) {
+	switch m := v.(type) {
+	case map[string]interface{}:
+		return m, true
+	case map[interface{}]interface{}:
+		f := make(map[string]interface{}, len(m))
+		for k, v := range m {
+			s, ok := k.(string)
+			if !ok {
+				return nil, false
+			}
+			f[s] = v
+		}
+		return f, true
+	}
+
+	return nil, false
+}
+
+// number returns the value of a decoded number.
+func number(v interface{}) (float64, bool) {
+	switch n := v.(type) {
+	case float64:
+		return n, true
+	case int:
+		return float64(n), true
+	case int64:
+		return float64(n), true
+	case uint64:
+		return float64(n), true
+	case json.Number:
+		f, err := n.Float64()
+		return f, err == nil
+	}
+
+	return 0, false
+}
+
+// integer returns the int64 or uint64 value of a decoded integer.
+func integer(v interface{}) (interface{}, bool) {
+	switch n := v.(type) {
+	case int:
+		return int64(n), true
+	case int64, uint64:
+		return n, true
+	case json.Number:
+		if i, err := strconv.ParseInt(n.String(), 10, 64); err == nil {
+			return i, true
+		}
+		u, err := strconv.ParseUint(n.String(), 10, 64)
+		return u, err == nil
+	}
+
+	return nil, false
+}
+
+// nanoseconds returns the duration of a decoded number of nanoseconds.
+func nanoseconds(v interface{}) (time.Duration, bool) {
+	switch n := v.(type) {
+	case int:
+		return time.Duration(n), true
+	case int64:
+		return time.Duration(n), true
+	case json.Number:
+		i, err := n.Int64()
+		return time.Duration(i), err == nil
+	}
+
+	return 0, false
+}
@@ -55,3 +55,20 @@ type ParseError struct {
 func (e ParseError) Error() string {
 	return fmt.Sprintf("parse error [%d:%d]: %s", e.line, e.column, e.msg)
 }
+
+// RegexError is raised when a regular expression literal fails to compile.
+type RegexError struct {
+	Pattern string // the regular expression.
+	Line    int    // the pattern line in the phrase, zero if unknown.
+	Column  int    // the pattern column in the phrase.
+	Msg     string // the compiler error message.
+}
+
+func (e RegexError) Error() string {
+	// If no position is given, the pattern did not come from the parser.
+	if e.Line == 0 {
+		return fmt.Sprintf("regex error: %s: %s", e.Pattern, e.Msg)
+	}
+
+	return fmt.Sprintf("regex error [%d:%d]: %s: %s", e.Line, e.Column, e.Pattern, e.Msg)
+}
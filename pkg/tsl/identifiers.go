@@ -0,0 +1,54 @@
+// Copyright 2018 Yaacov Zamir <kobi.zamir@gmail.com>
+// and other contributors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tsl
+
+// identifiers is a visitor collecting the identifiers of a tree.
+type identifiers struct {
+	names []string
+	seen  map[string]bool
+}
+
+func (v *identifiers) Enter(n Node) {
+	if n.Func != IdentOp {
+		return
+	}
+
+	if name := n.Left.(string); !v.seen[name] {
+		v.seen[name] = true
+		v.names = append(v.names, name)
+	}
+}
+
+func (v *identifiers) Leave(n Node) {}
+
+// GetIdentifiers returns the identifiers used by a tree, in the order they
+// first appear in the tree, without repetitions.
+//
+// Callers can check which document fields a tree uses before walking it, for
+// example to check field permissions, or to select an index.
+//
+// Example:
+//  	tree, err := tsl.ParseTSL("name = 'joe' and (age > 18 or name is null)")
+//
+//  	// Returns []string{"name", "age"}.
+//  	fields := tsl.GetIdentifiers(tree)
+//
+func GetIdentifiers(n Node) []string {
+	v := &identifiers{names: []string{}, seen: map[string]bool{}}
+	Accept(n, v)
+
+	return v.names
+}
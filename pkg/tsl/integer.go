@@ -0,0 +1,95 @@
+// Copyright 2018 Yaacov Zamir <kobi.zamir@gmail.com>
+// and other contributors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tsl
+
+import (
+	"math"
+	"strconv"
+)
+
+// maxExactFloat is the largest integer float64 numbers hold exactly.
+const maxExactFloat = 1 << 53
+
+// Integer returns the exact integer value of a number literal node, an int64
+// or a uint64 value.
+//
+// Number literals hold float64 values, integer literals float64 can not hold
+// exactly, larger than 2^53, also keep their exact value in the Right field of
+// the node, other integral number literals are converted from their float64
+// value.
+func Integer(n Node) (interface{}, bool) {
+	switch v := n.Right.(type) {
+	case int64, uint64:
+		return v, true
+	}
+
+	f, ok := n.Left.(float64)
+	switch {
+	case !ok || f != math.Trunc(f):
+		return nil, false
+	case f >= -(1<<63) && f < 1<<63:
+		return int64(f), true
+	case f >= 0 && f < 1<<64:
+		return uint64(f), true
+	}
+
+	return nil, false
+}
+
+// LiteralValue returns the value of a literal node, number literals return
+// their exact int64 or uint64 value if float64 can not hold it.
+func LiteralValue(n Node) interface{} {
+	if n.Func == NumberOp {
+		switch v := n.Right.(type) {
+		case int64, uint64:
+			return v
+		}
+	}
+
+	return n.Left
+}
+
+// integerLiteral returns the number literal node of an integer, integers
+// float64 can not hold exactly also keep their exact value.
+func integerLiteral(v interface{}) Node {
+	switch i := v.(type) {
+	case int64:
+		if i < -maxExactFloat || i > maxExactFloat {
+			return Node{Func: NumberOp, Left: float64(i), Right: i}
+		}
+		return Node{Func: NumberOp, Left: float64(i)}
+	case uint64:
+		if i > maxExactFloat {
+			return Node{Func: NumberOp, Left: float64(i), Right: i}
+		}
+		return Node{Func: NumberOp, Left: float64(i)}
+	}
+
+	return Node{Func: NumberOp, Left: v}
+}
+
+// exactInteger parses the exact value of an integer literal, if float64 can
+// not hold it.
+func exactInteger(s string) (interface{}, bool) {
+	if i, err := strconv.ParseInt(s, 10, 64); err == nil {
+		return i, i < -maxExactFloat || i > maxExactFloat
+	}
+	if u, err := strconv.ParseUint(s, 10, 64); err == nil {
+		return u, true
+	}
+
+	return nil, false
+}
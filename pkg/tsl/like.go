@@ -0,0 +1,67 @@
+// Copyright 2018 Yaacov Zamir <kobi.zamir@gmail.com>
+// and other contributors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tsl
+
+import (
+	"regexp"
+	"strings"
+)
+
+// LikePattern converts a LIKE pattern into a regular expression, matching the
+// whole string, `%` matches any sequence of characters and `_` matches one
+// character. If fold is true the expression is case insensitive.
+func LikePattern(pattern string, fold bool) string {
+	var b strings.Builder
+
+	if fold {
+		b.WriteString("(?i)")
+	}
+	b.WriteString("^")
+	for _, c := range pattern {
+		switch c {
+		case '%':
+			b.WriteString(".*")
+		case '_':
+			b.WriteString(".")
+		default:
+			b.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+	b.WriteString("$")
+
+	return b.String()
+}
+
+// LikeRegexp returns the compiled regular expression of a LIKE pattern string
+// literal node, the expression is compiled if the node was not prepared.
+func LikeRegexp(n Node, fold bool) (*regexp.Regexp, error) {
+	// Check for a prepared node.
+	if re, ok := n.Right.(*regexp.Regexp); ok {
+		return re, nil
+	}
+
+	pattern, ok := n.Left.(string)
+	if !ok {
+		return nil, UnexpectedLiteralError{ExpectedType: "string", Literal: n.Left}
+	}
+
+	re, err := regexp.Compile(LikePattern(pattern, fold))
+	if err != nil {
+		return nil, regexError(pattern, err)
+	}
+
+	return re, nil
+}
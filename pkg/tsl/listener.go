@@ -16,8 +16,12 @@
 package tsl
 
 import (
+	"fmt"
+	"math"
 	"strconv"
 	"strings"
+	"time"
+	"unicode"
 
 	"github.com/yaacov/tree-search-language/pkg/parser"
 )
@@ -28,6 +32,9 @@ type Listener struct {
 
 	Stack []Node
 	Errs  []error
+
+	arena    *Arena // the arena allocating literal lists, if any.
+	literals []Node // scratch list for collecting literals.
 }
 
 // GetTree return the parsed tree, if exist.
@@ -55,24 +62,103 @@ func (l *Listener) ExitColumnIdentifier(c *parser.ColumnIdentifierContext) {
 
 // ExitNumberLiteral is called when exiting the NumberLiteral production.
 func (l *Listener) ExitNumberLiteral(c *parser.NumberLiteralContext) {
-	// Check for a float value.
-	f, err := strconv.ParseFloat(c.SignedNumber().GetText(), 64)
+	s := c.SignedNumber().GetText()
+
+	// Check for a float value, large integers also keep their exact value.
+	f, err := strconv.ParseFloat(s, 64)
 	if err != nil {
 		l.Errs = append(l.Errs, err)
 	}
+	if i, ok := exactInteger(strings.TrimPrefix(s, "+")); ok && err == nil {
+		l.push(Node{Func: NumberOp, Left: f, Right: i})
+		return
+	}
 
 	l.exitLiteral(NumberOp, f)
 }
 
+// ExitDurationLiteral is called when exiting the DurationLiteral production.
+//
+// Duration literal nodes hold the duration in seconds, and the duration.
+func (l *Listener) ExitDurationLiteral(c *parser.DurationLiteralContext) {
+	s := c.DurationValue().GetText()
+	d, err := ParseDuration(s)
+	if err != nil {
+		l.Errs = append(l.Errs, UnexpectedLiteralError{ExpectedType: "duration", Literal: s})
+		return
+	}
+
+	l.push(Node{Func: DurationOp, Left: d.Seconds(), Right: d})
+}
+
 // ExitStringLiteral is called when exiting the StringLiteral production.
 func (l *Listener) ExitStringLiteral(c *parser.StringLiteralContext) {
-	// StringValue must be a string of format \'.*'\,
-	// length must be greater or equal to 2.
-	s := c.StringValue().GetText()
-	ln := len(s)
-	v := strings.Replace(s[1:ln-1], "''", "'", -1)
+	l.exitLiteral(StringOp, unquote(c.StringValue().GetText()))
+}
 
-	l.exitLiteral(StringOp, v)
+// ExitBooleanLiteral is called when exiting the BooleanLiteral production.
+func (l *Listener) ExitBooleanLiteral(c *parser.BooleanLiteralContext) {
+	l.exitLiteral(BooleanOp, c.BooleanValue().GetStart().GetTokenType() == parser.TSLParserK_TRUE)
+}
+
+// ExitFunctionCall is called when production FunctionCall is exited.
+func (l *Listener) ExitFunctionCall(c *parser.FunctionCallContext) {
+	name := c.IDENTIFIER().GetText()
+
+	// Check the function is registered.
+	op, ok := funcOps[strings.ToLower(name)]
+	if !ok {
+		t := c.IDENTIFIER().GetSymbol()
+		l.Errs = append(l.Errs, ParseError{
+			line:   t.GetLine(),
+			column: t.GetColumn(),
+			msg:    fmt.Sprintf("unknown function %s", name),
+		})
+		return
+	}
+
+	l.push(Node{Func: op, Left: l.pop()})
+}
+
+// ExitDateLiteral is called when exiting the DateLiteral production.
+//
+// Now literal nodes keep the offset in seconds in the Left field, and the
+// offset duration in the Right field, walkers get the current time when the
+// tree is walked, so parsed trees can be cached.
+func (l *Listener) ExitDateLiteral(c *parser.DateLiteralContext) {
+	v := c.DateValue().(*parser.DateValueContext)
+
+	// Parse the offset, a signed duration, like the `- 7d` of `now() - 7d`.
+	var d time.Duration
+	if o := v.DateOffset(); o != nil {
+		var err error
+		if d, err = ParseDuration(o.GetText()); err != nil {
+			l.Errs = append(l.Errs, UnexpectedLiteralError{ExpectedType: "duration", Literal: o.GetText()})
+			return
+		}
+	}
+
+	if v.K_NOW() != nil {
+		l.push(Node{Func: NowOp, Left: d.Seconds(), Right: d})
+		return
+	}
+
+	// Date literals are dates, like 2020-01-01, or date calls, like
+	// date('2020-01-01').
+	var s string
+	if t := v.DATE_LITERAL(); t != nil {
+		s = t.GetText()
+	} else {
+		s = unquote(v.StringValue().GetText())
+	}
+
+	n, err := dateCall(s, d)
+	if err != nil {
+		l.Errs = append(l.Errs, err)
+		return
+	}
+
+	l.push(n)
 }
 
 // ExitMulOps is called when production multiply op is exited.
@@ -103,6 +189,7 @@ func (l *Listener) ExitSubOps(c *parser.SubOpsContext) {
 // ExitLiteralOps is called when production LiteralOps is exited.
 func (l *Listener) ExitLiteralOps(c *parser.LiteralOpsContext) {
 	right, left := l.pop(), l.pop()
+
 	n := Node{
 		Func:  opDic[c.LiteralOp().GetText()],
 		Left:  left,
@@ -128,13 +215,31 @@ func (l *Listener) ExitStringOps(c *parser.StringOpsContext) {
 		Right: right,
 	}
 
+	// Compile regular expressions, so bad patterns fail at parse time.
+	if n.Func == RegexOp || n.Func == NotRegexOp {
+		re, err := Regexp(right)
+		if e, ok := err.(RegexError); ok {
+			e.Line = c.LiteralValue().GetStart().GetLine()
+			e.Column = c.LiteralValue().GetStart().GetColumn()
+
+			l.Errs = append(l.Errs, e)
+			return
+		}
+
+		right.Right = re
+		n.Right = right
+	}
+
 	l.push(n)
 }
 
 // ExitLike is called when production Like is exited.
 func (l *Listener) ExitLike(c *parser.LikeContext) {
 	right, left := l.pop(), l.pop()
-	op := ternaryOp(c.KeyNot() == nil, LikeOp, NotLikeOp)
+
+	// Check the keyword, like, ilike, contains, startswith or endswith.
+	keyword := strings.ToLower(c.LikeOp().GetText())
+	op := ternaryOp(c.KeyNot() == nil, likeOps[keyword][0], likeOps[keyword][1])
 
 	// Check right op is a string.
 	if right.Func != StringOp {
@@ -142,6 +247,16 @@ func (l *Listener) ExitLike(c *parser.LikeContext) {
 		return
 	}
 
+	// Compile like patterns, so walkers matching strings do not need to.
+	if keyword == "like" || keyword == "ilike" {
+		re, err := LikeRegexp(right, keyword == "ilike")
+		if err != nil {
+			l.Errs = append(l.Errs, err)
+			return
+		}
+		right.Right = re
+	}
+
 	n := Node{
 		Func:  op,
 		Left:  left,
@@ -154,6 +269,24 @@ func (l *Listener) ExitLike(c *parser.LikeContext) {
 // ExitIsLiteral is called when production IsLiteral is exited.
 func (l *Listener) ExitIsLiteral(c *parser.IsLiteralContext) {
 	right, left := l.pop(), l.pop()
+
+	// Check for `is true` and `is false`, `is not true` is true for null
+	// values, like in SQL.
+	if right.Func == BooleanOp {
+		op := ternaryOp(right.Left.(bool), IsTrueOp, IsFalseOp)
+		if c.KeyNot() != nil {
+			op = ternaryOp(right.Left.(bool), IsNotTrueOp, IsNotFalseOp)
+		}
+
+		n := Node{
+			Func: op,
+			Left: left,
+		}
+
+		l.push(n)
+		return
+	}
+
 	op := ternaryOp(c.KeyNot() == nil, EqOp, NotEqOp)
 
 	n := Node{
@@ -180,11 +313,26 @@ func (l *Listener) ExitIsNull(c *parser.IsNullContext) {
 
 // ExitIn is called when production In is exited.
 func (l *Listener) ExitIn(c *parser.InContext) {
+	l.literals = l.popLiterals(l.literals[:0])
+
+	// Literals are popped in reverse order.
+	list := l.newNodes(len(l.literals))
+	for i, v := range l.literals {
+		list[len(list)-1-i] = v
+	}
+
+	left := l.pop()
+
 	right := Node{
 		Func:  ArrayOp,
-		Right: l.popLiterals([]Node{}),
+		Right: list,
 	}
-	left := l.pop()
+
+	// Index large lists for fast membership checks.
+	if len(list) >= SetThreshold {
+		right.Left = NewSet(list)
+	}
+
 	op := ternaryOp(c.KeyNot() == nil, InOp, NotInOp)
 
 	n := Node{
@@ -196,12 +344,73 @@ func (l *Listener) ExitIn(c *parser.InContext) {
 	l.push(n)
 }
 
+// ExitWithin is called when production Within is exited.
+func (l *Listener) ExitWithin(c *parser.WithinContext) {
+	list := l.newNodes(3)
+	list[2], list[1] = l.pop(), l.pop()
+	left := l.pop()
+
+	// The distance is a number, directly followed by an optional unit.
+	s := c.Distance().GetText()
+	meters, ok := parseDistance(s)
+	if !ok {
+		l.Errs = append(l.Errs, UnexpectedLiteralError{ExpectedType: "distance", Literal: s})
+		return
+	}
+	list[0] = Node{Func: NumberOp, Left: meters}
+
+	op := ternaryOp(c.KeyNot() == nil, WithinOp, NotWithinOp)
+	l.exitWithin(op, left, list, []string{"distance", "latitude", "longitude"})
+}
+
+// ExitWithinBox is called when production WithinBox is exited.
+func (l *Listener) ExitWithinBox(c *parser.WithinBoxContext) {
+	list := l.newNodes(4)
+	for i := len(list) - 1; i >= 0; i-- {
+		list[i] = l.pop()
+	}
+	left := l.pop()
+
+	op := ternaryOp(c.KeyNot() == nil, WithinBoxOp, NotWithinBoxOp)
+	l.exitWithin(op, left, list, []string{"latitude", "longitude", "latitude", "longitude"})
+}
+
+// exitWithin pushes a geo operator node, the list literals are a distance in
+// meters and a (lat, lon) point, or the south west and the north east
+// (lat, lon) corners of a bounding box.
+func (l *Listener) exitWithin(op string, left Node, list []Node, kinds []string) {
+	// Check the literals are numbers in range.
+	limits := map[string]float64{"latitude": 90, "longitude": 180}
+	for i, v := range list {
+		f, ok := v.Left.(float64)
+		limit, limited := limits[kinds[i]]
+		if v.Func != NumberOp || !ok || (limited && math.Abs(f) > limit) {
+			l.Errs = append(l.Errs, UnexpectedLiteralError{ExpectedType: kinds[i], Literal: v.Left})
+			return
+		}
+	}
+
+	// The south west corner of a box is south of the north east corner.
+	if len(list) == 4 && list[0].Left.(float64) > list[2].Left.(float64) {
+		l.Errs = append(l.Errs, UnexpectedLiteralError{ExpectedType: "north east latitude", Literal: list[2].Left})
+		return
+	}
+
+	l.push(Node{
+		Func:  op,
+		Left:  left,
+		Right: Node{Func: ArrayOp, Right: list},
+	})
+}
+
 // ExitBetween is called when production Between is exited.
 func (l *Listener) ExitBetween(c *parser.BetweenContext) {
-	nodes := []Node{l.pop(), l.pop()}
+	nodes := l.newNodes(2)
+	nodes[1], nodes[0] = l.pop(), l.pop()
+
 	right := Node{
 		Func:  ArrayOp,
-		Right: []Node{nodes[1], nodes[0]},
+		Right: nodes,
 	}
 
 	left := l.pop()
@@ -276,6 +485,32 @@ func (l *Listener) exitLiteral(f string, v interface{}) {
 	l.push(n)
 }
 
+// parseDistance parses a distance, a number directly followed by an optional
+// unit, like `5km`, into meters.
+func parseDistance(s string) (float64, bool) {
+	number := strings.TrimRightFunc(s, unicode.IsLetter)
+	meters, err := strconv.ParseFloat(number, 64)
+	if err != nil {
+		return 0, false
+	}
+
+	if unit := s[len(number):]; unit != "" {
+		m, ok := distanceUnits[strings.ToLower(unit)]
+		if !ok {
+			return 0, false
+		}
+		meters *= m
+	}
+
+	return meters, true
+}
+
+// unquote returns the value of a string literal, the literal must be a string
+// of format \'.*'\, length must be greater or equal to 2.
+func unquote(s string) string {
+	return strings.Replace(s[1:len(s)-1], "''", "'", -1)
+}
+
 // ternaryOp return lh if conditional is true, rh o/w.
 func ternaryOp(conditional bool, lh string, rh string) string {
 	if conditional {
@@ -287,17 +522,30 @@ func ternaryOp(conditional bool, lh string, rh string) string {
 
 // popLiterals collect literal values, and create args list.
 func (l *Listener) popLiterals(in []Node) (out []Node) {
-	// Get a literal from stack.
-	p := l.pop()
+	// Pop literals from the stack, lists may be very long, so we loop
+	// instead of recursing.
+	for {
+		p := l.pop()
+
+		// If p is not a literal, add it back to stack and exit.
+		if p.Func != StringOp && p.Func != NumberOp && p.Func != DurationOp && p.Func != BooleanOp &&
+			p.Func != DateOp && p.Func != NowOp {
+			l.push(p)
+			return in
+		}
+
+		in = append(in, p)
+	}
+}
 
-	// Run recurtion on literals.
-	if p.Func == StringOp || p.Func == NumberOp {
-		return l.popLiterals(append(in, p))
+// newNodes is a helper function for allocating a list of nodes, using the
+// listener arena if set.
+func (l *Listener) newNodes(size int) []Node {
+	if l.arena == nil {
+		return make([]Node, size)
 	}
 
-	// If p is not a literal, add it back to stack and exit.
-	l.push(p)
-	return in
+	return l.arena.alloc(size)
 }
 
 // push is a helper function for pushing new node to the listener Stack.
@@ -0,0 +1,109 @@
+// Copyright 2018 Yaacov Zamir <kobi.zamir@gmail.com>
+// and other contributors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tsl
+
+// Prepare returns a copy of a tree with the regular expression and LIKE pattern
+// literals compiled, date literals parsed, and large IN lists indexed.
+//
+// The compiled expressions are stored in the Right field of the string literal
+// nodes, so walkers never need to recompile them, date literals get their time
+// in the Right field, and IN lists of SetThreshold literals or more get a Set in
+// the Left field of the array node. Trees returned by ParseTSL are already prepared,
+// Prepare is needed only for trees built by hand.
+func Prepare(n Node) (Node, error) {
+	switch n.Func {
+	case IdentOp, StringOp, NumberOp, DurationOp, NowOp, BooleanOp, NullOp:
+		// This are our leafs.
+		return n, nil
+	case DateOp:
+		t, err := Date(n)
+		if err != nil {
+			return n, err
+		}
+
+		n.Right = t
+		return n, nil
+	case ArrayOp:
+		// Prepare the date literals of between limits, copying the list
+		// before changing it.
+		list, copied := n.Right.([]Node), false
+		for i, v := range list {
+			if v.Func != DateOp {
+				continue
+			}
+
+			v, err := Prepare(v)
+			if err != nil {
+				return n, err
+			}
+			if !copied {
+				list, copied = append([]Node{}, list...), true
+			}
+			list[i] = v
+		}
+
+		n.Right = list
+		return n, nil
+	case RegexOp, NotRegexOp:
+		if r, ok := n.Right.(Node); ok && r.Func == StringOp {
+			re, err := Regexp(r)
+			if err != nil {
+				return n, err
+			}
+
+			r.Right = re
+			n.Right = r
+			return n, nil
+		}
+	case LikeOp, NotLikeOp, ILikeOp, NotILikeOp:
+		if r, ok := n.Right.(Node); ok && r.Func == StringOp {
+			re, err := LikeRegexp(r, n.Func == ILikeOp || n.Func == NotILikeOp)
+			if err != nil {
+				return n, err
+			}
+
+			r.Right = re
+			n.Right = r
+			return n, nil
+		}
+	case InOp, NotInOp:
+		if r, ok := n.Right.(Node); ok && r.Func == ArrayOp {
+			if list := r.Right.([]Node); len(list) >= SetThreshold {
+				r.Left = NewSet(list)
+				n.Right = r
+			}
+			return n, nil
+		}
+	}
+
+	// Prepare the left and right sub trees.
+	if l, ok := n.Left.(Node); ok {
+		l, err := Prepare(l)
+		if err != nil {
+			return n, err
+		}
+		n.Left = l
+	}
+	if r, ok := n.Right.(Node); ok {
+		r, err := Prepare(r)
+		if err != nil {
+			return n, err
+		}
+		n.Right = r
+	}
+
+	return n, nil
+}
@@ -0,0 +1,138 @@
+// Copyright 2018 Yaacov Zamir <kobi.zamir@gmail.com>
+// and other contributors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tsl
+
+import (
+	"strconv"
+
+	"github.com/antlr/antlr4/runtime/Go/antlr"
+
+	"github.com/yaacov/tree-search-language/pkg/parser"
+)
+
+// OrderBy is a sort key of a query.
+type OrderBy struct {
+	Field string // the field name.
+	Desc  bool   // sort in descending order.
+}
+
+// Query is a TSL filter tree, with optional projection, order by, limit and
+// offset clauses.
+type Query struct {
+	Fields  []string  // the projected fields, nil if all fields are selected.
+	Filter  Node      // the filter tree, Node{} if the query has no filter.
+	OrderBy []OrderBy // the sort keys, in order.
+	Limit   int64     // the maximum number of results, zero if not limited.
+	Offset  int64     // the number of results to skip.
+}
+
+// HasFilter checks if the query has a filter tree.
+func (q Query) HasFilter() bool {
+	return q.Filter.Func != ""
+}
+
+// ParseQuery parses a TSL phrase with an optional projection prefix, and
+// optional order by, limit and offset clauses, in this order, into a query:
+//
+//  fields title, author where name like 'jo%' order by spec.pages desc limit 10 offset 20
+//
+// The phrase is optional, for example `fields title order by name limit 10`
+// is a query without a filter.
+func ParseQuery(input string) (q Query, err error) {
+	// Setup the ErrorListener.
+	errorListener := NewErrorListener()
+
+	// Create the Lexer.
+	lexer := parser.NewTSLLexer(antlr.NewInputStream(input))
+	lexer.RemoveErrorListeners()
+	lexer.AddErrorListener(errorListener)
+	stream := antlr.NewCommonTokenStream(lexer, antlr.TokenDefaultChannel)
+
+	// Create the Parser.
+	p := parser.NewTSLParser(stream)
+	p.RemoveErrorListeners()
+	p.AddErrorListener(errorListener)
+
+	// Parse the query (by walking the tree).
+	var listener Listener
+	tree := p.Query().(*parser.QueryContext)
+	antlr.ParseTreeWalkerDefault.Walk(&listener, tree)
+
+	// Check for errors.
+	if err = errorListener.Err; err != nil {
+		return
+	}
+
+	// Get the filter tree.
+	if tree.Expr() != nil {
+		if q.Filter, err = listener.GetTree(); err != nil {
+			return
+		}
+	}
+
+	err = parseClauses(tree, &q)
+	return
+}
+
+// parseClauses reads the projection, order by, limit and offset clauses into
+// a query.
+func parseClauses(tree *parser.QueryContext, q *Query) (err error) {
+	if f, ok := tree.Fields().(*parser.FieldsContext); ok {
+		for _, c := range f.AllColumnName() {
+			q.Fields = append(q.Fields, c.GetText())
+		}
+	}
+
+	if o, ok := tree.OrderBy().(*parser.OrderByContext); ok {
+		for _, k := range o.AllSortKey() {
+			k := k.(*parser.SortKeyContext)
+			q.OrderBy = append(q.OrderBy, OrderBy{Field: k.ColumnName().GetText(), Desc: k.K_DESC() != nil})
+		}
+	}
+
+	if l, ok := tree.Limit().(*parser.LimitContext); ok {
+		if q.Limit, err = clauseNumber(l.NUMERIC_LITERAL()); err != nil {
+			return
+		}
+		if q.Limit == 0 {
+			return clauseError(l.NUMERIC_LITERAL(), "expected a positive limit")
+		}
+	}
+
+	if o, ok := tree.Offset().(*parser.OffsetContext); ok {
+		if q.Offset, err = clauseNumber(o.NUMERIC_LITERAL()); err != nil {
+			return
+		}
+	}
+
+	return nil
+}
+
+// clauseNumber reads the non negative integer of a limit or an offset clause.
+func clauseNumber(n antlr.TerminalNode) (int64, error) {
+	i, err := strconv.ParseInt(n.GetText(), 10, 64)
+	if err != nil {
+		return 0, clauseError(n, "expected an integer")
+	}
+
+	return i, nil
+}
+
+// clauseError returns a parse error at a clause token.
+func clauseError(n antlr.TerminalNode, msg string) error {
+	t := n.GetSymbol()
+	return ParseError{line: t.GetLine(), column: t.GetColumn(), msg: msg}
+}
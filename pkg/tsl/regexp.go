@@ -0,0 +1,52 @@
+// Copyright 2018 Yaacov Zamir <kobi.zamir@gmail.com>
+// and other contributors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tsl
+
+import (
+	"regexp"
+	"regexp/syntax"
+)
+
+// Regexp returns the compiled regular expression of a string literal node,
+// the expression is compiled if the node was not prepared.
+func Regexp(n Node) (*regexp.Regexp, error) {
+	// Check for a prepared node.
+	if re, ok := n.Right.(*regexp.Regexp); ok {
+		return re, nil
+	}
+
+	pattern, ok := n.Left.(string)
+	if !ok {
+		return nil, UnexpectedLiteralError{ExpectedType: "string", Literal: n.Left}
+	}
+
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, regexError(pattern, err)
+	}
+
+	return re, nil
+}
+
+// regexError converts a regular expression compiler error to a RegexError.
+func regexError(pattern string, err error) RegexError {
+	msg := err.Error()
+	if e, ok := err.(*syntax.Error); ok {
+		msg = e.Code.String()
+	}
+
+	return RegexError{Pattern: pattern, Msg: msg}
+}
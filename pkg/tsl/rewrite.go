@@ -0,0 +1,77 @@
+// Copyright 2018 Yaacov Zamir <kobi.zamir@gmail.com>
+// and other contributors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tsl
+
+// Rewrite returns a copy of a tree, rebuilt bottom-up, replacing nodes using a
+// replacement function.
+//
+// The replacement function is called for each node after its children were
+// rewritten, if it returns true, the node is replaced by the returned node. The
+// rewritten tree is prepared, so compiled regular expressions, parsed dates and
+// IN list sets of replaced literals are rebuilt.
+//
+// Example:
+//  	// Rename the `name` field, and replace `=` operators with `ilike`.
+//  	tree, err = tsl.Rewrite(tree, func(n tsl.Node) (tsl.Node, bool) {
+//  		switch {
+//  		case n.Func == tsl.IdentOp && n.Left == "name":
+//  			n.Left = "full_name"
+//  			return n, true
+//  		case n.Func == tsl.EqOp:
+//  			n.Func = tsl.ILikeOp
+//  			return n, true
+//  		}
+//  		return n, false
+//  	})
+//
+func Rewrite(n Node, replace func(Node) (Node, bool)) (Node, error) {
+	return Prepare(rewrite(n, replace))
+}
+
+// rewrite rebuilds a tree bottom-up, replacing nodes.
+func rewrite(n Node, replace func(Node) (Node, bool)) Node {
+	if l, ok := n.Left.(Node); ok {
+		n.Left = rewrite(l, replace)
+	}
+	switch r := n.Right.(type) {
+	case Node:
+		n.Right = rewrite(r, replace)
+	case []Node:
+		list := make([]Node, len(r))
+		for i, e := range r {
+			list[i] = rewrite(e, replace)
+		}
+		n.Right = list
+
+		// The list may have changed, the set is rebuilt by Prepare.
+		if _, ok := n.Left.(*Set); ok {
+			n.Left = nil
+		}
+	}
+
+	m, ok := replace(n)
+	if !ok {
+		return n
+	}
+
+	// Drop the compiled expressions and parsed dates of replaced literals,
+	// they are rebuilt by Prepare.
+	if m.Func == StringOp || m.Func == DateOp {
+		m.Right = nil
+	}
+
+	return m
+}
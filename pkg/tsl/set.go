@@ -0,0 +1,80 @@
+// Copyright 2018 Yaacov Zamir <kobi.zamir@gmail.com>
+// and other contributors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tsl
+
+// SetThreshold is the minimal length of IN lists indexed by a Set.
+const SetThreshold = 16
+
+// Set is a hash set of the literals of a large IN list.
+//
+// The parser stores a Set in the Left field of the array nodes of IN lists
+// with SetThreshold literals or more, evaluators can use it for O(1)
+// membership checks, while other walkers keep using the literal list in
+// the Right field.
+type Set struct {
+	strings map[string]struct{}
+	numbers map[float64]struct{}
+	others  int // literals that are not strings or numbers.
+}
+
+// NewSet creates a set of a literal list.
+func NewSet(list []Node) *Set {
+	s := &Set{
+		strings: map[string]struct{}{},
+		numbers: map[float64]struct{}{},
+	}
+
+	for _, n := range list {
+		switch v := n.Left.(type) {
+		case string:
+			s.strings[v] = struct{}{}
+		case float64:
+			s.numbers[v] = struct{}{}
+		default:
+			s.others++
+		}
+	}
+
+	return s
+}
+
+// Strings returns true if all the set literals are strings.
+func (s *Set) Strings() bool {
+	return len(s.numbers) == 0 && s.others == 0
+}
+
+// Numbers returns true if all the set literals are numbers.
+func (s *Set) Numbers() bool {
+	return len(s.strings) == 0 && s.others == 0
+}
+
+// HasString checks if a string is in the set.
+func (s *Set) HasString(v string) bool {
+	_, ok := s.strings[v]
+	return ok
+}
+
+// HasNumber checks if a number is in the set.
+func (s *Set) HasNumber(v float64) bool {
+	_, ok := s.numbers[v]
+	return ok
+}
+
+// MarshalJSON omits the set from JSON trees, it is rebuilt from the literal
+// list by Prepare.
+func (s *Set) MarshalJSON() ([]byte, error) {
+	return []byte("null"), nil
+}
@@ -17,11 +17,16 @@ package tsl
 
 import (
 	"encoding/json"
+	"fmt"
+	"reflect"
+	"regexp"
 	"strings"
 	"testing"
+	"time"
 	"unicode"
 
 	"github.com/antlr/antlr4/runtime/Go/antlr"
+	"gopkg.in/yaml.v2"
 
 	"github.com/yaacov/tree-search-language/pkg/parser"
 )
@@ -116,3 +121,952 @@ func TestListenerOpOrder(t *testing.T) {
 		t.Fail()
 	}
 }
+
+func TestListenerRegex(t *testing.T) {
+	// Test a valid pattern is compiled at parse time.
+	n, err := parseTSL("a ~= '^h(el)+o' and b ~! 'x'")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, c := range []Node{n.Left.(Node), n.Right.(Node)} {
+		if _, ok := c.Right.(Node).Right.(*regexp.Regexp); !ok {
+			t.Errorf("expected a compiled regexp in %v", c)
+		}
+	}
+
+	// Test a bad pattern fails with its position.
+	_, err = parseTSL("a = 1 and b ~= '(h'")
+	e, ok := err.(RegexError)
+	if !ok {
+		t.Fatalf("expected a regex error instead it was %v", err)
+	}
+	if e.Pattern != "(h" || e.Line != 1 || e.Column != 15 {
+		t.Errorf("unexpected regex error %+v", e)
+	}
+}
+
+func TestListenerDate(t *testing.T) {
+	tests := map[string]string{
+		"a > 2019-01-01":                        DateOp,
+		"a <= 2019-01-01T10:00:00+02:00":        DateOp,
+		"a = 2019-01-01T10:00:00.5Z":            DateOp,
+		"a > '2019-01-01'":                      StringOp,
+		"a = 'March'":                           StringOp,
+		"a like '2019-01-01'":                   StringOp,
+		"a between 2019-01-01 and 2020-01-01":   DateOp,
+		"a in (2019-01-01, date('2020-01-01'))": DateOp,
+	}
+
+	for input, want := range tests {
+		n, err := parseTSL(input)
+		if err != nil {
+			t.Fatalf("failed to parse %s: %v", input, err)
+		}
+
+		r := n.Right.(Node)
+		if r.Func == ArrayOp {
+			r = r.Right.([]Node)[0]
+		}
+		if r.Func != want {
+			t.Errorf("%s: expected a %s literal instead it was %v", input, want, r)
+		}
+		if _, ok := r.Right.(time.Time); ok != (want == DateOp) {
+			t.Errorf("%s: unexpected literal time %v", input, r.Right)
+		}
+	}
+
+	// Test date only literals are midnight UTC.
+	n, _ := parseTSL("a > 2019-03-01")
+	if d, err := Date(n.Right.(Node)); err != nil || !d.Equal(time.Date(2019, 3, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("unexpected date %v, %v", d, err)
+	}
+
+	// Test bad dates.
+	for _, input := range []string{"a = 2019-13-01", "a = 2019-01-01T25:00:00Z", "a = 2019-01-01T10:00:00"} {
+		if _, err := parseTSL(input); err == nil {
+			t.Errorf("%s: expected a parse error", input)
+		}
+	}
+}
+
+func TestListenerDuration(t *testing.T) {
+	tests := map[string]time.Duration{
+		"a > 5m":                 5 * time.Minute,
+		"a > 2h30m":              150 * time.Minute,
+		"a <= 7d":                7 * Day,
+		"a = -1.5h":              -90 * time.Minute,
+		"a between 1d12h and 2d": 36 * time.Hour,
+		"a in (10ms, 1s, 1m)":    10 * time.Millisecond,
+	}
+
+	for input, want := range tests {
+		n, err := parseTSL(input)
+		if err != nil {
+			t.Fatalf("failed to parse %s: %v", input, err)
+		}
+
+		r := n.Right.(Node)
+		if r.Func == ArrayOp {
+			r = r.Right.([]Node)[0]
+		}
+		if r.Func != DurationOp || r.Right != want || r.Left != want.Seconds() {
+			t.Errorf("%s: expected a %v duration literal instead it was %v", input, want, r)
+		}
+	}
+
+	// Test bad durations and numbers followed by identifiers.
+	for _, input := range []string{"a > 5x", "a > 5 m", "a > 1.5d", "a > 1e5s"} {
+		if _, err := parseTSL(input); err == nil {
+			t.Errorf("%s: expected a parse error", input)
+		}
+	}
+
+	// Test formatted durations are parsed back.
+	for _, d := range []time.Duration{0, 3 * Day, -90 * time.Minute, 1500 * time.Microsecond, 7} {
+		if got, err := ParseDuration(FormatDuration(d)); err != nil || got != d {
+			t.Errorf("%v: formatted as %s, parsed as %v, %v", d, FormatDuration(d), got, err)
+		}
+	}
+}
+
+func TestListenerILike(t *testing.T) {
+	tests := map[string]string{
+		"name ilike 'jo%'":            ILikeOp,
+		"name not ILike 'jo%'":        NotILikeOp,
+		"name like 'jo%'":             LikeOp,
+		"ilike = 'a' and b ilike 'c'": EqOp,
+		"name contains 'jo%'":         ContainsOp,
+		"name not StartsWith 'jo'":    NotStartsWithOp,
+		"name endswith 'e'":           EndsWithOp,
+		"contains = 'a'":              EqOp,
+	}
+
+	for input, want := range tests {
+		n, err := parseTSL(input)
+		if err != nil {
+			t.Fatalf("failed to parse %s: %v", input, err)
+		}
+		if n.Func == AndOp {
+			n = n.Left.(Node)
+		}
+		if n.Func != want {
+			t.Errorf("%s: expected %s instead it was %s", input, want, n.Func)
+		}
+	}
+
+	// Test case insensitive patterns are compiled at parse time.
+	n, _ := parseTSL("name ilike 'jo%'")
+	if re, ok := n.Right.(Node).Right.(*regexp.Regexp); !ok || !re.MatchString("JOE") {
+		t.Errorf("expected a compiled case insensitive pattern in %v", n)
+	}
+}
+
+func TestListenerFunctions(t *testing.T) {
+	tests := map[string]string{
+		"len(title) > 10":           LenOp,
+		"LOWER (author) = 'joe'":    LowerOp,
+		"upper(lower(name)) = 'A'":  UpperOp,
+		"abs(a - b) < 2":            AbsOp,
+		"round(price * 1.1) = 3":    RoundOp,
+		"semver(version) > '1.2.3'": SemverOp,
+		"len(title) + 1 > 10":       AddOp,
+		"len = 3 and lower is null": IdentOp,
+	}
+
+	for input, want := range tests {
+		n, err := parseTSL(input)
+		if err != nil {
+			t.Fatalf("failed to parse %s: %v", input, err)
+		}
+		if n.Func == AndOp {
+			n = n.Left.(Node)
+		}
+		if l := n.Left.(Node); l.Func != want {
+			t.Errorf("%s: expected %s instead it was %s", input, want, l.Func)
+		}
+	}
+
+	// Test function arguments are math expressions.
+	if _, err := parseTSL("len(a = 1)"); err == nil {
+		t.Errorf("expected a parse error for a function of a logical expression")
+	}
+}
+
+func TestListenerDateCalls(t *testing.T) {
+	tests := map[string]struct {
+		op     string
+		offset time.Duration
+	}{
+		"created > now()":                     {NowOp, 0},
+		"created > NOW() - 7d":                {NowOp, -7 * 24 * time.Hour},
+		"created < now()+1h30m":               {NowOp, 90 * time.Minute},
+		"created = date('2020-01-01')":        {DateOp, 0},
+		"created >= date('2020-01-01') - 12h": {DateOp, 0},
+		"title = 'now()' and created < now()": {StringOp, 0},
+	}
+
+	for input, want := range tests {
+		n, err := parseTSL(input)
+		if err != nil {
+			t.Fatalf("failed to parse %s: %v", input, err)
+		}
+		if n.Func == AndOp {
+			n = n.Left.(Node)
+		}
+		r := n.Right.(Node)
+		if r.Func != want.op {
+			t.Errorf("%s: expected %s instead it was %s", input, want.op, r.Func)
+		}
+		if d, ok := r.Right.(time.Duration); want.op == NowOp && (!ok || d != want.offset) {
+			t.Errorf("%s: expected an offset of %s instead it was %v", input, want.offset, r.Right)
+		}
+	}
+
+	// Test date offsets shift the date.
+	n, err := parseTSL("created >= date('2020-01-01') - 12h")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s := n.Right.(Node).Left; s != "2019-12-31T12:00:00Z" {
+		t.Errorf("expected a shifted date instead it was %v", s)
+	}
+
+	// Test offsets are durations, and dates are valid.
+	for _, input := range []string{"a > now() - 7", "a > now(1)", "a > date('xx')"} {
+		if _, err := parseTSL(input); err == nil {
+			t.Errorf("%s: expected a parse error", input)
+		}
+	}
+}
+
+func TestListenerWithin(t *testing.T) {
+	tests := map[string]struct {
+		op     string
+		values []float64
+	}{
+		"location within 5km of (32.1, 34.8)":      {WithinOp, []float64{5000, 32.1, 34.8}},
+		"location WITHIN 500 of (32.1, -34.8)":     {WithinOp, []float64{500, 32.1, -34.8}},
+		"location not within 2mi of (32.1, 34.8)":  {NotWithinOp, []float64{3218.688, 32.1, 34.8}},
+		"location within (32.0, 34.7, 32.2, 34.9)": {WithinBoxOp, []float64{32, 34.7, 32.2, 34.9}},
+		"location not within (-1, 170, 1, -170)":   {NotWithinBoxOp, []float64{-1, 170, 1, -170}},
+	}
+
+	for input, want := range tests {
+		n, err := parseTSL(input)
+		if err != nil {
+			t.Fatalf("failed to parse %s: %v", input, err)
+		}
+		if n.Func != want.op {
+			t.Errorf("%s: expected %s instead it was %s", input, want.op, n.Func)
+		}
+		for i, v := range n.Right.(Node).Right.([]Node) {
+			if v.Left != want.values[i] {
+				t.Errorf("%s: expected %v instead it was %v", input, want.values[i], v.Left)
+			}
+		}
+	}
+
+	// Test within is an identifier outside of geo operators.
+	if n, err := parseTSL("within in (1, 2)"); err != nil || n.Left.(Node).Left != "within" {
+		t.Errorf("expected a within identifier, got %v, %v", n, err)
+	}
+
+	// Test the list sizes and ranges are checked.
+	for _, input := range []string{
+		"a within 5km of (32.1, 34.8, 1)",
+		"a within 5km of (92.1, 34.8)",
+		"a within 5parsecs of (32.1, 34.8)",
+		"a within (32.3, 34.7, 32.2, 34.9)",
+		"a within ('a', 34.7, 32.2, 34.9)",
+	} {
+		if _, err := parseTSL(input); err == nil {
+			t.Errorf("%s: expected a parse error", input)
+		}
+	}
+}
+
+func TestListenerWildcard(t *testing.T) {
+	tests := map[string]string{
+		"spec.*.status = 'ok'":   "spec.*.status",
+		"labels.* like 'prod%'":  "labels.*",
+		"a.*.b.*.c is not null":  "a.*.b.*.c",
+		"spec.pages * 2 > 10":    "spec.pages",
+		"spec . pages = 2":       "spec.pages",
+		"labels.*.name in ('a')": "labels.*.name",
+	}
+
+	for input, want := range tests {
+		n, err := parseTSL(input)
+		if err != nil {
+			t.Fatalf("failed to parse %s: %v", input, err)
+		}
+
+		l := n.Left.(Node)
+		for l.Func != IdentOp {
+			l = l.Left.(Node)
+		}
+		if l.Left != want {
+			t.Errorf("%s: expected %s instead it was %v", input, want, l.Left)
+		}
+	}
+}
+
+func TestListenerBoolean(t *testing.T) {
+	tests := map[string]bool{
+		"active = true":            true,
+		"active != FALSE":          false,
+		"active in (false, true)":  false,
+		"active not in (true)":     true,
+		"true = false and a = 'x'": false,
+	}
+
+	for input, want := range tests {
+		n, err := parseTSL(input)
+		if err != nil {
+			t.Fatalf("failed to parse %s: %v", input, err)
+		}
+		if n.Func == AndOp {
+			n = n.Left.(Node)
+		}
+
+		r := n.Right.(Node)
+		if r.Func == ArrayOp {
+			r = r.Right.([]Node)[0]
+		}
+		if r.Func != BooleanOp || r.Left != want {
+			t.Errorf("%s: expected a %v boolean literal instead it was %v", input, want, r)
+		}
+	}
+
+	// Test quoted booleans are strings, and true identifiers are fields.
+	n, _ := parseTSL("active = 'true'")
+	if r := n.Right.(Node); r.Func != StringOp {
+		t.Errorf("expected a string literal instead it was %v", r)
+	}
+	n, _ = parseTSL("true = false")
+	if l := n.Left.(Node); l.Func != IdentOp || l.Left != "true" {
+		t.Errorf("expected a true identifier instead it was %v", l)
+	}
+}
+
+func TestListenerIsBoolean(t *testing.T) {
+	tests := map[string]string{
+		"active is true":      IsTrueOp,
+		"active is FALSE":     IsFalseOp,
+		"active is not true":  IsNotTrueOp,
+		"active is not 'yes'": NotEqOp,
+	}
+
+	for input, want := range tests {
+		n, err := parseTSL(input)
+		if err != nil {
+			t.Fatalf("failed to parse %s: %v", input, err)
+		}
+		if n.Func != want {
+			t.Errorf("%s: expected %s instead it was %s", input, want, n.Func)
+		}
+	}
+
+	// Test `is not` operators are unary.
+	n, _ := parseTSL("active is not false")
+	if n.Func != IsNotFalseOp || n.Right != nil {
+		t.Errorf("expected an unary is not false operator instead it was %v", n)
+	}
+}
+
+func TestPrepare(t *testing.T) {
+	tree := Node{
+		Func: OrOp,
+		Left: Node{
+			Func:  RegexOp,
+			Left:  Node{Func: IdentOp, Left: "a"},
+			Right: Node{Func: StringOp, Left: "^h"},
+		},
+		Right: Node{
+			Func:  EqOp,
+			Left:  Node{Func: IdentOp, Left: "b"},
+			Right: Node{Func: StringOp, Left: "^h"},
+		},
+	}
+
+	n, err := Prepare(tree)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := n.Left.(Node).Right.(Node).Right.(*regexp.Regexp); !ok {
+		t.Errorf("expected a compiled regexp in %v", n.Left)
+	}
+	if n.Right.(Node).Right.(Node).Right != nil {
+		t.Errorf("unexpected compiled string in %v", n.Right)
+	}
+
+	// Test the original tree is not changed.
+	if tree.Left.(Node).Right.(Node).Right != nil {
+		t.Errorf("unexpected change in the original tree")
+	}
+
+	// Test large lists are indexed.
+	list := []Node{}
+	for i := 0; i < SetThreshold; i++ {
+		list = append(list, Node{Func: NumberOp, Left: float64(i)})
+	}
+	in := Node{Func: InOp, Left: Node{Func: IdentOp, Left: "c"}, Right: Node{Func: ArrayOp, Right: list}}
+	if n, err = Prepare(in); err != nil {
+		t.Fatal(err)
+	}
+	if set, ok := n.Right.(Node).Left.(*Set); !ok || !set.HasNumber(3) {
+		t.Errorf("expected an indexed list in %v", n)
+	}
+
+	// Test a bad pattern.
+	tree.Left = Node{Func: NotRegexOp, Left: tree.Left.(Node).Left, Right: Node{Func: StringOp, Left: "a("}}
+	if _, err := Prepare(tree); err == nil {
+		t.Errorf("expected a regex error")
+	}
+}
+
+func TestArena(t *testing.T) {
+	arena := NewArena()
+
+	phrases := []string{
+		"a = 'hello'",
+		"a in (1, 2, 3) and b between 4 and 5",
+		"a = 12.3e1 or b = 'world' and c = 'hello'",
+		"name ~= '^j' and city not in ('rome', 'paris')",
+	}
+
+	for i := 0; i < 2; i++ {
+		for _, phrase := range phrases {
+			want, _ := ParseTSL(phrase)
+			got, err := arena.ParseTSL(phrase)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			ws, _ := json.Marshal(want)
+			gs, _ := json.Marshal(got)
+			if string(gs) != string(ws) {
+				t.Errorf("expected %s instead it was %s", ws, gs)
+			}
+		}
+		arena.Reset()
+	}
+
+	// Test errors do not leak into the next parse.
+	if _, err := arena.ParseTSL("a = "); err == nil {
+		t.Errorf("expected a parse error")
+	}
+	if _, err := arena.ParseTSL("a = 1"); err != nil {
+		t.Errorf("unexpected error %v", err)
+	}
+}
+
+func TestListenerSet(t *testing.T) {
+	items := []string{}
+	for i := 0; i < 20000; i++ {
+		items = append(items, fmt.Sprintf("'v%d'", i))
+	}
+
+	// Test large lists are indexed.
+	n, err := parseTSL("a in (" + strings.Join(items, ", ") + ")")
+	if err != nil {
+		t.Fatal(err)
+	}
+	r := n.Right.(Node)
+	set, ok := r.Left.(*Set)
+	if !ok || len(r.Right.([]Node)) != len(items) {
+		t.Fatalf("expected an indexed list of %d literals", len(items))
+	}
+	if !set.Strings() || set.Numbers() || !set.HasString("v12345") || set.HasString("v20000") {
+		t.Errorf("unexpected set membership")
+	}
+
+	// Test small lists are not indexed.
+	n, err = parseTSL("a in ('a', 'b', 3)")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n.Right.(Node).Left != nil {
+		t.Errorf("unexpected set in a small list")
+	}
+	if s := NewSet(n.Right.(Node).Right.([]Node)); s.Strings() || s.Numbers() || !s.HasNumber(3) {
+		t.Errorf("unexpected mixed set membership")
+	}
+}
+
+func TestNodeJSON(t *testing.T) {
+	phrases := []string{
+		"a = 'hello' and not b is null",
+		"a in (1, 2, 3) or b not between 4 and 5",
+		"name ~= '^j' and title ilike '%book%'",
+		"created > '2020-01-01' and age < 1d12h and ok is true",
+		"a + 2 * b >= 10 or c = false",
+		"tags.*.name = 'go'",
+		"id in (9007199254740993, 9007199254740992, 18446744073709551615)",
+	}
+
+	for _, phrase := range phrases {
+		want, err := parseTSL(phrase)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		data, err := json.Marshal(want)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var got Node
+		if err := json.Unmarshal(data, &got); err != nil {
+			t.Fatalf("%s: %v", data, err)
+		}
+
+		// Test the decoded tree encodes the same, and is prepared.
+		if s, _ := json.Marshal(got); string(s) != string(data) {
+			t.Errorf("expected %s instead it was %s", data, s)
+		}
+		if want, _ = Prepare(want); fmt.Sprintf("%v", got) != fmt.Sprintf("%v", want) {
+			t.Errorf("expected %v instead it was %v", want, got)
+		}
+	}
+
+	// Test regular expressions, dates, durations and sets are rebuilt.
+	var n Node
+	data := `{"func":"$and","left":{"func":"$regex","left":{"func":"$ident","left":"a"},"right":{"func":"$string","left":"^x"}},
+		"right":{"func":"$lt","left":{"func":"$ident","left":"b"},"right":{"func":"$duration","left":90}}}`
+	if err := json.Unmarshal([]byte(data), &n); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := n.Left.(Node).Right.(Node).Right.(*regexp.Regexp); !ok {
+		t.Errorf("expected a compiled regular expression")
+	}
+	if d := n.Right.(Node).Right.(Node).Right; d != 90*time.Second {
+		t.Errorf("expected 1m30s duration instead it was %v", d)
+	}
+
+	list := make([]string, SetThreshold)
+	for i := range list {
+		list[i] = fmt.Sprintf(`{"func":"$number","left":%d}`, i)
+	}
+	data = `{"func":"$in","left":{"func":"$ident","left":"a"},"right":{"func":"$array","right":[` + strings.Join(list, ",") + `]}}`
+	if err := json.Unmarshal([]byte(data), &n); err != nil {
+		t.Fatal(err)
+	}
+	if set, ok := n.Right.(Node).Left.(*Set); !ok || !set.HasNumber(3) {
+		t.Errorf("expected an indexed IN list")
+	}
+
+	// Test bad trees.
+	for _, data := range []string{
+		`{"left":{"func":"$ident","left":"a"}}`,
+		`{"func":"$eq","left":{"func":"$ident","left":"a"},"right":{"func":"$number","left":"1"}}`,
+		`{"func":"$regex","left":{"func":"$ident","left":"a"},"right":{"func":"$string","left":"("}}`,
+		`{"func":"$in","left":{"func":"$ident","left":"a"},"right":{"func":"$array","right":{}}}`,
+	} {
+		if err := json.Unmarshal([]byte(data), &n); err == nil {
+			t.Errorf("expected an error decoding %s", data)
+		}
+	}
+}
+
+func TestNodeYAML(t *testing.T) {
+	phrases := []string{
+		"a = 'hello' and not b is null",
+		"a in (1, 2, 3) or b not between 4 and 5",
+		"name ~= '^j' and title ilike '%book%'",
+		"created > '2020-01-01' and age < 1d12h and ok is true",
+		"a + 2 * b >= 10 or c = false",
+		"id in (9007199254740993, 9007199254740992)",
+	}
+
+	for _, phrase := range phrases {
+		want, err := parseTSL(phrase)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		data, err := yaml.Marshal(want)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var got Node
+		if err := yaml.Unmarshal(data, &got); err != nil {
+			t.Fatalf("%s: %v", data, err)
+		}
+
+		// Test the decoded tree encodes the same, and is prepared.
+		if s, _ := yaml.Marshal(got); string(s) != string(data) {
+			t.Errorf("expected %s instead it was %s", data, s)
+		}
+		if want, _ = Prepare(want); fmt.Sprintf("%v", got) != fmt.Sprintf("%v", want) {
+			t.Errorf("expected %v instead it was %v", want, got)
+		}
+	}
+
+	// Test a hand written filter definition.
+	var n Node
+	data := `
+func: $and
+left:
+  func: $regex
+  left: {func: $ident, left: name}
+  right: {func: $string, left: ^j}
+right:
+  func: $lt
+  left: {func: $ident, left: age}
+  right: {func: $duration, left: 90}
+`
+	if err := yaml.Unmarshal([]byte(data), &n); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := n.Left.(Node).Right.(Node).Right.(*regexp.Regexp); !ok {
+		t.Errorf("expected a compiled regular expression")
+	}
+	if d := n.Right.(Node).Right.(Node).Right; d != 90*time.Second {
+		t.Errorf("expected 1m30s duration instead it was %v", d)
+	}
+
+	if err := yaml.Unmarshal([]byte("func: $eq\nleft: {func: $number, left: one}"), &n); err == nil {
+		t.Errorf("expected an error decoding a bad number")
+	}
+}
+
+// recorder records the visited nodes.
+type recorder []string
+
+func (r *recorder) Enter(n Node) { *r = append(*r, n.Func) }
+func (r *recorder) Leave(n Node) { *r = append(*r, "/"+n.Func) }
+
+func TestAccept(t *testing.T) {
+	n, err := parseTSL("a = 1 or not b in ('x', 'y')")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var r recorder
+	Accept(n, &r)
+
+	expected := "$or $eq $ident /$ident $number /$number /$eq $not $in $ident /$ident $array " +
+		"$string /$string $string /$string /$array /$in /$not /$or"
+	if s := strings.Join(r, " "); s != expected {
+		t.Errorf("expected %s instead it was %s", expected, s)
+	}
+}
+
+func TestRewrite(t *testing.T) {
+	n, err := parseTSL("name = 'joe' and (email ~= 'joe@.*' or age between 10 and 20)")
+	if err != nil {
+		t.Fatal(err)
+	}
+	original, _ := json.Marshal(n)
+
+	// Rename a field, mask the values of another and substitute an operator.
+	got, err := Rewrite(n, func(n Node) (Node, bool) {
+		switch {
+		case n.Func == IdentOp && n.Left == "name":
+			n.Left = "full_name"
+			return n, true
+		case n.Func == RegexOp:
+			n.Right = Node{Func: StringOp, Left: "^\\*+$"}
+			return n, true
+		case n.Func == BetweenOp:
+			n.Func = NotBetweenOp
+			return n, true
+		}
+		return n, false
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := removeWhitespace(`
+		{"func":"$and","left":{"func":"$eq","left":{"func":"$ident","left":"full_name"},"right":{"func":"$string","left":"joe"}},
+		"right":{"func":"$or","left":{"func":"$regex","left":{"func":"$ident","left":"email"},"right":{"func":"$string","left":"^\\*+$"}},
+		"right":{"func":"$nbetween","left":{"func":"$ident","left":"age"},"right":{"func":"$array","right":[{"func":"$number","left":10},{"func":"$number","left":20}]}}}}
+	`)
+	if s, _ := json.Marshal(got); string(s) != expected {
+		t.Errorf("expected %s instead it was %s", expected, s)
+	}
+
+	// Test the replaced literal is compiled, and the original tree is not changed.
+	re, ok := got.Right.(Node).Left.(Node).Right.(Node).Right.(*regexp.Regexp)
+	if !ok || !re.MatchString("***") || re.MatchString("joe@x") {
+		t.Errorf("expected the replaced regular expression to be compiled")
+	}
+	if s, _ := json.Marshal(n); string(s) != string(original) {
+		t.Errorf("expected the original tree %s instead it was %s", original, s)
+	}
+
+	// Test replacement errors are reported.
+	_, err = Rewrite(n, func(n Node) (Node, bool) {
+		if n.Func == StringOp && n.Left == "joe@.*" {
+			return Node{Func: StringOp, Left: "("}, true
+		}
+		return n, false
+	})
+	if _, ok := err.(RegexError); !ok {
+		t.Errorf("expected a regex error instead it was %v", err)
+	}
+}
+
+func TestGetIdentifiers(t *testing.T) {
+	tests := []struct {
+		phrase   string
+		expected string
+	}{
+		{phrase: "name = 'joe' and (age > 18 or name is null)", expected: "name age"},
+		{phrase: "not (b in (1, 2) or a + b * c > 10)", expected: "b a c"},
+		{phrase: "spec.pages between 1 and 2 or tags.*.name = 'go'", expected: "spec.pages tags.*.name"},
+	}
+
+	for _, tt := range tests {
+		n, err := parseTSL(tt.phrase)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if s := strings.Join(GetIdentifiers(n), " "); s != tt.expected {
+			t.Errorf("%s: expected %s instead it was %s", tt.phrase, tt.expected, s)
+		}
+	}
+}
+
+func TestCanonicalize(t *testing.T) {
+	same := [][]string{
+		{"a = 1 and b = 2", "b = 2 and a = 1", "b = 2 and a = 1 and b = 2"},
+		{"a = 1 or (b = 2 or c = 3)", "(c = 3 or a = 1) or b = 2"},
+		{"a in ('x', 'y', 'x')", "a in ('y', 'x')"},
+		{"not (a + b * c > 1 and d = -0)", "not (d = 0 and (c * b) + a > 1)"},
+	}
+
+	for _, phrases := range same {
+		want, _ := parseTSL(phrases[0])
+		want = Canonicalize(want)
+		for _, phrase := range phrases[1:] {
+			got, err := parseTSL(phrase)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got = Canonicalize(got)
+
+			if !Equal(got, want) || Hash(got) != Hash(want) {
+				t.Errorf("expected %s to equal %s", phrase, phrases[0])
+			}
+		}
+	}
+
+	different := []string{"a = 1 and b = 2", "a = 1 or b = 2", "a = '1' and b = 2", "a in ('x')", "a - b > 1", "b - a > 1",
+		"a = 9007199254740993", "a = 9007199254740992"}
+	seen := map[uint64]string{}
+	for _, phrase := range different {
+		n, _ := parseTSL(phrase)
+		h := Hash(Canonicalize(n))
+		if other, ok := seen[h]; ok {
+			t.Errorf("expected %s to differ from %s", phrase, other)
+		}
+		seen[h] = phrase
+	}
+
+	// Test large integers are not deduplicated.
+	n, _ := parseTSL("a in (9007199254740993, 9007199254740992)")
+	if list := Canonicalize(n).Right.(Node).Right.([]Node); len(list) != 2 {
+		t.Errorf("expected two integers instead it was %v", list)
+	}
+
+	// Test equality ignores prepared literals.
+	a, _ := parseTSL("a ~= 'x'")
+	if b := (Node{Func: RegexOp, Left: Node{Func: IdentOp, Left: "a"}, Right: Node{Func: StringOp, Left: "x"}}); !Equal(a, b) {
+		t.Errorf("expected a prepared tree to equal a hand built tree")
+	}
+}
+
+func TestBuilder(t *testing.T) {
+	tests := []struct {
+		builder Builder
+		phrase  string
+	}{
+		{builder: Eq("author", "Joe").And(Gt("spec.pages", 100)), phrase: "author = 'Joe' and spec.pages > 100"},
+		{builder: Ne("a", int64(1)).Or(Lte("b", 2.5)).And(Gte("c", uint8(3))), phrase: "(a != 1 or b <= 2.5) and c >= 3"},
+		{builder: Lt("created", "2020-01-01").And(Between("age", 1*time.Hour, 2*time.Hour)), phrase: "created < '2020-01-01' and age between 1h and 2h"},
+		{builder: Between("created", "2020-01-01", "2021-01-01"), phrase: "created between '2020-01-01' and '2021-01-01'"},
+		{builder: Regex("name", "^j").And(ILike("title", "%go%")).Or(Like("title", "go%")), phrase: "name ~= '^j' and title ilike '%go%' or title like 'go%'"},
+		{builder: Contains("a", "x").And(StartsWith("b", "y")).And(EndsWith("c", "z")), phrase: "a contains 'x' and b startswith 'y' and c endswith 'z'"},
+		{builder: In("a", "x", "y").And(NotIn("b", 1, 2)), phrase: "a in ('x', 'y') and b not in (1, 2)"},
+		{builder: IsNull("a").Or(IsNotNull("b")).And(IsTrue("c").Or(IsFalse("d")).Not()), phrase: "(a is null or b is not null) and not (c is true or d is false)"},
+		{builder: Builder{}.And(Eq("a", true)).Or(Builder{}), phrase: "a = true"},
+		{builder: Eq("id", int64(9007199254740993)).And(Eq("n", uint64(18446744073709551615))), phrase: "id = 9007199254740993 and n = 18446744073709551615"},
+	}
+
+	for _, tt := range tests {
+		want, err := ParseTSL(tt.phrase)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		got, err := tt.builder.Build()
+		if err != nil {
+			t.Errorf("%s: %v", tt.phrase, err)
+			continue
+		}
+		if !Equal(got, want) {
+			t.Errorf("expected %s instead it was %s", Key(want), Key(got))
+		}
+	}
+
+	// Test prepared trees.
+	n, _ := Regex("name", "^j").Build()
+	if _, ok := n.Right.(Node).Right.(*regexp.Regexp); !ok {
+		t.Errorf("expected a compiled regular expression")
+	}
+
+	// Test build errors.
+	for _, b := range []Builder{
+		Eq("", 1),
+		Eq("a", []int{1}).And(Eq("b", 1)),
+		Eq("b", 1).Or(In("a", 1, nil)),
+		Regex("a", "(").Not(),
+	} {
+		if _, err := b.Build(); err == nil {
+			t.Errorf("expected a build error")
+		}
+	}
+}
+
+func TestCombine(t *testing.T) {
+	tenant, _ := ParseTSL("tenant = 'acme'")
+	deleted, _ := ParseTSL("deleted is null")
+	user, _ := ParseTSL("a = 1 or b = 2")
+
+	tests := []struct {
+		tree   Node
+		phrase string
+	}{
+		{tree: CombineAnd(tenant, Node{}, deleted, user), phrase: "tenant = 'acme' and deleted is null and (a = 1 or b = 2)"},
+		{tree: CombineOr(Node{}, user, tenant), phrase: "a = 1 or b = 2 or tenant = 'acme'"},
+		{tree: CombineAnd(Node{}, user), phrase: "a = 1 or b = 2"},
+	}
+
+	for _, tt := range tests {
+		want, _ := ParseTSL(tt.phrase)
+		if !Equal(tt.tree, want) {
+			t.Errorf("expected %s instead it was %s", Key(want), Key(tt.tree))
+		}
+	}
+
+	if n := CombineOr(Node{}, Node{}); n.Func != "" {
+		t.Errorf("expected an empty tree instead it was %s", Key(n))
+	}
+}
+
+func TestParseQuery(t *testing.T) {
+	tests := []struct {
+		input  string
+		filter string
+		query  Query
+		err    bool
+	}{
+		{input: "name = 'joe'", filter: "name = 'joe'"},
+		{input: "name = 'joe' order by spec.pages desc, name limit 10 offset 20", filter: "name = 'joe'",
+			query: Query{OrderBy: []OrderBy{{Field: "spec.pages", Desc: true}, {Field: "name"}}, Limit: 10, Offset: 20}},
+		{input: "(order = 1 or limit > 2) ORDER BY order ASC", filter: "(order = 1 or limit > 2)",
+			query: Query{OrderBy: []OrderBy{{Field: "order"}}}},
+		{input: "offset 5", query: Query{Offset: 5}},
+		{input: "limit 5 offset 10", query: Query{Limit: 5, Offset: 10}},
+		{input: "name = 'joe' order by", err: true},
+		{input: "name = 'joe' limit 0", err: true},
+		{input: "name = 'joe' limit 1.5", err: true},
+		{input: "name = 'joe' offset 5 limit 10", err: true},
+		{input: "name = 'joe' and order by name", err: true},
+		{input: "fields title, spec.pages where name = 'joe' order by name limit 5", filter: "name = 'joe'",
+			query: Query{Fields: []string{"title", "spec.pages"}, OrderBy: []OrderBy{{Field: "name"}}, Limit: 5}},
+		{input: "FIELDS title\nWHERE\n  name = 'joe'", filter: "name = 'joe'", query: Query{Fields: []string{"title"}}},
+		{input: "fields title, author", query: Query{Fields: []string{"title", "author"}}},
+		{input: "fields title limit 3", query: Query{Fields: []string{"title"}, Limit: 3}},
+		{input: "fields = 'a' and fields.b = 2", filter: "fields = 'a' and fields.b = 2"},
+		{input: "fields title where", err: true},
+		{input: "fields title name = 'joe'", err: true},
+		{input: "fields title, where name = 'joe'", err: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			q, err := ParseQuery(tt.input)
+			if tt.err {
+				if err == nil {
+					t.Errorf("expected an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if tt.filter == "" {
+				if q.HasFilter() {
+					t.Errorf("expected no filter, found %s", Key(q.Filter))
+				}
+			} else {
+				filter, err := ParseTSL(tt.filter)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if !Equal(q.Filter, filter) {
+					t.Errorf("filter = %s, want %s", Key(q.Filter), Key(filter))
+				}
+			}
+
+			q.Filter = Node{}
+			if !reflect.DeepEqual(q, tt.query) {
+				t.Errorf("query = %+v, want %+v", q, tt.query)
+			}
+		})
+	}
+}
+
+// benchmarkPhrase is a typical filter.
+const benchmarkPhrase = "author in ('Joe', 'Jane', 'Jim') and pages between 50 and 500 and title ~= 'Book' and rating is not null"
+
+func BenchmarkParseTSL(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if _, err := ParseTSL(benchmarkPhrase); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkArenaParseTSL(b *testing.B) {
+	arena := NewArena()
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if _, err := arena.ParseTSL(benchmarkPhrase); err != nil {
+			b.Fatal(err)
+		}
+		arena.Reset()
+	}
+}
+
+func TestInteger(t *testing.T) {
+	tests := map[string]interface{}{
+		"id = 5":                    int64(5),
+		"id = -7":                   int64(-7),
+		"id = 1.5":                  nil,
+		"id = 9007199254740993":     int64(9007199254740993),
+		"id = 18446744073709551615": uint64(18446744073709551615),
+		"id = -9223372036854775808": int64(-9223372036854775808),
+	}
+
+	for input, expected := range tests {
+		tree, err := ParseTSL(input)
+		if err != nil {
+			t.Fatalf("failed to parse %s: %v", input, err)
+		}
+
+		got, _ := Integer(tree.Right.(Node))
+		if got != expected {
+			t.Errorf("%s: expected %v instead it was %v", input, expected, got)
+		}
+	}
+}
@@ -0,0 +1,61 @@
+// Copyright 2018 Yaacov Zamir <kobi.zamir@gmail.com>
+// and other contributors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tsl
+
+// Visitor is visited by Accept, Enter is called before the children of a node
+// are visited, and Leave is called after.
+type Visitor interface {
+	Enter(n Node)
+	Leave(n Node)
+}
+
+// Accept visits a tree depth first, left to right.
+//
+// The operand nodes of operators, and the literal nodes of arrays are visited,
+// so visitors do not need to check the type of the node children.
+//
+// Example:
+//  	// counter counts the comparisons of a tree.
+//  	type counter struct{ comparisons int }
+//
+//  	func (c *counter) Enter(n tsl.Node) {
+//  		if l, ok := n.Left.(tsl.Node); ok && l.Func == tsl.IdentOp {
+//  			c.comparisons++
+//  		}
+//  	}
+//
+//  	func (c *counter) Leave(n tsl.Node) {}
+//
+//  	c := &counter{}
+//  	tsl.Accept(tree, c)
+//
+func Accept(n Node, v Visitor) {
+	v.Enter(n)
+
+	if l, ok := n.Left.(Node); ok {
+		Accept(l, v)
+	}
+	switch r := n.Right.(type) {
+	case Node:
+		Accept(r, v)
+	case []Node:
+		for _, e := range r {
+			Accept(e, v)
+		}
+	}
+
+	v.Leave(n)
+}
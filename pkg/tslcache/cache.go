@@ -0,0 +1,178 @@
+// Copyright 2018 Yaacov Zamir <kobi.zamir@gmail.com>
+// and other contributors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package tslcache implements a size bounded LRU cache of parsed and
+// compiled TSL phrases.
+//
+// Servers receiving the same filters repeatedly can use the cache to skip
+// parsing and compiling them, the cache is safe for concurrent use.
+//
+// Usage:
+//   // Cache up to 1000 phrases, compiled into squirrel SQL filters.
+//   cache := tslcache.New(1000, tslcache.SQLCompiler)
+//
+//   tree, filter, err := cache.Get("name = 'joe' and age > 18")
+//   sql, args, err := sq.Select("*").From("users").Where(filter.(sq.Sqlizer)).ToSql()
+//
+//   // Hits, misses, evictions and size.
+//   stats := cache.Stats()
+//
+// The plan cache is keyed by canonical tree hash, so equivalent filters share
+// the parsed tree and the values of all the compilers:
+//   plans := tslcache.NewPlanCache(1000, map[string]tslcache.CompileFunc{"sql": tslcache.SQLCompiler})
+//
+//   plan, err := plans.Get("age > 18 and name = 'joe'")
+//   filter := plan.Compiled["sql"]
+//
+package tslcache
+
+import (
+	"container/list"
+	"sync"
+
+	"github.com/yaacov/tree-search-language/pkg/tsl"
+	"github.com/yaacov/tree-search-language/pkg/walkers/sql"
+)
+
+// CompileFunc compiles a TSL tree, for example into an SQL filter.
+type CompileFunc = func(tree tsl.Node) (interface{}, error)
+
+// SQLCompiler compiles TSL trees into squirrel SQL filters.
+func SQLCompiler(tree tsl.Node) (interface{}, error) {
+	return sql.Walk(tree)
+}
+
+// Stats holds the cache counters.
+type Stats struct {
+	Hits      uint64 `json:"hits"`
+	Misses    uint64 `json:"misses"`
+	Evictions uint64 `json:"evictions"`
+	Size      int    `json:"size"`
+}
+
+// entry is a cached phrase.
+type entry struct {
+	phrase   string
+	tree     tsl.Node
+	compiled interface{}
+}
+
+// Cache is a size bounded LRU cache mapping TSL phrases to parsed trees and
+// compiled values.
+type Cache struct {
+	size    int
+	compile CompileFunc
+
+	mu    sync.Mutex
+	ll    *list.List               // most recently used entries first.
+	items map[string]*list.Element // phrase to list element.
+	stats Stats
+}
+
+// New creates a cache holding up to size phrases, compile may be nil to
+// cache only parsed trees.
+func New(size int, compile CompileFunc) *Cache {
+	if size < 1 {
+		size = 1
+	}
+
+	return &Cache{
+		size:    size,
+		compile: compile,
+		ll:      list.New(),
+		items:   map[string]*list.Element{},
+	}
+}
+
+// Get returns the parsed tree and compiled value of a phrase, parsing and
+// compiling it on cache miss. Phrases that fail to parse or compile are not
+// cached.
+func (c *Cache) Get(phrase string) (tree tsl.Node, compiled interface{}, err error) {
+	if e, ok := c.lookup(phrase); ok {
+		return e.tree, e.compiled, nil
+	}
+
+	// Parse and compile outside the lock.
+	tree, err = tsl.ParseTSL(phrase)
+	if err != nil {
+		return
+	}
+	if c.compile != nil {
+		compiled, err = c.compile(tree)
+		if err != nil {
+			return
+		}
+	}
+
+	c.add(&entry{phrase: phrase, tree: tree, compiled: compiled})
+	return
+}
+
+// Stats returns the cache counters.
+func (c *Cache) Stats() Stats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	s := c.stats
+	s.Size = c.ll.Len()
+	return s
+}
+
+// Purge removes all cached phrases, the counters are kept.
+func (c *Cache) Purge() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.ll.Init()
+	c.items = map[string]*list.Element{}
+}
+
+// lookup returns a cached entry and marks it as recently used.
+func (c *Cache) lookup(phrase string) (*entry, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	el, ok := c.items[phrase]
+	if !ok {
+		c.stats.Misses++
+		return nil, false
+	}
+
+	c.stats.Hits++
+	c.ll.MoveToFront(el)
+	return el.Value.(*entry), true
+}
+
+// add adds an entry, evicting the least recently used entries if the cache
+// is full.
+func (c *Cache) add(e *entry) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	// Check for an entry added by a concurrent Get.
+	if el, ok := c.items[e.phrase]; ok {
+		el.Value = e
+		c.ll.MoveToFront(el)
+		return
+	}
+
+	c.items[e.phrase] = c.ll.PushFront(e)
+	for c.ll.Len() > c.size {
+		el := c.ll.Back()
+		c.ll.Remove(el)
+		delete(c.items, el.Value.(*entry).phrase)
+		c.stats.Evictions++
+	}
+}
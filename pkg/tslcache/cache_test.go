@@ -0,0 +1,173 @@
+// Copyright 2018 Yaacov Zamir <kobi.zamir@gmail.com>
+// and other contributors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tslcache
+
+import (
+	"strings"
+	"sync"
+	"testing"
+
+	sq "github.com/Masterminds/squirrel"
+
+	"github.com/yaacov/tree-search-language/pkg/tsl"
+)
+
+func TestCache(t *testing.T) {
+	c := New(2, SQLCompiler)
+
+	for _, phrase := range []string{"a = 1", "b = 2", "a = 1", "c = 3", "b = 2"} {
+		_, compiled, err := c.Get(phrase)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, ok := compiled.(sq.Sqlizer); !ok {
+			t.Errorf("Get(%s) compiled = %T, want sq.Sqlizer", phrase, compiled)
+		}
+	}
+
+	// "b = 2" was evicted by "c = 3", "a = 1" was used more recently.
+	want := Stats{Hits: 1, Misses: 4, Evictions: 2, Size: 2}
+	if got := c.Stats(); got != want {
+		t.Errorf("Stats() = %+v, want %+v", got, want)
+	}
+
+	// Parse errors are not cached.
+	if _, _, err := c.Get("a ="); err == nil {
+		t.Error("Get() error = nil, want parse error")
+	}
+	if got := c.Stats().Size; got != 2 {
+		t.Errorf("Stats().Size = %d, want 2", got)
+	}
+}
+
+func TestCacheConcurrent(t *testing.T) {
+	c := New(10, nil)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				if _, _, err := c.Get("a = 1 or b = 2"); err != nil {
+					t.Error(err)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	if s := c.Stats(); s.Hits+s.Misses != 800 || s.Size != 1 {
+		t.Errorf("Stats() = %+v, want 800 gets of 1 phrase", s)
+	}
+}
+
+func TestCanonicalize(t *testing.T) {
+	same := [][]string{
+		{"a = 1 and b = 2", "b = 2 and a = 1", "b = 2 and a = 1 and b = 2"},
+		{"a = 1 or (b = 2 or c = 3)", "(c = 3 or a = 1) or b = 2"},
+		{"a in ('x', 'y', 'x')", "a in ('y', 'x')"},
+		{"(a = 1 or b = 2) and c in (1, 2)", "c in (2, 1, 1) and (b = 2 or a = 1)"},
+	}
+
+	for _, phrases := range same {
+		want := hash(t, phrases[0])
+		for _, phrase := range phrases[1:] {
+			if got := hash(t, phrase); got != want {
+				t.Errorf("Hash(%s) != Hash(%s)", phrase, phrases[0])
+			}
+		}
+	}
+
+	different := []string{"a = 1 and b = 2", "a = 1 or b = 2", "a = '1' and b = 2", "a in ('x')", "a not in ('x')",
+		"id = 9007199254740993", "id = 9007199254740992"}
+	seen := map[string]string{}
+	for _, phrase := range different {
+		h := hash(t, phrase)
+		if other, ok := seen[h]; ok {
+			t.Errorf("Hash(%s) == Hash(%s)", phrase, other)
+		}
+		seen[h] = phrase
+	}
+}
+
+func TestPlanCache(t *testing.T) {
+	c := NewPlanCache(2, map[string]CompileFunc{"sql": SQLCompiler})
+
+	invalidated := []string{}
+	c.OnInvalidate(func(p *Plan) {
+		invalidated = append(invalidated, p.Hash)
+	})
+
+	p1, err := c.Get("a = 1 and b = 2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := p1.Compiled["sql"].(sq.Sqlizer); !ok {
+		t.Errorf("Compiled[sql] = %T, want sq.Sqlizer", p1.Compiled["sql"])
+	}
+
+	// Equivalent phrases share the plan.
+	for _, phrase := range []string{"b = 2 and a = 1", "a = 1 and b = 2"} {
+		p, err := c.Get(phrase)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if p != p1 {
+			t.Errorf("Get(%s) returned a new plan", phrase)
+		}
+	}
+
+	// Evict the first plan.
+	c.Get("c = 3")
+	c.Get("d = 4")
+
+	want := PlanStats{Hits: 2, Misses: 3, Evictions: 1, Compiles: 3, Size: 2}
+	if got := c.Stats(); got != want {
+		t.Errorf("Stats() = %+v, want %+v", got, want)
+	}
+
+	// Invalidate plans using field c.
+	n := c.Invalidate(func(p *Plan) bool {
+		return strings.Contains(tsl.Key(p.Tree), `$ident"c"`)
+	})
+	if n != 1 || c.Stats().Size != 1 {
+		t.Errorf("Invalidate() = %d, want 1", n)
+	}
+	if len(invalidated) != 2 || invalidated[0] != p1.Hash {
+		t.Errorf("unexpected invalidation hook calls %v", invalidated)
+	}
+
+	c.Purge()
+	if s := c.Stats(); s.Size != 0 || s.Invalidations != 2 {
+		t.Errorf("Stats() = %+v, want an empty cache", s)
+	}
+
+	// Parse errors are not cached.
+	if _, err := c.Get("a ="); err == nil {
+		t.Error("Get() error = nil, want parse error")
+	}
+}
+
+// hash parses a phrase and returns its canonical hash.
+func hash(t *testing.T, phrase string) string {
+	tree, err := tsl.ParseTSL(phrase)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return Hash(tree)
+}
@@ -0,0 +1,39 @@
+// Copyright 2018 Yaacov Zamir <kobi.zamir@gmail.com>
+// and other contributors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tslcache
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+
+	"github.com/yaacov/tree-search-language/pkg/tsl"
+)
+
+// Canonicalize returns a canonical form of a tree, see tsl.Canonicalize.
+func Canonicalize(n tsl.Node) tsl.Node {
+	return tsl.Canonicalize(n)
+}
+
+// Hash returns the hex encoded SHA-256 hash of a tree canonical form.
+func Hash(n tsl.Node) string {
+	return hashKey(tsl.Key(tsl.Canonicalize(n)))
+}
+
+// hashKey returns the hex encoded SHA-256 hash of a tree key.
+func hashKey(k string) string {
+	sum := sha256.Sum256([]byte(k))
+	return hex.EncodeToString(sum[:])
+}
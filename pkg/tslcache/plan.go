@@ -0,0 +1,234 @@
+// Copyright 2018 Yaacov Zamir <kobi.zamir@gmail.com>
+// and other contributors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tslcache
+
+import (
+	"container/list"
+	"sync"
+
+	"github.com/yaacov/tree-search-language/pkg/tsl"
+)
+
+// maxPlanPhrases is the maximal number of phrases remembered for one plan,
+// other phrases of the plan are parsed and hashed on each Get.
+const maxPlanPhrases = 8
+
+// Plan holds the preparation work of a filter, shared by all the phrases of
+// equivalent filters.
+type Plan struct {
+	Hash     string                 // the canonical tree hash.
+	Tree     tsl.Node               // the canonical tree.
+	Compiled map[string]interface{} // the compiled values, by compiler name.
+
+	phrases []string
+}
+
+// PlanStats holds the plan cache counters.
+type PlanStats struct {
+	Hits          uint64 `json:"hits"`
+	Misses        uint64 `json:"misses"`
+	Evictions     uint64 `json:"evictions"`
+	Invalidations uint64 `json:"invalidations"`
+	Compiles      uint64 `json:"compiles"`
+	Size          int    `json:"size"`
+}
+
+// PlanCache is a size bounded LRU cache of plans keyed by canonical tree
+// hash, the cache is safe for concurrent use.
+//
+// Phrases of equivalent filters, for example "a = 1 and b = 2" and
+// "b = 2 and a = 1", share one plan, holding the canonical tree compiled
+// by all the cache compilers.
+type PlanCache struct {
+	size      int
+	compilers map[string]CompileFunc
+
+	mu      sync.Mutex
+	ll      *list.List               // most recently used plans first.
+	plans   map[string]*list.Element // hash to list element.
+	phrases map[string]*list.Element // known phrase to list element.
+	hooks   []func(*Plan)
+	stats   PlanStats
+}
+
+// NewPlanCache creates a plan cache holding up to size plans, compiled by
+// the named compilers.
+func NewPlanCache(size int, compilers map[string]CompileFunc) *PlanCache {
+	if size < 1 {
+		size = 1
+	}
+
+	return &PlanCache{
+		size:      size,
+		compilers: compilers,
+		ll:        list.New(),
+		plans:     map[string]*list.Element{},
+		phrases:   map[string]*list.Element{},
+	}
+}
+
+// Get returns the plan of a phrase, parsing and compiling it on cache miss.
+// Phrases that fail to parse or compile are not cached.
+func (c *PlanCache) Get(phrase string) (*Plan, error) {
+	// Check for a known phrase.
+	c.mu.Lock()
+	if el, ok := c.phrases[phrase]; ok {
+		c.stats.Hits++
+		c.ll.MoveToFront(el)
+		c.mu.Unlock()
+		return el.Value.(*Plan), nil
+	}
+	c.mu.Unlock()
+
+	tree, err := tsl.ParseTSL(phrase)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.get(phrase, tree)
+}
+
+// GetTree returns the plan of a parsed tree, compiling it on cache miss.
+func (c *PlanCache) GetTree(tree tsl.Node) (*Plan, error) {
+	return c.get("", tree)
+}
+
+// OnInvalidate adds a hook called with plans removed from the cache, by
+// eviction, invalidation or purge, hooks are called while the cache is
+// locked, and must not call the cache.
+func (c *PlanCache) OnInvalidate(hook func(*Plan)) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.hooks = append(c.hooks, hook)
+}
+
+// Invalidate removes the plans matching a predicate, for example plans of
+// filters using a field that changed, and returns the number of removed
+// plans.
+func (c *PlanCache) Invalidate(match func(*Plan) bool) int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	removed := 0
+	for el := c.ll.Front(); el != nil; {
+		next := el.Next()
+		if match(el.Value.(*Plan)) {
+			c.remove(el)
+			c.stats.Invalidations++
+			removed++
+		}
+		el = next
+	}
+
+	return removed
+}
+
+// Purge removes all cached plans, the counters are kept.
+func (c *PlanCache) Purge() {
+	c.Invalidate(func(*Plan) bool { return true })
+}
+
+// Stats returns the cache counters.
+func (c *PlanCache) Stats() PlanStats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	s := c.stats
+	s.Size = c.ll.Len()
+	return s
+}
+
+// get returns the plan of a tree, remembering the phrase of the tree if given.
+func (c *PlanCache) get(phrase string, tree tsl.Node) (*Plan, error) {
+	tree = tsl.Canonicalize(tree)
+	hash := hashKey(tsl.Key(tree))
+
+	// Check for a cached plan.
+	c.mu.Lock()
+	if el, ok := c.plans[hash]; ok {
+		c.stats.Hits++
+		c.ll.MoveToFront(el)
+		c.remember(phrase, el)
+		c.mu.Unlock()
+		return el.Value.(*Plan), nil
+	}
+	c.stats.Misses++
+	c.mu.Unlock()
+
+	// Compile outside the lock.
+	p := &Plan{Hash: hash, Tree: tree, Compiled: map[string]interface{}{}}
+	for name, compile := range c.compilers {
+		compiled, err := compile(tree)
+		if err != nil {
+			return nil, err
+		}
+		p.Compiled[name] = compiled
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.stats.Compiles++
+
+	// Check for a plan added by a concurrent Get.
+	if el, ok := c.plans[hash]; ok {
+		c.ll.MoveToFront(el)
+		c.remember(phrase, el)
+		return el.Value.(*Plan), nil
+	}
+
+	el := c.ll.PushFront(p)
+	c.plans[hash] = el
+	c.remember(phrase, el)
+
+	for c.ll.Len() > c.size {
+		c.remove(c.ll.Back())
+		c.stats.Evictions++
+	}
+
+	return p, nil
+}
+
+// remember maps a phrase to a plan element, the cache must be locked.
+func (c *PlanCache) remember(phrase string, el *list.Element) {
+	p := el.Value.(*Plan)
+	if phrase == "" || len(p.phrases) >= maxPlanPhrases {
+		return
+	}
+	if _, ok := c.phrases[phrase]; ok {
+		return
+	}
+
+	p.phrases = append(p.phrases, phrase)
+	c.phrases[phrase] = el
+}
+
+// remove removes a plan element and calls the invalidation hooks, the cache
+// must be locked.
+func (c *PlanCache) remove(el *list.Element) {
+	p := el.Value.(*Plan)
+
+	c.ll.Remove(el)
+	delete(c.plans, p.Hash)
+	for _, phrase := range p.phrases {
+		delete(c.phrases, phrase)
+	}
+
+	for _, hook := range c.hooks {
+		hook(p)
+	}
+}
@@ -0,0 +1,182 @@
+// Copyright 2018 Yaacov Zamir <kobi.zamir@gmail.com>
+// and other contributors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tslfuzz
+
+import (
+	"fmt"
+	"io/ioutil"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// Fields are the identifiers used in generated phrases and documents.
+var Fields = []string{"name", "author", "title", "spec.pages", "spec.rating", "a.b.c"}
+
+// strings are the string literals used in generated phrases and documents.
+var stringValues = []string{"", "Joe", "Jane", "joe's", "a%b", "_x", "10", "true", "2019-01-01", "2019-06-01T10:00:00Z"}
+
+// patterns are the regular expressions used in generated phrases.
+var patterns = []string{"^J", "e$", "o+", "[a-z]", ".*", "^$"}
+
+// dates are the date literals used in generated phrases.
+var dates = []string{"2019-01-01", "2019-06-01T10:00:00Z", "date('2019-03-01') + 1d"}
+
+// durations are the duration literals used in generated phrases.
+var durations = []string{"90s", "1h", "2h30m", "1d", "-5m"}
+
+// comparisons are the comparison operators used in generated phrases.
+var comparisons = []string{"=", "!=", "<", "<=", ">", ">="}
+
+// Corpus returns n random phrases generated using a seed.
+func Corpus(n int, seed int64) []string {
+	r := rand.New(rand.NewSource(seed))
+
+	phrases := make([]string, n)
+	for i := range phrases {
+		phrases[i] = Generate(r, 3)
+	}
+
+	return phrases
+}
+
+// WriteCorpus writes n random phrases generated using a seed to files in a
+// directory, one phrase per file.
+func WriteCorpus(dir string, n int, seed int64) error {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+
+	for i, phrase := range Corpus(n, seed) {
+		name := filepath.Join(dir, fmt.Sprintf("tsl-%d-%04d", seed, i))
+		if err := ioutil.WriteFile(name, []byte(phrase), 0644); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Generate returns a random TSL phrase, with logical operators nested up to
+// depth levels.
+func Generate(r *rand.Rand, depth int) string {
+	if depth > 0 && r.Intn(3) > 0 {
+		op := "and"
+		if r.Intn(2) == 0 {
+			op = "or"
+		}
+		return fmt.Sprintf("(%s %s %s)", Generate(r, depth-1), op, Generate(r, depth-1))
+	}
+
+	field := Fields[r.Intn(len(Fields))]
+
+	switch r.Intn(12) {
+	case 0:
+		return fmt.Sprintf("%s %s %s", field, comparisons[r.Intn(len(comparisons))], randomString(r))
+	case 1:
+		return fmt.Sprintf("%s %s %s", field, comparisons[r.Intn(len(comparisons))], randomNumber(r))
+	case 2:
+		not := []string{"", "not "}[r.Intn(2)]
+		list := []string{}
+		for i := 0; i < 1+r.Intn(4); i++ {
+			if r.Intn(2) == 0 {
+				list = append(list, randomString(r))
+			} else {
+				list = append(list, randomNumber(r))
+			}
+		}
+		return fmt.Sprintf("%s %sin (%s)", field, not, strings.Join(list, ", "))
+	case 3:
+		not := []string{"", "not "}[r.Intn(2)]
+		return fmt.Sprintf("%s %sbetween %s and %s", field, not, randomNumber(r), randomNumber(r))
+	case 4:
+		not := []string{"", "not "}[r.Intn(2)]
+		return fmt.Sprintf("%s %sbetween %s and %s", field, not, randomString(r), randomString(r))
+	case 5:
+		return fmt.Sprintf("%s is %snull", field, []string{"", "not "}[r.Intn(2)])
+	case 6:
+		return fmt.Sprintf("%s %s '%s'", field, []string{"~=", "~!"}[r.Intn(2)], patterns[r.Intn(len(patterns))])
+	case 7:
+		return fmt.Sprintf("%s %s%s %s", field, []string{"", "not "}[r.Intn(2)], []string{"like", "ilike", "contains", "startswith", "endswith"}[r.Intn(5)], randomString(r))
+	case 8:
+		return fmt.Sprintf("%s %s %s", field, comparisons[r.Intn(len(comparisons))], durations[r.Intn(len(durations))])
+	case 9:
+		b := []string{"true", "false"}[r.Intn(2)]
+		switch r.Intn(3) {
+		case 0:
+			return fmt.Sprintf("%s %s %s", field, []string{"=", "!="}[r.Intn(2)], b)
+		case 1:
+			return fmt.Sprintf("%s is %s%s", field, []string{"", "not "}[r.Intn(2)], b)
+		}
+		return fmt.Sprintf("%s %sin (%s)", field, []string{"", "not "}[r.Intn(2)], b)
+	case 10:
+		if r.Intn(2) == 0 {
+			not := []string{"", "not "}[r.Intn(2)]
+			return fmt.Sprintf("%s %sbetween %s and %s", field, not, dates[r.Intn(len(dates))], dates[r.Intn(len(dates))])
+		}
+		return fmt.Sprintf("%s %s %s", field, comparisons[r.Intn(len(comparisons))], dates[r.Intn(len(dates))])
+	}
+
+	return fmt.Sprintf("%s %s %s %s %s", field, []string{"+", "-", "*", "/", "%"}[r.Intn(5)], randomNumber(r),
+		comparisons[r.Intn(len(comparisons))], randomNumber(r))
+}
+
+// RandomDoc returns a random document, with random values of random types
+// for some of the Fields.
+func RandomDoc(r *rand.Rand) map[string]interface{} {
+	doc := map[string]interface{}{}
+
+	for _, field := range Fields {
+		switch r.Intn(11) {
+		case 0:
+			// Missing field.
+		case 1:
+			doc[field] = nil
+		case 2:
+			doc[field] = stringValues[r.Intn(len(stringValues))]
+		case 3:
+			doc[field] = float64(r.Intn(21)-10) / 2
+		case 4:
+			doc[field] = r.Intn(21) - 10
+		case 5:
+			doc[field] = r.Intn(2) == 0
+		case 6:
+			doc[field] = time.Date(2019, time.Month(1+r.Intn(12)), 1, 0, 0, 0, 0, time.UTC)
+		case 7:
+			doc[field] = time.Duration(r.Intn(181)-60) * time.Minute
+		case 8:
+			doc[field] = []string{stringValues[r.Intn(len(stringValues))], stringValues[r.Intn(len(stringValues))]}
+		case 9:
+			doc[field] = []interface{}{float64(r.Intn(21)-10) / 2, r.Intn(2) == 0, nil}[:r.Intn(4)]
+		case 10:
+			doc[field] = map[string]string{"unsupported": ""}
+		}
+	}
+
+	return doc
+}
+
+// randomString returns a random string literal.
+func randomString(r *rand.Rand) string {
+	return "'" + strings.Replace(stringValues[r.Intn(len(stringValues))], "'", "''", -1) + "'"
+}
+
+// randomNumber returns a random number literal.
+func randomNumber(r *rand.Rand) string {
+	return fmt.Sprintf("%g", float64(r.Intn(41)-20)/4)
+}
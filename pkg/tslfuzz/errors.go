@@ -0,0 +1,29 @@
+// Copyright 2018 Yaacov Zamir <kobi.zamir@gmail.com>
+// and other contributors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tslfuzz
+
+import "fmt"
+
+// PropertyError is raised when a fuzzed phrase breaks a checked property.
+type PropertyError struct {
+	Check  string // the checked property.
+	Phrase string // the fuzzed phrase.
+	Err    error  // the property failure.
+}
+
+func (e PropertyError) Error() string {
+	return fmt.Sprintf("%s check failed for %q: %v", e.Check, e.Phrase, e.Err)
+}
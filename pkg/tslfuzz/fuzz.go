@@ -0,0 +1,191 @@
+// Copyright 2018 Yaacov Zamir <kobi.zamir@gmail.com>
+// and other contributors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package tslfuzz implements fuzzing entry points for TSL parsers and walkers.
+//
+// Each entry point checks a property of the phrase in its input, and returns
+// an error if the property does not hold, phrases that fail to parse are
+// ignored. Walkers that panic on unexpected trees are found by the fuzzer
+// catching the panic.
+//
+//   RoundTrip - parse, print and reparse a phrase, the trees must be equal.
+//   Eval      - evaluate a phrase over random documents, the semantics, compile
+//               and vm walkers must agree.
+//   SQL       - convert a phrase to an SQL filter, the SQL must parse.
+//
+// Usage:
+//   func FuzzTSL(f *testing.F) {
+//       for _, phrase := range tslfuzz.Corpus(100, 1) {
+//           f.Add([]byte(phrase))
+//       }
+//
+//       f.Fuzz(func(t *testing.T, data []byte) {
+//           if err := tslfuzz.RoundTrip(data); err != nil {
+//               t.Fatal(err)
+//           }
+//       })
+//   }
+//
+package tslfuzz
+
+import (
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+	"math/rand"
+	"strings"
+
+	"github.com/xwb1989/sqlparser"
+
+	"github.com/yaacov/tree-search-language/pkg/savedsearch"
+	"github.com/yaacov/tree-search-language/pkg/tsl"
+	"github.com/yaacov/tree-search-language/pkg/walkers/compile"
+	"github.com/yaacov/tree-search-language/pkg/walkers/ident"
+	"github.com/yaacov/tree-search-language/pkg/walkers/semantics"
+	"github.com/yaacov/tree-search-language/pkg/walkers/sql"
+	"github.com/yaacov/tree-search-language/pkg/walkers/vm"
+)
+
+// evalDocs is the number of random documents evaluated by Eval.
+const evalDocs = 8
+
+// RoundTrip parses a phrase, prints the tree as a phrase and parses it again,
+// the two trees must be equal.
+func RoundTrip(data []byte) error {
+	phrase := string(data)
+
+	tree, err := tsl.ParseTSL(phrase)
+	if err != nil {
+		return nil
+	}
+
+	printed, err := savedsearch.Format(tree)
+	if err != nil {
+		return PropertyError{Check: "print", Phrase: phrase, Err: err}
+	}
+
+	reparsed, err := tsl.ParseTSL(printed)
+	if err != nil {
+		return PropertyError{Check: "reparse", Phrase: phrase, Err: fmt.Errorf("%s: %v", printed, err)}
+	}
+
+	a, _ := json.Marshal(tree)
+	b, _ := json.Marshal(reparsed)
+	if string(a) != string(b) {
+		return PropertyError{Check: "round trip", Phrase: phrase, Err: fmt.Errorf("%s != %s", a, b)}
+	}
+
+	return nil
+}
+
+// Eval evaluates a phrase over random documents, seeded by the phrase, using
+// the semantics, compile and vm walkers, the walkers must agree on the result,
+// and on failing to evaluate.
+func Eval(data []byte) error {
+	phrase := string(data)
+
+	tree, err := tsl.ParseTSL(phrase)
+	if err != nil {
+		return nil
+	}
+
+	// Compiling fails where the tree can not be evaluated.
+	m, err := compile.Compile(tree)
+	if err != nil {
+		return nil
+	}
+	p, err := vm.Compile(tree)
+	if err != nil {
+		return nil
+	}
+
+	h := fnv.New64a()
+	h.Write(data)
+	r := rand.New(rand.NewSource(int64(h.Sum64())))
+
+	for i := 0; i < evalDocs; i++ {
+		doc := RandomDoc(r)
+		eval := func(k string) (interface{}, bool) {
+			v, ok := doc[k]
+			return v, ok
+		}
+
+		want, wantErr := semantics.Walk(tree, eval)
+		got, gotErr := m(eval)
+		if !agree(want, wantErr, got, gotErr) {
+			return PropertyError{Check: "compile", Phrase: phrase, Err: mismatch(doc, want, wantErr, got, gotErr)}
+		}
+
+		got, gotErr = p.Run(eval)
+		if !agree(want, wantErr, got, gotErr) {
+			return PropertyError{Check: "vm", Phrase: phrase, Err: mismatch(doc, want, wantErr, got, gotErr)}
+		}
+	}
+
+	return nil
+}
+
+// SQL converts a phrase into an SQL filter, the filter must parse as the
+// WHERE clause of a SELECT statement.
+//
+// Identifiers are quoted using the ident walker, like applications mapping
+// identifiers to column names, before the tree is converted.
+func SQL(data []byte) error {
+	phrase := string(data)
+
+	tree, err := tsl.ParseTSL(phrase)
+	if err != nil {
+		return nil
+	}
+	tree, err = ident.Walk(tree, quoteIdent)
+	if err != nil {
+		return PropertyError{Check: "sql", Phrase: phrase, Err: err}
+	}
+
+	filter, err := sql.Walk(tree)
+	if err != nil {
+		return nil
+	}
+	where, _, err := filter.ToSql()
+	if err != nil {
+		return PropertyError{Check: "sql", Phrase: phrase, Err: err}
+	}
+
+	if _, err = sqlparser.Parse("SELECT * FROM t WHERE " + where); err != nil {
+		return PropertyError{Check: "sql", Phrase: phrase, Err: fmt.Errorf("%s: %v", where, err)}
+	}
+
+	return nil
+}
+
+// quoteIdent quotes an identifier as a MySQL column name.
+func quoteIdent(s string) (string, error) {
+	return "`" + strings.Replace(s, "`", "``", -1) + "`", nil
+}
+
+// agree checks that walkers results agree, results of failed evaluations are
+// not compared.
+func agree(want bool, wantErr error, got bool, gotErr error) bool {
+	if wantErr != nil || gotErr != nil {
+		return wantErr != nil && gotErr != nil
+	}
+
+	return got == want
+}
+
+// mismatch describes walkers results that do not agree.
+func mismatch(doc map[string]interface{}, want bool, wantErr error, got bool, gotErr error) error {
+	return fmt.Errorf("document %v: semantics %v (%v), got %v (%v)", doc, want, wantErr, got, gotErr)
+}
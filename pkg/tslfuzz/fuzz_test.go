@@ -0,0 +1,74 @@
+// Copyright 2018 Yaacov Zamir <kobi.zamir@gmail.com>
+// and other contributors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tslfuzz
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// seed adds the generated corpus to a fuzz target.
+func seed(f *testing.F) {
+	for _, phrase := range Corpus(200, 1) {
+		f.Add([]byte(phrase))
+	}
+}
+
+func FuzzRoundTrip(f *testing.F) {
+	seed(f)
+	f.Fuzz(func(t *testing.T, data []byte) {
+		if err := RoundTrip(data); err != nil {
+			t.Fatal(err)
+		}
+	})
+}
+
+func FuzzEval(f *testing.F) {
+	seed(f)
+	f.Fuzz(func(t *testing.T, data []byte) {
+		if err := Eval(data); err != nil {
+			t.Fatal(err)
+		}
+	})
+}
+
+func FuzzSQL(f *testing.F) {
+	seed(f)
+	f.Fuzz(func(t *testing.T, data []byte) {
+		if err := SQL(data); err != nil {
+			t.Fatal(err)
+		}
+	})
+}
+
+func TestWriteCorpus(t *testing.T) {
+	dir, err := ioutil.TempDir("", "tslfuzz")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	if err := WriteCorpus(dir, 10, 2); err != nil {
+		t.Fatal(err)
+	}
+
+	files, _ := filepath.Glob(filepath.Join(dir, "tsl-2-*"))
+	if len(files) != 10 {
+		t.Errorf("expected 10 corpus files instead it was %d", len(files))
+	}
+}
@@ -0,0 +1,35 @@
+// Copyright 2018 Yaacov Zamir <kobi.zamir@gmail.com>
+// and other contributors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tune
+
+import "fmt"
+
+// EmptySampleError is raised when a sample has no queries or no documents.
+type EmptySampleError struct{}
+
+func (e EmptySampleError) Error() string {
+	return fmt.Sprintf("sample must have queries and documents")
+}
+
+// QueryError is raised when a sample query fails to parse or compile.
+type QueryError struct {
+	Query string // the sample query.
+	Err   error  // the parse or compile error.
+}
+
+func (e QueryError) Error() string {
+	return fmt.Sprintf("query %q: %v", e.Query, e.Err)
+}
@@ -0,0 +1,174 @@
+// Copyright 2018 Yaacov Zamir <kobi.zamir@gmail.com>
+// and other contributors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tune
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/yaacov/tree-search-language/pkg/tsl"
+	"github.com/yaacov/tree-search-language/pkg/tslcache"
+	"github.com/yaacov/tree-search-language/pkg/walkers/compile"
+	"github.com/yaacov/tree-search-language/pkg/walkers/semantics"
+)
+
+// prepare parses and compiles the distinct queries.
+func prepare(queries []string) (trees []tsl.Node, matchers []compile.Matcher, err error) {
+	seen := map[string]bool{}
+
+	for _, q := range queries {
+		if seen[q] {
+			continue
+		}
+		seen[q] = true
+
+		tree, err := tsl.ParseTSL(q)
+		if err != nil {
+			return nil, nil, QueryError{Query: q, Err: err}
+		}
+		m, err := compile.Compile(tree)
+		if err != nil {
+			return nil, nil, QueryError{Query: q, Err: err}
+		}
+
+		trees = append(trees, tree)
+		matchers = append(matchers, m)
+	}
+
+	return
+}
+
+// measure calls op repeatedly with growing indexes until d is elapsed.
+func measure(d time.Duration, op func(i int)) Throughput {
+	start := time.Now()
+	ops := 0
+
+	// Check the clock once per batch of operations.
+	for batch := 1; time.Since(start) < d; batch *= 2 {
+		for i := 0; i < batch; i++ {
+			op(ops)
+			ops++
+		}
+	}
+
+	return Throughput{Ops: ops, Duration: time.Since(start)}
+}
+
+// measureParse measures parsing the sample queries.
+func measureParse(queries []string, d time.Duration) Throughput {
+	return measure(d, func(i int) {
+		tsl.ParseTSL(queries[i%len(queries)])
+	})
+}
+
+// measureCompile measures compiling parsed trees.
+func measureCompile(trees []tsl.Node, d time.Duration) Throughput {
+	return measure(d, func(i int) {
+		compile.Compile(trees[i%len(trees)])
+	})
+}
+
+// measureEval measures evaluating all documents with all matchers using a
+// pool of workers, an operation is one evaluation of a matcher.
+func measureEval(matchers []compile.Matcher, evals []semantics.EvalFunc, workers int, d time.Duration) Throughput {
+	var ops int64
+	var stop int32
+	var wg sync.WaitGroup
+
+	start := time.Now()
+	next := int64(-1)
+
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			// Evaluate one document with all matchers, until stopped.
+			for atomic.LoadInt32(&stop) == 0 {
+				eval := evals[int(atomic.AddInt64(&next, 1))%len(evals)]
+				for _, m := range matchers {
+					m(eval)
+				}
+				atomic.AddInt64(&ops, int64(len(matchers)))
+			}
+		}()
+	}
+
+	time.Sleep(d)
+	atomic.StoreInt32(&stop, 1)
+	wg.Wait()
+
+	return Throughput{Ops: int(ops), Duration: time.Since(start)}
+}
+
+// measureCache replays the queries against caches of growing sizes, up to the
+// number of distinct queries.
+func measureCache(queries []string, distinct int) (results []CacheResult) {
+	for size := 1; ; size *= 2 {
+		if size > distinct {
+			size = distinct
+		}
+
+		cache := tslcache.New(size, nil)
+		for _, q := range queries {
+			cache.Get(q)
+		}
+
+		stats := cache.Stats()
+		results = append(results, CacheResult{
+			Size:     size,
+			HitRatio: float64(stats.Hits) / float64(len(queries)),
+		})
+
+		if size == distinct {
+			return
+		}
+	}
+}
+
+// recommendWorkers returns the smallest worker count reaching the target
+// ratio of the best throughput.
+func recommendWorkers(results []WorkersResult, ratio float64) int {
+	best := 0.0
+	for _, r := range results {
+		if r.Throughput.PerSecond() > best {
+			best = r.Throughput.PerSecond()
+		}
+	}
+
+	for _, r := range results {
+		if r.Throughput.PerSecond() >= ratio*best {
+			return r.Workers
+		}
+	}
+
+	return 1
+}
+
+// recommendCacheSize returns the smallest cache size reaching the target ratio
+// of the best hit ratio.
+func recommendCacheSize(results []CacheResult, ratio float64) int {
+	best := results[len(results)-1].HitRatio
+
+	for _, r := range results {
+		if r.HitRatio >= ratio*best {
+			return r.Size
+		}
+	}
+
+	return results[len(results)-1].Size
+}
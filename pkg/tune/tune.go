@@ -0,0 +1,201 @@
+// Copyright 2018 Yaacov Zamir <kobi.zamir@gmail.com>
+// and other contributors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package tune measures TSL parse, compile and evaluation throughput on sample
+// queries and documents, and recommends worker counts and cache sizes.
+//
+// Operators can run the measurements on samples of their production queries
+// and data, and tune deployments empirically. Evaluation is measured using
+// compiled trees, over pools of growing worker counts, the recommended worker
+// count is the smallest one reaching most of the best throughput. The cache
+// size is recommended by replaying the sample queries, in order, against LRU
+// caches of growing sizes.
+//
+// Usage:
+//   report, err := tune.Run(tune.Sample{
+//       Queries: queries,
+//       Docs:    docs,
+//   }, tune.Options{})
+//
+//   fmt.Print(report)
+//   fmt.Println(report.RecommendedWorkers, report.RecommendedCacheSize)
+//
+package tune
+
+import (
+	"fmt"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/yaacov/tree-search-language/pkg/walkers/semantics"
+)
+
+// Default options.
+const (
+	DefaultDuration    = 200 * time.Millisecond
+	DefaultTargetRatio = 0.9
+)
+
+// Sample holds the queries and documents to measure.
+type Sample struct {
+	// Queries are TSL phrases, in the order they are received, repeated
+	// phrases are used to measure cache hit ratios.
+	Queries []string
+
+	// Docs are the documents evaluated by the queries.
+	Docs []map[string]interface{}
+
+	// Eval creates an evaluation function for a document, if nil documents
+	// keys are used as identifiers.
+	Eval func(doc map[string]interface{}) semantics.EvalFunc
+}
+
+// Options controls the measurements.
+type Options struct {
+	Duration    time.Duration // run time of each measurement, defaults to DefaultDuration.
+	MaxWorkers  int           // maximal evaluation workers, defaults to twice GOMAXPROCS.
+	TargetRatio float64       // part of the best result a recommendation must reach, defaults to DefaultTargetRatio.
+}
+
+// Throughput is the result of one measurement.
+type Throughput struct {
+	Ops      int           `json:"ops"`
+	Duration time.Duration `json:"duration"`
+}
+
+// PerSecond returns the number of operations per second.
+func (t Throughput) PerSecond() float64 {
+	if t.Duration <= 0 {
+		return 0
+	}
+
+	return float64(t.Ops) / t.Duration.Seconds()
+}
+
+// NsPerOp returns the mean operation time in nanoseconds.
+func (t Throughput) NsPerOp() float64 {
+	if t.Ops == 0 {
+		return 0
+	}
+
+	return float64(t.Duration.Nanoseconds()) / float64(t.Ops)
+}
+
+// WorkersResult is the evaluation throughput of a worker pool.
+type WorkersResult struct {
+	Workers    int        `json:"workers"`
+	Throughput Throughput `json:"throughput"`
+}
+
+// CacheResult is the hit ratio of a cache size replaying the sample queries.
+type CacheResult struct {
+	Size     int     `json:"size"`
+	HitRatio float64 `json:"hitRatio"`
+}
+
+// Report holds the measurements and recommendations.
+type Report struct {
+	Parse   Throughput      `json:"parse"`
+	Compile Throughput      `json:"compile"`
+	Eval    Throughput      `json:"eval"`
+	Workers []WorkersResult `json:"workers"`
+	Cache   []CacheResult   `json:"cache"`
+
+	RecommendedWorkers   int `json:"recommendedWorkers"`
+	RecommendedCacheSize int `json:"recommendedCacheSize"`
+}
+
+// Run measures the sample and returns a report.
+func Run(sample Sample, opts Options) (r Report, err error) {
+	// Check for an empty sample.
+	if len(sample.Queries) == 0 || len(sample.Docs) == 0 {
+		err = EmptySampleError{}
+		return
+	}
+
+	opts = opts.withDefaults()
+	if sample.Eval == nil {
+		sample.Eval = evalFactory
+	}
+
+	// Parse and compile the distinct queries.
+	trees, matchers, err := prepare(sample.Queries)
+	if err != nil {
+		return
+	}
+
+	r.Parse = measureParse(sample.Queries, opts.Duration)
+	r.Compile = measureCompile(trees, opts.Duration)
+
+	// Evaluate the documents using growing worker pools.
+	evals := make([]semantics.EvalFunc, len(sample.Docs))
+	for i, doc := range sample.Docs {
+		evals[i] = sample.Eval(doc)
+	}
+	for workers := 1; workers <= opts.MaxWorkers; workers *= 2 {
+		t := measureEval(matchers, evals, workers, opts.Duration)
+		r.Workers = append(r.Workers, WorkersResult{Workers: workers, Throughput: t})
+	}
+	r.Eval = r.Workers[0].Throughput
+	r.RecommendedWorkers = recommendWorkers(r.Workers, opts.TargetRatio)
+
+	// Replay the queries against growing caches.
+	r.Cache = measureCache(sample.Queries, len(trees))
+	r.RecommendedCacheSize = recommendCacheSize(r.Cache, opts.TargetRatio)
+
+	return
+}
+
+// String formats the report as a human readable table.
+func (r Report) String() string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "parse    %10.0f ops/s %10.0f ns/op\n", r.Parse.PerSecond(), r.Parse.NsPerOp())
+	fmt.Fprintf(&b, "compile  %10.0f ops/s %10.0f ns/op\n", r.Compile.PerSecond(), r.Compile.NsPerOp())
+	fmt.Fprintf(&b, "eval     %10.0f ops/s %10.0f ns/op\n", r.Eval.PerSecond(), r.Eval.NsPerOp())
+	for _, w := range r.Workers {
+		fmt.Fprintf(&b, "workers  %4d %10.0f ops/s\n", w.Workers, w.Throughput.PerSecond())
+	}
+	for _, c := range r.Cache {
+		fmt.Fprintf(&b, "cache    %4d %9.1f%% hits\n", c.Size, c.HitRatio*100)
+	}
+	fmt.Fprintf(&b, "recommended workers: %d, cache size: %d\n", r.RecommendedWorkers, r.RecommendedCacheSize)
+
+	return b.String()
+}
+
+// withDefaults returns the options with defaults set.
+func (o Options) withDefaults() Options {
+	if o.Duration <= 0 {
+		o.Duration = DefaultDuration
+	}
+	if o.MaxWorkers < 1 {
+		o.MaxWorkers = 2 * runtime.GOMAXPROCS(0)
+	}
+	if o.TargetRatio <= 0 || o.TargetRatio > 1 {
+		o.TargetRatio = DefaultTargetRatio
+	}
+
+	return o
+}
+
+// evalFactory creates an evaluation function using document keys as identifiers.
+func evalFactory(doc map[string]interface{}) semantics.EvalFunc {
+	return func(k string) (interface{}, bool) {
+		v, ok := doc[k]
+		return v, ok
+	}
+}
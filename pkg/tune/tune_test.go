@@ -0,0 +1,83 @@
+// Copyright 2018 Yaacov Zamir <kobi.zamir@gmail.com>
+// and other contributors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tune
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRun(t *testing.T) {
+	sample := Sample{
+		Queries: []string{
+			"author = 'Joe'",
+			"pages > 100",
+			"author = 'Joe'",
+			"author in ('Joe', 'Jane') and pages between 10 and 500",
+			"author = 'Joe'",
+			"pages > 100",
+		},
+		Docs: []map[string]interface{}{
+			{"author": "Joe", "pages": 120},
+			{"author": "Jane", "pages": 12},
+			{"author": "Jim", "pages": 300},
+		},
+	}
+
+	r, err := Run(sample, Options{Duration: 5 * time.Millisecond, MaxWorkers: 4})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if r.Parse.Ops == 0 || r.Compile.Ops == 0 || r.Eval.Ops == 0 {
+		t.Errorf("expected operations in all measurements: %+v", r)
+	}
+	if len(r.Workers) != 3 || r.Workers[2].Workers != 4 {
+		t.Errorf("expected pools of 1, 2 and 4 workers: %+v", r.Workers)
+	}
+	if r.RecommendedWorkers < 1 || r.RecommendedWorkers > 4 {
+		t.Errorf("unexpected recommended workers %d", r.RecommendedWorkers)
+	}
+
+	// Three distinct queries, replayed against caches of 1, 2 and 3 phrases.
+	want := []CacheResult{{1, 0}, {2, 2.0 / 6}, {3, 3.0 / 6}}
+	if len(r.Cache) != len(want) {
+		t.Fatalf("expected cache results %v instead it was %v", want, r.Cache)
+	}
+	for i := range want {
+		if r.Cache[i] != want[i] {
+			t.Errorf("expected cache result %v instead it was %v", want[i], r.Cache[i])
+		}
+	}
+	if r.RecommendedCacheSize != 3 {
+		t.Errorf("expected cache size 3 instead it was %d", r.RecommendedCacheSize)
+	}
+
+	if r.String() == "" {
+		t.Errorf("expected a formatted report")
+	}
+}
+
+func TestRunErrors(t *testing.T) {
+	docs := []map[string]interface{}{{"a": 1}}
+
+	if _, err := Run(Sample{Docs: docs}, Options{}); err == nil {
+		t.Errorf("expected an empty sample error")
+	}
+	if _, err := Run(Sample{Queries: []string{"a = "}, Docs: docs}, Options{}); err == nil {
+		t.Errorf("expected a query error")
+	}
+}
@@ -0,0 +1,75 @@
+// Copyright 2018 Yaacov Zamir <kobi.zamir@gmail.com>
+// and other contributors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validate
+
+import (
+	"fmt"
+	"strings"
+)
+
+// UnknownFieldError is raised when a tree uses a field missing from the schema.
+type UnknownFieldError struct {
+	Field string // the unknown field name.
+}
+
+func (e UnknownFieldError) Error() string {
+	return fmt.Sprintf("unknown field: %s", e.Field)
+}
+
+// OperatorError is raised when a tree uses an operator not allowed for a field.
+type OperatorError struct {
+	Field    string // the field name.
+	Operator string // the TSL operator.
+}
+
+func (e OperatorError) Error() string {
+	return fmt.Sprintf("operator %s not allowed for field: %s", e.Operator, e.Field)
+}
+
+// FieldTypeError is raised when a field is compared to a literal of the wrong type.
+type FieldTypeError struct {
+	Field        string      // the field name.
+	ExpectedType string      // the field type in the schema.
+	Literal      interface{} // the literal found.
+}
+
+func (e FieldTypeError) Error() string {
+	return fmt.Sprintf("field %s expects a %s literal, found: %v", e.Field, e.ExpectedType, e.Literal)
+}
+
+// Errors holds all the violations found in a tree.
+type Errors []error
+
+func (e Errors) Error() string {
+	msgs := make([]string, len(e))
+	for i, err := range e {
+		msgs[i] = err.Error()
+	}
+
+	return strings.Join(msgs, "; ")
+}
+
+// TypeError is raised when an operator is applied to an operand of the wrong
+// type.
+type TypeError struct {
+	Operator string // the TSL operator.
+	Expected string // the expected operand type.
+	Found    string // the operand type found.
+}
+
+func (e TypeError) Error() string {
+	return fmt.Sprintf("operator %s expects a %s operand, found: %s", e.Operator, e.Expected, e.Found)
+}
@@ -0,0 +1,197 @@
+// Copyright 2018 Yaacov Zamir <kobi.zamir@gmail.com>
+// and other contributors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package validate checks TSL trees against a schema of the document fields.
+//
+// Usage:
+//   schema := validate.Schema{
+//       "name":  {Type: validate.String},
+//       "pages": {Type: validate.Number, Ops: []string{tsl.LtOp, tsl.GtOp}},
+//   }
+//
+//   tree, err := tsl.ParseTSL("name = 3 and pages = 10 and title = 'x'")
+//
+//   // Returns the three violations, as validate.Errors.
+//   err = schema.Validate(tree)
+//
+//   // Infer the node types, and check operand types, e.g. `name > 5`
+//   // compares a string field to a number.
+//   typed, err := schema.TypeCheck(tree)
+//
+package validate
+
+import (
+	"github.com/yaacov/tree-search-language/pkg/tsl"
+)
+
+// Schema field types.
+const (
+	Any     = ""        // Any literal type is allowed.
+	String  = "string"  // Only string and date literals are allowed.
+	Number  = "number"  // Only number and duration literals are allowed.
+	Boolean = "boolean" // Only boolean literals are allowed.
+	Date    = "date"    // Only date literals are allowed.
+)
+
+// literalTypes maps TSL literal operators to schema field types.
+var literalTypes = map[string]string{
+	tsl.StringOp:   String,
+	tsl.DateOp:     Date,
+	tsl.NumberOp:   Number,
+	tsl.DurationOp: Number,
+	tsl.NowOp:      Date,
+	tsl.BooleanOp:  Boolean,
+}
+
+// Field describes a document field.
+type Field struct {
+	Type string   // the field type.
+	Ops  []string // the allowed TSL operators, all operators are allowed if nil.
+}
+
+// Schema maps the allowed field names to fields.
+//
+// Field names are matched exactly, wildcard identifiers like `spec.*` may
+// match fields that are not in the schema, so they are unknown fields unless
+// the schema has the wildcard field name.
+type Schema map[string]Field
+
+// Validate checks that a tree only use schema fields, using the allowed
+// operators, compared to literals of the right type.
+//
+// The whole tree is checked, and all the violations are returned as Errors,
+// unknown fields are reported once.
+func (s Schema) Validate(n tsl.Node) error {
+	v := &validator{schema: s, unknown: map[string]bool{}}
+	v.walk(n)
+
+	if len(v.errs) == 0 {
+		return nil
+	}
+	return v.errs
+}
+
+// validator collects the violations of a tree.
+type validator struct {
+	schema  Schema
+	unknown map[string]bool
+	errs    Errors
+}
+
+// walk checks a tree.
+func (v *validator) walk(n tsl.Node) {
+	switch n.Func {
+	case tsl.AndOp, tsl.OrOp:
+		v.walk(n.Left.(tsl.Node))
+		v.walk(n.Right.(tsl.Node))
+		return
+	case tsl.NotOp:
+		v.walk(n.Left.(tsl.Node))
+		return
+	}
+
+	l, ok := n.Left.(tsl.Node)
+	if !ok {
+		return
+	}
+	v.operand(l, n.Func)
+
+	// Check the literal types of fields.
+	if l.Func == tsl.IdentOp {
+		v.literals(l.Left.(string), n)
+	}
+}
+
+// operand checks the fields of a comparison operand, fields of math
+// expressions and function calls are checked using the math or function
+// operator.
+func (v *validator) operand(n tsl.Node, op string) {
+	if n.Func == tsl.IdentOp {
+		v.field(n.Left.(string), op)
+		return
+	}
+
+	if l, ok := n.Left.(tsl.Node); ok {
+		v.operand(l, n.Func)
+	}
+	if r, ok := n.Right.(tsl.Node); ok {
+		v.operand(r, n.Func)
+	}
+}
+
+// field checks that a field is in the schema, and that the operator is allowed.
+func (v *validator) field(name string, op string) {
+	f, ok := v.schema[name]
+	if !ok {
+		if !v.unknown[name] {
+			v.unknown[name] = true
+			v.errs = append(v.errs, UnknownFieldError{Field: name})
+		}
+		return
+	}
+
+	if f.Ops != nil && !contains(f.Ops, op) {
+		v.errs = append(v.errs, OperatorError{Field: name, Operator: op})
+	}
+}
+
+// literals checks that a field is compared to literals of the right type.
+func (v *validator) literals(name string, n tsl.Node) {
+	f, ok := v.schema[name]
+	if !ok || f.Type == Any {
+		return
+	}
+
+	switch n.Func {
+	case tsl.IsTrueOp, tsl.IsNotTrueOp, tsl.IsFalseOp, tsl.IsNotFalseOp:
+		if f.Type != Boolean {
+			v.errs = append(v.errs, FieldTypeError{Field: name, ExpectedType: f.Type, Literal: n.Func == tsl.IsTrueOp || n.Func == tsl.IsNotTrueOp})
+		}
+		return
+	}
+
+	r, ok := n.Right.(tsl.Node)
+	if !ok {
+		return
+	}
+
+	// Collect the literals.
+	literals := []tsl.Node{r}
+	if r.Func == tsl.ArrayOp {
+		literals = r.Right.([]tsl.Node)
+	}
+
+	for _, l := range literals {
+		if t, ok := literalTypes[l.Func]; ok && !allowed(f.Type, t) {
+			v.errs = append(v.errs, FieldTypeError{Field: name, ExpectedType: f.Type, Literal: l.Left})
+		}
+	}
+}
+
+// allowed checks if a literal type can be compared to a field type, date
+// literals are also strings.
+func allowed(fieldType string, literalType string) bool {
+	return fieldType == literalType || (fieldType == String && literalType == Date)
+}
+
+func contains(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+
+	return false
+}
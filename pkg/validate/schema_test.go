@@ -0,0 +1,80 @@
+// Copyright 2018 Yaacov Zamir <kobi.zamir@gmail.com>
+// and other contributors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validate
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/yaacov/tree-search-language/pkg/tsl"
+)
+
+func TestValidate(t *testing.T) {
+	schema := Schema{
+		"name":    {Type: String},
+		"pages":   {Type: Number, Ops: []string{tsl.LtOp, tsl.GtOp, tsl.BetweenOp, tsl.AddOp}},
+		"rating":  {Type: Number, Ops: []string{tsl.EqOp}},
+		"created": {Type: Date},
+		"active":  {Type: Boolean},
+		"tags":    {},
+	}
+
+	tests := []struct {
+		phrase string
+		want   Errors
+	}{
+		{phrase: "name = 'joe' and pages between 1 and 10 or not active is true", want: nil},
+		{phrase: "created > 2020-01-01 and name < '2020-01-01' and tags = 3", want: nil},
+		{phrase: "pages + rating > 10", want: Errors{OperatorError{Field: "rating", Operator: tsl.AddOp}}},
+		{phrase: "len(secret) > 3 or lower(name) = 'joe' or round(pages) < 3", want: Errors{UnknownFieldError{Field: "secret"}, OperatorError{Field: "pages", Operator: tsl.RoundOp}}},
+		{phrase: "name.* = 'joe' or tags.* = 'a'", want: Errors{UnknownFieldError{Field: "name.*"}, UnknownFieldError{Field: "tags.*"}}},
+		{
+			phrase: "name = 3 and pages = 10 and (title = 'x' or title is null)",
+			want: Errors{
+				FieldTypeError{Field: "name", ExpectedType: String, Literal: float64(3)},
+				OperatorError{Field: "pages", Operator: tsl.EqOp},
+				UnknownFieldError{Field: "title"},
+			},
+		},
+		{
+			phrase: "name in ('a', 1, true) and created < 'today' and name is true",
+			want: Errors{
+				FieldTypeError{Field: "name", ExpectedType: String, Literal: float64(1)},
+				FieldTypeError{Field: "name", ExpectedType: String, Literal: true},
+				FieldTypeError{Field: "created", ExpectedType: Date, Literal: "today"},
+				FieldTypeError{Field: "name", ExpectedType: String, Literal: true},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tree, err := tsl.ParseTSL(tt.phrase)
+		if err != nil {
+			t.Fatalf("failed to parse %s: %v", tt.phrase, err)
+		}
+
+		err = schema.Validate(tree)
+		if tt.want == nil {
+			if err != nil {
+				t.Errorf("Validate(%s) = %v, want nil", tt.phrase, err)
+			}
+			continue
+		}
+		if !reflect.DeepEqual(err, tt.want) {
+			t.Errorf("Validate(%s) = %v, want %v", tt.phrase, err, tt.want)
+		}
+	}
+}
@@ -0,0 +1,152 @@
+// Copyright 2018 Yaacov Zamir <kobi.zamir@gmail.com>
+// and other contributors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validate
+
+import (
+	"github.com/yaacov/tree-search-language/pkg/tsl"
+)
+
+// Null is the type of null literals, it can be compared to any type.
+const Null = "null"
+
+// TypedNode is a tree node annotated with its result type.
+type TypedNode struct {
+	tsl.Node
+
+	Type     string       // the node result type, Any if unknown.
+	Children []*TypedNode // the operand nodes, or the literals of an array.
+}
+
+// TypeCheck infers the result type of each node of a tree, using the schema
+// field types, and checks that operators are applied to operands of the right
+// type.
+//
+// Mismatches, like comparing a string field to a number in `author > 5`, are
+// found before walking the tree, instead of evaluating to false. All the
+// mismatches, and the unknown fields, are returned as Errors, with the typed
+// tree.
+//
+// Example:
+//  	schema := validate.Schema{"author": {Type: validate.String}}
+//
+//  	tree, err := tsl.ParseTSL("author > 5")
+//
+//  	// err is Errors{TypeError{Operator: "$gt", Expected: "string", Found: "number"}}.
+//  	typed, err := schema.TypeCheck(tree)
+//
+func (s Schema) TypeCheck(n tsl.Node) (*TypedNode, error) {
+	c := &checker{validator: validator{schema: s, unknown: map[string]bool{}}}
+	t := c.check(n)
+
+	if len(c.errs) == 0 {
+		return t, nil
+	}
+	return t, c.errs
+}
+
+// checker infers node types and collects the mismatches of a tree.
+type checker struct {
+	validator
+}
+
+// check returns the typed tree of a node.
+func (c *checker) check(n tsl.Node) *TypedNode {
+	t := &TypedNode{Node: n, Type: Boolean}
+
+	switch n.Func {
+	case tsl.IdentOp:
+		t.Type = c.fieldType(n.Left.(string))
+		return t
+	case tsl.StringOp, tsl.DateOp, tsl.NumberOp, tsl.DurationOp, tsl.NowOp, tsl.BooleanOp:
+		t.Type = literalTypes[n.Func]
+		return t
+	case tsl.NullOp:
+		t.Type = Null
+		return t
+	case tsl.ArrayOp:
+		// An array has the type of it's literals, if they have the same type.
+		t.Type = Any
+		for i, l := range n.Right.([]tsl.Node) {
+			e := c.check(l)
+			if i == 0 || e.Type == t.Type {
+				t.Type = e.Type
+			} else {
+				t.Type = Any
+			}
+			t.Children = append(t.Children, e)
+		}
+		return t
+	}
+
+	// Check the operands.
+	if l, ok := n.Left.(tsl.Node); ok {
+		t.Children = append(t.Children, c.check(l))
+	}
+	if r, ok := n.Right.(tsl.Node); ok {
+		t.Children = append(t.Children, c.check(r))
+	}
+
+	switch n.Func {
+	case tsl.AndOp, tsl.OrOp, tsl.NotOp, tsl.IsTrueOp, tsl.IsNotTrueOp, tsl.IsFalseOp, tsl.IsNotFalseOp:
+		c.expectAll(n.Func, Boolean, t.Children)
+	case tsl.AddOp, tsl.SubtractOp, tsl.MultiplyOp, tsl.DivideOp, tsl.ModuloOp:
+		c.expectAll(n.Func, Number, t.Children)
+		t.Type = Number
+	case tsl.IsNilOp, tsl.IsNotNilOp:
+		// Any type can be null.
+	case tsl.RegexOp, tsl.NotRegexOp, tsl.LikeOp, tsl.NotLikeOp, tsl.ILikeOp, tsl.NotILikeOp,
+		tsl.ContainsOp, tsl.NotContainsOp, tsl.StartsWithOp, tsl.NotStartsWithOp, tsl.EndsWithOp, tsl.NotEndsWithOp:
+		c.expectAll(n.Func, String, t.Children)
+	default:
+		// Comparisons, the right operand, or the array literals, must have the
+		// type of the left operand.
+		if len(t.Children) != 2 {
+			break
+		}
+
+		l, r := t.Children[0], t.Children[1]
+		if r.Func == tsl.ArrayOp {
+			c.expectAll(n.Func, l.Type, r.Children)
+		} else {
+			c.expectAll(n.Func, l.Type, []*TypedNode{r})
+		}
+	}
+
+	return t
+}
+
+// fieldType returns the schema type of a field.
+func (c *checker) fieldType(name string) string {
+	f, ok := c.schema[name]
+	if !ok {
+		if !c.unknown[name] {
+			c.unknown[name] = true
+			c.errs = append(c.errs, UnknownFieldError{Field: name})
+		}
+		return Any
+	}
+
+	return f.Type
+}
+
+// expectAll checks that typed nodes have the expected type.
+func (c *checker) expectAll(op string, expected string, nodes []*TypedNode) {
+	for _, t := range nodes {
+		if expected != Any && t.Type != Any && t.Type != Null && !allowed(expected, t.Type) {
+			c.errs = append(c.errs, TypeError{Operator: op, Expected: expected, Found: t.Type})
+		}
+	}
+}
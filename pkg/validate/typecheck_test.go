@@ -0,0 +1,89 @@
+// Copyright 2018 Yaacov Zamir <kobi.zamir@gmail.com>
+// and other contributors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validate
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/yaacov/tree-search-language/pkg/tsl"
+)
+
+func TestTypeCheck(t *testing.T) {
+	schema := Schema{
+		"author":  {Type: String},
+		"pages":   {Type: Number},
+		"created": {Type: Date},
+		"active":  {Type: Boolean},
+		"tags":    {},
+	}
+
+	tests := []struct {
+		phrase string
+		want   Errors
+	}{
+		{phrase: "author = 'joe' and pages * 2 between 1 and 10h or not active is true", want: nil},
+		{phrase: "created > 2020-01-01 and author like '2020-%' and tags = 3 and tags is null", want: nil},
+		{phrase: "author > 5", want: Errors{TypeError{Operator: tsl.GtOp, Expected: String, Found: Number}}},
+		{
+			phrase: "pages + author > 10 and pages ~= '^1' and active in (true, 'yes')",
+			want: Errors{
+				TypeError{Operator: tsl.AddOp, Expected: Number, Found: String},
+				TypeError{Operator: tsl.RegexOp, Expected: String, Found: Number},
+				TypeError{Operator: tsl.InOp, Expected: Boolean, Found: String},
+			},
+		},
+		{
+			phrase: "created < 'today' and pages is false or title = 1",
+			want: Errors{
+				TypeError{Operator: tsl.LtOp, Expected: Date, Found: String},
+				TypeError{Operator: tsl.IsFalseOp, Expected: Boolean, Found: Number},
+				UnknownFieldError{Field: "title"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tree, err := tsl.ParseTSL(tt.phrase)
+		if err != nil {
+			t.Fatalf("failed to parse %s: %v", tt.phrase, err)
+		}
+
+		_, err = schema.TypeCheck(tree)
+		if tt.want == nil {
+			if err != nil {
+				t.Errorf("TypeCheck(%s) = %v, want nil", tt.phrase, err)
+			}
+			continue
+		}
+		if !reflect.DeepEqual(err, tt.want) {
+			t.Errorf("TypeCheck(%s) = %v, want %v", tt.phrase, err, tt.want)
+		}
+	}
+
+	// Test the typed tree.
+	tree, _ := tsl.ParseTSL("pages * 2 > 10 and author in ('a', 'b')")
+	typed, err := schema.TypeCheck(tree)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	mul := typed.Children[0].Children[0]
+	list := typed.Children[1].Children[1]
+	if typed.Type != Boolean || mul.Type != Number || mul.Children[0].Type != Number || list.Type != String {
+		t.Errorf("unexpected types: %s, %s, %s, %s", typed.Type, mul.Type, mul.Children[0].Type, list.Type)
+	}
+}
@@ -0,0 +1,27 @@
+// Copyright 2018 Yaacov Zamir <kobi.zamir@gmail.com>
+// and other contributors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cel
+
+import "fmt"
+
+// ParseError is raised when CEL source fails to parse.
+type ParseError struct {
+	Msg string // the CEL parser errors.
+}
+
+func (e ParseError) Error() string {
+	return fmt.Sprintf("cel parse error: %s", e.Msg)
+}
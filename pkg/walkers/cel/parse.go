@@ -0,0 +1,288 @@
+// Copyright 2018 Yaacov Zamir <kobi.zamir@gmail.com>
+// and other contributors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cel
+
+import (
+	"strings"
+
+	"github.com/google/cel-go/common"
+	"github.com/google/cel-go/common/operators"
+	"github.com/google/cel-go/common/overloads"
+	"github.com/google/cel-go/parser"
+	exprpb "google.golang.org/genproto/googleapis/api/expr/v1alpha1"
+
+	"github.com/yaacov/tree-search-language/pkg/tsl"
+)
+
+// tslOps maps CEL functions to TSL binary operators.
+var tslOps = map[string]string{
+	operators.Equals:        tsl.EqOp,
+	operators.NotEquals:     tsl.NotEqOp,
+	operators.Less:          tsl.LtOp,
+	operators.LessEquals:    tsl.LteOp,
+	operators.Greater:       tsl.GtOp,
+	operators.GreaterEquals: tsl.GteOp,
+	operators.LogicalAnd:    tsl.AndOp,
+	operators.LogicalOr:     tsl.OrOp,
+	operators.Add:           tsl.AddOp,
+	operators.Subtract:      tsl.SubtractOp,
+	operators.Multiply:      tsl.MultiplyOp,
+	operators.Divide:        tsl.DivideOp,
+	operators.Modulo:        tsl.ModuloOp,
+}
+
+// substringOps maps CEL string functions to TSL substring operators.
+var substringOps = map[string]string{
+	overloads.Contains:   tsl.ContainsOp,
+	overloads.StartsWith: tsl.StartsWithOp,
+	overloads.EndsWith:   tsl.EndsWithOp,
+}
+
+// flipOps maps comparison operators to the operator used when the sides are
+// swapped, e.g. `5 < a` is `a > 5`.
+var flipOps = map[string]string{
+	tsl.EqOp:    tsl.EqOp,
+	tsl.NotEqOp: tsl.NotEqOp,
+	tsl.LtOp:    tsl.GtOp,
+	tsl.LteOp:   tsl.GteOp,
+	tsl.GtOp:    tsl.LtOp,
+	tsl.GteOp:   tsl.LteOp,
+}
+
+// ParseCEL parses CEL source into a TSL tree.
+func ParseCEL(src string) (tsl.Node, error) {
+	parsed, errs := parser.Parse(common.NewTextSource(src))
+	if len(errs.GetErrors()) > 0 {
+		return tsl.Node{}, ParseError{Msg: errs.ToDisplayString()}
+	}
+
+	n, err := Parse(parsed.GetExpr())
+	if err != nil {
+		return n, err
+	}
+
+	// Compile regular expressions, like trees parsed from TSL phrases.
+	return tsl.Prepare(n)
+}
+
+// Parse converts a CEL parsed expression into a TSL tree.
+//
+// Supported expressions are comparisons of fields (or math expressions of
+// fields) with literals, `in` lists of literals, `matches` calls, null
+// checks, and logical operators.
+func Parse(e *exprpb.Expr) (n tsl.Node, err error) {
+	var l, r tsl.Node
+
+	switch e.ExprKind.(type) {
+	case *exprpb.Expr_IdentExpr, *exprpb.Expr_SelectExpr:
+		var name string
+		if name, err = identName(e); err != nil {
+			return
+		}
+		n = tsl.Node{Func: tsl.IdentOp, Left: name}
+		return
+	case *exprpb.Expr_ConstExpr:
+		return literal(e.GetConstExpr())
+	case *exprpb.Expr_ListExpr:
+		nodes := []tsl.Node{}
+		for _, v := range e.GetListExpr().GetElements() {
+			if l, err = Parse(v); err != nil {
+				return
+			}
+			nodes = append(nodes, l)
+		}
+		n = tsl.Node{Func: tsl.ArrayOp, Right: nodes}
+		return
+	case *exprpb.Expr_CallExpr:
+		// Handled below.
+	default:
+		err = tsl.UnexpectedLiteralError{Literal: e.String()}
+		return
+	}
+
+	call := e.GetCallExpr()
+	args := call.GetArgs()
+	if call.GetTarget() != nil {
+		args = append([]*exprpb.Expr{call.GetTarget()}, args...)
+	}
+
+	switch function := call.GetFunction(); {
+	case function == operators.LogicalNot && len(args) == 1:
+		if l, err = Parse(args[0]); err != nil {
+			return
+		}
+		n = negate(l)
+	case function == operators.LogicalAnd || function == operators.LogicalOr:
+		// Fold variadic logical calls into binary nodes.
+		if n, err = Parse(args[0]); err != nil {
+			return
+		}
+		for _, arg := range args[1:] {
+			if r, err = Parse(arg); err != nil {
+				return
+			}
+			n = tsl.Node{Func: tslOps[function], Left: n, Right: r}
+		}
+	case (function == operators.In || function == operators.OldIn) && len(args) == 2:
+		if l, r, err = parseSides(args); err != nil {
+			return
+		}
+		if r.Func != tsl.ArrayOp || !literals(r.Right.([]tsl.Node)) {
+			err = tsl.UnexpectedLiteralError{ExpectedType: "list of literals", Literal: r.Func}
+			return
+		}
+		n = tsl.Node{Func: tsl.InOp, Left: l, Right: r}
+	case (function == overloads.Contains || function == overloads.StartsWith || function == overloads.EndsWith) && len(args) == 2:
+		if l, r, err = parseSides(args); err != nil {
+			return
+		}
+		if r.Func != tsl.StringOp {
+			err = tsl.UnexpectedLiteralError{ExpectedType: "string", Literal: r.Func}
+			return
+		}
+		n = tsl.Node{Func: substringOps[function], Left: l, Right: r}
+	case function == overloads.Matches && len(args) == 2:
+		if l, r, err = parseSides(args); err != nil {
+			return
+		}
+		if r.Func != tsl.StringOp {
+			err = tsl.UnexpectedLiteralError{ExpectedType: "string", Literal: r.Func}
+			return
+		}
+		n = tsl.Node{Func: tsl.RegexOp, Left: l, Right: r}
+	case tslOps[function] != "" && len(args) == 2:
+		if l, r, err = parseSides(args); err != nil {
+			return
+		}
+		n, err = binary(tslOps[function], l, r)
+	default:
+		err = tsl.UnexpectedLiteralError{Literal: function}
+	}
+
+	return
+}
+
+// parseSides parses the two arguments of a binary call.
+func parseSides(args []*exprpb.Expr) (l, r tsl.Node, err error) {
+	if l, err = Parse(args[0]); err != nil {
+		return
+	}
+	r, err = Parse(args[1])
+	return
+}
+
+// binary creates a binary TSL node, swapping the sides of comparisons with
+// the literal on the left.
+func binary(op string, l, r tsl.Node) (n tsl.Node, err error) {
+	if flipped, ok := flipOps[op]; ok && isLiteral(l) && !isLiteral(r) {
+		op, l, r = flipped, r, l
+	}
+
+	switch {
+	case (op == tsl.EqOp || op == tsl.NotEqOp) && r.Func == tsl.NullOp:
+		n = tsl.Node{Func: tsl.IsNilOp, Left: l}
+		if op == tsl.NotEqOp {
+			n.Func = tsl.IsNotNilOp
+		}
+	case op == tsl.AndOp || op == tsl.OrOp:
+		n = tsl.Node{Func: op, Left: l, Right: r}
+	case isLiteral(l):
+		err = tsl.UnexpectedLiteralError{Literal: l.Left}
+	case flipOps[op] != "" && !isLiteral(r):
+		err = tsl.UnexpectedLiteralError{ExpectedType: "literal", Literal: r.Func}
+	default:
+		n = tsl.Node{Func: op, Left: l, Right: r}
+	}
+
+	return
+}
+
+// negate returns the negated TSL node, using the negated operator when one
+// exists.
+func negate(n tsl.Node) tsl.Node {
+	switch n.Func {
+	case tsl.InOp:
+		n.Func = tsl.NotInOp
+	case tsl.RegexOp:
+		n.Func = tsl.NotRegexOp
+	case tsl.ContainsOp:
+		n.Func = tsl.NotContainsOp
+	case tsl.StartsWithOp:
+		n.Func = tsl.NotStartsWithOp
+	case tsl.EndsWithOp:
+		n.Func = tsl.NotEndsWithOp
+	default:
+		n = tsl.Node{Func: tsl.NotOp, Left: n}
+	}
+
+	return n
+}
+
+// identName returns the dot separated name of a field selection.
+func identName(e *exprpb.Expr) (string, error) {
+	switch k := e.ExprKind.(type) {
+	case *exprpb.Expr_IdentExpr:
+		return k.IdentExpr.GetName(), nil
+	case *exprpb.Expr_SelectExpr:
+		// Presence tests (e.g. has(a.b)) are not field selections.
+		if k.SelectExpr.GetTestOnly() {
+			break
+		}
+		operand, err := identName(k.SelectExpr.GetOperand())
+		if err != nil {
+			return "", err
+		}
+		return strings.Join([]string{operand, k.SelectExpr.GetField()}, "."), nil
+	}
+
+	return "", tsl.UnexpectedLiteralError{ExpectedType: "field", Literal: e.String()}
+}
+
+// literal converts a CEL constant into a TSL literal node.
+func literal(c *exprpb.Constant) (n tsl.Node, err error) {
+	switch k := c.ConstantKind.(type) {
+	case *exprpb.Constant_StringValue:
+		n = tsl.Node{Func: tsl.StringOp, Left: k.StringValue}
+	case *exprpb.Constant_Int64Value:
+		n = tsl.Node{Func: tsl.NumberOp, Left: float64(k.Int64Value)}
+	case *exprpb.Constant_Uint64Value:
+		n = tsl.Node{Func: tsl.NumberOp, Left: float64(k.Uint64Value)}
+	case *exprpb.Constant_DoubleValue:
+		n = tsl.Node{Func: tsl.NumberOp, Left: k.DoubleValue}
+	case *exprpb.Constant_BoolValue:
+		n = tsl.Node{Func: tsl.BooleanOp, Left: k.BoolValue}
+	case *exprpb.Constant_NullValue:
+		n = tsl.Node{Func: tsl.NullOp}
+	default:
+		err = tsl.UnexpectedLiteralError{Literal: c.String()}
+	}
+
+	return
+}
+
+func isLiteral(n tsl.Node) bool {
+	return n.Func == tsl.StringOp || n.Func == tsl.NumberOp || n.Func == tsl.BooleanOp || n.Func == tsl.NullOp
+}
+
+func literals(nodes []tsl.Node) bool {
+	for _, n := range nodes {
+		if !isLiteral(n) {
+			return false
+		}
+	}
+
+	return true
+}
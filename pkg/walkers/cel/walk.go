@@ -0,0 +1,264 @@
+// Copyright 2018 Yaacov Zamir <kobi.zamir@gmail.com>
+// and other contributors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package cel converts between TSL trees and CEL expressions.
+//
+// Walk converts a TSL tree into a CEL parsed expression, and Parse converts a
+// CEL parsed expression back into a TSL tree, where the expression can be
+// written using TSL.
+//
+// Usage:
+//   // Convert a TSL tree into CEL source.
+//   src, err := cel.Unparse(tree)
+//
+//   // Or check and evaluate the CEL expression using cel-go.
+//   expr, err := cel.Walk(tree)
+//   ast := celgo.ParsedExprToAst(&exprpb.ParsedExpr{Expr: expr})
+//
+//   // Convert CEL source into a TSL tree.
+//   tree, err := cel.ParseCEL("spec.pages > 100 && author in ['Joe', 'Jane']")
+//
+// cel-go: https://github.com/google/cel-go
+//
+package cel
+
+import (
+	"math"
+	"strings"
+
+	"github.com/google/cel-go/common/operators"
+	"github.com/google/cel-go/common/overloads"
+	"github.com/google/cel-go/parser"
+	exprpb "google.golang.org/genproto/googleapis/api/expr/v1alpha1"
+	structpb "google.golang.org/protobuf/types/known/structpb"
+
+	"github.com/yaacov/tree-search-language/pkg/tsl"
+)
+
+// ops maps TSL binary operators to CEL functions.
+var ops = map[string]string{
+	tsl.EqOp:       operators.Equals,
+	tsl.NotEqOp:    operators.NotEquals,
+	tsl.LtOp:       operators.Less,
+	tsl.LteOp:      operators.LessEquals,
+	tsl.GtOp:       operators.Greater,
+	tsl.GteOp:      operators.GreaterEquals,
+	tsl.AndOp:      operators.LogicalAnd,
+	tsl.OrOp:       operators.LogicalOr,
+	tsl.AddOp:      operators.Add,
+	tsl.SubtractOp: operators.Subtract,
+	tsl.MultiplyOp: operators.Multiply,
+	tsl.DivideOp:   operators.Divide,
+	tsl.ModuloOp:   operators.Modulo,
+}
+
+// substringOverloads maps TSL substring operators to CEL string functions.
+var substringOverloads = map[string]string{
+	tsl.ContainsOp:      overloads.Contains,
+	tsl.NotContainsOp:   overloads.Contains,
+	tsl.StartsWithOp:    overloads.StartsWith,
+	tsl.NotStartsWithOp: overloads.StartsWith,
+	tsl.EndsWithOp:      overloads.EndsWith,
+	tsl.NotEndsWithOp:   overloads.EndsWith,
+}
+
+// Walk travel the TSL tree to create a CEL parsed expression.
+//
+// Identifiers are converted to field selections (e.g. `spec.pages` selects
+// the `pages` field of `spec`), the BETWEEN operator is converted to two
+// comparisons, and the LIKE operator to a `matches` call.
+func Walk(n tsl.Node) (*exprpb.Expr, error) {
+	w := &walker{}
+	return w.walk(n)
+}
+
+// Unparse converts a TSL tree into CEL source.
+func Unparse(n tsl.Node) (string, error) {
+	e, err := Walk(n)
+	if err != nil {
+		return "", err
+	}
+
+	return parser.Unparse(e, &exprpb.SourceInfo{})
+}
+
+// walker creates CEL expressions with unique ids.
+type walker struct {
+	id int64
+}
+
+func (w *walker) walk(n tsl.Node) (e *exprpb.Expr, err error) {
+	var l, r *exprpb.Expr
+
+	switch n.Func {
+	case tsl.IdentOp:
+		e = w.ident(n.Left.(string))
+	case tsl.StringOp, tsl.DateOp:
+		e = w.constant(&exprpb.Constant{ConstantKind: &exprpb.Constant_StringValue{StringValue: n.Left.(string)}})
+	case tsl.NumberOp, tsl.DurationOp:
+		e = w.number(n.Left.(float64))
+	case tsl.BooleanOp:
+		e = w.constant(&exprpb.Constant{ConstantKind: &exprpb.Constant_BoolValue{BoolValue: n.Left.(bool)}})
+	case tsl.NullOp:
+		e = w.constant(&exprpb.Constant{ConstantKind: &exprpb.Constant_NullValue{NullValue: structpb.NullValue_NULL_VALUE}})
+	case tsl.ArrayOp:
+		list := &exprpb.Expr_CreateList{}
+		for _, v := range n.Right.([]tsl.Node) {
+			if l, err = w.walk(v); err != nil {
+				return
+			}
+			list.Elements = append(list.Elements, l)
+		}
+		e = w.expr(&exprpb.Expr{ExprKind: &exprpb.Expr_ListExpr{ListExpr: list}})
+	case tsl.EqOp, tsl.NotEqOp, tsl.LtOp, tsl.LteOp, tsl.GtOp, tsl.GteOp, tsl.AndOp, tsl.OrOp,
+		tsl.AddOp, tsl.SubtractOp, tsl.MultiplyOp, tsl.DivideOp, tsl.ModuloOp:
+		if l, r, err = w.walkSides(n); err != nil {
+			return
+		}
+		e = w.call(ops[n.Func], l, r)
+	case tsl.InOp, tsl.NotInOp:
+		if l, r, err = w.walkSides(n); err != nil {
+			return
+		}
+		e = w.call(operators.In, l, r)
+		if n.Func == tsl.NotInOp {
+			e = w.call(operators.LogicalNot, e)
+		}
+	case tsl.RegexOp, tsl.NotRegexOp:
+		if l, r, err = w.walkSides(n); err != nil {
+			return
+		}
+		e = w.member(overloads.Matches, l, r)
+		if n.Func == tsl.NotRegexOp {
+			e = w.call(operators.LogicalNot, e)
+		}
+	case tsl.LikeOp, tsl.NotLikeOp, tsl.ILikeOp, tsl.NotILikeOp:
+		if l, err = w.walk(n.Left.(tsl.Node)); err != nil {
+			return
+		}
+		fold := n.Func == tsl.ILikeOp || n.Func == tsl.NotILikeOp
+		pattern := tsl.LikePattern(n.Right.(tsl.Node).Left.(string), fold)
+		e = w.member(overloads.Matches, l, w.constant(&exprpb.Constant{ConstantKind: &exprpb.Constant_StringValue{StringValue: pattern}}))
+		if n.Func == tsl.NotLikeOp || n.Func == tsl.NotILikeOp {
+			e = w.call(operators.LogicalNot, e)
+		}
+	case tsl.ContainsOp, tsl.NotContainsOp, tsl.StartsWithOp, tsl.NotStartsWithOp, tsl.EndsWithOp, tsl.NotEndsWithOp:
+		if l, r, err = w.walkSides(n); err != nil {
+			return
+		}
+		e = w.member(substringOverloads[n.Func], l, r)
+		if n.Func == tsl.NotContainsOp || n.Func == tsl.NotStartsWithOp || n.Func == tsl.NotEndsWithOp {
+			e = w.call(operators.LogicalNot, e)
+		}
+	case tsl.BetweenOp, tsl.NotBetweenOp:
+		// CEL does not have a between function, translating sql's between into
+		// two comparisons, begin and end values are included.
+		values := n.Right.(tsl.Node).Right.([]tsl.Node)
+		left := n.Left.(tsl.Node)
+		if e, err = w.walk(tsl.Node{
+			Func:  tsl.AndOp,
+			Left:  tsl.Node{Func: tsl.GteOp, Left: left, Right: values[0]},
+			Right: tsl.Node{Func: tsl.LteOp, Left: left, Right: values[1]},
+		}); err != nil {
+			return
+		}
+		if n.Func == tsl.NotBetweenOp {
+			e = w.call(operators.LogicalNot, e)
+		}
+	case tsl.IsNilOp, tsl.IsNotNilOp:
+		if l, err = w.walk(n.Left.(tsl.Node)); err != nil {
+			return
+		}
+		r = w.constant(&exprpb.Constant{ConstantKind: &exprpb.Constant_NullValue{NullValue: structpb.NullValue_NULL_VALUE}})
+		op := operators.Equals
+		if n.Func == tsl.IsNotNilOp {
+			op = operators.NotEquals
+		}
+		e = w.call(op, l, r)
+	case tsl.IsTrueOp, tsl.IsFalseOp, tsl.IsNotTrueOp, tsl.IsNotFalseOp:
+		if l, err = w.walk(n.Left.(tsl.Node)); err != nil {
+			return
+		}
+		r = w.constant(&exprpb.Constant{ConstantKind: &exprpb.Constant_BoolValue{BoolValue: n.Func == tsl.IsTrueOp || n.Func == tsl.IsNotTrueOp}})
+		e = w.call(operators.Equals, l, r)
+		if n.Func == tsl.IsNotTrueOp || n.Func == tsl.IsNotFalseOp {
+			e = w.call(operators.LogicalNot, e)
+		}
+	case tsl.NotOp:
+		if l, err = w.walk(n.Left.(tsl.Node)); err != nil {
+			return
+		}
+		e = w.call(operators.LogicalNot, l)
+	default:
+		// If here than the operator is not supported.
+		err = tsl.UnexpectedLiteralError{Literal: n.Func}
+	}
+
+	return
+}
+
+// walkSides walks the left and right nodes of a binary operator.
+func (w *walker) walkSides(n tsl.Node) (l, r *exprpb.Expr, err error) {
+	if l, err = w.walk(n.Left.(tsl.Node)); err != nil {
+		return
+	}
+	r, err = w.walk(n.Right.(tsl.Node))
+	return
+}
+
+// expr sets a new id to an expression.
+func (w *walker) expr(e *exprpb.Expr) *exprpb.Expr {
+	w.id++
+	e.Id = w.id
+
+	return e
+}
+
+// ident creates a field selection expression from a dot separated identifier.
+func (w *walker) ident(s string) *exprpb.Expr {
+	parts := strings.Split(s, ".")
+
+	e := w.expr(&exprpb.Expr{ExprKind: &exprpb.Expr_IdentExpr{IdentExpr: &exprpb.Expr_Ident{Name: parts[0]}}})
+	for _, field := range parts[1:] {
+		e = w.expr(&exprpb.Expr{ExprKind: &exprpb.Expr_SelectExpr{SelectExpr: &exprpb.Expr_Select{Operand: e, Field: field}}})
+	}
+
+	return e
+}
+
+func (w *walker) constant(c *exprpb.Constant) *exprpb.Expr {
+	return w.expr(&exprpb.Expr{ExprKind: &exprpb.Expr_ConstExpr{ConstExpr: c}})
+}
+
+// number creates an int constant for whole numbers, and a double constant
+// otherwise.
+func (w *walker) number(f float64) *exprpb.Expr {
+	if f == math.Trunc(f) && math.Abs(f) < 1<<53 {
+		return w.constant(&exprpb.Constant{ConstantKind: &exprpb.Constant_Int64Value{Int64Value: int64(f)}})
+	}
+
+	return w.constant(&exprpb.Constant{ConstantKind: &exprpb.Constant_DoubleValue{DoubleValue: f}})
+}
+
+// call creates a global function call expression.
+func (w *walker) call(function string, args ...*exprpb.Expr) *exprpb.Expr {
+	return w.expr(&exprpb.Expr{ExprKind: &exprpb.Expr_CallExpr{CallExpr: &exprpb.Expr_Call{Function: function, Args: args}}})
+}
+
+// member creates a member function call expression.
+func (w *walker) member(function string, target *exprpb.Expr, args ...*exprpb.Expr) *exprpb.Expr {
+	return w.expr(&exprpb.Expr{ExprKind: &exprpb.Expr_CallExpr{CallExpr: &exprpb.Expr_Call{Function: function, Target: target, Args: args}}})
+}
+
@@ -0,0 +1,90 @@
+// Copyright 2018 Yaacov Zamir <kobi.zamir@gmail.com>
+// and other contributors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cel
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/yaacov/tree-search-language/pkg/tsl"
+)
+
+func TestUnparse(t *testing.T) {
+	tests := []struct {
+		phrase string
+		want   string
+	}{
+		{phrase: "name = 'joe' and spec.pages > 100", want: `name == "joe" && spec.pages > 100`},
+		{phrase: "spec.rating >= 4.5 or not author ~= 'J.*'", want: `spec.rating >= 4.5 || !author.matches("J.*")`},
+		{phrase: "city not in ('rome')", want: `!(city in ["rome"])`},
+		{phrase: "title like 'a%'", want: `title.matches("^a.*$")`},
+		{phrase: "pages between 1 and 10", want: `pages >= 1 && pages <= 10`},
+		{phrase: "author is not null", want: `author != null`},
+		{phrase: "pages * 2 < 30", want: `pages * 2 < 30`},
+	}
+
+	for _, tt := range tests {
+		tree, err := tsl.ParseTSL(tt.phrase)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		got, err := Unparse(tree)
+		if err != nil {
+			t.Errorf("Unparse(%s) error = %v", tt.phrase, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("Unparse(%s) = %s, want %s", tt.phrase, got, tt.want)
+		}
+	}
+}
+
+func TestParseCEL(t *testing.T) {
+	tests := []struct {
+		src     string
+		want    string
+		wantErr bool
+	}{
+		{src: `name == "joe" && spec.pages > 100`, want: "name = 'joe' and spec.pages > 100"},
+		{src: `100 < spec.pages`, want: "spec.pages > 100"},
+		{src: `!(city in ["rome"]) || author != null`, want: "city not in ('rome') or author is not null"},
+		{src: `!author.matches("J.*")`, want: "author ~! 'J.*'"},
+		{src: `!(pages * 2 < 30)`, want: "not pages * 2 < 30"},
+		{src: `size(name) > 3`, wantErr: true},
+		{src: `a == b`, wantErr: true},
+		{src: `a ==`, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseCEL(tt.src)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseCEL(%s) error = %v, wantErr %v", tt.src, err, tt.wantErr)
+			continue
+		}
+		if tt.wantErr {
+			continue
+		}
+
+		want, err := tsl.ParseTSL(tt.want)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("ParseCEL(%s) = %v, want %v", tt.src, got, want)
+		}
+	}
+}
@@ -0,0 +1,104 @@
+// Copyright 2018 Yaacov Zamir <kobi.zamir@gmail.com>
+// and other contributors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package columnar
+
+import "math/bits"
+
+// Bitmap is a row selection bitmap, bit i is set if row i is selected.
+type Bitmap struct {
+	words []uint64
+	n     int
+}
+
+// NewBitmap returns an empty bitmap of n rows.
+func NewBitmap(n int) Bitmap {
+	return Bitmap{words: make([]uint64, (n+63)/64), n: n}
+}
+
+// newFullBitmap returns a bitmap of n rows, all selected.
+func newFullBitmap(n int) Bitmap {
+	b := NewBitmap(n)
+	for i := range b.words {
+		b.words[i] = ^uint64(0)
+	}
+	b.trim()
+
+	return b
+}
+
+// Len returns the number of rows.
+func (b Bitmap) Len() int {
+	return b.n
+}
+
+// Get checks if row i is selected.
+func (b Bitmap) Get(i int) bool {
+	return b.words[i/64]&(1<<(uint(i)%64)) != 0
+}
+
+// Set selects row i.
+func (b Bitmap) Set(i int) {
+	b.words[i/64] |= 1 << (uint(i) % 64)
+}
+
+// Clear unselects row i.
+func (b Bitmap) Clear(i int) {
+	b.words[i/64] &^= 1 << (uint(i) % 64)
+}
+
+// And unselects the rows not selected in o.
+func (b Bitmap) And(o Bitmap) {
+	for i := range b.words {
+		b.words[i] &= o.words[i]
+	}
+}
+
+// Or selects the rows selected in o.
+func (b Bitmap) Or(o Bitmap) {
+	for i := range b.words {
+		b.words[i] |= o.words[i]
+	}
+}
+
+// Count returns the number of selected rows.
+func (b Bitmap) Count() (n int) {
+	for _, w := range b.words {
+		n += bits.OnesCount64(w)
+	}
+
+	return
+}
+
+// Indices returns the selected rows.
+func (b Bitmap) Indices() []int {
+	indices := make([]int, 0, b.Count())
+
+	for i, w := range b.words {
+		for w != 0 {
+			indices = append(indices, i*64+bits.TrailingZeros64(w))
+			w &= w - 1
+		}
+	}
+
+	return indices
+}
+
+// trim unselects the bits after the last row.
+func (b Bitmap) trim() {
+	if r := b.n % 64; r != 0 {
+		b.words[len(b.words)-1] &= (1 << uint(r)) - 1
+	}
+}
@@ -0,0 +1,110 @@
+// Copyright 2018 Yaacov Zamir <kobi.zamir@gmail.com>
+// and other contributors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package columnar
+
+import (
+	"github.com/apache/arrow/go/arrow/array"
+)
+
+// numberValues returns the values of a number column as float64, or nil if
+// the column is not a number column.
+func numberValues(col array.Interface) []float64 {
+	switch a := col.(type) {
+	case *array.Float64:
+		return a.Float64Values()
+	case *array.Float32:
+		values := make([]float64, a.Len())
+		for i, v := range a.Float32Values() {
+			values[i] = float64(v)
+		}
+		return values
+	case *array.Int64:
+		values := make([]float64, a.Len())
+		for i, v := range a.Int64Values() {
+			values[i] = float64(v)
+		}
+		return values
+	case *array.Int32:
+		values := make([]float64, a.Len())
+		for i, v := range a.Int32Values() {
+			values[i] = float64(v)
+		}
+		return values
+	case *array.Int16:
+		values := make([]float64, a.Len())
+		for i, v := range a.Int16Values() {
+			values[i] = float64(v)
+		}
+		return values
+	case *array.Int8:
+		values := make([]float64, a.Len())
+		for i, v := range a.Int8Values() {
+			values[i] = float64(v)
+		}
+		return values
+	case *array.Uint64:
+		values := make([]float64, a.Len())
+		for i, v := range a.Uint64Values() {
+			values[i] = float64(v)
+		}
+		return values
+	case *array.Uint32:
+		values := make([]float64, a.Len())
+		for i, v := range a.Uint32Values() {
+			values[i] = float64(v)
+		}
+		return values
+	case *array.Uint16:
+		values := make([]float64, a.Len())
+		for i, v := range a.Uint16Values() {
+			values[i] = float64(v)
+		}
+		return values
+	case *array.Uint8:
+		values := make([]float64, a.Len())
+		for i, v := range a.Uint8Values() {
+			values[i] = float64(v)
+		}
+		return values
+	}
+
+	return nil
+}
+
+// isStringColumn checks for columns compared as strings.
+func isStringColumn(col array.Interface) bool {
+	switch col.(type) {
+	case *array.String:
+		return true
+	}
+
+	return false
+}
+
+// isBoolColumn checks for columns compared as booleans.
+func isBoolColumn(col array.Interface) bool {
+	_, ok := col.(*array.Boolean)
+	return ok
+}
+
+// stringValue returns a function returning the values of a string column.
+func stringValue(col array.Interface) func(int) string {
+	if a, ok := col.(*array.String); ok {
+		return a.Value
+	}
+
+	return nil
+}
@@ -0,0 +1,389 @@
+// Copyright 2018 Yaacov Zamir <kobi.zamir@gmail.com>
+// and other contributors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package columnar evaluates TSL trees over Apache Arrow record batches.
+//
+// Trees are evaluated column at a time, each predicate scans one column and
+// produces a row selection bitmap, and logical operators combine bitmaps.
+// Rows are matched like semantics.Walk, null values and missing columns do
+// not match comparisons, boolean columns are compared to boolean literals.
+//
+// Usage:
+//   selection, err := columnar.Evaluate(tree, record)
+//
+//   for _, row := range selection.Indices() {
+//     ...
+//   }
+//
+package columnar
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/apache/arrow/go/arrow/array"
+
+	"github.com/yaacov/tree-search-language/pkg/tsl"
+)
+
+// Evaluate returns the rows of a record batch matching a TSL tree.
+//
+// Column names are the TSL identifiers, comparisons must compare an
+// identifier to literals.
+func Evaluate(n tsl.Node, rec array.Record) (Bitmap, error) {
+	e := evaluator{rec: rec, rows: int(rec.NumRows())}
+	return e.eval(n)
+}
+
+// evaluator holds the record batch being evaluated.
+type evaluator struct {
+	rec  array.Record
+	rows int
+}
+
+// eval returns the rows matching a node.
+func (e evaluator) eval(n tsl.Node) (Bitmap, error) {
+	switch n.Func {
+	case tsl.AndOp, tsl.OrOp:
+		l, err := e.eval(n.Left.(tsl.Node))
+		if err != nil {
+			return l, err
+		}
+		r, err := e.eval(n.Right.(tsl.Node))
+		if err != nil {
+			return r, err
+		}
+
+		if n.Func == tsl.AndOp {
+			l.And(r)
+		} else {
+			l.Or(r)
+		}
+		return l, nil
+	}
+
+	// Predicates compare an identifier to literals.
+	l, ok := n.Left.(tsl.Node)
+	if !ok || l.Func != tsl.IdentOp {
+		return Bitmap{}, tsl.UnexpectedLiteralError{Literal: n.Func}
+	}
+	name := l.Left.(string)
+	col := e.column(name)
+
+	switch n.Func {
+	case tsl.IsNilOp, tsl.IsNotNilOp:
+		return e.evalNil(n.Func, col), nil
+	case tsl.IsTrueOp, tsl.IsFalseOp:
+		// Check the value is equal to a boolean literal.
+		n = tsl.Node{Func: tsl.EqOp, Left: l, Right: tsl.Node{Func: tsl.BooleanOp, Left: n.Func == tsl.IsTrueOp}}
+	case tsl.IsNotTrueOp, tsl.IsNotFalseOp:
+		// Select the rows not equal to a boolean literal, null values are
+		// neither true nor false.
+		eq, err := e.eval(tsl.Node{Func: tsl.EqOp, Left: l, Right: tsl.Node{Func: tsl.BooleanOp, Left: n.Func == tsl.IsNotTrueOp}})
+		if err != nil {
+			return eq, err
+		}
+
+		b := newFullBitmap(e.rows)
+		for i := 0; i < e.rows; i++ {
+			if eq.Get(i) {
+				b.Clear(i)
+			}
+		}
+		return b, nil
+	}
+
+	// Comparisons of missing columns are false.
+	b := NewBitmap(e.rows)
+	if col == nil {
+		return b, nil
+	}
+
+	r, ok := n.Right.(tsl.Node)
+	if !ok {
+		return b, tsl.UnexpectedLiteralError{Literal: n.Func}
+	}
+
+	var err error
+	switch values := numberValues(col); {
+	case values != nil:
+		err = compareNumbers(n.Func, values, r, b)
+	case isStringColumn(col):
+		err = compareStrings(n.Func, stringValue(col), r, b)
+	case isBoolColumn(col):
+		err = compareBools(n.Func, col.(*array.Boolean), r, b)
+	default:
+		err = tsl.UnexpectedLiteralError{Literal: fmt.Sprintf("%s[%s]", name, col.DataType().Name())}
+	}
+	if err != nil {
+		return b, err
+	}
+
+	// Comparisons of null values are false.
+	if col.NullN() > 0 {
+		for i := 0; i < e.rows; i++ {
+			if col.IsNull(i) {
+				b.Clear(i)
+			}
+		}
+	}
+
+	return b, nil
+}
+
+// column returns a column by name, or nil if missing.
+func (e evaluator) column(name string) array.Interface {
+	indices := e.rec.Schema().FieldIndices(name)
+	if len(indices) == 0 {
+		return nil
+	}
+
+	return e.rec.Column(indices[0])
+}
+
+// evalNil returns the rows matching IS NULL and IS NOT NULL.
+func (e evaluator) evalNil(op string, col array.Interface) Bitmap {
+	// All the values of missing columns are null.
+	if col == nil {
+		if op == tsl.IsNilOp {
+			return newFullBitmap(e.rows)
+		}
+		return NewBitmap(e.rows)
+	}
+
+	b := NewBitmap(e.rows)
+	for i := 0; i < e.rows; i++ {
+		if col.IsNull(i) == (op == tsl.IsNilOp) {
+			b.Set(i)
+		}
+	}
+
+	return b
+}
+
+// compareNumbers selects the rows of number values matching a predicate.
+func compareNumbers(op string, values []float64, r tsl.Node, b Bitmap) error {
+	switch r.Func {
+	case tsl.NumberOp, tsl.DurationOp:
+		f := r.Left.(float64)
+
+		switch op {
+		case tsl.EqOp:
+			for i, v := range values {
+				if v == f {
+					b.Set(i)
+				}
+			}
+		case tsl.NotEqOp:
+			for i, v := range values {
+				if v != f {
+					b.Set(i)
+				}
+			}
+		case tsl.LtOp:
+			for i, v := range values {
+				if v < f {
+					b.Set(i)
+				}
+			}
+		case tsl.LteOp:
+			for i, v := range values {
+				if v <= f {
+					b.Set(i)
+				}
+			}
+		case tsl.GtOp:
+			for i, v := range values {
+				if v > f {
+					b.Set(i)
+				}
+			}
+		case tsl.GteOp:
+			for i, v := range values {
+				if v >= f {
+					b.Set(i)
+				}
+			}
+		default:
+			return tsl.UnexpectedLiteralError{Literal: op}
+		}
+		return nil
+	case tsl.ArrayOp:
+		list := []float64{}
+		for _, v := range r.Right.([]tsl.Node) {
+			f, ok := v.Left.(float64)
+			if !ok {
+				return tsl.UnexpectedLiteralError{Literal: fmt.Sprintf("%v", v.Left)}
+			}
+			list = append(list, f)
+		}
+
+		match, err := listPredicate(op, len(list))
+		if err != nil {
+			return err
+		}
+
+		set := map[float64]bool{}
+		for _, f := range list {
+			set[f] = true
+		}
+		for i, v := range values {
+			if match(set[v], len(list) == 2 && v >= list[0] && v < list[1]) {
+				b.Set(i)
+			}
+		}
+		return nil
+	}
+
+	return tsl.UnexpectedLiteralError{Literal: fmt.Sprintf("%v", r.Left)}
+}
+
+// compareStrings selects the rows of string values matching a predicate.
+func compareStrings(op string, value func(int) string, r tsl.Node, b Bitmap) error {
+	switch r.Func {
+	case tsl.StringOp, tsl.DateOp:
+		s := r.Left.(string)
+
+		var match func(string) bool
+		switch op {
+		case tsl.EqOp:
+			match = func(v string) bool { return v == s }
+		case tsl.NotEqOp:
+			match = func(v string) bool { return v != s }
+		case tsl.LtOp:
+			match = func(v string) bool { return v < s }
+		case tsl.LteOp:
+			match = func(v string) bool { return v <= s }
+		case tsl.GtOp:
+			match = func(v string) bool { return v > s }
+		case tsl.GteOp:
+			match = func(v string) bool { return v >= s }
+		case tsl.RegexOp, tsl.NotRegexOp:
+			re, err := tsl.Regexp(r)
+			if err != nil {
+				return err
+			}
+			want := op == tsl.RegexOp
+			match = func(v string) bool { return re.MatchString(v) == want }
+		case tsl.ContainsOp, tsl.NotContainsOp:
+			want := op == tsl.ContainsOp
+			match = func(v string) bool { return strings.Contains(v, s) == want }
+		case tsl.StartsWithOp, tsl.NotStartsWithOp:
+			want := op == tsl.StartsWithOp
+			match = func(v string) bool { return strings.HasPrefix(v, s) == want }
+		case tsl.EndsWithOp, tsl.NotEndsWithOp:
+			want := op == tsl.EndsWithOp
+			match = func(v string) bool { return strings.HasSuffix(v, s) == want }
+		default:
+			return tsl.UnexpectedLiteralError{Literal: op}
+		}
+
+		for i := 0; i < b.Len(); i++ {
+			if match(value(i)) {
+				b.Set(i)
+			}
+		}
+		return nil
+	case tsl.ArrayOp:
+		list := []string{}
+		for _, v := range r.Right.([]tsl.Node) {
+			s, ok := v.Left.(string)
+			if !ok {
+				return tsl.UnexpectedLiteralError{Literal: fmt.Sprintf("%v", v.Left)}
+			}
+			list = append(list, s)
+		}
+
+		match, err := listPredicate(op, len(list))
+		if err != nil {
+			return err
+		}
+
+		set := map[string]bool{}
+		for _, s := range list {
+			set[s] = true
+		}
+		for i := 0; i < b.Len(); i++ {
+			v := value(i)
+			if match(set[v], len(list) == 2 && v >= list[0] && v < list[1]) {
+				b.Set(i)
+			}
+		}
+		return nil
+	}
+
+	return tsl.UnexpectedLiteralError{Literal: fmt.Sprintf("%v", r.Left)}
+}
+
+// compareBools selects the rows of boolean values matching a predicate.
+func compareBools(op string, values *array.Boolean, r tsl.Node, b Bitmap) error {
+	var match func(bool) bool
+
+	switch r.Func {
+	case tsl.BooleanOp:
+		want := r.Left.(bool)
+		switch op {
+		case tsl.EqOp:
+			match = func(v bool) bool { return v == want }
+		case tsl.NotEqOp:
+			match = func(v bool) bool { return v != want }
+		default:
+			return tsl.UnexpectedLiteralError{Literal: op}
+		}
+	case tsl.ArrayOp:
+		set := map[bool]bool{}
+		for _, v := range r.Right.([]tsl.Node) {
+			f, ok := v.Left.(bool)
+			if !ok {
+				return tsl.UnexpectedLiteralError{Literal: fmt.Sprintf("%v", v.Left)}
+			}
+			set[f] = true
+		}
+
+		if op != tsl.InOp && op != tsl.NotInOp {
+			return tsl.UnexpectedLiteralError{Literal: op}
+		}
+		want := op == tsl.InOp
+		match = func(v bool) bool { return set[v] == want }
+	default:
+		return tsl.UnexpectedLiteralError{Literal: fmt.Sprintf("%v", r.Left)}
+	}
+
+	for i := 0; i < b.Len(); i++ {
+		if match(values.Value(i)) {
+			b.Set(i)
+		}
+	}
+	return nil
+}
+
+// listPredicate returns the predicate of a list operator, given if the value
+// is in the list, and if it is between the two list values.
+func listPredicate(op string, n int) (func(in, between bool) bool, error) {
+	switch op {
+	case tsl.InOp:
+		return func(in, between bool) bool { return in }, nil
+	case tsl.NotInOp:
+		return func(in, between bool) bool { return !in }, nil
+	case tsl.BetweenOp, tsl.NotBetweenOp:
+		if n != 2 {
+			return nil, tsl.UnexpectedLiteralError{Literal: op}
+		}
+		want := op == tsl.BetweenOp
+		return func(in, between bool) bool { return between == want }, nil
+	}
+
+	return nil, tsl.UnexpectedLiteralError{Literal: op}
+}
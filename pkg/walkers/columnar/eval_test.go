@@ -0,0 +1,179 @@
+// Copyright 2018 Yaacov Zamir <kobi.zamir@gmail.com>
+// and other contributors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package columnar
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/array"
+	"github.com/apache/arrow/go/arrow/memory"
+
+	"github.com/yaacov/tree-search-language/pkg/tsl"
+	"github.com/yaacov/tree-search-language/pkg/walkers/semantics"
+)
+
+var schema = arrow.NewSchema([]arrow.Field{
+	{Name: "title", Type: arrow.BinaryTypes.String, Nullable: true},
+	{Name: "author", Type: arrow.BinaryTypes.String},
+	{Name: "pages", Type: arrow.PrimitiveTypes.Int64, Nullable: true},
+	{Name: "rating", Type: arrow.PrimitiveTypes.Float64},
+	{Name: "published", Type: arrow.FixedWidthTypes.Boolean},
+}, nil)
+
+// newRecord returns a record of n rows, and the same rows as documents.
+func newRecord(n int) (array.Record, []map[string]interface{}) {
+	b := array.NewRecordBuilder(memory.NewGoAllocator(), schema)
+	defer b.Release()
+
+	docs := []map[string]interface{}{}
+	for i := 0; i < n; i++ {
+		doc := map[string]interface{}{
+			"author":    []string{"Joe", "Jane", "Bob"}[i%3],
+			"rating":    float64(i % 5),
+			"published": i%2 == 0,
+		}
+
+		if i%7 == 0 {
+			b.Field(0).(*array.StringBuilder).AppendNull()
+			b.Field(2).(*array.Int64Builder).AppendNull()
+		} else {
+			doc["title"] = fmt.Sprintf("Book %d", i)
+			doc["pages"] = int64(i * 10)
+			b.Field(0).(*array.StringBuilder).Append(doc["title"].(string))
+			b.Field(2).(*array.Int64Builder).Append(doc["pages"].(int64))
+		}
+		b.Field(1).(*array.StringBuilder).Append(doc["author"].(string))
+		b.Field(3).(*array.Float64Builder).Append(doc["rating"].(float64))
+		b.Field(4).(*array.BooleanBuilder).Append(doc["published"].(bool))
+
+		docs = append(docs, doc)
+	}
+
+	return b.NewRecord(), docs
+}
+
+func TestEvaluate(t *testing.T) {
+	rec, docs := newRecord(100)
+	defer rec.Release()
+
+	phrases := []string{
+		"author = 'Joe'",
+		"author != 'Joe' or pages >= 500",
+		"title ~= '^Book 1' and pages > 100",
+		"title ~! '5$'",
+		"author in ('Joe', 'Jim')",
+		"author not in ('Joe', 'Jim')",
+		"rating in (1, 3)",
+		"rating not in (1, 3)",
+		"pages between 100 and 500",
+		"pages not between 100 and 500",
+		"title between 'Book 2' and 'Book 5'",
+		"title is null",
+		"pages is not null and rating < 2",
+		"published = true",
+		"published != true or rating in (1, 3)",
+		"published in (false)",
+		"published is false or rating = 4",
+		"published is true and pages > 100",
+		"published is not true or isbn is not false",
+		"isbn is null",
+		"isbn = 'x' or rating = 4",
+	}
+
+	for _, phrase := range phrases {
+		tree, err := tsl.ParseTSL(phrase)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		b, err := Evaluate(tree, rec)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		want := []int{}
+		for i, doc := range docs {
+			ok, err := semantics.Walk(tree, func(k string) (interface{}, bool) {
+				v, ok := doc[k]
+				return v, ok
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if ok {
+				want = append(want, i)
+			}
+		}
+
+		if got := b.Indices(); !reflect.DeepEqual(got, want) {
+			t.Errorf("Evaluate(%q) = %v, want %v", phrase, got, want)
+		}
+	}
+
+	// Comparing a number column to a string is an error.
+	tree, _ := tsl.ParseTSL("pages = 'many'")
+	if _, err := Evaluate(tree, rec); err == nil {
+		t.Error("Evaluate() error = nil, want unexpected literal error")
+	}
+}
+
+func TestPrune(t *testing.T) {
+	groups := []RowGroup{
+		{Rows: 100, Columns: map[string]ColumnStats{"pages": {Min: int64(0), Max: int64(99)}, "author": {Min: "Bob", Max: "Joe"}}},
+		{Rows: 100, Columns: map[string]ColumnStats{"pages": {Min: int64(100), Max: int64(199)}, "author": {Min: "Jane", Max: "Jane"}}},
+		{Rows: 100, Columns: map[string]ColumnStats{"pages": {Nulls: 100}}},
+		{Rows: 100},
+		{Rows: 0},
+	}
+
+	tests := []struct {
+		phrase string
+		want   []int
+	}{
+		{"pages >= 150", []int{1, 3}},
+		{"pages < 100 or pages in (250, 150)", []int{0, 1, 3}},
+		{"author = 'Joe' and pages between 50 and 150", []int{0, 3}},
+		{"author = 'Jim' and rating > 4", []int{0, 2, 3}},
+		{"pages > 500 and pages < 300", []int{}},
+		{"title is null", []int{0, 1, 2, 3}},
+	}
+
+	for _, tt := range tests {
+		tree, err := tsl.ParseTSL(tt.phrase)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if got := Prune(tree, groups); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("Prune(%q) = %v, want %v", tt.phrase, got, tt.want)
+		}
+	}
+}
+
+func BenchmarkEvaluate(b *testing.B) {
+	rec, _ := newRecord(1000000)
+	defer rec.Release()
+
+	tree, _ := tsl.ParseTSL("author in ('Joe', 'Jane') and pages between 50 and 5000000 and rating < 3")
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		Evaluate(tree, rec)
+	}
+}
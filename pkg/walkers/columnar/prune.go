@@ -0,0 +1,113 @@
+// Copyright 2018 Yaacov Zamir <kobi.zamir@gmail.com>
+// and other contributors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package columnar
+
+import (
+	"github.com/yaacov/tree-search-language/pkg/tsl"
+	"github.com/yaacov/tree-search-language/pkg/walkers/ranges"
+)
+
+// ColumnStats are the statistics of a column in a row group, e.g. read from
+// Parquet row group or page metadata.
+type ColumnStats struct {
+	Min   interface{} // the smallest string or number value, nil if unknown.
+	Max   interface{} // the largest string or number value, nil if unknown.
+	Nulls int64       // the number of null values.
+}
+
+// RowGroup holds the statistics of a group of rows.
+type RowGroup struct {
+	Rows    int64                  // the number of rows.
+	Columns map[string]ColumnStats // the column statistics, missing columns are not pruned.
+}
+
+// Prune returns the indices of the row groups that may have rows matching a
+// tree, row groups that can not match can be skipped without reading them.
+//
+// The field ranges of the tree are compared to the min/max statistics of the
+// row groups, and row groups where a constrained column is all null are
+// skipped.
+func Prune(tree tsl.Node, groups []RowGroup) []int {
+	fields := ranges.Extract(tree)
+	indices := []int{}
+
+	for i, g := range groups {
+		if mayMatch(fields, g) {
+			indices = append(indices, i)
+		}
+	}
+
+	return indices
+}
+
+// mayMatch checks if a row group may have rows in the field ranges.
+func mayMatch(fields map[string]ranges.Range, g RowGroup) bool {
+	if g.Rows == 0 {
+		return false
+	}
+
+	for field, r := range fields {
+		if r.IsEmpty() {
+			return false
+		}
+
+		stats, ok := g.Columns[field]
+		if !ok {
+			continue
+		}
+
+		// Null values are not in any range.
+		if stats.Nulls >= g.Rows {
+			return false
+		}
+
+		if overlaps, _ := r.Overlaps(statValue(stats.Min), statValue(stats.Max)); !overlaps {
+			return false
+		}
+	}
+
+	return true
+}
+
+// statValue converts statistics number values to float64.
+func statValue(v interface{}) interface{} {
+	switch v := v.(type) {
+	case float32:
+		return float64(v)
+	case int:
+		return float64(v)
+	case int8:
+		return float64(v)
+	case int16:
+		return float64(v)
+	case int32:
+		return float64(v)
+	case int64:
+		return float64(v)
+	case uint:
+		return float64(v)
+	case uint8:
+		return float64(v)
+	case uint16:
+		return float64(v)
+	case uint32:
+		return float64(v)
+	case uint64:
+		return float64(v)
+	}
+
+	return v
+}
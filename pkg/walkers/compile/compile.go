@@ -0,0 +1,51 @@
+// Copyright 2018 Yaacov Zamir <kobi.zamir@gmail.com>
+// and other contributors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package compile compiles TSL trees into nested Go closures.
+//
+// A compiled tree evaluates documents like semantics.Walk, without walking
+// the tree and switching on node operators and literal types for every
+// document. Trees are compiled by semantics.Compile, comparisons are
+// specialized by literal type at compile time, and regular expressions are
+// compiled once.
+//
+// Usage:
+//   m, err := compile.Compile(tree)
+//
+//   for _, doc := range docs {
+//     ok, err := m(evalFactory(doc))
+//     ...
+//   }
+//
+package compile
+
+import (
+	"github.com/yaacov/tree-search-language/pkg/tsl"
+	"github.com/yaacov/tree-search-language/pkg/walkers/semantics"
+)
+
+// Matcher is a compiled TSL tree, it checks if a document compiles to `true`
+// or `false`.
+type Matcher func(eval semantics.EvalFunc) (bool, error)
+
+// Compile compiles a TSL tree into a matcher, using semantics.Compile.
+func Compile(n tsl.Node) (Matcher, error) {
+	m, err := semantics.Compile(n)
+	if err != nil {
+		return nil, err
+	}
+
+	return m, nil
+}
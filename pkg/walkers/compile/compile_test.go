@@ -0,0 +1,209 @@
+// Copyright 2018 Yaacov Zamir <kobi.zamir@gmail.com>
+// and other contributors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package compile
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/yaacov/tree-search-language/pkg/tsl"
+	"github.com/yaacov/tree-search-language/pkg/walkers/semantics"
+)
+
+var docs = []map[string]interface{}{
+	{"title": "Book", "author": "Joe", "pages": 100, "rating": 4.5, "published": true},
+	{"title": "Other Book", "author": "Jane", "pages": int64(50), "rating": nil},
+	{"title": "Big Book", "author": "Joe", "pages": uint32(500), "rating": float32(3)},
+	{},
+}
+
+func evalFactory(doc map[string]interface{}) semantics.EvalFunc {
+	return func(k string) (interface{}, bool) {
+		v, ok := doc[k]
+		return v, ok
+	}
+}
+
+func TestCompile(t *testing.T) {
+	phrases := []string{
+		"author = 'Joe'",
+		"author != 'Joe' or pages >= 500",
+		"title ~= '^Big' and pages > 100",
+		"title ~! 'Book$'",
+		"author in ('Joe', 'Jim')",
+		"author not in ('Joe', 'Jim')",
+		"pages in (50, 100)",
+		"pages not in (50, 100)",
+		"pages between 50 and 500",
+		"pages not between 50 and 500",
+		"title between 'A' and 'C'",
+		"rating is null",
+		"rating is not null and rating < 4",
+		"published = true",
+		"author < 'K' and author <= 'Joe' and pages != 7",
+		"((rating is null or pages < 60) and author = 'Jane') or title = 'Big Book'",
+	}
+
+	for _, phrase := range phrases {
+		tree, err := tsl.ParseTSL(phrase)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		m, err := Compile(tree)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		for _, doc := range docs {
+			eval := evalFactory(doc)
+
+			want, wantErr := semantics.Walk(tree, eval)
+			got, err := m(eval)
+			if got != want || (err == nil) != (wantErr == nil) {
+				t.Errorf("%q on %v = %v, %v, want %v, %v", phrase, doc, got, err, want, wantErr)
+			}
+		}
+	}
+}
+
+// integerPhrases compare integers float64 can not hold exactly.
+var integerPhrases = []string{
+	"id = 9007199254740993",
+	"id = 9007199254740992",
+	"id != 9007199254740993",
+	"id > 9007199254740992",
+	"id >= 9007199254740993",
+	"id < 9007199254740993",
+	"id > 5",
+	"id in (9007199254740992)",
+	"id in (9007199254740993, 1)",
+	"id not in (9007199254740993)",
+	"id between 9007199254740992 and 9007199254740993",
+	"id = 18446744073709551615",
+}
+
+var integerDocs = []map[string]interface{}{
+	{"id": int64(9007199254740993)},
+	{"id": uint64(9007199254740992)},
+	{"id": float64(9007199254740992)},
+	{"id": uint64(18446744073709551615)},
+	{"id": int64(-9007199254740993)},
+	{"id": 5},
+	{"id": []interface{}{int64(9007199254740993)}},
+}
+
+func TestCompileIntegers(t *testing.T) {
+	for _, phrase := range integerPhrases {
+		tree, err := tsl.ParseTSL(phrase)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		m, err := Compile(tree)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		for _, doc := range integerDocs {
+			eval := evalFactory(doc)
+
+			want, wantErr := semantics.Walk(tree, eval)
+			got, err := m(eval)
+			if got != want || (err == nil) != (wantErr == nil) {
+				t.Errorf("%q on %v = %v, %v, want %v, %v", phrase, doc, got, err, want, wantErr)
+			}
+		}
+	}
+}
+
+func TestCompileTypes(t *testing.T) {
+	created := time.Date(2019, 3, 1, 0, 0, 0, 0, time.UTC)
+	eval := evalFactory(map[string]interface{}{"created": created, "pages": "many"})
+
+	tests := []struct {
+		phrase  string
+		want    bool
+		wantErr bool
+	}{
+		{"created > 2019-01-01T00:00:00Z", true, false},
+		{"created < 2019-01-01T00:00:00Z", false, false},
+		{"created = 'March'", false, true},
+		{"pages > 5", false, true},
+	}
+
+	for _, tt := range tests {
+		tree, _ := tsl.ParseTSL(tt.phrase)
+		m, err := Compile(tree)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		got, err := m(eval)
+		if got != tt.want || (err != nil) != tt.wantErr {
+			t.Errorf("%q = %v, %v, want %v, error %v", tt.phrase, got, err, tt.want, tt.wantErr)
+		}
+	}
+
+	// Bad regular expressions fail to compile.
+	tree := tsl.Node{
+		Func:  tsl.RegexOp,
+		Left:  tsl.Node{Func: tsl.IdentOp, Left: "title"},
+		Right: tsl.Node{Func: tsl.StringOp, Left: "("},
+	}
+	if _, err := Compile(tree); err == nil {
+		t.Error("Compile() error = nil, want bad regexp error")
+	}
+}
+
+// benchmarkPhrase is a typical filter.
+const benchmarkPhrase = "author in ('Joe', 'Jane', 'Jim') and pages between 50 and 500 and title ~= 'Book' and rating is not null"
+
+func benchmarkDocs() []semantics.EvalFunc {
+	evals := []semantics.EvalFunc{}
+	for i := 0; i < 1000; i++ {
+		evals = append(evals, evalFactory(map[string]interface{}{
+			"title":  fmt.Sprintf("Book %d", i),
+			"author": []string{"Joe", "Jane", "Bob"}[i%3],
+			"pages":  i,
+			"rating": float64(i % 5),
+		}))
+	}
+
+	return evals
+}
+
+func BenchmarkWalk(b *testing.B) {
+	tree, _ := tsl.ParseTSL(benchmarkPhrase)
+	evals := benchmarkDocs()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		semantics.Walk(tree, evals[i%len(evals)])
+	}
+}
+
+func BenchmarkCompile(b *testing.B) {
+	tree, _ := tsl.ParseTSL(benchmarkPhrase)
+	m, _ := Compile(tree)
+	evals := benchmarkDocs()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		m(evals[i%len(evals)])
+	}
+}
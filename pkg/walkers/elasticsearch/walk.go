@@ -0,0 +1,296 @@
+// Copyright 2018 Yaacov Zamir <kobi.zamir@gmail.com>
+// and other contributors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package elasticsearch helps to create Elasticsearch query DSL filters using
+// the TSL package.
+package elasticsearch
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/yaacov/tree-search-language/pkg/tsl"
+)
+
+// Query is an Elasticsearch query DSL object, it marshals into the JSON body
+// of a search query.
+type Query = map[string]interface{}
+
+// Walk travel the TSL tree to create an Elasticsearch bool query.
+//
+// Comparisons are translated into term, terms, range, exists, regexp, prefix
+// and wildcard queries, joined using bool queries. Like SQL, negated
+// comparisons do not match missing fields.
+//
+//  // Prepare a query
+//  query, _ := elasticsearch.Walk(tree)
+//
+//  // Create the search body
+//  body, _ := json.Marshal(map[string]interface{}{"query": query})
+//
+// Elasticsearch query DSL: https://www.elastic.co/guide/en/elasticsearch/reference/current/query-dsl.html
+//
+func Walk(n tsl.Node) (Query, error) {
+	switch n.Func {
+	case tsl.AndOp, tsl.OrOp:
+		l, err := Walk(n.Left.(tsl.Node))
+		if err != nil {
+			return nil, err
+		}
+		r, err := Walk(n.Right.(tsl.Node))
+		if err != nil {
+			return nil, err
+		}
+
+		if n.Func == tsl.AndOp {
+			return Query{"bool": Query{"filter": []interface{}{l, r}}}, nil
+		}
+		return Query{"bool": Query{"should": []interface{}{l, r}, "minimum_should_match": 1}}, nil
+	case tsl.NotOp:
+		l, err := Walk(n.Left.(tsl.Node))
+		if err != nil {
+			return nil, err
+		}
+		return mustNot(l), nil
+	}
+
+	field, err := identString(n.Left)
+	if err != nil {
+		return nil, err
+	}
+
+	switch n.Func {
+	case tsl.IsNilOp:
+		return mustNot(exists(field)), nil
+	case tsl.IsNotNilOp:
+		return exists(field), nil
+	case tsl.IsTrueOp, tsl.IsFalseOp:
+		return term(field, n.Func == tsl.IsTrueOp), nil
+	case tsl.IsNotTrueOp, tsl.IsNotFalseOp:
+		// Not true also matches null and missing values.
+		return mustNot(term(field, n.Func == tsl.IsNotTrueOp)), nil
+	}
+
+	r, ok := n.Right.(tsl.Node)
+	if !ok {
+		return nil, tsl.UnexpectedLiteralError{Literal: n.Func}
+	}
+
+	switch n.Func {
+	case tsl.EqOp:
+		return term(field, literal(r)), nil
+	case tsl.NotEqOp:
+		return not(field, term(field, literal(r))), nil
+	case tsl.LtOp:
+		return rangeQuery(field, Query{"lt": literal(r)}), nil
+	case tsl.LteOp:
+		return rangeQuery(field, Query{"lte": literal(r)}), nil
+	case tsl.GtOp:
+		return rangeQuery(field, Query{"gt": literal(r)}), nil
+	case tsl.GteOp:
+		return rangeQuery(field, Query{"gte": literal(r)}), nil
+	case tsl.InOp, tsl.NotInOp:
+		values, err := arrayValues(r)
+		if err != nil {
+			return nil, err
+		}
+
+		q := Query{"terms": Query{field: values}}
+		if n.Func == tsl.NotInOp {
+			return not(field, q), nil
+		}
+		return q, nil
+	case tsl.BetweenOp, tsl.NotBetweenOp:
+		// Note: sql's between operator is inclusive: begin and end values are included.
+		values, err := arrayValues(r)
+		if err != nil {
+			return nil, err
+		}
+		if len(values) != 2 {
+			return nil, tsl.UnexpectedLiteralError{Literal: values}
+		}
+
+		q := rangeQuery(field, Query{"gte": values[0], "lte": values[1]})
+		if n.Func == tsl.NotBetweenOp {
+			return not(field, q), nil
+		}
+		return q, nil
+	}
+
+	// The rest of the operators match strings.
+	s, ok := r.Left.(string)
+	if !ok {
+		return nil, tsl.UnexpectedLiteralError{Literal: r.Left}
+	}
+
+	switch n.Func {
+	case tsl.RegexOp:
+		return regexpQuery(field, s), nil
+	case tsl.NotRegexOp:
+		return not(field, regexpQuery(field, s)), nil
+	case tsl.LikeOp, tsl.ILikeOp:
+		return wildcard(field, likePattern(s), n.Func == tsl.ILikeOp), nil
+	case tsl.NotLikeOp, tsl.NotILikeOp:
+		return not(field, wildcard(field, likePattern(s), n.Func == tsl.NotILikeOp)), nil
+	case tsl.StartsWithOp:
+		return Query{"prefix": Query{field: Query{"value": s}}}, nil
+	case tsl.NotStartsWithOp:
+		return not(field, Query{"prefix": Query{field: Query{"value": s}}}), nil
+	case tsl.ContainsOp, tsl.NotContainsOp:
+		q := wildcard(field, "*"+escapeWildcard(s)+"*", false)
+		if n.Func == tsl.NotContainsOp {
+			return not(field, q), nil
+		}
+		return q, nil
+	case tsl.EndsWithOp, tsl.NotEndsWithOp:
+		q := wildcard(field, "*"+escapeWildcard(s), false)
+		if n.Func == tsl.NotEndsWithOp {
+			return not(field, q), nil
+		}
+		return q, nil
+	}
+
+	// If here than the operator is not supported.
+	return nil, tsl.UnexpectedLiteralError{Literal: n.Func}
+}
+
+// identString returns the field name of an identifier node, math expressions
+// are not supported.
+func identString(n interface{}) (string, error) {
+	l, ok := n.(tsl.Node)
+	if !ok || l.Func != tsl.IdentOp {
+		return "", tsl.UnexpectedLiteralError{Literal: l.Func}
+	}
+
+	return l.Left.(string), nil
+}
+
+// arrayValues returns the literal values of an array node, supported values
+// are strings, numbers and booleans.
+func arrayValues(n tsl.Node) ([]interface{}, error) {
+	if n.Func != tsl.ArrayOp {
+		return nil, tsl.UnexpectedLiteralError{Literal: n.Func}
+	}
+
+	values := []interface{}{}
+	for _, v := range n.Right.([]tsl.Node) {
+		switch l := literal(v).(type) {
+		case string, float64, bool:
+			values = append(values, l)
+		default:
+			return nil, tsl.UnexpectedLiteralError{Literal: v.Left}
+		}
+	}
+
+	return values, nil
+}
+
+// literal returns the query value of a literal node, now literals are
+// Elasticsearch date math expressions, e.g. `now-604800s`.
+func literal(n tsl.Node) interface{} {
+	if n.Func != tsl.NowOp {
+		return tsl.LiteralValue(n)
+	}
+
+	seconds := int64(n.Right.(time.Duration) / time.Second)
+	switch {
+	case seconds > 0:
+		return fmt.Sprintf("now+%ds", seconds)
+	case seconds < 0:
+		return fmt.Sprintf("now%ds", seconds)
+	}
+	return "now"
+}
+
+// term returns a term query, matching an exact field value.
+func term(field string, v interface{}) Query {
+	return Query{"term": Query{field: v}}
+}
+
+// rangeQuery returns a range query with range parameters.
+func rangeQuery(field string, params Query) Query {
+	return Query{"range": Query{field: params}}
+}
+
+// exists returns an exists query, matching documents with a field value.
+func exists(field string) Query {
+	return Query{"exists": Query{"field": field}}
+}
+
+// mustNot returns a bool query matching the documents not matching a query.
+func mustNot(q Query) Query {
+	return Query{"bool": Query{"must_not": []interface{}{q}}}
+}
+
+// not returns a bool query matching the documents with a field value that do
+// not match a query, like SQL, null values are not compared.
+func not(field string, q Query) Query {
+	return Query{"bool": Query{"filter": []interface{}{exists(field)}, "must_not": []interface{}{q}}}
+}
+
+// regexpQuery returns a regexp query, Elasticsearch regular expressions are
+// anchored to the whole value, unanchored patterns are padded with `.*`.
+func regexpQuery(field string, pattern string) Query {
+	if strings.HasPrefix(pattern, "^") {
+		pattern = pattern[1:]
+	} else {
+		pattern = ".*" + pattern
+	}
+	if strings.HasSuffix(pattern, "$") && !strings.HasSuffix(pattern, `\$`) {
+		pattern = pattern[:len(pattern)-1]
+	} else {
+		pattern = pattern + ".*"
+	}
+
+	return Query{"regexp": Query{field: Query{"value": pattern}}}
+}
+
+// wildcard returns a wildcard query.
+func wildcard(field string, pattern string, fold bool) Query {
+	params := Query{"value": pattern}
+	if fold {
+		params["case_insensitive"] = true
+	}
+
+	return Query{"wildcard": Query{field: params}}
+}
+
+// likePattern converts a LIKE pattern into a wildcard pattern, `%` matches any
+// sequence of characters and `_` matches one character.
+func likePattern(pattern string) string {
+	var b strings.Builder
+
+	for _, c := range pattern {
+		switch c {
+		case '%':
+			b.WriteByte('*')
+		case '_':
+			b.WriteByte('?')
+		default:
+			b.WriteString(escapeWildcard(string(c)))
+		}
+	}
+
+	return b.String()
+}
+
+// escapeWildcard escapes the wildcard pattern special characters of a string.
+func escapeWildcard(s string) string {
+	return wildcardEscaper.Replace(s)
+}
+
+// wildcardEscaper escapes `*`, `?` and `\` in wildcard patterns.
+var wildcardEscaper = strings.NewReplacer(`\`, `\\`, `*`, `\*`, `?`, `\?`)
@@ -0,0 +1,68 @@
+// Copyright 2018 Yaacov Zamir <kobi.zamir@gmail.com>
+// and other contributors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package elasticsearch
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/yaacov/tree-search-language/pkg/tsl"
+)
+
+func TestWalk(t *testing.T) {
+	tests := []struct {
+		phrase string
+		want   string
+	}{
+		{phrase: "name = 'joe'", want: `{"term":{"name":"joe"}}`},
+		{phrase: "pages > 100 and author is not null",
+			want: `{"bool":{"filter":[{"range":{"pages":{"gt":100}}},{"exists":{"field":"author"}}]}}`},
+		{phrase: "city in ('rome', 'paris') or city is null",
+			want: `{"bool":{"minimum_should_match":1,"should":[{"terms":{"city":["rome","paris"]}},{"bool":{"must_not":[{"exists":{"field":"city"}}]}}]}}`},
+		{phrase: "city != 'rome'",
+			want: `{"bool":{"filter":[{"exists":{"field":"city"}}],"must_not":[{"term":{"city":"rome"}}]}}`},
+		{phrase: "pages between 1 and 10", want: `{"range":{"pages":{"gte":1,"lte":10}}}`},
+		{phrase: "created > now() - 7d", want: `{"range":{"created":{"gt":"now-604800s"}}}`},
+		{phrase: "author ~= '^Jo'", want: `{"regexp":{"author":{"value":"Jo.*"}}}`},
+		{phrase: "title ilike 'a%_*'", want: `{"wildcard":{"title":{"case_insensitive":true,"value":"a*?\\*"}}}`},
+		{phrase: "title contains 'rome'", want: `{"wildcard":{"title":{"value":"*rome*"}}}`},
+		{phrase: "title startswith 'Jo'", want: `{"prefix":{"title":{"value":"Jo"}}}`},
+		{phrase: "active is not true", want: `{"bool":{"must_not":[{"term":{"active":true}}]}}`},
+	}
+
+	for _, tt := range tests {
+		tree, err := tsl.ParseTSL(tt.phrase)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		q, err := Walk(tree)
+		if err != nil {
+			t.Errorf("Walk(%s) error = %v", tt.phrase, err)
+			continue
+		}
+		got, _ := json.Marshal(q)
+		if string(got) != tt.want {
+			t.Errorf("Walk(%s) = %s, want %s", tt.phrase, got, tt.want)
+		}
+	}
+
+	// Math expressions are not supported.
+	tree, _ := tsl.ParseTSL("pages * 2 > 10")
+	if _, err := Walk(tree); err == nil {
+		t.Error("expected an unexpected literal error")
+	}
+}
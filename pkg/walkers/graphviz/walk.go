@@ -79,7 +79,7 @@ func Walk(in string, n tsl.Node, nodeID string) (out string, err error) {
 			n.Func,
 			n.Left)
 		out = fmt.Sprintf("%s%s", in, nodeLabel)
-	case tsl.StringOp:
+	case tsl.StringOp, tsl.DateOp:
 		// Add leaf label and value.
 		nodeLabel := fmt.Sprintf("%s [%s label=\"%s | '%s'\" ]",
 			nodeID,
@@ -87,7 +87,7 @@ func Walk(in string, n tsl.Node, nodeID string) (out string, err error) {
 			n.Func,
 			n.Left)
 		out = fmt.Sprintf("%s%s", in, nodeLabel)
-	case tsl.NumberOp:
+	case tsl.NumberOp, tsl.DurationOp:
 		// Add leaf label and value.
 		nodeLabel := fmt.Sprintf("%s [%s label=\"%s | %g\" ]",
 			nodeID,
@@ -95,6 +95,14 @@ func Walk(in string, n tsl.Node, nodeID string) (out string, err error) {
 			n.Func,
 			n.Left)
 		out = fmt.Sprintf("%s%s", in, nodeLabel)
+	case tsl.BooleanOp:
+		// Add leaf label and value.
+		nodeLabel := fmt.Sprintf("%s [%s label=\"%s | %t\" ]",
+			nodeID,
+			numberStyle,
+			n.Func,
+			n.Left)
+		out = fmt.Sprintf("%s%s", in, nodeLabel)
 	default:
 		// Add node label.
 		st := fmt.Sprintf("%s [%s label=\"%s\"]",
@@ -103,12 +111,12 @@ func Walk(in string, n tsl.Node, nodeID string) (out string, err error) {
 			n.Func)
 		childrens := []string{}
 
-		// Add left child.
-		if n.Left != nil {
+		// Add left child, array nodes may hold a literal set on the left.
+		if left, ok := n.Left.(tsl.Node); ok {
 			leftID := randStr(4)
 			childrens = append(childrens, leftID)
 
-			l, err := Walk(in, n.Left.(tsl.Node), leftID)
+			l, err := Walk(in, left, leftID)
 			if err != nil {
 				return "", err
 			}
@@ -65,8 +65,11 @@ func Walk(n tsl.Node, checkColumnName func(string) (string, error)) (tsl.Node, e
 		}
 
 		return n, err
-	case tsl.StringOp, tsl.NumberOp:
+	case tsl.StringOp, tsl.DateOp, tsl.NumberOp, tsl.DurationOp, tsl.NowOp, tsl.BooleanOp, tsl.ArrayOp:
 		// This are our leafs.
+		//
+		// If it's an array of nodes.
+		// We assume that all are leafs, no nead to walk on them.
 		return n, nil
 	default:
 		// Check identifiers on left side.
@@ -79,16 +82,9 @@ func Walk(n tsl.Node, checkColumnName func(string) (string, error)) (tsl.Node, e
 
 		// Check identifiers on right side.
 		if n.Right != nil {
-			// Check if right hand arg is a node, or an array of nodes.
-			//
-			// If it's an array of nodes.
-			// We assume that all are leafs, no nead to walk on them.
-			if n.Func != tsl.ArrayOp {
-				// It's a node.
-				n.Right, err = Walk(n.Right.(tsl.Node), checkColumnName)
-				if err != nil {
-					return n, err
-				}
+			n.Right, err = Walk(n.Right.(tsl.Node), checkColumnName)
+			if err != nil {
+				return n, err
 			}
 		}
 
@@ -0,0 +1,114 @@
+// Copyright 2018 Yaacov Zamir <kobi.zamir@gmail.com>
+// and other contributors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package mermaid helps to create Mermaid flowcharts using the TSL package.
+package mermaid
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/yaacov/tree-search-language/pkg/tsl"
+)
+
+// Walk travel the TSL tree to create a Mermaid `graph TD` flowchart.
+//
+// Users can call the Walk method to get the flowchart string, it can be
+// embedded in Markdown documents using a `mermaid` code block.
+//
+//   s, err = mermaid.Walk(tree)
+//
+// The return string for the phrase "city = 'rome'" will be:
+//
+//   graph TD
+//     n0["$eq"]
+//     n1(["city"])
+//     n0 --> n1
+//     n2("'rome'")
+//     n0 --> n2
+//
+// Operators are boxes, identifiers are stadiums and literals are rounded boxes,
+// node ids are numbered in walk order, so walking a tree always returns the
+// same flowchart.
+func Walk(n tsl.Node) (string, error) {
+	w := walker{}
+	w.b.WriteString("graph TD\n")
+
+	if _, err := w.walk(n); err != nil {
+		return "", err
+	}
+
+	return w.b.String(), nil
+}
+
+// walker holds the flowchart of one tree walk.
+type walker struct {
+	b      strings.Builder
+	nextID int
+}
+
+// walk adds a node and its children to the flowchart, and returns the node id.
+func (w *walker) walk(n tsl.Node) (string, error) {
+	id := fmt.Sprintf("n%d", w.nextID)
+	w.nextID++
+
+	switch n.Func {
+	case tsl.IdentOp:
+		w.node(id, "([", fmt.Sprintf("%v", n.Left), "])")
+		return id, nil
+	case tsl.StringOp, tsl.DateOp:
+		w.node(id, "(", "'"+strings.Replace(n.Left.(string), "'", "''", -1)+"'", ")")
+		return id, nil
+	case tsl.NumberOp, tsl.DurationOp:
+		w.node(id, "(", strconv.FormatFloat(n.Left.(float64), 'g', -1, 64), ")")
+		return id, nil
+	case tsl.BooleanOp:
+		w.node(id, "(", strconv.FormatBool(n.Left.(bool)), ")")
+		return id, nil
+	case tsl.NullOp:
+		w.node(id, "(", "null", ")")
+		return id, nil
+	}
+
+	w.node(id, "[", n.Func, "]")
+
+	// Collect the children, array nodes may hold a literal set on the left.
+	children := []tsl.Node{}
+	if l, ok := n.Left.(tsl.Node); ok {
+		children = append(children, l)
+	}
+	switch r := n.Right.(type) {
+	case tsl.Node:
+		children = append(children, r)
+	case []tsl.Node:
+		children = append(children, r...)
+	}
+
+	for _, child := range children {
+		childID, err := w.walk(child)
+		if err != nil {
+			return "", err
+		}
+		fmt.Fprintf(&w.b, "  %s --> %s\n", id, childID)
+	}
+
+	return id, nil
+}
+
+// node adds a node with a quoted label to the flowchart.
+func (w *walker) node(id string, open string, label string, close string) {
+	fmt.Fprintf(&w.b, "  %s%s\"%s\"%s\n", id, open, strings.Replace(label, `"`, "#quot;", -1), close)
+}
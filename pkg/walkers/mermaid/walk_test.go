@@ -0,0 +1,57 @@
+// Copyright 2018 Yaacov Zamir <kobi.zamir@gmail.com>
+// and other contributors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mermaid
+
+import (
+	"testing"
+
+	"github.com/yaacov/tree-search-language/pkg/tsl"
+)
+
+func TestWalk(t *testing.T) {
+	tree, err := tsl.ParseTSL(`city = 'rome' and "name" in ('joe', 2)`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := Walk(tree)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := `graph TD
+  n0["$and"]
+  n1["$eq"]
+  n2(["city"])
+  n1 --> n2
+  n3("'rome'")
+  n1 --> n3
+  n0 --> n1
+  n4["$in"]
+  n5(["#quot;name#quot;"])
+  n4 --> n5
+  n6["$array"]
+  n7("'joe'")
+  n6 --> n7
+  n8("2")
+  n6 --> n8
+  n4 --> n6
+  n0 --> n4
+`
+	if got != want {
+		t.Errorf("Walk() = %s, want %s", got, want)
+	}
+}
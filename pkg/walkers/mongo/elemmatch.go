@@ -0,0 +1,147 @@
+// Copyright 2018 Yaacov Zamir <kobi.zamir@gmail.com>
+// and other contributors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mongo
+
+import (
+	"strings"
+
+	"github.com/mongodb/mongo-go-driver/bson"
+
+	"github.com/yaacov/tree-search-language/pkg/tsl"
+)
+
+// WalkElemMatch travel the TSL tree like Walk, and groups predicates on fields
+// of array sub-documents into `$elemMatch` operators, so all the grouped
+// predicates must match the same array element.
+//
+// Arrays are the names of the document fields holding arrays of sub-documents,
+// predicates joined by `and` on fields of the same array are grouped.
+//
+//  // The phrase "items.price > 10 and items.qty > 2" is translated into
+//  // {"items": {"$elemMatch": {"$and": [{"price": {"$gt": 10}}, {"qty": {"$gt": 2}}]}}}
+//  filter, _ := mongo.WalkElemMatch(tree, "items")
+//
+func WalkElemMatch(n tsl.Node, arrays ...string) (bson.D, error) {
+	switch n.Func {
+	case tsl.AndOp:
+		return walkAndElemMatch(n, arrays)
+	case tsl.OrOp:
+		l, err := WalkElemMatch(n.Left.(tsl.Node), arrays...)
+		if err != nil {
+			return nil, err
+		}
+		r, err := WalkElemMatch(n.Right.(tsl.Node), arrays...)
+		if err != nil {
+			return nil, err
+		}
+		return bson.D{{n.Func, bson.A{l, r}}}, nil
+	}
+
+	// A single predicate on an array field.
+	if array := arrayField(n, arrays); array != "" {
+		return elemMatch(array, []tsl.Node{n})
+	}
+
+	return Walk(n)
+}
+
+// walkAndElemMatch groups the predicates of a chain of `and` nodes by array.
+func walkAndElemMatch(n tsl.Node, arrays []string) (bson.D, error) {
+	groups := map[string][]tsl.Node{}
+	order := []string{}
+	filters := bson.A{}
+
+	for _, c := range conjuncts(n, nil) {
+		array := arrayField(c, arrays)
+		if array == "" {
+			b, err := WalkElemMatch(c, arrays...)
+			if err != nil {
+				return nil, err
+			}
+			filters = append(filters, b)
+			continue
+		}
+
+		if _, ok := groups[array]; !ok {
+			order = append(order, array)
+		}
+		groups[array] = append(groups[array], c)
+	}
+
+	for _, array := range order {
+		b, err := elemMatch(array, groups[array])
+		if err != nil {
+			return nil, err
+		}
+		filters = append(filters, b)
+	}
+
+	if len(filters) == 1 {
+		return filters[0].(bson.D), nil
+	}
+	return bson.D{{tsl.AndOp, filters}}, nil
+}
+
+// conjuncts appends the operands of a chain of `and` nodes.
+func conjuncts(n tsl.Node, nodes []tsl.Node) []tsl.Node {
+	if n.Func != tsl.AndOp {
+		return append(nodes, n)
+	}
+
+	nodes = conjuncts(n.Left.(tsl.Node), nodes)
+	return conjuncts(n.Right.(tsl.Node), nodes)
+}
+
+// arrayField returns the longest array name prefixing the identifier of a
+// predicate, or an empty string if none.
+func arrayField(n tsl.Node, arrays []string) string {
+	l, ok := n.Left.(tsl.Node)
+	if !ok || l.Func != tsl.IdentOp {
+		return ""
+	}
+	field := l.Left.(string)
+
+	array := ""
+	for _, a := range arrays {
+		if strings.HasPrefix(field, a+".") && len(a) > len(array) {
+			array = a
+		}
+	}
+
+	return array
+}
+
+// elemMatch creates an `$elemMatch` operator of predicates on the fields of
+// an array.
+func elemMatch(array string, nodes []tsl.Node) (bson.D, error) {
+	filters := bson.A{}
+	for _, n := range nodes {
+		// Use the field name inside the array element.
+		l := n.Left.(tsl.Node)
+		n.Left = tsl.Node{Func: tsl.IdentOp, Left: strings.TrimPrefix(l.Left.(string), array+".")}
+
+		b, err := Walk(n)
+		if err != nil {
+			return nil, err
+		}
+		filters = append(filters, b)
+	}
+
+	if len(filters) == 1 {
+		return bson.D{{array, bson.D{{"$elemMatch", filters[0]}}}}, nil
+	}
+	return bson.D{{array, bson.D{{"$elemMatch", bson.D{{tsl.AndOp, filters}}}}}}, nil
+}
@@ -0,0 +1,59 @@
+// Copyright 2018 Yaacov Zamir <kobi.zamir@gmail.com>
+// and other contributors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mongo
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/yaacov/tree-search-language/pkg/tsl"
+)
+
+func TestWalkElemMatch(t *testing.T) {
+	tests := []struct {
+		phrase string
+		want   string
+	}{
+		{
+			phrase: "items.price > 10 and items.qty > 2",
+			want:   "[{items [{$elemMatch [{$and [[{price [{$gt 10}]}] [{qty [{$gt 2}]}]]}]}]}]",
+		},
+		{
+			phrase: "name = 'joe' and items.price > 10",
+			want:   "[{$and [[{name [{$eq joe}]}] [{items [{$elemMatch [{price [{$gt 10}]}]}]}]]}]",
+		},
+		{
+			phrase: "items.qty = 1 or parts.qty = 1",
+			want:   "[{$or [[{items [{$elemMatch [{qty [{$eq 1}]}]}]}] [{parts.qty [{$eq 1}]}]]}]",
+		},
+	}
+
+	for _, tt := range tests {
+		tree, err := tsl.ParseTSL(tt.phrase)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		b, err := WalkElemMatch(tree, "items")
+		if err != nil {
+			t.Errorf("WalkElemMatch(%s) error = %v", tt.phrase, err)
+			continue
+		}
+		if got := fmt.Sprintf("%v", b); got != tt.want {
+			t.Errorf("WalkElemMatch(%s) = %s, want %s", tt.phrase, got, tt.want)
+		}
+	}
+}
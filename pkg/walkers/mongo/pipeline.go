@@ -0,0 +1,131 @@
+// Copyright 2018 Yaacov Zamir <kobi.zamir@gmail.com>
+// and other contributors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mongo
+
+import (
+	"github.com/mongodb/mongo-go-driver/bson"
+
+	"github.com/yaacov/tree-search-language/pkg/tsl"
+)
+
+// SortField is a sort key of an aggregation pipeline.
+type SortField struct {
+	Field string // the field name.
+	Desc  bool   // sort in descending order.
+}
+
+// Pipeline travel the TSL tree to create mongo-go-driver aggregation pipeline
+// stages, a `$match` stage filtering the documents, followed by a `$sort` stage
+// if sort fields are given, and a `$limit` stage if limit is positive.
+//
+// The stages can be embedded into existing pipelines.
+//
+//  // Prepare the stages
+//  stages, _ := mongo.Pipeline(tree, []mongo.SortField{{Field: "pages", Desc: true}}, 10)
+//
+//  // Run the aggregation, grouping the matching documents
+//  pipeline := append(stages, bson.D{{"$group", bson.D{{"_id", "$author"}}}})
+//  cur, _ := collection.Aggregate(ctx, pipeline)
+//
+func Pipeline(n tsl.Node, sort []SortField, limit int64) ([]bson.D, error) {
+	filter, err := Walk(n)
+	if err != nil {
+		return nil, err
+	}
+
+	stages := []bson.D{{{"$match", filter}}}
+
+	if len(sort) > 0 {
+		stages = append(stages, bson.D{{"$sort", sortKeys(sort)}})
+	}
+
+	if limit > 0 {
+		stages = append(stages, bson.D{{"$limit", limit}})
+	}
+
+	return stages, nil
+}
+
+// QueryPipeline travel the TSL query to create mongo-go-driver aggregation
+// pipeline stages, a `$match` stage if the query has a filter, followed by
+// `$sort`, `$skip` and `$limit` stages for the query clauses, and a
+// `$project` stage for the query projection.
+//
+//  q, _ := tsl.ParseQuery("pages > 100 order by pages desc limit 10")
+//  stages, _ := mongo.QueryPipeline(q)
+//  cur, _ := collection.Aggregate(ctx, stages)
+//
+func QueryPipeline(q tsl.Query) ([]bson.D, error) {
+	stages := []bson.D{}
+
+	if q.HasFilter() {
+		filter, err := Walk(q.Filter)
+		if err != nil {
+			return nil, err
+		}
+		stages = append(stages, bson.D{{"$match", filter}})
+	}
+
+	if len(q.OrderBy) > 0 {
+		sort := make([]SortField, len(q.OrderBy))
+		for i, s := range q.OrderBy {
+			sort[i] = SortField{Field: s.Field, Desc: s.Desc}
+		}
+		stages = append(stages, bson.D{{"$sort", sortKeys(sort)}})
+	}
+
+	if q.Offset > 0 {
+		stages = append(stages, bson.D{{"$skip", q.Offset}})
+	}
+	if q.Limit > 0 {
+		stages = append(stages, bson.D{{"$limit", q.Limit}})
+	}
+	if len(q.Fields) > 0 {
+		stages = append(stages, bson.D{{"$project", Projection(q.Fields)}})
+	}
+
+	return stages, nil
+}
+
+// Projection creates a mongo-go-driver projection document including
+// fields, dotted fields are nested document fields.
+//
+//  q, _ := tsl.ParseQuery("fields title, author where pages > 100")
+//  filter, _ := mongo.Walk(q.Filter)
+//  cur, _ := collection.Find(ctx, filter, options.Find().SetProjection(mongo.Projection(q.Fields)))
+//
+func Projection(fields []string) bson.D {
+	projection := bson.D{}
+	for _, f := range fields {
+		projection = append(projection, bson.E{f, 1})
+	}
+
+	return projection
+}
+
+// sortKeys returns the keys of a `$sort` stage.
+func sortKeys(sort []SortField) bson.D {
+	keys := bson.D{}
+	for _, s := range sort {
+		order := 1
+		if s.Desc {
+			order = -1
+		}
+		keys = append(keys, bson.E{s.Field, order})
+	}
+
+	return keys
+}
@@ -17,6 +17,10 @@
 package mongo
 
 import (
+	"context"
+	"regexp"
+	"time"
+
 	"github.com/mongodb/mongo-go-driver/bson"
 	"github.com/mongodb/mongo-go-driver/bson/primitive"
 
@@ -34,8 +38,20 @@ func identString(n interface{}) string {
 	return ""
 }
 
+// literal returns the bson value of a literal node, now literals are the
+// current time, shifted by the offset, that is encoded as an ISODate value.
+func literal(n tsl.Node) interface{} {
+	if n.Func == tsl.NowOp {
+		if t, err := tsl.Date(n); err == nil {
+			return t
+		}
+	}
+
+	return tsl.LiteralValue(n)
+}
+
 // bsonFromArray helper method creates a slice of bson values from an interface,
-// supported values can be strings or floats.
+// supported values can be strings, floats or dates.
 func bsonFromArray(a interface{}) (values []interface{}, err error) {
 	n := a.(tsl.Node)
 
@@ -48,9 +64,9 @@ func bsonFromArray(a interface{}) (values []interface{}, err error) {
 	nodes := n.Right.([]tsl.Node)
 	for _, v := range nodes {
 		// Check node value type.
-		switch l := v.Left.(type) {
-		case string, float64:
-			// Node value is string or float.
+		switch l := literal(v).(type) {
+		case string, float64, int64, uint64, time.Time:
+			// Node value is string, float or date.
 			values = append(values, l)
 		default:
 			// Not a string or a float,
@@ -64,6 +80,53 @@ func bsonFromArray(a interface{}) (values []interface{}, err error) {
 	return
 }
 
+// earthRadius is the equatorial radius of the earth in meters, mongo converts
+// $centerSphere radians into distances using it.
+const earthRadius = 6378100.0
+
+// geoWithin returns the $geoWithin filter of a geo operator, the values are
+// a distance in meters and a (lat, lon) point, or two (lat, lon) box corners,
+// mongo orders the point coordinates [lon, lat].
+func geoWithin(op string, values []interface{}) bson.D {
+	var shape bson.D
+	switch op {
+	case tsl.WithinOp, tsl.NotWithinOp:
+		shape = bson.D{{"$centerSphere", bson.A{bson.A{values[2], values[1]}, values[0].(float64) / earthRadius}}}
+	default:
+		shape = bson.D{{"$box", bson.A{bson.A{values[1], values[0]}, bson.A{values[3], values[2]}}}}
+	}
+
+	q := bson.D{{"$geoWithin", shape}}
+	if op == tsl.NotWithinOp || op == tsl.NotWithinBoxOp {
+		q = bson.D{{"$not", q}}
+	}
+
+	return q
+}
+
+// likeOptions returns the regex options of a like operator.
+func likeOptions(op string) string {
+	if op == tsl.ILikeOp || op == tsl.NotILikeOp {
+		return "i"
+	}
+
+	return ""
+}
+
+// substringPattern returns the regex pattern of a substring operator.
+func substringPattern(op string, s string) string {
+	pattern := regexp.QuoteMeta(s)
+
+	switch op {
+	case tsl.StartsWithOp, tsl.NotStartsWithOp:
+		return "^" + pattern
+	case tsl.EndsWithOp, tsl.NotEndsWithOp:
+		return pattern + "$"
+	}
+
+	return pattern
+}
+
 // Walk travel the TSL tree to create mongo-go-driver bson select operators.
 //
 // Users can call the Walk method to filter a mongo Find.
@@ -77,26 +140,43 @@ func bsonFromArray(a interface{}) (values []interface{}, err error) {
 // mongo-go-driver: https://github.com/mongodb/mongo-go-driver
 //
 func Walk(n tsl.Node) (b bson.D, err error) {
+	return walk(context.Background(), n)
+}
+
+// WalkContext travel the TSL tree like Walk, and stops with the context
+// error if the context is done, so walks of very deep trees can be
+// cancelled or bounded by a deadline.
+func WalkContext(ctx context.Context, n tsl.Node) (bson.D, error) {
+	return walk(ctx, n)
+}
+
+// walk implements Walk.
+func walk(ctx context.Context, n tsl.Node) (b bson.D, err error) {
 	var values []interface{}
 	var l, r bson.D
 
+	// Check for a cancelled walk.
+	if err = ctx.Err(); err != nil {
+		return
+	}
+
 	// Note: tsl function constants should be the same as mongo bson
 	// functions (e.g. tsl.AndOp is "$and" in tsl and in mongo bson),
 	// this is the reason we can use n.Func as a function name in the mongo
 	// bson Element construction.
 	switch n.Func {
 	case tsl.OrOp, tsl.AndOp:
-		l, err = Walk(n.Left.(tsl.Node))
+		l, err = walk(ctx, n.Left.(tsl.Node))
 		if err != nil {
 			return
 		}
-		r, err = Walk(n.Right.(tsl.Node))
+		r, err = walk(ctx, n.Right.(tsl.Node))
 		if err != nil {
 			return
 		}
 		b = bson.D{{n.Func, bson.A{l, r}}}
 	case tsl.EqOp, tsl.NotEqOp, tsl.LtOp, tsl.LteOp, tsl.GtOp, tsl.GteOp:
-		b = bson.D{{identString(n.Left), bson.D{{n.Func, n.Right.(tsl.Node).Left}}}}
+		b = bson.D{{identString(n.Left), bson.D{{n.Func, literal(n.Right.(tsl.Node))}}}}
 	case tsl.InOp, tsl.NotInOp:
 		values, err = bsonFromArray(n.Right)
 		if err != nil {
@@ -107,11 +187,31 @@ func Walk(n tsl.Node) (b bson.D, err error) {
 		b = bson.D{{identString(n.Left), bson.D{{"$exists", false}}}}
 	case tsl.IsNotNilOp:
 		b = bson.D{{identString(n.Left), bson.D{{"$exists", true}}}}
+	case tsl.IsTrueOp, tsl.IsFalseOp:
+		b = bson.D{{identString(n.Left), bson.D{{"$eq", n.Func == tsl.IsTrueOp}}}}
+	case tsl.IsNotTrueOp, tsl.IsNotFalseOp:
+		// Not equal also matches null and missing values.
+		b = bson.D{{identString(n.Left), bson.D{{"$ne", n.Func == tsl.IsNotTrueOp}}}}
 	case tsl.RegexOp:
 		b = bson.D{{identString(n.Left), primitive.Regex{n.Right.(tsl.Node).Left.(string), ""}}}
 	case tsl.NotRegexOp:
 		b = bson.D{{identString(n.Left),
 			bson.D{{"$not", primitive.Regex{n.Right.(tsl.Node).Left.(string), ""}}}}}
+	case tsl.LikeOp, tsl.ILikeOp:
+		// Mongo does not have a like function, translating sql's like into a
+		// regex, and ilike into a case insensitive regex.
+		pattern := tsl.LikePattern(n.Right.(tsl.Node).Left.(string), false)
+		b = bson.D{{identString(n.Left), primitive.Regex{pattern, likeOptions(n.Func)}}}
+	case tsl.NotLikeOp, tsl.NotILikeOp:
+		pattern := tsl.LikePattern(n.Right.(tsl.Node).Left.(string), false)
+		b = bson.D{{identString(n.Left), bson.D{{"$not", primitive.Regex{pattern, likeOptions(n.Func)}}}}}
+	case tsl.ContainsOp, tsl.StartsWithOp, tsl.EndsWithOp:
+		// Substring checks are translated into a regex of the quoted substring.
+		pattern := substringPattern(n.Func, n.Right.(tsl.Node).Left.(string))
+		b = bson.D{{identString(n.Left), primitive.Regex{pattern, ""}}}
+	case tsl.NotContainsOp, tsl.NotStartsWithOp, tsl.NotEndsWithOp:
+		pattern := substringPattern(n.Func, n.Right.(tsl.Node).Left.(string))
+		b = bson.D{{identString(n.Left), bson.D{{"$not", primitive.Regex{pattern, ""}}}}}
 	case tsl.BetweenOp:
 		// Mongo does not have a between function, translating sql's between into
 		// two none eq operators.
@@ -137,6 +237,12 @@ func Walk(n tsl.Node) (b bson.D, err error) {
 				bson.D{{identString(n.Left), bson.D{{"$lt", values[0]}}}},
 				bson.D{{identString(n.Left), bson.D{{"$gt", values[1]}}}},
 			}}}
+	case tsl.WithinOp, tsl.NotWithinOp, tsl.WithinBoxOp, tsl.NotWithinBoxOp:
+		values, err = bsonFromArray(n.Right)
+		if err != nil {
+			return
+		}
+		b = bson.D{{identString(n.Left), geoWithin(n.Func, values)}}
 	default:
 		// If here than the operator is not supported.
 		err = tsl.UnexpectedLiteralError{Literal: n.Func}
@@ -0,0 +1,77 @@
+// Copyright 2018 Yaacov Zamir <kobi.zamir@gmail.com>
+// and other contributors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mongo
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/mongodb/mongo-go-driver/bson"
+
+	"github.com/yaacov/tree-search-language/pkg/tsl"
+)
+
+func TestWalkNow(t *testing.T) {
+	tree, err := tsl.ParseTSL("created >= now() - 7d")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	b, err := Walk(tree)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The current time is a date value, that is encoded as an ISODate.
+	want := time.Now().Add(-7 * 24 * time.Hour)
+	v, ok := b[0].Value.(bson.D)[0].Value.(time.Time)
+	if !ok || b[0].Key != "created" || v.Sub(want) > time.Minute || want.Sub(v) > time.Minute {
+		t.Errorf("expected a date near %v instead it was %v", want, b)
+	}
+}
+
+func TestWalkGeo(t *testing.T) {
+	tests := []struct {
+		phrase string
+		want   string
+	}{
+		{
+			phrase: "location within 6378.1km of (32.1, 34.8)",
+			want:   "[{location [{$geoWithin [{$centerSphere [[34.8 32.1] 1]}]}]}]",
+		},
+		{
+			phrase: "location not within (32, 34.7, 32.2, 34.9)",
+			want:   "[{location [{$not [{$geoWithin [{$box [[34.7 32] [34.9 32.2]]}]}]}]}]",
+		},
+	}
+
+	for _, tt := range tests {
+		tree, err := tsl.ParseTSL(tt.phrase)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		b, err := Walk(tree)
+		if err != nil {
+			t.Errorf("Walk(%s) error = %v", tt.phrase, err)
+			continue
+		}
+		if got := fmt.Sprintf("%v", b); got != tt.want {
+			t.Errorf("Walk(%s) = %s, want %s", tt.phrase, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,226 @@
+// Copyright 2018 Yaacov Zamir <kobi.zamir@gmail.com>
+// and other contributors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package phrase prints TSL trees as TSL phrases.
+//
+// Trees are printed in a canonical form, keywords are lower case, strings use
+// single quotes and parentheses are added only where the operator precedence
+// of the TSL grammar requires them, so parsing the printed phrase returns the
+// same tree.
+//
+// Usage:
+//   tree, err := tsl.ParseTSL("(name = 'joe') AND ((pages > 10) OR (pages < 2))")
+//
+//   // s is "name = 'joe' and (pages > 10 or pages < 2)"
+//   s, err := phrase.Walk(tree)
+//
+package phrase
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/yaacov/tree-search-language/pkg/tsl"
+)
+
+// Ops maps TSL operators to phrase operators.
+var Ops = map[string]string{
+	tsl.EqOp:            "=",
+	tsl.NotEqOp:         "!=",
+	tsl.LtOp:            "<",
+	tsl.LteOp:           "<=",
+	tsl.GtOp:            ">",
+	tsl.GteOp:           ">=",
+	tsl.RegexOp:         "~=",
+	tsl.NotRegexOp:      "~!",
+	tsl.LikeOp:          "like",
+	tsl.NotLikeOp:       "not like",
+	tsl.ILikeOp:         "ilike",
+	tsl.NotILikeOp:      "not ilike",
+	tsl.ContainsOp:      "contains",
+	tsl.NotContainsOp:   "not contains",
+	tsl.StartsWithOp:    "startswith",
+	tsl.NotStartsWithOp: "not startswith",
+	tsl.EndsWithOp:      "endswith",
+	tsl.NotEndsWithOp:   "not endswith",
+	tsl.InOp:            "in",
+	tsl.NotInOp:         "not in",
+	tsl.BetweenOp:       "between",
+	tsl.NotBetweenOp:    "not between",
+	tsl.WithinOp:        "within",
+	tsl.NotWithinOp:     "not within",
+	tsl.WithinBoxOp:     "within",
+	tsl.NotWithinBoxOp:  "not within",
+	tsl.AndOp:           "and",
+	tsl.OrOp:            "or",
+	tsl.NotOp:           "not",
+	tsl.AddOp:           "+",
+	tsl.SubtractOp:      "-",
+	tsl.MultiplyOp:      "*",
+	tsl.DivideOp:        "/",
+	tsl.ModuloOp:        "%",
+	tsl.IsNilOp:         "is null",
+	tsl.IsNotNilOp:      "is not null",
+	tsl.IsTrueOp:        "is true",
+	tsl.IsNotTrueOp:     "is not true",
+	tsl.IsFalseOp:       "is false",
+	tsl.IsNotFalseOp:    "is not false",
+	tsl.LenOp:           "len",
+	tsl.LowerOp:         "lower",
+	tsl.UpperOp:         "upper",
+	tsl.AbsOp:           "abs",
+	tsl.RoundOp:         "round",
+	tsl.SemverOp:        "semver",
+}
+
+// precedence is the binding strength of logical operators, comparisons bind
+// stronger than the logical operators.
+var precedence = map[string]int{
+	tsl.OrOp:  1,
+	tsl.AndOp: 2,
+	tsl.NotOp: 3,
+}
+
+// isMath checks if a node is a math expression.
+func isMath(n tsl.Node) bool {
+	switch n.Func {
+	case tsl.AddOp, tsl.SubtractOp, tsl.MultiplyOp, tsl.DivideOp, tsl.ModuloOp:
+		return true
+	}
+
+	return false
+}
+
+// Walk travel the TSL tree and prints it as a TSL phrase.
+func Walk(n tsl.Node) (string, error) {
+	switch n.Func {
+	case tsl.IdentOp:
+		return n.Left.(string), nil
+	case tsl.StringOp:
+		return "'" + strings.Replace(n.Left.(string), "'", "''", -1) + "'", nil
+	case tsl.DateOp:
+		// Date literals are RFC 3339 or `YYYY-MM-DD` dates.
+		return n.Left.(string), nil
+	case tsl.NumberOp:
+		switch i := n.Right.(type) {
+		case int64, uint64:
+			// Large integers keep their exact value.
+			return fmt.Sprintf("%d", i), nil
+		}
+		return strconv.FormatFloat(n.Left.(float64), 'g', -1, 64), nil
+	case tsl.DurationOp:
+		return tsl.FormatDuration(n.Right.(time.Duration)), nil
+	case tsl.NowOp:
+		// Now literals are printed as a now call, shifted by the offset.
+		d := n.Right.(time.Duration)
+		switch {
+		case d > 0:
+			return "now() + " + tsl.FormatDuration(d), nil
+		case d < 0:
+			return "now() - " + tsl.FormatDuration(-d), nil
+		}
+		return "now()", nil
+	case tsl.BooleanOp:
+		return strconv.FormatBool(n.Left.(bool)), nil
+	case tsl.NullOp:
+		return "null", nil
+	case tsl.IsNilOp, tsl.IsNotNilOp, tsl.IsTrueOp, tsl.IsFalseOp, tsl.IsNotTrueOp, tsl.IsNotFalseOp:
+		l, err := Walk(n.Left.(tsl.Node))
+		return l + " " + Ops[n.Func], err
+	case tsl.NotOp:
+		l, err := side(n, n.Left.(tsl.Node), false)
+		return "not " + l, err
+	case tsl.LenOp, tsl.LowerOp, tsl.UpperOp, tsl.AbsOp, tsl.RoundOp, tsl.SemverOp:
+		l, err := Walk(n.Left.(tsl.Node))
+		return Ops[n.Func] + "(" + l + ")", err
+	}
+
+	op, ok := Ops[n.Func]
+	if !ok {
+		return "", tsl.UnexpectedLiteralError{Literal: n.Func}
+	}
+
+	l, err := side(n, n.Left.(tsl.Node), false)
+	if err != nil {
+		return "", err
+	}
+	r := n.Right.(tsl.Node)
+
+	switch n.Func {
+	case tsl.InOp, tsl.NotInOp, tsl.BetweenOp, tsl.NotBetweenOp, tsl.WithinOp, tsl.NotWithinOp, tsl.WithinBoxOp, tsl.NotWithinBoxOp:
+		values := []string{}
+		for _, v := range r.Right.([]tsl.Node) {
+			s, err := Walk(v)
+			if err != nil {
+				return "", err
+			}
+			values = append(values, s)
+		}
+		if n.Func == tsl.BetweenOp || n.Func == tsl.NotBetweenOp {
+			if len(values) != 2 {
+				return "", tsl.UnexpectedLiteralError{Literal: values}
+			}
+			return fmt.Sprintf("%s %s %s and %s", l, op, values[0], values[1]), nil
+		}
+		if n.Func == tsl.WithinOp || n.Func == tsl.NotWithinOp {
+			// Distances are printed in meters.
+			if len(values) != 3 {
+				return "", tsl.UnexpectedLiteralError{Literal: values}
+			}
+			return fmt.Sprintf("%s %s %s of (%s, %s)", l, op, values[0], values[1], values[2]), nil
+		}
+		return fmt.Sprintf("%s %s (%s)", l, op, strings.Join(values, ", ")), nil
+	}
+
+	rs, err := side(n, r, true)
+	if err != nil {
+		return "", err
+	}
+
+	return l + " " + op + " " + rs, nil
+}
+
+// side prints an operand of a node, adding parentheses if the operand binds
+// weaker than the node, logical operators are left associative, so right
+// operands of the same precedence are grouped too.
+//
+// The right operand of a math operator in the TSL grammar is a whole math
+// expression, so `a - b + c` is parsed as `a - (b + c)`, right math operands
+// are never grouped, and left math operands of math operators are always
+// grouped.
+func side(n tsl.Node, operand tsl.Node, right bool) (string, error) {
+	s, err := Walk(operand)
+	if err != nil {
+		return "", err
+	}
+
+	if isMath(operand) {
+		if isMath(n) && !right {
+			return "(" + s + ")", nil
+		}
+		return s, nil
+	}
+
+	p, ok := precedence[operand.Func]
+	if !ok {
+		return s, nil
+	}
+	if parent := precedence[n.Func]; p < parent || (right && p == parent) {
+		return "(" + s + ")", nil
+	}
+	return s, nil
+}
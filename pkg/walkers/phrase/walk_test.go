@@ -0,0 +1,77 @@
+// Copyright 2018 Yaacov Zamir <kobi.zamir@gmail.com>
+// and other contributors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package phrase
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/yaacov/tree-search-language/pkg/tsl"
+)
+
+func TestWalk(t *testing.T) {
+	tests := []struct {
+		phrase string
+		want   string
+	}{
+		{phrase: "(name = 'joe') AND ((pages > 10) OR (pages < 2))", want: "name = 'joe' and (pages > 10 or pages < 2)"},
+		{phrase: "a = 1 or b = 2 and c = 3", want: "a = 1 or b = 2 and c = 3"},
+		{phrase: "(a = 1 or b = 2) and c = 3", want: "(a = 1 or b = 2) and c = 3"},
+		{phrase: "a = 1 and (b = 2 and c = 3)", want: "a = 1 and (b = 2 and c = 3)"},
+		{phrase: "not (a = 1 or b = 2) and not c = 3", want: "not (a = 1 or b = 2) and not c = 3"},
+		{phrase: "(a + b) * 2 > 10", want: "(a + b) * 2 > 10"},
+		{phrase: "a - (b + c) < 1", want: "a - b + c < 1"},
+		{phrase: "(a - b) - c < 1", want: "(a - b) - c < 1"},
+		{phrase: "a - (b - c) < 1", want: "a - b - c < 1"},
+		{phrase: "a * 2 * c < 1", want: "(a * 2) * c < 1"},
+		{phrase: "name NOT ILIKE 'jo%' and city in ('rome','paris')", want: "name not ilike 'jo%' and city in ('rome', 'paris')"},
+		{phrase: "title = 'it''s' and age between 1 and 2h", want: "title = 'it''s' and age between 1 and 2h0m0s"},
+		{phrase: "active IS NOT TRUE or deleted is null", want: "active is not true or deleted is null"},
+		{phrase: "len(title) > 10 and LOWER ( author ) = 'joe'", want: "len(title) > 10 and lower(author) = 'joe'"},
+		{phrase: "created > NOW()-7d and updated < now()", want: "created > now() - 7d and updated < now()"},
+		{phrase: "SEMVER(version) >= '1.10.2'", want: "semver(version) >= '1.10.2'"},
+		{phrase: "location within 5km of (32.1, 34.8) or home not within (32, 34.7, 32.2, 34.9)", want: "location within 5000 of (32.1, 34.8) or home not within (32, 34.7, 32.2, 34.9)"},
+		{phrase: "round(price * 1.1) + abs(a - b) = 3", want: "round(price * 1.1) + abs(a - b) = 3"},
+		{phrase: "id = 9007199254740993 or id in (18446744073709551615, 1e3)", want: "id = 9007199254740993 or id in (18446744073709551615, 1000)"},
+	}
+
+	for _, tt := range tests {
+		tree, err := tsl.ParseTSL(tt.phrase)
+		if err != nil {
+			t.Fatalf("failed to parse %s: %v", tt.phrase, err)
+		}
+
+		got, err := Walk(tree)
+		if err != nil {
+			t.Errorf("Walk(%s) error = %v", tt.phrase, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("Walk(%s) = %s, want %s", tt.phrase, got, tt.want)
+		}
+
+		// Check the printed phrase parses into the same tree.
+		reparsed, err := tsl.ParseTSL(got)
+		if err != nil {
+			t.Fatalf("failed to parse %s: %v", got, err)
+		}
+		a, _ := json.Marshal(tree)
+		b, _ := json.Marshal(reparsed)
+		if string(a) != string(b) {
+			t.Errorf("%s: expected tree %s instead it was %s", got, a, b)
+		}
+	}
+}
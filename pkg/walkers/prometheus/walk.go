@@ -0,0 +1,150 @@
+// Copyright 2018 Yaacov Zamir <kobi.zamir@gmail.com>
+// and other contributors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package prometheus converts TSL trees into Prometheus label matchers.
+//
+// Matchers support a restricted subset of TSL, comparisons of label names joined
+// by `and`, using the `=`, `!=`, `~=`, `~!`, `in` and `not in` operators.
+//
+// Usage:
+//   // The phrase "job = 'api' and status ~= '^5..$'" is converted into
+//   // the matchers `{job="api",status=~"5.."}`.
+//   matchers, err := prometheus.Walk(tree)
+//
+//   query := "rate(http_requests_total" + matchers + "[5m])"
+//
+package prometheus
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/yaacov/tree-search-language/pkg/tsl"
+)
+
+// labelName matches valid Prometheus label names.
+var labelName = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]*$`)
+
+// matchOps maps TSL operators to Prometheus matcher operators.
+var matchOps = map[string]string{
+	tsl.EqOp:       "=",
+	tsl.NotEqOp:    "!=",
+	tsl.RegexOp:    "=~",
+	tsl.NotRegexOp: "!~",
+	tsl.InOp:       "=~",
+	tsl.NotInOp:    "!~",
+}
+
+// Walk travel the TSL tree to create Prometheus label matchers, for example
+// `{job="api",status=~"5.."}`.
+//
+// Prometheus regular expressions are anchored to the whole label value, not
+// anchored TSL patterns are padded with `.*`, and `in` lists are converted
+// into alternations of the quoted values.
+func Walk(n tsl.Node) (string, error) {
+	matchers, err := walk(n, nil)
+	if err != nil {
+		return "", err
+	}
+
+	return "{" + strings.Join(matchers, ",") + "}", nil
+}
+
+// walk appends the matchers of a node.
+func walk(n tsl.Node, matchers []string) ([]string, error) {
+	if n.Func == tsl.AndOp {
+		matchers, err := walk(n.Left.(tsl.Node), matchers)
+		if err != nil {
+			return nil, err
+		}
+		return walk(n.Right.(tsl.Node), matchers)
+	}
+
+	op, ok := matchOps[n.Func]
+	if !ok {
+		return nil, tsl.UnexpectedLiteralError{Literal: n.Func}
+	}
+
+	// Check the label name.
+	l, ok := n.Left.(tsl.Node)
+	if !ok || l.Func != tsl.IdentOp || !labelName.MatchString(l.Left.(string)) {
+		return nil, tsl.UnexpectedLiteralError{Literal: l.Left}
+	}
+
+	r, ok := n.Right.(tsl.Node)
+	if !ok {
+		return nil, tsl.UnexpectedLiteralError{Literal: n.Func}
+	}
+
+	var value string
+	switch n.Func {
+	case tsl.InOp, tsl.NotInOp:
+		values := []string{}
+		for _, v := range r.Right.([]tsl.Node) {
+			s, err := literal(v)
+			if err != nil {
+				return nil, err
+			}
+			values = append(values, regexp.QuoteMeta(s))
+		}
+		value = strings.Join(values, "|")
+	case tsl.RegexOp, tsl.NotRegexOp:
+		s, err := literal(r)
+		if err != nil {
+			return nil, err
+		}
+		value = anchored(s)
+	default:
+		s, err := literal(r)
+		if err != nil {
+			return nil, err
+		}
+		value = s
+	}
+
+	return append(matchers, l.Left.(string)+op+strconv.Quote(value)), nil
+}
+
+// literal returns the label value of a literal node.
+func literal(n tsl.Node) (string, error) {
+	switch v := n.Left.(type) {
+	case string:
+		return v, nil
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64), nil
+	case bool:
+		return strconv.FormatBool(v), nil
+	}
+
+	return "", tsl.UnexpectedLiteralError{Literal: n.Left}
+}
+
+// anchored converts a TSL regular expression into an anchored Prometheus
+// regular expression.
+func anchored(pattern string) string {
+	if strings.HasPrefix(pattern, "^") {
+		pattern = pattern[1:]
+	} else {
+		pattern = ".*" + pattern
+	}
+	if strings.HasSuffix(pattern, "$") && !strings.HasSuffix(pattern, `\$`) {
+		pattern = pattern[:len(pattern)-1]
+	} else {
+		pattern = pattern + ".*"
+	}
+
+	return pattern
+}
@@ -0,0 +1,54 @@
+// Copyright 2018 Yaacov Zamir <kobi.zamir@gmail.com>
+// and other contributors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package prometheus
+
+import (
+	"testing"
+
+	"github.com/yaacov/tree-search-language/pkg/tsl"
+)
+
+func TestWalk(t *testing.T) {
+	tests := []struct {
+		phrase  string
+		want    string
+		wantErr bool
+	}{
+		{phrase: "job = 'api' and status ~= '^5..$'", want: `{job="api",status=~"5.."}`},
+		{phrase: "env != 'dev' and path ~! 'health'", want: `{env!="dev",path!~".*health.*"}`},
+		{phrase: "method in ('GET', 'HEAD') and code not in (404)", want: `{method=~"GET|HEAD",code!~"404"}`},
+		{phrase: `msg = 'say "hi"'`, want: `{msg="say \"hi\""}`},
+		{phrase: "job = 'api' or job = 'web'", wantErr: true},
+		{phrase: "code > 499", wantErr: true},
+		{phrase: "spec.job = 'api'", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		tree, err := tsl.ParseTSL(tt.phrase)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		got, err := Walk(tree)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("Walk(%s) error = %v, wantErr %v", tt.phrase, err, tt.wantErr)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("Walk(%s) = %s, want %s", tt.phrase, got, tt.want)
+		}
+	}
+}
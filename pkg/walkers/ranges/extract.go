@@ -0,0 +1,140 @@
+// Copyright 2018 Yaacov Zamir <kobi.zamir@gmail.com>
+// and other contributors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package ranges extracts the value ranges of fields from TSL trees.
+//
+// A field range holds all the field values that can match a tree, for
+// example the range of `pages` in `pages > 10 and pages <= 100` is (10, 100].
+// Ranges are conservative, values outside the range of a field can not match
+// the tree, values inside the range may or may not match. Ranges are used to
+// skip data partitions, row groups and index ranges that can not match.
+//
+// Usage:
+//   r := ranges.Extract(tree)
+//
+//   if pages, ok := r["pages"]; ok && !pages.Overlaps(minPages, maxPages) {
+//     // Skip this partition.
+//   }
+//
+package ranges
+
+import (
+	"github.com/yaacov/tree-search-language/pkg/tsl"
+)
+
+// Extract returns the ranges of the fields constrained by a tree, fields
+// without a range may have any value.
+//
+// Comparisons of identifiers to literals constrain their field, AND nodes
+// intersect the ranges of their sides, and OR nodes join the ranges of
+// fields constrained by both sides.
+func Extract(n tsl.Node) map[string]Range {
+	switch n.Func {
+	case tsl.AndOp:
+		l := Extract(n.Left.(tsl.Node))
+		r := Extract(n.Right.(tsl.Node))
+
+		for field, rr := range r {
+			lr, ok := l[field]
+			if !ok {
+				l[field] = rr
+				continue
+			}
+
+			if i, ok := lr.Intersect(rr); ok {
+				l[field] = i
+			} else {
+				delete(l, field)
+			}
+		}
+		return l
+	case tsl.OrOp:
+		l := Extract(n.Left.(tsl.Node))
+		r := Extract(n.Right.(tsl.Node))
+
+		ranges := map[string]Range{}
+		for field, lr := range l {
+			if rr, ok := r[field]; ok {
+				if u, ok := lr.Union(rr); ok {
+					ranges[field] = u
+				}
+			}
+		}
+		return ranges
+	}
+
+	// Check for an identifier compared to literals.
+	l, ok := n.Left.(tsl.Node)
+	if !ok || l.Func != tsl.IdentOp {
+		return map[string]Range{}
+	}
+
+	if r, ok := predicateRange(n); ok {
+		return map[string]Range{l.Left.(string): r}
+	}
+
+	return map[string]Range{}
+}
+
+// predicateRange returns the range of a comparison.
+func predicateRange(n tsl.Node) (Range, bool) {
+	r, ok := n.Right.(tsl.Node)
+	if !ok {
+		return Range{}, false
+	}
+
+	switch r.Func {
+	case tsl.StringOp, tsl.DateOp, tsl.NumberOp, tsl.DurationOp:
+		v := r.Left
+
+		switch n.Func {
+		case tsl.EqOp:
+			return Range{Min: v, Max: v, MinInclusive: true, MaxInclusive: true}, true
+		case tsl.LtOp:
+			return Range{Max: v}, true
+		case tsl.LteOp:
+			return Range{Max: v, MaxInclusive: true}, true
+		case tsl.GtOp:
+			return Range{Min: v}, true
+		case tsl.GteOp:
+			return Range{Min: v, MinInclusive: true}, true
+		}
+	case tsl.ArrayOp:
+		values := r.Right.([]tsl.Node)
+
+		switch n.Func {
+		case tsl.BetweenOp:
+			if len(values) == 2 {
+				return Range{Min: values[0].Left, Max: values[1].Left, MinInclusive: true}.check()
+			}
+		case tsl.InOp:
+			// The range of a list is the range from its smallest to its largest value.
+			if len(values) == 0 {
+				return Range{}, false
+			}
+			hull := Range{Min: values[0].Left, Max: values[0].Left, MinInclusive: true, MaxInclusive: true}
+			for _, v := range values[1:] {
+				var ok bool
+				hull, ok = hull.Union(Range{Min: v.Left, Max: v.Left, MinInclusive: true, MaxInclusive: true})
+				if !ok {
+					return Range{}, false
+				}
+			}
+			return hull, true
+		}
+	}
+
+	return Range{}, false
+}
@@ -0,0 +1,93 @@
+// Copyright 2018 Yaacov Zamir <kobi.zamir@gmail.com>
+// and other contributors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ranges
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/yaacov/tree-search-language/pkg/tsl"
+)
+
+func TestExtract(t *testing.T) {
+	tests := []struct {
+		phrase string
+		want   map[string]Range
+	}{
+		{
+			"pages > 10 and pages <= 100 and author = 'Joe'",
+			map[string]Range{
+				"pages":  {Min: 10.0, Max: 100.0, MaxInclusive: true},
+				"author": {Min: "Joe", Max: "Joe", MinInclusive: true, MaxInclusive: true},
+			},
+		},
+		{
+			"pages between 10 and 20 or (pages in (30, 5, 40) and rating > 3)",
+			map[string]Range{
+				"pages": {Min: 5.0, Max: 40.0, MinInclusive: true, MaxInclusive: true},
+			},
+		},
+		{
+			"pages < 10 or author = 'Joe'",
+			map[string]Range{},
+		},
+		{
+			"pages != 10 and title ~= 'Book'",
+			map[string]Range{},
+		},
+		{
+			"pages > 10 and pages = 'ten'",
+			map[string]Range{},
+		},
+	}
+
+	for _, tt := range tests {
+		tree, err := tsl.ParseTSL(tt.phrase)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if got := Extract(tree); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("Extract(%q) = %+v, want %+v", tt.phrase, got, tt.want)
+		}
+	}
+}
+
+func TestRange(t *testing.T) {
+	tree, _ := tsl.ParseTSL("pages > 10 and pages < 5")
+	if r := Extract(tree)["pages"]; !r.IsEmpty() {
+		t.Errorf("%+v IsEmpty() = false, want true", r)
+	}
+
+	r := Range{Min: 10.0, Max: 20.0, MinInclusive: true}
+	tests := []struct {
+		min, max interface{}
+		want     bool
+	}{
+		{0.0, 9.0, false},
+		{0.0, 10.0, true},
+		{20.0, 30.0, false},
+		{nil, 15.0, true},
+		{15.0, nil, true},
+		{"a", "b", true},
+	}
+
+	for _, tt := range tests {
+		if got, _ := r.Overlaps(tt.min, tt.max); got != tt.want {
+			t.Errorf("Overlaps(%v, %v) = %v, want %v", tt.min, tt.max, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,177 @@
+// Copyright 2018 Yaacov Zamir <kobi.zamir@gmail.com>
+// and other contributors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ranges
+
+// Range is a range of string or number (float64) values.
+type Range struct {
+	Min          interface{} // the lower bound, nil if unbounded.
+	Max          interface{} // the upper bound, nil if unbounded.
+	MinInclusive bool        // the lower bound is in the range.
+	MaxInclusive bool        // the upper bound is in the range.
+}
+
+// IsEmpty checks if no value is in the range.
+func (r Range) IsEmpty() bool {
+	if r.Min == nil || r.Max == nil {
+		return false
+	}
+
+	c, ok := compare(r.Min, r.Max)
+	return ok && (c > 0 || (c == 0 && !(r.MinInclusive && r.MaxInclusive)))
+}
+
+// Contains checks if a value is in the range, ok is false if the value type
+// does not match the range type.
+func (r Range) Contains(v interface{}) (in bool, ok bool) {
+	return r.Overlaps(v, v)
+}
+
+// Overlaps checks if some value between min and max (inclusive) is in the
+// range, nil min or max are unbounded. Overlaps returns true if the bounds
+// types do not match the range type.
+func (r Range) Overlaps(min, max interface{}) (overlaps bool, ok bool) {
+	o, ok := r.Intersect(Range{Min: min, Max: max, MinInclusive: true, MaxInclusive: true})
+	if !ok {
+		return true, false
+	}
+
+	return !o.IsEmpty(), true
+}
+
+// Intersect returns the values in both ranges, ok is false if the ranges
+// types do not match.
+func (r Range) Intersect(o Range) (i Range, ok bool) {
+	i = r
+
+	// Take the larger lower bound.
+	c, ok := compareBounds(r.Min, o.Min)
+	switch {
+	case !ok:
+		return Range{}, false
+	case o.Min == nil:
+	case r.Min == nil || c < 0:
+		i.Min, i.MinInclusive = o.Min, o.MinInclusive
+	case c == 0:
+		i.MinInclusive = r.MinInclusive && o.MinInclusive
+	}
+
+	// Take the smaller upper bound.
+	c, ok = compareBounds(r.Max, o.Max)
+	switch {
+	case !ok:
+		return Range{}, false
+	case o.Max == nil:
+	case r.Max == nil || c > 0:
+		i.Max, i.MaxInclusive = o.Max, o.MaxInclusive
+	case c == 0:
+		i.MaxInclusive = r.MaxInclusive && o.MaxInclusive
+	}
+
+	return i.check()
+}
+
+// Union returns the smallest range containing both ranges, ok is false if the
+// ranges types do not match.
+func (r Range) Union(o Range) (u Range, ok bool) {
+	if r.IsEmpty() {
+		return o, true
+	}
+	if o.IsEmpty() {
+		return r, true
+	}
+	u = r
+
+	// Take the smaller lower bound.
+	c, ok := compareBounds(r.Min, o.Min)
+	switch {
+	case !ok:
+		return Range{}, false
+	case r.Min == nil || o.Min == nil:
+		u.Min, u.MinInclusive = nil, false
+	case c > 0:
+		u.Min, u.MinInclusive = o.Min, o.MinInclusive
+	case c == 0:
+		u.MinInclusive = r.MinInclusive || o.MinInclusive
+	}
+
+	// Take the larger upper bound.
+	c, ok = compareBounds(r.Max, o.Max)
+	switch {
+	case !ok:
+		return Range{}, false
+	case r.Max == nil || o.Max == nil:
+		u.Max, u.MaxInclusive = nil, false
+	case c < 0:
+		u.Max, u.MaxInclusive = o.Max, o.MaxInclusive
+	case c == 0:
+		u.MaxInclusive = r.MaxInclusive || o.MaxInclusive
+	}
+
+	return u.check()
+}
+
+// check checks that the range bounds have the same type.
+func (r Range) check() (Range, bool) {
+	if r.Min != nil && r.Max != nil {
+		if _, ok := compare(r.Min, r.Max); !ok {
+			return Range{}, false
+		}
+	}
+
+	return r, true
+}
+
+// compareBounds compares two bounds, nil bounds are comparable to any bound.
+func compareBounds(a, b interface{}) (int, bool) {
+	if a == nil || b == nil {
+		return 0, true
+	}
+
+	return compare(a, b)
+}
+
+// compare compares two strings or two numbers, ok is false if the value
+// types do not match.
+func compare(a, b interface{}) (c int, ok bool) {
+	switch a := a.(type) {
+	case string:
+		b, ok := b.(string)
+		if !ok {
+			return 0, false
+		}
+		switch {
+		case a < b:
+			return -1, true
+		case a > b:
+			return 1, true
+		}
+		return 0, true
+	case float64:
+		b, ok := b.(float64)
+		if !ok {
+			return 0, false
+		}
+		switch {
+		case a < b:
+			return -1, true
+		case a > b:
+			return 1, true
+		}
+		return 0, true
+	}
+
+	return 0, false
+}
@@ -0,0 +1,322 @@
+// Copyright 2018 Yaacov Zamir <kobi.zamir@gmail.com>
+// and other contributors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package rego converts TSL trees into OPA Rego rule bodies.
+//
+// A tree is converted into rule bodies, each body is a list of conditions that
+// must all be true, and the tree matches a document if any of the bodies is
+// true. Rego has no inline `or`, so trees are converted into a disjunction of
+// conjunctions, each `or` adds bodies.
+//
+// Usage:
+//   bodies, err := rego.Walk(tree, "input")
+//
+//   // Create a policy module with a `deny` rule, true if the tree matches.
+//   module := "package filters\n\n" + rego.Rule("deny", bodies)
+//
+// Open Policy Agent: https://www.openpolicyagent.org/docs/latest/policy-language/
+//
+package rego
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/yaacov/tree-search-language/pkg/tsl"
+)
+
+// compareOps maps TSL comparison operators to Rego operators.
+var compareOps = map[string]string{
+	tsl.EqOp:    "==",
+	tsl.NotEqOp: "!=",
+	tsl.LtOp:    "<",
+	tsl.LteOp:   "<=",
+	tsl.GtOp:    ">",
+	tsl.GteOp:   ">=",
+}
+
+// mathOps maps TSL math operators to Rego operators.
+var mathOps = map[string]string{
+	tsl.AddOp:      "+",
+	tsl.SubtractOp: "-",
+	tsl.MultiplyOp: "*",
+	tsl.DivideOp:   "/",
+	tsl.ModuloOp:   "%",
+}
+
+// stringFuncs maps TSL substring operators to Rego built-in functions.
+var stringFuncs = map[string]string{
+	tsl.ContainsOp:      "contains",
+	tsl.NotContainsOp:   "contains",
+	tsl.StartsWithOp:    "startswith",
+	tsl.NotStartsWithOp: "startswith",
+	tsl.EndsWithOp:      "endswith",
+	tsl.NotEndsWithOp:   "endswith",
+}
+
+// varName matches field names that can be used in Rego dot references.
+var varName = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]*$`)
+
+// Walk travel the TSL tree to create Rego rule bodies, identifiers are
+// references into the ref document, for example `spec.pages` is converted
+// into `input.spec.pages` if ref is `input`.
+//
+// Like SQL, comparisons of null or missing fields are false, negated
+// comparisons check that the field is not null.
+func Walk(n tsl.Node, ref string) ([][]string, error) {
+	switch n.Func {
+	case tsl.OrOp:
+		l, err := Walk(n.Left.(tsl.Node), ref)
+		if err != nil {
+			return nil, err
+		}
+		r, err := Walk(n.Right.(tsl.Node), ref)
+		if err != nil {
+			return nil, err
+		}
+		return append(l, r...), nil
+	case tsl.AndOp:
+		l, err := Walk(n.Left.(tsl.Node), ref)
+		if err != nil {
+			return nil, err
+		}
+		r, err := Walk(n.Right.(tsl.Node), ref)
+		if err != nil {
+			return nil, err
+		}
+
+		// Join each of the left bodies with each of the right bodies.
+		bodies := [][]string{}
+		for _, a := range l {
+			for _, b := range r {
+				body := append(append([]string{}, a...), b...)
+				bodies = append(bodies, body)
+			}
+		}
+		return bodies, nil
+	case tsl.NotOp:
+		// Rego negates single expressions.
+		bodies, err := Walk(n.Left.(tsl.Node), ref)
+		if err != nil {
+			return nil, err
+		}
+		if len(bodies) != 1 || len(bodies[0]) != 1 {
+			return nil, tsl.UnexpectedLiteralError{Literal: n.Func}
+		}
+		return [][]string{{"not " + bodies[0][0]}}, nil
+	}
+
+	return walkPredicate(n, ref)
+}
+
+// Rule formats rule bodies into a Rego rule, true if any of the bodies is true.
+func Rule(name string, bodies [][]string) string {
+	var b strings.Builder
+
+	for _, body := range bodies {
+		b.WriteString(name + " {\n")
+		for _, condition := range body {
+			b.WriteString("\t" + condition + "\n")
+		}
+		b.WriteString("}\n")
+	}
+
+	return b.String()
+}
+
+// walkPredicate creates the rule bodies of a predicate node.
+func walkPredicate(n tsl.Node, ref string) ([][]string, error) {
+	l, ok := n.Left.(tsl.Node)
+	if !ok {
+		return nil, tsl.UnexpectedLiteralError{Literal: n.Func}
+	}
+	left, err := expression(l, ref)
+	if err != nil {
+		return nil, err
+	}
+	notNull := left + " != null"
+
+	switch n.Func {
+	case tsl.IsNilOp:
+		return body(nullable(l, ref) + " == null"), nil
+	case tsl.IsNotNilOp:
+		return body(notNull), nil
+	case tsl.IsTrueOp, tsl.IsFalseOp:
+		return body(left + " == " + strconv.FormatBool(n.Func == tsl.IsTrueOp)), nil
+	case tsl.IsNotTrueOp, tsl.IsNotFalseOp:
+		// Not true also matches null and missing values.
+		return body(nullable(l, ref) + " != " + strconv.FormatBool(n.Func == tsl.IsNotTrueOp)), nil
+	}
+
+	r, ok := n.Right.(tsl.Node)
+	if !ok {
+		return nil, tsl.UnexpectedLiteralError{Literal: n.Func}
+	}
+
+	switch n.Func {
+	case tsl.EqOp, tsl.NotEqOp, tsl.LtOp, tsl.LteOp, tsl.GtOp, tsl.GteOp:
+		right, err := expression(r, ref)
+		if err != nil {
+			return nil, err
+		}
+		return body(left + " " + compareOps[n.Func] + " " + right), nil
+	case tsl.InOp, tsl.NotInOp:
+		values, err := array(r)
+		if err != nil {
+			return nil, err
+		}
+		set := "{" + strings.Join(values, ", ") + "}[" + left + "]"
+		if n.Func == tsl.NotInOp {
+			return body(notNull, "not "+set), nil
+		}
+		return body(set), nil
+	case tsl.BetweenOp, tsl.NotBetweenOp:
+		// Note: sql's between operator is inclusive: begin and end values are included.
+		values, err := array(r)
+		if err != nil {
+			return nil, err
+		}
+		if len(values) != 2 {
+			return nil, tsl.UnexpectedLiteralError{Literal: r.Left}
+		}
+		if n.Func == tsl.NotBetweenOp {
+			return [][]string{{left + " < " + values[0]}, {left + " > " + values[1]}}, nil
+		}
+		return body(left+" >= "+values[0], left+" <= "+values[1]), nil
+	}
+
+	// The rest of the operators match strings.
+	s, ok := r.Left.(string)
+	if !ok {
+		return nil, tsl.UnexpectedLiteralError{Literal: r.Left}
+	}
+
+	var condition string
+	not := false
+	switch n.Func {
+	case tsl.RegexOp, tsl.NotRegexOp:
+		condition = "regex.match(" + strconv.Quote(s) + ", " + left + ")"
+		not = n.Func == tsl.NotRegexOp
+	case tsl.LikeOp, tsl.NotLikeOp, tsl.ILikeOp, tsl.NotILikeOp:
+		fold := n.Func == tsl.ILikeOp || n.Func == tsl.NotILikeOp
+		condition = "regex.match(" + strconv.Quote(tsl.LikePattern(s, fold)) + ", " + left + ")"
+		not = n.Func == tsl.NotLikeOp || n.Func == tsl.NotILikeOp
+	case tsl.ContainsOp, tsl.NotContainsOp, tsl.StartsWithOp, tsl.NotStartsWithOp, tsl.EndsWithOp, tsl.NotEndsWithOp:
+		condition = stringFuncs[n.Func] + "(" + left + ", " + strconv.Quote(s) + ")"
+		not = n.Func == tsl.NotContainsOp || n.Func == tsl.NotStartsWithOp || n.Func == tsl.NotEndsWithOp
+	default:
+		// If here than the operator is not supported.
+		return nil, tsl.UnexpectedLiteralError{Literal: n.Func}
+	}
+
+	if not {
+		return body(notNull, "not "+condition), nil
+	}
+	return body(condition), nil
+}
+
+// body returns a single rule body of conditions.
+func body(conditions ...string) [][]string {
+	return [][]string{conditions}
+}
+
+// expression converts identifiers, literals and math expressions into Rego
+// expressions.
+func expression(n tsl.Node, ref string) (string, error) {
+	switch n.Func {
+	case tsl.IdentOp:
+		return reference(n.Left.(string), ref), nil
+	case tsl.StringOp, tsl.DateOp:
+		return strconv.Quote(n.Left.(string)), nil
+	case tsl.NumberOp, tsl.DurationOp:
+		return strconv.FormatFloat(n.Left.(float64), 'g', -1, 64), nil
+	case tsl.BooleanOp:
+		return strconv.FormatBool(n.Left.(bool)), nil
+	case tsl.NullOp:
+		return "null", nil
+	}
+
+	op, ok := mathOps[n.Func]
+	if !ok {
+		return "", tsl.UnexpectedLiteralError{Literal: n.Func}
+	}
+
+	// Add parentheses to nested expressions.
+	sides := [2]string{}
+	for i, side := range []interface{}{n.Left, n.Right} {
+		s, err := expression(side.(tsl.Node), ref)
+		if err != nil {
+			return "", err
+		}
+		if _, ok := mathOps[side.(tsl.Node).Func]; ok {
+			s = "(" + s + ")"
+		}
+		sides[i] = s
+	}
+
+	return sides[0] + " " + op + " " + sides[1], nil
+}
+
+// array converts the literals of an array node into Rego values.
+func array(n tsl.Node) ([]string, error) {
+	if n.Func != tsl.ArrayOp {
+		return nil, tsl.UnexpectedLiteralError{Literal: n.Func}
+	}
+
+	values := []string{}
+	for _, v := range n.Right.([]tsl.Node) {
+		s, err := expression(v, "")
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, s)
+	}
+
+	return values, nil
+}
+
+// reference converts a dot separated identifier into a reference into the ref
+// document, names that are not Rego variable names are quoted.
+func reference(name string, ref string) string {
+	var b strings.Builder
+
+	b.WriteString(ref)
+	for _, part := range strings.Split(name, ".") {
+		if varName.MatchString(part) {
+			b.WriteString("." + part)
+		} else {
+			b.WriteString("[" + strconv.Quote(part) + "]")
+		}
+	}
+
+	return b.String()
+}
+
+// nullable converts an identifier into an expression that is null for null and
+// missing fields, other expressions are returned as is.
+func nullable(n tsl.Node, ref string) string {
+	if n.Func != tsl.IdentOp {
+		s, _ := expression(n, ref)
+		return s
+	}
+
+	path := []string{}
+	for _, part := range strings.Split(n.Left.(string), ".") {
+		path = append(path, strconv.Quote(part))
+	}
+
+	return "object.get(" + ref + ", [" + strings.Join(path, ", ") + "], null)"
+}
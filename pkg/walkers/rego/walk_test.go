@@ -0,0 +1,87 @@
+// Copyright 2018 Yaacov Zamir <kobi.zamir@gmail.com>
+// and other contributors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rego
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/yaacov/tree-search-language/pkg/tsl"
+)
+
+func TestWalk(t *testing.T) {
+	tests := []struct {
+		phrase string
+		want   [][]string
+	}{
+		{
+			phrase: "name = 'joe' and spec.pages > 100",
+			want:   [][]string{{`input.name == "joe"`, `input.spec.pages > 100`}},
+		},
+		{
+			phrase: "(city = 'rome' or city = 'paris') and pages * 2 < 30",
+			want:   [][]string{{`input.city == "rome"`, `input.pages * 2 < 30`}, {`input.city == "paris"`, `input.pages * 2 < 30`}},
+		},
+		{
+			phrase: "city not in ('rome', 'paris')",
+			want:   [][]string{{`input.city != null`, `not {"rome", "paris"}[input.city]`}},
+		},
+		{
+			phrase: "title like 'a%' and author is null",
+			want:   [][]string{{`regex.match("^a.*$", input.title)`, `object.get(input, ["author"], null) == null`}},
+		},
+		{
+			phrase: "pages not between 1 and 10",
+			want:   [][]string{{`input.pages < 1`}, {`input.pages > 10`}},
+		},
+		{
+			phrase: "name not startswith 'Jo' and active is true",
+			want:   [][]string{{`input.name != null`, `not startswith(input.name, "Jo")`, `input.active == true`}},
+		},
+	}
+
+	for _, tt := range tests {
+		tree, err := tsl.ParseTSL(tt.phrase)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		got, err := Walk(tree, "input")
+		if err != nil {
+			t.Errorf("Walk(%s) error = %v", tt.phrase, err)
+			continue
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("Walk(%s) = %q, want %q", tt.phrase, got, tt.want)
+		}
+	}
+}
+
+func TestRule(t *testing.T) {
+	// Identifiers may be mapped to names that are not Rego variable names.
+	tree, _ := tsl.ParseTSL("city = 'rome' or spec.deleted is not null")
+	tree.Right = tsl.Node{Func: tsl.IsNotNilOp, Left: tsl.Node{Func: tsl.IdentOp, Left: "spec.deleted-at"}}
+
+	bodies, err := Walk(tree, "input")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := "deny {\n\tinput.city == \"rome\"\n}\ndeny {\n\tinput.spec[\"deleted-at\"] != null\n}\n"
+	if got := Rule("deny", bodies); got != want {
+		t.Errorf("Rule() = %q, want %q", got, want)
+	}
+}
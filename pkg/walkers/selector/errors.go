@@ -0,0 +1,32 @@
+// Copyright 2018 Yaacov Zamir <kobi.zamir@gmail.com>
+// and other contributors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package selector
+
+import (
+	"fmt"
+	"strings"
+)
+
+// UnsupportedError is raised when a tree uses operators a selector does not
+// support.
+type UnsupportedError struct {
+	Selector  string   // the selector kind, label or field.
+	Operators []string // the unsupported operators.
+}
+
+func (e UnsupportedError) Error() string {
+	return fmt.Sprintf("unsupported %s selector operators: %s", e.Selector, strings.Join(e.Operators, ", "))
+}
@@ -0,0 +1,207 @@
+// Copyright 2018 Yaacov Zamir <kobi.zamir@gmail.com>
+// and other contributors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package selector converts TSL trees into Kubernetes label and field
+// selector strings.
+//
+// Selectors support a restricted subset of TSL, requirements joined by `and`:
+//
+//   Labels - `=`, `!=`, `in`, `not in`, `is null`, `is not null`, and `<`, `>`
+//            of integers.
+//   Fields - `=` and `!=`.
+//
+// Usage:
+//   s, err := selector.Labels(tree)
+//
+//   pods, err := clientset.CoreV1().Pods("").List(ctx, metav1.ListOptions{LabelSelector: s})
+//
+package selector
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/yaacov/tree-search-language/pkg/tsl"
+)
+
+// Selector kinds.
+const (
+	Label = "label"
+	Field = "field"
+)
+
+// fieldEscaper escapes field selector values, like fields.EscapeValue.
+var fieldEscaper = strings.NewReplacer(`\`, `\\`, `,`, `\,`, `=`, `\=`)
+
+// Labels converts a TSL tree into a label selector string, for example
+// `app=web,tier in (back,front)`.
+//
+// Trees using operators the selector does not support return an
+// UnsupportedError listing the operators.
+func Labels(n tsl.Node) (string, error) {
+	w := walker{kind: Label}
+	return w.selector(n)
+}
+
+// Fields converts a TSL tree into a field selector string, for example
+// `metadata.name=web,status.phase!=Running`.
+//
+// Trees using operators the selector does not support return an
+// UnsupportedError listing the operators.
+func Fields(n tsl.Node) (string, error) {
+	w := walker{kind: Field}
+	return w.selector(n)
+}
+
+// walker collects the requirements of a selector, and the operators it does
+// not support.
+type walker struct {
+	kind         string
+	requirements []string
+	unsupported  map[string]bool
+}
+
+// selector walks a tree and returns the selector string.
+func (w *walker) selector(n tsl.Node) (string, error) {
+	w.walk(n)
+
+	if len(w.unsupported) > 0 {
+		ops := []string{}
+		for op := range w.unsupported {
+			ops = append(ops, op)
+		}
+		sort.Strings(ops)
+
+		return "", UnsupportedError{Selector: w.kind, Operators: ops}
+	}
+
+	return strings.Join(w.requirements, ","), nil
+}
+
+// walk appends the requirements of a node.
+func (w *walker) walk(n tsl.Node) {
+	switch n.Func {
+	case tsl.AndOp:
+		w.walk(n.Left.(tsl.Node))
+		w.walk(n.Right.(tsl.Node))
+		return
+	case tsl.OrOp:
+		// Check the operands for more unsupported operators.
+		w.unsupport(n.Func)
+		w.walk(n.Left.(tsl.Node))
+		w.walk(n.Right.(tsl.Node))
+		return
+	case tsl.NotOp:
+		w.unsupport(n.Func)
+		w.walk(n.Left.(tsl.Node))
+		return
+	}
+
+	// Requirements compare a key to values.
+	l, ok := n.Left.(tsl.Node)
+	if !ok || l.Func != tsl.IdentOp {
+		w.unsupport(n.Func)
+		if ok {
+			w.unsupport(l.Func)
+		}
+		return
+	}
+	key := l.Left.(string)
+
+	switch {
+	case n.Func == tsl.EqOp || n.Func == tsl.NotEqOp:
+		if v, ok := w.value(n.Right); ok {
+			w.requirements = append(w.requirements, key+map[string]string{tsl.EqOp: "=", tsl.NotEqOp: "!="}[n.Func]+v)
+			return
+		}
+	case w.kind == Field:
+	case n.Func == tsl.InOp || n.Func == tsl.NotInOp:
+		if values, ok := w.values(n.Right); ok {
+			w.requirements = append(w.requirements, fmt.Sprintf("%s %s (%s)", key,
+				map[string]string{tsl.InOp: "in", tsl.NotInOp: "notin"}[n.Func], strings.Join(values, ",")))
+			return
+		}
+	case n.Func == tsl.IsNotNilOp:
+		w.requirements = append(w.requirements, key)
+		return
+	case n.Func == tsl.IsNilOp:
+		w.requirements = append(w.requirements, "!"+key)
+		return
+	case n.Func == tsl.GtOp || n.Func == tsl.LtOp:
+		// Labels are compared to integers.
+		if r, ok := n.Right.(tsl.Node); ok && r.Func == tsl.NumberOp && r.Left.(float64) == math.Trunc(r.Left.(float64)) {
+			w.requirements = append(w.requirements, key+map[string]string{tsl.GtOp: ">", tsl.LtOp: "<"}[n.Func]+
+				strconv.FormatFloat(r.Left.(float64), 'f', -1, 64))
+			return
+		}
+	}
+
+	w.unsupport(n.Func)
+}
+
+// unsupport records an operator the selector does not support.
+func (w *walker) unsupport(op string) {
+	if w.unsupported == nil {
+		w.unsupported = map[string]bool{}
+	}
+	w.unsupported[op] = true
+}
+
+// value returns the selector string of a literal node.
+func (w *walker) value(v interface{}) (string, bool) {
+	n, ok := v.(tsl.Node)
+	if !ok {
+		return "", false
+	}
+
+	var s string
+	switch l := n.Left.(type) {
+	case string:
+		s = l
+	case float64:
+		s = strconv.FormatFloat(l, 'f', -1, 64)
+	case bool:
+		s = strconv.FormatBool(l)
+	default:
+		return "", false
+	}
+
+	if w.kind == Field {
+		s = fieldEscaper.Replace(s)
+	}
+	return s, true
+}
+
+// values returns the selector strings of an array node.
+func (w *walker) values(v interface{}) ([]string, bool) {
+	n, ok := v.(tsl.Node)
+	if !ok || n.Func != tsl.ArrayOp {
+		return nil, false
+	}
+
+	values := []string{}
+	for _, e := range n.Right.([]tsl.Node) {
+		s, ok := w.value(e)
+		if !ok {
+			return nil, false
+		}
+		values = append(values, s)
+	}
+
+	return values, true
+}
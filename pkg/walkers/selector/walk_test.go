@@ -0,0 +1,92 @@
+// Copyright 2018 Yaacov Zamir <kobi.zamir@gmail.com>
+// and other contributors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package selector
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/yaacov/tree-search-language/pkg/tsl"
+)
+
+func TestLabels(t *testing.T) {
+	tests := []struct {
+		phrase string
+		want   string
+	}{
+		{phrase: "app = 'web' and tier in ('back', 'front')", want: "app=web,tier in (back,front)"},
+		{phrase: "env != 'prod' and release not in ('canary')", want: "env!=prod,release notin (canary)"},
+		{phrase: "partition is not null and legacy is null", want: "partition,!legacy"},
+		{phrase: "replicas > 2 and replicas < 10", want: "replicas>2,replicas<10"},
+	}
+
+	for _, tt := range tests {
+		tree, err := tsl.ParseTSL(tt.phrase)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		got, err := Labels(tree)
+		if err != nil {
+			t.Errorf("Labels(%s) error = %v", tt.phrase, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("Labels(%s) = %s, want %s", tt.phrase, got, tt.want)
+		}
+	}
+}
+
+func TestFields(t *testing.T) {
+	tree, _ := tsl.ParseTSL("metadata.name = 'a,b' and status.phase != 'Running'")
+	got, err := Fields(tree)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := `metadata.name=a\,b,status.phase!=Running`; got != want {
+		t.Errorf("Fields() = %s, want %s", got, want)
+	}
+}
+
+func TestUnsupported(t *testing.T) {
+	tests := []struct {
+		phrase   string
+		selector func(tsl.Node) (string, error)
+		want     []string
+	}{
+		{phrase: "app = 'web' or name like 'a%'", selector: Labels, want: []string{tsl.LikeOp, tsl.OrOp}},
+		{phrase: "replicas >= 2.5 and app in ('web')", selector: Labels, want: []string{tsl.GteOp}},
+		{phrase: "spec.replicas * 2 = 4", selector: Labels, want: []string{tsl.EqOp, tsl.MultiplyOp}},
+		{phrase: "status.phase in ('Running') and status.phase is null", selector: Fields, want: []string{tsl.InOp, tsl.IsNilOp}},
+	}
+
+	for _, tt := range tests {
+		tree, err := tsl.ParseTSL(tt.phrase)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		_, err = tt.selector(tree)
+		e, ok := err.(UnsupportedError)
+		if !ok {
+			t.Errorf("%s: expected an unsupported error, got %v", tt.phrase, err)
+			continue
+		}
+		if !reflect.DeepEqual(e.Operators, tt.want) {
+			t.Errorf("%s: unsupported operators = %v, want %v", tt.phrase, e.Operators, tt.want)
+		}
+	}
+}
@@ -0,0 +1,101 @@
+// Copyright 2018 Yaacov Zamir <kobi.zamir@gmail.com>
+// and other contributors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package semantics
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/yaacov/tree-search-language/pkg/tsl"
+)
+
+// Coercion is a policy for comparing document values to literals of another
+// type.
+type Coercion int
+
+// Coercion policies.
+const (
+	// NoCoercion compares document values as is, like Walk.
+	NoCoercion Coercion = iota
+
+	// NumericStrings compares string values holding numbers to number
+	// literals, and uses them in math expressions, as numbers.
+	NumericStrings
+
+	// Lenient coerces numeric strings like NumericStrings, compares number
+	// values to string literals as strings, and `true` and `false` string
+	// values to boolean literals as booleans.
+	Lenient
+)
+
+// coerce converts an operand to the type of the literals of a predicate,
+// operands that can not be converted are returned as is.
+func (c Coercion) coerce(n tsl.Node, l operand) operand {
+	literal := tsl.Node{}
+	if r, ok := n.Right.(tsl.Node); ok {
+		literal = r
+		if r.Func == tsl.ArrayOp {
+			if list := r.Right.([]tsl.Node); len(list) > 0 {
+				literal = list[0]
+			}
+		}
+	}
+
+	switch {
+	case c >= NumericStrings && l.kind == stringKind && (literal.Func == tsl.NumberOp || literal.Func == tsl.DurationOp):
+		return c.number(l)
+	case c >= Lenient && l.kind == numberKind && literal.Func == tsl.StringOp:
+		return operand{kind: stringKind, s: strconv.FormatFloat(l.f, 'f', -1, 64)}
+	case c >= Lenient && l.kind == stringKind && isBoolPredicate(n, literal):
+		if b, err := strconv.ParseBool(l.s); err == nil {
+			return operand{kind: boolKind, b: b}
+		}
+	}
+
+	return l
+}
+
+// number converts a numeric string operand into a number operand.
+func (c Coercion) number(l operand) operand {
+	if c < NumericStrings || l.kind != stringKind {
+		return l
+	}
+
+	// Parse integers exactly.
+	s := strings.TrimSpace(l.s)
+	if i, err := strconv.ParseInt(s, 10, 64); err == nil {
+		return intOperand(i)
+	}
+	if u, err := strconv.ParseUint(s, 10, 64); err == nil {
+		return uintOperand(u)
+	}
+
+	if f, err := strconv.ParseFloat(s, 64); err == nil {
+		return operand{kind: numberKind, f: f}
+	}
+
+	return l
+}
+
+// isBoolPredicate checks if a predicate compares to a boolean.
+func isBoolPredicate(n tsl.Node, literal tsl.Node) bool {
+	switch n.Func {
+	case tsl.IsTrueOp, tsl.IsFalseOp, tsl.IsNotTrueOp, tsl.IsNotFalseOp:
+		return true
+	}
+
+	return literal.Func == tsl.BooleanOp
+}
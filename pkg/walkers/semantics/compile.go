@@ -0,0 +1,260 @@
+// Copyright 2018 Yaacov Zamir <kobi.zamir@gmail.com>
+// and other contributors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package semantics
+
+import (
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/yaacov/tree-search-language/pkg/tsl"
+)
+
+// Compile compiles a TSL tree into a matcher closure, that checks if a
+// document evaluates to `true` or `false` like Walk.
+//
+// Operators are dispatched by the literal type once, and regular expressions
+// are compiled once, so evaluating the same tree against many documents
+// avoids walking the tree for every document. The walkers compile package
+// uses this compiler.
+//
+//  match, err := semantics.Compile(tree)
+//
+//  for _, doc := range docs {
+//    ok, err := match(semantics.DocEval(doc, semantics.Flat))
+//    ...
+//  }
+//
+func Compile(n tsl.Node) (func(eval EvalFunc) (bool, error), error) {
+	c, err := compileNode(n)
+	if err != nil {
+		return nil, err
+	}
+
+	return func(eval EvalFunc) (bool, error) {
+		w := walker{eval: eval}
+		return c(&w)
+	}, nil
+}
+
+// CompileDoc compiles a TSL tree into a document matcher closure, identifiers
+// are looked up in the documents using the lookup mode.
+//
+//  match, err := semantics.CompileDoc(tree, semantics.Nested)
+//
+//  for _, doc := range docs {
+//    ok, err := match(doc)
+//    ...
+//  }
+//
+func CompileDoc(n tsl.Node, lookup Lookup) (func(doc Doc) (bool, error), error) {
+	match, err := Compile(n)
+	if err != nil {
+		return nil, err
+	}
+
+	return func(doc Doc) (bool, error) {
+		return match(DocEval(doc, lookup))
+	}, nil
+}
+
+// compiled is a compiled node, it is evaluated using the field cache of a walker.
+type compiled = func(w *walker) (bool, error)
+
+// comparison is a compiled predicate, with an evaluated left side operand.
+type comparison = func(l operand) (bool, error)
+
+// compileNode compiles a node into a closure.
+func compileNode(n tsl.Node) (compiled, error) {
+	switch n.Func {
+	case tsl.AndOp, tsl.OrOp:
+		return compileLogicalOp(n)
+	case tsl.WithinOp, tsl.NotWithinOp, tsl.WithinBoxOp, tsl.NotWithinBoxOp:
+		return func(w *walker) (bool, error) { return w.geo(n) }, nil
+	}
+
+	var resolve func(w *walker) (operand, error)
+
+	l, ok := n.Left.(tsl.Node)
+	if !ok {
+		// Boolean literals, for example an optimized constant tree, and
+		// malformed nodes are walked.
+		return func(w *walker) (bool, error) { return w.step(n, nil) }, nil
+	}
+	switch {
+	case l.Func == tsl.IdentOp:
+		resolve = func(w *walker) (operand, error) { return w.resolve(l) }
+	case isMath(l):
+		resolve = func(w *walker) (operand, error) { return w.resolveMath(l) }
+	default:
+		// A predicate on a literal does not depend on the document.
+		b, err := compare(n, literalOperand(l))
+		return func(*walker) (bool, error) { return b, err }, nil
+	}
+
+	cmp, err := compileComparison(n)
+	if err != nil {
+		return nil, err
+	}
+
+	return func(w *walker) (bool, error) {
+		v, err := resolve(w)
+		if err != nil {
+			return false, err
+		}
+		if v.kind == listKind {
+			return w.compareList(n, v)
+		}
+		return cmp(v)
+	}, nil
+}
+
+// compileLogicalOp compiles AND and OR nodes, the left side is evaluated
+// first, and the right side only if the left side does not decide the result,
+// like Walk.
+func compileLogicalOp(n tsl.Node) (compiled, error) {
+	l, err := compileNode(n.Left.(tsl.Node))
+	if err != nil {
+		return nil, err
+	}
+	r, err := compileNode(n.Right.(tsl.Node))
+	if err != nil {
+		return nil, err
+	}
+
+	and := n.Func == tsl.AndOp
+	return func(w *walker) (bool, error) {
+		left, err := l(w)
+		if err != nil || left != and {
+			return left, err
+		}
+
+		return r(w)
+	}, nil
+}
+
+// compileComparison specializes a predicate by it's operator and literal
+// type, operands of other types are compared using compare.
+func compileComparison(n tsl.Node) (comparison, error) {
+	fallback := func(l operand) (bool, error) {
+		return compare(n, l)
+	}
+
+	r, ok := n.Right.(tsl.Node)
+	if !ok {
+		return fallback, nil
+	}
+
+	switch n.Func {
+	case tsl.RegexOp, tsl.NotRegexOp, tsl.LikeOp, tsl.NotLikeOp, tsl.ILikeOp, tsl.NotILikeOp:
+		if r.Func != tsl.StringOp && r.Func != tsl.DateOp {
+			return fallback, nil
+		}
+
+		var re *regexp.Regexp
+		var err error
+		switch n.Func {
+		case tsl.RegexOp, tsl.NotRegexOp:
+			re, err = regexps.regexp(r)
+		default:
+			re, err = regexps.like(r, n.Func == tsl.ILikeOp || n.Func == tsl.NotILikeOp)
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		not := n.Func == tsl.NotRegexOp || n.Func == tsl.NotLikeOp || n.Func == tsl.NotILikeOp
+		return func(l operand) (bool, error) {
+			if l.kind != stringKind {
+				return fallback(l)
+			}
+			return re.MatchString(l.s) != not, nil
+		}, nil
+	}
+
+	switch r.Func {
+	case tsl.StringOp:
+		if op, ok := stringComparisons[n.Func]; ok {
+			s := r.Left.(string)
+			return func(l operand) (bool, error) {
+				if l.kind != stringKind {
+					return fallback(l)
+				}
+				return op(l.s, s), nil
+			}, nil
+		}
+	case tsl.NumberOp, tsl.DurationOp:
+		// Integer literals float64 can not hold are compared exactly.
+		if op, ok := numberComparisons[n.Func]; ok && !isExactLiteral(r) {
+			f := r.Left.(float64)
+			return func(l operand) (bool, error) {
+				if l.kind != numberKind {
+					return fallback(l)
+				}
+				return op(l.f, f), nil
+			}, nil
+		}
+	case tsl.DateOp:
+		t, err := tsl.Date(r)
+		if op, ok := timeComparisons[n.Func]; ok && err == nil {
+			return func(l operand) (bool, error) {
+				if l.kind != timeKind {
+					return fallback(l)
+				}
+				return op(l.t, t), nil
+			}, nil
+		}
+	}
+
+	return fallback, nil
+}
+
+// stringComparisons maps operators to string comparisons.
+var stringComparisons = map[string]func(a, b string) bool{
+	tsl.EqOp:    func(a, b string) bool { return a == b },
+	tsl.NotEqOp: func(a, b string) bool { return a != b },
+	tsl.LtOp:    func(a, b string) bool { return a < b },
+	tsl.LteOp:   func(a, b string) bool { return a <= b },
+	tsl.GtOp:    func(a, b string) bool { return a > b },
+	tsl.GteOp:   func(a, b string) bool { return a >= b },
+
+	tsl.ContainsOp:      strings.Contains,
+	tsl.NotContainsOp:   func(a, b string) bool { return !strings.Contains(a, b) },
+	tsl.StartsWithOp:    strings.HasPrefix,
+	tsl.NotStartsWithOp: func(a, b string) bool { return !strings.HasPrefix(a, b) },
+	tsl.EndsWithOp:      strings.HasSuffix,
+	tsl.NotEndsWithOp:   func(a, b string) bool { return !strings.HasSuffix(a, b) },
+}
+
+// numberComparisons maps operators to number comparisons.
+var numberComparisons = map[string]func(a, b float64) bool{
+	tsl.EqOp:    func(a, b float64) bool { return a == b },
+	tsl.NotEqOp: func(a, b float64) bool { return a != b },
+	tsl.LtOp:    func(a, b float64) bool { return a < b },
+	tsl.LteOp:   func(a, b float64) bool { return a <= b },
+	tsl.GtOp:    func(a, b float64) bool { return a > b },
+	tsl.GteOp:   func(a, b float64) bool { return a >= b },
+}
+
+// timeComparisons maps operators to date comparisons.
+var timeComparisons = map[string]func(a, b time.Time) bool{
+	tsl.EqOp:    func(a, b time.Time) bool { return a.Equal(b) },
+	tsl.NotEqOp: func(a, b time.Time) bool { return !a.Equal(b) },
+	tsl.LtOp:    func(a, b time.Time) bool { return a.Before(b) },
+	tsl.LteOp:   func(a, b time.Time) bool { return !a.After(b) },
+	tsl.GtOp:    func(a, b time.Time) bool { return a.After(b) },
+	tsl.GteOp:   func(a, b time.Time) bool { return !a.Before(b) },
+}
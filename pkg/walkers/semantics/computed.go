@@ -0,0 +1,54 @@
+// Copyright 2018 Yaacov Zamir <kobi.zamir@gmail.com>
+// and other contributors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package semantics
+
+// ComputedFunc computes the value of a virtual field, from the fields of a
+// document.
+type ComputedFunc = func(eval EvalFunc) interface{}
+
+// ComputedEval wraps an evaluation function with computed fields, identifiers
+// that are not found in the document are computed by the callback registered
+// for them, so trees can use fields that are derived from other fields.
+//
+// Document fields take precedence over computed fields with the same name.
+//
+// Example:
+//  	computed := map[string]semantics.ComputedFunc{
+//  		"full_name": func(eval semantics.EvalFunc) interface{} {
+//  			first, _ := eval("first_name")
+//  			last, _ := eval("last_name")
+//  			return fmt.Sprintf("%v %v", first, last)
+//  		},
+//  	}
+//
+//  	// If our tsl tree represents the tsl phrase "full_name = 'Joe Smith'"
+//  	// and our record is {"first_name": "Joe", "last_name": "Smith"}, we will
+//  	// get `true`.
+//  	compliance, err = semantics.Walk(tree, semantics.ComputedEval(eval, computed))
+//
+func ComputedEval(eval EvalFunc, fields map[string]ComputedFunc) EvalFunc {
+	return func(k string) (interface{}, bool) {
+		if v, ok := eval(k); ok {
+			return v, true
+		}
+
+		if f, ok := fields[k]; ok {
+			return f(eval), true
+		}
+
+		return nil, false
+	}
+}
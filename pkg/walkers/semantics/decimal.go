@@ -0,0 +1,103 @@
+// Copyright 2018 Yaacov Zamir <kobi.zamir@gmail.com>
+// and other contributors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package semantics
+
+import (
+	"math/big"
+	"strconv"
+
+	"github.com/yaacov/tree-search-language/pkg/tsl"
+)
+
+// rational is implemented by decimal types that convert to an exact rational
+// number, for example github.com/shopspring/decimal Decimal values.
+type rational interface {
+	Rat() *big.Rat
+}
+
+// decimalOperand converts an arbitrary-precision document value into a
+// decimal operand, nil pointers are null values.
+func decimalOperand(_v interface{}) (operand, bool) {
+	switch v := _v.(type) {
+	case *big.Rat:
+		if v == nil {
+			return operand{kind: nullKind}, true
+		}
+		return operand{kind: decimalKind, d: v}, true
+	case *big.Int:
+		if v == nil {
+			return operand{kind: nullKind}, true
+		}
+		return operand{kind: decimalKind, d: new(big.Rat).SetInt(v)}, true
+	case *big.Float:
+		if v == nil {
+			return operand{kind: nullKind}, true
+		}
+		// Infinite values are not rational numbers.
+		if d, _ := v.Rat(nil); d != nil {
+			return operand{kind: decimalKind, d: d}, true
+		}
+	case rational:
+		if d := v.Rat(); d != nil {
+			return operand{kind: decimalKind, d: d}, true
+		}
+	}
+
+	return operand{}, false
+}
+
+// decimalLiteral converts a number literal into a rational number, using the
+// shortest decimal representation of the literal, so `0.1` is exactly one
+// tenth and not the nearest float64 value.
+func decimalLiteral(f float64) *big.Rat {
+	d, ok := new(big.Rat).SetString(strconv.FormatFloat(f, 'g', -1, 64))
+	if !ok {
+		return new(big.Rat).SetFloat64(f)
+	}
+
+	return d
+}
+
+func handleDecimalOp(op string, left *big.Rat, right float64) (bool, error) {
+	return handleNumberOp(op, float64(left.Cmp(decimalLiteral(right))), 0)
+}
+
+func handleDecimalArrayOp(op string, left *big.Rat, right []tsl.Node) (bool, error) {
+	// Check the list literals are numbers.
+	for _, node := range right {
+		if _, ok := node.Left.(float64); !ok {
+			return false, tsl.UnexpectedLiteralError{ExpectedType: "number", Literal: node.Left}
+		}
+	}
+
+	switch op {
+	case tsl.BetweenOp, tsl.NotBetweenOp:
+		begin := left.Cmp(decimalLiteral(right[0].Left.(float64)))
+		end := left.Cmp(decimalLiteral(right[1].Left.(float64)))
+		return (begin >= 0 && end < 0) == (op == tsl.BetweenOp), nil
+	case tsl.InOp, tsl.NotInOp:
+		found := false
+		for _, node := range right {
+			if left.Cmp(decimalLiteral(node.Left.(float64))) == 0 {
+				found = true
+				break
+			}
+		}
+		return handleSetOp(op, found)
+	}
+
+	return false, tsl.UnexpectedLiteralError{Literal: op}
+}
@@ -0,0 +1,203 @@
+// Copyright 2018 Yaacov Zamir <kobi.zamir@gmail.com>
+// and other contributors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package semantics
+
+import (
+	"sort"
+	"strings"
+)
+
+// Doc is a decoded document.
+type Doc = map[string]interface{}
+
+// Lookup is the way document evaluation functions look up dotted identifiers.
+type Lookup int
+
+// Lookup modes.
+const (
+	// Flat looks up identifiers as document keys, "spec.pages" is the value
+	// of the "spec.pages" key.
+	Flat Lookup = iota
+	// Nested looks up dot separated identifiers into nested objects, "spec.pages"
+	// is the value of the "pages" key of the "spec" object.
+	Nested
+)
+
+// DocEval creates an evaluation function for a document.
+//
+// Identifiers with `*` wildcard parts, like `spec.*.status` or `labels.*`,
+// evaluate to the list of values of all the matching keys, a wildcard matches
+// one part of the path, so a predicate on such identifier is true if any of
+// the values match.
+//
+// Example:
+//  	doc := map[string]interface{}{
+//  		"title": "A good book",
+//  		"spec": map[string]interface{}{
+//  			"pages":  14,
+//  			"rating": 5,
+//  		},
+//  	}
+//
+//  	// If our tsl tree represents the tsl phrase "spec.pages > 10"
+//  	// we will get the boolean value `true` for our document.
+//  	compliance, err = semantics.Walk(tree, semantics.DocEval(doc, semantics.Nested))
+//
+func DocEval(doc Doc, lookup Lookup) EvalFunc {
+	if lookup == Flat {
+		return func(k string) (interface{}, bool) {
+			if isWildcard(k) {
+				return expandFlat(doc, k)
+			}
+
+			v, ok := doc[k]
+			return v, ok
+		}
+	}
+
+	return func(k string) (interface{}, bool) {
+		if isWildcard(k) {
+			values := expandNested(nil, doc, strings.Split(k, "."))
+			if len(values) == 0 {
+				return nil, false
+			}
+			return values, true
+		}
+
+		return lookupNested(doc, k)
+	}
+}
+
+// lookupNested follows a dot separated path into nested objects.
+func lookupNested(doc map[string]interface{}, k string) (interface{}, bool) {
+	m := doc
+	for {
+		i := strings.IndexByte(k, '.')
+		if i < 0 {
+			v, ok := m[k]
+			return v, ok
+		}
+
+		// Step into the next nested object.
+		next, ok := m[k[:i]].(map[string]interface{})
+		if !ok {
+			return nil, false
+		}
+		m, k = next, k[i+1:]
+	}
+}
+
+// isWildcard checks for dot separated identifiers with a `*` part.
+func isWildcard(k string) bool {
+	return k == "*" || strings.HasPrefix(k, "*.") || strings.HasSuffix(k, ".*") || strings.Contains(k, ".*.")
+}
+
+// expandFlat returns the values of the document keys matching a wildcard
+// identifier, ordered by key.
+func expandFlat(doc map[string]interface{}, k string) (interface{}, bool) {
+	pattern := strings.Split(k, ".")
+
+	keys := []string{}
+	for key := range doc {
+		if matchPath(pattern, strings.Split(key, ".")) {
+			keys = append(keys, key)
+		}
+	}
+	if len(keys) == 0 {
+		return nil, false
+	}
+	sort.Strings(keys)
+
+	values := []interface{}{}
+	for _, key := range keys {
+		values = appendValue(values, doc[key])
+	}
+
+	return values, true
+}
+
+// matchPath checks if the parts of a path match the parts of a wildcard path.
+func matchPath(pattern []string, path []string) bool {
+	if len(pattern) != len(path) {
+		return false
+	}
+
+	for i := range pattern {
+		if pattern[i] != "*" && pattern[i] != path[i] {
+			return false
+		}
+	}
+
+	return true
+}
+
+// expandNested appends the values matching the parts of a wildcard path in
+// nested objects, wildcards match object keys, ordered by key, and list
+// elements.
+func expandNested(values []interface{}, v interface{}, path []string) []interface{} {
+	if len(path) == 0 {
+		return appendValue(values, v)
+	}
+
+	switch v := v.(type) {
+	case map[string]interface{}:
+		if path[0] != "*" {
+			if next, ok := v[path[0]]; ok {
+				values = expandNested(values, next, path[1:])
+			}
+			return values
+		}
+
+		keys := make([]string, 0, len(v))
+		for key := range v {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+
+		for _, key := range keys {
+			values = expandNested(values, v[key], path[1:])
+		}
+	case []interface{}:
+		if path[0] == "*" {
+			for _, next := range v {
+				values = expandNested(values, next, path[1:])
+			}
+		}
+	}
+
+	return values
+}
+
+// appendValue appends a value to the list of values of a wildcard identifier,
+// list values are appended element by element.
+func appendValue(values []interface{}, v interface{}) []interface{} {
+	switch v := v.(type) {
+	case []interface{}:
+		return append(values, v...)
+	case []string:
+		for _, s := range v {
+			values = append(values, s)
+		}
+		return values
+	case []float64:
+		for _, f := range v {
+			values = append(values, f)
+		}
+		return values
+	}
+
+	return append(values, v)
+}
@@ -0,0 +1,186 @@
+// Copyright 2018 Yaacov Zamir <kobi.zamir@gmail.com>
+// and other contributors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package semantics
+
+import (
+	"runtime"
+	"sync"
+
+	"github.com/yaacov/tree-search-language/pkg/tsl"
+)
+
+// filterChunkSize is the number of documents evaluated by a worker at a time.
+const filterChunkSize = 256
+
+// FilterSlice evaluates the documents of a slice, and returns the indexes of
+// the matching documents, in order.
+//
+// The slice is accessed using an evaluation function factory, creating the
+// evaluation function of the document at an index, evaluation stops on the
+// first document that fails to evaluate.
+//
+// Example:
+//  	// Get the books matching the tree.
+//  	indexes, err := semantics.FilterSlice(tree, len(books), func(i int) semantics.EvalFunc {
+//  		return evalFactory(books[i])
+//  	})
+//
+func FilterSlice(n tsl.Node, size int, evalAt func(int) EvalFunc) ([]int, error) {
+	return filterRange(walkMatcher(n), 0, size, evalAt, []int{})
+}
+
+// FilterSliceOrdered evaluates the documents of a slice in parallel, and
+// returns the same indexes and error as FilterSlice.
+//
+// Documents are evaluated in chunks, each worker evaluates one chunk of a
+// window of consecutive chunks, and the chunk matches are joined in order
+// before the next window is evaluated, so memory is bounded by the window
+// size, regardless of the slice size. If workers is less than one, GOMAXPROCS
+// workers are used.
+//
+// Example:
+//  	// Get the books matching the tree, keeping the books sort order.
+//  	indexes, err := semantics.FilterSliceOrdered(tree, len(books), func(i int) semantics.EvalFunc {
+//  		return evalFactory(books[i])
+//  	}, 0)
+//
+func FilterSliceOrdered(n tsl.Node, size int, evalAt func(int) EvalFunc, workers int) ([]int, error) {
+	return filterOrdered(walkMatcher(n), size, evalAt, workers)
+}
+
+// FilterOptions holds the options of FilterDocs.
+type FilterOptions struct {
+	// Lookup is the document identifier lookup.
+	Lookup Lookup
+
+	// Workers is the number of parallel workers, zero or one evaluate the
+	// documents sequentially, and a negative number uses GOMAXPROCS workers.
+	Workers int
+}
+
+// FilterDocs evaluates a slice of decoded documents, and returns the matching
+// documents and their indexes, in order.
+//
+// The tree is compiled once using Compile, and evaluation stops on the first
+// document that fails to evaluate.
+//
+// Example:
+//  	// Get the books matching the tree, using four workers.
+//  	matches, indexes, err := semantics.FilterDocs(tree, books, semantics.FilterOptions{
+//  		Lookup:  semantics.Nested,
+//  		Workers: 4,
+//  	})
+//
+func FilterDocs(n tsl.Node, docs []map[string]interface{}, opts FilterOptions) ([]map[string]interface{}, []int, error) {
+	match, err := Compile(n)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	evalAt := func(i int) EvalFunc {
+		return DocEval(docs[i], opts.Lookup)
+	}
+
+	var indexes []int
+	switch {
+	case opts.Workers == 0 || opts.Workers == 1:
+		indexes, err = filterRange(match, 0, len(docs), evalAt, []int{})
+	default:
+		indexes, err = filterOrdered(match, len(docs), evalAt, opts.Workers)
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+
+	matches := make([]map[string]interface{}, len(indexes))
+	for i, index := range indexes {
+		matches[i] = docs[index]
+	}
+
+	return matches, indexes, nil
+}
+
+// walkMatcher returns a matcher evaluating a tree using Walk.
+func walkMatcher(n tsl.Node) func(EvalFunc) (bool, error) {
+	return func(eval EvalFunc) (bool, error) {
+		return Walk(n, eval)
+	}
+}
+
+// filterOrdered implements FilterSliceOrdered using a matcher.
+func filterOrdered(match func(EvalFunc) (bool, error), size int, evalAt func(int) EvalFunc, workers int) ([]int, error) {
+	if workers < 1 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+
+	matches := []int{}
+	chunks := make([][]int, workers)
+	errs := make([]error, workers)
+
+	for start := 0; start < size; start += workers * filterChunkSize {
+		var wg sync.WaitGroup
+
+		// Reset the chunks of the previous window.
+		for w := 0; w < workers; w++ {
+			chunks[w], errs[w] = chunks[w][:0], nil
+		}
+
+		// Evaluate the chunks of the window.
+		for w := 0; w < workers; w++ {
+			begin := start + w*filterChunkSize
+			if begin >= size {
+				break
+			}
+			end := begin + filterChunkSize
+			if end > size {
+				end = size
+			}
+
+			wg.Add(1)
+			go func(w, begin, end int) {
+				defer wg.Done()
+				chunks[w], errs[w] = filterRange(match, begin, end, evalAt, chunks[w])
+			}(w, begin, end)
+		}
+		wg.Wait()
+
+		// Join the chunk matches in order, stopping on the first error.
+		for w := 0; w < workers; w++ {
+			if errs[w] != nil {
+				return nil, errs[w]
+			}
+			matches = append(matches, chunks[w]...)
+		}
+	}
+
+	return matches, nil
+}
+
+// filterRange evaluates the documents in the index range [begin, end), and
+// appends the indexes of the matching documents to out.
+func filterRange(match func(EvalFunc) (bool, error), begin, end int, evalAt func(int) EvalFunc, out []int) ([]int, error) {
+	for i := begin; i < end; i++ {
+		b, err := match(evalAt(i))
+		if err != nil {
+			return nil, err
+		}
+		if b {
+			out = append(out, i)
+		}
+	}
+
+	return out, nil
+}
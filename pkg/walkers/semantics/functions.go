@@ -0,0 +1,108 @@
+// Copyright 2018 Yaacov Zamir <kobi.zamir@gmail.com>
+// and other contributors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package semantics
+
+import (
+	"math"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/yaacov/tree-search-language/pkg/tsl"
+)
+
+// function is the implementation of a TSL function operator.
+type function struct {
+	name string
+	call func(w *walker, v operand) (operand, error)
+}
+
+// functions maps TSL function operators to their implementations.
+var functions = map[string]function{
+	tsl.LenOp:    {name: "len", call: funcLen},
+	tsl.LowerOp:  {name: "lower", call: stringFunc(strings.ToLower)},
+	tsl.UpperOp:  {name: "upper", call: stringFunc(strings.ToUpper)},
+	tsl.AbsOp:    {name: "abs", call: numberFunc(math.Abs)},
+	tsl.RoundOp:  {name: "round", call: numberFunc(math.Round)},
+	tsl.SemverOp: {name: "semver", call: funcSemver},
+}
+
+// resolveFunc evaluates a function call node, functions of null values
+// evaluate to null.
+func (w *walker) resolveFunc(n tsl.Node, f function) (operand, error) {
+	arg, ok := n.Left.(tsl.Node)
+	if !ok {
+		return operand{}, tsl.UnexpectedLiteralError{Literal: n.Func}
+	}
+
+	var v operand
+	var err error
+	switch {
+	case arg.Func == tsl.IdentOp:
+		v, err = w.resolve(arg)
+	case isMath(arg):
+		v, err = w.resolveMath(arg)
+	default:
+		v = literalOperand(arg)
+	}
+	if err != nil || v.kind == nullKind {
+		return v, err
+	}
+
+	return f.call(w, v)
+}
+
+// funcLen returns the number of characters of a string, or the number of
+// elements of a list.
+func funcLen(w *walker, v operand) (operand, error) {
+	switch v.kind {
+	case stringKind:
+		return operand{kind: numberKind, f: float64(utf8.RuneCountInString(v.s))}, nil
+	case listKind:
+		switch a := v.a.(type) {
+		case []string:
+			return operand{kind: numberKind, f: float64(len(a))}, nil
+		case []float64:
+			return operand{kind: numberKind, f: float64(len(a))}, nil
+		case []interface{}:
+			return operand{kind: numberKind, f: float64(len(a))}, nil
+		}
+	}
+
+	return operand{}, tsl.UnexpectedLiteralError{ExpectedType: "string", Literal: v.value()}
+}
+
+// stringFunc returns a function of string values.
+func stringFunc(f func(string) string) func(w *walker, v operand) (operand, error) {
+	return func(w *walker, v operand) (operand, error) {
+		if v.kind != stringKind {
+			return operand{}, tsl.UnexpectedLiteralError{ExpectedType: "string", Literal: v.value()}
+		}
+
+		return operand{kind: stringKind, s: f(v.s)}, nil
+	}
+}
+
+// numberFunc returns a function of number values.
+func numberFunc(f func(float64) float64) func(w *walker, v operand) (operand, error) {
+	return func(w *walker, v operand) (operand, error) {
+		v, err := w.number(v)
+		if err != nil {
+			return v, err
+		}
+
+		return operand{kind: numberKind, f: f(v.f)}, nil
+	}
+}
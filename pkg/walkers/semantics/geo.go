@@ -0,0 +1,160 @@
+// Copyright 2018 Yaacov Zamir <kobi.zamir@gmail.com>
+// and other contributors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package semantics
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/yaacov/tree-search-language/pkg/tsl"
+)
+
+// earthRadius is the mean radius of the earth in meters.
+const earthRadius = 6371008.8
+
+// point is a geographic location in degrees.
+type point struct {
+	lat float64
+	lon float64
+}
+
+// isGeo checks if a node is a geo predicate.
+func isGeo(n tsl.Node) bool {
+	switch n.Func {
+	case tsl.WithinOp, tsl.NotWithinOp, tsl.WithinBoxOp, tsl.NotWithinBoxOp:
+		return true
+	}
+
+	return false
+}
+
+// geo evaluates a geo predicate node, like null comparisons, predicates on
+// missing locations are false.
+func (w *walker) geo(n tsl.Node) (bool, error) {
+	p, ok, err := w.location(n.Left.(tsl.Node))
+	if err != nil || !ok {
+		return false, err
+	}
+
+	// The list literals are checked by the parser.
+	var v [4]float64
+	for i, l := range n.Right.(tsl.Node).Right.([]tsl.Node) {
+		if i < len(v) {
+			v[i] = l.Left.(float64)
+		}
+	}
+
+	switch n.Func {
+	case tsl.WithinOp, tsl.NotWithinOp:
+		b := haversine(p, point{lat: v[1], lon: v[2]}) <= v[0]
+		return b == (n.Func == tsl.WithinOp), nil
+	case tsl.WithinBoxOp, tsl.NotWithinBoxOp:
+		b := inBox(p, point{lat: v[0], lon: v[1]}, point{lat: v[2], lon: v[3]})
+		return b == (n.Func == tsl.WithinBoxOp), nil
+	}
+
+	return false, tsl.UnexpectedLiteralError{Literal: n.Func}
+}
+
+// location evaluates an identifier node into a point, the identifier value
+// is a map with `lat` and `lon` keys, or a GeoJSON point, if the identifier
+// is missing, the `<identifier>.lat` and `<identifier>.lon` fields are used.
+func (w *walker) location(l tsl.Node) (point, bool, error) {
+	if l.Func != tsl.IdentOp {
+		return point{}, false, tsl.UnexpectedLiteralError{ExpectedType: "location", Literal: l.Func}
+	}
+
+	name := l.Left.(string)
+	if key, ok := w.aliases[name]; ok {
+		name = key
+	}
+
+	if v, ok := w.eval(name); ok && v != nil {
+		m, ok := v.(map[string]interface{})
+		if !ok {
+			return point{}, false, tsl.UnexpectedLiteralError{ExpectedType: "location", Literal: fmt.Sprintf("%s[%v]", name, v)}
+		}
+		p, err := w.point(name, m)
+		return p, err == nil, err
+	}
+
+	// Look for latitude and longitude fields.
+	lat, ok := w.eval(name + ".lat")
+	lon, lok := w.eval(name + ".lon")
+	if !lok {
+		lon, lok = w.eval(name + ".lng")
+	}
+	if !ok || !lok || lat == nil || lon == nil {
+		if w.missing != nil && w.missing.Error {
+			return point{}, false, MissingFieldError{Field: l.Left.(string)}
+		}
+		return point{}, false, nil
+	}
+
+	p, err := w.point(name, map[string]interface{}{"lat": lat, "lon": lon})
+	return p, err == nil, err
+}
+
+// point converts a location map into a point, GeoJSON points hold the
+// longitude first.
+func (w *walker) point(name string, m map[string]interface{}) (point, error) {
+	lat, lon := m["lat"], m["lon"]
+	if lon == nil {
+		lon = m["lng"]
+	}
+	if c, ok := m["coordinates"].([]interface{}); ok && m["type"] == "Point" && len(c) == 2 {
+		lat, lon = c[1], c[0]
+	}
+
+	var f [2]float64
+	for i, v := range []interface{}{lat, lon} {
+		o, ok := valueOperand(v)
+		if ok {
+			o, _ = w.number(o)
+		}
+		if !ok || o.kind != numberKind {
+			return point{}, tsl.UnexpectedLiteralError{ExpectedType: "location", Literal: fmt.Sprintf("%s[%v]", name, m)}
+		}
+		f[i] = o.f
+	}
+
+	return point{lat: f[0], lon: f[1]}, nil
+}
+
+// haversine returns the great circle distance between two points in meters.
+func haversine(a, b point) float64 {
+	rad := math.Pi / 180
+	dlat := (b.lat - a.lat) * rad
+	dlon := (b.lon - a.lon) * rad
+
+	h := math.Sin(dlat/2)*math.Sin(dlat/2) +
+		math.Cos(a.lat*rad)*math.Cos(b.lat*rad)*math.Sin(dlon/2)*math.Sin(dlon/2)
+
+	return 2 * earthRadius * math.Asin(math.Min(1, math.Sqrt(h)))
+}
+
+// inBox checks if a point is inside a bounding box, a box with a west
+// longitude larger than the east longitude crosses the antimeridian.
+func inBox(p, sw, ne point) bool {
+	if p.lat < sw.lat || p.lat > ne.lat {
+		return false
+	}
+	if sw.lon <= ne.lon {
+		return p.lon >= sw.lon && p.lon <= ne.lon
+	}
+
+	return p.lon >= sw.lon || p.lon <= ne.lon
+}
@@ -0,0 +1,220 @@
+// Copyright 2018 Yaacov Zamir <kobi.zamir@gmail.com>
+// and other contributors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package semantics
+
+import (
+	"strings"
+
+	"github.com/yaacov/tree-search-language/pkg/tsl"
+)
+
+// Evaluation holds the results of evaluating a tree over a document, and can
+// update them when document fields change, re-evaluating only the predicates
+// using the changed fields.
+//
+// Example:
+//  	// Evaluate the tree over a live object.
+//  	e, err := semantics.Evaluate(tree, evalFactory(object))
+//
+//  	// The object status changed, only predicates using status are evaluated.
+//  	object["status"] = "done"
+//  	compliance, err := e.Update(evalFactory(object), "status")
+//
+type Evaluation struct {
+	tree tsl.Node
+	root *result
+}
+
+// result is the evaluation result of a node.
+type result struct {
+	b      bool
+	fields map[string]bool // the fields used by the node.
+
+	left, right *result // the results of logical node operands.
+}
+
+// Evaluate evaluates a tree over a document, and returns an evaluation that
+// can be updated when the document changes.
+func Evaluate(n tsl.Node, eval EvalFunc) (*Evaluation, error) {
+	root, err := evaluate(n, eval)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Evaluation{tree: n, root: root}, nil
+}
+
+// Result returns the current evaluation result.
+func (e *Evaluation) Result() bool {
+	return e.root != nil && e.root.b
+}
+
+// Update re-evaluates the predicates using the changed fields, and returns
+// the new evaluation result. A changed field also changes its nested fields
+// and its parents, so changing "spec" re-evaluates predicates using
+// "spec.status", and predicates using wildcard fields are re-evaluated when a
+// matching field changes. If the update fails, the next update evaluates
+// the whole tree.
+func (e *Evaluation) Update(eval EvalFunc, changed ...string) (bool, error) {
+	var err error
+
+	// After a failed update, evaluate the whole tree.
+	if e.root == nil {
+		e.root, err = evaluate(e.tree, eval)
+		if err != nil {
+			return false, err
+		}
+		return e.root.b, nil
+	}
+
+	fields := make(map[string]bool, len(changed))
+	for _, f := range changed {
+		fields[f] = true
+	}
+
+	if err = update(e.tree, e.root, eval, fields); err != nil {
+		e.root = nil
+		return false, err
+	}
+
+	return e.root.b, nil
+}
+
+// evaluate evaluates a node, keeping the results of logical node operands.
+func evaluate(n tsl.Node, eval EvalFunc) (*result, error) {
+	switch n.Func {
+	case tsl.AndOp, tsl.OrOp:
+		l, err := evaluate(n.Left.(tsl.Node), eval)
+		if err != nil {
+			return nil, err
+		}
+		r, err := evaluate(n.Right.(tsl.Node), eval)
+		if err != nil {
+			return nil, err
+		}
+
+		// Collect the fields of both operands.
+		fields := make(map[string]bool, len(l.fields)+len(r.fields))
+		for f := range l.fields {
+			fields[f] = true
+		}
+		for f := range r.fields {
+			fields[f] = true
+		}
+
+		res := &result{fields: fields, left: l, right: r}
+		res.b = combine(n.Func, l.b, r.b)
+		return res, nil
+	}
+
+	// Evaluate predicates.
+	b, err := Walk(n, eval)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &result{b: b, fields: map[string]bool{}}
+	collectFields(n, res.fields)
+	return res, nil
+}
+
+// collectFields collects the identifiers used by a node.
+func collectFields(n tsl.Node, fields map[string]bool) {
+	if n.Func == tsl.IdentOp {
+		fields[n.Left.(string)] = true
+		return
+	}
+
+	if l, ok := n.Left.(tsl.Node); ok {
+		collectFields(l, fields)
+	}
+	if r, ok := n.Right.(tsl.Node); ok {
+		collectFields(r, fields)
+	}
+}
+
+// update re-evaluates the predicates of a node using changed fields.
+func update(n tsl.Node, res *result, eval EvalFunc, changed map[string]bool) error {
+	// Check if the node uses a changed field.
+	if !intersects(res.fields, changed) {
+		return nil
+	}
+
+	switch n.Func {
+	case tsl.AndOp, tsl.OrOp:
+		if err := update(n.Left.(tsl.Node), res.left, eval, changed); err != nil {
+			return err
+		}
+		if err := update(n.Right.(tsl.Node), res.right, eval, changed); err != nil {
+			return err
+		}
+
+		res.b = combine(n.Func, res.left.b, res.right.b)
+		return nil
+	}
+
+	b, err := Walk(n, eval)
+	if err != nil {
+		return err
+	}
+
+	res.b = b
+	return nil
+}
+
+// combine combines the results of logical node operands.
+func combine(op string, left, right bool) bool {
+	if op == tsl.AndOp {
+		return left && right
+	}
+
+	return left || right
+}
+
+// intersects checks if two field sets have related fields.
+func intersects(a, b map[string]bool) bool {
+	for f := range a {
+		if b[f] {
+			return true
+		}
+	}
+
+	for f := range a {
+		for g := range b {
+			if related(f, g) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// related checks if two dot separated fields are the same field, or if one of
+// them is a parent of the other, e.g. "spec" and "spec.status". A `*` part
+// of a wildcard field matches any part.
+func related(a, b string) bool {
+	pa := strings.Split(a, ".")
+	pb := strings.Split(b, ".")
+
+	for i := 0; i < len(pa) && i < len(pb); i++ {
+		if pa[i] != pb[i] && pa[i] != "*" && pb[i] != "*" {
+			return false
+		}
+	}
+
+	return true
+}
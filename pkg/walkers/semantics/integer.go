@@ -0,0 +1,149 @@
+// Copyright 2018 Yaacov Zamir <kobi.zamir@gmail.com>
+// and other contributors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package semantics
+
+import (
+	"github.com/yaacov/tree-search-language/pkg/tsl"
+)
+
+// maxExactFloat is the largest integer float64 numbers hold exactly.
+const maxExactFloat = 1 << 53
+
+// integer is an exact 64 bit integer, holding int64 and uint64 values.
+type integer struct {
+	neg bool   // the value is negative.
+	abs uint64 // the absolute value.
+}
+
+// intInteger converts an int64 value into an integer.
+func intInteger(v int64) integer {
+	if v < 0 {
+		return integer{neg: true, abs: uint64(-(v + 1)) + 1}
+	}
+
+	return integer{abs: uint64(v)}
+}
+
+// cmp compares two integers, and returns -1, 0 or +1.
+func (a integer) cmp(b integer) int {
+	switch {
+	case a.neg != b.neg:
+		if a.neg {
+			return -1
+		}
+		return 1
+	case a.abs == b.abs:
+		return 0
+	case (a.abs < b.abs) != a.neg:
+		return -1
+	}
+
+	return 1
+}
+
+// float returns the nearest float64 value of an integer.
+func (a integer) float() float64 {
+	if a.neg {
+		return -float64(a.abs)
+	}
+
+	return float64(a.abs)
+}
+
+// intOperand converts an int64 value into an operand, values float64 can not
+// hold exactly are integer operands.
+func intOperand(v int64) operand {
+	if v < -maxExactFloat || v > maxExactFloat {
+		return operand{kind: intKind, n: intInteger(v)}
+	}
+
+	return operand{kind: numberKind, f: float64(v)}
+}
+
+// uintOperand converts a uint64 value into an operand, values float64 can not
+// hold exactly are integer operands.
+func uintOperand(v uint64) operand {
+	if v > maxExactFloat {
+		return operand{kind: intKind, n: integer{abs: v}}
+	}
+
+	return operand{kind: numberKind, f: float64(v)}
+}
+
+// literalInteger returns the exact integer of a number literal node.
+func literalInteger(r tsl.Node) (integer, bool) {
+	switch v, _ := tsl.Integer(r); v := v.(type) {
+	case int64:
+		return intInteger(v), true
+	case uint64:
+		return integer{abs: v}, true
+	}
+
+	return integer{}, false
+}
+
+// isExactLiteral checks if a number literal node holds an exact integer
+// float64 can not hold.
+func isExactLiteral(r tsl.Node) bool {
+	switch r.Right.(type) {
+	case int64, uint64:
+		return true
+	}
+
+	return false
+}
+
+func handleIntegerOp(op string, left integer, r tsl.Node) (bool, error) {
+	if right, ok := literalInteger(r); ok {
+		return handleNumberOp(op, float64(left.cmp(right)), 0)
+	}
+
+	// Fractional literals are smaller than 2^53, so comparing them to the
+	// nearest float64 value of a larger integer is exact.
+	return handleNumberOp(op, left.float(), r.Left.(float64))
+}
+
+func handleIntegerArrayOp(op string, left integer, right []tsl.Node) (bool, error) {
+	// Check the list literals are numbers.
+	for _, node := range right {
+		if _, ok := node.Left.(float64); !ok {
+			return false, tsl.UnexpectedLiteralError{ExpectedType: "number", Literal: node.Left}
+		}
+	}
+
+	switch op {
+	case tsl.BetweenOp, tsl.NotBetweenOp:
+		begin, err := handleIntegerOp(tsl.GteOp, left, right[0])
+		if err != nil {
+			return false, err
+		}
+		end, err := handleIntegerOp(tsl.LtOp, left, right[1])
+		if err != nil {
+			return false, err
+		}
+		return (begin && end) == (op == tsl.BetweenOp), nil
+	case tsl.InOp, tsl.NotInOp:
+		found := false
+		for _, node := range right {
+			if found, _ = handleIntegerOp(tsl.EqOp, left, node); found {
+				break
+			}
+		}
+		return handleSetOp(op, found)
+	}
+
+	return false, tsl.UnexpectedLiteralError{Literal: op}
+}
@@ -0,0 +1,237 @@
+// Copyright 2018 Yaacov Zamir <kobi.zamir@gmail.com>
+// and other contributors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package semantics
+
+import (
+	"bytes"
+	"encoding/json"
+	"strconv"
+	"strings"
+
+	"github.com/yaacov/tree-search-language/pkg/tsl"
+)
+
+// WalkJSON travel the TSL tree like Walk, evaluating a raw JSON object
+// document using JSONEval.
+//
+// Example:
+//  	// If our tsl tree represents the tsl phrase "spec.pages > 10"
+//  	// we will get the boolean value `true` for our document.
+//  	data := []byte(`{"title": "A good book", "spec": {"pages": 14}}`)
+//  	compliance, err = semantics.WalkJSON(tree, data)
+//
+func WalkJSON(n tsl.Node, data []byte) (bool, error) {
+	return Walk(n, JSONEval(data))
+}
+
+// JSONEval creates an evaluation function for a raw JSON object document.
+//
+// The document is not decoded, each identifier value is extracted from the
+// raw bytes when it is evaluated, skipping the members that are not on its
+// path, so filtering large documents on a few fields is cheap. Use it with a
+// compiled tree for high-throughput filtering:
+//
+//  	match, err := semantics.Compile(tree)
+//  	...
+//  	ok, err := match(semantics.JSONEval(line))
+//
+// Dot separated identifiers are paths into nested objects, like the Nested
+// lookup, and identifiers with `*` wildcard parts are not supported. Integer
+// numbers evaluate to int64 values when they fit, other numbers to float64
+// values, and arrays and objects are decoded like encoding/json. Malformed
+// documents are not validated, values that can not be extracted are missing.
+func JSONEval(data []byte) EvalFunc {
+	return func(k string) (interface{}, bool) {
+		raw, ok := jsonLookup(data, k)
+		if !ok {
+			return nil, false
+		}
+
+		return jsonValue(raw)
+	}
+}
+
+// jsonLookup returns the raw value of a dot separated path into nested objects.
+func jsonLookup(data []byte, k string) ([]byte, bool) {
+	for {
+		name, rest, nested := k, "", false
+		if i := strings.IndexByte(k, '.'); i >= 0 {
+			name, rest, nested = k[:i], k[i+1:], true
+		}
+
+		var ok bool
+		if data, ok = jsonMember(data, name); !ok || !nested {
+			return data, ok
+		}
+		k = rest
+	}
+}
+
+// jsonMember returns the raw value of an object member.
+func jsonMember(data []byte, name string) ([]byte, bool) {
+	i := jsonSpace(data, 0)
+	if i >= len(data) || data[i] != '{' {
+		return nil, false
+	}
+
+	for i++; ; i++ {
+		// Read the member key.
+		i = jsonSpace(data, i)
+		if i >= len(data) || data[i] != '"' {
+			return nil, false
+		}
+		end := jsonString(data, i)
+		if end < 0 {
+			return nil, false
+		}
+		key := data[i:end]
+
+		i = jsonSpace(data, end)
+		if i >= len(data) || data[i] != ':' {
+			return nil, false
+		}
+
+		// Read the member value.
+		i = jsonSpace(data, i+1)
+		end = jsonSkip(data, i)
+		if end < 0 {
+			return nil, false
+		}
+		if jsonKeyEqual(key, name) {
+			return data[i:end], true
+		}
+
+		i = jsonSpace(data, end)
+		if i >= len(data) || data[i] != ',' {
+			return nil, false
+		}
+	}
+}
+
+// jsonKeyEqual checks if a quoted object key equals a name, keys with escape
+// sequences are unquoted before comparing.
+func jsonKeyEqual(key []byte, name string) bool {
+	if bytes.IndexByte(key, '\\') < 0 {
+		return string(key[1:len(key)-1]) == name
+	}
+
+	var s string
+	return json.Unmarshal(key, &s) == nil && s == name
+}
+
+// jsonSpace returns the index of the first non white space byte from i.
+func jsonSpace(data []byte, i int) int {
+	for i < len(data) {
+		switch data[i] {
+		case ' ', '\t', '\r', '\n':
+			i++
+		default:
+			return i
+		}
+	}
+
+	return i
+}
+
+// jsonString returns the index after the end of the string starting at i,
+// or -1 if the string is not terminated.
+func jsonString(data []byte, i int) int {
+	for i++; i < len(data); i++ {
+		switch data[i] {
+		case '\\':
+			i++
+		case '"':
+			return i + 1
+		}
+	}
+
+	return -1
+}
+
+// jsonSkip returns the index after the end of the value starting at i, or -1
+// if there is no value.
+func jsonSkip(data []byte, i int) int {
+	if i >= len(data) {
+		return -1
+	}
+
+	switch data[i] {
+	case '"':
+		return jsonString(data, i)
+	case '{', '[':
+		// Skip to the matching closing bracket, ignoring brackets in strings.
+		depth := 0
+		for i < len(data) {
+			switch data[i] {
+			case '"':
+				if i = jsonString(data, i); i < 0 {
+					return -1
+				}
+				continue
+			case '{', '[':
+				depth++
+			case '}', ']':
+				if depth--; depth == 0 {
+					return i + 1
+				}
+			}
+			i++
+		}
+		return -1
+	}
+
+	// Numbers, booleans and null end at a delimiter.
+	start := i
+	for i < len(data) && strings.IndexByte(",}] \t\r\n", data[i]) < 0 {
+		i++
+	}
+	if i == start {
+		return -1
+	}
+
+	return i
+}
+
+// jsonValue decodes a raw JSON value.
+func jsonValue(raw []byte) (interface{}, bool) {
+	switch raw[0] {
+	case '"':
+		if bytes.IndexByte(raw, '\\') < 0 {
+			return string(raw[1 : len(raw)-1]), true
+		}
+	case 'n':
+		return nil, string(raw) == "null"
+	case 't':
+		return true, string(raw) == "true"
+	case 'f':
+		return false, string(raw) == "false"
+	case '{', '[':
+	default:
+		if i, err := strconv.ParseInt(string(raw), 10, 64); err == nil {
+			return i, true
+		}
+		f, err := strconv.ParseFloat(string(raw), 64)
+		return f, err == nil
+	}
+
+	// Escaped strings, arrays and objects are decoded.
+	var v interface{}
+	if err := json.Unmarshal(raw, &v); err != nil {
+		return nil, false
+	}
+
+	return v, true
+}
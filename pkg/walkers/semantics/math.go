@@ -0,0 +1,152 @@
+// Copyright 2018 Yaacov Zamir <kobi.zamir@gmail.com>
+// and other contributors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package semantics
+
+import (
+	"math"
+	"strconv"
+
+	"github.com/yaacov/tree-search-language/pkg/tsl"
+)
+
+// mathOps are the infix operators of math nodes.
+var mathOps = map[string]string{
+	tsl.AddOp:      "+",
+	tsl.SubtractOp: "-",
+	tsl.MultiplyOp: "*",
+	tsl.DivideOp:   "/",
+	tsl.ModuloOp:   "%",
+}
+
+// isMath checks if a node is a math expression, or a function call.
+func isMath(n tsl.Node) bool {
+	if _, ok := functions[n.Func]; ok {
+		return true
+	}
+
+	_, ok := mathOps[n.Func]
+	return ok
+}
+
+// resolveMath evaluates a math expression into a number operand.
+//
+// Expressions using null or missing fields, and divisions by zero evaluate to
+// null, so comparing them is false. Function calls evaluate to the type of
+// the function result, for example `lower(author)` is a string.
+func (w *walker) resolveMath(n tsl.Node) (operand, error) {
+	if f, ok := functions[n.Func]; ok {
+		return w.resolveFunc(n, f)
+	}
+
+	switch n.Func {
+	case tsl.IdentOp:
+		return w.resolve(n)
+	case tsl.NumberOp, tsl.DurationOp:
+		return literalOperand(n), nil
+	}
+
+	l, lok := n.Left.(tsl.Node)
+	r, rok := n.Right.(tsl.Node)
+	if !isMath(n) || !lok || !rok {
+		return operand{}, tsl.UnexpectedLiteralError{Literal: n.Func}
+	}
+
+	a, err := w.mathOperand(l)
+	if err != nil {
+		return a, err
+	}
+	b, err := w.mathOperand(r)
+	if err != nil {
+		return b, err
+	}
+	if a.kind == nullKind || b.kind == nullKind {
+		return operand{kind: nullKind}, nil
+	}
+
+	v := operand{kind: numberKind}
+	switch n.Func {
+	case tsl.AddOp:
+		v.f = a.f + b.f
+	case tsl.SubtractOp:
+		v.f = a.f - b.f
+	case tsl.MultiplyOp:
+		v.f = a.f * b.f
+	case tsl.DivideOp, tsl.ModuloOp:
+		if b.f == 0 {
+			return operand{kind: nullKind}, nil
+		}
+		if n.Func == tsl.DivideOp {
+			v.f = a.f / b.f
+		} else {
+			v.f = math.Mod(a.f, b.f)
+		}
+	}
+
+	return v, nil
+}
+
+// mathOperand evaluates a side of a math expression into a number operand.
+func (w *walker) mathOperand(n tsl.Node) (operand, error) {
+	v, err := w.resolveMath(n)
+	if err != nil {
+		return v, err
+	}
+
+	return w.number(v)
+}
+
+// number converts an operand into a number operand, math expressions are
+// evaluated using float64 numbers.
+func (w *walker) number(v operand) (operand, error) {
+	v = w.coercion.number(v)
+	switch v.kind {
+	case numberKind, nullKind:
+		return v, nil
+	case decimalKind:
+		f, _ := v.d.Float64()
+		return operand{kind: numberKind, f: f}, nil
+	case intKind:
+		return operand{kind: numberKind, f: v.n.float()}, nil
+	}
+
+	return v, tsl.UnexpectedLiteralError{ExpectedType: "number", Literal: v.value()}
+}
+
+// mathString returns the infix phrase of a math expression, used as the
+// identifier of matches.
+func mathString(n tsl.Node) string {
+	switch n.Func {
+	case tsl.IdentOp:
+		return n.Left.(string)
+	case tsl.NumberOp, tsl.DurationOp:
+		return strconv.FormatFloat(n.Left.(float64), 'g', -1, 64)
+	}
+	if f, ok := functions[n.Func]; ok {
+		return f.name + "(" + mathString(n.Left.(tsl.Node)) + ")"
+	}
+
+	// Add parentheses to nested expressions.
+	sides := [2]string{}
+	for i, side := range []interface{}{n.Left, n.Right} {
+		s := mathString(side.(tsl.Node))
+		if _, ok := mathOps[side.(tsl.Node).Func]; ok {
+			s = "(" + s + ")"
+		}
+		sides[i] = s
+	}
+
+	return sides[0] + " " + mathOps[n.Func] + " " + sides[1]
+}
@@ -0,0 +1,64 @@
+// Copyright 2018 Yaacov Zamir <kobi.zamir@gmail.com>
+// and other contributors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package semantics
+
+import (
+	"fmt"
+
+	"github.com/yaacov/tree-search-language/pkg/tsl"
+)
+
+// MissingFieldError is returned by walks using the Missing{Error: true}
+// policy, when a tree uses a field that is missing from the document.
+type MissingFieldError struct {
+	Field string
+}
+
+func (e MissingFieldError) Error() string {
+	return fmt.Sprintf("missing field: %s", e.Field)
+}
+
+// Missing is a policy for evaluating identifiers of fields missing from the
+// document, a field is missing when the evaluation function does not find it.
+//
+// The zero value evaluates missing fields to null, like Walk.
+type Missing struct {
+	// Error fails the walk with a MissingFieldError naming the field.
+	Error bool
+
+	// Default is the value of missing fields, a nil Default is null.
+	Default interface{}
+}
+
+// operand evaluates an identifier node into an operand, using the policy for
+// missing fields.
+func (m *Missing) operand(l tsl.Node, eval EvalFunc) (operand, error) {
+	missing := false
+
+	v, err := identOperand(l, func(k string) (interface{}, bool) {
+		v, ok := eval(k)
+		if !ok {
+			missing = true
+			return m.Default, true
+		}
+		return v, true
+	})
+	if missing && m.Error {
+		return operand{}, MissingFieldError{Field: l.Left.(string)}
+	}
+
+	return v, err
+}
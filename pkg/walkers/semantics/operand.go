@@ -0,0 +1,176 @@
+// Copyright 2018 Yaacov Zamir <kobi.zamir@gmail.com>
+// and other contributors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package semantics
+
+import (
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/yaacov/tree-search-language/pkg/tsl"
+)
+
+// operandKind is the kind of value an operand holds.
+type operandKind int
+
+// Operand kinds.
+const (
+	nullKind    operandKind = iota // a null or missing value.
+	stringKind                     // a string value.
+	numberKind                     // a number value.
+	timeKind                       // a date value.
+	boolKind                       // a boolean value.
+	decimalKind                    // an arbitrary-precision number value.
+	intKind                        // an integer value float64 can not hold exactly.
+	listKind                       // a list of values, for example []string.
+	versionKind                    // a semantic version string value.
+	otherKind                      // a non literal node, for example a math expression.
+)
+
+// operand is an evaluated value, it is passed by value on the evaluation hot
+// path, avoiding boxing values in tsl.Node interfaces and type assertions.
+type operand struct {
+	kind operandKind
+	s    string
+	f    float64
+	t    time.Time
+	b    bool
+	d    *big.Rat
+	n    integer
+	a    interface{}
+}
+
+// value returns the operand value, as would be found in a tsl.Node literal.
+func (o operand) value() interface{} {
+	switch o.kind {
+	case stringKind, versionKind:
+		return o.s
+	case numberKind:
+		return o.f
+	case timeKind:
+		return o.t
+	case boolKind:
+		return o.b
+	case decimalKind:
+		return o.d
+	case intKind:
+		if o.n.neg {
+			return -int64(o.n.abs-1) - 1
+		}
+		return o.n.abs
+	case listKind:
+		return o.a
+	}
+
+	return nil
+}
+
+// identOperand evaluates an identifier node into an operand.
+func identOperand(l tsl.Node, eval EvalFunc) (operand, error) {
+	_v, _ := eval(l.Left.(string))
+	switch v := _v.(type) {
+	case []string, []float64, []interface{}:
+		return operand{kind: listKind, a: v}, nil
+	}
+
+	if o, ok := valueOperand(_v); ok {
+		return o, nil
+	}
+
+	return operand{}, tsl.UnexpectedLiteralError{Literal: fmt.Sprintf("%s[%v]", l.Left.(string), _v)}
+}
+
+// valueOperand converts a document value into an operand.
+func valueOperand(_v interface{}) (operand, bool) {
+	switch v := _v.(type) {
+	case string:
+		return operand{kind: stringKind, s: v}, true
+	case nil:
+		return operand{kind: nullKind}, true
+	case time.Time:
+		return operand{kind: timeKind, t: v}, true
+	case time.Duration:
+		return operand{kind: numberKind, f: v.Seconds()}, true
+	case bool:
+		return operand{kind: boolKind, b: v}, true
+	case float32:
+		return operand{kind: numberKind, f: float64(v)}, true
+	case float64:
+		return operand{kind: numberKind, f: v}, true
+	case int32:
+		return operand{kind: numberKind, f: float64(v)}, true
+	case int64:
+		return intOperand(v), true
+	case uint32:
+		return operand{kind: numberKind, f: float64(v)}, true
+	case uint64:
+		return uintOperand(v), true
+	case int:
+		return intOperand(int64(v)), true
+	case uint:
+		return uintOperand(uint64(v)), true
+	}
+
+	return decimalOperand(_v)
+}
+
+// listLen returns the number of elements of a list operand.
+func listLen(o operand) int {
+	switch a := o.a.(type) {
+	case []string:
+		return len(a)
+	case []float64:
+		return len(a)
+	case []interface{}:
+		return len(a)
+	}
+
+	return 0
+}
+
+// listElement returns the operand of the i-th element of a list operand,
+// lists of lists are not supported.
+func listElement(o operand, i int) (operand, error) {
+	switch a := o.a.(type) {
+	case []string:
+		return operand{kind: stringKind, s: a[i]}, nil
+	case []float64:
+		return operand{kind: numberKind, f: a[i]}, nil
+	case []interface{}:
+		if e, ok := valueOperand(a[i]); ok {
+			return e, nil
+		}
+		return operand{}, tsl.UnexpectedLiteralError{Literal: fmt.Sprintf("%v", a[i])}
+	}
+
+	return operand{}, tsl.UnexpectedLiteralError{Literal: fmt.Sprintf("%v", o.a)}
+}
+
+// literalOperand converts a literal node into an operand.
+func literalOperand(l tsl.Node) operand {
+	switch l.Func {
+	case tsl.StringOp, tsl.DateOp:
+		return operand{kind: stringKind, s: l.Left.(string)}
+	case tsl.NumberOp, tsl.DurationOp:
+		return operand{kind: numberKind, f: l.Left.(float64)}
+	case tsl.BooleanOp:
+		return operand{kind: boolKind, b: l.Left.(bool)}
+	case tsl.NullOp:
+		return operand{kind: nullKind}
+	}
+
+	return operand{kind: otherKind}
+}
@@ -0,0 +1,83 @@
+// Copyright 2018 Yaacov Zamir <kobi.zamir@gmail.com>
+// and other contributors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package semantics
+
+import (
+	"context"
+
+	"github.com/yaacov/tree-search-language/pkg/tsl"
+)
+
+// Options configure the evaluation of TSL trees, options can be combined, and
+// the zero value evaluates trees like Walk.
+type Options struct {
+	// Strict makes comparing a document value to a literal of another type,
+	// for example a string field to a number literal, return a
+	// TypeMismatchError naming the field and both types. Null and missing
+	// values are not type checked, comparing them is false.
+	Strict bool
+
+	// Coercion is the policy for comparing document values to literals of
+	// another type, so documents where numbers arrive as strings, like JSON
+	// or CSV sources, can match `pages > 50`.
+	Coercion Coercion
+
+	// Missing is the policy for fields missing from the document, so APIs
+	// can reject queries on unknown fields, or compare them to a default
+	// value, a nil policy evaluates them to null.
+	Missing *Missing
+
+	// Aliases maps the identifiers of the tree to document keys, so the
+	// field names users query can differ from the keys of the documents,
+	// identifiers that are not in the map are evaluated as is.
+	Aliases map[string]string
+
+	// All makes predicates on list fields true only if all the list elements
+	// match.
+	All bool
+
+	// Context stops the walk with the context error when it is done, so
+	// evaluation of very deep trees can be cancelled or bounded by a deadline.
+	Context context.Context
+
+	// Trace is called after each node of the tree is evaluated.
+	Trace TraceFunc
+}
+
+// Walk travel the TSL tree like Walk, using the options.
+//
+// Example:
+//  	o := semantics.Options{
+//  		Strict:  true,
+//  		Missing: &semantics.Missing{Error: true},
+//  		Aliases: map[string]string{"author": "metadata.creator"},
+//  		Context: ctx,
+//  	}
+//  	compliance, err = o.Walk(tree, eval)
+//
+func (o Options) Walk(n tsl.Node, eval EvalFunc) (bool, error) {
+	w := walker{
+		eval:     eval,
+		trace:    o.Trace,
+		all:      o.All,
+		strict:   o.Strict,
+		coercion: o.Coercion,
+		missing:  o.Missing,
+		aliases:  o.Aliases,
+		ctx:      o.Context,
+	}
+	return w.walk(n, nil)
+}
@@ -0,0 +1,184 @@
+// Copyright 2018 Yaacov Zamir <kobi.zamir@gmail.com>
+// and other contributors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package semantics
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/yaacov/tree-search-language/pkg/tsl"
+)
+
+// QuerySlice evaluates a TSL query over the documents of a slice, and returns
+// the indexes of the matching documents, sorted by the query sort keys, after
+// skipping the query offset, and up to the query limit.
+//
+// Documents are sorted in a stable way, null and missing values are sorted
+// after other values, or before them in descending order, like in SQL.
+//
+// Example:
+//  	q, err := tsl.ParseQuery("spec.pages > 10 order by spec.rating desc limit 5")
+//
+//  	// Get the top rated books matching the query.
+//  	indexes, err := semantics.QuerySlice(q, len(books), func(i int) semantics.EvalFunc {
+//  		return evalFactory(books[i])
+//  	})
+//
+func QuerySlice(q tsl.Query, size int, evalAt func(int) EvalFunc) ([]int, error) {
+	var indexes []int
+	var err error
+
+	if q.HasFilter() {
+		indexes, err = FilterSlice(q.Filter, size, evalAt)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		indexes = make([]int, size)
+		for i := range indexes {
+			indexes[i] = i
+		}
+	}
+
+	if len(q.OrderBy) > 0 {
+		if err = sortSlice(indexes, q.OrderBy, evalAt); err != nil {
+			return nil, err
+		}
+	}
+
+	// Skip offset documents, and return up to limit documents.
+	if q.Offset >= int64(len(indexes)) {
+		return []int{}, nil
+	}
+	indexes = indexes[q.Offset:]
+	if q.Limit > 0 && q.Limit < int64(len(indexes)) {
+		indexes = indexes[:q.Limit]
+	}
+
+	return indexes, nil
+}
+
+// sortSlice sorts document indexes by sort keys.
+func sortSlice(indexes []int, keys []tsl.OrderBy, evalAt func(int) EvalFunc) error {
+	// Evaluate the sort values of the documents.
+	values := make(map[int][]operand, len(indexes))
+	for _, i := range indexes {
+		eval := evalAt(i)
+
+		values[i] = make([]operand, len(keys))
+		for j, k := range keys {
+			v, _ := eval(k.Field)
+			o, ok := valueOperand(v)
+			if !ok {
+				return tsl.UnexpectedLiteralError{Literal: fmt.Sprintf("%s[%v]", k.Field, v)}
+			}
+			values[i][j] = o
+		}
+	}
+
+	sort.SliceStable(indexes, func(a, b int) bool {
+		for j, k := range keys {
+			c := compareOperands(values[indexes[a]][j], values[indexes[b]][j])
+			if k.Desc {
+				c = -c
+			}
+			if c != 0 {
+				return c < 0
+			}
+		}
+
+		return false
+	})
+
+	return nil
+}
+
+// compareOperands compares two sort values, null values are greater than other
+// values, and values of different kinds are ordered by kind.
+func compareOperands(a, b operand) int {
+	// Compare integers to other numbers as numbers.
+	switch {
+	case a.kind == intKind && b.kind == numberKind:
+		a = operand{kind: numberKind, f: a.n.float()}
+	case a.kind == numberKind && b.kind == intKind:
+		b = operand{kind: numberKind, f: b.n.float()}
+	}
+
+	switch {
+	case a.kind == b.kind:
+	case a.kind == nullKind:
+		return 1
+	case b.kind == nullKind:
+		return -1
+	case a.kind < b.kind:
+		return -1
+	default:
+		return 1
+	}
+
+	switch a.kind {
+	case stringKind:
+		return strings.Compare(a.s, b.s)
+	case numberKind:
+		switch {
+		case a.f < b.f:
+			return -1
+		case a.f > b.f:
+			return 1
+		}
+	case timeKind:
+		switch {
+		case a.t.Before(b.t):
+			return -1
+		case a.t.After(b.t):
+			return 1
+		}
+	case decimalKind:
+		return a.d.Cmp(b.d)
+	case intKind:
+		return a.n.cmp(b.n)
+	case boolKind:
+		switch {
+		case !a.b && b.b:
+			return -1
+		case a.b && !b.b:
+			return 1
+		}
+	}
+
+	return 0
+}
+
+// Project returns the values of the projected fields of a document, keyed by
+// field name, fields missing from the document are skipped.
+//
+// Example:
+//  	q, err := tsl.ParseQuery("fields title, spec.pages where spec.rating > 3")
+//
+//  	// Get the title and pages of a book.
+//  	values := semantics.Project(q.Fields, evalFactory(book))
+//
+func Project(fields []string, eval EvalFunc) map[string]interface{} {
+	values := make(map[string]interface{}, len(fields))
+	for _, f := range fields {
+		if v, ok := eval(f); ok {
+			values[f] = v
+		}
+	}
+
+	return values
+}
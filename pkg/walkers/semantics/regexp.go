@@ -0,0 +1,96 @@
+// Copyright 2018 Yaacov Zamir <kobi.zamir@gmail.com>
+// and other contributors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package semantics
+
+import (
+	"regexp"
+	"sync"
+
+	"github.com/yaacov/tree-search-language/pkg/tsl"
+)
+
+// regexpCacheSize is the maximum number of cached regular expressions, the
+// cache is reset when full.
+const regexpCacheSize = 256
+
+// regexpKey is the cache key of a regular expression or LIKE pattern.
+type regexpKey struct {
+	pattern string
+	like    bool
+	fold    bool
+}
+
+// regexpCache holds the compiled regular expressions of literal nodes that
+// were not prepared, for example nodes of trees built by hand, so walking a
+// tree over a dataset compiles each pattern once. It is safe for concurrent
+// use.
+type regexpCache struct {
+	mu sync.RWMutex
+	m  map[regexpKey]*regexp.Regexp
+}
+
+// regexps is the regular expression cache of the walkers.
+var regexps regexpCache
+
+// regexp returns the compiled regular expression of a string literal node.
+func (c *regexpCache) regexp(r tsl.Node) (*regexp.Regexp, error) {
+	return c.get(r, regexpKey{like: false}, func() (*regexp.Regexp, error) {
+		return tsl.Regexp(r)
+	})
+}
+
+// like returns the compiled LIKE pattern of a string literal node.
+func (c *regexpCache) like(r tsl.Node, fold bool) (*regexp.Regexp, error) {
+	return c.get(r, regexpKey{like: true, fold: fold}, func() (*regexp.Regexp, error) {
+		return tsl.LikeRegexp(r, fold)
+	})
+}
+
+// get returns a prepared or cached regular expression, or compiles and caches
+// a new one.
+func (c *regexpCache) get(r tsl.Node, key regexpKey, compile func() (*regexp.Regexp, error)) (*regexp.Regexp, error) {
+	// Check for a prepared node.
+	if re, ok := r.Right.(*regexp.Regexp); ok {
+		return re, nil
+	}
+
+	pattern, ok := r.Left.(string)
+	if !ok {
+		return compile()
+	}
+	key.pattern = pattern
+
+	c.mu.RLock()
+	re, ok := c.m[key]
+	c.mu.RUnlock()
+	if ok {
+		return re, nil
+	}
+
+	re, err := compile()
+	if err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	if c.m == nil || len(c.m) >= regexpCacheSize {
+		c.m = make(map[regexpKey]*regexp.Regexp)
+	}
+	c.m[key] = re
+	c.mu.Unlock()
+
+	return re, nil
+}
@@ -0,0 +1,208 @@
+// Copyright 2018 Yaacov Zamir <kobi.zamir@gmail.com>
+// and other contributors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package semantics
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/yaacov/tree-search-language/pkg/tsl"
+)
+
+// version is a parsed semantic version, build metadata is ignored.
+type version struct {
+	core [3]uint64 // major, minor and patch numbers.
+	pre  []string  // pre-release identifiers.
+}
+
+// parseVersion parses a semantic version, like `1.10.2` or `v2.0.0-rc.1+5`,
+// a leading `v` is allowed, and missing minor and patch numbers are zero.
+func parseVersion(s string) (version, bool) {
+	var v version
+
+	s = strings.TrimSpace(s)
+	if len(s) > 0 && (s[0] == 'v' || s[0] == 'V') {
+		s = s[1:]
+	}
+	if i := strings.IndexByte(s, '+'); i >= 0 {
+		s = s[:i]
+	}
+	if i := strings.IndexByte(s, '-'); i >= 0 {
+		v.pre = strings.Split(s[i+1:], ".")
+		for _, p := range v.pre {
+			if p == "" {
+				return v, false
+			}
+		}
+		s = s[:i]
+	}
+
+	parts := strings.Split(s, ".")
+	if len(parts) > len(v.core) {
+		return v, false
+	}
+	for i, p := range parts {
+		n, err := strconv.ParseUint(p, 10, 64)
+		if err != nil {
+			return v, false
+		}
+		v.core[i] = n
+	}
+
+	return v, true
+}
+
+// compareVersions compares versions by semver precedence, it returns -1, 0 or
+// 1 if a is lower, equal or higher than b.
+func compareVersions(a, b version) int {
+	for i := range a.core {
+		if c := compareUints(a.core[i], b.core[i]); c != 0 {
+			return c
+		}
+	}
+
+	// A pre-release has a lower precedence than the release.
+	switch {
+	case len(a.pre) == 0 && len(b.pre) == 0:
+		return 0
+	case len(a.pre) == 0:
+		return 1
+	case len(b.pre) == 0:
+		return -1
+	}
+
+	for i := 0; i < len(a.pre) && i < len(b.pre); i++ {
+		if c := comparePre(a.pre[i], b.pre[i]); c != 0 {
+			return c
+		}
+	}
+
+	return compareUints(uint64(len(a.pre)), uint64(len(b.pre)))
+}
+
+// comparePre compares pre-release identifiers, numeric identifiers are
+// compared numerically, and have a lower precedence than alphanumeric ones.
+func comparePre(a, b string) int {
+	an, aerr := strconv.ParseUint(a, 10, 64)
+	bn, berr := strconv.ParseUint(b, 10, 64)
+	switch {
+	case aerr == nil && berr == nil:
+		return compareUints(an, bn)
+	case aerr == nil:
+		return -1
+	case berr == nil:
+		return 1
+	}
+
+	return strings.Compare(a, b)
+}
+
+// compareUints returns -1, 0 or 1 if a is lower, equal or higher than b.
+func compareUints(a, b uint64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+
+	return 0
+}
+
+// funcSemver marks a string value as a semantic version, versions are
+// compared to string literals by semver precedence.
+func funcSemver(w *walker, v operand) (operand, error) {
+	if v.kind != stringKind {
+		return operand{}, tsl.UnexpectedLiteralError{ExpectedType: "string", Literal: v.value()}
+	}
+
+	ver, ok := parseVersion(v.s)
+	if !ok {
+		return operand{}, tsl.UnexpectedLiteralError{ExpectedType: "semver", Literal: v.s}
+	}
+
+	return operand{kind: versionKind, s: v.s, a: ver}, nil
+}
+
+// literalVersion parses a string literal node holding a semantic version.
+func literalVersion(r tsl.Node) (version, error) {
+	s, ok := r.Left.(string)
+	if r.Func != tsl.StringOp || !ok {
+		return version{}, tsl.UnexpectedLiteralError{ExpectedType: "semver", Literal: r.Left}
+	}
+
+	v, ok := parseVersion(s)
+	if !ok {
+		return version{}, tsl.UnexpectedLiteralError{ExpectedType: "semver", Literal: s}
+	}
+
+	return v, nil
+}
+
+func handleVersionOp(op string, left version, r tsl.Node) (bool, error) {
+	right, err := literalVersion(r)
+	if err != nil {
+		return false, err
+	}
+	c := compareVersions(left, right)
+
+	switch op {
+	case tsl.EqOp:
+		return c == 0, nil
+	case tsl.NotEqOp:
+		return c != 0, nil
+	case tsl.LtOp:
+		return c < 0, nil
+	case tsl.LteOp:
+		return c <= 0, nil
+	case tsl.GtOp:
+		return c > 0, nil
+	case tsl.GteOp:
+		return c >= 0, nil
+	}
+
+	return false, tsl.UnexpectedLiteralError{Literal: op}
+}
+
+func handleVersionArrayOp(op string, left version, right []tsl.Node) (bool, error) {
+	// Check the list literals are versions.
+	versions := make([]version, len(right))
+	for i, node := range right {
+		v, err := literalVersion(node)
+		if err != nil {
+			return false, err
+		}
+		versions[i] = v
+	}
+
+	switch op {
+	case tsl.InOp, tsl.NotInOp:
+		for _, v := range versions {
+			if compareVersions(left, v) == 0 {
+				return op == tsl.InOp, nil
+			}
+		}
+		return op == tsl.NotInOp, nil
+	case tsl.BetweenOp, tsl.NotBetweenOp:
+		if len(versions) != 2 {
+			return false, tsl.UnexpectedLiteralError{Literal: op}
+		}
+		b := compareVersions(left, versions[0]) >= 0 && compareVersions(left, versions[1]) <= 0
+		return b == (op == tsl.BetweenOp), nil
+	}
+
+	return false, tsl.UnexpectedLiteralError{Literal: op}
+}
@@ -0,0 +1,110 @@
+// Copyright 2018 Yaacov Zamir <kobi.zamir@gmail.com>
+// and other contributors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package semantics
+
+import (
+	"fmt"
+
+	"github.com/yaacov/tree-search-language/pkg/tsl"
+)
+
+// TypeMismatchError is returned by strict walks, see Options.Strict, when a
+// document value is compared to a literal of another type.
+type TypeMismatchError struct {
+	Field       string      // the field name, or math expression.
+	FieldType   string      // the type of the document value.
+	LiteralType string      // the type of the literal.
+	Literal     interface{} // the literal value.
+}
+
+func (e TypeMismatchError) Error() string {
+	return fmt.Sprintf("type mismatch: %s is a %s, compared to %s literal %v", e.Field, e.FieldType, e.LiteralType, e.Literal)
+}
+
+// kindNames are the type names of operand kinds.
+var kindNames = map[operandKind]string{
+	nullKind:    "null",
+	stringKind:  "string",
+	numberKind:  "number",
+	timeKind:    "date",
+	boolKind:    "boolean",
+	decimalKind: "decimal",
+	intKind:     "integer",
+	listKind:    "list",
+	versionKind: "version",
+	otherKind:   "expression",
+}
+
+// literalKinds maps literal operators to the operand kinds they compare to.
+var literalKinds = map[string][]operandKind{
+	tsl.StringOp:   {stringKind, versionKind},
+	tsl.DateOp:     {stringKind, timeKind},
+	tsl.NowOp:      {stringKind, timeKind},
+	tsl.NumberOp:   {numberKind, decimalKind, intKind},
+	tsl.DurationOp: {numberKind, decimalKind, intKind},
+	tsl.BooleanOp:  {boolKind},
+}
+
+// literalNames are the type names of literal operators.
+var literalNames = map[string]string{
+	tsl.StringOp:   "string",
+	tsl.DateOp:     "date",
+	tsl.NowOp:      "date",
+	tsl.NumberOp:   "number",
+	tsl.DurationOp: "duration",
+	tsl.BooleanOp:  "boolean",
+}
+
+// checkTypes checks that a predicate compares an operand to literals of the
+// same type.
+func checkTypes(n tsl.Node, l operand) error {
+	r, ok := n.Right.(tsl.Node)
+	if !ok || l.kind == nullKind {
+		return nil
+	}
+
+	literals := []tsl.Node{r}
+	if r.Func == tsl.ArrayOp {
+		literals = r.Right.([]tsl.Node)
+	}
+
+	for _, literal := range literals {
+		kinds, ok := literalKinds[literal.Func]
+		if !ok || hasKind(kinds, l.kind) {
+			continue
+		}
+
+		return TypeMismatchError{
+			Field:       mathString(n.Left.(tsl.Node)),
+			FieldType:   kindNames[l.kind],
+			LiteralType: literalNames[literal.Func],
+			Literal:     literal.Left,
+		}
+	}
+
+	return nil
+}
+
+// hasKind checks if a list of kinds includes a kind.
+func hasKind(kinds []operandKind, kind operandKind) bool {
+	for _, k := range kinds {
+		if k == kind {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,202 @@
+// Copyright 2018 Yaacov Zamir <kobi.zamir@gmail.com>
+// and other contributors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package semantics
+
+import (
+	"math/big"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/yaacov/tree-search-language/pkg/tsl"
+)
+
+// structFields caches the identifier names of struct types fields.
+var structFields sync.Map // map[reflect.Type]map[string][]int
+
+// WalkStruct travel the TSL tree like Walk, evaluating a Go struct, or a
+// pointer to one, using StructEval.
+//
+// Example:
+//  	type Spec struct {
+//  		Pages int `json:"pages"`
+//  	}
+//  	type Book struct {
+//  		Title string `tsl:"title"`
+//  		Spec  *Spec  `json:"spec"`
+//  	}
+//
+//  	// If our tsl tree represents the tsl phrase "spec.pages > 10"
+//  	// we will get the boolean value `true` for our book.
+//  	book := Book{Title: "A good book", Spec: &Spec{Pages: 14}}
+//  	compliance, err = semantics.WalkStruct(tree, book)
+//
+func WalkStruct(n tsl.Node, v interface{}) (bool, error) {
+	return Walk(n, StructEval(v))
+}
+
+// StructEval creates an evaluation function for a Go struct, or a pointer to
+// one.
+//
+// Dot separated identifiers are paths into nested structs, pointers to
+// structs, and maps with string keys. Struct fields are named by their `tsl`
+// struct tag, their `json` struct tag, or their Go field name, fields of
+// embedded structs are promoted like in Go, and unexported fields are not
+// accessible. Nil pointers evaluate to null, and identifiers with `*` wildcard
+// parts are not supported.
+func StructEval(v interface{}) EvalFunc {
+	root := reflect.ValueOf(v)
+
+	return func(k string) (interface{}, bool) {
+		v := root
+		for _, name := range strings.Split(k, ".") {
+			var ok bool
+			if v, ok = structStep(v, name); !ok {
+				return nil, false
+			}
+		}
+
+		return structValue(v), true
+	}
+}
+
+// structStep returns the field or map value of a struct or a map.
+func structStep(v reflect.Value, name string) (reflect.Value, bool) {
+	for v.Kind() == reflect.Interface || v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return v, false
+		}
+		v = v.Elem()
+	}
+
+	switch v.Kind() {
+	case reflect.Struct:
+		index, ok := fieldNames(v.Type())[name]
+		if !ok {
+			return v, false
+		}
+
+		// Embedded nil pointers have no fields.
+		f, err := v.FieldByIndexErr(index)
+		return f, err == nil
+	case reflect.Map:
+		if v.Type().Key().Kind() != reflect.String {
+			return v, false
+		}
+
+		f := v.MapIndex(reflect.ValueOf(name).Convert(v.Type().Key()))
+		return f, f.IsValid()
+	}
+
+	return v, false
+}
+
+// fieldNames returns the field indexes of a struct type, keyed by identifier
+// name, fields of embedded structs are promoted unless a shallower field has
+// the same name.
+func fieldNames(t reflect.Type) map[string][]int {
+	if names, ok := structFields.Load(t); ok {
+		return names.(map[string][]int)
+	}
+
+	names := map[string][]int{}
+	for _, f := range reflect.VisibleFields(t) {
+		if !f.IsExported() {
+			continue
+		}
+
+		name := tagName(f.Tag.Get("tsl"))
+		if name == "" {
+			name = tagName(f.Tag.Get("json"))
+		}
+		if name == "" {
+			name = f.Name
+		}
+		if name == "-" {
+			continue
+		}
+
+		if index, ok := names[name]; !ok || len(f.Index) < len(index) {
+			names[name] = f.Index
+		}
+	}
+
+	structFields.Store(t, names)
+	return names
+}
+
+// tagName returns the name part of a struct tag value, like `name,omitempty`.
+func tagName(tag string) string {
+	if i := strings.IndexByte(tag, ','); i >= 0 {
+		return tag[:i]
+	}
+
+	return tag
+}
+
+// structValue converts a field value into a value evaluation functions
+// return, numbers are converted into int64, uint64 or float64 values, and
+// named types into their underlying types.
+func structValue(v reflect.Value) interface{} {
+	for v.Kind() == reflect.Interface || v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return nil
+		}
+
+		// Keep pointers to arbitrary-precision numbers.
+		if v.Kind() == reflect.Ptr {
+			switch v.Interface().(type) {
+			case *big.Rat, *big.Int, *big.Float:
+				return v.Interface()
+			}
+		}
+		v = v.Elem()
+	}
+
+	if !v.CanInterface() {
+		return nil
+	}
+	switch i := v.Interface().(type) {
+	case time.Time, time.Duration, rational:
+		return i
+	}
+
+	switch v.Kind() {
+	case reflect.String:
+		return v.String()
+	case reflect.Bool:
+		return v.Bool()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return v.Int()
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return v.Uint()
+	case reflect.Float32, reflect.Float64:
+		return v.Float()
+	case reflect.Slice, reflect.Array:
+		if v.Kind() == reflect.Slice && v.IsNil() {
+			return nil
+		}
+
+		list := make([]interface{}, v.Len())
+		for i := range list {
+			list[i] = structValue(v.Index(i))
+		}
+		return list
+	}
+
+	return v.Interface()
+}
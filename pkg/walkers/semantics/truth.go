@@ -0,0 +1,138 @@
+// Copyright 2018 Yaacov Zamir <kobi.zamir@gmail.com>
+// and other contributors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package semantics
+
+import (
+	"github.com/yaacov/tree-search-language/pkg/tsl"
+)
+
+// Truth is a three-valued logic value.
+type Truth int
+
+// Three-valued logic values.
+const (
+	False   Truth = iota // the document does not match.
+	True                 // the document matches.
+	Unknown              // the match depends on null or missing values.
+)
+
+// String returns the SQL name of a truth value.
+func (t Truth) String() string {
+	switch t {
+	case True:
+		return "TRUE"
+	case False:
+		return "FALSE"
+	}
+
+	return "UNKNOWN"
+}
+
+// WalkTruth travel the TSL tree like Walk, using SQL three-valued logic.
+//
+// Comparing a null or missing field, or a math expression using one, is
+// UNKNOWN instead of false, and UNKNOWN values propagate through AND, OR and
+// NOT nodes like in SQL, for example `not (pages > 5)` is UNKNOWN for a
+// document without pages, where an SQL backend would not return the
+// document. The `is null`, `is true` and `is false` checks are never UNKNOWN.
+//
+// Example:
+//  	// Match documents like an SQL WHERE clause.
+//  	t, err := semantics.WalkTruth(tree, eval)
+//  	compliance := t == semantics.True
+//
+func WalkTruth(n tsl.Node, eval EvalFunc) (Truth, error) {
+	w := walker{eval: eval}
+	return w.truth(n)
+}
+
+// truth implements WalkTruth.
+func (w *walker) truth(n tsl.Node) (Truth, error) {
+	switch n.Func {
+	case tsl.AndOp, tsl.OrOp:
+		return w.truthLogicalOp(n)
+	case tsl.NotOp:
+		t, err := w.truth(n.Left.(tsl.Node))
+		switch t {
+		case True:
+			return False, err
+		case False:
+			return True, err
+		}
+		return t, err
+	case tsl.IsNilOp, tsl.IsNotNilOp, tsl.IsTrueOp, tsl.IsFalseOp, tsl.IsNotTrueOp, tsl.IsNotFalseOp:
+		// Null checks are never unknown.
+	case tsl.WithinOp, tsl.NotWithinOp, tsl.WithinBoxOp, tsl.NotWithinBoxOp:
+		_, ok, err := w.location(n.Left.(tsl.Node))
+		if err != nil || !ok {
+			return Unknown, err
+		}
+	default:
+		null, err := w.isNull(n.Left.(tsl.Node))
+		if err != nil || null {
+			return Unknown, err
+		}
+	}
+
+	b, err := w.walk(n, nil)
+	if err != nil || !b {
+		return False, err
+	}
+
+	return True, nil
+}
+
+// truthLogicalOp implements the three-valued AND and OR nodes, the left side
+// is evaluated first, and the right side only if the left side does not
+// decide the result.
+func (w *walker) truthLogicalOp(n tsl.Node) (Truth, error) {
+	// The value deciding the node result.
+	decisive := False
+	if n.Func == tsl.OrOp {
+		decisive = True
+	}
+
+	left, err := w.truth(n.Left.(tsl.Node))
+	if err != nil || left == decisive {
+		return left, err
+	}
+	right, err := w.truth(n.Right.(tsl.Node))
+	if err != nil || right == decisive {
+		return right, err
+	}
+
+	if left == Unknown || right == Unknown {
+		return Unknown, nil
+	}
+	return left, nil
+}
+
+// isNull checks if the left side of a predicate evaluates to null.
+func (w *walker) isNull(l tsl.Node) (bool, error) {
+	var v operand
+	var err error
+
+	switch {
+	case l.Func == tsl.IdentOp:
+		v, err = w.resolve(l)
+	case isMath(l):
+		v, err = w.resolveMath(l)
+	default:
+		v = literalOperand(l)
+	}
+
+	return v.kind == nullKind, err
+}
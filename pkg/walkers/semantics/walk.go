@@ -20,8 +20,11 @@
 package semantics
 
 import (
+	"context"
 	"fmt"
-	"regexp"
+	"math"
+	"strings"
+	"time"
 
 	"github.com/yaacov/tree-search-language/pkg/tsl"
 )
@@ -29,13 +32,36 @@ import (
 // EvalFunc is a key evaluation function type.
 type EvalFunc = func(string) (interface{}, bool)
 
+// TraceFunc is a node evaluation trace function type, it is called after each
+// node is evaluated, with the node, the evaluation result and the evaluation time.
+type TraceFunc = func(n tsl.Node, b bool, err error, d time.Duration)
+
+// Match describes one predicate that evaluated to `true` for a document.
+type Match struct {
+	Func  string      `json:"func"`
+	Ident string      `json:"ident"`
+	Value interface{} `json:"value"`
+	Args  interface{} `json:"args,omitempty"`
+}
+
 // Walk travel the TSL tree and implements search semantics.
 //
 // Users can call the Walk method to check if a document compiles to `true` or `false`
 // when applied to a tsl tree.
 //
+// Documents are accessed only through the evaluation function, it is called once
+// for each field the tree uses, so documents do not need to be copied into a map,
+// the evaluation function can read struct fields, database rows, or fetch values lazily.
+//
+// AND and OR nodes are evaluated left to right, and the right side is evaluated only
+// if the left side does not decide the result, so fields and errors of the right side
+// are not reached.
+//
+// Walk evaluates the tree using the zero Options, use Options.Walk for strict
+// type checks, coercion, missing field policies, aliases, cancellation and tracing.
+//
 // Example:
-//  	record :=  map[string]string {
+//  	record :=  map[string]interface{} {
 //  		"title":       "A good book",
 //  		"author":      "Joe",
 //  		"spec.pages":  14,
@@ -43,7 +69,7 @@ type EvalFunc = func(string) (interface{}, bool)
 //  	}
 //
 //  	// evalFactory creates an evaluation function for a data record.
-//  	func evalFactory(r map[string]string) semantics.EvalFunc {
+//  	func evalFactory(r map[string]interface{}) semantics.EvalFunc {
 //  		return func(k string) (interface{}, bool) {
 //  			v, ok := r[k]
 //  			return v, ok
@@ -61,135 +87,371 @@ type EvalFunc = func(string) (interface{}, bool)
 //  	eval :=  evalFactory(record)
 //  	compliance, err = semantics.Walk(tree, eval)
 //
+//  	// Evaluate a struct without copying it into a map.
+//  	eval = func(k string) (interface{}, bool) {
+//  		switch k {
+//  		case "title":
+//  			return book.Title, true
+//  		case "spec.pages":
+//  			return book.Pages, true
+//  		}
+//  		return nil, false
+//  	}
+//  	compliance, err = semantics.Walk(tree, eval)
+//
 func Walk(n tsl.Node, eval EvalFunc) (bool, error) {
-	l := n.Left.(tsl.Node)
+	return Options{}.Walk(n, eval)
+}
+
+// WalkMatches travel the TSL tree like Walk, and also reports the predicates
+// that made the document compile to `true`, and the document values involved.
+//
+// Example:
+//  	// If our tsl tree represents the tsl phrase "author = 'Joe' or spec.pages > 50"
+//  	// and our record is {"author": "Joe", "spec.pages": 14}, we will get
+//  	// one match: {Func: "$eq", Ident: "author", Value: "Joe", Args: "Joe"}.
+//  	compliance, matches, err = semantics.WalkMatches(tree, eval)
+//
+func WalkMatches(n tsl.Node, eval EvalFunc) (bool, []Match, error) {
+	return WalkMatchesTrace(n, eval, nil)
+}
+
+// WalkMatchesTrace travel the TSL tree like WalkMatches, and calls the trace
+// function after each node of the tree is evaluated, a nil trace function is
+// not called.
+func WalkMatchesTrace(n tsl.Node, eval EvalFunc, trace TraceFunc) (bool, []Match, error) {
+	matches := []Match{}
+
+	w := walker{eval: eval, trace: trace}
+	b, err := w.walk(n, &matches)
+	if err != nil || !b {
+		return b, nil, err
+	}
+
+	return b, matches, nil
+}
 
-	// Check for identifiers.
-	if l.Func == tsl.IdentOp {
-		newNode, err := handleIdent(n, eval)
+// WalkAll travel the TSL tree like Walk, but predicates on list fields, for
+// example a `[]string` field, are true only if all the list elements match.
+//
+// Walk and WalkMatches use any semantics, a predicate on a list field
+// is true if any of the list elements match, and WalkAll use all semantics, a
+// predicate on an empty list field is true.
+//
+// Example:
+//  	// If our tsl tree represents the tsl phrase "tags = 'urgent'"
+//  	// and our record is {"tags": []string{"urgent", "bug"}}, Walk will return
+//  	// `true` and WalkAll will return `false`.
+//  	compliance, err = semantics.WalkAll(tree, eval)
+//
+func WalkAll(n tsl.Node, eval EvalFunc) (bool, error) {
+	return Options{All: true}.Walk(n, eval)
+}
+
+// fieldCacheSize is the number of resolved fields cached in one tree walk.
+const fieldCacheSize = 8
+
+// field is a resolved document field.
+type field struct {
+	name string
+	v    operand
+}
+
+// walker holds the evaluation and trace functions of one tree walk.
+//
+// Fields referenced by more than one predicate are resolved from the document
+// once per walk, the first resolved fields are cached in a fixed size array,
+// so walks do not allocate.
+type walker struct {
+	eval     EvalFunc
+	trace    TraceFunc
+	all      bool
+	strict   bool
+	coercion Coercion
+	missing  *Missing
+	aliases  map[string]string
+	ctx      context.Context
+
+	fields  [fieldCacheSize]field
+	nfields int
+}
+
+// walk evaluates a node, if matches is not nil, matching predicates are collected.
+func (w *walker) walk(n tsl.Node, matches *[]Match) (bool, error) {
+	// Check for a cancelled walk.
+	if w.ctx != nil {
+		if err := w.ctx.Err(); err != nil {
+			return false, err
+		}
+	}
+
+	// If we do not trace, just evaluate the node.
+	if w.trace == nil {
+		return w.step(n, matches)
+	}
+
+	start := time.Now()
+	b, err := w.step(n, matches)
+	w.trace(n, b, err, time.Since(start))
+
+	return b, err
+}
+
+// step implements the node semantics.
+func (w *walker) step(n tsl.Node, matches *[]Match) (bool, error) {
+	// Check for geo predicates on locations.
+	if isGeo(n) {
+		return w.geo(n)
+	}
+
+	// Check for boolean literals, for example an optimized constant tree.
+	if b, ok := n.Left.(bool); ok && n.Func == tsl.BooleanOp {
+		return b, nil
+	}
+
+	l, ok := n.Left.(tsl.Node)
+	if !ok {
+		return false, tsl.UnexpectedLiteralError{Literal: n.Func}
+	}
+
+	// Check for identifiers and math expressions.
+	if l.Func == tsl.IdentOp || isMath(l) {
+		var v operand
+		var err error
+		if l.Func == tsl.IdentOp {
+			v, err = w.resolve(l)
+		} else {
+			v, err = w.resolveMath(l)
+		}
 		if err != nil {
 			return false, err
 		}
-		return Walk(newNode, eval)
+
+		var b bool
+		if v.kind == listKind {
+			b, err = w.compareList(n, v)
+		} else {
+			b, err = w.compareOperand(n, v)
+		}
+		if b && err == nil && matches != nil {
+			*matches = append(*matches, newMatch(n, v))
+		}
+		return b, err
 	}
 
 	// Implement tree semantics.
 	switch n.Func {
-	case tsl.EqOp, tsl.NotEqOp, tsl.LtOp, tsl.LteOp, tsl.GtOp, tsl.GteOp, tsl.RegexOp, tsl.NotRegexOp,
-		tsl.BetweenOp, tsl.NotBetweenOp, tsl.NotInOp, tsl.InOp:
-		r := n.Right.(tsl.Node)
+	case tsl.AndOp, tsl.OrOp:
+		return w.handleLogicalOp(n, matches)
+	}
 
-		switch l.Func {
-		case tsl.StringOp:
-			if r.Func == tsl.StringOp {
-				return handleStringOp(n, eval)
-			}
-			if r.Func == tsl.ArrayOp {
-				return handleStringArrayOp(n, eval)
-			}
-		case tsl.NumberOp:
-			if r.Func == tsl.NumberOp {
-				return handleNumberOp(n, eval)
-			}
-			if r.Func == tsl.ArrayOp {
-				return handleNumberArrayOp(n, eval)
-			}
-		case tsl.NullOp:
-			// Any comparison operation on a null element is false.
-			return false, nil
+	return compare(n, literalOperand(l))
+}
+
+// resolve evaluates an identifier node into an operand, using the walk field
+// cache.
+func (w *walker) resolve(l tsl.Node) (operand, error) {
+	name := l.Left.(string)
+
+	// Check for a cached field.
+	for i := 0; i < w.nfields; i++ {
+		if w.fields[i].name == name {
+			return w.fields[i].v, nil
 		}
+	}
 
-		return false, tsl.UnexpectedLiteralError{Literal: fmt.Sprintf("%v", r.Left)}
-	case tsl.IsNotNilOp:
-		return l.Func != tsl.NullOp, nil
-	case tsl.IsNilOp:
-		return l.Func == tsl.NullOp, nil
-	case tsl.AndOp, tsl.OrOp:
-		return handleLogicalOp(n, eval)
+	// Map the identifier to a document key.
+	if key, ok := w.aliases[name]; ok {
+		l.Left = key
 	}
 
-	return false, tsl.UnexpectedLiteralError{Literal: n.Func}
+	var v operand
+	var err error
+	if w.missing != nil {
+		v, err = w.missing.operand(l, w.eval)
+	} else {
+		v, err = identOperand(l, w.eval)
+	}
+	if err == nil && w.nfields < fieldCacheSize {
+		w.fields[w.nfields] = field{name: name, v: v}
+		w.nfields++
+	}
+
+	return v, err
 }
 
-func handleIdent(n tsl.Node, eval EvalFunc) (tsl.Node, error) {
-	l := n.Left.(tsl.Node)
+// newMatch creates a match from a predicate node, and the document value of
+// it's identifier or math expression.
+func newMatch(n tsl.Node, v operand) Match {
+	m := Match{
+		Func:  n.Func,
+		Ident: mathString(n.Left.(tsl.Node)),
+		Value: v.value(),
+	}
 
-	_v, _ := eval(l.Left.(string))
-	switch v := _v.(type) {
-	case string:
-		n.Left = tsl.Node{
-			Func: tsl.StringOp,
-			Left: v,
-		}
-	case nil:
-		n.Left = tsl.Node{
-			Func: tsl.NullOp,
-			Left: nil,
-		}
-	case bool:
-		val := "false"
-		if v {
-			val = "true"
-		}
-		n.Left = tsl.Node{
-			Func: tsl.StringOp,
-			Left: val,
-		}
-	case float32:
-		n.Left = tsl.Node{
-			Func: tsl.NumberOp,
-			Left: float64(v),
-		}
-	case float64:
-		n.Left = tsl.Node{
-			Func: tsl.NumberOp,
-			Left: v,
-		}
-	case int32:
-		n.Left = tsl.Node{
-			Func: tsl.NumberOp,
-			Left: float64(v),
-		}
-	case int64:
-		n.Left = tsl.Node{
-			Func: tsl.NumberOp,
-			Left: float64(v),
-		}
-	case uint32:
-		n.Left = tsl.Node{
-			Func: tsl.NumberOp,
-			Left: float64(v),
-		}
-	case uint64:
-		n.Left = tsl.Node{
-			Func: tsl.NumberOp,
-			Left: float64(v),
-		}
-	case int:
-		n.Left = tsl.Node{
-			Func: tsl.NumberOp,
-			Left: float64(v),
-		}
-	case uint:
-		n.Left = tsl.Node{
-			Func: tsl.NumberOp,
-			Left: float64(v),
+	// Collect the literal arguments of the predicate.
+	if r, ok := n.Right.(tsl.Node); ok {
+		if r.Func == tsl.ArrayOp {
+			args := []interface{}{}
+			for _, node := range r.Right.([]tsl.Node) {
+				args = append(args, node.Left)
+			}
+			m.Args = args
+		} else {
+			m.Args = r.Left
 		}
-	default:
-		return n, tsl.UnexpectedLiteralError{Literal: fmt.Sprintf("%s[%v]", l.Left.(string), v)}
 	}
 
-	return n, nil
+	return m
 }
 
-func handleStringOp(n tsl.Node, eval EvalFunc) (bool, error) {
-	l := n.Left.(tsl.Node)
+// compareList implements the semantics of a predicate node, with a list left
+// side operand, comparing each of the list elements.
+func (w *walker) compareList(n tsl.Node, l operand) (bool, error) {
+	// A list is not null, even if it is empty.
+	switch n.Func {
+	case tsl.IsNilOp, tsl.IsNotNilOp:
+		return compare(n, l)
+	}
+
+	for i := 0; i < listLen(l); i++ {
+		e, err := listElement(l, i)
+		if err != nil {
+			return false, err
+		}
+
+		b, err := w.compareOperand(n, e)
+		if err != nil {
+			return false, err
+		}
+
+		// Stop on the first element that decides the result.
+		if b != w.all {
+			return b, nil
+		}
+	}
+
+	return w.all, nil
+}
+
+// compareOperand compares an operand like compare, coercing the operand using
+// the walk coercion policy, and checking the operand and literal types in
+// strict walks.
+func (w *walker) compareOperand(n tsl.Node, l operand) (bool, error) {
+	if w.coercion != NoCoercion {
+		l = w.coercion.coerce(n, l)
+	}
+	if w.strict {
+		if err := checkTypes(n, l); err != nil {
+			return false, err
+		}
+	}
+
+	return compare(n, l)
+}
+
+// compare implements the semantics of a predicate node, with an evaluated left
+// side operand.
+func compare(n tsl.Node, l operand) (bool, error) {
+	switch n.Func {
+	case tsl.EqOp, tsl.NotEqOp, tsl.LtOp, tsl.LteOp, tsl.GtOp, tsl.GteOp, tsl.RegexOp, tsl.NotRegexOp,
+		tsl.LikeOp, tsl.NotLikeOp, tsl.ILikeOp, tsl.NotILikeOp, tsl.BetweenOp, tsl.NotBetweenOp, tsl.NotInOp, tsl.InOp,
+		tsl.ContainsOp, tsl.NotContainsOp, tsl.StartsWithOp, tsl.NotStartsWithOp, tsl.EndsWithOp, tsl.NotEndsWithOp:
+	case tsl.IsNotNilOp:
+		return l.kind != nullKind, nil
+	case tsl.IsNilOp:
+		return l.kind == nullKind, nil
+	case tsl.IsTrueOp, tsl.IsFalseOp, tsl.IsNotTrueOp, tsl.IsNotFalseOp:
+		return handleIsBoolOp(n.Func, l)
+	default:
+		return false, tsl.UnexpectedLiteralError{Literal: n.Func}
+	}
+
+	// Any comparison operation on a null element is false.
+	if l.kind == nullKind {
+		return false, nil
+	}
+
 	r := n.Right.(tsl.Node)
 
-	left := l.Left.(string)
+	// Check for IN lists indexed by a set.
+	if set, ok := r.Left.(*tsl.Set); ok && r.Func == tsl.ArrayOp {
+		switch {
+		case l.kind == stringKind && set.Strings():
+			return handleSetOp(n.Func, set.HasString(l.s))
+		case l.kind == numberKind && set.Numbers():
+			return handleSetOp(n.Func, set.HasNumber(l.f))
+		}
+	}
+
+	switch l.kind {
+	case stringKind:
+		if r.Func == tsl.StringOp || r.Func == tsl.DateOp {
+			return handleStringOp(n.Func, l.s, r)
+		}
+		// Compare strings holding dates to the current time as dates.
+		if t, ok := tsl.ParseDate(l.s); ok && (r.Func == tsl.NowOp || (r.Func == tsl.ArrayOp && hasNow(r))) {
+			if r.Func == tsl.ArrayOp {
+				return handleDateArrayOp(n.Func, t, r.Right.([]tsl.Node))
+			}
+			return handleDateOp(n.Func, t, r)
+		}
+		if r.Func == tsl.ArrayOp {
+			return handleStringArrayOp(n.Func, l.s, r.Right.([]tsl.Node))
+		}
+	case numberKind:
+		if r.Func == tsl.NumberOp || r.Func == tsl.DurationOp {
+			// Compare integers to large integer literals exactly.
+			if isExactLiteral(r) && l.f == math.Trunc(l.f) && math.Abs(l.f) <= maxExactFloat {
+				return handleIntegerOp(n.Func, intInteger(int64(l.f)), r)
+			}
+			return handleNumberOp(n.Func, l.f, r.Left.(float64))
+		}
+		if r.Func == tsl.ArrayOp {
+			return handleNumberArrayOp(n.Func, l.f, r.Right.([]tsl.Node))
+		}
+	case timeKind:
+		if r.Func == tsl.DateOp || r.Func == tsl.NowOp {
+			return handleDateOp(n.Func, l.t, r)
+		}
+		if r.Func == tsl.ArrayOp {
+			return handleDateArrayOp(n.Func, l.t, r.Right.([]tsl.Node))
+		}
+	case intKind:
+		if r.Func == tsl.NumberOp || r.Func == tsl.DurationOp {
+			return handleIntegerOp(n.Func, l.n, r)
+		}
+		if r.Func == tsl.ArrayOp {
+			return handleIntegerArrayOp(n.Func, l.n, r.Right.([]tsl.Node))
+		}
+	case decimalKind:
+		if r.Func == tsl.NumberOp || r.Func == tsl.DurationOp {
+			return handleDecimalOp(n.Func, l.d, r.Left.(float64))
+		}
+		if r.Func == tsl.ArrayOp {
+			return handleDecimalArrayOp(n.Func, l.d, r.Right.([]tsl.Node))
+		}
+	case boolKind:
+		if r.Func == tsl.BooleanOp {
+			return handleBoolOp(n.Func, l.b, r.Left.(bool))
+		}
+		if r.Func == tsl.ArrayOp {
+			return handleBoolArrayOp(n.Func, l.b, r.Right.([]tsl.Node))
+		}
+	case versionKind:
+		if r.Func == tsl.ArrayOp {
+			return handleVersionArrayOp(n.Func, l.a.(version), r.Right.([]tsl.Node))
+		}
+		return handleVersionOp(n.Func, l.a.(version), r)
+	}
+
+	return false, tsl.UnexpectedLiteralError{Literal: fmt.Sprintf("%v", r.Left)}
+}
+
+func handleStringOp(op string, left string, r tsl.Node) (bool, error) {
 	right := r.Left.(string)
 
-	switch n.Func {
+	switch op {
 	case tsl.EqOp:
 		return left == right, nil
 	case tsl.NotEqOp:
@@ -203,30 +465,48 @@ func handleStringOp(n tsl.Node, eval EvalFunc) (bool, error) {
 	case tsl.GteOp:
 		return left >= right, nil
 	case tsl.RegexOp:
-		valid, err := regexp.Compile(right)
+		valid, err := regexps.regexp(r)
 		if err != nil {
-			return false, tsl.UnexpectedLiteralError{Literal: right}
+			return false, err
 		}
 		return valid.MatchString(left), nil
 	case tsl.NotRegexOp:
-		valid, err := regexp.Compile(right)
+		valid, err := regexps.regexp(r)
 		if err != nil {
-			return false, tsl.UnexpectedLiteralError{Literal: right}
+			return false, err
 		}
 		return !valid.MatchString(left), nil
+	case tsl.LikeOp, tsl.ILikeOp:
+		valid, err := regexps.like(r, op == tsl.ILikeOp)
+		if err != nil {
+			return false, err
+		}
+		return valid.MatchString(left), nil
+	case tsl.NotLikeOp, tsl.NotILikeOp:
+		valid, err := regexps.like(r, op == tsl.NotILikeOp)
+		if err != nil {
+			return false, err
+		}
+		return !valid.MatchString(left), nil
+	case tsl.ContainsOp:
+		return strings.Contains(left, right), nil
+	case tsl.NotContainsOp:
+		return !strings.Contains(left, right), nil
+	case tsl.StartsWithOp:
+		return strings.HasPrefix(left, right), nil
+	case tsl.NotStartsWithOp:
+		return !strings.HasPrefix(left, right), nil
+	case tsl.EndsWithOp:
+		return strings.HasSuffix(left, right), nil
+	case tsl.NotEndsWithOp:
+		return !strings.HasSuffix(left, right), nil
 	}
 
-	return false, tsl.UnexpectedLiteralError{Literal: n.Func}
+	return false, tsl.UnexpectedLiteralError{Literal: op}
 }
 
-func handleNumberOp(n tsl.Node, eval EvalFunc) (bool, error) {
-	l := n.Left.(tsl.Node)
-	r := n.Right.(tsl.Node)
-
-	left := l.Left.(float64)
-	right := r.Left.(float64)
-
-	switch n.Func {
+func handleNumberOp(op string, left float64, right float64) (bool, error) {
+	switch op {
 	case tsl.EqOp:
 		return left == right, nil
 	case tsl.NotEqOp:
@@ -241,17 +521,79 @@ func handleNumberOp(n tsl.Node, eval EvalFunc) (bool, error) {
 		return left >= right, nil
 	}
 
-	return false, tsl.UnexpectedLiteralError{Literal: n.Func}
+	return false, tsl.UnexpectedLiteralError{Literal: op}
 }
 
-func handleStringArrayOp(n tsl.Node, eval EvalFunc) (bool, error) {
-	l := n.Left.(tsl.Node)
-	r := n.Right.(tsl.Node)
+func handleDateOp(op string, left time.Time, r tsl.Node) (bool, error) {
+	right, err := tsl.Date(r)
+	if err != nil {
+		return false, err
+	}
 
-	left := l.Left.(string)
-	right := r.Right.([]tsl.Node)
+	switch op {
+	case tsl.EqOp:
+		return left.Equal(right), nil
+	case tsl.NotEqOp:
+		return !left.Equal(right), nil
+	case tsl.LtOp:
+		return left.Before(right), nil
+	case tsl.LteOp:
+		return !left.After(right), nil
+	case tsl.GtOp:
+		return left.After(right), nil
+	case tsl.GteOp:
+		return !left.Before(right), nil
+	}
 
-	switch n.Func {
+	return false, tsl.UnexpectedLiteralError{Literal: op}
+}
+
+func handleBoolOp(op string, left bool, right bool) (bool, error) {
+	switch op {
+	case tsl.EqOp:
+		return left == right, nil
+	case tsl.NotEqOp:
+		return left != right, nil
+	}
+
+	return false, tsl.UnexpectedLiteralError{Literal: op}
+}
+
+func handleIsBoolOp(op string, l operand) (bool, error) {
+	// Null values are neither true nor false.
+	not := op == tsl.IsNotTrueOp || op == tsl.IsNotFalseOp
+	want := op == tsl.IsTrueOp || op == tsl.IsNotTrueOp
+
+	switch l.kind {
+	case nullKind:
+		return not, nil
+	case boolKind:
+		return (l.b == want) != not, nil
+	}
+
+	return false, tsl.UnexpectedLiteralError{ExpectedType: "boolean", Literal: l.value()}
+}
+
+func handleSetOp(op string, found bool) (bool, error) {
+	switch op {
+	case tsl.InOp:
+		return found, nil
+	case tsl.NotInOp:
+		return !found, nil
+	}
+
+	return false, tsl.UnexpectedLiteralError{Literal: op}
+}
+
+func handleStringArrayOp(op string, left string, right []tsl.Node) (bool, error) {
+	// Check the list literals are strings.
+	for _, node := range right {
+		if _, ok := node.Left.(string); !ok {
+			return false, tsl.UnexpectedLiteralError{ExpectedType: "string", Literal: node.Left}
+		}
+	}
+
+	switch op {
 	case tsl.BetweenOp:
 		begin := right[0].Left.(string)
 		end := right[1].Left.(string)
@@ -261,30 +603,81 @@ func handleStringArrayOp(n tsl.Node, eval EvalFunc) (bool, error) {
 		end := right[1].Left.(string)
 		return left < begin || left >= end, nil
 	case tsl.InOp:
-		b := false
 		for _, node := range right {
-			b = b || left == node.Left.(string)
+			if left == node.Left.(string) {
+				return true, nil
+			}
 		}
-		return b, nil
+		return false, nil
 	case tsl.NotInOp:
-		b := true
 		for _, node := range right {
-			b = b && left != node.Left.(string)
+			if left == node.Left.(string) {
+				return false, nil
+			}
 		}
-		return b, nil
+		return true, nil
 	}
 
-	return false, tsl.UnexpectedLiteralError{Literal: n.Func}
+	return false, tsl.UnexpectedLiteralError{Literal: op}
 }
 
-func handleNumberArrayOp(n tsl.Node, eval EvalFunc) (bool, error) {
-	l := n.Left.(tsl.Node)
-	r := n.Right.(tsl.Node)
+// hasNow checks if a list literal has a now literal.
+func hasNow(r tsl.Node) bool {
+	for _, node := range r.Right.([]tsl.Node) {
+		if node.Func == tsl.NowOp {
+			return true
+		}
+	}
 
-	left := l.Left.(float64)
-	right := r.Right.([]tsl.Node)
+	return false
+}
 
-	switch n.Func {
+func handleDateArrayOp(op string, left time.Time, right []tsl.Node) (bool, error) {
+	// Check the list literals are dates.
+	dates := [2]time.Time{}
+	for i, node := range right {
+		t, err := tsl.Date(node)
+		if (node.Func != tsl.DateOp && node.Func != tsl.NowOp) || err != nil {
+			return false, tsl.UnexpectedLiteralError{ExpectedType: "date", Literal: node.Left}
+		}
+		if i < len(dates) {
+			dates[i] = t
+		}
+	}
+
+	switch op {
+	case tsl.BetweenOp:
+		return !left.Before(dates[0]) && left.Before(dates[1]), nil
+	case tsl.NotBetweenOp:
+		return left.Before(dates[0]) || !left.Before(dates[1]), nil
+	}
+
+	return false, tsl.UnexpectedLiteralError{Literal: op}
+}
+
+func handleBoolArrayOp(op string, left bool, right []tsl.Node) (bool, error) {
+	// Check the list literals are booleans.
+	found := false
+	for _, node := range right {
+		b, ok := node.Left.(bool)
+		if !ok {
+			return false, tsl.UnexpectedLiteralError{ExpectedType: "boolean", Literal: node.Left}
+		}
+		found = found || left == b
+	}
+
+	return handleSetOp(op, found)
+}
+
+func handleNumberArrayOp(op string, left float64, right []tsl.Node) (bool, error) {
+	// Check the list literals are numbers.
+	for _, node := range right {
+		if _, ok := node.Left.(float64); !ok {
+			return false, tsl.UnexpectedLiteralError{ExpectedType: "number", Literal: node.Left}
+		}
+	}
+
+	switch op {
 	case tsl.BetweenOp:
 		begin := right[0].Left.(float64)
 		end := right[1].Left.(float64)
@@ -294,40 +687,70 @@ func handleNumberArrayOp(n tsl.Node, eval EvalFunc) (bool, error) {
 		end := right[1].Left.(float64)
 		return left < begin || left >= end, nil
 	case tsl.InOp:
-		b := false
 		for _, node := range right {
-			b = b || left == node.Left.(float64)
+			if left == node.Left.(float64) {
+				return true, nil
+			}
 		}
-		return b, nil
+		return false, nil
 	case tsl.NotInOp:
-		b := true
 		for _, node := range right {
-			b = b && left != node.Left.(float64)
+			if left == node.Left.(float64) {
+				return false, nil
+			}
 		}
-		return b, nil
+		return true, nil
 	}
 
-	return false, tsl.UnexpectedLiteralError{Literal: n.Func}
+	return false, tsl.UnexpectedLiteralError{Literal: op}
 }
 
-func handleLogicalOp(n tsl.Node, eval EvalFunc) (bool, error) {
+func (w *walker) handleLogicalOp(n tsl.Node, matches *[]Match) (bool, error) {
+	var leftMatches, rightMatches *[]Match
+
 	l := n.Left.(tsl.Node)
 	r := n.Right.(tsl.Node)
 
-	right, err := Walk(r, eval)
+	// Collect matches of each side separately, we only report
+	// the matches of sides that evaluated to true.
+	if matches != nil {
+		leftMatches, rightMatches = &[]Match{}, &[]Match{}
+	}
+
+	left, err := w.walk(l, leftMatches)
 	if err != nil {
 		return false, err
 	}
-	left, err := Walk(l, eval)
+
+	// Stop if the left side decides the result, when collecting matches both
+	// sides of OR nodes are evaluated, so all the matching predicates are
+	// reported.
+	switch {
+	case n.Func == tsl.AndOp && !left:
+		return false, nil
+	case n.Func == tsl.OrOp && left && matches == nil:
+		return true, nil
+	}
+
+	right, err := w.walk(r, rightMatches)
 	if err != nil {
 		return false, err
 	}
 
+	if matches != nil {
+		if left {
+			*matches = append(*matches, *leftMatches...)
+		}
+		if right {
+			*matches = append(*matches, *rightMatches...)
+		}
+	}
+
 	switch n.Func {
 	case tsl.AndOp:
-		return right && left, nil
+		return left && right, nil
 	case tsl.OrOp:
-		return right || left, nil
+		return left || right, nil
 	}
 
 	return false, tsl.UnexpectedLiteralError{Literal: n.Func}
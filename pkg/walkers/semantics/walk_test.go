@@ -0,0 +1,1499 @@
+// Copyright 2018 Yaacov Zamir <kobi.zamir@gmail.com>
+// and other contributors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package semantics
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/yaacov/tree-search-language/pkg/tsl"
+)
+
+// book is a test record.
+var book = map[string]interface{}{
+	"title":       "A good book",
+	"author":      "Joe",
+	"spec.pages":  14,
+	"spec.rating": 5,
+}
+
+// evalFactory creates an evaluation function for a data record.
+func evalFactory(r map[string]interface{}) EvalFunc {
+	return func(k string) (interface{}, bool) {
+		v, ok := r[k]
+		return v, ok
+	}
+}
+
+func TestWalk(t *testing.T) {
+	tests := map[string]bool{
+		"author = 'Joe'":                                 true,
+		"author != 'Joe'":                                false,
+		"spec.pages > 50":                                false,
+		"spec.pages between 10 and 20":                   true,
+		"author in ('Jane', 'Joe')":                      true,
+		"title ~= 'good' and spec.rating >= 5":           true,
+		"spec.pages > 50 or spec.rating is null":         false,
+		"spec.pages > 50 or price is null":               true,
+		"title ilike 'a GOOD%'":                          true,
+		"author not ilike 'j_e'":                         false,
+		"author ILIKE 'jo'":                              false,
+		"title like 'A %'":                               true,
+		"title like 'a %'":                               false,
+		"author like 'J_e' and title not like '%bad%'":   true,
+		"title like '%.%'":                               false,
+		"title contains 'good'":                          true,
+		"title not contains '%'":                         true,
+		"author startswith 'Jo' and author endswith 'e'": true,
+		"author not startswith 'J'":                      false,
+		"title endswith 'GOOD'":                          false,
+	}
+
+	for input, expected := range tests {
+		tree, err := tsl.ParseTSL(input)
+		if err != nil {
+			t.Fatalf("failed to parse %s: %v", input, err)
+		}
+
+		b, err := Walk(tree, evalFactory(book))
+		if err != nil {
+			t.Fatalf("failed to walk %s: %v", input, err)
+		}
+		if b != expected {
+			t.Errorf("%s: expected %v instead it was %v", input, expected, b)
+		}
+	}
+}
+
+func TestWalkMatches(t *testing.T) {
+	tree, err := tsl.ParseTSL("author = 'Joe' or spec.pages > 50")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	b, matches, err := WalkMatches(tree, evalFactory(book))
+	if err != nil || !b {
+		t.Fatalf("expected a match, got %v, %v", b, err)
+	}
+
+	if len(matches) != 1 {
+		t.Fatalf("expected one match instead it was %v", matches)
+	}
+
+	m := matches[0]
+	if m.Func != tsl.EqOp || m.Ident != "author" || m.Value != "Joe" || m.Args != "Joe" {
+		t.Errorf("unexpected match %v", m)
+	}
+}
+
+func TestWalkTrace(t *testing.T) {
+	tree, err := tsl.ParseTSL("author = 'Joe' and spec.pages > 50 or title ~= 'good'")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Collect the evaluated nodes and their results, in evaluation order.
+	traced := []string{}
+	trace := func(n tsl.Node, b bool, err error, d time.Duration) {
+		traced = append(traced, fmt.Sprintf("%s:%v", n.Func, b))
+	}
+
+	b, err := Options{Trace: trace}.Walk(tree, evalFactory(book))
+	if err != nil || !b {
+		t.Fatalf("expected a match, got %v, %v", b, err)
+	}
+
+	want := []string{"$eq:true", "$gt:false", "$and:false", "$regex:true", "$or:true"}
+	if !reflect.DeepEqual(traced, want) {
+		t.Errorf("expected trace %v instead it was %v", want, traced)
+	}
+
+	// Test matches are reported while tracing.
+	traced = []string{}
+	b, matches, err := WalkMatchesTrace(tree, evalFactory(book), trace)
+	if err != nil || !b || len(matches) != 1 || matches[0].Func != tsl.RegexOp {
+		t.Errorf("expected one regex match, got %v, %v, %v", b, matches, err)
+	}
+	if !reflect.DeepEqual(traced, want) {
+		t.Errorf("expected trace %v instead it was %v", want, traced)
+	}
+}
+
+func TestWalkErrors(t *testing.T) {
+	inputs := []string{
+		"author between 1 and 5",
+		"spec.pages in ('a', 'b')",
+		"author > 5",
+	}
+
+	for _, input := range inputs {
+		tree, err := tsl.ParseTSL(input)
+		if err != nil {
+			t.Fatalf("failed to parse %s: %v", input, err)
+		}
+
+		if _, err := Walk(tree, evalFactory(book)); err == nil {
+			t.Errorf("%s: expected a literal type error", input)
+		}
+	}
+}
+
+func TestWalkShortCircuit(t *testing.T) {
+	tests := map[string]bool{
+		"author = 'Joe' or author > 5":                    true,
+		"author = 'Jim' and author > 5":                   false,
+		"spec.pages < 20 or (author > 5 and title = 'x')": true,
+		"not_a_field is not null and spec.pages > 'a'":    false,
+	}
+
+	for input, expected := range tests {
+		tree, err := tsl.ParseTSL(input)
+		if err != nil {
+			t.Fatalf("failed to parse %s: %v", input, err)
+		}
+
+		// The right side errors are not reached.
+		for _, walk := range []func(tsl.Node, EvalFunc) (bool, error){Walk, WalkAll} {
+			b, err := walk(tree, evalFactory(book))
+			if err != nil || b != expected {
+				t.Errorf("%s: expected %v instead it was %v, %v", input, expected, b, err)
+			}
+		}
+	}
+
+	// Only the left side is evaluated.
+	tree, err := tsl.ParseTSL("author = 'Joe' or spec.pages > 5")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	lookups := []string{}
+	eval := func(k string) (interface{}, bool) {
+		lookups = append(lookups, k)
+		v, ok := book[k]
+		return v, ok
+	}
+	if b, err := Walk(tree, eval); err != nil || !b || len(lookups) != 1 || lookups[0] != "author" {
+		t.Errorf("expected one author lookup, got %v, %v, %v", b, err, lookups)
+	}
+}
+
+func TestWalkTruth(t *testing.T) {
+	tests := map[string]Truth{
+		"author = 'Joe'":                             True,
+		"author != 'Joe'":                            False,
+		"spec.missing = 5":                           Unknown,
+		"spec.missing != 5":                          Unknown,
+		"spec.missing * 2 > 5":                       Unknown,
+		"not (spec.missing > 5)":                     Unknown,
+		"not (spec.pages > 50)":                      True,
+		"spec.missing > 5 and author = 'Jim'":        False,
+		"spec.missing > 5 and author = 'Joe'":        Unknown,
+		"spec.missing > 5 or author = 'Joe'":         True,
+		"spec.missing > 5 or author = 'Jim'":         Unknown,
+		"spec.missing is null":                       True,
+		"spec.missing is not true":                   True,
+		"not (spec.missing is null or author = 'x')": False,
+	}
+
+	for input, expected := range tests {
+		tree, err := tsl.ParseTSL(input)
+		if err != nil {
+			t.Fatalf("failed to parse %s: %v", input, err)
+		}
+
+		got, err := WalkTruth(tree, evalFactory(book))
+		if err != nil || got != expected {
+			t.Errorf("%s: expected %v instead it was %v, %v", input, expected, got, err)
+		}
+	}
+}
+
+func TestWalkStrict(t *testing.T) {
+	tests := []struct {
+		phrase string
+		want   bool
+		err    error
+	}{
+		{phrase: "author = 'Joe' and spec.pages > 5", want: true},
+		{phrase: "spec.missing > 5 or author != 'Joe'", want: false},
+		{phrase: "author > 5", err: TypeMismatchError{Field: "author", FieldType: "string", LiteralType: "number", Literal: 5.0}},
+		{phrase: "spec.pages in (1, 'a')", err: TypeMismatchError{Field: "spec.pages", FieldType: "number", LiteralType: "string", Literal: "a"}},
+		{phrase: "spec.pages * 2 = true", err: TypeMismatchError{Field: "spec.pages * 2", FieldType: "number", LiteralType: "boolean", Literal: true}},
+	}
+
+	for _, tt := range tests {
+		tree, err := tsl.ParseTSL(tt.phrase)
+		if err != nil {
+			t.Fatalf("failed to parse %s: %v", tt.phrase, err)
+		}
+
+		got, err := Options{Strict: true}.Walk(tree, evalFactory(book))
+		if got != tt.want || err != tt.err {
+			t.Errorf("%s: expected %v, %v instead it was %v, %v", tt.phrase, tt.want, tt.err, got, err)
+		}
+	}
+}
+
+func TestWalkCoerce(t *testing.T) {
+	record := map[string]interface{}{
+		"pages":  " 120",
+		"title":  "A good book",
+		"isbn":   9780,
+		"active": "true",
+	}
+
+	tests := []struct {
+		phrase   string
+		coercion Coercion
+		want     bool
+		err      bool
+	}{
+		{"pages > 50", NoCoercion, false, true},
+		{"pages > 50", NumericStrings, true, false},
+		{"pages in (100, 120)", NumericStrings, true, false},
+		{"pages * 2 = 240", NumericStrings, true, false},
+		{"title > 50", NumericStrings, false, true},
+		{"isbn = '9780'", NumericStrings, false, true},
+		{"isbn = '9780'", Lenient, true, false},
+		{"active = true", NumericStrings, false, true},
+		{"active = true and active is true", Lenient, true, false},
+	}
+
+	for _, tt := range tests {
+		tree, err := tsl.ParseTSL(tt.phrase)
+		if err != nil {
+			t.Fatalf("failed to parse %s: %v", tt.phrase, err)
+		}
+
+		got, err := Options{Coercion: tt.coercion}.Walk(tree, evalFactory(record))
+		if got != tt.want || (err != nil) != tt.err {
+			t.Errorf("%s (%d): expected %v instead it was %v, %v", tt.phrase, tt.coercion, tt.want, got, err)
+		}
+	}
+}
+
+// decimal is a test decimal type, converting to a rational number like
+// shopspring decimals.
+type decimal string
+
+func (d decimal) Rat() *big.Rat {
+	r, _ := new(big.Rat).SetString(string(d))
+	return r
+}
+
+func TestWalkDecimals(t *testing.T) {
+	// 0.1 + 0.2 is not 0.3 using float64 numbers.
+	sum := new(big.Rat).Add(big.NewRat(1, 10), big.NewRat(2, 10))
+	large, _ := new(big.Int).SetString("123456789012345678901234567890", 10)
+
+	record := map[string]interface{}{
+		"sum":     sum,
+		"float":   new(big.Float).SetRat(sum),
+		"large":   large,
+		"price":   decimal("19.99"),
+		"missing": (*big.Rat)(nil),
+	}
+
+	tests := map[string]bool{
+		"sum = 0.3":                      true,
+		"sum != 0.3":                     false,
+		"sum > 0.29 and sum <= 0.3":      true,
+		"sum in (0.1, 0.3)":              true,
+		"sum between 0.3 and 0.4":        true,
+		"sum not between 0.1 and 0.3":    true,
+		"float > 0.29":                   true,
+		"large > 123456789012345678":     true,
+		"price = 19.99":                  true,
+		"price * 2 > 39":                 true,
+		"missing = 1 or missing is null": true,
+	}
+
+	for input, expected := range tests {
+		tree, err := tsl.ParseTSL(input)
+		if err != nil {
+			t.Fatalf("failed to parse %s: %v", input, err)
+		}
+
+		b, err := Walk(tree, evalFactory(record))
+		if err != nil || b != expected {
+			t.Errorf("%s: expected %v instead it was %v, %v", input, expected, b, err)
+		}
+	}
+}
+
+func TestWalkIntegers(t *testing.T) {
+	record := map[string]interface{}{
+		"id":       int64(9007199254740993),
+		"counter":  uint64(18446744073709551615),
+		"negative": int64(-9223372036854775808),
+		"small":    9007199254740992,
+	}
+
+	tests := map[string]bool{
+		"id = 9007199254740993":                            true,
+		"id = 9007199254740992":                            false,
+		"id > 9007199254740992":                            true,
+		"id != 9007199254740994":                           true,
+		"id > 1.5 and id < 1e17":                           true,
+		"id in (1, 9007199254740993)":                      true,
+		"id not in (9007199254740992)":                     true,
+		"id between 9007199254740993 and 9007199254740994": true,
+		"counter = 18446744073709551615":                   true,
+		"counter > 9223372036854775807":                    true,
+		"negative < -9223372036854775807":                  true,
+		"negative = -9223372036854775808":                  true,
+		"small = 9007199254740992":                         true,
+		"small < 9007199254740993":                         true,
+		"id - 1 > 0":                                       true,
+	}
+
+	for input, expected := range tests {
+		tree, err := tsl.ParseTSL(input)
+		if err != nil {
+			t.Fatalf("failed to parse %s: %v", input, err)
+		}
+
+		b, err := Walk(tree, evalFactory(record))
+		if err != nil || b != expected {
+			t.Errorf("%s: expected %v instead it was %v, %v", input, expected, b, err)
+		}
+	}
+}
+
+type testBase struct {
+	ID    int64  `tsl:"id"`
+	Owner string `json:"owner,omitempty"`
+}
+
+type testSpec struct {
+	Pages  uint16   `json:"pages"`
+	Rating *float32 `json:"rating"`
+	Tags   []string `json:"tags"`
+}
+
+type testBook struct {
+	testBase
+	Title  string            `tsl:"title" json:"name"`
+	Author string            `json:"-"`
+	Spec   *testSpec         `json:"spec"`
+	Labels map[string]string `json:"labels"`
+	secret string
+}
+
+func TestWalkStruct(t *testing.T) {
+	rating := float32(4.5)
+	book := &testBook{
+		testBase: testBase{ID: 7, Owner: "joe"},
+		Title:    "A good book",
+		Author:   "Joe",
+		Spec:     &testSpec{Pages: 14, Rating: &rating, Tags: []string{"new", "sale"}},
+		Labels:   map[string]string{"env": "prod"},
+		secret:   "x",
+	}
+
+	tests := map[string]bool{
+		"title = 'A good book'":               true,
+		"name = 'A good book'":                false,
+		"id = 7 and owner = 'joe'":            true,
+		"spec.pages > 10 and spec.rating > 4": true,
+		"spec.tags = 'sale'":                  true,
+		"labels.env = 'prod'":                 true,
+		"Author is null and secret is null":   true,
+		"labels.missing is null":              true,
+	}
+
+	for input, expected := range tests {
+		tree, err := tsl.ParseTSL(input)
+		if err != nil {
+			t.Fatalf("failed to parse %s: %v", input, err)
+		}
+
+		b, err := WalkStruct(tree, book)
+		if err != nil || b != expected {
+			t.Errorf("%s: expected %v instead it was %v, %v", input, expected, b, err)
+		}
+	}
+
+	// Nil pointers are null.
+	tree, err := tsl.ParseTSL("spec.pages is null and spec.rating is null")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if b, err := WalkStruct(tree, testBook{}); err != nil || !b {
+		t.Errorf("expected nil pointers to be null, got %v, %v", b, err)
+	}
+}
+
+func TestWalkJSON(t *testing.T) {
+	data := []byte(`{
+		"title": "A \"good\" book", "count": 9007199254740993,
+		"skip": {"a": [1, "}", {"b": "]"}], "c": null},
+		"spec": {"pages": 14, "rating": 4.5, "tags": ["new", "sale"], "draft": false},
+		"sp\u0065c2": {"pages": 3}, "empty": {}, "none": null
+	}`)
+
+	tests := map[string]bool{
+		"title = 'A \"good\" book'":                     true,
+		"count = 9007199254740993":                      true,
+		"count = 9007199254740992":                      false,
+		"spec.pages > 10 and spec.rating = 4.5":         true,
+		"spec.tags = 'sale' and spec.draft is false":    true,
+		"spec2.pages = 3":                               true,
+		"skip.c is null and none is null":               true,
+		"empty.pages is null and missing.pages is null": true,
+		"title.pages is null":                           true,
+	}
+
+	for input, expected := range tests {
+		tree, err := tsl.ParseTSL(input)
+		if err != nil {
+			t.Fatalf("failed to parse %s: %v", input, err)
+		}
+
+		b, err := WalkJSON(tree, data)
+		if err != nil || b != expected {
+			t.Errorf("%s: expected %v instead it was %v, %v", input, expected, b, err)
+		}
+	}
+
+	// Malformed documents have no values.
+	eval := JSONEval([]byte(`{"a": 1, "b": `))
+	if v, ok := eval("a"); !ok || v != int64(1) {
+		t.Errorf("expected a to be 1, got %v, %v", v, ok)
+	}
+	if v, ok := eval("b"); ok {
+		t.Errorf("expected b to be missing, got %v", v)
+	}
+}
+
+func TestWalkMissing(t *testing.T) {
+	tests := []struct {
+		input    string
+		missing  Missing
+		expected bool
+		field    string
+	}{
+		{input: "price is null", missing: Missing{}, expected: true},
+		{input: "price = 0", missing: Missing{Default: 0}, expected: true},
+		{input: "price is null", missing: Missing{Default: 0}, expected: false},
+		{input: "spec.pages > 10 and price < 5", missing: Missing{Default: 2.5}, expected: true},
+		{input: "author = 'Joe' and spec.pages > 10", missing: Missing{Error: true}, expected: true},
+		{input: "author = 'Joe' and price > 10", missing: Missing{Error: true}, field: "price"},
+		{input: "author = 'Jane' and price > 10", missing: Missing{Error: true}, expected: false},
+		{input: "(price + 1) > 10", missing: Missing{Error: true}, field: "price"},
+	}
+
+	for _, tt := range tests {
+		tree, err := tsl.ParseTSL(tt.input)
+		if err != nil {
+			t.Fatalf("failed to parse %s: %v", tt.input, err)
+		}
+
+		b, err := Options{Missing: &tt.missing}.Walk(tree, evalFactory(book))
+		if tt.field != "" {
+			if e, ok := err.(MissingFieldError); !ok || e.Field != tt.field {
+				t.Errorf("%s: expected a missing %s field error, got %v", tt.input, tt.field, err)
+			}
+			continue
+		}
+		if err != nil || b != tt.expected {
+			t.Errorf("%s: expected %v instead it was %v, %v", tt.input, tt.expected, b, err)
+		}
+	}
+}
+
+func TestWalkAliases(t *testing.T) {
+	aliases := map[string]string{
+		"writer": "author",
+		"pages":  "spec.pages",
+		"title":  "spec.rating",
+	}
+
+	tests := map[string]bool{
+		"writer = 'Joe' and pages = 14": true,
+		"author = 'Joe'":                true,
+		"title = 5":                     true,
+		"title < 5":                     false,
+		"(pages * 2) > 20":              true,
+	}
+
+	for input, expected := range tests {
+		tree, err := tsl.ParseTSL(input)
+		if err != nil {
+			t.Fatalf("failed to parse %s: %v", input, err)
+		}
+
+		b, err := Options{Aliases: aliases}.Walk(tree, evalFactory(book))
+		if err != nil || b != expected {
+			t.Errorf("%s: expected %v instead it was %v, %v", input, expected, b, err)
+		}
+	}
+}
+
+func TestWalkOptions(t *testing.T) {
+	o := Options{
+		Strict:  true,
+		Missing: &Missing{Error: true},
+		Aliases: map[string]string{"writer": "author", "pages": "spec.pages"},
+	}
+
+	tests := []struct {
+		input    string
+		expected bool
+		err      error
+	}{
+		{input: "writer = 'Joe' and pages > 10", expected: true},
+		{input: "writer = 'Jane' or pages < 10", expected: false},
+		{input: "writer = 'Joe' and price > 10", err: MissingFieldError{Field: "price"}},
+		{input: "pages = 'many'", err: TypeMismatchError{}},
+	}
+
+	for _, tt := range tests {
+		tree, err := tsl.ParseTSL(tt.input)
+		if err != nil {
+			t.Fatalf("failed to parse %s: %v", tt.input, err)
+		}
+
+		b, err := o.Walk(tree, evalFactory(book))
+		switch tt.err.(type) {
+		case MissingFieldError:
+			if err != tt.err {
+				t.Errorf("%s: expected %v, got %v", tt.input, tt.err, err)
+			}
+		case TypeMismatchError:
+			if _, ok := err.(TypeMismatchError); !ok {
+				t.Errorf("%s: expected a type mismatch error, got %v", tt.input, err)
+			}
+		default:
+			if err != nil || b != tt.expected {
+				t.Errorf("%s: expected %v instead it was %v, %v", tt.input, tt.expected, b, err)
+			}
+		}
+	}
+}
+
+func TestComputedEval(t *testing.T) {
+	computed := map[string]ComputedFunc{
+		"byline": func(eval EvalFunc) interface{} {
+			title, _ := eval("title")
+			author, _ := eval("author")
+			return fmt.Sprintf("%v by %v", title, author)
+		},
+		"spec.score": func(eval EvalFunc) interface{} {
+			pages, _ := eval("spec.pages")
+			rating, _ := eval("spec.rating")
+			return pages.(int) * rating.(int)
+		},
+		"title": func(eval EvalFunc) interface{} {
+			return "shadowed"
+		},
+	}
+
+	tests := map[string]bool{
+		"byline = 'A good book by Joe'":      true,
+		"spec.score = 70":                    true,
+		"title = 'A good book'":              true,
+		"author = 'Joe' and missing is null": true,
+	}
+
+	eval := ComputedEval(evalFactory(book), computed)
+	for input, expected := range tests {
+		tree, err := tsl.ParseTSL(input)
+		if err != nil {
+			t.Fatalf("failed to parse %s: %v", input, err)
+		}
+
+		b, err := Walk(tree, eval)
+		if err != nil || b != expected {
+			t.Errorf("%s: expected %v instead it was %v, %v", input, expected, b, err)
+		}
+	}
+}
+
+func TestWalkFunctions(t *testing.T) {
+	record := map[string]interface{}{
+		"title":  "A good book",
+		"author": "Joe",
+		"tags":   []string{"new", "sale"},
+		"price":  -4.6,
+		"pages":  int64(14),
+	}
+
+	tests := map[string]bool{
+		"len(title) = 11":                  true,
+		"len(tags) = 2":                    true,
+		"lower(author) = 'joe'":            true,
+		"upper(lower(author)) = 'JOE'":     true,
+		"lower(author) in ('jane', 'joe')": true,
+		"abs(price) > 4.5":                 true,
+		"round(price) = -5":                true,
+		"round(abs(price) * 2) = 9":        true,
+		"len(title) + pages = 25":          true,
+		"lower(missing) is null":           true,
+		"len(missing) > 0":                 false,
+		"lower(title) ~= '^a good'":        true,
+		"lower(author) = 'Joe'":            false,
+	}
+
+	for input, expected := range tests {
+		tree, err := tsl.ParseTSL(input)
+		if err != nil {
+			t.Fatalf("failed to parse %s: %v", input, err)
+		}
+
+		b, err := Walk(tree, evalFactory(record))
+		if err != nil || b != expected {
+			t.Errorf("%s: expected %v instead it was %v, %v", input, expected, b, err)
+		}
+	}
+
+	// Functions of the wrong type fail.
+	for _, input := range []string{"lower(price) = 'a'", "abs(title) > 1", "lower(author) + 1 > 1"} {
+		tree, err := tsl.ParseTSL(input)
+		if err != nil {
+			t.Fatalf("failed to parse %s: %v", input, err)
+		}
+
+		if _, err := Walk(tree, evalFactory(record)); err == nil {
+			t.Errorf("%s: expected an error", input)
+		}
+	}
+}
+
+func TestWalkNow(t *testing.T) {
+	now := time.Now()
+	record := map[string]interface{}{
+		"created": now.Add(-48 * time.Hour),
+		"updated": now.Add(-time.Hour).UTC().Format(time.RFC3339),
+		"title":   "A good book",
+	}
+
+	tests := map[string]bool{
+		"created > now() - 7d":                             true,
+		"created > now() - 1d":                             false,
+		"created < now()":                                  true,
+		"updated > now() - 2h and updated < now()":         true,
+		"updated between now() - 1d and now() + 1d":        true,
+		"created not between now() - 1d and now()":         true,
+		"created > date('2020-01-01')":                     true,
+		"created < date('2020-01-01') + 1d":                false,
+		"updated > date('2020-01-01') and created < now()": true,
+	}
+
+	for input, expected := range tests {
+		tree, err := tsl.ParseTSL(input)
+		if err != nil {
+			t.Fatalf("failed to parse %s: %v", input, err)
+		}
+
+		b, err := Walk(tree, evalFactory(record))
+		if err != nil || b != expected {
+			t.Errorf("%s: expected %v instead it was %v, %v", input, expected, b, err)
+		}
+	}
+
+	// Values that are not dates can not be compared to the current time.
+	tree, err := tsl.ParseTSL("title > now()")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Walk(tree, evalFactory(record)); err == nil {
+		t.Errorf("expected an error comparing a title to the current time")
+	}
+}
+
+func TestWalkGeo(t *testing.T) {
+	record := map[string]interface{}{
+		"location": map[string]interface{}{"lat": 32.0853, "lon": 34.7818},
+		"office":   map[string]interface{}{"type": "Point", "coordinates": []interface{}{34.7818, 32.0853}},
+		"home.lat": 32.794,
+		"home.lon": int64(35),
+		"title":    "A good book",
+	}
+
+	tests := map[string]bool{
+		"location within 5km of (32.1, 34.8)":                    true,
+		"location within 1km of (32.1, 34.8)":                    false,
+		"location not within 1km of (32.1, 34.8)":                true,
+		"office within 2500 of (32.1, 34.8)":                     true,
+		"home within 80km of (32.0853, 34.7818)":                 false,
+		"home within 60mi of (32.0853, 34.7818)":                 true,
+		"location within (32.0, 34.7, 32.2, 34.9)":               true,
+		"home within (32.0, 34.7, 32.2, 34.9)":                   false,
+		"home not within (32.0, 34.7, 32.2, 34.9)":               true,
+		"location within (32.0, 170, 32.2, 34.9)":                true,
+		"missing within 5km of (32.1, 34.8)":                     false,
+		"missing not within 5km of (32.1, 34.8)":                 false,
+		"title = 'A good book' and office within (0, 0, 40, 40)": true,
+	}
+
+	for input, expected := range tests {
+		tree, err := tsl.ParseTSL(input)
+		if err != nil {
+			t.Fatalf("failed to parse %s: %v", input, err)
+		}
+
+		b, err := Walk(tree, evalFactory(record))
+		if err != nil || b != expected {
+			t.Errorf("%s: expected %v instead it was %v, %v", input, expected, b, err)
+		}
+	}
+
+	// Values that are not locations fail.
+	tree, err := tsl.ParseTSL("title within 5km of (32.1, 34.8)")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Walk(tree, evalFactory(record)); err == nil {
+		t.Errorf("expected an error for a title location")
+	}
+}
+
+func TestWalkSemver(t *testing.T) {
+	record := map[string]interface{}{
+		"version": "1.10.2",
+		"release": "v2.0.0-rc.10+build.5",
+		"broken":  "1.x",
+		"pages":   int64(14),
+	}
+
+	tests := map[string]bool{
+		"semver(version) > '1.9.12'":                               true,
+		"version > '1.9.12'":                                       false,
+		"semver(version) = '1.10.2+build.1'":                       true,
+		"semver(version) < '1.10.10'":                              true,
+		"semver(version) >= '1.10'":                                true,
+		"semver(version) between '1.2.0' and '1.10.2'":             true,
+		"semver(version) not between '1.2.0' and '1.10.1'":         true,
+		"semver(version) in ('1.9.0', 'v1.10.2')":                  true,
+		"semver(release) < '2.0.0'":                                true,
+		"semver(release) > '2.0.0-rc.9'":                           true,
+		"semver(release) > '2.0.0-rc.a'":                           false,
+		"semver(release) > '2.0.0-rc'":                             true,
+		"semver(release) < '2.0.0-rc.10.1'":                        true,
+		"semver(missing) > '1.0.0'":                                false,
+		"semver(version) > '1.0.0' and semver(release) != '2.0.0'": true,
+	}
+
+	for input, expected := range tests {
+		tree, err := tsl.ParseTSL(input)
+		if err != nil {
+			t.Fatalf("failed to parse %s: %v", input, err)
+		}
+
+		b, err := Walk(tree, evalFactory(record))
+		if err != nil || b != expected {
+			t.Errorf("%s: expected %v instead it was %v, %v", input, expected, b, err)
+		}
+	}
+
+	// Values and literals that are not versions fail.
+	for _, input := range []string{"semver(broken) > '1.0.0'", "semver(version) > 'latest'", "semver(pages) > '1.0.0'", "semver(version) > 1"} {
+		tree, err := tsl.ParseTSL(input)
+		if err != nil {
+			t.Fatalf("failed to parse %s: %v", input, err)
+		}
+
+		if _, err := Walk(tree, evalFactory(record)); err == nil {
+			t.Errorf("%s: expected an error", input)
+		}
+	}
+}
+
+func TestWalkContext(t *testing.T) {
+	tree, err := tsl.ParseTSL("author = 'Joe' and spec.pages > 5")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	b, err := Options{Context: context.Background()}.Walk(tree, evalFactory(book))
+	if err != nil || !b {
+		t.Fatalf("expected a match, got %v, %v", b, err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := (Options{Context: ctx}).Walk(tree, evalFactory(book)); err != context.Canceled {
+		t.Errorf("expected a canceled error, got %v", err)
+	}
+}
+
+func TestCompile(t *testing.T) {
+	docs := []map[string]interface{}{
+		book,
+		{"title": "Other", "author": "Jane", "spec.pages": int64(300), "spec.rating": nil},
+		{"title": "Third", "author": []string{"Joe", "Jim"}, "spec.pages": 5.5, "date": time.Date(2020, 1, 2, 0, 0, 0, 0, time.UTC)},
+		{},
+	}
+	phrases := []string{
+		"author = 'Joe'",
+		"author != 'Joe' or spec.pages >= 100",
+		"title ~= '^A' and spec.pages < 20",
+		"title ~! 'book$'",
+		"title like 'A%' or title not ilike '%OTHER'",
+		"author in ('Joe', 'Jim')",
+		"spec.pages between 5 and 20",
+		"spec.rating is null or spec.rating > 4",
+		"spec.pages * 2 > 20",
+		"title contains 'good' and title endswith 'book'",
+		"date > '2019-01-01' and date < '2021-01-01'",
+		"author > 5",
+	}
+
+	for _, phrase := range phrases {
+		tree, err := tsl.ParseTSL(phrase)
+		if err != nil {
+			t.Fatalf("failed to parse %s: %v", phrase, err)
+		}
+
+		match, err := Compile(tree)
+		if err != nil {
+			t.Fatalf("failed to compile %s: %v", phrase, err)
+		}
+		matchDoc, err := CompileDoc(tree, Flat)
+		if err != nil {
+			t.Fatalf("failed to compile %s: %v", phrase, err)
+		}
+
+		for _, doc := range docs {
+			want, wantErr := Walk(tree, evalFactory(doc))
+			got, err := match(evalFactory(doc))
+			if got != want || (err == nil) != (wantErr == nil) {
+				t.Errorf("%s on %v = %v, %v, want %v, %v", phrase, doc, got, err, want, wantErr)
+			}
+			got, err = matchDoc(doc)
+			if got != want || (err == nil) != (wantErr == nil) {
+				t.Errorf("CompileDoc %s on %v = %v, %v, want %v, %v", phrase, doc, got, err, want, wantErr)
+			}
+		}
+	}
+
+	// Regular expressions are compiled once, when compiling the tree.
+	tree := tsl.Node{
+		Func:  tsl.RegexOp,
+		Left:  tsl.Node{Func: tsl.IdentOp, Left: "title"},
+		Right: tsl.Node{Func: tsl.StringOp, Left: "("},
+	}
+	if _, err := Compile(tree); err == nil {
+		t.Errorf("expected an invalid regular expression error")
+	}
+}
+
+func TestRegexpCache(t *testing.T) {
+	// Trees built by hand are not prepared.
+	tree := tsl.Node{
+		Func:  tsl.ILikeOp,
+		Left:  tsl.Node{Func: tsl.IdentOp, Left: "title"},
+		Right: tsl.Node{Func: tsl.StringOp, Left: "%GOOD%"},
+	}
+
+	for i := 0; i < 3; i++ {
+		b, err := Walk(tree, evalFactory(book))
+		if err != nil || !b {
+			t.Fatalf("expected a match, got %v, %v", b, err)
+		}
+	}
+
+	key := regexpKey{pattern: "%GOOD%", like: true, fold: true}
+	re, ok := regexps.m[key]
+	if !ok {
+		t.Fatalf("expected a cached pattern")
+	}
+	if cached, _ := regexps.like(tree.Right.(tsl.Node), true); cached != re {
+		t.Errorf("expected the cached pattern to be reused")
+	}
+}
+
+func TestWalkFieldCache(t *testing.T) {
+	tree, err := tsl.ParseTSL("(spec.pages > 5 and spec.pages < 10) or (spec.pages > 100 and author = 'Joe') or author = 'Joe'")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Count the document lookups of each field.
+	lookups := map[string]int{}
+	eval := func(k string) (interface{}, bool) {
+		lookups[k]++
+		v, ok := book[k]
+		return v, ok
+	}
+
+	b, err := Walk(tree, eval)
+	if err != nil || !b {
+		t.Fatalf("expected a match, got %v, %v", b, err)
+	}
+	if lookups["spec.pages"] != 1 || lookups["author"] != 1 {
+		t.Errorf("expected one lookup of each field instead it was %v", lookups)
+	}
+}
+
+func TestDocEval(t *testing.T) {
+	doc := map[string]interface{}{
+		"title":      "A good book",
+		"spec.pages": 500,
+		"spec": map[string]interface{}{
+			"pages": 14,
+			"tags":  map[string]interface{}{"genre": "drama"},
+		},
+		"labels": map[string]interface{}{"app": "web", "tier": "front"},
+		"items": []interface{}{
+			map[string]interface{}{"status": "done"},
+			map[string]interface{}{"status": "open"},
+		},
+		"labels.app":  "db",
+		"labels.tier": "back",
+	}
+
+	tests := []struct {
+		input  string
+		lookup Lookup
+		want   bool
+	}{
+		{"spec.pages > 50", Flat, true},
+		{"spec.pages > 50", Nested, false},
+		{"spec.pages = 14", Nested, true},
+		{"spec.tags.genre = 'drama'", Nested, true},
+		{"spec.tags.genre is null", Flat, true},
+		{"title.name is null", Nested, true},
+		{"spec.missing.pages is null", Nested, true},
+		{"labels.* = 'front'", Nested, true},
+		{"labels.* = 'front'", Flat, false},
+		{"labels.* = 'back'", Flat, true},
+		{"items.*.status = 'open'", Nested, true},
+		{"items.*.status = 'closed'", Nested, false},
+		{"spec.*.genre = 'drama'", Nested, true},
+		{"spec.*.missing is null", Nested, true},
+	}
+
+	for _, tt := range tests {
+		tree, err := tsl.ParseTSL(tt.input)
+		if err != nil {
+			t.Fatalf("failed to parse %s: %v", tt.input, err)
+		}
+
+		b, err := Walk(tree, DocEval(doc, tt.lookup))
+		if err != nil {
+			t.Fatalf("failed to walk %s: %v", tt.input, err)
+		}
+		if b != tt.want {
+			t.Errorf("%s (lookup %d): expected %v instead it was %v", tt.input, tt.lookup, tt.want, b)
+		}
+	}
+}
+
+func TestWalkDates(t *testing.T) {
+	doc := map[string]interface{}{
+		"created": time.Date(2019, 3, 1, 10, 0, 0, 0, time.UTC),
+		"updated": "2019-03-01",
+	}
+
+	tests := map[string]bool{
+		"created > 2019-01-01":                          true,
+		"created < 2019-03-01T12:00:00+02:00":           false,
+		"created = 2019-03-01T10:00:00Z":                true,
+		"created between 2019-03-01 and 2019-03-02":     true,
+		"created not between 2019-03-01 and 2019-03-02": false,
+		"updated >= 2019-03-01":                         true,
+		"updated between 2019-01-01 and 2019-02-01":     false,
+	}
+
+	for input, want := range tests {
+		tree, err := tsl.ParseTSL(input)
+		if err != nil {
+			t.Fatalf("failed to parse %s: %v", input, err)
+		}
+
+		b, err := Walk(tree, evalFactory(doc))
+		if err != nil {
+			t.Fatalf("failed to walk %s: %v", input, err)
+		}
+		if b != want {
+			t.Errorf("%s: expected %v instead it was %v", input, want, b)
+		}
+	}
+
+	// Test dates are not compared to strings.
+	tree, _ := tsl.ParseTSL("created = 'March'")
+	if _, err := Walk(tree, evalFactory(doc)); err == nil {
+		t.Errorf("expected a literal type error")
+	}
+}
+
+func TestWalkDurations(t *testing.T) {
+	doc := map[string]interface{}{
+		"uptime":  90 * time.Minute,
+		"timeout": 30,
+	}
+
+	tests := map[string]bool{
+		"uptime > 1h":                 true,
+		"uptime >= 1h30m":             true,
+		"uptime < 5400":               false,
+		"uptime between 1d and 2d":    false,
+		"timeout = 30s":               true,
+		"timeout in (10s, 30s, 1m)":   true,
+		"timeout > 1m or uptime < 2h": true,
+	}
+
+	for input, want := range tests {
+		tree, err := tsl.ParseTSL(input)
+		if err != nil {
+			t.Fatalf("failed to parse %s: %v", input, err)
+		}
+
+		b, err := Walk(tree, evalFactory(doc))
+		if err != nil {
+			t.Fatalf("failed to walk %s: %v", input, err)
+		}
+		if b != want {
+			t.Errorf("%s: expected %v instead it was %v", input, want, b)
+		}
+	}
+}
+
+func TestWalkBooleans(t *testing.T) {
+	doc := map[string]interface{}{
+		"active":  true,
+		"deleted": false,
+	}
+
+	tests := map[string]bool{
+		"active = true":                    true,
+		"active != true":                   false,
+		"deleted is false":                 true,
+		"deleted is not false":             false,
+		"active in (false, true)":          true,
+		"deleted not in (false)":           false,
+		"missing = true":                   false,
+		"active = true and deleted = true": false,
+	}
+
+	for input, want := range tests {
+		tree, err := tsl.ParseTSL(input)
+		if err != nil {
+			t.Fatalf("failed to parse %s: %v", input, err)
+		}
+
+		b, err := Walk(tree, evalFactory(doc))
+		if err != nil {
+			t.Fatalf("failed to walk %s: %v", input, err)
+		}
+		if b != want {
+			t.Errorf("%s: expected %v instead it was %v", input, want, b)
+		}
+	}
+
+	// Booleans are not compared to strings.
+	tree, _ := tsl.ParseTSL("active = 'true'")
+	if _, err := Walk(tree, evalFactory(doc)); err == nil {
+		t.Error("expected an unexpected literal error")
+	}
+}
+
+func TestWalkLists(t *testing.T) {
+	doc := map[string]interface{}{
+		"tags":    []string{"urgent", "bug"},
+		"scores":  []float64{3, 7},
+		"labels":  []interface{}{"bug", nil},
+		"history": []string{},
+	}
+
+	tests := []struct {
+		input string
+		any   bool
+		all   bool
+	}{
+		{"tags = 'urgent'", true, false},
+		{"tags != 'urgent'", true, false},
+		{"tags in ('urgent', 'bug')", true, true},
+		{"tags like 'u%'", true, false},
+		{"scores > 5", true, false},
+		{"scores between 1 and 10", true, true},
+		{"labels = 'bug'", true, false},
+		{"tags is not null", true, true},
+		{"history is null", false, false},
+		{"history = 'urgent'", false, true},
+	}
+
+	for _, tt := range tests {
+		tree, err := tsl.ParseTSL(tt.input)
+		if err != nil {
+			t.Fatalf("failed to parse %s: %v", tt.input, err)
+		}
+
+		b, err := Walk(tree, evalFactory(doc))
+		if err != nil {
+			t.Fatalf("failed to walk %s: %v", tt.input, err)
+		}
+		if b != tt.any {
+			t.Errorf("%s: expected %v instead it was %v", tt.input, tt.any, b)
+		}
+
+		b, err = WalkAll(tree, evalFactory(doc))
+		if err != nil {
+			t.Fatalf("failed to walk all %s: %v", tt.input, err)
+		}
+		if b != tt.all {
+			t.Errorf("%s: expected all %v instead it was %v", tt.input, tt.all, b)
+		}
+	}
+}
+
+func TestWalkMath(t *testing.T) {
+	doc := map[string]interface{}{
+		"price":          2.5,
+		"quantity":       60,
+		"pages":          400,
+		"appendix_pages": 150,
+		"name":           "Joe",
+	}
+
+	tests := map[string]bool{
+		"price * quantity > 100":          true,
+		"pages + appendix_pages <= 500":   false,
+		"(pages - 100) / 3 = 100":         true,
+		"pages % 7 between 1 and 2":       true,
+		"pages / 0 > 1 or pages / 0 <= 1": false,
+		"missing + 1 is null":             true,
+		"price * missing > 0":             false,
+	}
+
+	for input, want := range tests {
+		tree, err := tsl.ParseTSL(input)
+		if err != nil {
+			t.Fatalf("failed to parse %s: %v", input, err)
+		}
+
+		b, err := Walk(tree, evalFactory(doc))
+		if err != nil {
+			t.Fatalf("failed to walk %s: %v", input, err)
+		}
+		if b != want {
+			t.Errorf("%s: expected %v instead it was %v", input, want, b)
+		}
+	}
+
+	// Test math on strings.
+	tree, _ := tsl.ParseTSL("name * 2 > 1")
+	if _, err := Walk(tree, evalFactory(doc)); err == nil {
+		t.Errorf("expected a literal type error")
+	}
+
+	// Test matches of math expressions.
+	tree, _ = tsl.ParseTSL("price * (quantity + 4) > 100")
+	_, matches, err := WalkMatches(tree, evalFactory(doc))
+	if err != nil || len(matches) != 1 || matches[0].Ident != "price * (quantity + 4)" || matches[0].Value != 160.0 {
+		t.Errorf("unexpected matches %v, %v", matches, err)
+	}
+}
+
+func TestWalkLargeIn(t *testing.T) {
+	authors := []string{}
+	pages := []string{}
+	for i := 0; i < 1000; i++ {
+		authors = append(authors, fmt.Sprintf("'author%d'", i))
+		pages = append(pages, fmt.Sprintf("%d", i*2))
+	}
+	authors = append(authors, "'Joe'")
+
+	tests := map[string]bool{
+		"author in (" + strings.Join(authors, ", ") + ")":        true,
+		"author not in (" + strings.Join(authors, ", ") + ")":    false,
+		"spec.pages in (" + strings.Join(pages, ", ") + ")":      true,
+		"spec.rating not in (" + strings.Join(pages, ", ") + ")": true,
+	}
+
+	for input, expected := range tests {
+		tree, err := tsl.ParseTSL(input)
+		if err != nil {
+			t.Fatalf("failed to parse %s: %v", input, err)
+		}
+
+		b, err := Walk(tree, evalFactory(book))
+		if err != nil {
+			t.Fatalf("failed to walk %s: %v", input, err)
+		}
+		if b != expected {
+			t.Errorf("%s: expected %v instead it was %v", input[:20], expected, b)
+		}
+	}
+}
+
+func TestFilterSliceOrdered(t *testing.T) {
+	tree, err := tsl.ParseTSL("spec.rating = 3 or spec.pages between 1000 and 1100")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	docs := make([]map[string]interface{}, 5000)
+	for i := range docs {
+		docs[i] = map[string]interface{}{"spec.pages": i, "spec.rating": i % 7}
+	}
+	evalAt := func(i int) EvalFunc {
+		return evalFactory(docs[i])
+	}
+
+	want, err := FilterSlice(tree, len(docs), evalAt)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// 714 documents with rating 3, and 100 pages in range, 14 of them with rating 3.
+	if len(want) != 800 {
+		t.Fatalf("expected 800 matches instead it was %d", len(want))
+	}
+
+	for _, workers := range []int{0, 1, 3, 8} {
+		got, err := FilterSliceOrdered(tree, len(docs), evalAt, workers)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if fmt.Sprint(got) != fmt.Sprint(want) {
+			t.Errorf("%d workers: matches differ from FilterSlice", workers)
+		}
+	}
+
+	// Test the first error in input order is returned.
+	docs[4000]["spec.rating"] = []int{1}
+	docs[3000]["spec.rating"] = "bad"
+	docs[300]["spec.rating"] = struct{}{}
+
+	_, want1 := FilterSlice(tree, len(docs), evalAt)
+	_, got1 := FilterSliceOrdered(tree, len(docs), evalAt, 4)
+	if want1 == nil || got1 == nil || want1.Error() != got1.Error() {
+		t.Errorf("expected error %v instead it was %v", want1, got1)
+	}
+}
+
+func TestFilterDocs(t *testing.T) {
+	tree, err := tsl.ParseTSL("spec.rating = 3 or spec.pages between 1000 and 1100")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	docs := make([]map[string]interface{}, 5000)
+	for i := range docs {
+		docs[i] = map[string]interface{}{"spec": map[string]interface{}{"pages": i, "rating": i % 7}}
+	}
+
+	for _, workers := range []int{0, 1, 4, -1} {
+		matches, indexes, err := FilterDocs(tree, docs, FilterOptions{Lookup: Nested, Workers: workers})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(indexes) != 800 || len(matches) != len(indexes) {
+			t.Fatalf("%d workers: expected 800 matches instead it was %d", workers, len(indexes))
+		}
+		for i, index := range indexes {
+			if fmt.Sprint(matches[i]) != fmt.Sprint(docs[index]) {
+				t.Errorf("%d workers: match %d is not document %d", workers, i, index)
+			}
+		}
+	}
+
+	docs[300]["spec"] = map[string]interface{}{"rating": "bad"}
+	if _, _, err := FilterDocs(tree, docs, FilterOptions{Lookup: Nested, Workers: 4}); err == nil {
+		t.Errorf("expected a literal type error")
+	}
+}
+
+func TestQuerySlice(t *testing.T) {
+	docs := []map[string]interface{}{
+		{"title": "a", "spec.pages": 30, "spec.rating": 5},
+		{"title": "b", "spec.pages": 10, "spec.rating": 3},
+		{"title": "c", "spec.pages": 20},
+		{"title": "d", "spec.pages": 40, "spec.rating": 3},
+		{"title": "e", "spec.pages": 5, "spec.rating": 4},
+	}
+	evalAt := func(i int) EvalFunc {
+		return evalFactory(docs[i])
+	}
+
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"spec.pages > 5", "[0 1 2 3]"},
+		{"order by spec.pages", "[4 1 2 0 3]"},
+		{"spec.pages > 5 order by spec.rating, spec.pages desc", "[3 1 0 2]"},
+		{"order by spec.rating desc, title desc", "[2 0 4 3 1]"},
+		{"order by spec.pages limit 2 offset 1", "[1 2]"},
+		{"spec.pages > 5 limit 10 offset 3", "[3]"},
+		{"offset 10", "[]"},
+	}
+
+	for _, tt := range tests {
+		q, err := tsl.ParseQuery(tt.input)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		got, err := QuerySlice(q, len(docs), evalAt)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if fmt.Sprint(got) != tt.want {
+			t.Errorf("%s: expected %s instead it was %v", tt.input, tt.want, got)
+		}
+	}
+}
+
+func TestProject(t *testing.T) {
+	q, err := tsl.ParseQuery("fields title, spec.pages, isbn where spec.rating > 3")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got := Project(q.Fields, evalFactory(book))
+	if fmt.Sprint(got) != "map[spec.pages:14 title:A good book]" {
+		t.Errorf("unexpected projection %v", got)
+	}
+}
+
+func TestEvaluation(t *testing.T) {
+	tree, err := tsl.ParseTSL("(author = 'Joe' or title ~= 'great') and spec.pages > 10 and spec.rating >= 4")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Count the document lookups of each field.
+	doc := map[string]interface{}{}
+	for k, v := range book {
+		doc[k] = v
+	}
+	lookups := map[string]int{}
+	eval := func(k string) (interface{}, bool) {
+		lookups[k]++
+		v, ok := doc[k]
+		return v, ok
+	}
+
+	e, err := Evaluate(tree, eval)
+	if err != nil || !e.Result() {
+		t.Fatalf("expected a match, got %v, %v", e, err)
+	}
+
+	// Update one field, only predicates using it are evaluated.
+	lookups = map[string]int{}
+	doc["spec.rating"] = 3
+	b, err := e.Update(eval, "spec.rating")
+	if err != nil || b {
+		t.Fatalf("expected no match, got %v, %v", b, err)
+	}
+	if len(lookups) != 1 || lookups["spec.rating"] != 1 {
+		t.Errorf("expected one lookup of spec.rating instead it was %v", lookups)
+	}
+
+	// Update fields not used by the tree.
+	lookups = map[string]int{}
+	if b, err = e.Update(eval, "price"); err != nil || b || len(lookups) != 0 {
+		t.Errorf("unexpected update %v, %v, %v", b, err, lookups)
+	}
+
+	// Update several fields.
+	doc["spec.rating"] = 5
+	doc["author"] = "Jane"
+	if b, err = e.Update(eval, "spec.rating", "author"); err != nil || b {
+		t.Errorf("expected no match, got %v, %v", b, err)
+	}
+	doc["title"] = "A great book"
+	if b, err = e.Update(eval, "title"); err != nil || !b {
+		t.Errorf("expected a match, got %v, %v", b, err)
+	}
+
+	// A failed update evaluates the whole tree on the next update.
+	doc["spec.pages"] = "many"
+	if _, err = e.Update(eval, "spec.pages"); err == nil {
+		t.Errorf("expected an evaluation error")
+	}
+	doc["spec.pages"] = 100
+	if b, err = e.Update(eval); err != nil || !b {
+		t.Errorf("expected a match, got %v, %v", b, err)
+	}
+}
+
+func TestEvaluationNested(t *testing.T) {
+	doc := map[string]interface{}{
+		"spec": map[string]interface{}{
+			"status": "pending",
+			"a":      map[string]interface{}{"status": "pending"},
+		},
+	}
+	eval := DocEval(doc, Nested)
+
+	tests := []struct {
+		phrase  string
+		changed string
+		update  func()
+	}{
+		{
+			phrase:  "spec.status = 'done'",
+			changed: "spec",
+			update:  func() { doc["spec"] = map[string]interface{}{"status": "done"} },
+		},
+		{
+			phrase:  "spec.*.status = 'done'",
+			changed: "spec.a.status",
+			update:  func() { doc["spec"].(map[string]interface{})["a"] = map[string]interface{}{"status": "done"} },
+		},
+		{
+			phrase:  "spec.*.status = 'done'",
+			changed: "spec.a",
+			update:  func() { doc["spec"].(map[string]interface{})["a"] = map[string]interface{}{"status": "done"} },
+		},
+	}
+
+	for _, tt := range tests {
+		doc["spec"] = map[string]interface{}{
+			"status": "pending",
+			"a":      map[string]interface{}{"status": "pending"},
+		}
+
+		tree, err := tsl.ParseTSL(tt.phrase)
+		if err != nil {
+			t.Fatal(err)
+		}
+		e, err := Evaluate(tree, eval)
+		if err != nil || e.Result() {
+			t.Fatalf("%s: expected no match, got %v, %v", tt.phrase, e, err)
+		}
+
+		tt.update()
+		if b, err := e.Update(eval, tt.changed); err != nil || !b {
+			t.Errorf("%s: expected a match after %s changed, got %v, %v", tt.phrase, tt.changed, b, err)
+		}
+	}
+}
+
+func BenchmarkWalk(b *testing.B) {
+	tree, err := tsl.ParseTSL("author in ('Jane', 'Joe') and spec.pages between 10 and 20 and title ~= 'good' and spec.rating is not null")
+	if err != nil {
+		b.Fatal(err)
+	}
+	eval := evalFactory(book)
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if _, err := Walk(tree, eval); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
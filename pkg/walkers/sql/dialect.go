@@ -0,0 +1,247 @@
+// Copyright 2018 Yaacov Zamir <kobi.zamir@gmail.com>
+// and other contributors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sql
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/yaacov/tree-search-language/pkg/tsl"
+)
+
+// Dialect describes the SQL spelling of a database.
+type Dialect struct {
+	// Name of the dialect.
+	Name string
+
+	// Quote quotes identifiers, identifiers are used as is if nil.
+	Quote func(ident string) string
+
+	// Placeholder is the placeholder format of the dialect, the walkers
+	// create filters using `?` placeholders, that are replaced by the
+	// statement builder.
+	Placeholder sq.PlaceholderFormat
+
+	// Formats maps TSL operators to SQL expression formats, the column
+	// expression replaces `%s` and the literal replaces `?`. Operators
+	// missing from the map use the default formats, regular expression
+	// and geo operators are not supported if missing.
+	Formats map[string]string
+
+	// JSONB translates dotted identifiers into Postgres JSONB accessors, the
+	// first part is the column name and the rest are object keys, e.g.
+	// `metadata.owner.name` is `"metadata"->'owner'->>'name'`. Values compared
+	// to numbers or booleans are cast to numeric or boolean.
+	JSONB bool
+
+	// Now returns the SQL expression of the current time shifted by an
+	// offset, for `now()` literals, the default is `NOW()` shifted by an
+	// SQL standard interval if nil.
+	Now func(offset time.Duration) string
+}
+
+// defaultFormats are the SQL expression formats of the default dialect.
+var defaultFormats = map[string]string{
+	tsl.IsTrueOp:     "%s IS TRUE",
+	tsl.IsNotTrueOp:  "%s IS NOT TRUE",
+	tsl.IsFalseOp:    "%s IS FALSE",
+	tsl.IsNotFalseOp: "%s IS NOT FALSE",
+	tsl.LikeOp:       "%s LIKE ?",
+	tsl.NotLikeOp:    "%s NOT LIKE ?",
+
+	// Case insensitive like is translated into a like of lower case values.
+	tsl.ILikeOp:    "LOWER(%s) LIKE LOWER(?)",
+	tsl.NotILikeOp: "LOWER(%s) NOT LIKE LOWER(?)",
+
+	// Functions.
+	tsl.LenOp:   "LENGTH(%s)",
+	tsl.LowerOp: "LOWER(%s)",
+	tsl.UpperOp: "UPPER(%s)",
+	tsl.AbsOp:   "ABS(%s)",
+	tsl.RoundOp: "ROUND(%s)",
+}
+
+// SQL dialects.
+var (
+	// Default dialect, identifiers are not quoted, and regular expressions
+	// are not supported.
+	Default = Dialect{
+		Name:        "default",
+		Placeholder: sq.Question,
+	}
+
+	// Postgres dialect.
+	Postgres = Dialect{
+		Name:        "postgres",
+		Quote:       quoter(`"`, `"`),
+		Placeholder: sq.Dollar,
+		Formats: map[string]string{
+			tsl.ILikeOp:    "%s ILIKE ?",
+			tsl.NotILikeOp: "%s NOT ILIKE ?",
+			tsl.RegexOp:    "%s ~ ?",
+			tsl.NotRegexOp: "%s !~ ?",
+
+			// PostGIS geo operators, the args are the longitude, the latitude
+			// and the distance in meters, or the box corners longitude first.
+			tsl.WithinOp:       "ST_DWithin(%s::geography, ST_SetSRID(ST_MakePoint(?, ?), 4326)::geography, ?)",
+			tsl.NotWithinOp:    "NOT ST_DWithin(%s::geography, ST_SetSRID(ST_MakePoint(?, ?), 4326)::geography, ?)",
+			tsl.WithinBoxOp:    "ST_Intersects(%s::geometry, ST_MakeEnvelope(?, ?, ?, ?, 4326))",
+			tsl.NotWithinBoxOp: "NOT ST_Intersects(%s::geometry, ST_MakeEnvelope(?, ?, ?, ?, 4326))",
+		},
+		Now: intervalNow("NOW()", "INTERVAL '%s seconds'"),
+	}
+
+	// MySQL dialect.
+	MySQL = Dialect{
+		Name:        "mysql",
+		Quote:       quoter("`", "`"),
+		Placeholder: sq.Question,
+		Formats: map[string]string{
+			tsl.RegexOp:    "%s REGEXP ?",
+			tsl.NotRegexOp: "%s NOT REGEXP ?",
+		},
+		Now: intervalNow("NOW()", "INTERVAL %s SECOND"),
+	}
+
+	// SQLite dialect, the REGEXP operator requires a user defined regexp
+	// function.
+	SQLite = Dialect{
+		Name:        "sqlite",
+		Quote:       quoter(`"`, `"`),
+		Placeholder: sq.Question,
+		Formats: map[string]string{
+			tsl.RegexOp:    "%s REGEXP ?",
+			tsl.NotRegexOp: "%s NOT REGEXP ?",
+		},
+		Now: sqliteNow,
+	}
+
+	// MSSQL dialect, booleans are bit columns, and regular expressions are
+	// not supported.
+	MSSQL = Dialect{
+		Name:        "mssql",
+		Quote:       quoter("[", "]"),
+		Placeholder: atPFormat{},
+		Formats: map[string]string{
+			tsl.IsTrueOp:     "%s = 1",
+			tsl.IsNotTrueOp:  "(%[1]s <> 1 OR %[1]s IS NULL)",
+			tsl.IsFalseOp:    "%s = 0",
+			tsl.IsNotFalseOp: "(%[1]s <> 0 OR %[1]s IS NULL)",
+			tsl.LenOp:        "LEN(%s)",
+			tsl.RoundOp:      "ROUND(%s, 0)",
+		},
+		Now: mssqlNow,
+	}
+)
+
+// format returns the SQL expression format of an operator.
+func (d Dialect) format(op string) (string, bool) {
+	if f, ok := d.Formats[op]; ok {
+		return f, true
+	}
+
+	f, ok := defaultFormats[op]
+	return f, ok
+}
+
+// now returns the SQL expression of the current time shifted by an offset.
+func (d Dialect) now(offset time.Duration) string {
+	if d.Now == nil {
+		return defaultNow(offset)
+	}
+
+	return d.Now(offset)
+}
+
+// defaultNow shifts the current time using SQL standard intervals.
+var defaultNow = intervalNow("NOW()", "INTERVAL '%s' SECOND")
+
+// sqliteNow shifts the current time using datetime modifiers.
+func sqliteNow(offset time.Duration) string {
+	if offset == 0 {
+		return "datetime('now')"
+	}
+
+	sign := "+"
+	if offset < 0 {
+		sign, offset = "-", -offset
+	}
+	return fmt.Sprintf("datetime('now', '%s%s seconds')", sign, seconds(offset))
+}
+
+// mssqlNow shifts the current time using DATEADD.
+func mssqlNow(offset time.Duration) string {
+	if offset == 0 {
+		return "GETDATE()"
+	}
+
+	return fmt.Sprintf("DATEADD(second, %s, GETDATE())", seconds(offset))
+}
+
+// quote quotes an identifier.
+func (d Dialect) quote(ident string) string {
+	if d.Quote == nil {
+		return ident
+	}
+
+	return d.Quote(ident)
+}
+
+// quoter returns an identifier quoting function, each part of a dotted
+// identifier is quoted, and closing quotes inside a part are doubled.
+func quoter(open string, close string) func(string) string {
+	return func(ident string) string {
+		parts := strings.Split(ident, ".")
+		for i, p := range parts {
+			parts[i] = open + strings.Replace(p, close, close+close, -1) + close
+		}
+
+		return strings.Join(parts, ".")
+	}
+}
+
+// atPFormat replaces placeholders with `@p` prefixed positional placeholders
+// (e.g. @p1, @p2, @p3).
+type atPFormat struct{}
+
+func (atPFormat) ReplacePlaceholders(sql string) (string, error) {
+	var b strings.Builder
+
+	i := 0
+	for {
+		p := strings.Index(sql, "?")
+		if p == -1 {
+			break
+		}
+
+		b.WriteString(sql[:p])
+		if strings.HasPrefix(sql[p:], "??") {
+			// Escaped `?`.
+			b.WriteString("?")
+			sql = sql[p+2:]
+			continue
+		}
+
+		i++
+		fmt.Fprintf(&b, "@p%d", i)
+		sql = sql[p+1:]
+	}
+	b.WriteString(sql)
+
+	return b.String(), nil
+}
@@ -0,0 +1,170 @@
+// Copyright 2018 Yaacov Zamir <kobi.zamir@gmail.com>
+// and other contributors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sql
+
+import (
+	"reflect"
+	"testing"
+
+	sq "github.com/Masterminds/squirrel"
+
+	"github.com/yaacov/tree-search-language/pkg/tsl"
+)
+
+func TestWalkDialect(t *testing.T) {
+	tests := []struct {
+		phrase  string
+		dialect Dialect
+		sql     string
+		args    []interface{}
+	}{
+		{"name = 'joe' and spec.pages > 100", Default, "SELECT * FROM books WHERE (name = ? AND spec.pages > ?)", []interface{}{"joe", 100.0}},
+		{"name ilike 'jo%'", Default, "SELECT * FROM books WHERE LOWER(name) LIKE LOWER(?)", []interface{}{"jo%"}},
+		{"name = 'joe' and spec.pages > 100", Postgres, `SELECT * FROM books WHERE ("name" = $1 AND "spec"."pages" > $2)`, []interface{}{"joe", 100.0}},
+		{"name ilike 'jo%'", Postgres, `SELECT * FROM books WHERE "name" ILIKE $1`, []interface{}{"jo%"}},
+		{"name not ilike 'jo%'", Postgres, `SELECT * FROM books WHERE "name" NOT ILIKE $1`, []interface{}{"jo%"}},
+		{"name ~= '^jo'", Postgres, `SELECT * FROM books WHERE "name" ~ $1`, []interface{}{"^jo"}},
+		{"name ~! '^jo'", Postgres, `SELECT * FROM books WHERE "name" !~ $1`, []interface{}{"^jo"}},
+		{"name = 'joe' and spec.pages > 100", MySQL, "SELECT * FROM books WHERE (`name` = ? AND `spec`.`pages` > ?)", []interface{}{"joe", 100.0}},
+		{"name ~= '^jo'", MySQL, "SELECT * FROM books WHERE `name` REGEXP ?", []interface{}{"^jo"}},
+		{"name ilike 'jo%'", MySQL, "SELECT * FROM books WHERE LOWER(`name`) LIKE LOWER(?)", []interface{}{"jo%"}},
+		{"name ~! '^jo'", SQLite, `SELECT * FROM books WHERE "name" NOT REGEXP ?`, []interface{}{"^jo"}},
+		{"name = 'joe' and pages between 1 and 9", MSSQL, "SELECT * FROM books WHERE ([name] = @p1 AND [pages] BETWEEN @p2 AND @p3)", []interface{}{"joe", 1.0, 9.0}},
+		{"sold is true", MSSQL, "SELECT * FROM books WHERE [sold] = 1", nil},
+		{"sold is not false", MSSQL, "SELECT * FROM books WHERE ([sold] <> 0 OR [sold] IS NULL)", nil},
+		{"len(title) > 10", Default, "SELECT * FROM books WHERE LENGTH(title) > ?", []interface{}{10.0}},
+		{"lower(name) = 'joe'", Postgres, `SELECT * FROM books WHERE LOWER("name") = $1`, []interface{}{"joe"}},
+		{"abs(pages - 100) < 5", MySQL, "SELECT * FROM books WHERE ABS((`pages` - ?)) < ?", []interface{}{100.0, 5.0}},
+		{"len(name) < 4 and round(price * 1.1) = 3", MSSQL, "SELECT * FROM books WHERE (LEN([name]) < @p1 AND ROUND(([price] * @p2), 0) = @p3)", []interface{}{4.0, 1.1, 3.0}},
+		{"created > now() - 7d", Default, "SELECT * FROM books WHERE created > NOW() - INTERVAL '604800' SECOND", nil},
+		{"created > now() - 7d", Postgres, `SELECT * FROM books WHERE "created" > NOW() - INTERVAL '604800 seconds'`, nil},
+		{"created <= now() + 1h and name = 'joe'", MySQL, "SELECT * FROM books WHERE (`created` <= NOW() + INTERVAL 3600 SECOND AND `name` = ?)", []interface{}{"joe"}},
+		{"created between date('2020-01-01') and now()", SQLite, `SELECT * FROM books WHERE "created" BETWEEN ? AND datetime('now')`, []interface{}{"2020-01-01"}},
+		{"created < now() - 30m", SQLite, `SELECT * FROM books WHERE "created" < datetime('now', '-1800 seconds')`, nil},
+		{"created >= now() - 1d", MSSQL, "SELECT * FROM books WHERE [created] >= DATEADD(second, -86400, GETDATE())", nil},
+		{"created < date('2020-01-01') + 1d", Postgres, `SELECT * FROM books WHERE "created" < $1`, []interface{}{"2020-01-02T00:00:00Z"}},
+		{"location within 5km of (32.1, 34.8)", Postgres, `SELECT * FROM books WHERE ST_DWithin("location"::geography, ST_SetSRID(ST_MakePoint($1, $2), 4326)::geography, $3)`, []interface{}{34.8, 32.1, 5000.0}},
+		{"location not within (32, 34.7, 32.2, 34.9)", Postgres, `SELECT * FROM books WHERE NOT ST_Intersects("location"::geometry, ST_MakeEnvelope($1, $2, $3, $4, 4326))`, []interface{}{34.7, 32.0, 34.9, 32.2}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.dialect.Name+": "+tt.phrase, func(t *testing.T) {
+			tree, err := tsl.ParseTSL(tt.phrase)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			filter, err := WalkDialect(tree, tt.dialect)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			sql, args, err := sq.Select("*").
+				From("books").
+				Where(filter).
+				PlaceholderFormat(tt.dialect.Placeholder).
+				ToSql()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if sql != tt.sql {
+				t.Errorf("SQL = %s, want %s", sql, tt.sql)
+			}
+			if !reflect.DeepEqual(args, tt.args) {
+				t.Errorf("args = %v, want %v", args, tt.args)
+			}
+		})
+	}
+}
+
+func TestWalkDialectErrors(t *testing.T) {
+	for _, d := range []Dialect{Default, MSSQL} {
+		tree, err := tsl.ParseTSL("name ~= '^jo'")
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if _, err := WalkDialect(tree, d); err == nil {
+			t.Errorf("%s: expected a regular expression error", d.Name)
+		}
+	}
+
+	// Geo operators require PostGIS.
+	for _, d := range []Dialect{Default, MySQL, SQLite, MSSQL} {
+		tree, err := tsl.ParseTSL("location within 5km of (32.1, 34.8)")
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if _, err := WalkDialect(tree, d); err == nil {
+			t.Errorf("%s: expected a geo operator error", d.Name)
+		}
+	}
+}
+
+func TestQuoter(t *testing.T) {
+	if q := MySQL.Quote("a`b.c"); q != "`a``b`.`c`" {
+		t.Errorf("quote = %s", q)
+	}
+}
+
+func TestWhere(t *testing.T) {
+	jsonb := Postgres
+	jsonb.JSONB = true
+
+	tests := []struct {
+		phrase  string
+		dialect Dialect
+		where   string
+		args    []interface{}
+	}{
+		{"name = 'joe' and pages > 100", Default, "(name = ? AND pages > ?)", []interface{}{"joe", 100.0}},
+		{"name = 'joe' and pages > 100", Postgres, `("name" = $1 AND "pages" > $2)`, []interface{}{"joe", 100.0}},
+		{"pages * 2 between 10 and 20", Postgres, `("pages" * $1) BETWEEN $2 AND $3`, []interface{}{2.0, 10.0, 20.0}},
+		{"not pages - 1 in (2, 3)", MySQL, "NOT ((`pages` - ?) IN (?,?))", []interface{}{1.0, 2.0, 3.0}},
+		{"name = 'x'' OR 1=1 --'", SQLite, `"name" = ?`, []interface{}{"x' OR 1=1 --"}},
+		{"metadata.owner.name = 'joe'", jsonb, `"metadata"->'owner'->>'name' = $1`, []interface{}{"joe"}},
+		{"metadata.pages > 100 and name = 'joe'", jsonb, `(("metadata"->>'pages')::numeric > $1 AND "name" = $2)`, []interface{}{100.0, "joe"}},
+		{"metadata.spec.pages + 1 in (2, 3)", jsonb, `(("metadata"->'spec'->>'pages')::numeric + $1) IN ($2,$3)`, []interface{}{1.0, 2.0, 3.0}},
+		{"metadata.sold is not true", jsonb, `("metadata"->>'sold')::boolean IS NOT TRUE`, nil},
+		{"metadata.owner is not null", jsonb, `"metadata"->>'owner' IS NOT NULL`, nil},
+		{"metadata.pages = 100", Postgres, `"metadata"."pages" = $1`, []interface{}{100.0}},
+		{"pages + 1 is not true", MSSQL, "(([pages] + @p1) <> 1 OR ([pages] + @p2) IS NULL)", []interface{}{1.0, 1.0}},
+		{"id = 9007199254740993 or id in (18446744073709551615, 2)", Default, "(id = ? OR id IN (?,?))", []interface{}{int64(9007199254740993), uint64(18446744073709551615), 2.0}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.dialect.Name+": "+tt.phrase, func(t *testing.T) {
+			tree, err := tsl.ParseTSL(tt.phrase)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			where, args, err := Where(tree, tt.dialect)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if where != tt.where {
+				t.Errorf("where = %s, want %s", where, tt.where)
+			}
+			if !reflect.DeepEqual(args, tt.args) {
+				t.Errorf("args = %v, want %v", args, tt.args)
+			}
+		})
+	}
+}
@@ -0,0 +1,160 @@
+// Copyright 2018 Yaacov Zamir <kobi.zamir@gmail.com>
+// and other contributors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sql
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"strings"
+
+	sq "github.com/Masterminds/squirrel"
+
+	"github.com/yaacov/tree-search-language/pkg/tsl"
+)
+
+// namedArg is an arg with a suggested parameter name.
+type namedArg struct {
+	name  string
+	value interface{}
+}
+
+// namedExpr names the args of a comparison, the args of the column
+// expression are named `<column>_term`, and the compared literals by the
+// comparison operator, e.g. `<column>_min` for a lower bound.
+type namedExpr struct {
+	sq.Sqlizer
+
+	column string
+	op     string
+	left   int
+}
+
+//nolint
+func (e namedExpr) ToSql() (sql string, args []interface{}, err error) {
+	var values []interface{}
+
+	sql, values, err = e.Sqlizer.ToSql()
+	if err != nil {
+		return "", nil, err
+	}
+
+	args = make([]interface{}, len(values))
+	for i, arg := range values {
+		name := e.column
+		switch j := i - e.left; {
+		case j < 0:
+			name += "_term"
+		case e.op == tsl.GtOp || e.op == tsl.GteOp:
+			name += "_min"
+		case e.op == tsl.LtOp || e.op == tsl.LteOp:
+			name += "_max"
+		case e.op == tsl.BetweenOp || e.op == tsl.NotBetweenOp:
+			name += []string{"_min", "_max"}[j%2]
+		case e.op == tsl.InOp || e.op == tsl.NotInOp:
+			name += fmt.Sprintf("_%d", j+1)
+		}
+
+		args[i] = namedArg{name: name, value: arg}
+	}
+
+	return
+}
+
+// paramChars matches the characters not allowed in parameter names.
+var paramChars = regexp.MustCompile(`[^A-Za-z0-9_]+`)
+
+// paramName returns the parameter name of the column compared in a node.
+func paramName(n tsl.Node) string {
+	l, ok := n.Left.(tsl.Node)
+	if !ok {
+		return "arg"
+	}
+
+	idents := tsl.GetIdentifiers(l)
+	if len(idents) == 0 {
+		return "arg"
+	}
+
+	return paramChars.ReplaceAllString(idents[0], "_")
+}
+
+// Named travel the TSL tree like the Where function, using named parameters
+// (e.g. `:author`, `:pages_min`) instead of placeholders, and returns the
+// query with a map of parameter values, for use with sqlx.NamedQuery.
+//
+// Parameters are named by the compared field, and the comparison operator,
+// colons in the query are escaped as `::`.
+//
+//  where, bindings, _ := sql.Named(tree, sql.Postgres)
+//  rows, _ := db.NamedQuery("SELECT * FROM books WHERE "+where, bindings)
+//
+func Named(n tsl.Node, d Dialect) (whereClause string, bindings map[string]interface{}, err error) {
+	return Options{Dialect: d}.Named(n)
+}
+
+// Named travel the TSL tree like the Named function, using the options
+// column mapping.
+func (o Options) Named(n tsl.Node) (whereClause string, bindings map[string]interface{}, err error) {
+	var s sq.Sqlizer
+	var sql string
+	var args []interface{}
+
+	s, err = walker{d: o.Dialect, o: o, named: true}.walk(n)
+	if err != nil {
+		return
+	}
+
+	sql, args, err = s.ToSql()
+	if err != nil {
+		return
+	}
+
+	// Escape colons, e.g. in postgres casts.
+	parts := strings.Split(strings.Replace(sql, ":", "::", -1), "?")
+	if len(parts) != len(args)+1 {
+		return "", nil, tsl.UnexpectedLiteralError{Literal: sql}
+	}
+
+	var b strings.Builder
+	bindings = map[string]interface{}{}
+	for i, arg := range args {
+		b.WriteString(parts[i])
+		b.WriteString(":")
+		b.WriteString(bind(bindings, arg.(namedArg)))
+	}
+	b.WriteString(parts[len(args)])
+
+	return b.String(), bindings, nil
+}
+
+// bind adds a named arg to the bindings, and returns its parameter name, if
+// the name is bound to a different value, a numeric suffix is added.
+func bind(bindings map[string]interface{}, arg namedArg) string {
+	name := arg.name
+	for i := 2; ; i++ {
+		v, ok := bindings[name]
+		if !ok {
+			bindings[name] = arg.value
+			return name
+		}
+		if reflect.DeepEqual(v, arg.value) {
+			return name
+		}
+
+		name = fmt.Sprintf("%s_%d", arg.name, i)
+	}
+}
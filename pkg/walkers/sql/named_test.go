@@ -0,0 +1,81 @@
+// Copyright 2018 Yaacov Zamir <kobi.zamir@gmail.com>
+// and other contributors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sql
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/yaacov/tree-search-language/pkg/tsl"
+)
+
+func TestNamed(t *testing.T) {
+	jsonb := Postgres
+	jsonb.JSONB = true
+
+	tests := []struct {
+		phrase   string
+		dialect  Dialect
+		where    string
+		bindings map[string]interface{}
+	}{
+		{
+			"author = 'joe' and pages > 100",
+			Default,
+			"(author = :author AND pages > :pages_min)",
+			map[string]interface{}{"author": "joe", "pages_min": 100.0},
+		},
+		{
+			"spec.pages between 10 and 20 or spec.pages < 5",
+			Postgres,
+			`("spec"."pages" BETWEEN :spec_pages_min AND :spec_pages_max OR "spec"."pages" < :spec_pages_max_2)`,
+			map[string]interface{}{"spec_pages_min": 10.0, "spec_pages_max": 20.0, "spec_pages_max_2": 5.0},
+		},
+		{
+			"author in ('joe', 'jane') and not title ~= 'go' and title ~= 'go'",
+			MySQL,
+			"((`author` IN (:author_1,:author_2) AND NOT (`title` REGEXP :title)) AND `title` REGEXP :title)",
+			map[string]interface{}{"author_1": "joe", "author_2": "jane", "title": "go"},
+		},
+		{
+			"meta.pages * 2 >= 10",
+			jsonb,
+			`(("meta"->>'pages')::::numeric * :meta_pages_term) >= :meta_pages_min`,
+			map[string]interface{}{"meta_pages_term": 2.0, "meta_pages_min": 10.0},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.phrase, func(t *testing.T) {
+			tree, err := tsl.ParseTSL(tt.phrase)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			where, bindings, err := Named(tree, tt.dialect)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if where != tt.where {
+				t.Errorf("where = %s, want %s", where, tt.where)
+			}
+			if !reflect.DeepEqual(bindings, tt.bindings) {
+				t.Errorf("bindings = %v, want %v", bindings, tt.bindings)
+			}
+		})
+	}
+}
@@ -0,0 +1,150 @@
+// Copyright 2018 Yaacov Zamir <kobi.zamir@gmail.com>
+// and other contributors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sql
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+
+	"github.com/yaacov/tree-search-language/pkg/tsl"
+)
+
+// nowOps are the SQL comparison operators of comparisons with now literals.
+var nowOps = map[string]string{
+	tsl.EqOp:         "=",
+	tsl.NotEqOp:      "<>",
+	tsl.LtOp:         "<",
+	tsl.LteOp:        "<=",
+	tsl.GtOp:         ">",
+	tsl.GteOp:        ">=",
+	tsl.InOp:         "IN",
+	tsl.NotInOp:      "NOT IN",
+	tsl.BetweenOp:    "BETWEEN",
+	tsl.NotBetweenOp: "NOT BETWEEN",
+}
+
+// hasNow checks if a node compares a column to now literals.
+func hasNow(n tsl.Node) bool {
+	r, ok := n.Right.(tsl.Node)
+	if !ok {
+		return false
+	}
+
+	for _, l := range literalNodes(r) {
+		if l.Func == tsl.NowOp {
+			return true
+		}
+	}
+
+	return false
+}
+
+// literalNodes returns the literals of a literal or a list literal node.
+func literalNodes(r tsl.Node) []tsl.Node {
+	if r.Func == tsl.ArrayOp {
+		return r.Right.([]tsl.Node)
+	}
+
+	return []tsl.Node{r}
+}
+
+// nowStep handle a comparison step with now literals for Walk, now literals
+// are translated into the dialect current time expression, and the other
+// literals are bound to placeholders.
+func (w walker) nowStep(n tsl.Node) (s sq.Sqlizer, err error) {
+	op, ok := nowOps[n.Func]
+	if !ok {
+		return nil, tsl.UnexpectedLiteralError{Literal: n.Func}
+	}
+
+	// JSONB values are compared as timestamps.
+	if w.d.JSONB {
+		w.cast = "timestamptz"
+	}
+
+	l, err := w.walk(n.Left.(tsl.Node))
+	if err != nil {
+		return
+	}
+
+	sql, args, err := l.ToSql()
+	if err != nil {
+		return
+	}
+
+	var values []string
+	var literals []interface{}
+	for _, v := range literalNodes(n.Right.(tsl.Node)) {
+		if v.Func != tsl.NowOp {
+			values = append(values, "?")
+			literals = append(literals, tsl.LiteralValue(v))
+			continue
+		}
+
+		offset, _ := v.Right.(time.Duration)
+		values = append(values, w.d.now(offset))
+	}
+
+	var t string
+	switch n.Func {
+	case tsl.BetweenOp, tsl.NotBetweenOp:
+		if len(values) != 2 {
+			return nil, tsl.UnexpectedLiteralError{Literal: n.Func}
+		}
+		t = fmt.Sprintf("%s %s %s AND %s", sql, op, values[0], values[1])
+	case tsl.InOp, tsl.NotInOp:
+		t = fmt.Sprintf("%s %s (%s)", sql, op, strings.Join(values, ", "))
+	default:
+		t = fmt.Sprintf("%s %s %s", sql, op, values[0])
+	}
+	s = sq.Expr(t, literals...)
+
+	// Bind the literals of the column expression.
+	if len(args) > 0 {
+		s = argsExpr{s, args}
+	}
+
+	// Name the args of the comparison.
+	if w.named {
+		s = namedExpr{s, paramName(n), n.Func, len(args)}
+	}
+
+	return
+}
+
+// intervalNow returns a current time function of a dialect, offsets are
+// added using an interval format, the offset seconds replace `%s`.
+func intervalNow(now string, interval string) func(time.Duration) string {
+	return func(offset time.Duration) string {
+		switch {
+		case offset > 0:
+			return now + " + " + fmt.Sprintf(interval, seconds(offset))
+		case offset < 0:
+			return now + " - " + fmt.Sprintf(interval, seconds(-offset))
+		}
+
+		return now
+	}
+}
+
+// seconds formats the seconds of a duration.
+func seconds(d time.Duration) string {
+	return strconv.FormatFloat(d.Seconds(), 'f', -1, 64)
+}
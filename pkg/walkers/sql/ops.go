@@ -17,6 +17,7 @@ package sql
 
 import (
 	"fmt"
+	"strings"
 
 	sq "github.com/Masterminds/squirrel"
 	"github.com/yaacov/tree-search-language/pkg/tsl"
@@ -111,3 +112,65 @@ type modExpr []sq.Sqlizer
 func (n modExpr) ToSql() (sql string, args []interface{}, err error) {
 	return mathExpToSQL(n, "%")
 }
+
+// argsExpr prepends the args of the column expression to the args of an
+// SQL expression.
+type argsExpr struct {
+	sq.Sqlizer
+
+	args []interface{}
+}
+
+//nolint
+func (e argsExpr) ToSql() (sql string, args []interface{}, err error) {
+	sql, args, err = e.Sqlizer.ToSql()
+	if err != nil {
+		return "", nil, err
+	}
+
+	args = append(append([]interface{}{}, e.args...), args...)
+
+	return
+}
+
+// repeatArgs returns the args of a column expression used n times.
+func repeatArgs(args []interface{}, n int) (s []interface{}) {
+	for i := 0; i < n; i++ {
+		s = append(s, args...)
+	}
+
+	return
+}
+
+// substringPatterns maps substring operators to the formats of their LIKE
+// patterns.
+var substringPatterns = map[string]string{
+	tsl.ContainsOp:      "%%%s%%",
+	tsl.NotContainsOp:   "%%%s%%",
+	tsl.StartsWithOp:    "%s%%",
+	tsl.NotStartsWithOp: "%s%%",
+	tsl.EndsWithOp:      "%%%s",
+	tsl.NotEndsWithOp:   "%%%s",
+}
+
+// likeEscaper escapes LIKE wildcards using the `!` escape character.
+var likeEscaper = strings.NewReplacer("!", "!!", "%", "!%", "_", "!_")
+
+// substringExpr translates a substring operator into a LIKE expression, LIKE
+// wildcards in the substring are escaped.
+func substringExpr(sql string, op string, s string) sq.Sqlizer {
+	like := "LIKE"
+	if op == tsl.NotContainsOp || op == tsl.NotStartsWithOp || op == tsl.NotEndsWithOp {
+		like = "NOT LIKE"
+	}
+
+	// Escape wildcards only if needed, the ESCAPE clause is portable, but
+	// makes simple queries harder to read.
+	escape := ""
+	if strings.ContainsAny(s, "!%_") {
+		s = likeEscaper.Replace(s)
+		escape = " ESCAPE '!'"
+	}
+
+	return sq.Expr(fmt.Sprintf("%s %s ?%s", sql, like, escape), fmt.Sprintf(substringPatterns[op], s))
+}
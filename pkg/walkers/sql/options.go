@@ -0,0 +1,105 @@
+// Copyright 2018 Yaacov Zamir <kobi.zamir@gmail.com>
+// and other contributors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sql
+
+import (
+	"context"
+	"fmt"
+
+	sq "github.com/Masterminds/squirrel"
+
+	"github.com/yaacov/tree-search-language/pkg/tsl"
+)
+
+// UnknownColumnError is raised when an identifier has no mapped column, e.g.
+// when a strict walk finds an unmapped identifier.
+type UnknownColumnError struct {
+	Column string // the identifier name.
+}
+
+func (e UnknownColumnError) Error() string {
+	return fmt.Sprintf("unknown column: %s", e.Column)
+}
+
+// Columns maps TSL identifiers to SQL column expressions, mapped expressions
+// are used as is, e.g. "author": "books.author_name".
+type Columns map[string]string
+
+// Column returns the column of an identifier, a nil Columns map allows all
+// identifiers, using the identifier as column name.
+func (c Columns) Column(name string) (string, error) {
+	if c == nil {
+		return name, nil
+	}
+
+	if column, ok := c[name]; ok {
+		return column, nil
+	}
+
+	return name, UnknownColumnError{Column: name}
+}
+
+// Options configure the translation of TSL trees into SQL.
+type Options struct {
+	// Dialect is the SQL dialect.
+	Dialect Dialect
+
+	// Columns maps TSL identifiers to SQL column expressions.
+	Columns Columns
+
+	// Strict makes identifiers missing from Columns an error, so user facing
+	// field names can not reach the SQL query.
+	Strict bool
+}
+
+// Walk travel the TSL tree like WalkDialect, using the options column
+// mapping.
+//
+//  o := sql.Options{
+//    Dialect: sql.Postgres,
+//    Columns: sql.Columns{"author": "author_name", "pages": "spec_pages"},
+//    Strict:  true,
+//  }
+//  filter, err := o.Walk(tree)
+//
+func (o Options) Walk(n tsl.Node) (sq.Sqlizer, error) {
+	return walker{d: o.Dialect, o: o}.walk(n)
+}
+
+// WalkContext travel the TSL tree like Walk, and stops with the context
+// error if the context is done.
+func (o Options) WalkContext(ctx context.Context, n tsl.Node) (sq.Sqlizer, error) {
+	return walker{d: o.Dialect, o: o, ctx: ctx}.walk(n)
+}
+
+// Where travel the TSL tree like the Where function, using the options
+// column mapping.
+func (o Options) Where(n tsl.Node) (whereClause string, args []interface{}, err error) {
+	var s sq.Sqlizer
+
+	s, err = o.Walk(n)
+	if err != nil {
+		return
+	}
+
+	whereClause, args, err = s.ToSql()
+	if err != nil || o.Dialect.Placeholder == nil {
+		return
+	}
+
+	whereClause, err = o.Dialect.Placeholder.ReplacePlaceholders(whereClause)
+	return
+}
@@ -0,0 +1,83 @@
+// Copyright 2018 Yaacov Zamir <kobi.zamir@gmail.com>
+// and other contributors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sql
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/yaacov/tree-search-language/pkg/tsl"
+)
+
+func TestOptionsWhere(t *testing.T) {
+	columns := map[string]string{
+		"author":     "books.author_name",
+		"spec.pages": "books.pages",
+	}
+
+	tests := []struct {
+		phrase  string
+		options Options
+		where   string
+		args    []interface{}
+		err     error
+	}{
+		{"author = 'joe' and spec.pages > 100", Options{Dialect: Postgres, Columns: columns}, "(books.author_name = $1 AND books.pages > $2)", []interface{}{"joe", 100.0}, nil},
+		{"author = 'joe' and title = 'hello'", Options{Dialect: Postgres, Columns: columns}, `(books.author_name = $1 AND "title" = $2)`, []interface{}{"joe", "hello"}, nil},
+		{"spec.pages between 1 and 9", Options{Dialect: MySQL, Columns: columns, Strict: true}, "books.pages BETWEEN ? AND ?", []interface{}{1.0, 9.0}, nil},
+		{"author = 'joe' and title = 'hello'", Options{Dialect: Postgres, Columns: columns, Strict: true}, "", nil, UnknownColumnError{Column: "title"}},
+		{"title = 'hello'", Options{Strict: true}, "", nil, UnknownColumnError{Column: "title"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.phrase, func(t *testing.T) {
+			tree, err := tsl.ParseTSL(tt.phrase)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			where, args, err := tt.options.Where(tree)
+			if err != tt.err {
+				t.Fatalf("error = %v, want %v", err, tt.err)
+			}
+
+			if where != tt.where {
+				t.Errorf("where = %s, want %s", where, tt.where)
+			}
+			if !reflect.DeepEqual(args, tt.args) {
+				t.Errorf("args = %v, want %v", args, tt.args)
+			}
+		})
+	}
+}
+
+func TestWalkContext(t *testing.T) {
+	tree, err := tsl.ParseTSL("author = 'joe' and spec.pages > 100")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := WalkContext(context.Background(), tree); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := WalkContext(ctx, tree); err != context.Canceled {
+		t.Errorf("expected a canceled error, got %v", err)
+	}
+}
@@ -0,0 +1,107 @@
+// Copyright 2018 Yaacov Zamir <kobi.zamir@gmail.com>
+// and other contributors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sql
+
+import (
+	sq "github.com/Masterminds/squirrel"
+
+	"github.com/yaacov/tree-search-language/pkg/tsl"
+)
+
+// WalkQuery adds a TSL query to a squirrel select builder, the query filter
+// is added as a where clause, followed by the order by, limit and offset
+// clauses.
+//
+//  q, _ := tsl.ParseQuery("name = 'joe' order by age desc limit 10")
+//  b, _ := sql.WalkQuery(q, sq.Select("name, city, state").From("users"))
+//  sql, args, _ := b.ToSql()
+//
+func WalkQuery(q tsl.Query, b sq.SelectBuilder) (sq.SelectBuilder, error) {
+	return Options{Dialect: Default}.WalkQuery(q, b)
+}
+
+// WalkQuery adds a TSL query to a squirrel select builder like the WalkQuery
+// function, using the options dialect and column mapping.
+func (o Options) WalkQuery(q tsl.Query, b sq.SelectBuilder) (sq.SelectBuilder, error) {
+	if q.HasFilter() {
+		filter, err := o.Walk(q.Filter)
+		if err != nil {
+			return b, err
+		}
+		b = b.Where(filter)
+	}
+
+	for _, s := range q.OrderBy {
+		column, err := o.column(s.Field)
+		if err != nil {
+			return b, err
+		}
+
+		if s.Desc {
+			column += " DESC"
+		}
+		b = b.OrderBy(column)
+	}
+
+	if q.Limit > 0 {
+		b = b.Limit(uint64(q.Limit))
+	}
+	if q.Offset > 0 {
+		b = b.Offset(uint64(q.Offset))
+	}
+
+	return b, nil
+}
+
+// Select creates a squirrel select builder of a TSL query from a table, the
+// query projection is the select list, or `*` if the query has no projection.
+//
+//  q, _ := tsl.ParseQuery("fields name, city where age > 18 order by name")
+//  b, _ := sql.Select(q, "users")
+//  sql, args, _ := b.ToSql()
+//
+func Select(q tsl.Query, from string) (sq.SelectBuilder, error) {
+	return Options{Dialect: Default}.Select(q, from)
+}
+
+// Select creates a squirrel select builder like the Select function, using
+// the options dialect and column mapping.
+func (o Options) Select(q tsl.Query, from string) (sq.SelectBuilder, error) {
+	columns := []string{"*"}
+	if len(q.Fields) > 0 {
+		columns = make([]string, len(q.Fields))
+		for i, f := range q.Fields {
+			column, err := o.column(f)
+			if err != nil {
+				return sq.SelectBuilder{}, err
+			}
+			columns[i] = column
+		}
+	}
+
+	return o.WalkQuery(q, sq.Select(columns...).From(from))
+}
+
+// column returns the SQL column expression of a field.
+func (o Options) column(field string) (string, error) {
+	s, err := o.Walk(tsl.Node{Func: tsl.IdentOp, Left: field})
+	if err != nil {
+		return "", err
+	}
+
+	column, _, err := s.ToSql()
+	return column, err
+}
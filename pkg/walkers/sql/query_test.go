@@ -0,0 +1,119 @@
+// Copyright 2018 Yaacov Zamir <kobi.zamir@gmail.com>
+// and other contributors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sql
+
+import (
+	"reflect"
+	"testing"
+
+	sq "github.com/Masterminds/squirrel"
+
+	"github.com/yaacov/tree-search-language/pkg/tsl"
+)
+
+func TestWalkQuery(t *testing.T) {
+	tests := []struct {
+		input   string
+		options Options
+		sql     string
+		args    []interface{}
+	}{
+		{"name = 'joe' order by age desc, name limit 10 offset 20", Options{Dialect: Default},
+			"SELECT * FROM users WHERE name = ? ORDER BY age DESC, name LIMIT 10 OFFSET 20", []interface{}{"joe"}},
+		{"order by spec.age limit 5", Options{Dialect: Postgres},
+			`SELECT * FROM users ORDER BY "spec"."age" LIMIT 5`, nil},
+		{"name = 'joe' order by spec.age desc", Options{Dialect: Postgres, Columns: map[string]string{"name": "name", "spec.age": "age"}, Strict: true},
+			"SELECT * FROM users WHERE name = $1 ORDER BY age DESC", []interface{}{"joe"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			q, err := tsl.ParseQuery(tt.input)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			b, err := tt.options.WalkQuery(q, sq.Select("*").From("users"))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			sql, args, err := b.PlaceholderFormat(tt.options.Dialect.Placeholder).ToSql()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if sql != tt.sql {
+				t.Errorf("SQL = %s, want %s", sql, tt.sql)
+			}
+			if !reflect.DeepEqual(args, tt.args) {
+				t.Errorf("args = %v, want %v", args, tt.args)
+			}
+		})
+	}
+
+	// Sort fields are checked like filter fields.
+	q, err := tsl.ParseQuery("order by secret")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := (Options{Strict: true}).WalkQuery(q, sq.Select("*").From("users")); err == nil {
+		t.Errorf("expected an unknown column error")
+	}
+}
+
+func TestSelect(t *testing.T) {
+	tests := []struct {
+		input   string
+		options Options
+		sql     string
+	}{
+		{"name = 'joe'", Options{Dialect: Default}, "SELECT * FROM users WHERE name = ?"},
+		{"fields name, spec.age where name = 'joe' limit 1", Options{Dialect: MySQL}, "SELECT `name`, `spec`.`age` FROM users WHERE `name` = ? LIMIT 1"},
+		{"fields name, spec.age", Options{Dialect: Postgres, Columns: map[string]string{"name": "full_name", "spec.age": "age"}, Strict: true}, "SELECT full_name, age FROM users"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			q, err := tsl.ParseQuery(tt.input)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			b, err := tt.options.Select(q, "users")
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			sql, _, err := b.ToSql()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if sql != tt.sql {
+				t.Errorf("SQL = %s, want %s", sql, tt.sql)
+			}
+		})
+	}
+
+	// Projected fields are checked like filter fields.
+	q, err := tsl.ParseQuery("fields secret")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := (Options{Strict: true}).Select(q, "users"); err == nil {
+		t.Errorf("expected an unknown column error")
+	}
+}
@@ -17,8 +17,9 @@
 package sql
 
 import (
+	"context"
 	"fmt"
-	"strconv"
+	"strings"
 
 	sq "github.com/Masterminds/squirrel"
 
@@ -47,24 +48,44 @@ func nodesToStrings(in interface{}) (s []interface{}) {
 
 	// Assume all Nodes are Leafs.
 	for _, n := range nn {
-		s = append(s, n.Left)
+		s = append(s, tsl.LiteralValue(n))
 	}
 
 	return
 }
 
+// walker walks TSL trees using an SQL dialect.
+type walker struct {
+	d Dialect
+	o Options
+
+	// cast is the SQL type JSONB values are cast to.
+	cast string
+
+	// named is set to bind the args to named parameters.
+	named bool
+
+	// ctx stops the walk when done, if not nil.
+	ctx context.Context
+}
+
 // binaryStep handle a binary operator step for Walk.
-func binaryStep(n tsl.Node) (s sq.Sqlizer, err error) {
+func (w walker) binaryStep(n tsl.Node) (s sq.Sqlizer, err error) {
 	var l, r sq.Sqlizer
 
+	// Math operators compare JSONB values as numbers.
+	if n.Func != tsl.AndOp && n.Func != tsl.OrOp {
+		w.cast = "numeric"
+	}
+
 	// Get left hand side node.
-	l, err = Walk(n.Left.(tsl.Node))
+	l, err = w.walk(n.Left.(tsl.Node))
 	if err != nil {
 		return
 	}
 
 	// Get right hand side node.
-	r, err = Walk(n.Right.(tsl.Node))
+	r, err = w.walk(n.Right.(tsl.Node))
 	if err != nil {
 		return
 	}
@@ -92,21 +113,62 @@ func binaryStep(n tsl.Node) (s sq.Sqlizer, err error) {
 	return
 }
 
+// funcStep handle a function call step for Walk.
+func (w walker) funcStep(n tsl.Node) (s sq.Sqlizer, err error) {
+	f, ok := w.d.format(n.Func)
+	if !ok {
+		return nil, tsl.UnexpectedLiteralError{Literal: n.Func}
+	}
+
+	// Number functions use JSONB values as numbers, and string functions
+	// use them as text.
+	w.cast = ""
+	if n.Func == tsl.AbsOp || n.Func == tsl.RoundOp {
+		w.cast = "numeric"
+	}
+
+	l, err := w.walk(n.Left.(tsl.Node))
+	if err != nil {
+		return
+	}
+
+	sql, args, err := l.ToSql()
+	if err != nil {
+		return
+	}
+
+	return sq.Expr(fmt.Sprintf(f, sql), args...), nil
+}
+
 // unaryStep handle a unary operator step for Walk.
-func unaryStep(n tsl.Node) (s sq.Sqlizer, err error) {
+func (w walker) unaryStep(n tsl.Node) (s sq.Sqlizer, err error) {
 	var l sq.Sqlizer
 	var sql string
+	var args []interface{}
+
+	// Comparisons with the current time are translated into SQL expressions.
+	if hasNow(n) {
+		return w.nowStep(n)
+	}
+
+	// Cast JSONB values to the type of the compared literals.
+	if w.d.JSONB {
+		w.cast = castType(n)
+	}
 
-	l, err = Walk(n.Left.(tsl.Node))
+	l, err = w.walk(n.Left.(tsl.Node))
 	if err != nil {
 		return
 	}
 
-	sql, _, err = l.ToSql()
+	sql, args, err = l.ToSql()
 	if err != nil {
 		return
 	}
 
+	// Number of times the column expression is used in the SQL expression.
+	columns := 1
+
 	right := nodesToStrings(n.Right)
 
 	switch n.Func {
@@ -136,12 +198,18 @@ func unaryStep(n tsl.Node) (s sq.Sqlizer, err error) {
 	case tsl.IsNotNilOp:
 		// not eq nil will be translated into IS NOT NULL.
 		s = sq.NotEq{sql: nil}
-	case tsl.LikeOp:
-		t := fmt.Sprintf("%s LIKE ?", sql)
-		s = sq.Expr(t, right[0])
-	case tsl.NotLikeOp:
-		t := fmt.Sprintf("%s NOT LIKE ?", sql)
-		s = sq.Expr(t, right[0])
+	case tsl.IsTrueOp, tsl.IsNotTrueOp, tsl.IsFalseOp, tsl.IsNotFalseOp:
+		s, columns = w.expr(n.Func, sql)
+	case tsl.LikeOp, tsl.NotLikeOp, tsl.ILikeOp, tsl.NotILikeOp, tsl.RegexOp, tsl.NotRegexOp:
+		s, columns = w.expr(n.Func, sql, right[0])
+	case tsl.ContainsOp, tsl.NotContainsOp, tsl.StartsWithOp, tsl.NotStartsWithOp, tsl.EndsWithOp, tsl.NotEndsWithOp:
+		// Substring checks are translated into a like of a pattern.
+		s = substringExpr(sql, n.Func, right[0].(string))
+	case tsl.WithinOp, tsl.NotWithinOp:
+		// Points are ordered (lon, lat) in SQL geo functions.
+		s, columns = w.expr(n.Func, sql, right[2], right[1], right[0])
+	case tsl.WithinBoxOp, tsl.NotWithinBoxOp:
+		s, columns = w.expr(n.Func, sql, right[1], right[0], right[3], right[2])
 	case tsl.BetweenOp:
 		t := fmt.Sprintf("%s BETWEEN ? AND ?", sql)
 		s = sq.Expr(t, right[0], right[1])
@@ -153,9 +221,37 @@ func unaryStep(n tsl.Node) (s sq.Sqlizer, err error) {
 		err = tsl.UnexpectedLiteralError{Literal: n.Func}
 	}
 
+	// Check for operators not supported by the dialect.
+	if s == nil && err == nil {
+		err = tsl.UnexpectedLiteralError{Literal: n.Func}
+	}
+
+	// Bind the literals of the column expression, notExpr already binds them.
+	if s != nil && n.Func != tsl.NotOp && len(args) > 0 {
+		s = argsExpr{s, repeatArgs(args, columns)}
+	}
+
+	// Name the args of the comparison.
+	if s != nil && n.Func != tsl.NotOp && w.named {
+		s = namedExpr{s, paramName(n), n.Func, len(args) * columns}
+	}
+
 	return
 }
 
+// expr formats an SQL expression of an operator using the dialect format,
+// it returns nil if the operator is not supported by the dialect, and the
+// number of times the column expression is used.
+func (w walker) expr(op string, sql string, args ...interface{}) (sq.Sqlizer, int) {
+	f, ok := w.d.format(op)
+	if !ok {
+		return nil, 0
+	}
+
+	columns := strings.Count(f, "%s") + strings.Count(f, "%[1]s")
+	return sq.Expr(fmt.Sprintf(f, sql), args...), columns
+}
+
 // Walk travel the TSL tree to create squirrel SQL select operators.
 //
 // Users can call the Walk method inside a squirrel Where to add the query.
@@ -169,22 +265,152 @@ func unaryStep(n tsl.Node) (s sq.Sqlizer, err error) {
 // Squirrel: https://github.com/Masterminds/squirrel
 //
 func Walk(n tsl.Node) (s sq.Sqlizer, err error) {
+	return WalkDialect(n, Default)
+}
+
+// WalkContext travel the TSL tree like Walk, and stops with the context
+// error if the context is done, so walks of very deep trees can be
+// cancelled or bounded by a deadline.
+func WalkContext(ctx context.Context, n tsl.Node) (sq.Sqlizer, error) {
+	return Options{Dialect: Default}.WalkContext(ctx, n)
+}
+
+// WalkDialect travel the TSL tree like Walk, using an SQL dialect for quoting
+// identifiers, and for spelling operators.
+//
+// The filter uses `?` placeholders, the dialect placeholder format should be
+// set on the statement builder.
+//
+//  filter, _ := sql.WalkDialect(tree, sql.Postgres)
+//  sql, args, _ := sq.Select("name, city, state").
+//    From("users").
+//    Where(filter).
+//    PlaceholderFormat(sql.Postgres.Placeholder).
+//    ToSql()
+//
+func WalkDialect(n tsl.Node, d Dialect) (s sq.Sqlizer, err error) {
+	return Options{Dialect: d}.Walk(n)
+}
+
+// ident returns the SQL expression of an identifier, if the dialect uses
+// JSONB, dotted identifiers are translated into JSONB accessors.
+func (w walker) ident(name string) string {
+	parts := strings.Split(name, ".")
+	if !w.d.JSONB || len(parts) == 1 {
+		return w.d.quote(name)
+	}
+
+	sql := w.d.quote(parts[0])
+	for i, p := range parts[1:] {
+		// The last key is accessed as text.
+		op := "->"
+		if i == len(parts)-2 {
+			op = "->>"
+		}
+		sql += op + "'" + strings.Replace(p, "'", "''", -1) + "'"
+	}
+
+	if w.cast != "" {
+		sql = fmt.Sprintf("(%s)::%s", sql, w.cast)
+	}
+
+	return sql
+}
+
+// castType returns the SQL type of the literals compared in a node, if JSONB
+// values need to be cast for the comparison.
+func castType(n tsl.Node) string {
+	switch n.Func {
+	case tsl.IsTrueOp, tsl.IsNotTrueOp, tsl.IsFalseOp, tsl.IsNotFalseOp:
+		return "boolean"
+	case tsl.EqOp, tsl.NotEqOp, tsl.LtOp, tsl.LteOp, tsl.GtOp, tsl.GteOp,
+		tsl.InOp, tsl.NotInOp, tsl.BetweenOp, tsl.NotBetweenOp:
+	default:
+		return ""
+	}
+
+	r, ok := n.Right.(tsl.Node)
+	if !ok {
+		return ""
+	}
+
+	literals := []tsl.Node{r}
+	if r.Func == tsl.ArrayOp {
+		literals = r.Right.([]tsl.Node)
+	}
+
+	cast := ""
+	for i, l := range literals {
+		t := ""
+		switch l.Func {
+		case tsl.NumberOp, tsl.DurationOp:
+			t = "numeric"
+		case tsl.BooleanOp:
+			t = "boolean"
+		}
+
+		// Mixed literal types are compared as text.
+		if i > 0 && t != cast {
+			return ""
+		}
+		cast = t
+	}
+
+	return cast
+}
+
+// Where travel the TSL tree to create an SQL where clause, and the args bound
+// to its placeholders, using the dialect placeholder format.
+//
+// Literals are never inlined in the where clause, making it safe to use with
+// user input, and friendly to prepared statements.
+//
+//  where, args, _ := sql.Where(tree, sql.Postgres)
+//  rows, _ := db.Query("SELECT name, city, state FROM users WHERE "+where, args...)
+//
+func Where(n tsl.Node, d Dialect) (whereClause string, args []interface{}, err error) {
+	return Options{Dialect: d}.Where(n)
+}
+
+// walk implements Walk.
+func (w walker) walk(n tsl.Node) (s sq.Sqlizer, err error) {
+	// Check for a cancelled walk.
+	if w.ctx != nil {
+		if err = w.ctx.Err(); err != nil {
+			return
+		}
+	}
+
 	switch n.Func {
 	case tsl.IdentOp:
-		s = sq.Expr(n.Left.(string))
-	case tsl.NumberOp:
-		f := strconv.FormatFloat(n.Left.(float64), 'g', -1, 64)
-		s = sq.Expr(f)
-	case tsl.StringOp:
-		s = sq.Expr(n.Left.(string))
+		name := n.Left.(string)
+
+		// Mapped identifiers are used as is.
+		if column, ok := w.o.Columns[name]; ok {
+			s = sq.Expr(column)
+		} else if w.o.Strict {
+			err = UnknownColumnError{Column: name}
+		} else {
+			s = sq.Expr(w.ident(name))
+		}
+	case tsl.NumberOp, tsl.DurationOp, tsl.StringOp, tsl.BooleanOp:
+		// Literals are never inlined, they are bound to placeholders.
+		s = sq.Expr("?", tsl.LiteralValue(n))
 	case tsl.AndOp, tsl.OrOp, tsl.AddOp, tsl.SubtractOp, tsl.MultiplyOp, tsl.DivideOp,
 		tsl.ModuloOp:
-		return binaryStep(n)
+		return w.binaryStep(n)
+	case tsl.LenOp, tsl.LowerOp, tsl.UpperOp, tsl.AbsOp, tsl.RoundOp:
+		return w.funcStep(n)
 	case tsl.NotOp, tsl.EqOp, tsl.NotEqOp, tsl.LtOp, tsl.LteOp, tsl.GtOp, tsl.GteOp,
-		tsl.InOp, tsl.NotInOp, tsl.IsNilOp, tsl.IsNotNilOp:
-		return unaryStep(n)
-	case tsl.LikeOp, tsl.NotLikeOp, tsl.BetweenOp, tsl.NotBetweenOp:
-		return unaryStep(n)
+		tsl.InOp, tsl.NotInOp, tsl.IsNilOp, tsl.IsNotNilOp,
+		tsl.IsTrueOp, tsl.IsNotTrueOp, tsl.IsFalseOp, tsl.IsNotFalseOp:
+		return w.unaryStep(n)
+	case tsl.LikeOp, tsl.NotLikeOp, tsl.ILikeOp, tsl.NotILikeOp, tsl.RegexOp, tsl.NotRegexOp, tsl.BetweenOp, tsl.NotBetweenOp:
+		return w.unaryStep(n)
+	case tsl.ContainsOp, tsl.NotContainsOp, tsl.StartsWithOp, tsl.NotStartsWithOp, tsl.EndsWithOp, tsl.NotEndsWithOp:
+		return w.unaryStep(n)
+	case tsl.WithinOp, tsl.NotWithinOp, tsl.WithinBoxOp, tsl.NotWithinBoxOp:
+		return w.unaryStep(n)
 	default:
 		// If here than the operator is not supported.
 		err = tsl.UnexpectedLiteralError{Literal: n.Func}
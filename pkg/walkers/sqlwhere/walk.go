@@ -0,0 +1,292 @@
+// Copyright 2018 Yaacov Zamir <kobi.zamir@gmail.com>
+// and other contributors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package sqlwhere helps to create SQL where clauses using the TSL package,
+// without depending on an SQL builder.
+//
+// Usage:
+//   where, args, err := sqlwhere.Walk(tree)
+//   rows, err := db.Query("SELECT * FROM books WHERE "+where, args...)
+//
+package sqlwhere
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/yaacov/tree-search-language/pkg/tsl"
+)
+
+// Placeholder returns the placeholder of the n'th arg, starting at 1.
+type Placeholder func(n int) string
+
+// Placeholder formats.
+var (
+	// Question placeholders (e.g. ?, ?, ?).
+	Question Placeholder = func(int) string { return "?" }
+
+	// Dollar placeholders (e.g. $1, $2, $3).
+	Dollar Placeholder = func(n int) string { return fmt.Sprintf("$%d", n) }
+)
+
+// compareOps maps comparison operators to SQL operators.
+var compareOps = map[string]string{
+	tsl.EqOp:    "=",
+	tsl.NotEqOp: "<>",
+	tsl.LtOp:    "<",
+	tsl.LteOp:   "<=",
+	tsl.GtOp:    ">",
+	tsl.GteOp:   ">=",
+}
+
+// mathOps maps math operators to SQL operators.
+var mathOps = map[string]string{
+	tsl.AddOp:      "+",
+	tsl.SubtractOp: "-",
+	tsl.MultiplyOp: "*",
+	tsl.DivideOp:   "/",
+	tsl.ModuloOp:   "%",
+}
+
+// isOps maps boolean check operators to SQL expression formats.
+var isOps = map[string]string{
+	tsl.IsNilOp:      "%s IS NULL",
+	tsl.IsNotNilOp:   "%s IS NOT NULL",
+	tsl.IsTrueOp:     "%s IS TRUE",
+	tsl.IsNotTrueOp:  "%s IS NOT TRUE",
+	tsl.IsFalseOp:    "%s IS FALSE",
+	tsl.IsNotFalseOp: "%s IS NOT FALSE",
+}
+
+// likeOps maps like operators to SQL expression formats, the placeholder
+// replaces the second `%s`.
+var likeOps = map[string]string{
+	tsl.LikeOp:    "%s LIKE %s",
+	tsl.NotLikeOp: "%s NOT LIKE %s",
+
+	// Case insensitive like is translated into a like of lower case values.
+	tsl.ILikeOp:    "LOWER(%s) LIKE LOWER(%s)",
+	tsl.NotILikeOp: "LOWER(%s) NOT LIKE LOWER(%s)",
+}
+
+// substringPatterns maps substring operators to the formats of their LIKE
+// patterns.
+var substringPatterns = map[string]string{
+	tsl.ContainsOp:      "%%%s%%",
+	tsl.NotContainsOp:   "%%%s%%",
+	tsl.StartsWithOp:    "%s%%",
+	tsl.NotStartsWithOp: "%s%%",
+	tsl.EndsWithOp:      "%%%s",
+	tsl.NotEndsWithOp:   "%%%s",
+}
+
+// likeEscaper escapes LIKE wildcards using the `!` escape character.
+var likeEscaper = strings.NewReplacer("!", "!!", "%", "!%", "_", "!_")
+
+// walker collects the args of a where clause.
+type walker struct {
+	placeholder Placeholder
+	args        []interface{}
+}
+
+// Walk travel the TSL tree to create an SQL where clause using `?`
+// placeholders, and the args bound to them.
+//
+// The where clause is the same as the one created by the squirrel based sql
+// walker, literals are never inlined in the where clause.
+//
+//  where, args, _ := sqlwhere.Walk(tree)
+//  rows, _ := db.Query("SELECT name, city, state FROM users WHERE "+where, args...)
+//
+func Walk(n tsl.Node) (whereClause string, args []interface{}, err error) {
+	return WalkPlaceholder(n, Question)
+}
+
+// WalkPlaceholder travel the TSL tree like Walk, using a placeholder format.
+func WalkPlaceholder(n tsl.Node, p Placeholder) (whereClause string, args []interface{}, err error) {
+	w := &walker{placeholder: p}
+
+	whereClause, err = w.walk(n)
+	if err != nil {
+		return "", nil, err
+	}
+
+	return whereClause, w.args, nil
+}
+
+// bind adds an arg, and returns its placeholder.
+func (w *walker) bind(v interface{}) string {
+	w.args = append(w.args, v)
+	return w.placeholder(len(w.args))
+}
+
+// walk returns the SQL expression of a node.
+func (w *walker) walk(n tsl.Node) (string, error) {
+	switch n.Func {
+	case tsl.IdentOp:
+		return n.Left.(string), nil
+	case tsl.NumberOp, tsl.DurationOp, tsl.StringOp, tsl.BooleanOp:
+		return w.bind(tsl.LiteralValue(n)), nil
+	case tsl.AndOp, tsl.OrOp:
+		l, err := w.walk(n.Left.(tsl.Node))
+		if err != nil {
+			return "", err
+		}
+		r, err := w.walk(n.Right.(tsl.Node))
+		if err != nil {
+			return "", err
+		}
+
+		if n.Func == tsl.AndOp {
+			return fmt.Sprintf("(%s AND %s)", l, r), nil
+		}
+		return fmt.Sprintf("(%s OR %s)", l, r), nil
+	case tsl.NotOp:
+		l, err := w.walk(n.Left.(tsl.Node))
+		if err != nil {
+			return "", err
+		}
+
+		return fmt.Sprintf("NOT (%s)", l), nil
+	}
+
+	// Math expressions.
+	if op, ok := mathOps[n.Func]; ok {
+		l, err := w.walk(n.Left.(tsl.Node))
+		if err != nil {
+			return "", err
+		}
+		r, err := w.walk(n.Right.(tsl.Node))
+		if err != nil {
+			return "", err
+		}
+
+		return fmt.Sprintf("(%s %s %s)", l, op, r), nil
+	}
+
+	// The rest of the operators compare the left hand side expression.
+	l, ok := n.Left.(tsl.Node)
+	if !ok {
+		return "", tsl.UnexpectedLiteralError{Literal: n.Func}
+	}
+
+	sql, err := w.walk(l)
+	if err != nil {
+		return "", err
+	}
+
+	return w.compare(n, sql)
+}
+
+// compare returns the SQL expression of a comparison of a column expression.
+func (w *walker) compare(n tsl.Node, sql string) (string, error) {
+	if f, ok := isOps[n.Func]; ok {
+		return fmt.Sprintf(f, sql), nil
+	}
+
+	values := literals(n.Right)
+
+	switch n.Func {
+	case tsl.InOp, tsl.NotInOp:
+		// An empty list matches nothing, or everything if negated.
+		if len(values) == 0 {
+			if n.Func == tsl.InOp {
+				return "(1=0)", nil
+			}
+			return "(1=1)", nil
+		}
+
+		placeholders := make([]string, len(values))
+		for i, v := range values {
+			placeholders[i] = w.bind(v)
+		}
+
+		op := "IN"
+		if n.Func == tsl.NotInOp {
+			op = "NOT IN"
+		}
+		return fmt.Sprintf("%s %s (%s)", sql, op, strings.Join(placeholders, ",")), nil
+	case tsl.BetweenOp, tsl.NotBetweenOp:
+		if len(values) != 2 {
+			return "", tsl.UnexpectedLiteralError{Literal: values}
+		}
+
+		op := "BETWEEN"
+		if n.Func == tsl.NotBetweenOp {
+			op = "NOT BETWEEN"
+		}
+		return fmt.Sprintf("%s %s %s AND %s", sql, op, w.bind(values[0]), w.bind(values[1])), nil
+	}
+
+	if len(values) != 1 {
+		return "", tsl.UnexpectedLiteralError{Literal: n.Func}
+	}
+
+	if op, ok := compareOps[n.Func]; ok {
+		return fmt.Sprintf("%s %s %s", sql, op, w.bind(values[0])), nil
+	}
+	if f, ok := likeOps[n.Func]; ok {
+		return fmt.Sprintf(f, sql, w.bind(values[0])), nil
+	}
+	if f, ok := substringPatterns[n.Func]; ok {
+		return w.substring(sql, n.Func, f, values[0])
+	}
+
+	// If here than the operator is not supported.
+	return "", tsl.UnexpectedLiteralError{Literal: n.Func}
+}
+
+// substring translates a substring operator into a LIKE expression, LIKE
+// wildcards in the substring are escaped.
+func (w *walker) substring(sql string, op string, pattern string, v interface{}) (string, error) {
+	s, ok := v.(string)
+	if !ok {
+		return "", tsl.UnexpectedLiteralError{ExpectedType: "string", Literal: v}
+	}
+
+	like := "LIKE"
+	if op == tsl.NotContainsOp || op == tsl.NotStartsWithOp || op == tsl.NotEndsWithOp {
+		like = "NOT LIKE"
+	}
+
+	// Escape wildcards only if needed, the ESCAPE clause is portable, but
+	// makes simple queries harder to read.
+	escape := ""
+	if strings.ContainsAny(s, "!%_") {
+		s = likeEscaper.Replace(s)
+		escape = " ESCAPE '!'"
+	}
+
+	return fmt.Sprintf("%s %s %s%s", sql, like, w.bind(fmt.Sprintf(pattern, s)), escape), nil
+}
+
+// literals returns the literal values of a literal or an array node.
+func literals(in interface{}) []interface{} {
+	n, ok := in.(tsl.Node)
+	if !ok {
+		return nil
+	}
+
+	if n.Func != tsl.ArrayOp {
+		return []interface{}{tsl.LiteralValue(n)}
+	}
+
+	values := []interface{}{}
+	for _, v := range n.Right.([]tsl.Node) {
+		values = append(values, tsl.LiteralValue(v))
+	}
+
+	return values
+}
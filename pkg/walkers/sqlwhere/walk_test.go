@@ -0,0 +1,94 @@
+// Copyright 2018 Yaacov Zamir <kobi.zamir@gmail.com>
+// and other contributors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqlwhere
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/yaacov/tree-search-language/pkg/tsl"
+	"github.com/yaacov/tree-search-language/pkg/walkers/sql"
+)
+
+func TestWalk(t *testing.T) {
+	phrases := []string{
+		"name = 'joe' and city != 'rome'",
+		"a < 1 or a <= 2 or a > 3 or a >= 4",
+		"name in ('joe', 'jane') and grade not between 0 and 50",
+		"name not in ('joe') or not grade between 10 and 20",
+		"spec.pages * 2 + 1 > 100",
+		"name is null and city is not null",
+		"sold is true and new is not false",
+		"name like 'jo%' and city not ilike 'ro%'",
+		"name contains 'o_e' and city not startswith 'ro'",
+		"id = 9007199254740993 or id in (18446744073709551615, 2)",
+	}
+
+	for _, phrase := range phrases {
+		t.Run(phrase, func(t *testing.T) {
+			tree, err := tsl.ParseTSL(phrase)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			where, args, err := Walk(tree)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			// Expect the where clause of the squirrel based walker.
+			expectedWhere, expectedArgs, err := sql.Where(tree, sql.Default)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if where != expectedWhere {
+				t.Errorf("where = %s, want %s", where, expectedWhere)
+			}
+			if !reflect.DeepEqual(args, expectedArgs) {
+				t.Errorf("args = %v, want %v", args, expectedArgs)
+			}
+		})
+	}
+}
+
+func TestWalkPlaceholder(t *testing.T) {
+	tree, err := tsl.ParseTSL("name in ('joe', 'jane') and grade between 0 and 50")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	where, args, err := WalkPlaceholder(tree, Dollar)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := "(name IN ($1,$2) AND grade BETWEEN $3 AND $4)"
+	if where != expected {
+		t.Errorf("where = %s, want %s", where, expected)
+	}
+	if len(args) != 4 {
+		t.Errorf("args = %v", args)
+	}
+
+	tree, err = tsl.ParseTSL("name ~= '^jo'")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err = Walk(tree); err == nil {
+		t.Errorf("expected a regular expression error")
+	}
+}
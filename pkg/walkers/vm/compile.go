@@ -0,0 +1,244 @@
+// Copyright 2018 Yaacov Zamir <kobi.zamir@gmail.com>
+// and other contributors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vm
+
+import (
+	"github.com/yaacov/tree-search-language/pkg/tsl"
+)
+
+// compiler holds the program being compiled, and the constant pool indexes.
+type compiler struct {
+	p       *Program
+	fields  map[string]uint32
+	strings map[string]uint32
+	numbers map[float64]uint32
+}
+
+// Compile compiles a TSL tree into a program.
+//
+// Trees are compiled in post order, logical nodes follow the code of their
+// sides. Comparisons of an identifier and literals are compiled into
+// comparison instructions, other nodes, for example LIKE operators, math
+// expressions and functions, are compiled into walk instructions evaluated
+// using semantics.Walk.
+func Compile(n tsl.Node) (*Program, error) {
+	c := compiler{
+		p:       &Program{},
+		fields:  map[string]uint32{},
+		strings: map[string]uint32{},
+		numbers: map[float64]uint32{},
+	}
+
+	if err := c.compile(n); err != nil {
+		return nil, err
+	}
+	if err := c.p.prepare(); err != nil {
+		return nil, err
+	}
+
+	return c.p, nil
+}
+
+// compile appends the code of a node.
+func (c *compiler) compile(n tsl.Node) error {
+	switch n.Func {
+	case tsl.AndOp, tsl.OrOp:
+		l, lok := n.Left.(tsl.Node)
+		r, rok := n.Right.(tsl.Node)
+		if !lok || !rok {
+			c.walk(n)
+			return nil
+		}
+
+		if err := c.compile(l); err != nil {
+			return err
+		}
+		if err := c.compile(r); err != nil {
+			return err
+		}
+
+		op := opAnd
+		if n.Func == tsl.OrOp {
+			op = opOr
+		}
+		c.emit(instruction{op: op})
+		return nil
+	}
+
+	// Comparisons compare an identifier to literals.
+	l, ok := n.Left.(tsl.Node)
+	if !ok || l.Func != tsl.IdentOp {
+		c.walk(n)
+		return nil
+	}
+	field := c.field(l.Left.(string))
+
+	switch n.Func {
+	case tsl.IsNilOp:
+		c.emit(instruction{op: opIsNil, field: field})
+		return nil
+	case tsl.IsNotNilOp:
+		c.emit(instruction{op: opIsNotNil, field: field})
+		return nil
+	case tsl.IsTrueOp, tsl.IsFalseOp:
+		c.emit(instruction{op: opBoolEq, field: field, arg: boolArg(n.Func == tsl.IsTrueOp)})
+		return nil
+	case tsl.IsNotTrueOp, tsl.IsNotFalseOp:
+		c.emit(instruction{op: opBoolIsNot, field: field, arg: boolArg(n.Func == tsl.IsNotTrueOp)})
+		return nil
+	}
+
+	r, ok := n.Right.(tsl.Node)
+	if !ok {
+		c.walk(n)
+		return nil
+	}
+
+	switch r.Func {
+	case tsl.DateOp:
+		if op, ok := dateOps[n.Func]; ok {
+			c.emit(instruction{op: op, field: field, arg: c.string(r.Left.(string))})
+			return nil
+		}
+	case tsl.StringOp:
+		if op, ok := stringOps[n.Func]; ok {
+			c.emit(instruction{op: op, field: field, arg: c.string(r.Left.(string))})
+			return nil
+		}
+	case tsl.NumberOp, tsl.DurationOp:
+		// Number constants are float64 values, integer literals float64 can
+		// not hold exactly are compared exactly by walk instructions.
+		if op, ok := numberOps[n.Func]; ok && !isExact(r) {
+			c.emit(instruction{op: op, field: field, arg: c.number(r.Left.(float64))})
+			return nil
+		}
+	case tsl.BooleanOp:
+		if op, ok := boolOps[n.Func]; ok {
+			c.emit(instruction{op: op, field: field, arg: boolArg(r.Left.(bool))})
+			return nil
+		}
+	case tsl.ArrayOp:
+		if c.compileList(n.Func, field, r.Right.([]tsl.Node)) {
+			return nil
+		}
+	}
+
+	c.walk(n)
+	return nil
+}
+
+// compileList appends the code of a comparison to a list of literals of one
+// type, it returns false if the list has no list instruction.
+func (c *compiler) compileList(op string, field uint32, values []tsl.Node) bool {
+	strings := []string{}
+	numbers := []float64{}
+	dates := 0
+
+	for _, v := range values {
+		switch v.Func {
+		case tsl.StringOp, tsl.DateOp:
+			strings = append(strings, v.Left.(string))
+		case tsl.NumberOp, tsl.DurationOp:
+			if isExact(v) {
+				return false
+			}
+			numbers = append(numbers, v.Left.(float64))
+		}
+		if v.Func == tsl.DateOp {
+			dates++
+		}
+	}
+
+	switch {
+	case dates == len(values) && dateListOps[op] != 0:
+		c.p.stringLists = append(c.p.stringLists, strings)
+		c.emit(instruction{op: dateListOps[op], field: field, arg: uint32(len(c.p.stringLists) - 1)})
+	case len(strings) == len(values) && stringListOps[op] != 0:
+		c.p.stringLists = append(c.p.stringLists, strings)
+		c.emit(instruction{op: stringListOps[op], field: field, arg: uint32(len(c.p.stringLists) - 1)})
+	case len(numbers) == len(values) && numberListOps[op] != 0:
+		c.p.numberLists = append(c.p.numberLists, numbers)
+		c.emit(instruction{op: numberListOps[op], field: field, arg: uint32(len(c.p.numberLists) - 1)})
+	default:
+		return false
+	}
+
+	return true
+}
+
+// walk appends a walk instruction evaluating a node using semantics.Walk.
+func (c *compiler) walk(n tsl.Node) {
+	c.p.trees = append(c.p.trees, n)
+	c.emit(instruction{op: opWalk, arg: uint32(len(c.p.trees) - 1)})
+}
+
+// isExact checks for number literals of integers float64 can not hold
+// exactly, they keep their exact value.
+func isExact(n tsl.Node) bool {
+	_, ok := tsl.LiteralValue(n).(float64)
+	return n.Func == tsl.NumberOp && !ok
+}
+
+// boolArg returns the argument of a boolean instruction, boolean constants
+// are stored in the argument, 1 for true and 0 for false.
+func boolArg(b bool) uint32 {
+	if b {
+		return 1
+	}
+
+	return 0
+}
+
+// emit appends an instruction.
+func (c *compiler) emit(in instruction) {
+	c.p.code = append(c.p.code, in)
+}
+
+// field returns the index of a field name.
+func (c *compiler) field(name string) uint32 {
+	i, ok := c.fields[name]
+	if !ok {
+		i = uint32(len(c.p.fields))
+		c.fields[name] = i
+		c.p.fields = append(c.p.fields, name)
+	}
+
+	return i
+}
+
+// string returns the index of a string constant.
+func (c *compiler) string(s string) uint32 {
+	i, ok := c.strings[s]
+	if !ok {
+		i = uint32(len(c.p.strings))
+		c.strings[s] = i
+		c.p.strings = append(c.p.strings, s)
+	}
+
+	return i
+}
+
+// number returns the index of a number constant.
+func (c *compiler) number(f float64) uint32 {
+	i, ok := c.numbers[f]
+	if !ok {
+		i = uint32(len(c.p.numbers))
+		c.numbers[f] = i
+		c.p.numbers = append(c.p.numbers, f)
+	}
+
+	return i
+}
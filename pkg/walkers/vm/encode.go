@@ -0,0 +1,211 @@
+// Copyright 2018 Yaacov Zamir <kobi.zamir@gmail.com>
+// and other contributors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vm
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"io"
+	"math"
+
+	"github.com/yaacov/tree-search-language/pkg/tsl"
+)
+
+// Serialized programs header, and format version.
+const (
+	magic   = "TSLB"
+	version = 3
+)
+
+// MarshalBinary serializes a program.
+func (p *Program) MarshalBinary() ([]byte, error) {
+	var b bytes.Buffer
+
+	b.WriteString(magic)
+	b.WriteByte(version)
+
+	putUint(&b, uint64(len(p.code)))
+	for _, in := range p.code {
+		b.WriteByte(byte(in.op))
+		putUint(&b, uint64(in.field))
+		putUint(&b, uint64(in.arg))
+	}
+
+	putStrings(&b, p.fields)
+	putStrings(&b, p.strings)
+	putNumbers(&b, p.numbers)
+
+	putUint(&b, uint64(len(p.stringLists)))
+	for _, list := range p.stringLists {
+		putStrings(&b, list)
+	}
+	putUint(&b, uint64(len(p.numberLists)))
+	for _, list := range p.numberLists {
+		putNumbers(&b, list)
+	}
+
+	// Tree constants are encoded as JSON trees.
+	trees := make([]string, len(p.trees))
+	for i, tree := range p.trees {
+		data, err := json.Marshal(tree)
+		if err != nil {
+			return nil, err
+		}
+		trees[i] = string(data)
+	}
+	putStrings(&b, trees)
+
+	return b.Bytes(), nil
+}
+
+// UnmarshalBinary deserializes and checks a program.
+func (p *Program) UnmarshalBinary(data []byte) (err error) {
+	r := bytes.NewReader(data)
+
+	header := make([]byte, len(magic)+1)
+	if _, err = io.ReadFull(r, header); err != nil || string(header[:len(magic)]) != magic {
+		return ProgramError{Msg: "bad header"}
+	}
+	if header[len(magic)] != version {
+		return ProgramError{Msg: "unsupported version"}
+	}
+
+	d := decoder{r: r}
+	q := Program{}
+
+	q.code = make([]instruction, d.length())
+	for i := range q.code {
+		op, err := r.ReadByte()
+		if err != nil && d.err == nil {
+			d.err = err
+		}
+		q.code[i] = instruction{op: opcode(op), field: uint32(d.uint()), arg: uint32(d.uint())}
+	}
+
+	q.fields = d.strings()
+	q.strings = d.strings()
+	q.numbers = d.numbers()
+
+	q.stringLists = make([][]string, d.length())
+	for i := range q.stringLists {
+		q.stringLists[i] = d.strings()
+	}
+	q.numberLists = make([][]float64, d.length())
+	for i := range q.numberLists {
+		q.numberLists[i] = d.numbers()
+	}
+
+	trees := d.strings()
+	q.trees = make([]tsl.Node, len(trees))
+	for i, tree := range trees {
+		if err := json.Unmarshal([]byte(tree), &q.trees[i]); err != nil && d.err == nil {
+			d.err = err
+		}
+	}
+
+	if d.err != nil || r.Len() != 0 {
+		return ProgramError{Msg: "bad program data"}
+	}
+	if err = q.prepare(); err != nil {
+		return err
+	}
+
+	*p = q
+	return nil
+}
+
+// putUint writes an unsigned varint.
+func putUint(b *bytes.Buffer, v uint64) {
+	var buf [binary.MaxVarintLen64]byte
+	b.Write(buf[:binary.PutUvarint(buf[:], v)])
+}
+
+// putStrings writes a list of strings.
+func putStrings(b *bytes.Buffer, list []string) {
+	putUint(b, uint64(len(list)))
+	for _, s := range list {
+		putUint(b, uint64(len(s)))
+		b.WriteString(s)
+	}
+}
+
+// putNumbers writes a list of numbers.
+func putNumbers(b *bytes.Buffer, list []float64) {
+	putUint(b, uint64(len(list)))
+	for _, f := range list {
+		var buf [8]byte
+		binary.LittleEndian.PutUint64(buf[:], math.Float64bits(f))
+		b.Write(buf[:])
+	}
+}
+
+// decoder reads program data, keeping the first error.
+type decoder struct {
+	r   *bytes.Reader
+	err error
+}
+
+// uint reads an unsigned varint.
+func (d *decoder) uint() uint64 {
+	if d.err != nil {
+		return 0
+	}
+
+	v, err := binary.ReadUvarint(d.r)
+	d.err = err
+	return v
+}
+
+// length reads a list length, lengths larger than the remaining data are
+// errors.
+func (d *decoder) length() int {
+	n := d.uint()
+	if n > uint64(d.r.Len()) {
+		d.err = io.ErrUnexpectedEOF
+		return 0
+	}
+
+	return int(n)
+}
+
+// strings reads a list of strings.
+func (d *decoder) strings() []string {
+	list := make([]string, d.length())
+	for i := range list {
+		buf := make([]byte, d.length())
+		if _, err := io.ReadFull(d.r, buf); err != nil && d.err == nil {
+			d.err = err
+		}
+		list[i] = string(buf)
+	}
+
+	return list
+}
+
+// numbers reads a list of numbers.
+func (d *decoder) numbers() []float64 {
+	list := make([]float64, d.length())
+	for i := range list {
+		var buf [8]byte
+		if _, err := io.ReadFull(d.r, buf[:]); err != nil && d.err == nil {
+			d.err = err
+		}
+		list[i] = math.Float64frombits(binary.LittleEndian.Uint64(buf[:]))
+	}
+
+	return list
+}
@@ -0,0 +1,28 @@
+// Copyright 2018 Yaacov Zamir <kobi.zamir@gmail.com>
+// and other contributors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vm
+
+import "fmt"
+
+// ProgramError is raised when a program is not valid, e.g. when deserializing
+// corrupted program data.
+type ProgramError struct {
+	Msg string // the error message.
+}
+
+func (e ProgramError) Error() string {
+	return fmt.Sprintf("bad program: %s", e.Msg)
+}
@@ -0,0 +1,149 @@
+// Copyright 2018 Yaacov Zamir <kobi.zamir@gmail.com>
+// and other contributors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vm
+
+import "github.com/yaacov/tree-search-language/pkg/tsl"
+
+// opcode is a VM instruction code.
+type opcode uint8
+
+// VM instruction codes, comparison instructions compare a document field to
+// a constant and push the result, logical instructions pop two results and
+// push their conjunction or disjunction, and walk instructions evaluate a
+// tree constant using semantics.Walk and push the result.
+const (
+	opAnd opcode = iota
+	opOr
+	opIsNil
+	opIsNotNil
+	opStrEq
+	opStrNe
+	opStrLt
+	opStrLte
+	opStrGt
+	opStrGte
+	opStrRegex
+	opStrNotRegex
+	opStrIn
+	opStrNotIn
+	opStrBetween
+	opStrNotBetween
+	opNumEq
+	opNumNe
+	opNumLt
+	opNumLte
+	opNumGt
+	opNumGte
+	opNumIn
+	opNumNotIn
+	opNumBetween
+	opNumNotBetween
+	opBoolEq
+	opBoolNe
+	opBoolIsNot
+	opDateEq
+	opDateNe
+	opDateLt
+	opDateLte
+	opDateGt
+	opDateGte
+	opDateBetween
+	opDateNotBetween
+	opWalk
+	opCount
+)
+
+// opNames are the instruction names used when printing programs.
+var opNames = [opCount]string{
+	"and", "or", "isnil", "isnotnil",
+	"str.eq", "str.ne", "str.lt", "str.lte", "str.gt", "str.gte",
+	"str.regex", "str.nregex", "str.in", "str.nin", "str.between", "str.nbetween",
+	"num.eq", "num.ne", "num.lt", "num.lte", "num.gt", "num.gte",
+	"num.in", "num.nin", "num.between", "num.nbetween",
+	"bool.eq", "bool.ne", "bool.isnot",
+	"date.eq", "date.ne", "date.lt", "date.lte", "date.gt", "date.gte",
+	"date.between", "date.nbetween",
+	"walk",
+}
+
+// Operators of comparison instructions.
+var (
+	stringOps = map[string]opcode{
+		tsl.EqOp: opStrEq, tsl.NotEqOp: opStrNe, tsl.LtOp: opStrLt, tsl.LteOp: opStrLte,
+		tsl.GtOp: opStrGt, tsl.GteOp: opStrGte, tsl.RegexOp: opStrRegex, tsl.NotRegexOp: opStrNotRegex,
+	}
+	stringListOps = map[string]opcode{
+		tsl.InOp: opStrIn, tsl.NotInOp: opStrNotIn, tsl.BetweenOp: opStrBetween, tsl.NotBetweenOp: opStrNotBetween,
+	}
+	numberOps = map[string]opcode{
+		tsl.EqOp: opNumEq, tsl.NotEqOp: opNumNe, tsl.LtOp: opNumLt, tsl.LteOp: opNumLte,
+		tsl.GtOp: opNumGt, tsl.GteOp: opNumGte,
+	}
+	numberListOps = map[string]opcode{
+		tsl.InOp: opNumIn, tsl.NotInOp: opNumNotIn, tsl.BetweenOp: opNumBetween, tsl.NotBetweenOp: opNumNotBetween,
+	}
+	boolOps = map[string]opcode{
+		tsl.EqOp: opBoolEq, tsl.NotEqOp: opBoolNe,
+	}
+	dateOps = map[string]opcode{
+		tsl.EqOp: opDateEq, tsl.NotEqOp: opDateNe, tsl.LtOp: opDateLt, tsl.LteOp: opDateLte,
+		tsl.GtOp: opDateGt, tsl.GteOp: opDateGte,
+	}
+	dateListOps = map[string]opcode{
+		tsl.BetweenOp: opDateBetween, tsl.NotBetweenOp: opDateNotBetween,
+	}
+)
+
+// Operand kinds of instructions.
+const (
+	argNone = iota
+	argString
+	argStringList
+	argNumber
+	argNumberList
+	argBool
+	argTree
+)
+
+// argKind returns the kind of an instruction constant argument.
+func (op opcode) argKind() int {
+	switch {
+	case op >= opStrEq && op <= opStrNotRegex:
+		return argString
+	case op >= opStrIn && op <= opStrNotBetween:
+		return argStringList
+	case op >= opNumEq && op <= opNumGte:
+		return argNumber
+	case op >= opNumIn && op <= opNumNotBetween:
+		return argNumberList
+	case op >= opBoolEq && op <= opBoolIsNot:
+		return argBool
+	case op >= opDateEq && op <= opDateGte:
+		return argString
+	case op >= opDateBetween && op <= opDateNotBetween:
+		return argStringList
+	case op == opWalk:
+		return argTree
+	}
+
+	return argNone
+}
+
+// isDate checks if an instruction compares dates, date instructions use the
+// string constants, and compare string values like string instructions.
+func (op opcode) isDate() bool {
+	return op >= opDateEq && op <= opDateNotBetween
+}
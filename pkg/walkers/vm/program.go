@@ -0,0 +1,249 @@
+// Copyright 2018 Yaacov Zamir <kobi.zamir@gmail.com>
+// and other contributors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package vm compiles TSL trees into bytecode programs evaluated by a small
+// stack VM.
+//
+// A program is a flat list of fixed size instructions plus constant pools,
+// evaluating it does not chase tree pointers, and programs can be serialized
+// and sent between processes. Programs evaluate documents like
+// semantics.Walk, nodes without a comparison instruction are kept as tree
+// constants and evaluated using semantics.Walk.
+//
+// Usage:
+//   p, err := vm.Compile(tree)
+//
+//   ok, err := p.Run(eval)
+//
+//   // Serialize the program.
+//   data, err := p.MarshalBinary()
+//
+//   p = &vm.Program{}
+//   err = p.UnmarshalBinary(data)
+//
+package vm
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/yaacov/tree-search-language/pkg/tsl"
+	"github.com/yaacov/tree-search-language/pkg/walkers/phrase"
+)
+
+// instruction is one VM instruction.
+type instruction struct {
+	op    opcode
+	field uint32 // the field index of comparison instructions.
+	arg   uint32 // the constant index of comparison instructions.
+}
+
+// Program is a compiled TSL tree.
+type Program struct {
+	code        []instruction
+	fields      []string
+	strings     []string
+	numbers     []float64
+	stringLists [][]string
+	numberLists [][]float64
+	trees       []tsl.Node
+
+	// Derived from the constants when the program is prepared.
+	depth      int
+	jumps      []int
+	regexps    []*regexp.Regexp
+	times      []*time.Time
+	listTimes  []*[2]time.Time
+	stringSets []map[string]bool
+	numberSets []map[float64]bool
+}
+
+// prepare checks the program and derives the regexps, date and set constants,
+// and the short-circuit jumps of logical instructions.
+func (p *Program) prepare() error {
+	p.regexps = make([]*regexp.Regexp, len(p.strings))
+	p.times = make([]*time.Time, len(p.strings))
+	p.listTimes = make([]*[2]time.Time, len(p.stringLists))
+	p.stringSets = make([]map[string]bool, len(p.stringLists))
+	p.numberSets = make([]map[float64]bool, len(p.numberLists))
+
+	// Check the instructions and the stack depth, and map the last
+	// instruction of each left operand to it's logical instruction.
+	depth := 0
+	starts := []int{}
+	p.depth = 0
+	p.jumps = make([]int, len(p.code))
+	for i, in := range p.code {
+		p.jumps[i] = -1
+
+		if in.op >= opCount {
+			return ProgramError{Msg: fmt.Sprintf("unknown opcode %d at %d", in.op, i)}
+		}
+
+		if in.op == opAnd || in.op == opOr {
+			if depth < 2 {
+				return ProgramError{Msg: fmt.Sprintf("stack underflow at %d", i)}
+			}
+			depth--
+
+			// The left operand ends before the right operand starts.
+			right := starts[len(starts)-1]
+			starts = starts[:len(starts)-1]
+			p.jumps[right-1] = i
+			continue
+		}
+		starts = append(starts, i)
+
+		if int(in.field) >= len(p.fields) && in.op != opWalk {
+			return ProgramError{Msg: fmt.Sprintf("bad field index at %d", i)}
+		}
+		if err := p.prepareArg(in); err != nil {
+			return err
+		}
+
+		depth++
+		if depth > p.depth {
+			p.depth = depth
+		}
+	}
+	if depth != 1 {
+		return ProgramError{Msg: "program must leave one result on the stack"}
+	}
+
+	// Build the list sets.
+	for i, list := range p.stringLists {
+		p.stringSets[i] = map[string]bool{}
+		for _, s := range list {
+			p.stringSets[i][s] = true
+		}
+	}
+	for i, list := range p.numberLists {
+		p.numberSets[i] = map[float64]bool{}
+		for _, f := range list {
+			p.numberSets[i][f] = true
+		}
+	}
+
+	return nil
+}
+
+// prepareArg checks the constant argument of an instruction, and compiles
+// regexps and date literals.
+func (p *Program) prepareArg(in instruction) error {
+	arg := int(in.arg)
+
+	switch in.op.argKind() {
+	case argString:
+		if arg >= len(p.strings) {
+			return ProgramError{Msg: "bad string index"}
+		}
+
+		switch in.op {
+		case opStrRegex, opStrNotRegex:
+			re, err := regexp.Compile(p.strings[arg])
+			if err != nil {
+				return ProgramError{Msg: err.Error()}
+			}
+			p.regexps[arg] = re
+		case opDateEq, opDateNe, opDateLt, opDateLte, opDateGt, opDateGte:
+			t, ok := tsl.ParseDate(p.strings[arg])
+			if !ok {
+				return ProgramError{Msg: "bad date " + p.strings[arg]}
+			}
+			p.times[arg] = &t
+		}
+	case argStringList:
+		if arg >= len(p.stringLists) {
+			return ProgramError{Msg: "bad string list index"}
+		}
+		if in.op == opStrBetween || in.op == opStrNotBetween || in.op.isDate() {
+			list := p.stringLists[arg]
+			if len(list) != 2 {
+				return ProgramError{Msg: "between expects two values"}
+			}
+		}
+
+		// Parse the date limits.
+		if in.op.isDate() {
+			list := p.stringLists[arg]
+			begin, beginOk := tsl.ParseDate(list[0])
+			end, endOk := tsl.ParseDate(list[1])
+			if !beginOk || !endOk {
+				return ProgramError{Msg: "bad date limits"}
+			}
+			p.listTimes[arg] = &[2]time.Time{begin, end}
+		}
+	case argNumber:
+		if arg >= len(p.numbers) {
+			return ProgramError{Msg: "bad number index"}
+		}
+	case argNumberList:
+		if arg >= len(p.numberLists) {
+			return ProgramError{Msg: "bad number list index"}
+		}
+		if (in.op == opNumBetween || in.op == opNumNotBetween) && len(p.numberLists[arg]) != 2 {
+			return ProgramError{Msg: "between expects two values"}
+		}
+	case argBool:
+		if arg > 1 {
+			return ProgramError{Msg: "bad boolean value"}
+		}
+	case argTree:
+		if arg >= len(p.trees) {
+			return ProgramError{Msg: "bad tree index"}
+		}
+	}
+
+	return nil
+}
+
+// String returns the program instructions, one per line.
+func (p *Program) String() string {
+	var b strings.Builder
+
+	for _, in := range p.code {
+		b.WriteString(opNames[in.op])
+
+		if in.op != opAnd && in.op != opOr && in.op != opWalk {
+			b.WriteString(" " + p.fields[in.field])
+		}
+
+		switch in.op.argKind() {
+		case argString:
+			b.WriteString(" " + strconv.Quote(p.strings[in.arg]))
+		case argStringList:
+			fmt.Fprintf(&b, " %q", p.stringLists[in.arg])
+		case argNumber:
+			fmt.Fprintf(&b, " %v", p.numbers[in.arg])
+		case argNumberList:
+			fmt.Fprintf(&b, " %v", p.numberLists[in.arg])
+		case argBool:
+			fmt.Fprintf(&b, " %v", in.arg == 1)
+		case argTree:
+			s, err := phrase.Walk(p.trees[in.arg])
+			if err != nil {
+				s = err.Error()
+			}
+			b.WriteString(" " + s)
+		}
+
+		b.WriteString("\n")
+	}
+
+	return b.String()
+}
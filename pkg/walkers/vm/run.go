@@ -0,0 +1,369 @@
+// Copyright 2018 Yaacov Zamir <kobi.zamir@gmail.com>
+// and other contributors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vm
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/yaacov/tree-search-language/pkg/tsl"
+	"github.com/yaacov/tree-search-language/pkg/walkers/semantics"
+)
+
+// stackSize is the size of the stack allocated on the Go stack.
+const stackSize = 32
+
+// Run checks if a document compiles to `true` or `false`, it is safe for
+// concurrent use.
+func (p *Program) Run(eval semantics.EvalFunc) (bool, error) {
+	var buf [stackSize]bool
+
+	stack := buf[:0]
+	if p.depth > stackSize {
+		stack = make([]bool, 0, p.depth)
+	}
+
+	for pc := 0; pc < len(p.code); pc++ {
+		switch in := p.code[pc]; in.op {
+		case opAnd:
+			n := len(stack) - 1
+			stack[n-1] = stack[n-1] && stack[n]
+			stack = stack[:n]
+		case opOr:
+			n := len(stack) - 1
+			stack[n-1] = stack[n-1] || stack[n]
+			stack = stack[:n]
+		case opWalk:
+			b, err := semantics.Walk(p.trees[in.arg], eval)
+			if err != nil {
+				return false, err
+			}
+			stack = append(stack, b)
+		default:
+			b, err := p.compare(in, eval)
+			if err != nil {
+				return false, err
+			}
+			stack = append(stack, b)
+		}
+
+		// Skip the right operand and the logical instruction, if the left
+		// operand decides the result, a false AND or a true OR operand.
+		for j := p.jumps[pc]; j >= 0 && stack[len(stack)-1] == (p.code[j].op == opOr); j = p.jumps[j] {
+			pc = j
+		}
+	}
+
+	return stack[0], nil
+}
+
+// compare runs a comparison instruction.
+//
+// List values, for example a `[]string` value, match if any of the list
+// elements match, like semantics.Walk.
+func (p *Program) compare(in instruction, eval semantics.EvalFunc) (bool, error) {
+	field := p.fields[in.field]
+	v, _ := eval(field)
+
+	if n := listLen(v); n >= 0 && in.op.argKind() != argNone {
+		for i := 0; i < n; i++ {
+			b, err := p.compareValue(in, field, listElement(v, i))
+			if err != nil || b {
+				return b, err
+			}
+		}
+		return false, nil
+	}
+
+	return p.compareValue(in, field, v)
+}
+
+// compareValue runs a comparison instruction on one document value.
+func (p *Program) compareValue(in instruction, field string, v interface{}) (bool, error) {
+	switch in.op.argKind() {
+	case argNone:
+		if v == nil {
+			return in.op == opIsNil, nil
+		}
+		if _, ok := number(v); !ok && !isLiteral(v) && listLen(v) < 0 {
+			return false, unexpectedValue(field, v)
+		}
+		return in.op == opIsNotNil, nil
+	case argString, argStringList:
+		switch v := v.(type) {
+		case nil:
+			return false, nil
+		case string:
+			return p.compareString(in, v), nil
+		case time.Time:
+			if in.op.isDate() && in.op.argKind() == argString {
+				return compareTime(in.op, v, *p.times[in.arg]), nil
+			}
+			if in.op.isDate() {
+				list := p.listTimes[in.arg]
+				between := !v.Before(list[0]) && v.Before(list[1])
+				return between == (in.op == opDateBetween), nil
+			}
+		}
+	case argNumber, argNumberList:
+		if v == nil {
+			return false, nil
+		}
+		if isLargeInteger(v) {
+			return p.walkNumber(in, field, v)
+		}
+		if f, ok := number(v); ok {
+			return p.compareNumber(in, f), nil
+		}
+	case argBool:
+		switch v := v.(type) {
+		case nil:
+			// Null values are neither true nor false.
+			return in.op == opBoolIsNot, nil
+		case bool:
+			return (v == (in.arg == 1)) == (in.op == opBoolEq), nil
+		}
+	}
+
+	// Empty lists are compiled as string lists, and match numbers and
+	// booleans too.
+	if _, isBool := v.(bool); in.op.argKind() == argStringList && len(p.stringLists[in.arg]) == 0 {
+		if _, ok := number(v); ok || isBool {
+			return p.compareString(in, ""), nil
+		}
+	}
+
+	// The document value does not match the constant type.
+	if _, ok := number(v); ok || isLiteral(v) {
+		var literal interface{}
+		switch in.op.argKind() {
+		case argString:
+			literal = p.strings[in.arg]
+		case argNumber:
+			literal = p.numbers[in.arg]
+		case argBool:
+			literal = in.arg == 1
+		}
+		return false, tsl.UnexpectedLiteralError{Literal: fmt.Sprintf("%v", literal)}
+	}
+
+	return false, unexpectedValue(field, v)
+}
+
+// compareString runs a string comparison instruction.
+func (p *Program) compareString(in instruction, v string) bool {
+	switch in.op {
+	case opStrEq, opDateEq:
+		return v == p.strings[in.arg]
+	case opStrNe, opDateNe:
+		return v != p.strings[in.arg]
+	case opStrLt, opDateLt:
+		return v < p.strings[in.arg]
+	case opStrLte, opDateLte:
+		return v <= p.strings[in.arg]
+	case opStrGt, opDateGt:
+		return v > p.strings[in.arg]
+	case opStrGte, opDateGte:
+		return v >= p.strings[in.arg]
+	case opStrRegex:
+		return p.regexps[in.arg].MatchString(v)
+	case opStrNotRegex:
+		return !p.regexps[in.arg].MatchString(v)
+	case opStrIn:
+		return p.stringSets[in.arg][v]
+	case opStrNotIn:
+		return !p.stringSets[in.arg][v]
+	case opStrBetween, opDateBetween:
+		list := p.stringLists[in.arg]
+		return v >= list[0] && v < list[1]
+	case opStrNotBetween, opDateNotBetween:
+		list := p.stringLists[in.arg]
+		return v < list[0] || v >= list[1]
+	}
+
+	return false
+}
+
+// compareNumber runs a number comparison instruction.
+func (p *Program) compareNumber(in instruction, v float64) bool {
+	switch in.op {
+	case opNumEq:
+		return v == p.numbers[in.arg]
+	case opNumNe:
+		return v != p.numbers[in.arg]
+	case opNumLt:
+		return v < p.numbers[in.arg]
+	case opNumLte:
+		return v <= p.numbers[in.arg]
+	case opNumGt:
+		return v > p.numbers[in.arg]
+	case opNumGte:
+		return v >= p.numbers[in.arg]
+	case opNumIn:
+		return p.numberSets[in.arg][v]
+	case opNumNotIn:
+		return !p.numberSets[in.arg][v]
+	case opNumBetween:
+		list := p.numberLists[in.arg]
+		return v >= list[0] && v < list[1]
+	case opNumNotBetween:
+		list := p.numberLists[in.arg]
+		return v < list[0] || v >= list[1]
+	}
+
+	return false
+}
+
+// walkNumber runs a number comparison instruction on an integer value float64
+// can not hold exactly, comparing it exactly using semantics.Walk.
+func (p *Program) walkNumber(in instruction, field string, v interface{}) (bool, error) {
+	n := tsl.Node{Left: tsl.Node{Func: tsl.IdentOp, Left: field}}
+
+	if in.op.argKind() == argNumber {
+		n.Right = tsl.Node{Func: tsl.NumberOp, Left: p.numbers[in.arg]}
+	} else {
+		list := []tsl.Node{}
+		for _, f := range p.numberLists[in.arg] {
+			list = append(list, tsl.Node{Func: tsl.NumberOp, Left: f})
+		}
+		n.Right = tsl.Node{Func: tsl.ArrayOp, Right: list}
+	}
+	for op, code := range numberOps {
+		if code == in.op {
+			n.Func = op
+		}
+	}
+	for op, code := range numberListOps {
+		if code == in.op {
+			n.Func = op
+		}
+	}
+
+	return semantics.Walk(n, func(string) (interface{}, bool) {
+		return v, true
+	})
+}
+
+// compareTime runs a date comparison instruction on a date value.
+func compareTime(op opcode, v time.Time, t time.Time) bool {
+	switch op {
+	case opDateEq:
+		return v.Equal(t)
+	case opDateNe:
+		return !v.Equal(t)
+	case opDateLt:
+		return v.Before(t)
+	case opDateLte:
+		return !v.After(t)
+	case opDateGt:
+		return v.After(t)
+	case opDateGte:
+		return !v.Before(t)
+	}
+
+	return false
+}
+
+// number converts document number values to float64.
+func number(v interface{}) (float64, bool) {
+	switch v := v.(type) {
+	case float64:
+		return v, true
+	case float32:
+		return float64(v), true
+	case int:
+		return float64(v), true
+	case int32:
+		return float64(v), true
+	case int64:
+		return float64(v), true
+	case uint:
+		return float64(v), true
+	case uint32:
+		return float64(v), true
+	case uint64:
+		return float64(v), true
+	case time.Duration:
+		return v.Seconds(), true
+	}
+
+	return 0, false
+}
+
+// maxExactFloat is the largest integer float64 numbers hold exactly.
+const maxExactFloat = 1 << 53
+
+// isLargeInteger checks for document integer values float64 can not hold
+// exactly.
+func isLargeInteger(v interface{}) bool {
+	switch v := v.(type) {
+	case int:
+		return isLargeInteger(int64(v))
+	case int64:
+		return v < -maxExactFloat || v > maxExactFloat
+	case uint:
+		return isLargeInteger(uint64(v))
+	case uint64:
+		return v > maxExactFloat
+	}
+
+	return false
+}
+
+// isLiteral checks for document values, other than numbers, compared to
+// literals: strings, dates and booleans.
+func isLiteral(v interface{}) bool {
+	switch v.(type) {
+	case string, bool, time.Time:
+		return true
+	}
+
+	return false
+}
+
+// listLen returns the number of elements of a list value, or -1 if the value
+// is not a list.
+func listLen(v interface{}) int {
+	switch v := v.(type) {
+	case []string:
+		return len(v)
+	case []float64:
+		return len(v)
+	case []interface{}:
+		return len(v)
+	}
+
+	return -1
+}
+
+// listElement returns the i-th element of a list value.
+func listElement(v interface{}, i int) interface{} {
+	switch v := v.(type) {
+	case []string:
+		return v[i]
+	case []float64:
+		return v[i]
+	case []interface{}:
+		return v[i]
+	}
+
+	return nil
+}
+
+// unexpectedValue returns the error of a document value of unsupported type.
+func unexpectedValue(field string, v interface{}) error {
+	return tsl.UnexpectedLiteralError{Literal: fmt.Sprintf("%s[%v]", field, v)}
+}
@@ -0,0 +1,201 @@
+// Copyright 2018 Yaacov Zamir <kobi.zamir@gmail.com>
+// and other contributors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vm
+
+import (
+	"testing"
+	"time"
+
+	"github.com/yaacov/tree-search-language/pkg/tsl"
+	"github.com/yaacov/tree-search-language/pkg/walkers/semantics"
+)
+
+var docs = []map[string]interface{}{
+	{"title": "Book", "author": "Joe", "pages": 100, "rating": 4.5, "published": true},
+	{"title": "Other Book", "author": "Jane", "pages": int64(50), "rating": nil},
+	{"title": "Big Book", "author": "Joe", "pages": uint32(500), "rating": float32(3)},
+	{"created": time.Date(2019, 3, 1, 0, 0, 0, 0, time.UTC)},
+	{},
+}
+
+var phrases = []string{
+	"author = 'Joe'",
+	"author != 'Joe' or pages >= 500",
+	"title ~= '^Big' and pages > 100",
+	"title ~! 'Book$'",
+	"author in ('Joe', 'Jim') and author not in ('Jane')",
+	"pages in (50, 100) or pages not in (7)",
+	"pages between 50 and 500",
+	"pages not between 50 and 500",
+	"title between 'A' and 'C'",
+	"rating is null",
+	"rating is not null and rating < 4",
+	"published = true",
+	"published != false or pages in (50, 100)",
+	"(author < 'K' or author <= 'Joe') and (pages != 7 or rating = 3)",
+	"((rating is null or pages < 60) and author = 'Jane') or title = 'Big Book'",
+	"author = 'Joe' or (title ~= 'Big' and pages > 200) or rating > 4",
+}
+
+func evalFactory(doc map[string]interface{}) semantics.EvalFunc {
+	return func(k string) (interface{}, bool) {
+		v, ok := doc[k]
+		return v, ok
+	}
+}
+
+// walkPhrases are evaluated by walk instructions.
+var walkPhrases = []string{
+	"title like 'Big%'",
+	"title ilike '%book' and author not like 'J_e'",
+	"title contains 'Other'",
+	"title not contains 'Big' or title startswith 'Big'",
+	"author endswith 'e' and author not endswith 'ne'",
+	"pages * 2 > 150",
+	"pages + rating > 100",
+	"len(title) = 4 or lower(author) = 'jane'",
+	"abs(pages - 200) < 150 or round(rating) = 3",
+	"created < now() and created > now() - 100000d",
+	"author = 'Joe' and (title like '%Book' or pages % 7 = 3)",
+}
+
+// integerPhrases compare integers float64 can not hold exactly.
+var integerPhrases = []string{
+	"id = 9007199254740993",
+	"id = 9007199254740992",
+	"id != 9007199254740993",
+	"id > 9007199254740992",
+	"id >= 9007199254740993",
+	"id < 9007199254740993",
+	"id > 5",
+	"id in (9007199254740992)",
+	"id in (9007199254740993, 1)",
+	"id not in (9007199254740993)",
+	"id between 9007199254740992 and 9007199254740993",
+	"id = 18446744073709551615",
+}
+
+var integerDocs = []map[string]interface{}{
+	{"id": int64(9007199254740993)},
+	{"id": uint64(9007199254740992)},
+	{"id": float64(9007199254740992)},
+	{"id": uint64(18446744073709551615)},
+	{"id": int64(-9007199254740993)},
+	{"id": 5},
+	{"id": []interface{}{int64(9007199254740993)}},
+}
+
+func TestRun(t *testing.T) {
+	runPhrases(t, phrases, docs)
+}
+
+func TestRunIntegers(t *testing.T) {
+	runPhrases(t, integerPhrases, integerDocs)
+}
+
+func TestRunWalk(t *testing.T) {
+	runPhrases(t, walkPhrases, docs)
+}
+
+// runPhrases compares running the deserialized programs of phrases to
+// semantics.Walk.
+func runPhrases(t *testing.T, phrases []string, docs []map[string]interface{}) {
+	for _, phrase := range phrases {
+		tree, err := tsl.ParseTSL(phrase)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		p, err := Compile(tree)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// Run the deserialized program.
+		data, err := p.MarshalBinary()
+		if err != nil {
+			t.Fatal(err)
+		}
+		q := &Program{}
+		if err := q.UnmarshalBinary(data); err != nil {
+			t.Fatal(err)
+		}
+
+		for _, doc := range docs {
+			eval := evalFactory(doc)
+
+			want, wantErr := semantics.Walk(tree, eval)
+			got, err := q.Run(eval)
+			if got != want || (err == nil) != (wantErr == nil) {
+				t.Errorf("%q on %v = %v, %v, want %v, %v\n%s", phrase, doc, got, err, want, wantErr, q)
+			}
+		}
+	}
+}
+
+func TestRunDates(t *testing.T) {
+	tree, _ := tsl.ParseTSL("created >= 2019-01-01T00:00:00Z")
+	p, err := Compile(tree)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got, err := p.Run(evalFactory(docs[3])); !got || err != nil {
+		t.Errorf("Run() = %v, %v, want true", got, err)
+	}
+}
+
+func TestUnmarshalBinary(t *testing.T) {
+	tree, _ := tsl.ParseTSL("author in ('Joe', 'Jim') and pages > 10")
+	p, _ := Compile(tree)
+	data, _ := p.MarshalBinary()
+
+	// Truncated and corrupted programs are rejected.
+	for i := 0; i < len(data); i++ {
+		if err := (&Program{}).UnmarshalBinary(data[:i]); err == nil {
+			t.Errorf("UnmarshalBinary(data[:%d]) error = nil", i)
+		}
+	}
+
+	bad := append([]byte{}, data...)
+	bad[len(magic)+2] = byte(opCount)
+	if err := (&Program{}).UnmarshalBinary(bad); err == nil {
+		t.Error("UnmarshalBinary() error = nil, want unknown opcode error")
+	}
+}
+
+func TestCompileErrors(t *testing.T) {
+	// Bad regular expressions fail to compile.
+	tree := tsl.Node{
+		Func:  tsl.RegexOp,
+		Left:  tsl.Node{Func: tsl.IdentOp, Left: "title"},
+		Right: tsl.Node{Func: tsl.StringOp, Left: "("},
+	}
+	if _, err := Compile(tree); err == nil {
+		t.Error("Compile() error = nil, want bad regexp error")
+	}
+}
+
+func BenchmarkRun(b *testing.B) {
+	tree, _ := tsl.ParseTSL("author in ('Joe', 'Jane', 'Jim') and pages between 50 and 500 and title ~= 'Book' and rating is not null")
+	p, _ := Compile(tree)
+	eval := evalFactory(docs[0])
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		p.Run(eval)
+	}
+}